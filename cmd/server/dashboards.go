@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/lab-backend/internal/dashboards"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// Files written by "dashboards export".
+const (
+	dashboardFile = "lab-backend-dashboard.json"
+	alertsFile    = "lab-backend-alerts.yaml"
+)
+
+// runDashboards implements "lab-backend dashboards export", writing a Grafana
+// dashboard and Prometheus alert rules generated from the registered metrics.
+func runDashboards(args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		return errors.New("usage: lab-backend dashboards export [-output-dir dir]")
+	}
+
+	fs := flag.NewFlagSet("dashboards export", flag.ContinueOnError)
+	outputDir := fs.String("output-dir", ".", "Directory to write the dashboard and alert rules to")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+
+	defs := metrics.Definitions()
+	ver := version.Short()
+
+	dashboard, err := json.MarshalIndent(dashboards.Dashboard(defs, ver), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal dashboard: %w", err)
+	}
+
+	rules, err := yaml.Marshal(dashboards.AlertRules(defs))
+	if err != nil {
+		return fmt.Errorf("marshal alert rules: %w", err)
+	}
+
+	rules = append([]byte(fmt.Sprintf("# Generated by lab-backend %s. Do not edit.\n", ver)), rules...)
+
+	for name, data := range map[string][]byte{
+		dashboardFile: append(dashboard, '\n'),
+		alertsFile:    rules,
+	} {
+		path := filepath.Join(*outputDir, name)
+
+		if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // monitoring assets are not secret.
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+
+		fmt.Fprintf(os.Stdout, "Wrote %s\n", path)
+	}
+
+	return nil
+}
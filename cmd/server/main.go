@@ -15,18 +15,26 @@ import (
 
 	"github.com/ethpandaops/lab-backend/internal/bounds"
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/compat"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
 	"github.com/ethpandaops/lab-backend/internal/leader"
 	"github.com/ethpandaops/lab-backend/internal/redis"
 	"github.com/ethpandaops/lab-backend/internal/server"
+	"github.com/ethpandaops/lab-backend/internal/synthetic"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 	"github.com/ethpandaops/lab-backend/internal/version"
 	"github.com/ethpandaops/lab-backend/internal/wallclock"
+	"github.com/ethpandaops/lab-backend/internal/warmup"
 )
 
 // infrastructure holds core infrastructure components.
 type infrastructure struct {
-	redisClient redis.Client
-	elector     leader.Elector
+	redisClient     redis.Client
+	compat          *compat.Tracker
+	elector         leader.Elector
+	shutdownTracing func(context.Context) error
 }
 
 // services holds application services.
@@ -36,15 +44,55 @@ type services struct {
 	upstreamBounds        *bounds.Service
 	boundsProvider        bounds.Provider
 	wallclockSvc          *wallclock.Service
+	syntheticNetwork      *synthetic.Network
+	cacheWarmer           *warmup.Warmer
 	wg                    sync.WaitGroup
 }
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "dashboards" {
+		if err := runDashboards(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	// Parse command-line flags
 	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	printSchema := flag.Bool("print-schema", false, "Print every config option with its type, documentation and default, then exit")
+	printDefaults := flag.Bool("print-defaults", false, "Print the default config, then exit")
+	validate := flag.Bool("validate", false, "Validate the config and check cartographoor and Redis connectivity, then exit")
 
 	flag.Parse()
 
+	// Config reference
+	if *printSchema || *printDefaults {
+		write := config.WriteDefaults
+		if *printSchema {
+			write = config.WriteSchema
+		}
+
+		if err := write(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	// Dry run for CI: non-zero exit when any check fails
+	if *validate {
+		if err := runValidate(context.Background(), os.Stdout, *configPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	// Setup logger
 	logger := setupLogger()
 
@@ -71,11 +119,17 @@ func main() {
 	}
 
 	// Start HTTP server
-	srv, err := startServer(cfg, logger, infra, svc)
+	srv, err := startServer(ctx, cfg, logger, infra, svc)
 	if err != nil {
 		logger.WithError(err).Fatal("Server startup failed")
 	}
 
+	// Warm upstream caches with popular queries (leader only)
+	if cfg.CacheWarming.Enabled {
+		svc.cacheWarmer = warmup.New(logger, cfg.CacheWarming, srv.Proxy(), infra.elector)
+		svc.cacheWarmer.Start(ctx)
+	}
+
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -140,11 +194,24 @@ func loadAndValidateConfig(
 		return nil, fmt.Errorf("validate config: %w", err)
 	}
 
+	// Structured logs for log shippers
+	if cfg.Server.LogFormat == "json" {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+
 	logger.WithFields(logrus.Fields{
-		"port":      cfg.Server.Port,
-		"log_level": cfg.Server.LogLevel,
+		"port":       cfg.Server.Port,
+		"log_level":  cfg.Server.LogLevel,
+		"log_format": cfg.Server.LogFormat,
 	}).Info("Configuration loaded")
 
+	// Spread out periodic timers before any background loop starts
+	jitter.Configure(*cfg.Timers.Jitter, *cfg.Timers.Splay)
+
+	// Bound and coalesce requests per upstream host before any client is built
+	upstream.ConfigureLimits(cfg.UpstreamLimits.Limits())
+	upstream.ConfigureCoalescing(cfg.UpstreamCoalescing.Coalescing())
+
 	return cfg, nil
 }
 
@@ -154,21 +221,63 @@ func setupInfrastructure(
 	logger *logrus.Logger,
 	cfg *config.Config,
 ) (*infrastructure, error) {
+	infra := &infrastructure{
+		shutdownTracing: func(context.Context) error { return nil },
+	}
+
+	// Initialize tracing first so Redis and upstream calls are traced from the start
+	if cfg.Tracing.Enabled {
+		shutdown, err := tracing.Setup(ctx, tracing.Config{
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			Headers:     cfg.Tracing.Headers,
+			ServiceName: cfg.Tracing.ServiceName,
+			Version:     version.Short(),
+			SampleRatio: cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up tracing: %w", err)
+		}
+
+		infra.shutdownTracing = shutdown
+
+		logger.WithFields(logrus.Fields{
+			"endpoint":     cfg.Tracing.Endpoint,
+			"sample_ratio": cfg.Tracing.SampleRatio,
+		}).Info("Tracing enabled")
+	}
+
 	// Initialize Redis client
 	redisClient := redis.NewClient(logger, redis.Config{
-		Address:      cfg.Redis.Address,
-		Password:     cfg.Redis.Password,
-		DB:           cfg.Redis.DB,
-		DialTimeout:  cfg.Redis.DialTimeout,
-		ReadTimeout:  cfg.Redis.ReadTimeout,
-		WriteTimeout: cfg.Redis.WriteTimeout,
-		PoolSize:     cfg.Redis.PoolSize,
+		Mode:              cfg.Redis.Mode,
+		Address:           cfg.Redis.Address,
+		Password:          cfg.Redis.Password,
+		DB:                cfg.Redis.DB,
+		MasterName:        cfg.Redis.MasterName,
+		SentinelAddresses: cfg.Redis.SentinelAddresses,
+		SentinelPassword:  cfg.Redis.SentinelPassword,
+		ClusterAddresses:  cfg.Redis.ClusterAddresses,
+		DialTimeout:       cfg.Redis.DialTimeout,
+		ReadTimeout:       cfg.Redis.ReadTimeout,
+		WriteTimeout:      cfg.Redis.WriteTimeout,
+		PoolSize:          cfg.Redis.PoolSize,
 	})
 
 	if err := redisClient.Start(ctx); err != nil {
 		return nil, fmt.Errorf("failed to start Redis client: %w", err)
 	}
 
+	// Publish this replica's data format version before it can become leader
+	tracker := compat.New(logger, compat.Config{
+		KeyPrefix:         cfg.Compat.KeyPrefix,
+		HeartbeatInterval: cfg.Compat.HeartbeatInterval,
+		ReplicaTTL:        cfg.Compat.ReplicaTTL,
+	}, redisClient, version.Short())
+
+	if err := tracker.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start compatibility tracker: %w", err)
+	}
+
 	// Initialize leader election
 	elector := leader.NewElector(logger, leader.Config{
 		LockKey:       cfg.Leader.LockKey,
@@ -181,10 +290,11 @@ func setupInfrastructure(
 		return nil, fmt.Errorf("failed to start leader election: %w", err)
 	}
 
-	return &infrastructure{
-		redisClient: redisClient,
-		elector:     elector,
-	}, nil
+	infra.redisClient = redisClient
+	infra.compat = tracker
+	infra.elector = elector
+
+	return infra, nil
 }
 
 // setupServices initializes cartographoor and bounds services.
@@ -198,9 +308,32 @@ func setupServices(
 ) (*services, error) {
 	svc := &services{}
 
+	// Pick up changes to the networks file while running
+	if cfg.NetworksFile != "" {
+		svc.wg.Add(1)
+
+		go func() {
+			defer svc.wg.Done()
+
+			cfg.WatchNetworksFile(ctx, logger)
+		}()
+	}
+
 	// Create cartographoor service
 	var err error
 
+	// Start the synthetic test network first, so it is proxied and has
+	// bounds like any network from config.yaml
+	if cfg.Synthetic.Enabled {
+		svc.syntheticNetwork = synthetic.New(logger, cfg.Synthetic)
+
+		if err := svc.syntheticNetwork.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start synthetic network: %w", err)
+		}
+
+		cfg.Networks = append(cfg.Networks, svc.syntheticNetwork.NetworkConfig())
+	}
+
 	// Create upstream service (fetches from Cartographoor API)
 	svc.cartographoorSvc, err = cartographoor.New(&cfg.Cartographoor, logger)
 	if err != nil {
@@ -213,6 +346,7 @@ func setupServices(
 		cfg.Cartographoor,
 		infra.redisClient,
 		infra.elector,
+		infra.compat,
 		svc.cartographoorSvc,
 	)
 
@@ -236,9 +370,11 @@ func setupServices(
 			RefreshInterval: cfg.Bounds.RefreshInterval,
 			PageSize:        500,
 			BoundsTTL:       cfg.Bounds.BoundsTTL,
+			StaleRetention:  cfg.Bounds.StaleRetention,
 		},
 		infra.redisClient,
 		infra.elector,
+		infra.compat,
 		svc.upstreamBounds,
 	)
 
@@ -260,13 +396,7 @@ func setupServices(
 	// Populate wallclocks from cartographoor networks
 	networks := svc.cartographoorProvider.GetActiveNetworks(ctx)
 	for name, network := range networks {
-		genesisTimeWithDelay := time.Unix(network.GenesisTime+network.GenesisDelay, 0)
-
-		if err := svc.wallclockSvc.AddNetwork(wallclock.NetworkConfig{
-			Name:           name,
-			GenesisTime:    genesisTimeWithDelay,
-			SecondsPerSlot: 12,
-		}); err != nil {
+		if err := svc.wallclockSvc.AddNetwork(wallclockConfig(name, network)); err != nil {
 			logger.WithFields(logrus.Fields{
 				"network": name,
 				"error":   err.Error(),
@@ -274,6 +404,16 @@ func setupServices(
 		}
 	}
 
+	if cfg.Synthetic.Enabled {
+		if err := svc.wallclockSvc.AddNetwork(wallclock.NetworkConfig{
+			Name:           cfg.Synthetic.Name,
+			GenesisTime:    time.Unix(cfg.Synthetic.GenesisTime, 0),
+			SecondsPerSlot: cfg.Synthetic.SecondsPerSlot,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to add wallclock for synthetic network: %w", err)
+		}
+	}
+
 	logger.WithField("networks", len(networks)).Info("Wallclock service started")
 
 	// Sync wallclocks when cartographoor updates
@@ -298,13 +438,7 @@ func setupServices(
 				networks := svc.cartographoorProvider.GetActiveNetworks(ctx)
 
 				for name, network := range networks {
-					genesisTime := time.Unix(network.GenesisTime, 0)
-
-					if err := svc.wallclockSvc.AddNetwork(wallclock.NetworkConfig{
-						Name:           name,
-						GenesisTime:    genesisTime,
-						SecondsPerSlot: 12,
-					}); err != nil {
+					if err := svc.wallclockSvc.AddNetwork(wallclockConfig(name, network)); err != nil {
 						logger.WithFields(logrus.Fields{
 							"network": name,
 							"error":   err.Error(),
@@ -312,6 +446,14 @@ func setupServices(
 					}
 				}
 
+				// Drop wallclocks of networks that are no longer active, e.g.
+				// quarantined for invalid genesis data
+				for _, name := range svc.wallclockSvc.Networks() {
+					if _, ok := networks[name]; !ok && !(cfg.Synthetic.Enabled && name == cfg.Synthetic.Name) {
+						svc.wallclockSvc.RemoveNetwork(name)
+					}
+				}
+
 				logger.Debug("Wallclocks synced with cartographoor")
 			case <-ctx.Done():
 				return
@@ -322,14 +464,27 @@ func setupServices(
 	return svc, nil
 }
 
+// wallclockConfig returns the wallclock timing for a cartographoor network.
+// Slot timing left unset by cartographoor falls back to the wallclock defaults.
+func wallclockConfig(name string, network *cartographoor.Network) wallclock.NetworkConfig {
+	return wallclock.NetworkConfig{
+		Name:               name,
+		GenesisTime:        time.Unix(network.GenesisTime+network.GenesisDelay, 0),
+		SecondsPerSlot:     network.SecondsPerSlot,
+		SlotsPerEpoch:      network.SlotsPerEpoch,
+		GenesisForkVersion: network.GenesisForkVersion,
+	}
+}
+
 // startServer creates and starts the HTTP server.
 func startServer(
+	ctx context.Context,
 	cfg *config.Config,
 	logger *logrus.Logger,
 	infra *infrastructure,
 	svc *services,
 ) (*server.Server, error) {
-	srv, err := server.New(logger, cfg, infra.redisClient, svc.cartographoorProvider, svc.boundsProvider, svc.wallclockSvc)
+	srv, err := server.New(ctx, logger, cfg, infra.redisClient, infra.elector, svc.cartographoorProvider, svc.boundsProvider, svc.wallclockSvc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create server: %w", err)
 	}
@@ -338,7 +493,7 @@ func startServer(
 	go func() {
 		logger.WithField("port", cfg.Server.Port).Info("HTTP server starting")
 
-		if err := srv.Start(); err != nil && err != http.ErrServerClosed {
+		if err := srv.Start(ctx); err != nil && err != http.ErrServerClosed {
 			logger.WithError(err).Fatal("HTTP server error")
 		}
 	}()
@@ -352,6 +507,7 @@ func startServer(
 // 2. Providers (stop background loops that use Redis).
 // 3. Leader election (release leadership lock).
 // 4. Redis client (close connections).
+// 5. Tracing (flush pending spans).
 func shutdownGracefully(
 	logger *logrus.Logger,
 	cfg *config.Config,
@@ -370,6 +526,11 @@ func shutdownGracefully(
 		logger.WithError(err).Error("Error during server shutdown")
 	}
 
+	// Stop cache warming
+	if svc.cacheWarmer != nil {
+		svc.cacheWarmer.Stop()
+	}
+
 	// Stop providers
 	if svc.cartographoorProvider != nil {
 		if err := svc.cartographoorProvider.Stop(); err != nil {
@@ -383,6 +544,13 @@ func shutdownGracefully(
 		}
 	}
 
+	// Stop synthetic network
+	if svc.syntheticNetwork != nil {
+		if err := svc.syntheticNetwork.Stop(shutdownCtx); err != nil {
+			logger.WithError(err).Error("Error stopping synthetic network")
+		}
+	}
+
 	// Stop wallclock service
 	if svc.wallclockSvc != nil {
 		if err := svc.wallclockSvc.Stop(); err != nil {
@@ -399,10 +567,18 @@ func shutdownGracefully(
 		logger.WithError(err).Error("Error stopping leader election")
 	}
 
+	// Stop publishing this replica's version
+	infra.compat.Stop()
+
 	// Stop Redis client (closes connections)
 	if err := infra.redisClient.Stop(); err != nil {
 		logger.WithError(err).Error("Error stopping Redis client")
 	}
 
+	// Flush pending spans
+	if err := infra.shutdownTracing(shutdownCtx); err != nil {
+		logger.WithError(err).Error("Error flushing traces")
+	}
+
 	logger.Info("Server stopped gracefully")
 }
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+// errValidationFailed is returned by runValidate when any check fails.
+var errValidationFailed = errors.New("config validation failed")
+
+// runValidate implements -validate: it loads and validates the config, then
+// checks that cartographoor and Redis are reachable with it, printing one
+// PASS or FAIL line per check to out. The HTTP server is not started and
+// leadership is not taken.
+func runValidate(ctx context.Context, out io.Writer, configPath string) error {
+	// Services log to the report instead
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	report := func(check, detail string, err error) bool {
+		if err != nil {
+			fmt.Fprintf(out, "FAIL  %-13s %v\n", check, err)
+
+			return false
+		}
+
+		fmt.Fprintf(out, "PASS  %-13s %s\n", check, detail)
+
+		return true
+	}
+
+	cfg, err := config.Load(configPath)
+	if err == nil {
+		err = cfg.Validate()
+	}
+
+	if !report("config", configPath, err) {
+		return errValidationFailed
+	}
+
+	detail, err := validateCartographoor(ctx, logger, cfg)
+	ok := report("cartographoor", detail, err)
+
+	detail, err = validateRedis(ctx, logger, cfg)
+	ok = report("redis", detail, err) && ok
+
+	if !ok {
+		return errValidationFailed
+	}
+
+	return nil
+}
+
+// validateCartographoor fetches the cartographoor networks once.
+func validateCartographoor(ctx context.Context, logger logrus.FieldLogger, cfg *config.Config) (string, error) {
+	svc, err := cartographoor.New(&cfg.Cartographoor, logger)
+	if err != nil {
+		return "", err
+	}
+
+	networks, err := svc.FetchNetworks(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sources := make([]string, 0, len(cfg.Cartographoor.SourceList()))
+	for _, source := range cfg.Cartographoor.SourceList() {
+		sources = append(sources, source.Name)
+	}
+
+	return fmt.Sprintf("%d networks from %s", len(networks), strings.Join(sources, ", ")), nil
+}
+
+// validateRedis connects to Redis and pings it.
+func validateRedis(ctx context.Context, logger logrus.FieldLogger, cfg *config.Config) (string, error) {
+	client := redis.NewClient(logger, redis.Config{
+		Mode:              cfg.Redis.Mode,
+		Address:           cfg.Redis.Address,
+		Password:          cfg.Redis.Password,
+		DB:                cfg.Redis.DB,
+		MasterName:        cfg.Redis.MasterName,
+		SentinelAddresses: cfg.Redis.SentinelAddresses,
+		SentinelPassword:  cfg.Redis.SentinelPassword,
+		ClusterAddresses:  cfg.Redis.ClusterAddresses,
+		DialTimeout:       cfg.Redis.DialTimeout,
+		ReadTimeout:       cfg.Redis.ReadTimeout,
+		WriteTimeout:      cfg.Redis.WriteTimeout,
+		PoolSize:          cfg.Redis.PoolSize,
+	})
+
+	defer client.Stop() //nolint:errcheck // best effort.
+
+	if err := client.Start(ctx); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s mode reachable", cfg.Redis.Mode), nil
+}
@@ -0,0 +1,232 @@
+// Package alerting notifies webhooks when CBT tables stop advancing. The
+// leader compares the stored bounds with the last max bound seen per table;
+// a table whose max bound stands still for longer than its network's
+// threshold fires one alert, and another once it advances again. What was
+// seen is kept in Redis, so a new leader carries on where the last one left off.
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/scheduler"
+)
+
+const (
+	// redisStateKey holds the tableState of every table, by network and table.
+	redisStateKey = "lab:alerting:freshness"
+
+	// stateTTL drops the state once checks stop, e.g. after alerts are disabled.
+	stateTTL = 24 * time.Hour
+)
+
+var stalledTables = metrics.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "bounds_stalled_tables",
+		Help: "Tables whose max bound has not advanced within the freshness threshold",
+	},
+	[]string{"network"},
+)
+
+// Freshness checks that the max bound of every table keeps advancing.
+type Freshness struct {
+	cfg      config.FreshnessAlertsConfig
+	log      logrus.FieldLogger
+	redis    redis.Client
+	bounds   bounds.Provider
+	notifier *Notifier
+	now      func() time.Time
+}
+
+// NewFreshness creates a freshness checker.
+func NewFreshness(
+	log logrus.FieldLogger,
+	cfg config.FreshnessAlertsConfig,
+	redisClient redis.Client,
+	boundsProvider bounds.Provider,
+) *Freshness {
+	return &Freshness{
+		cfg:      cfg,
+		log:      log.WithField("component", "freshness_alerts"),
+		redis:    redisClient,
+		bounds:   boundsProvider,
+		notifier: NewNotifier(cfg.Webhooks),
+		now:      time.Now,
+	}
+}
+
+// Job returns the job checking freshness, run on the leader by the scheduler.
+func (f *Freshness) Job() scheduler.Job {
+	return scheduler.Job{
+		Name:     "alerting.freshness",
+		Interval: f.cfg.CheckInterval,
+		Run:      f.Check,
+	}
+}
+
+// Check compares the stored bounds with the previous check and sends alerts
+// for tables that stalled or recovered. Networks whose bounds are stale are
+// skipped: their tables are not refreshed, which the bounds staleness
+// already reports.
+func (f *Freshness) Check(ctx context.Context) error {
+	state, err := f.loadState(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		now     = f.now().UTC()
+		current = f.bounds.GetAllBounds(ctx)
+		next    = make(map[string]map[string]*tableState, len(current))
+		alerts  []Alert
+	)
+
+	stalledTables.Reset()
+
+	for _, network := range slices.Sorted(maps.Keys(current)) {
+		data := current[network]
+
+		if data.Stale {
+			if previous, ok := state[network]; ok {
+				next[network] = previous
+			}
+
+			continue
+		}
+
+		var (
+			threshold = f.cfg.ThresholdFor(network)
+			tables    = make(map[string]*tableState, len(data.Tables))
+			stalled   int
+		)
+
+		for _, table := range slices.Sorted(maps.Keys(data.Tables)) {
+			maxBound := data.Tables[table].Max
+
+			st, ok := state[network][table]
+			if !ok {
+				tables[table] = &tableState{Max: maxBound, AdvancedAt: now}
+
+				continue
+			}
+
+			tables[table] = st
+
+			switch {
+			case maxBound > st.Max:
+				if st.Alerted {
+					alerts = append(alerts, newAlert(StatusRecovered, network, table, maxBound, st.AdvancedAt, threshold, now))
+				}
+
+				st.Max, st.AdvancedAt, st.Alerted = maxBound, now, false
+			case maxBound < st.Max:
+				// Rewound, e.g. a table rebuilt; it has to advance from here
+				st.Max = maxBound
+			}
+
+			if !st.Alerted && now.Sub(st.AdvancedAt) > threshold {
+				alerts = append(alerts, newAlert(StatusStalled, network, table, st.Max, st.AdvancedAt, threshold, now))
+				st.Alerted = true
+			}
+
+			if st.Alerted {
+				stalled++
+			}
+		}
+
+		next[network] = tables
+
+		stalledTables.WithLabelValues(network).Set(float64(stalled))
+	}
+
+	// Stored before notifying, so an alert is sent at most once even if a
+	// webhook fails
+	if err := f.storeState(ctx, next); err != nil {
+		return err
+	}
+
+	var sendErrs []error
+
+	for _, alert := range alerts {
+		f.log.WithFields(logrus.Fields{
+			"network":   alert.Network,
+			"table":     alert.Table,
+			"max":       alert.Max,
+			"lag":       alert.Lag,
+			"threshold": alert.Threshold,
+		}).Warnf("Table bounds %s", alert.Status)
+
+		if err := f.notifier.Send(ctx, alert); err != nil {
+			sendErrs = append(sendErrs, err)
+		}
+	}
+
+	if err := errors.Join(sendErrs...); err != nil {
+		return fmt.Errorf("send freshness alerts: %w", err)
+	}
+
+	return nil
+}
+
+func newAlert(status, network, table string, maxBound int64, advancedAt time.Time, threshold time.Duration, now time.Time) Alert {
+	lag := now.Sub(advancedAt).Round(time.Second)
+
+	return Alert{
+		Status:         status,
+		Network:        network,
+		Table:          table,
+		Max:            maxBound,
+		LastAdvancedAt: advancedAt,
+		Lag:            lag.String(),
+		LagSeconds:     lag.Seconds(),
+		Threshold:      threshold.String(),
+		Timestamp:      now,
+	}
+}
+
+// loadState reads what the previous check saw. Nothing is an empty state.
+func (f *Freshness) loadState(ctx context.Context) (map[string]map[string]*tableState, error) {
+	state := make(map[string]map[string]*tableState)
+
+	data, err := f.redis.Get(ctx, redisStateKey)
+	if errors.Is(err, redis.ErrNotFound) {
+		return state, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read freshness state: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		f.log.WithError(err).Warn("Discarding malformed freshness state")
+
+		return make(map[string]map[string]*tableState), nil
+	}
+
+	return state, nil
+}
+
+func (f *Freshness) storeState(ctx context.Context, state map[string]map[string]*tableState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal freshness state: %w", err)
+	}
+
+	if err := f.redis.Set(ctx, redisStateKey, string(data), stateTTL); err != nil {
+		return fmt.Errorf("store freshness state: %w", err)
+	}
+
+	return nil
+}
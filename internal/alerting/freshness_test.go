@@ -0,0 +1,199 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+// webhookReceiver records the bodies posted to it.
+type webhookReceiver struct {
+	mu     sync.Mutex
+	bodies []map[string]any
+}
+
+func (w *webhookReceiver) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.mu.Lock()
+		w.bodies = append(w.bodies, body)
+		w.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (w *webhookReceiver) received() []map[string]any {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	bodies := w.bodies
+	w.bodies = nil
+
+	return bodies
+}
+
+func TestFreshness_Check(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	var generic, slack webhookReceiver
+
+	cfg := config.FreshnessAlertsConfig{
+		Enabled:   true,
+		Threshold: 30 * time.Minute,
+		Networks:  map[string]time.Duration{"sepolia": time.Hour},
+		Webhooks: []config.WebhookConfig{
+			{URL: generic.serve(t).URL},
+			{Type: config.WebhookTypeSlack, URL: slack.serve(t).URL},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var (
+		mu      sync.Mutex
+		current = map[string]*bounds.BoundsData{
+			"mainnet": {Tables: map[string]bounds.TableBounds{
+				"fct_block": {Min: 0, Max: 100},
+				"fct_head":  {Min: 0, Max: 100},
+			}},
+			"sepolia": {Tables: map[string]bounds.TableBounds{
+				"fct_block": {Min: 0, Max: 100},
+			}},
+		}
+	)
+
+	setMax := func(network, table string, maxBound int64) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		current[network].Tables[table] = bounds.TableBounds{Max: maxBound}
+	}
+
+	provider := boundsmocks.NewMockProvider(gomock.NewController(t))
+	provider.EXPECT().GetAllBounds(gomock.Any()).DoAndReturn(func(context.Context) map[string]*bounds.BoundsData {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return current
+	}).AnyTimes()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newChecker := func() *Freshness {
+		f := NewFreshness(logger, cfg, client, provider)
+		f.now = func() time.Time { return now }
+
+		return f
+	}
+
+	f := newChecker()
+	ctx := context.Background()
+
+	// First sight of every table
+	require.NoError(t, f.Check(ctx))
+	assert.Empty(t, generic.received())
+
+	// fct_block keeps advancing, fct_head stalls past mainnet's threshold;
+	// sepolia's longer threshold is not reached yet
+	now = now.Add(40 * time.Minute)
+	setMax("mainnet", "fct_block", 200)
+	require.NoError(t, f.Check(ctx))
+
+	alerts := generic.received()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, StatusStalled, alerts[0]["status"])
+	assert.Equal(t, "mainnet", alerts[0]["network"])
+	assert.Equal(t, "fct_head", alerts[0]["table"])
+	assert.Equal(t, "40m0s", alerts[0]["lag"])
+	assert.Equal(t, "30m0s", alerts[0]["threshold"])
+
+	messages := slack.received()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0]["text"], "`fct_head` has not advanced for 40m0s")
+
+	// Alerts are sent once, by whichever instance checks next
+	now = now.Add(10 * time.Minute)
+	setMax("mainnet", "fct_block", 300)
+	setMax("sepolia", "fct_block", 150)
+	require.NoError(t, newChecker().Check(ctx))
+	assert.Empty(t, generic.received())
+
+	// A stalled table that advances again recovers
+	now = now.Add(time.Minute)
+	setMax("mainnet", "fct_head", 101)
+	require.NoError(t, f.Check(ctx))
+
+	alerts = generic.received()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, StatusRecovered, alerts[0]["status"])
+	assert.Equal(t, "fct_head", alerts[0]["table"])
+	assert.Equal(t, "51m0s", alerts[0]["lag"])
+	assert.Contains(t, slack.received()[0]["text"], "is advancing again after 51m0s")
+}
+
+func TestFreshness_SkipsStaleNetworks(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	var generic webhookReceiver
+
+	cfg := config.FreshnessAlertsConfig{
+		Enabled:  true,
+		Webhooks: []config.WebhookConfig{{URL: generic.serve(t).URL}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	data := &bounds.BoundsData{Tables: map[string]bounds.TableBounds{"fct_block": {Max: 100}}}
+
+	provider := boundsmocks.NewMockProvider(gomock.NewController(t))
+	provider.EXPECT().GetAllBounds(gomock.Any()).Return(map[string]*bounds.BoundsData{"mainnet": data}).AnyTimes()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	f := NewFreshness(logger, cfg, client, provider)
+	f.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	require.NoError(t, f.Check(ctx))
+
+	// Bounds fetching failing is not a stalled table
+	data.Stale = true
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, f.Check(ctx))
+	assert.Empty(t, generic.received())
+
+	// Once fetched again, the time it was stale counts
+	data.Stale = false
+	require.NoError(t, f.Check(ctx))
+	assert.Len(t, generic.received(), 1)
+}
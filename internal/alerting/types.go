@@ -0,0 +1,31 @@
+//nolint:tagliatelle // superior snake-case yo.
+package alerting
+
+import "time"
+
+// Alert statuses.
+const (
+	StatusStalled   = "stalled"
+	StatusRecovered = "recovered"
+)
+
+// Alert is sent when a table's max bound stalls, or advances again after
+// stalling.
+type Alert struct {
+	Status         string    `json:"status"` // StatusStalled or StatusRecovered
+	Network        string    `json:"network"`
+	Table          string    `json:"table"`
+	Max            int64     `json:"max"`              // Max bound of the table
+	LastAdvancedAt time.Time `json:"last_advanced_at"` // When the max bound last moved before this alert
+	Lag            string    `json:"lag"`              // How long the max bound stood still, e.g. "45m0s"
+	LagSeconds     float64   `json:"lag_seconds"`
+	Threshold      string    `json:"threshold"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// tableState tracks the max bound of a table across checks.
+type tableState struct {
+	Max        int64     `json:"max"`
+	AdvancedAt time.Time `json:"advanced_at"` // When Max last moved forward, or was first seen
+	Alerted    bool      `json:"alerted"`     // Whether a stalled alert was sent and not yet recovered
+}
@@ -0,0 +1,90 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// Notifier posts alerts to webhooks, each in its own format.
+type Notifier struct {
+	webhooks   []config.WebhookConfig
+	httpClient *http.Client
+}
+
+// NewNotifier creates a notifier for the given webhooks.
+func NewNotifier(webhooks []config.WebhookConfig) *Notifier {
+	return &Notifier{
+		webhooks:   webhooks,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send posts alert to every webhook. A failing webhook does not stop the
+// others; their errors are joined.
+func (n *Notifier) Send(ctx context.Context, alert Alert) error {
+	var errs []error
+
+	for _, webhook := range n.webhooks {
+		if err := n.post(ctx, webhook, alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s webhook: %w", webhook.Type, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) post(ctx context.Context, webhook config.WebhookConfig, alert Alert) error {
+	body, err := json.Marshal(payload(webhook.Type, alert))
+	if err != nil {
+		return fmt.Errorf("marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// payload returns the body sent to a webhook of the given type.
+func payload(webhookType string, alert Alert) any {
+	switch webhookType {
+	case config.WebhookTypeSlack:
+		return map[string]string{"text": alert.Text()}
+	case config.WebhookTypeDiscord:
+		return map[string]string{"content": alert.Text()}
+	default:
+		return alert
+	}
+}
+
+// Text returns a one-line message for chat webhooks.
+func (a Alert) Text() string {
+	if a.Status == StatusRecovered {
+		return fmt.Sprintf(":white_check_mark: %s `%s` is advancing again after %s (max %d)",
+			a.Network, a.Table, a.Lag, a.Max)
+	}
+
+	return fmt.Sprintf(":warning: %s `%s` has not advanced for %s (threshold %s, max %d)",
+		a.Network, a.Table, a.Lag, a.Threshold, a.Max)
+}
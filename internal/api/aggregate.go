@@ -0,0 +1,365 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*AggregateHandler)(nil)
+
+// tableNamePattern matches CBT table names accepted by the aggregate endpoint.
+var tableNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// aggregateReservedParams are query parameters set by the aggregate endpoint
+// itself; all others are forwarded to every table query.
+var aggregateReservedParams = []string{"tables", "slot_gte", "slot_lte", "page_size", "page_token"}
+
+// NetworkProxy is the network proxy table queries are sent through.
+type NetworkProxy interface {
+	http.Handler
+	Networks() []string
+}
+
+// AggregateResponse is the response for GET /api/v1/{network}/aggregate.
+type AggregateResponse struct {
+	Network   string                       `json:"network"`
+	SlotGTE   uint64                       `json:"slot_gte"`
+	SlotLTE   uint64                       `json:"slot_lte"`
+	Tables    map[string][]json.RawMessage `json:"tables"`              // Rows per table, in upstream order
+	Truncated []string                     `json:"truncated,omitempty"` // Tables with rows beyond max_pages pages
+}
+
+// AggregateHandler handles GET /api/v1/{network}/aggregate requests. It
+// fetches every page of several tables for a slot range through the proxy,
+// tables in parallel, and returns them in one response.
+type AggregateHandler struct {
+	cfg    config.AggregateConfig
+	proxy  NetworkProxy
+	logger logrus.FieldLogger
+}
+
+// NewAggregateHandler creates a new aggregate handler.
+func NewAggregateHandler(cfg config.AggregateConfig, proxy NetworkProxy, logger logrus.FieldLogger) *AggregateHandler {
+	return &AggregateHandler{
+		cfg:    cfg,
+		proxy:  proxy,
+		logger: logger.WithField("handler", "aggregate"),
+	}
+}
+
+// aggregateQuery is a parsed aggregate request.
+type aggregateQuery struct {
+	tables  []string
+	slotGTE uint64
+	slotLTE uint64
+	extra   url.Values // Forwarded to every table query
+}
+
+// tableResult is the merged rows of one table.
+type tableResult struct {
+	rows      []json.RawMessage
+	truncated bool
+}
+
+// ServeHTTP fetches the requested tables and writes the combined response.
+func (h *AggregateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	network := r.PathValue("network")
+	if !slices.Contains(h.proxy.Networks(), network) {
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, fmt.Sprintf("network %s not found", network))
+
+		return
+	}
+
+	query, err := h.parseQuery(r.URL.Query())
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, err.Error())
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	results, err := h.fetchTables(ctx, r, network, query)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).WithField("network", network).Warn("Aggregation failed")
+		httperr.Write(w, r, http.StatusBadGateway, httperr.CodeUpstreamUnavailable, err.Error())
+
+		return
+	}
+
+	response := AggregateResponse{
+		Network: network,
+		SlotGTE: query.slotGTE,
+		SlotLTE: query.slotLTE,
+		Tables:  make(map[string][]json.RawMessage, len(results)),
+	}
+
+	for _, table := range query.tables {
+		response.Tables[table] = results[table].rows
+
+		if results[table].truncated {
+			response.Truncated = append(response.Truncated, table)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
+
+// parseQuery validates the tables and slot range of a request.
+func (h *AggregateHandler) parseQuery(values url.Values) (*aggregateQuery, error) {
+	var tables []string
+
+	for table := range strings.SplitSeq(values.Get("tables"), ",") {
+		table = strings.TrimSpace(table)
+		if table == "" || slices.Contains(tables, table) {
+			continue
+		}
+
+		if !tableNamePattern.MatchString(table) {
+			return nil, fmt.Errorf("invalid table name %q", table)
+		}
+
+		tables = append(tables, table)
+	}
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("tables parameter required")
+	}
+
+	if len(tables) > h.cfg.MaxTables {
+		return nil, fmt.Errorf("at most %d tables allowed, got %d", h.cfg.MaxTables, len(tables))
+	}
+
+	slotGTE, err := strconv.ParseUint(values.Get("slot_gte"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("slot_gte must be a slot number")
+	}
+
+	slotLTE, err := strconv.ParseUint(values.Get("slot_lte"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("slot_lte must be a slot number")
+	}
+
+	if slotLTE < slotGTE {
+		return nil, fmt.Errorf("slot_lte must not be below slot_gte")
+	}
+
+	if slotLTE-slotGTE >= h.cfg.MaxSlotRange {
+		return nil, fmt.Errorf("slot range must be below %d slots", h.cfg.MaxSlotRange)
+	}
+
+	extra := make(url.Values, len(values))
+	for key, vals := range values {
+		if !slices.Contains(aggregateReservedParams, key) {
+			extra[key] = vals
+		}
+	}
+
+	return &aggregateQuery{
+		tables:  tables,
+		slotGTE: slotGTE,
+		slotLTE: slotLTE,
+		extra:   extra,
+	}, nil
+}
+
+// fetchTables fetches every table, concurrency at a time. The first failure
+// cancels the remaining fetches.
+func (h *AggregateHandler) fetchTables(
+	ctx context.Context,
+	r *http.Request,
+	network string,
+	query *aggregateQuery,
+) (map[string]tableResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		sem      = make(chan struct{}, h.cfg.Concurrency)
+		mu       sync.Mutex
+		results  = make(map[string]tableResult, len(query.tables))
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	for _, table := range query.tables {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			result, err := h.fetchTable(ctx, r, network, table, query)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("table %s: %w", table, err)
+				}
+
+				cancel()
+
+				return
+			}
+
+			results[table] = result
+		})
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// fetchTable follows next_page_token until the table is exhausted or
+// max_pages pages have been fetched.
+func (h *AggregateHandler) fetchTable(
+	ctx context.Context,
+	r *http.Request,
+	network string,
+	table string,
+	query *aggregateQuery,
+) (tableResult, error) {
+	var (
+		result    tableResult
+		pageToken string
+	)
+
+	for range h.cfg.MaxPages {
+		values := make(url.Values, len(query.extra)+4)
+		for key, vals := range query.extra {
+			values[key] = vals
+		}
+
+		values.Set("slot_gte", strconv.FormatUint(query.slotGTE, 10))
+		values.Set("slot_lte", strconv.FormatUint(query.slotLTE, 10))
+		values.Set("page_size", strconv.Itoa(h.cfg.PageSize))
+
+		if pageToken != "" {
+			values.Set("page_token", pageToken)
+		}
+
+		rows, next, err := h.fetchPage(ctx, r, "/api/v1/"+network+"/"+table+"?"+values.Encode(), table)
+		if err != nil {
+			return tableResult{}, err
+		}
+
+		result.rows = append(result.rows, rows...)
+
+		if next == "" {
+			return result, nil
+		}
+
+		pageToken = next
+	}
+
+	result.truncated = true
+
+	return result, nil
+}
+
+// fetchPage sends one table query through the proxy and returns its rows and
+// the token of the next page.
+func (h *AggregateHandler) fetchPage(
+	ctx context.Context,
+	r *http.Request,
+	target string,
+	table string,
+) ([]json.RawMessage, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+	if err != nil {
+		return nil, "", fmt.Errorf("create request: %w", err)
+	}
+
+	// Keep the caller's identity (request ID, preview token, address) but
+	// have the upstream reply uncompressed, as the body is parsed here
+	req.Header = r.Header.Clone()
+	req.Header.Del("Accept-Encoding")
+	req.RemoteAddr = r.RemoteAddr
+
+	rw := &bufferWriter{header: make(http.Header), status: http.StatusOK}
+	h.proxy.ServeHTTP(rw, req)
+
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if rw.status != http.StatusOK {
+		return nil, "", fmt.Errorf("upstream returned status %d", rw.status)
+	}
+
+	var page map[string]json.RawMessage
+	if err := json.Unmarshal(rw.body.Bytes(), &page); err != nil {
+		return nil, "", fmt.Errorf("parse response: %w", err)
+	}
+
+	var rows []json.RawMessage
+	if raw, ok := page[table]; ok {
+		if err := json.Unmarshal(raw, &rows); err != nil {
+			return nil, "", fmt.Errorf("parse %s rows: %w", table, err)
+		}
+	}
+
+	var next string
+	if raw, ok := page["next_page_token"]; ok {
+		_ = json.Unmarshal(raw, &next)
+	}
+
+	return rows, next, nil
+}
+
+// bufferWriter records the status and body of a response.
+type bufferWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *bufferWriter) WriteHeader(code int) {
+	b.status = code
+}
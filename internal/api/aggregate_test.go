@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// fakeNetworkProxy serves tables of mainnet with pageSize rows of total per
+// table, paginated by page_token.
+type fakeNetworkProxy struct {
+	total    map[string]int
+	pageSize int
+	requests atomic.Int32
+	lastPath atomic.Value
+}
+
+func (p *fakeNetworkProxy) Networks() []string {
+	return []string{"mainnet"}
+}
+
+func (p *fakeNetworkProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.requests.Add(1)
+	p.lastPath.Store(r.URL.String())
+
+	table := strings.TrimPrefix(r.URL.Path, "/api/v1/mainnet/")
+
+	total, ok := p.total[table]
+	if !ok {
+		http.Error(w, "unknown table", http.StatusBadRequest)
+
+		return
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("page_token"))
+
+	rows := make([]map[string]int, 0, p.pageSize)
+	for i := offset; i < total && i < offset+p.pageSize; i++ {
+		rows = append(rows, map[string]int{"slot": i})
+	}
+
+	next := ""
+	if offset+p.pageSize < total {
+		next = strconv.Itoa(offset + p.pageSize)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{table: rows, "next_page_token": next})
+}
+
+func newTestAggregateHandler(t *testing.T, proxy NetworkProxy) *AggregateHandler {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.AggregateConfig{Enabled: true, MaxTables: 3, MaxPages: 3}
+	require.NoError(t, cfg.Validate())
+
+	return NewAggregateHandler(cfg, proxy, logger)
+}
+
+func serveAggregate(handler http.Handler, path string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/{network}/aggregate", handler)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	return rec
+}
+
+func TestAggregateHandler_MergesPages(t *testing.T) {
+	proxy := &fakeNetworkProxy{total: map[string]int{"fct_block": 5, "fct_attestation": 1, "fct_big": 100}, pageSize: 2}
+	handler := newTestAggregateHandler(t, proxy)
+
+	rec := serveAggregate(handler, "/api/v1/mainnet/aggregate?tables=fct_block,fct_attestation,fct_big,fct_block&slot_gte=100&slot_lte=200")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp AggregateResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+	assert.Equal(t, "mainnet", resp.Network)
+	assert.Equal(t, uint64(100), resp.SlotGTE)
+	assert.Equal(t, uint64(200), resp.SlotLTE)
+	assert.Len(t, resp.Tables["fct_block"], 5)
+	assert.Len(t, resp.Tables["fct_attestation"], 1)
+
+	// max_pages pages of fct_big are returned, and it is flagged as truncated
+	assert.Len(t, resp.Tables["fct_big"], 6)
+	assert.Equal(t, []string{"fct_big"}, resp.Truncated)
+
+	// 3 pages of fct_block, 1 of fct_attestation, 3 of fct_big
+	assert.Equal(t, int32(7), proxy.requests.Load())
+}
+
+func TestAggregateHandler_ForwardsQuery(t *testing.T) {
+	proxy := &fakeNetworkProxy{total: map[string]int{"fct_block": 1}, pageSize: 10}
+	handler := newTestAggregateHandler(t, proxy)
+
+	rec := serveAggregate(handler, "/api/v1/mainnet/aggregate?tables=fct_block&slot_gte=1&slot_lte=2&order_by=slot&page_size=1")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	path, _ := proxy.lastPath.Load().(string)
+	assert.Contains(t, path, "order_by=slot")
+	assert.Contains(t, path, "slot_gte=1")
+	assert.Contains(t, path, "slot_lte=2")
+	assert.Contains(t, path, fmt.Sprintf("page_size=%d", handler.cfg.PageSize))
+}
+
+func TestAggregateHandler_Errors(t *testing.T) {
+	proxy := &fakeNetworkProxy{total: map[string]int{"fct_block": 1}, pageSize: 10}
+	handler := newTestAggregateHandler(t, proxy)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{name: "unknown network", path: "/api/v1/sepolia/aggregate?tables=fct_block&slot_gte=1&slot_lte=2", status: http.StatusNotFound},
+		{name: "no tables", path: "/api/v1/mainnet/aggregate?slot_gte=1&slot_lte=2", status: http.StatusBadRequest},
+		{name: "invalid table", path: "/api/v1/mainnet/aggregate?tables=../admin&slot_gte=1&slot_lte=2", status: http.StatusBadRequest},
+		{name: "too many tables", path: "/api/v1/mainnet/aggregate?tables=a,b,c,d&slot_gte=1&slot_lte=2", status: http.StatusBadRequest},
+		{name: "missing slot", path: "/api/v1/mainnet/aggregate?tables=fct_block&slot_gte=1", status: http.StatusBadRequest},
+		{name: "inverted range", path: "/api/v1/mainnet/aggregate?tables=fct_block&slot_gte=2&slot_lte=1", status: http.StatusBadRequest},
+		{name: "range too large", path: "/api/v1/mainnet/aggregate?tables=fct_block&slot_gte=0&slot_lte=7200", status: http.StatusBadRequest},
+		{name: "upstream error", path: "/api/v1/mainnet/aggregate?tables=fct_block,fct_missing&slot_gte=1&slot_lte=2", status: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, serveAggregate(handler, tt.path).Code)
+		})
+	}
+}
+
+func TestAggregateHandler_Timeout(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	slow := &slowNetworkProxy{delay: 2 * time.Second}
+
+	handler := NewAggregateHandler(config.AggregateConfig{
+		Enabled:        true,
+		MaxTables:      1,
+		MaxSlotRange:   10,
+		MaxPages:       1,
+		PageSize:       1,
+		Concurrency:    1,
+		RequestTimeout: 50 * time.Millisecond,
+	}, slow, logger)
+
+	rec := serveAggregate(handler, "/api/v1/mainnet/aggregate?tables=fct_block&slot_gte=1&slot_lte=2")
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+}
+
+// slowNetworkProxy answers once the request is cancelled or delay passed.
+type slowNetworkProxy struct {
+	delay time.Duration
+}
+
+func (p *slowNetworkProxy) Networks() []string {
+	return []string{"mainnet"}
+}
+
+func (p *slowNetworkProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-r.Context().Done():
+		w.WriteHeader(http.StatusBadGateway)
+	case <-time.After(p.delay):
+		_, _ = w.Write([]byte(`{"fct_block":[]}`))
+	}
+}
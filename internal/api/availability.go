@@ -0,0 +1,231 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*AvailabilityHandler)(nil)
+
+// Reasons a feature is unavailable on a network.
+const (
+	UnavailableDisabled      = "disabled"       // Disabled in config, held back by a requirement or outside the client's rollout
+	UnavailableNoBounds      = "no_bounds"      // The network has no bounds (yet)
+	UnavailableMissingTables = "missing_tables" // Some tables of the feature have no data on the network
+	UnavailableNoOverlap     = "no_overlap"     // The tables have data, but for no common slot
+)
+
+// AvailabilityResponse is the response for GET /api/v1/availability.
+type AvailabilityResponse struct {
+	Features []FeatureAvailability `json:"features"`
+}
+
+// FeatureAvailability is whether a feature is usable on each network.
+type FeatureAvailability struct {
+	Path     string                         `json:"path"`
+	Tables   []string                       `json:"tables"`
+	Networks map[string]NetworkAvailability `json:"networks"`
+}
+
+// NetworkAvailability is whether a feature is usable on a network and, when
+// its tables are time-based, for which slots all of them have data. The max
+// slot is exclusive, as in bounds.
+type NetworkAvailability struct {
+	Available     bool                `json:"available"`
+	Reason        string              `json:"reason,omitempty"`
+	MissingTables []string            `json:"missing_tables,omitempty"`
+	Slots         *bounds.TableBounds `json:"slots,omitempty"`
+	Stale         bool                `json:"stale,omitempty"` // Bounds are no longer being refreshed
+}
+
+// AvailabilityHandler handles GET /api/v1/availability requests. It combines
+// the features of the config endpoint with the bounds of the tables each
+// feature reads, telling which feature is usable on which network and for
+// which slot range.
+type AvailabilityHandler struct {
+	configHandler *ConfigHandler
+	provider      bounds.Provider
+	wallclock     *wallclock.Service
+	logger        logrus.FieldLogger
+}
+
+// NewAvailabilityHandler creates a new availability handler. Hidden networks
+// are only reported to requests carrying a valid preview token.
+func NewAvailabilityHandler(
+	configHandler *ConfigHandler,
+	provider bounds.Provider,
+	wallclockSvc *wallclock.Service,
+	logger logrus.FieldLogger,
+) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		configHandler: configHandler,
+		provider:      provider,
+		wallclock:     wallclockSvc,
+		logger:        logger.WithField("handler", "availability"),
+	}
+}
+
+// networkBounds is a network's bounds, nil when it has none.
+type networkBounds struct {
+	data  *bounds.BoundsData
+	stale bool
+}
+
+// ServeHTTP builds the availability matrix.
+func (h *AvailabilityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	preview := h.configHandler.PreviewAllowed(r)
+
+	networks := h.configHandler.buildNetworks(ctx, preview)
+	features := h.configHandler.buildFeatures(ctx, r.Header.Get(config.ClientIDHeaderName))
+
+	settings := tenancy.FromContext(ctx).Features(h.configHandler.config.Features)
+
+	tables := make(map[string][]string, len(settings))
+	for _, feature := range settings {
+		tables[feature.Path] = feature.Tables
+	}
+
+	networkData := make(map[string]networkBounds, len(networks))
+	for _, network := range networks {
+		networkData[network.Name] = h.getBounds(ctx, network.Name)
+	}
+
+	response := AvailabilityResponse{Features: make([]FeatureAvailability, 0, len(features))}
+
+	for _, feature := range features {
+		availability := FeatureAvailability{
+			Path:     feature.Path,
+			Tables:   tables[feature.Path],
+			Networks: make(map[string]NetworkAvailability, len(networks)),
+		}
+
+		if availability.Tables == nil {
+			availability.Tables = []string{}
+		}
+
+		for _, network := range networks {
+			if slices.Contains(feature.DisabledNetworks, network.Name) {
+				availability.Networks[network.Name] = NetworkAvailability{Reason: UnavailableDisabled}
+
+				continue
+			}
+
+			availability.Networks[network.Name] = h.networkAvailability(network.Name, availability.Tables, networkData[network.Name])
+		}
+
+		response.Features = append(response.Features, availability)
+	}
+
+	if preview {
+		// Never let shared caches store the preview variant
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+
+	if h.configHandler.hasRollouts(r.Context()) {
+		// Features differ per client while a rollout is in progress
+		w.Header().Add("Vary", config.ClientIDHeaderName)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
+
+// getBounds returns the bounds of network. Stale bounds are used all the same.
+func (h *AvailabilityHandler) getBounds(ctx context.Context, network string) networkBounds {
+	if h.provider == nil {
+		return networkBounds{}
+	}
+
+	data, err := h.provider.GetBounds(ctx, network)
+
+	switch {
+	case errors.Is(err, errs.ErrStale):
+		return networkBounds{data: data, stale: true}
+	case err != nil:
+		if !errors.Is(err, errs.ErrNotFound) {
+			h.logger.WithError(err).WithField("network", network).Debug("Failed to get bounds for network")
+		}
+
+		return networkBounds{}
+	default:
+		return networkBounds{data: data}
+	}
+}
+
+// networkAvailability checks that every table has bounds on network, and
+// intersects the slot ranges of the time-based ones.
+func (h *AvailabilityHandler) networkAvailability(network string, tables []string, nb networkBounds) NetworkAvailability {
+	if len(tables) == 0 {
+		return NetworkAvailability{Available: true}
+	}
+
+	if nb.data == nil {
+		return NetworkAvailability{Reason: UnavailableNoBounds}
+	}
+
+	availability := NetworkAvailability{Stale: nb.stale}
+
+	for _, table := range tables {
+		if _, ok := nb.data.Tables[table]; !ok {
+			availability.MissingTables = append(availability.MissingTables, table)
+		}
+	}
+
+	if len(availability.MissingTables) > 0 {
+		availability.Reason = UnavailableMissingTables
+
+		return availability
+	}
+
+	translator, ok := newBoundsTranslator(h.wallclock, network)
+	if !ok {
+		availability.Available = true
+
+		return availability
+	}
+
+	for _, table := range tables {
+		slots, ok := translator.translate(nb.data.Tables[table], BoundsUnitSlot)
+		if !ok {
+			continue // Block numbers and other counters have no slots
+		}
+
+		if availability.Slots == nil {
+			availability.Slots = &slots
+
+			continue
+		}
+
+		availability.Slots.Min = max(availability.Slots.Min, slots.Min)
+		availability.Slots.Max = min(availability.Slots.Max, slots.Max)
+	}
+
+	if availability.Slots != nil && availability.Slots.Max <= availability.Slots.Min {
+		availability.Slots = nil
+		availability.Reason = UnavailableNoOverlap
+
+		return availability
+	}
+
+	availability.Available = true
+
+	return availability
+}
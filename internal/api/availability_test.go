@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+func TestAvailabilityHandler_ServeHTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	genesis := int64(1606824023)
+
+	wallclockSvc := wallclock.New(logger)
+	require.NoError(t, wallclockSvc.AddNetwork(wallclock.NetworkConfig{Name: "mainnet", GenesisTime: time.Unix(genesis, 0)}))
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "sepolia", TargetURL: "http://sepolia"},
+		},
+		Features: []config.FeatureSettings{
+			{Path: "/ethereum/live-slots", Tables: []string{"fct_block", "fct_block_blob_count"}, DisabledNetworks: []string{"sepolia"}},
+			{Path: "/ethereum/execution/payloads", Tables: []string{"fct_block", "canonical_execution_block"}},
+			{Path: "/ethereum/forks"},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	provider := boundsmocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetBounds(gomock.Any(), "mainnet").Return(&bounds.BoundsData{
+		Tables: map[string]bounds.TableBounds{
+			"fct_block":                 {Min: genesis + 64*12, Max: genesis + 200*12},
+			"fct_block_blob_count":      {Min: genesis + 100*12, Max: genesis + 300*12},
+			"canonical_execution_block": {Min: 0, Max: 21000000},
+		},
+	}, nil).AnyTimes()
+	provider.EXPECT().GetBounds(gomock.Any(), "sepolia").Return(nil, errs.ErrNotFound).AnyTimes()
+
+	handler := NewAvailabilityHandler(NewConfigHandler(logger, cfg, nil, nil, nil, nil), provider, wallclockSvc, logger)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/availability", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response AvailabilityResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Features, 3)
+
+	features := make(map[string]FeatureAvailability, len(response.Features))
+	for _, feature := range response.Features {
+		features[feature.Path] = feature
+	}
+
+	liveSlots := features["/ethereum/live-slots"]
+	assert.Equal(t, NetworkAvailability{Available: true, Slots: &bounds.TableBounds{Min: 100, Max: 200}}, liveSlots.Networks["mainnet"])
+	assert.Equal(t, NetworkAvailability{Reason: UnavailableDisabled}, liveSlots.Networks["sepolia"])
+
+	// Block numbers don't narrow the slot range
+	payloads := features["/ethereum/execution/payloads"]
+	assert.Equal(t, NetworkAvailability{Available: true, Slots: &bounds.TableBounds{Min: 64, Max: 200}}, payloads.Networks["mainnet"])
+	assert.Equal(t, NetworkAvailability{Reason: UnavailableNoBounds}, payloads.Networks["sepolia"])
+
+	// Features without tables are available wherever they are enabled
+	forks := features["/ethereum/forks"]
+	assert.Empty(t, forks.Tables)
+	assert.True(t, forks.Networks["sepolia"].Available)
+}
+
+func TestAvailabilityHandler_networkAvailability(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	genesis := int64(1606824023)
+
+	wallclockSvc := wallclock.New(logger)
+	require.NoError(t, wallclockSvc.AddNetwork(wallclock.NetworkConfig{Name: "mainnet", GenesisTime: time.Unix(genesis, 0)}))
+
+	handler := NewAvailabilityHandler(NewConfigHandler(logger, &config.Config{}, nil, nil, nil, nil), nil, wallclockSvc, logger)
+
+	data := networkBounds{
+		data: &bounds.BoundsData{Tables: map[string]bounds.TableBounds{
+			"fct_early": {Min: genesis + 10*12, Max: genesis + 20*12},
+			"fct_late":  {Min: genesis + 30*12, Max: genesis + 40*12},
+		}},
+		stale: true,
+	}
+
+	assert.Equal(t,
+		NetworkAvailability{Reason: UnavailableMissingTables, MissingTables: []string{"fct_missing"}, Stale: true},
+		handler.networkAvailability("mainnet", []string{"fct_early", "fct_missing"}, data))
+
+	assert.Equal(t,
+		NetworkAvailability{Reason: UnavailableNoOverlap, Stale: true},
+		handler.networkAvailability("mainnet", []string{"fct_early", "fct_late"}, data))
+}
@@ -2,26 +2,48 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"maps"
 	"net/http"
 
+	"github.com/ethpandaops/lab-backend/internal/auth"
 	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/negcache"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
 	"github.com/sirupsen/logrus"
 )
 
 // Verify interface compliance at compile time.
 var _ http.Handler = (*BoundsHandler)(nil)
 
+// staleWarning is the Warning header sent with bounds that are no longer being refreshed.
+const staleWarning = `110 lab-backend "Response is Stale"`
+
 // BoundsHandler handles GET /api/v1/{network}/bounds requests.
 type BoundsHandler struct {
-	provider bounds.Provider
-	logger   logrus.FieldLogger
+	provider  bounds.Provider
+	wallclock *wallclock.Service // Slot timing for ?unit= translations, nil serves positions only
+	unknown   *negcache.Cache    // Networks without bounds, nil to always look up
+	logger    logrus.FieldLogger
 }
 
-// NewBoundsHandler creates a new bounds handler.
-func NewBoundsHandler(provider bounds.Provider, logger logrus.FieldLogger) *BoundsHandler {
+// NewBoundsHandler creates a new bounds handler. Networks without bounds are
+// remembered in unknown, if set, and answered with 404 without reading Redis.
+// Bounds of time-based tables are translated to slots, epochs or timestamps
+// with the network's wallclock when requested with ?unit=.
+func NewBoundsHandler(
+	provider bounds.Provider,
+	wallclockSvc *wallclock.Service,
+	unknown *negcache.Cache,
+	logger logrus.FieldLogger,
+) *BoundsHandler {
 	return &BoundsHandler{
-		provider: provider,
-		logger:   logger.WithField("handler", "bounds"),
+		provider:  provider,
+		wallclock: wallclockSvc,
+		unknown:   unknown,
+		logger:    logger.WithField("handler", "bounds"),
 	}
 }
 
@@ -31,7 +53,14 @@ func (h *BoundsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	network := r.PathValue("network")
 	if network == "" {
 		h.logger.Error("Network parameter missing from path")
-		http.Error(w, "network parameter required", http.StatusBadRequest)
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "network parameter required")
+
+		return
+	}
+
+	unit, err := parseBoundsUnit(r.URL.Query().Get("unit"))
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, err.Error())
 
 		return
 	}
@@ -39,26 +68,58 @@ func (h *BoundsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Check if provider is available
 	if h.provider == nil {
 		h.logger.Error("Bounds provider not available")
-		http.Error(w, "bounds service unavailable", http.StatusServiceUnavailable)
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "bounds service unavailable")
+
+		return
+	}
+
+	if h.unknown.Has(network) {
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found or bounds unavailable")
 
 		return
 	}
 
 	// Get bounds from provider
-	boundsData, exists := h.provider.GetBounds(r.Context(), network)
-	if !exists {
-		h.logger.WithField("network", network).Warn("Bounds not found for network")
-		http.Error(w, "network not found or bounds unavailable", http.StatusNotFound)
+	boundsData, err := h.provider.GetBounds(r.Context(), network)
+
+	switch {
+	case errors.Is(err, errs.ErrStale):
+		// Stale bounds beat no bounds; flag them so clients can tell
+		h.logger.WithError(err).Debug("Serving stale bounds")
+		w.Header().Set("Warning", staleWarning)
+	case errors.Is(err, errs.ErrNotFound):
+		h.logger.WithError(err).WithField("network", network).Debug("No bounds for network")
+		h.unknown.Add(network)
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found or bounds unavailable")
+
+		return
+	case err != nil:
+		h.logger.WithError(err).WithField("network", network).Warn("Failed to get bounds for network")
+		httperr.WriteErr(w, r, err, "network not found or bounds unavailable")
 
 		return
 	}
 
+	// Translate positions to the requested unit
+	var response any = boundsData.Tables
+
+	if unit != BoundsUnitPosition {
+		translator, ok := newBoundsTranslator(h.wallclock, network)
+		if !ok && unit != BoundsUnitAll {
+			httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "slot timing unavailable for network")
+
+			return
+		}
+
+		response = translateBounds(boundsData.Tables, translator, unit)
+	}
+
 	// Send JSON response (encode just the tables map)
 	w.Header().Set("Content-Type", "application/json")
 
-	if err := json.NewEncoder(w).Encode(boundsData.Tables); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.logger.WithError(err).Error("Failed to encode response")
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
 
 		return
 	}
@@ -68,3 +129,65 @@ func (h *BoundsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		"table_count": len(boundsData.Tables),
 	}).Debug("Served bounds request")
 }
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*BoundsStatusHandler)(nil)
+
+// BoundsStatusHandler handles GET /api/v1/bounds/status requests.
+type BoundsStatusHandler struct {
+	provider      bounds.Provider
+	configHandler *ConfigHandler
+	logger        logrus.FieldLogger
+}
+
+// NewBoundsStatusHandler creates a new bounds status handler. Hidden networks
+// are only reported to requests carrying a valid preview token.
+func NewBoundsStatusHandler(
+	provider bounds.Provider,
+	configHandler *ConfigHandler,
+	logger logrus.FieldLogger,
+) *BoundsStatusHandler {
+	return &BoundsStatusHandler{
+		provider:      provider,
+		configHandler: configHandler,
+		logger:        logger.WithField("handler", "bounds_status"),
+	}
+}
+
+// ServeHTTP returns the per-network circuit breaker status of the bounds fetcher.
+func (h *BoundsStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.provider == nil {
+		h.logger.Error("Bounds provider not available")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "bounds service unavailable")
+
+		return
+	}
+
+	status, err := h.provider.GetStatus(r.Context())
+	if err != nil {
+		h.logger.WithError(err).Debug("Failed to get bounds status")
+		httperr.WriteErr(w, r, err, "bounds status unavailable")
+
+		return
+	}
+
+	if !h.configHandler.PreviewAllowed(r) {
+		for network := range h.configHandler.HiddenNetworks(r.Context()) {
+			delete(status.Networks, network)
+		}
+	}
+
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		maps.DeleteFunc(status.Networks, func(network string, _ bounds.BreakerStatus) bool {
+			return !identity.AllowsNetwork(network)
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
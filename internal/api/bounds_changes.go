@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*BoundsChangesHandler)(nil)
+
+// boundsChangesKeepAlive is how often an idle stream gets a comment, so
+// proxies don't close it.
+const boundsChangesKeepAlive = 15 * time.Second
+
+// BoundsChangeSource delivers the bounds changes of every refresh.
+type BoundsChangeSource interface {
+	SubscribeChanges() (<-chan *bounds.Changes, func())
+}
+
+// BoundsChangesHandler handles GET /api/v1/bounds/changes requests. It
+// streams the bounds changes of every refresh as server-sent events, one
+// "bounds_changes" event per refresh, so tooling can spot stalled or
+// regressing CBT tables. ?network=<name> limits the stream to one network,
+// and network-scoped API keys only see their networks.
+type BoundsChangesHandler struct {
+	source BoundsChangeSource
+	logger logrus.FieldLogger
+}
+
+// NewBoundsChangesHandler creates a new bounds changes handler.
+func NewBoundsChangesHandler(source BoundsChangeSource, logger logrus.FieldLogger) *BoundsChangesHandler {
+	return &BoundsChangesHandler{
+		source: source,
+		logger: logger.WithField("handler", "bounds_changes"),
+	}
+}
+
+// ServeHTTP streams bounds changes until the client disconnects.
+func (h *BoundsChangesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+
+	// The stream outlives the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Debug("Failed to clear write deadline")
+	}
+
+	network := r.URL.Query().Get("network")
+	identity, keyed := auth.IdentityFromContext(r.Context())
+
+	// Only networks asked for and the API key is scoped to are streamed
+	keep := func(name string) bool {
+		return (network == "" || name == network) && (!keyed || identity.AllowsNetwork(name))
+	}
+
+	changes, unsubscribe := h.source.SubscribeChanges()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+
+	if err := rc.Flush(); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Streaming not supported")
+
+		return
+	}
+
+	keepAlive := time.NewTicker(boundsChangesKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case c := <-changes:
+			if network != "" || keyed {
+				if c = c.Filter(keep); c.Empty() {
+					continue
+				}
+			}
+
+			data, err := json.Marshal(c)
+			if err != nil {
+				requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to marshal bounds changes")
+
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: bounds_changes\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+)
+
+type fakeBoundsChangeSource struct {
+	changes chan *bounds.Changes
+}
+
+func (f *fakeBoundsChangeSource) SubscribeChanges() (<-chan *bounds.Changes, func()) {
+	return f.changes, func() {}
+}
+
+func TestBoundsChangesHandler_ServeHTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	source := &fakeBoundsChangeSource{changes: make(chan *bounds.Changes, 2)}
+
+	server := httptest.NewServer(NewBoundsChangesHandler(source, logger))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/bounds/changes?network=mainnet", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Changes to other networks only are filtered out
+	source.changes <- &bounds.Changes{Networks: []bounds.NetworkChanges{{Network: "sepolia", Added: []string{"fct_block"}}}}
+	source.changes <- &bounds.Changes{Networks: []bounds.NetworkChanges{
+		{Network: "sepolia", Added: []string{"fct_block"}},
+		{Network: "mainnet", Regressed: []bounds.BoundChange{{Table: "fct_block", Bound: bounds.BoundMax, From: 200, To: 150}}},
+	}}
+
+	reader := bufio.NewReader(resp.Body)
+
+	var event, data string
+
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		}
+	}
+
+	assert.Equal(t, "bounds_changes", event)
+
+	var changes bounds.Changes
+	require.NoError(t, json.Unmarshal([]byte(data), &changes))
+	require.Len(t, changes.Networks, 1)
+	assert.Equal(t, "mainnet", changes.Networks[0].Network)
+	assert.Equal(t, int64(150), changes.Networks[0].Regressed[0].To)
+}
+
+func TestBoundsChangesHandler_ScopedKey(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	source := &fakeBoundsChangeSource{changes: make(chan *bounds.Changes, 2)}
+	handler := NewBoundsChangesHandler(source, logger)
+
+	identity := auth.Identity{KeyID: "k1", Networks: []string{"mainnet"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/bounds/changes", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	// Without ?network= the stream is still limited to the key's networks
+	source.changes <- &bounds.Changes{Networks: []bounds.NetworkChanges{{Network: "sepolia", Added: []string{"fct_block"}}}}
+	source.changes <- &bounds.Changes{Networks: []bounds.NetworkChanges{
+		{Network: "sepolia", Added: []string{"fct_block"}},
+		{Network: "mainnet", Added: []string{"fct_attestation"}},
+	}}
+
+	reader := bufio.NewReader(resp.Body)
+
+	var data string
+
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		if strings.HasPrefix(line, "data: ") {
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		}
+	}
+
+	var changes bounds.Changes
+	require.NoError(t, json.Unmarshal([]byte(data), &changes))
+	require.Len(t, changes.Networks, 1)
+	assert.Equal(t, "mainnet", changes.Networks[0].Network)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -13,8 +14,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethpandaops/lab-backend/internal/auth"
 	"github.com/ethpandaops/lab-backend/internal/bounds"
 	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/negcache"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
 )
 
 func TestBoundsHandler_ServeHTTP(t *testing.T) {
@@ -22,9 +28,10 @@ func TestBoundsHandler_ServeHTTP(t *testing.T) {
 		name           string
 		network        string
 		mockBounds     *bounds.BoundsData
-		mockFound      bool
+		mockErr        error
 		providerNil    bool
 		expectedStatus int
+		expectStale    bool
 		validateResp   func(t *testing.T, tables map[string]bounds.TableBounds)
 	}{
 		{
@@ -37,7 +44,6 @@ func TestBoundsHandler_ServeHTTP(t *testing.T) {
 				},
 				LastUpdated: time.Now(),
 			},
-			mockFound:      true,
 			expectedStatus: http.StatusOK,
 			validateResp: func(t *testing.T, tables map[string]bounds.TableBounds) {
 				t.Helper()
@@ -54,9 +60,26 @@ func TestBoundsHandler_ServeHTTP(t *testing.T) {
 			name:           "network not found returns 404",
 			network:        "nonexistent",
 			mockBounds:     nil,
-			mockFound:      false,
+			mockErr:        errs.ErrNotFound,
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:           "redis unavailable returns 502",
+			network:        "mainnet",
+			mockErr:        errs.ErrUpstreamUnavailable,
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:    "stale bounds are served with a warning",
+			network: "mainnet",
+			mockBounds: &bounds.BoundsData{
+				Tables:      map[string]bounds.TableBounds{"beacon_block": {Min: 100, Max: 200}},
+				LastUpdated: time.Now().Add(-time.Hour),
+			},
+			mockErr:        errs.ErrStale,
+			expectedStatus: http.StatusOK,
+			expectStale:    true,
+		},
 		{
 			name:           "missing network parameter returns 400",
 			network:        "",
@@ -81,14 +104,14 @@ func TestBoundsHandler_ServeHTTP(t *testing.T) {
 				mockProvider := boundsmocks.NewMockProvider(ctrl)
 				mockProvider.EXPECT().
 					GetBounds(gomock.Any(), tt.network).
-					Return(tt.mockBounds, tt.mockFound).
+					Return(tt.mockBounds, tt.mockErr).
 					Times(1)
 				provider = mockProvider
 			}
 
 			logger := logrus.New()
 			logger.SetOutput(io.Discard)
-			handler := NewBoundsHandler(provider, logger)
+			handler := NewBoundsHandler(provider, nil, nil, logger)
 
 			// Create request with path value
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/"+tt.network+"/bounds", http.NoBody)
@@ -101,6 +124,7 @@ func TestBoundsHandler_ServeHTTP(t *testing.T) {
 
 			// Assert status
 			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectStale, rec.Header().Get("Warning") != "")
 
 			// Validate response if expected to succeed
 			if tt.expectedStatus == http.StatusOK && tt.validateResp != nil {
@@ -125,12 +149,12 @@ func TestBoundsHandler_ContentType(t *testing.T) {
 		Return(&bounds.BoundsData{
 			Tables:      map[string]bounds.TableBounds{},
 			LastUpdated: time.Now(),
-		}, true).
+		}, nil).
 		Times(1)
 
 	logger := logrus.New()
 	logger.SetOutput(io.Discard)
-	handler := NewBoundsHandler(mockProvider, logger)
+	handler := NewBoundsHandler(mockProvider, nil, nil, logger)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/bounds", http.NoBody)
 	req.SetPathValue("network", "mainnet")
@@ -142,3 +166,185 @@ func TestBoundsHandler_ContentType(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
 }
+
+func TestBoundsHandler_Units(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	genesis := int64(1606824023)
+
+	wallclockSvc := wallclock.New(logger)
+	require.NoError(t, wallclockSvc.AddNetwork(wallclock.NetworkConfig{Name: "mainnet", GenesisTime: time.Unix(genesis, 0)}))
+
+	mockProvider := boundsmocks.NewMockProvider(ctrl)
+	mockProvider.EXPECT().
+		GetBounds(gomock.Any(), gomock.Any()).
+		Return(&bounds.BoundsData{
+			Tables: map[string]bounds.TableBounds{
+				// Slots 64 up to 100, the last one partly covered
+				"fct_block": {Min: genesis + 64*12, Max: genesis + 99*12 + 5},
+				// Block numbers
+				"canonical_execution_block": {Min: 0, Max: 21000000},
+			},
+		}, nil).
+		AnyTimes()
+
+	handler := NewBoundsHandler(mockProvider, wallclockSvc, nil, logger)
+
+	serve := func(network, unit string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/"+network+"/bounds?unit="+unit, http.NoBody)
+		req.SetPathValue("network", network)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	tests := []struct {
+		unit string
+		want map[string]bounds.TableBounds
+	}{
+		{unit: "slot", want: map[string]bounds.TableBounds{"fct_block": {Min: 64, Max: 100}}},
+		{unit: "epoch", want: map[string]bounds.TableBounds{"fct_block": {Min: 2, Max: 4}}},
+		{unit: "timestamp", want: map[string]bounds.TableBounds{"fct_block": {Min: genesis + 64*12, Max: genesis + 100*12}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			rec := serve("mainnet", tt.unit)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var got map[string]bounds.TableBounds
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	rec := serve("mainnet", "all")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var all map[string]TableBoundsUnits
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &all))
+
+	assert.Equal(t, &bounds.TableBounds{Min: 64, Max: 100}, all["fct_block"].Slot)
+	assert.Equal(t, TableBoundsUnits{Min: 0, Max: 21000000}, all["canonical_execution_block"])
+
+	assert.Equal(t, http.StatusBadRequest, serve("mainnet", "blocks").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serve("sepolia", "slot").Code)
+}
+
+func TestBoundsHandler_NegativeCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Only the first request for an unknown network reads the provider
+	mockProvider := boundsmocks.NewMockProvider(ctrl)
+	mockProvider.EXPECT().
+		GetBounds(gomock.Any(), "nonexistent").
+		Return(nil, fmt.Errorf("bounds for nonexistent: %w", errs.ErrNotFound)).
+		Times(1)
+
+	cfg := config.NegativeCacheConfig{}
+	require.NoError(t, cfg.Validate())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	handler := NewBoundsHandler(mockProvider, nil, negcache.New("test", cfg), logger)
+
+	for range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/nonexistent/bounds", http.NoBody)
+		req.SetPathValue("network", "nonexistent")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestBoundsStatusHandler_ServeHTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	hidden := true
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "devnet-9", TargetURL: "http://devnet", Hidden: &hidden},
+		},
+		Preview: config.PreviewConfig{Tokens: []string{"preview-token-0123456789"}},
+	}
+	require.NoError(t, cfg.Preview.Validate())
+
+	ctrl := gomock.NewController(t)
+	provider := boundsmocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetStatus(gomock.Any()).DoAndReturn(func(_ any) (*bounds.Status, error) {
+		return &bounds.Status{
+			Networks: map[string]bounds.BreakerStatus{
+				"mainnet":  {State: bounds.BreakerOpen, ConsecutiveFailures: 5, LastError: "unexpected status 502"},
+				"devnet-9": {State: bounds.BreakerClosed},
+			},
+		}, nil
+	}).Times(3)
+
+	handler := NewBoundsStatusHandler(provider, NewConfigHandler(logger, cfg, nil, nil, nil, nil), logger)
+
+	serve := func(token string, identity *auth.Identity) bounds.Status {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/bounds/status", http.NoBody)
+		if token != "" {
+			req.Header.Set("X-Lab-Preview-Token", token)
+		}
+
+		if identity != nil {
+			req = req.WithContext(auth.WithIdentity(req.Context(), *identity))
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+		var status bounds.Status
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+
+		return status
+	}
+
+	// Hidden networks are only reported with a preview token
+	status := serve("", nil)
+	assert.Len(t, status.Networks, 1)
+	assert.NotContains(t, status.Networks, "devnet-9")
+	assert.Equal(t, bounds.BreakerOpen, status.Networks["mainnet"].State)
+	assert.Equal(t, 5, status.Networks["mainnet"].ConsecutiveFailures)
+
+	status = serve("preview-token-0123456789", nil)
+	assert.Len(t, status.Networks, 2)
+
+	// Network-scoped API keys only see their networks
+	status = serve("preview-token-0123456789", &auth.Identity{KeyID: "k1", Networks: []string{"devnet-9"}})
+	assert.Len(t, status.Networks, 1)
+	assert.Contains(t, status.Networks, "devnet-9")
+}
+
+func TestBoundsStatusHandler_Unavailable(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	ctrl := gomock.NewController(t)
+	provider := boundsmocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetStatus(gomock.Any()).Return(nil, fmt.Errorf("bounds status: %w", errs.ErrNotFound))
+
+	handler := NewBoundsStatusHandler(provider, NewConfigHandler(logger, &config.Config{}, nil, nil, nil, nil), logger)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bounds/status", http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+// Units bounds can be served in, selected with ?unit=.
+const (
+	BoundsUnitPosition  = "position"  // Raw positions as fetched from the CBT API (default)
+	BoundsUnitSlot      = "slot"      // Slot numbers, time-based tables only
+	BoundsUnitEpoch     = "epoch"     // Epoch numbers, time-based tables only
+	BoundsUnitTimestamp = "timestamp" // Unix seconds of slot starts, time-based tables only
+	BoundsUnitAll       = "all"       // Positions with every translation
+)
+
+// TableBoundsUnits is a table's bounds in raw positions and, for time-based
+// tables, translated to slots, epochs and timestamps. Max bounds stay
+// exclusive in every unit.
+type TableBoundsUnits struct {
+	Min       int64               `json:"min"`
+	Max       int64               `json:"max"`
+	Slot      *bounds.TableBounds `json:"slot,omitempty"`
+	Epoch     *bounds.TableBounds `json:"epoch,omitempty"`
+	Timestamp *bounds.TableBounds `json:"timestamp,omitempty"`
+}
+
+// boundsTranslator converts the positions of a network's time-based tables
+// with its wallclock.
+type boundsTranslator struct {
+	timing wallclock.NetworkConfig
+}
+
+// newBoundsTranslator returns a translator for network, false when the
+// network has no wallclock.
+func newBoundsTranslator(wallclockSvc *wallclock.Service, network string) (*boundsTranslator, bool) {
+	if wallclockSvc == nil {
+		return nil, false
+	}
+
+	timing, ok := wallclockSvc.GetConfig(network)
+	if !ok || timing.SecondsPerSlot == 0 || timing.SlotsPerEpoch == 0 {
+		return nil, false
+	}
+
+	return &boundsTranslator{timing: timing}, true
+}
+
+// timeBased reports whether a table's positions are slot start times, which
+// block numbers and other counters never reach.
+func (t *boundsTranslator) timeBased(tb bounds.TableBounds) bool {
+	return tb.Min >= t.timing.GenesisTime.Unix() && tb.Max >= tb.Min
+}
+
+// translate returns tb in unit, false for tables that are not time-based.
+func (t *boundsTranslator) translate(tb bounds.TableBounds, unit string) (bounds.TableBounds, bool) {
+	if !t.timeBased(tb) {
+		return bounds.TableBounds{}, false
+	}
+
+	secondsPerSlot := int64(t.timing.SecondsPerSlot) //nolint:gosec // small config values
+	slotsPerEpoch := int64(t.timing.SlotsPerEpoch)   //nolint:gosec // small config values
+	genesis := t.timing.GenesisTime.Unix()
+
+	// The slot of the first position, and the one after the slot of the last
+	minSlot := (tb.Min - genesis) / secondsPerSlot
+	maxSlot := ceilDiv(tb.Max-genesis, secondsPerSlot)
+
+	switch unit {
+	case BoundsUnitSlot:
+		return bounds.TableBounds{Min: minSlot, Max: maxSlot}, true
+	case BoundsUnitEpoch:
+		return bounds.TableBounds{Min: minSlot / slotsPerEpoch, Max: ceilDiv(maxSlot, slotsPerEpoch)}, true
+	case BoundsUnitTimestamp:
+		return bounds.TableBounds{Min: genesis + minSlot*secondsPerSlot, Max: genesis + maxSlot*secondsPerSlot}, true
+	default:
+		return tb, true
+	}
+}
+
+// all returns tb with every translation that applies to it. A nil translator
+// leaves the raw positions only.
+func (t *boundsTranslator) all(tb bounds.TableBounds) TableBoundsUnits {
+	units := TableBoundsUnits{Min: tb.Min, Max: tb.Max}
+
+	if t == nil || !t.timeBased(tb) {
+		return units
+	}
+
+	slot, _ := t.translate(tb, BoundsUnitSlot)
+	epoch, _ := t.translate(tb, BoundsUnitEpoch)
+	timestamp, _ := t.translate(tb, BoundsUnitTimestamp)
+
+	units.Slot, units.Epoch, units.Timestamp = &slot, &epoch, &timestamp
+
+	return units
+}
+
+// translateBounds returns tables in unit. Tables that are not time-based are
+// left out of slot, epoch and timestamp bounds.
+func translateBounds(tables map[string]bounds.TableBounds, t *boundsTranslator, unit string) any {
+	if unit == BoundsUnitAll {
+		result := make(map[string]TableBoundsUnits, len(tables))
+		for table, tb := range tables {
+			result[table] = t.all(tb)
+		}
+
+		return result
+	}
+
+	result := make(map[string]bounds.TableBounds, len(tables))
+
+	for table, tb := range tables {
+		if translated, ok := t.translate(tb, unit); ok {
+			result[table] = translated
+		}
+	}
+
+	return result
+}
+
+// parseBoundsUnit validates the ?unit= query parameter.
+func parseBoundsUnit(unit string) (string, error) {
+	switch unit {
+	case "":
+		return BoundsUnitPosition, nil
+	case BoundsUnitPosition, BoundsUnitSlot, BoundsUnitEpoch, BoundsUnitTimestamp, BoundsUnitAll:
+		return unit, nil
+	default:
+		return "", fmt.Errorf("invalid unit %q: must be position, slot, epoch, timestamp or all", unit)
+	}
+}
+
+// ceilDiv divides positive a by b, rounding up.
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
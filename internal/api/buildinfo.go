@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*BuildInfoHandler)(nil)
+
+// BuildInfoHandler handles GET /admin/v1/buildinfo requests.
+type BuildInfoHandler struct {
+	info   version.BuildInfo
+	logger logrus.FieldLogger
+}
+
+// NewBuildInfoHandler creates a new build info handler.
+// Build info is read once since it cannot change while the process runs.
+func NewBuildInfoHandler(logger logrus.FieldLogger) *BuildInfoHandler {
+	return &BuildInfoHandler{
+		info:   version.GetBuildInfo(),
+		logger: logger.WithField("handler", "buildinfo"),
+	}
+}
+
+// ServeHTTP returns the module build info of the running binary.
+func (h *BuildInfoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(h.info); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
@@ -4,12 +4,23 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"hash/fnv"
+	"maps"
+	"math"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
 	"github.com/sirupsen/logrus"
 )
 
@@ -32,6 +43,21 @@ type NetworkInfo struct {
 	Forks        Forks               `json:"forks"`
 	ServiceUrls  map[string]string   `json:"service_urls"`            // Map of service name to URL
 	BlobSchedule []BlobScheduleEntry `json:"blob_schedule,omitempty"` // Optional blob schedule
+	Aliases      []string            `json:"aliases,omitempty"`       // Former names that resolve to this network
+	Proxy        ProxyInfo           `json:"proxy"`                   // How to query the network's tables
+}
+
+// CachePolicyUpstream is the cache policy class of proxied table responses,
+// which keep the Cache-Control header set by the upstream CBT API.
+const CachePolicyUpstream = "upstream"
+
+// ProxyInfo describes how to query a network's tables through the proxy.
+type ProxyInfo struct {
+	BasePath            string                        `json:"base_path"`                       // Tables are queried at {base_path}/{table}
+	SlotTransform       string                        `json:"slot_transform"`                  // Whether slot_* filters are rewritten: "transform" or "passthrough"
+	SlotTransformTables map[string]string             `json:"slot_transform_tables,omitempty"` // Tables whose mode differs from slot_transform
+	CachePolicy         string                        `json:"cache_policy"`                    // Cache policy class of table responses
+	Tables              map[string]bounds.TableBounds `json:"tables,omitempty"`                // Bounds of the tables with data
 }
 
 // Forks contains fork information for a network (API response format with snake_case).
@@ -40,11 +66,21 @@ type Forks struct {
 	Execution map[string]ExecutionFork `json:"execution,omitempty"` // Map of execution fork name to fork info
 }
 
+// Fork statuses at response time.
+const (
+	ForkStatusActive  = "active"
+	ForkStatusPending = "pending"
+)
+
 // ConsensusFork represents a single consensus fork with epoch and minimum client versions (API response format with snake_case).
+// The activation fields are computed from the network's wallclock and left out when it has none.
 type ConsensusFork struct {
 	Epoch             int64             `json:"epoch"`
 	Timestamp         int64             `json:"timestamp,omitempty"`
 	MinClientVersions map[string]string `json:"min_client_versions,omitempty"` // Map of client name to version
+	ActivationSlot    *uint64           `json:"activation_slot,omitempty"`     // First slot of the fork epoch
+	ActivationTime    *int64            `json:"activation_time,omitempty"`     // Unix start time of activation_slot
+	Status            string            `json:"status,omitempty"`              // "active" or "pending" at response time
 }
 
 // ExecutionFork represents an execution layer fork with block number and timestamp.
@@ -70,21 +106,35 @@ type Feature struct {
 
 // ConfigHandler handles /api/v1/config requests.
 type ConfigHandler struct {
-	config   *config.Config
-	provider cartographoor.Provider
-	logger   logrus.FieldLogger
+	config        *config.Config
+	provider      cartographoor.Provider
+	bounds        bounds.Provider
+	slotTransform *slottransform.Service
+	wallclock     *wallclock.Service
+	preview       *previewGate
+	logger        logrus.FieldLogger
 }
 
-// NewConfigHandler creates a new config API handler.
+// NewConfigHandler creates a new config API handler. boundsProvider,
+// slotTransform and wallclockSvc may be nil, in which case table bounds are
+// left out, slot filters are reported as transformed and forks carry no
+// activation slot, time or status.
 func NewConfigHandler(
 	logger logrus.FieldLogger,
 	cfg *config.Config,
 	provider cartographoor.Provider,
+	boundsProvider bounds.Provider,
+	slotTransform *slottransform.Service,
+	wallclockSvc *wallclock.Service,
 ) *ConfigHandler {
 	return &ConfigHandler{
-		config:   cfg,
-		provider: provider,
-		logger:   logger.WithField("handler", "config"),
+		config:        cfg,
+		provider:      provider,
+		bounds:        boundsProvider,
+		slotTransform: slotTransform,
+		wallclock:     wallclockSvc,
+		preview:       newPreviewGate(cfg.Preview),
+		logger:        logger.WithField("handler", "config"),
 	}
 }
 
@@ -92,20 +142,36 @@ func NewConfigHandler(
 func (h *ConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Only allow GET requests
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		httperr.Write(w, r, http.StatusMethodNotAllowed, httperr.CodeMethodNotAllowed, "method not allowed")
 
 		return
 	}
 
-	// Get config data
-	response := h.GetConfigData(r.Context())
+	// Get config data, including hidden networks for preview requests and
+	// features rolled out to this client
+	preview := h.PreviewAllowed(r)
+
+	response := ConfigResponse{
+		Networks: h.buildNetworks(r.Context(), preview),
+		Features: h.buildFeatures(r.Context(), r.Header.Get(config.ClientIDHeaderName)),
+	}
+
+	if preview {
+		// Never let shared caches store the preview variant
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+
+	if h.hasRollouts(r.Context()) {
+		// Features differ per client while a rollout is in progress
+		w.Header().Add("Vary", config.ClientIDHeaderName)
+	}
 
 	// Set headers.
 	w.Header().Set("Content-Type", "application/json")
 
 	// Encode response
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "failed to encode response")
 
 		return
 	}
@@ -113,19 +179,62 @@ func (h *ConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // GetConfigData returns the config data structure for both API and frontend use.
 // This ensures both endpoints use the same logic and return consistent data.
+// Hidden networks are excluded, and features still rolling out are disabled
+// on their rollout networks, as no client ID is known.
 func (h *ConfigHandler) GetConfigData(ctx context.Context) ConfigResponse {
 	return ConfigResponse{
-		Networks: h.buildNetworks(ctx),
-		Features: h.buildFeatures(ctx),
+		Networks: h.buildNetworks(ctx, false),
+		Features: h.buildFeatures(ctx, ""),
+	}
+}
+
+// GetPreviewConfigData returns the config data including hidden networks,
+// for requests that passed PreviewAllowed.
+func (h *ConfigHandler) GetPreviewConfigData(ctx context.Context) ConfigResponse {
+	return ConfigResponse{
+		Networks: h.buildNetworks(ctx, true),
+		Features: h.buildFeatures(ctx, ""),
+	}
+}
+
+// PreviewAllowed reports whether r carries a valid preview token and may see hidden networks.
+func (h *ConfigHandler) PreviewAllowed(r *http.Request) bool {
+	return h.preview.allowed(r)
+}
+
+// PreviewCookie returns the cookie to set when r carries a valid preview token
+// in the ?preview= query parameter, or nil.
+func (h *ConfigHandler) PreviewCookie(r *http.Request) *http.Cookie {
+	return h.preview.cookie(r)
+}
+
+// NetworksFileChanged returns a channel closed when the networks of
+// networks_file change, nil without a networks file.
+func (h *ConfigHandler) NetworksFileChanged() <-chan struct{} {
+	return h.config.NetworksFileChanged()
+}
+
+// HiddenNetworks returns the names of enabled networks that are hidden.
+func (h *ConfigHandler) HiddenNetworks(ctx context.Context) map[string]bool {
+	hidden := make(map[string]bool)
+
+	for name, net := range config.BuildMergedNetworkList(ctx, h.logger, h.config, h.provider) {
+		if net.IsHidden() {
+			hidden[name] = true
+		}
 	}
+
+	return hidden
 }
 
 // buildNetworks converts config.NetworkConfig to NetworkInfo slice.
 // Uses merged NetworkConfig which already has cartographoor + config.yaml overlay applied.
-// Only returns enabled networks.
-func (h *ConfigHandler) buildNetworks(ctx context.Context) []NetworkInfo {
+// Only returns enabled networks the request's tenant and API key may see.
+func (h *ConfigHandler) buildNetworks(ctx context.Context, includeHidden bool) []NetworkInfo {
 	// Build merged network list (cartographoor base + config.yaml overrides)
 	mergedNetworks := config.BuildMergedNetworkList(ctx, h.logger, h.config, h.provider)
+	tenant := tenancy.FromContext(ctx)
+	identity, keyed := auth.IdentityFromContext(ctx)
 
 	// Convert to NetworkInfo slice (only enabled networks)
 	networks := make([]NetworkInfo, 0, len(mergedNetworks))
@@ -135,6 +244,21 @@ func (h *ConfigHandler) buildNetworks(ctx context.Context) []NetworkInfo {
 			continue
 		}
 
+		// Skip soft-launched networks unless previewing
+		if net.IsHidden() && !includeHidden {
+			continue
+		}
+
+		// Skip networks the request's tenant doesn't show
+		if !tenant.ShowsNetwork(net.Name) {
+			continue
+		}
+
+		// Skip networks the request's API key is not scoped to
+		if keyed && !identity.AllowsNetwork(net.Name) {
+			continue
+		}
+
 		// Use merged NetworkConfig values (already has cartographoor + config.yaml)
 		displayName := net.DisplayName
 
@@ -159,9 +283,10 @@ func (h *ConfigHandler) buildNetworks(ctx context.Context) []NetworkInfo {
 
 		// Get forks, serviceUrls, and blobSchedule from cartographoor if available
 		if h.provider != nil {
-			if cartNet, exists := h.provider.GetNetwork(ctx, net.Name); exists {
+			if cartNet, err := h.provider.GetNetwork(ctx, net.Name); err == nil {
 				// Transform cartographoor.Forks to API Forks
 				forks = transformForks(cartNet.Forks)
+				h.addForkActivations(forks, net.Name, time.Now())
 				// Copy serviceUrls from cartographoor
 				serviceUrls = cartNet.ServiceUrls
 				// Transform blobSchedule from cartographoor
@@ -187,6 +312,8 @@ func (h *ConfigHandler) buildNetworks(ctx context.Context) []NetworkInfo {
 			Forks:        forks,
 			ServiceUrls:  serviceUrls,
 			BlobSchedule: blobSchedule,
+			Aliases:      net.Aliases,
+			Proxy:        h.buildProxy(ctx, net.Name),
 		})
 	}
 
@@ -198,15 +325,91 @@ func (h *ConfigHandler) buildNetworks(ctx context.Context) []NetworkInfo {
 	return networks
 }
 
+// buildProxy describes how to query the tables of a network.
+func (h *ConfigHandler) buildProxy(ctx context.Context, network string) ProxyInfo {
+	info := ProxyInfo{
+		BasePath:      "/api/v1/" + network,
+		SlotTransform: h.slotTransform.Mode(network, ""),
+		CachePolicy:   CachePolicyUpstream,
+	}
+
+	if h.bounds == nil {
+		return info
+	}
+
+	// Stale bounds come with the data and are still the best known
+	data, err := h.bounds.GetBounds(ctx, network)
+	if data == nil {
+		if err != nil {
+			h.logger.WithError(err).WithField("network", network).Debug("No bounds for network proxy info")
+		}
+
+		return info
+	}
+
+	info.Tables = data.Tables
+
+	for table := range data.Tables {
+		if mode := h.slotTransform.Mode(network, table); mode != info.SlotTransform {
+			if info.SlotTransformTables == nil {
+				info.SlotTransformTables = make(map[string]string)
+			}
+
+			info.SlotTransformTables[table] = mode
+		}
+	}
+
+	return info
+}
+
 // buildFeatures converts config features slice to API response array.
-func (h *ConfigHandler) buildFeatures(_ context.Context) []Feature {
-	features := make([]Feature, 0, len(h.config.Features))
+// Networks where clientID is outside a feature's rollout, or that have not
+// reached a feature's fork or epoch yet, are added to its disabled networks.
+func (h *ConfigHandler) buildFeatures(ctx context.Context, clientID string) []Feature {
+	settings := tenancy.FromContext(ctx).Features(h.config.Features)
+	features := make([]Feature, 0, len(settings))
+
+	// Networks are only needed to evaluate fork and epoch requirements
+	var (
+		networks     map[string]*cartographoor.Network
+		networkNames []string
+	)
+
+	if hasRequirements(settings) {
+		networks = h.requirementNetworks(ctx)
+		networkNames = slices.Sorted(maps.Keys(networks))
+	}
 
-	for _, feature := range h.config.Features {
+	now := time.Now()
+
+	for _, feature := range settings {
 		// Copy disabled_networks slice to avoid sharing underlying array
 		disabledNetworks := make([]string, len(feature.DisabledNetworks))
 		copy(disabledNetworks, feature.DisabledNetworks)
 
+		rolloutNetworks := make([]string, 0, len(feature.Rollout))
+		for network := range feature.Rollout {
+			rolloutNetworks = append(rolloutNetworks, network)
+		}
+
+		sort.Strings(rolloutNetworks)
+
+		for _, network := range rolloutNetworks {
+			if !inRollout(feature.Path, clientID, feature.RolloutPercent(network)) &&
+				!slices.Contains(disabledNetworks, network) {
+				disabledNetworks = append(disabledNetworks, network)
+			}
+		}
+
+		if feature.HasRequirements() {
+			for _, network := range networkNames {
+				if !requirementMet(feature.Requirement(network), networks[network], now) &&
+					!slices.Contains(disabledNetworks, network) {
+					disabledNetworks = append(disabledNetworks, network)
+				}
+			}
+		}
+
 		features = append(features, Feature{
 			Path:             feature.Path,
 			DisabledNetworks: disabledNetworks,
@@ -221,6 +424,115 @@ func (h *ConfigHandler) buildFeatures(_ context.Context) []Feature {
 	return features
 }
 
+// hasRollouts reports whether any feature of the request's tenant is rolled
+// out to only part of the clients.
+func (h *ConfigHandler) hasRollouts(ctx context.Context) bool {
+	for _, feature := range tenancy.FromContext(ctx).Features(h.config.Features) {
+		for _, percent := range feature.Rollout {
+			if percent < 100 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasRequirements reports whether any of features waits for a fork or epoch.
+func hasRequirements(features []config.FeatureSettings) bool {
+	return slices.ContainsFunc(features, func(f config.FeatureSettings) bool {
+		return f.HasRequirements()
+	})
+}
+
+// requirementNetworks returns every listed network with its cartographoor
+// data, nil for networks cartographoor doesn't know.
+func (h *ConfigHandler) requirementNetworks(ctx context.Context) map[string]*cartographoor.Network {
+	var cartNetworks map[string]*cartographoor.Network
+	if h.provider != nil {
+		cartNetworks = h.provider.GetNetworks(ctx)
+	}
+
+	networks := make(map[string]*cartographoor.Network)
+
+	for name, net := range config.BuildMergedNetworkList(ctx, h.logger, h.config, h.provider) {
+		if net.Enabled != nil && !*net.Enabled {
+			continue
+		}
+
+		networks[name] = cartNetworks[name]
+	}
+
+	return networks
+}
+
+// requirementMet reports whether net has reached the fork and epoch of rule
+// at now. Without fork data, a network never meets a requirement.
+func requirementMet(rule config.FeatureNetworkRule, net *cartographoor.Network, now time.Time) bool {
+	if rule.IsZero() {
+		return true
+	}
+
+	if net == nil {
+		return false
+	}
+
+	epoch, ok := currentEpoch(net, now)
+	if !ok {
+		return false
+	}
+
+	if rule.RequiresFork != "" {
+		fork, scheduled := net.Forks.Consensus[rule.RequiresFork]
+		if !scheduled || epoch < fork.Epoch {
+			return false
+		}
+	}
+
+	return rule.MinEpoch == nil || epoch >= *rule.MinEpoch
+}
+
+// currentEpoch returns the epoch of net at now, false before genesis.
+func currentEpoch(net *cartographoor.Network, now time.Time) (int64, bool) {
+	secondsPerSlot, slotsPerEpoch := int64(net.SecondsPerSlot), int64(net.SlotsPerEpoch) //nolint:gosec // small config values
+	if secondsPerSlot == 0 {
+		secondsPerSlot = 12
+	}
+
+	if slotsPerEpoch == 0 {
+		slotsPerEpoch = 32
+	}
+
+	// Slot 0 starts after the genesis delay, as in the wallclock service
+	elapsed := now.Unix() - (net.GenesisTime + net.GenesisDelay)
+	if net.GenesisTime == 0 || elapsed < 0 {
+		return 0, false
+	}
+
+	return elapsed / (secondsPerSlot * slotsPerEpoch), true
+}
+
+// inRollout reports whether clientID falls within the first percent of 100
+// buckets for path. Buckets are salted with the feature path so clients land
+// in independent buckets per feature, and raising percent only adds clients.
+// Clients without an ID are only included at 100 percent.
+func inRollout(path, clientID string, percent int) bool {
+	if percent >= 100 {
+		return true
+	}
+
+	if clientID == "" || percent <= 0 {
+		return false
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(path))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(clientID))
+
+	return int(hash.Sum32()%100) < percent
+}
+
 // transformForks converts cartographoor.Forks to API Forks format (for snake_case output).
 func transformForks(cartForks cartographoor.Forks) Forks {
 	consensus := make(map[string]ConsensusFork, len(cartForks.Consensus))
@@ -249,6 +561,49 @@ func transformForks(cartForks cartographoor.Forks) Forks {
 	}
 }
 
+// addForkActivations fills in the activation slot, time and status of every
+// consensus fork from the network's wallclock, the same timing the proxy uses
+// for slot conversions. Forks are left as they are without a wallclock.
+func (h *ConfigHandler) addForkActivations(forks Forks, network string, now time.Time) {
+	if h.wallclock == nil {
+		return
+	}
+
+	wc := h.wallclock.GetWallclock(network)
+	timing, ok := h.wallclock.GetConfig(network)
+
+	if wc == nil || !ok {
+		return
+	}
+
+	// Later slots overflow time.Duration, such forks are not scheduled yet
+	maxSlot := uint64(math.MaxInt64/int64(time.Second)) / timing.SecondsPerSlot
+
+	for name, fork := range forks.Consensus {
+		if fork.Epoch < 0 || uint64(fork.Epoch) > maxSlot/timing.SlotsPerEpoch { //nolint:gosec // not negative
+			fork.Status = ForkStatusPending
+			forks.Consensus[name] = fork
+
+			continue
+		}
+
+		slot := uint64(fork.Epoch) * timing.SlotsPerEpoch //nolint:gosec // not negative
+		activationSlot := wc.Slots().FromNumber(slot)
+		start := activationSlot.TimeWindow().Start()
+		activationTime := start.Unix()
+
+		fork.ActivationSlot = &slot
+		fork.ActivationTime = &activationTime
+
+		fork.Status = ForkStatusPending
+		if !now.Before(start) {
+			fork.Status = ForkStatusActive
+		}
+
+		forks.Consensus[name] = fork
+	}
+}
+
 // transformBlobSchedule converts cartographoor.BlobScheduleEntry to API BlobScheduleEntry format (for snake_case output).
 func transformBlobSchedule(cartSchedule []cartographoor.BlobScheduleEntry) []BlobScheduleEntry {
 	if cartSchedule == nil {
@@ -3,19 +3,30 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
 )
 
 func TestConfigHandler_ServeHTTP(t *testing.T) {
@@ -140,7 +151,7 @@ func TestConfigHandler_ServeHTTP(t *testing.T) {
 					net := network // Capture for closure
 					mock.EXPECT().
 						GetNetwork(gomock.Any(), name).
-						Return(net, true).
+						Return(net, nil).
 						AnyTimes()
 				}
 
@@ -154,7 +165,7 @@ func TestConfigHandler_ServeHTTP(t *testing.T) {
 
 			logger := logrus.New()
 			logger.SetOutput(io.Discard)
-			handler := NewConfigHandler(logger, cfg, mockProvider)
+			handler := NewConfigHandler(logger, cfg, mockProvider, nil, nil, nil)
 
 			// Create request
 			req := httptest.NewRequest(tt.method, "/api/v1/config", http.NoBody)
@@ -274,12 +285,12 @@ func TestConfigHandler_buildNetworks(t *testing.T) {
 				// Mock GetNetwork for any network (including config-only ones)
 				mock.EXPECT().
 					GetNetwork(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, name string) (*cartographoor.Network, bool) {
+					DoAndReturn(func(ctx context.Context, name string) (*cartographoor.Network, error) {
 						if net, exists := tt.cartoNetworks[name]; exists {
-							return net, true
+							return net, nil
 						}
 
-						return nil, false
+						return nil, errs.ErrNotFound
 					}).
 					AnyTimes()
 
@@ -300,7 +311,7 @@ func TestConfigHandler_buildNetworks(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			result := handler.buildNetworks(ctx)
+			result := handler.buildNetworks(ctx, false)
 
 			// Verify network names match expected
 			actualNames := make([]string, len(result))
@@ -351,7 +362,7 @@ func TestConfigHandler_buildFeatures(t *testing.T) {
 				logger: logger,
 			}
 
-			result := handler.buildFeatures(context.Background())
+			result := handler.buildFeatures(context.Background(), "")
 
 			assert.Len(t, result, tt.expected)
 
@@ -624,7 +635,7 @@ func TestConfigHandler_ForksAndBlobScheduleInResponse(t *testing.T) {
 		AnyTimes()
 	mock.EXPECT().
 		GetNetwork(gomock.Any(), "mainnet").
-		Return(cartoNetworks["mainnet"], true).
+		Return(cartoNetworks["mainnet"], nil).
 		AnyTimes()
 
 	cfg := &config.Config{
@@ -634,7 +645,7 @@ func TestConfigHandler_ForksAndBlobScheduleInResponse(t *testing.T) {
 
 	logger := logrus.New()
 	logger.SetOutput(io.Discard)
-	handler := NewConfigHandler(logger, cfg, mock)
+	handler := NewConfigHandler(logger, cfg, mock, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -676,3 +687,403 @@ func TestConfigHandler_ForksAndBlobScheduleInResponse(t *testing.T) {
 	assert.Equal(t, int64(1750000000), network.BlobSchedule[1].Timestamp)
 	assert.Equal(t, int64(15), network.BlobSchedule[1].MaxBlobsPerBlock)
 }
+
+func TestConfigHandler_ForkActivations(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	wallclockSvc := wallclock.New(logger)
+	require.NoError(t, wallclockSvc.AddNetwork(wallclock.NetworkConfig{
+		Name:        "mainnet",
+		GenesisTime: time.Unix(1606824023, 0),
+	}))
+
+	handler := NewConfigHandler(logger, &config.Config{}, nil, nil, nil, wallclockSvc)
+
+	forks := transformForks(cartographoor.Forks{
+		Consensus: map[string]cartographoor.ConsensusFork{
+			"phase0": {Epoch: 0},
+			"altair": {Epoch: 74240},
+			"gloas":  {Epoch: math.MaxInt64},
+		},
+	})
+
+	// One second before altair activates
+	handler.addForkActivations(forks, "mainnet", time.Unix(1606824023+74240*32*12-1, 0))
+
+	phase0 := forks.Consensus["phase0"]
+	require.NotNil(t, phase0.ActivationSlot)
+	assert.Equal(t, uint64(0), *phase0.ActivationSlot)
+	assert.Equal(t, int64(1606824023), *phase0.ActivationTime)
+	assert.Equal(t, ForkStatusActive, phase0.Status)
+
+	altair := forks.Consensus["altair"]
+	require.NotNil(t, altair.ActivationSlot)
+	assert.Equal(t, uint64(2375680), *altair.ActivationSlot)
+	assert.Equal(t, int64(1635332183), *altair.ActivationTime)
+	assert.Equal(t, ForkStatusPending, altair.Status)
+
+	// Unscheduled forks have no activation time
+	gloas := forks.Consensus["gloas"]
+	assert.Nil(t, gloas.ActivationSlot)
+	assert.Nil(t, gloas.ActivationTime)
+	assert.Equal(t, ForkStatusPending, gloas.Status)
+
+	// Networks without a wallclock are left as they are
+	forks = transformForks(cartographoor.Forks{
+		Consensus: map[string]cartographoor.ConsensusFork{"phase0": {Epoch: 0}},
+	})
+	handler.addForkActivations(forks, "sepolia", time.Now())
+
+	assert.Empty(t, forks.Consensus["phase0"].Status)
+}
+
+func TestConfigHandler_HiddenNetworks(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	hidden := true
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "devnet-9", TargetURL: "http://devnet", Hidden: &hidden},
+		},
+		Preview: config.PreviewConfig{Tokens: []string{"preview-token-0123456789"}},
+	}
+	require.NoError(t, cfg.Preview.Validate())
+
+	handler := NewConfigHandler(logger, cfg, nil, nil, nil, nil)
+
+	networkNames := func(req *http.Request) ([]string, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp ConfigResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+		names := make([]string, 0, len(resp.Networks))
+		for _, network := range resp.Networks {
+			names = append(names, network.Name)
+		}
+
+		return names, rec
+	}
+
+	// Public requests don't see hidden networks
+	names, rec := networkNames(httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody))
+	assert.Equal(t, []string{"mainnet"}, names)
+	assert.Empty(t, rec.Header().Get("Cache-Control"))
+
+	// Wrong token
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+	req.Header.Set("X-Lab-Preview-Token", "not-the-preview-token")
+	names, _ = networkNames(req)
+	assert.Equal(t, []string{"mainnet"}, names)
+
+	// Header token
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+	req.Header.Set("X-Lab-Preview-Token", "preview-token-0123456789")
+	names, rec = networkNames(req)
+	assert.Equal(t, []string{"devnet-9", "mainnet"}, names)
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+
+	// Cookie token
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: "lab_preview", Value: "preview-token-0123456789"})
+	names, _ = networkNames(req)
+	assert.Equal(t, []string{"devnet-9", "mainnet"}, names)
+
+	// Query token also yields a cookie to remember it
+	req = httptest.NewRequest(http.MethodGet, "/?preview=preview-token-0123456789", http.NoBody)
+	assert.True(t, handler.PreviewAllowed(req))
+
+	cookie := handler.PreviewCookie(req)
+	require.NotNil(t, cookie)
+	assert.Equal(t, "lab_preview", cookie.Name)
+	assert.True(t, cookie.HttpOnly)
+
+	assert.Equal(t, map[string]bool{"devnet-9": true}, handler.HiddenNetworks(context.Background()))
+}
+
+func TestConfigHandler_Tenants(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "sepolia", TargetURL: "http://sepolia"},
+		},
+		Features: []config.FeatureSettings{
+			{Path: "/ethereum/epochs"},
+			{Path: "/ethereum/slots", Rollout: map[string]int{"mainnet": 50}},
+		},
+		Tenants: []config.TenantConfig{{
+			Name:     "acme",
+			Hosts:    []string{"lab.acme.io"},
+			Networks: []string{"mainnet"},
+			Features: []config.FeatureSettings{
+				{Path: "/ethereum/slots"},
+				{Path: "/acme/dashboard", DisabledNetworks: []string{"sepolia"}},
+			},
+		}},
+	}
+
+	tenants, err := tenancy.NewResolver(cfg.Tenants, nil)
+	require.NoError(t, err)
+
+	handler := NewConfigHandler(logger, cfg, nil, nil, nil, nil)
+
+	serve := func(host string) (ConfigResponse, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+		req.Host = host
+
+		if tenant, _ := tenants.Resolve(req); tenant != nil {
+			req = req.WithContext(tenancy.ContextWithTenant(req.Context(), tenant))
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp ConfigResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+		return resp, rec
+	}
+
+	resp, rec := serve("lab.ethpandaops.io")
+	assert.Len(t, resp.Networks, 2)
+	assert.Equal(t, []Feature{
+		{Path: "/ethereum/epochs", DisabledNetworks: []string{}},
+		{Path: "/ethereum/slots", DisabledNetworks: []string{"mainnet"}},
+	}, resp.Features)
+	assert.Equal(t, config.ClientIDHeaderName, rec.Header().Get("Vary"))
+
+	// The tenant sees its networks, and its features replace and extend the global ones
+	resp, rec = serve("lab.acme.io")
+	require.Len(t, resp.Networks, 1)
+	assert.Equal(t, "mainnet", resp.Networks[0].Name)
+	assert.Equal(t, []Feature{
+		{Path: "/acme/dashboard", DisabledNetworks: []string{"sepolia"}},
+		{Path: "/ethereum/epochs", DisabledNetworks: []string{}},
+		{Path: "/ethereum/slots", DisabledNetworks: []string{}},
+	}, resp.Features)
+	assert.Empty(t, rec.Header().Get("Vary"))
+}
+
+func TestConfigHandler_ScopedKey(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "sepolia", TargetURL: "http://sepolia"},
+		},
+	}
+
+	handler := NewConfigHandler(logger, cfg, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+	req = req.WithContext(auth.WithIdentity(req.Context(), auth.Identity{KeyID: "k1", Networks: []string{"sepolia"}}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp ConfigResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+	// Only the networks the API key is scoped to are listed
+	require.Len(t, resp.Networks, 1)
+	assert.Equal(t, "sepolia", resp.Networks[0].Name)
+}
+
+func TestConfigHandler_ProxyInfo(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	ctrl := gomock.NewController(t)
+	boundsProvider := boundsmocks.NewMockProvider(ctrl)
+	boundsProvider.EXPECT().GetBounds(gomock.Any(), "mainnet").Return(&bounds.BoundsData{
+		Tables: map[string]bounds.TableBounds{
+			"fct_block":      {Min: 100, Max: 200},
+			"fct_slot_index": {Min: 0, Max: 200},
+		},
+	}, nil)
+	boundsProvider.EXPECT().GetBounds(gomock.Any(), "sepolia").Return(nil, errs.ErrNotFound)
+
+	slotCfg := config.SlotTransformConfig{
+		SlotTransformPolicy: config.SlotTransformPolicy{
+			Networks: map[string]config.SlotTransformNetworkPolicy{
+				"mainnet": {Tables: map[string]string{"fct_slot_index": config.SlotTransformModePassthrough}},
+				"sepolia": {Mode: config.SlotTransformModePassthrough},
+			},
+		},
+	}
+	require.NoError(t, slotCfg.Validate())
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "sepolia", TargetURL: "http://sepolia"},
+		},
+	}
+
+	handler := NewConfigHandler(logger, cfg, nil, boundsProvider, slottransform.New(logger, slotCfg, nil), nil)
+	data := handler.GetConfigData(context.Background())
+	require.Len(t, data.Networks, 2)
+
+	assert.Equal(t, ProxyInfo{
+		BasePath:            "/api/v1/mainnet",
+		SlotTransform:       config.SlotTransformModeTransform,
+		SlotTransformTables: map[string]string{"fct_slot_index": config.SlotTransformModePassthrough},
+		CachePolicy:         CachePolicyUpstream,
+		Tables: map[string]bounds.TableBounds{
+			"fct_block":      {Min: 100, Max: 200},
+			"fct_slot_index": {Min: 0, Max: 200},
+		},
+	}, data.Networks[0].Proxy)
+
+	assert.Equal(t, ProxyInfo{
+		BasePath:      "/api/v1/sepolia",
+		SlotTransform: config.SlotTransformModePassthrough,
+		CachePolicy:   CachePolicyUpstream,
+	}, data.Networks[1].Proxy)
+}
+
+func TestConfigHandler_FeatureRollout(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := &config.Config{
+		Features: []config.FeatureSettings{
+			{Path: "/ethereum/live-slots", DisabledNetworks: []string{"holesky"}, Rollout: map[string]int{"mainnet": 25, "sepolia": 100}},
+			{Path: "/ethereum/block-production"},
+		},
+	}
+
+	handler := NewConfigHandler(logger, cfg, nil, nil, nil, nil)
+
+	disabledFor := func(clientID string) ([]string, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+		if clientID != "" {
+			req.Header.Set(config.ClientIDHeaderName, clientID)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp ConfigResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Len(t, resp.Features, 2)
+		assert.Empty(t, resp.Features[0].DisabledNetworks, "features without a rollout are unaffected")
+
+		return resp.Features[1].DisabledNetworks, rec
+	}
+
+	// Clients without an ID are left out of partial rollouts
+	disabled, rec := disabledFor("")
+	assert.Equal(t, []string{"holesky", "mainnet"}, disabled)
+	assert.Equal(t, config.ClientIDHeaderName, rec.Header().Get("Vary"))
+
+	// Roughly a quarter of the clients get the feature, each consistently
+	enabled := 0
+
+	for i := range 1000 {
+		clientID := fmt.Sprintf("client-%d", i)
+
+		first, _ := disabledFor(clientID)
+		second, _ := disabledFor(clientID)
+		assert.Equal(t, first, second)
+
+		if !slices.Contains(first, "mainnet") {
+			enabled++
+		}
+	}
+
+	assert.InDelta(t, 250, enabled, 60)
+
+	// The frontend config has no client ID
+	data := handler.GetConfigData(context.Background())
+	assert.Equal(t, []string{"holesky", "mainnet"}, data.Features[1].DisabledNetworks)
+}
+
+func TestConfigHandler_FeatureRequirements(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	now := time.Now().Unix()
+	epochSeconds := int64(12 * 32)
+
+	// Mainnet is 100 epochs past genesis with electra at epoch 50, devnet
+	// hasn't scheduled electra
+	networks := map[string]*cartographoor.Network{
+		"mainnet": {
+			Name:        "mainnet",
+			Status:      cartographoor.NetworkStatusActive,
+			GenesisTime: now - 100*epochSeconds,
+			Forks: cartographoor.Forks{Consensus: map[string]cartographoor.ConsensusFork{
+				"electra": {Epoch: 50},
+				"fulu":    {Epoch: 200},
+			}},
+		},
+		"devnet-1": {
+			Name:        "devnet-1",
+			Status:      cartographoor.NetworkStatusActive,
+			GenesisTime: now - 10*epochSeconds,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	provider := cartomocks.NewMockProvider(ctrl)
+	provider.EXPECT().GetActiveNetworks(gomock.Any()).Return(networks).AnyTimes()
+	provider.EXPECT().GetNetworks(gomock.Any()).Return(networks).AnyTimes()
+
+	minEpoch := int64(5)
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{{Name: "local", TargetURL: "http://local"}},
+		Features: []config.FeatureSettings{
+			{Path: "/a/electra", RequiresFork: "electra"},
+			{Path: "/b/fulu", RequiresFork: "fulu", Networks: map[string]config.FeatureNetworkRule{
+				"devnet-1": {MinEpoch: &minEpoch},
+			}},
+			{Path: "/c/plain"},
+		},
+	}
+
+	handler := NewConfigHandler(logger, cfg, provider, nil, nil, nil)
+
+	features := handler.buildFeatures(context.Background(), "")
+	require.Len(t, features, 3)
+
+	// Networks without fork data never meet a requirement
+	assert.Equal(t, []string{"devnet-1", "local"}, features[0].DisabledNetworks)
+	// Per-network rules replace requires_fork
+	assert.Equal(t, []string{"local", "mainnet"}, features[1].DisabledNetworks)
+	assert.Empty(t, features[2].DisabledNetworks)
+}
+
+func TestInRollout(t *testing.T) {
+	assert.True(t, inRollout("/a", "", 100))
+	assert.False(t, inRollout("/a", "", 99))
+	assert.False(t, inRollout("/a", "client", 0))
+
+	// Raising the percentage never drops a client
+	for i := range 200 {
+		clientID := fmt.Sprintf("client-%d", i)
+		included := false
+
+		for percent := 0; percent <= 100; percent++ {
+			in := inRollout("/a", clientID, percent)
+			if included {
+				assert.True(t, in, "client %s dropped at %d%%", clientID, percent)
+			}
+
+			included = in
+		}
+	}
+}
@@ -0,0 +1,134 @@
+//nolint:tagliatelle // superior snake-case yo.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/denylist"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// maxDenyListBody bounds POST /admin/v1/denylist request bodies.
+const maxDenyListBody = 4096
+
+// DenyListManager lists, adds and removes deny list entries.
+type DenyListManager interface {
+	Entries() []denylist.Entry
+	Add(ctx context.Context, cidr, reason string, ttl time.Duration) (*denylist.Entry, error)
+	Remove(ctx context.Context, cidr string) (bool, error)
+}
+
+// DenyListResponse is the response for GET /admin/v1/denylist.
+type DenyListResponse struct {
+	Entries []denylist.Entry `json:"entries"`
+}
+
+// DenyListAddRequest is the body of POST /admin/v1/denylist.
+type DenyListAddRequest struct {
+	CIDR   string `json:"cidr"`   // IP or CIDR range
+	Reason string `json:"reason"` // Operator note, not shown to blocked clients
+	TTL    string `json:"ttl"`    // Duration until the entry expires, e.g. "24h"; empty never expires
+}
+
+// DenyListHandler handles the /admin/v1/denylist endpoints.
+type DenyListHandler struct {
+	list   DenyListManager
+	logger logrus.FieldLogger
+}
+
+// NewDenyListHandler creates a new deny list handler.
+func NewDenyListHandler(list DenyListManager, logger logrus.FieldLogger) *DenyListHandler {
+	return &DenyListHandler{
+		list:   list,
+		logger: logger.WithField("handler", "deny_list"),
+	}
+}
+
+// List handles GET /admin/v1/denylist.
+func (h *DenyListHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, http.StatusOK, DenyListResponse{Entries: h.list.Entries()})
+}
+
+// Add handles POST /admin/v1/denylist.
+func (h *DenyListHandler) Add(w http.ResponseWriter, r *http.Request) {
+	var req DenyListAddRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDenyListBody)).Decode(&req); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid request body")
+
+		return
+	}
+
+	var ttl time.Duration
+
+	if req.TTL != "" {
+		parsed, err := time.ParseDuration(req.TTL)
+		if err != nil || parsed <= 0 {
+			httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "ttl must be a positive duration")
+
+			return
+		}
+
+		ttl = parsed
+	}
+
+	entry, err := h.list.Add(r.Context(), req.CIDR, req.Reason, ttl)
+
+	switch {
+	case errors.Is(err, denylist.ErrInvalidCIDR):
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, err.Error())
+
+		return
+	case err != nil && entry == nil:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to add deny list entry")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to add deny list entry")
+
+		return
+	case err != nil:
+		// Stored, but this instance picks it up on its next reload
+		requestid.Logger(r.Context(), h.logger).WithError(err).Warn("Failed to reload deny list")
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, entry)
+}
+
+// Remove handles DELETE /admin/v1/denylist?cidr=<ip or range>.
+func (h *DenyListHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	removed, err := h.list.Remove(r.Context(), r.URL.Query().Get("cidr"))
+
+	switch {
+	case errors.Is(err, denylist.ErrInvalidCIDR):
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, err.Error())
+
+		return
+	case err != nil && !removed:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to remove deny list entry")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to remove deny list entry")
+
+		return
+	case err != nil:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Warn("Failed to reload deny list")
+	case !removed:
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNotFound, "entry not found")
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DenyListHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
@@ -0,0 +1,78 @@
+//nolint:tagliatelle // superior snake-case yo.
+package api
+
+import (
+	"cmp"
+	"slices"
+)
+
+// NetworkForkSchedule is a network's consensus forks in activation order,
+// embedded into index.html as window.__FORKS__ so fork countdowns render on
+// first paint. Statuses are as of the time the page was built; clients count
+// down from activation_time.
+type NetworkForkSchedule struct {
+	Forks []ScheduledFork `json:"forks"`
+	Next  *ScheduledFork  `json:"next,omitempty"` // First pending fork, if any
+}
+
+// ScheduledFork is a consensus fork with its activation slot and time. Both
+// are left out for networks without a wallclock, where only the published
+// timestamp, if any, is known.
+type ScheduledFork struct {
+	Name           string  `json:"name"`
+	Epoch          int64   `json:"epoch"`
+	ActivationSlot *uint64 `json:"activation_slot,omitempty"`
+	ActivationTime *int64  `json:"activation_time,omitempty"` // Unix start time of activation_slot
+	Status         string  `json:"status,omitempty"`          // "active" or "pending" when the page was built
+}
+
+// ForkSchedule returns the fork schedule of every network in the response,
+// by network name. Networks without consensus forks are left out.
+func (c ConfigResponse) ForkSchedule() map[string]NetworkForkSchedule {
+	schedule := make(map[string]NetworkForkSchedule, len(c.Networks))
+
+	for _, network := range c.Networks {
+		if len(network.Forks.Consensus) == 0 {
+			continue
+		}
+
+		forks := make([]ScheduledFork, 0, len(network.Forks.Consensus))
+
+		for name, fork := range network.Forks.Consensus {
+			scheduled := ScheduledFork{
+				Name:           name,
+				Epoch:          fork.Epoch,
+				ActivationSlot: fork.ActivationSlot,
+				ActivationTime: fork.ActivationTime,
+				Status:         fork.Status,
+			}
+
+			// Without a wallclock, fall back to the timestamp cartographoor publishes
+			if scheduled.ActivationTime == nil && fork.Timestamp != 0 {
+				timestamp := fork.Timestamp
+				scheduled.ActivationTime = &timestamp
+			}
+
+			forks = append(forks, scheduled)
+		}
+
+		slices.SortFunc(forks, func(a, b ScheduledFork) int {
+			return cmp.Or(cmp.Compare(a.Epoch, b.Epoch), cmp.Compare(a.Name, b.Name))
+		})
+
+		entry := NetworkForkSchedule{Forks: forks}
+
+		for i := range forks {
+			if forks[i].Status == ForkStatusPending {
+				next := forks[i]
+				entry.Next = &next
+
+				break
+			}
+		}
+
+		schedule[network.Name] = entry
+	}
+
+	return schedule
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigResponse_ForkSchedule(t *testing.T) {
+	slot := func(v uint64) *uint64 { return &v }
+	unix := func(v int64) *int64 { return &v }
+
+	response := ConfigResponse{
+		Networks: []NetworkInfo{
+			{
+				Name: "mainnet",
+				Forks: Forks{
+					Consensus: map[string]ConsensusFork{
+						"fulu":    {Epoch: 411392, ActivationSlot: slot(13164544), ActivationTime: unix(1764798551), Status: ForkStatusPending},
+						"electra": {Epoch: 364032, ActivationSlot: slot(11649024), ActivationTime: unix(1746612311), Status: ForkStatusActive},
+						"gloas":   {Epoch: 500000, Status: ForkStatusPending},
+					},
+				},
+			},
+			{
+				Name: "devnet-1",
+				Forks: Forks{
+					Consensus: map[string]ConsensusFork{
+						"electra": {Epoch: 0, Timestamp: 1700000000},
+						"altair":  {Epoch: 0},
+					},
+				},
+			},
+			{Name: "empty"},
+		},
+	}
+
+	schedule := response.ForkSchedule()
+	require.Len(t, schedule, 2)
+
+	// Forks are in activation order, the first pending one is next
+	mainnet := schedule["mainnet"]
+	require.Len(t, mainnet.Forks, 3)
+	assert.Equal(t, "electra", mainnet.Forks[0].Name)
+	assert.Equal(t, "fulu", mainnet.Forks[1].Name)
+	assert.Equal(t, "gloas", mainnet.Forks[2].Name)
+	require.NotNil(t, mainnet.Next)
+	assert.Equal(t, "fulu", mainnet.Next.Name)
+	assert.Equal(t, uint64(13164544), *mainnet.Next.ActivationSlot)
+	assert.Equal(t, int64(1764798551), *mainnet.Next.ActivationTime)
+	assert.Nil(t, mainnet.Forks[2].ActivationTime)
+
+	// Without a wallclock, forks at the same epoch are ordered by name and
+	// fall back to the published timestamp
+	devnet := schedule["devnet-1"]
+	require.Len(t, devnet.Forks, 2)
+	assert.Equal(t, "altair", devnet.Forks[0].Name)
+	assert.Nil(t, devnet.Forks[0].ActivationTime)
+	assert.Equal(t, "electra", devnet.Forks[1].Name)
+	require.NotNil(t, devnet.Forks[1].ActivationTime)
+	assert.Equal(t, int64(1700000000), *devnet.Forks[1].ActivationTime)
+	assert.Nil(t, devnet.Next)
+
+	assert.NotContains(t, schedule, "empty")
+}
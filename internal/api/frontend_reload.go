@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*FrontendReloadHandler)(nil)
+
+// FrontendReloader reloads index.html and head.json of the served frontend.
+type FrontendReloader interface {
+	Reload(ctx context.Context) error
+}
+
+// FrontendReloadResponse is the response for POST /admin/v1/frontend/reload.
+type FrontendReloadResponse struct {
+	Status string `json:"status"`
+}
+
+// FrontendReloadHandler handles POST /admin/v1/frontend/reload requests, sent
+// by deploy hooks after head.json or index.html changed.
+type FrontendReloadHandler struct {
+	frontend FrontendReloader
+	logger   logrus.FieldLogger
+}
+
+// NewFrontendReloadHandler creates a new frontend reload handler.
+func NewFrontendReloadHandler(frontend FrontendReloader, logger logrus.FieldLogger) *FrontendReloadHandler {
+	return &FrontendReloadHandler{
+		frontend: frontend,
+		logger:   logger.WithField("handler", "frontend_reload"),
+	}
+}
+
+// ServeHTTP reloads the frontend route cache.
+func (h *FrontendReloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.frontend.Reload(r.Context()); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to reload frontend")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "failed to reload frontend")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(FrontendReloadResponse{Status: "reloaded"}); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
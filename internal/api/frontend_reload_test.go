@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reloaderFunc func(ctx context.Context) error
+
+func (f reloaderFunc) Reload(ctx context.Context) error { return f(ctx) }
+
+func TestFrontendReloadHandler(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	t.Run("reloads", func(t *testing.T) {
+		calls := 0
+		h := NewFrontendReloadHandler(reloaderFunc(func(context.Context) error {
+			calls++
+
+			return nil
+		}), logger)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/v1/frontend/reload", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, calls)
+
+		var resp FrontendReloadResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, "reloaded", resp.Status)
+	})
+
+	t.Run("reload failure", func(t *testing.T) {
+		h := NewFrontendReloadHandler(reloaderFunc(func(context.Context) error {
+			return errors.New("failed to open index.html")
+		}), logger)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/v1/frontend/reload", http.NoBody))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
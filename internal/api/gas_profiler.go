@@ -1,9 +1,12 @@
 package api
 
+//go:generate mockgen -package mocks -destination mocks/mock_gas_profiler.go github.com/ethpandaops/lab-backend/internal/api GasProfiler
+
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +16,11 @@ import (
 	"time"
 
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,6 +29,10 @@ const (
 	healthCheckTimeout = 5 * time.Second
 )
 
+// errInvalidRPCResponse is returned by call when the upstream response is not
+// JSON-RPC.
+var errInvalidRPCResponse = errors.New("invalid JSON-RPC response")
+
 // truncateString returns s truncated to maxLen characters with an ellipsis
 // appended when truncation occurs. Useful for safe log output.
 func truncateString(s string, maxLen int) string {
@@ -32,11 +44,28 @@ func truncateString(s string, maxLen int) string {
 }
 
 // Verify interface compliance at compile time.
-var _ http.Handler = (*GasProfilerHandler)(nil)
+var (
+	_ http.Handler = (*GasProfilerHandler)(nil)
+	_ GasProfiler  = (*GasProfilerHandler)(nil)
+)
+
+// GasProfiler serves gas profiler requests and polls endpoint health in the
+// background.
+type GasProfiler interface {
+	http.Handler
+	// Start runs a first health check and starts the poller. Health checks
+	// run under ctx.
+	Start(ctx context.Context)
+	// Stop stops the poller.
+	Stop()
+	// EndpointStats returns the load balancing state of every endpoint.
+	EndpointStats() []GasProfilerEndpointStats
+}
 
 // GasProfilerHandler handles gas profiler simulation requests.
 // It proxies requests to network-specific Erigon nodes with xatu RPC endpoints.
-// Supports multiple endpoints per network with round-robin load balancing.
+// Supports multiple endpoints per network, load balanced with the configured
+// strategy (round-robin by default).
 // A background poller checks each endpoint's sync status via eth_syncing
 // and only routes traffic to fully synced nodes.
 type GasProfilerHandler struct {
@@ -44,22 +73,32 @@ type GasProfilerHandler struct {
 	client *http.Client
 	logger logrus.FieldLogger
 
+	// Simulation results cached per head block
+	cache    *gasProfilerCache // nil when caching is disabled
+	headTask *tasks.Task
+
 	// Round-robin counters per network
 	counters   map[string]*atomic.Uint64
 	countersMu sync.RWMutex
 
+	// Request stats per endpoint name, for load balancing
+	stats   map[string]*endpointStats
+	statsMu sync.Mutex
+
 	// Health tracking: keyed by endpoint name, true = synced
 	healthy  map[string]bool
 	healthMu sync.RWMutex
 
 	// Lifecycle
+	cancel context.CancelFunc // Aborts in-flight health checks on Stop
 	stopCh chan struct{}
 	wg     sync.WaitGroup
 	booted bool
+	task   *tasks.Task
 }
 
 // NewGasProfilerHandler creates a new gas profiler handler.
-func NewGasProfilerHandler(cfg *config.GasProfilerConfig, logger logrus.FieldLogger) *GasProfilerHandler {
+func NewGasProfilerHandler(cfg *config.GasProfilerConfig, redisClient redis.Client, logger logrus.FieldLogger) *GasProfilerHandler {
 	// Initialize counters for each network
 	counters := make(map[string]*atomic.Uint64, len(cfg.GetNetworks()))
 
@@ -77,7 +116,9 @@ func NewGasProfilerHandler(cfg *config.GasProfilerConfig, logger logrus.FieldLog
 		cfg:      cfg,
 		client:   cfg.HTTPClient(),
 		logger:   logger.WithField("handler", "gas_profiler"),
+		cache:    newGasProfilerCache(cfg.Cache, redisClient, logger),
 		counters: counters,
+		stats:    make(map[string]*endpointStats, len(cfg.Endpoints)),
 		healthy:  healthy,
 		stopCh:   make(chan struct{}),
 	}
@@ -85,40 +126,77 @@ func NewGasProfilerHandler(cfg *config.GasProfilerConfig, logger logrus.FieldLog
 
 // Start begins the background health polling goroutine.
 // It runs an initial health check synchronously before returning.
-func (h *GasProfilerHandler) Start() {
+func (h *GasProfilerHandler) Start(ctx context.Context) {
+	h.task = tasks.Default().Register("gas_profiler.health", h.cfg.HealthInterval)
+
+	ctx, h.cancel = context.WithCancel(ctx)
+
 	// Run first health check immediately so we know status at boot
-	h.checkHealth()
+	_ = h.task.Run(func() error { return h.checkHealth(ctx) })
 
 	h.wg.Go(func() {
-		ticker := time.NewTicker(h.cfg.HealthInterval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				h.checkHealth()
-			case <-h.stopCh:
-				return
-			}
-		}
+		h.task.Supervise(h.logger, h.stopCh, func() { h.pollHealth(ctx) })
 	})
 
 	h.logger.WithField("interval", h.cfg.HealthInterval).
 		Info("Started endpoint health poller")
+
+	if h.cache == nil {
+		return
+	}
+
+	// Observe head blocks so cached results can be invalidated
+	h.headTask = tasks.Default().Register("gas_profiler.head", h.cfg.Cache.HeadInterval)
+
+	_ = h.headTask.Run(func() error { return h.checkHeads(ctx) })
+
+	h.wg.Go(func() {
+		h.headTask.Supervise(h.logger, h.stopCh, func() { h.pollHeads(ctx) })
+	})
 }
 
 // Stop signals the background poller to stop and waits for it to finish.
 func (h *GasProfilerHandler) Stop() {
+	if h.cancel != nil {
+		h.cancel()
+	}
+
 	close(h.stopCh)
 	h.wg.Wait()
 
 	h.logger.Info("Stopped endpoint health poller")
 }
 
+// pollHealth runs checkHealth on every health interval until stopped.
+func (h *GasProfilerHandler) pollHealth(ctx context.Context) {
+	ticker := jitter.NewTicker(h.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = h.task.Run(func() error { return h.checkHealth(ctx) })
+		case <-h.stopCh:
+			return
+		}
+	}
+}
+
 // checkHealth polls each endpoint with eth_syncing and updates health status.
-func (h *GasProfilerHandler) checkHealth() {
+// Returns an error describing how many endpoints are not synced, if any.
+// Health is left unchanged once ctx is done.
+func (h *GasProfilerHandler) checkHealth(ctx context.Context) error {
+	unsynced := 0
+
 	for _, ep := range h.cfg.Endpoints {
-		synced := h.isEndpointSynced(ep)
+		synced := h.isEndpointSynced(ctx, ep)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !synced {
+			unsynced++
+		}
 
 		h.healthMu.RLock()
 		prev := h.healthy[ep.Name]
@@ -138,11 +216,17 @@ func (h *GasProfilerHandler) checkHealth() {
 	}
 
 	h.booted = true
+
+	if unsynced > 0 {
+		return fmt.Errorf("%d of %d endpoints not synced", unsynced, len(h.cfg.Endpoints))
+	}
+
+	return nil
 }
 
 // isEndpointSynced sends an eth_syncing RPC call and returns true if the
 // node is fully synced (result is false), or false if syncing/unreachable.
-func (h *GasProfilerHandler) isEndpointSynced(ep config.GasProfilerEndpoint) bool {
+func (h *GasProfilerHandler) isEndpointSynced(ctx context.Context, ep config.GasProfilerEndpoint) bool {
 	log := h.logger.WithField("endpoint", ep.Name)
 
 	rpcReq := jsonRPCRequest{
@@ -159,7 +243,7 @@ func (h *GasProfilerHandler) isEndpointSynced(ep config.GasProfilerEndpoint) boo
 		return false
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
 	defer cancel()
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
@@ -170,6 +254,7 @@ func (h *GasProfilerHandler) isEndpointSynced(ep config.GasProfilerEndpoint) boo
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	ep.Auth.Apply(httpReq)
 
 	resp, err := h.client.Do(httpReq)
 	if err != nil {
@@ -225,8 +310,8 @@ func (h *GasProfilerHandler) isEndpointSynced(ep config.GasProfilerEndpoint) boo
 	return true
 }
 
-// getEndpoint returns a healthy endpoint for the network using round-robin.
-// Returns nil if no healthy endpoints are available.
+// getEndpoint returns a healthy endpoint for the network using the configured
+// strategy. Returns nil if no healthy endpoints are available.
 func (h *GasProfilerHandler) getEndpoint(network string) *config.GasProfilerEndpoint {
 	endpoints := h.cfg.GetEndpointsForNetwork(network)
 	if len(endpoints) == 0 {
@@ -250,22 +335,7 @@ func (h *GasProfilerHandler) getEndpoint(network string) *config.GasProfilerEndp
 		return nil
 	}
 
-	if len(healthy) == 1 {
-		return healthy[0]
-	}
-
-	// Round-robin selection across healthy endpoints
-	h.countersMu.RLock()
-	counter := h.counters[network]
-	h.countersMu.RUnlock()
-
-	if counter == nil {
-		return healthy[0]
-	}
-
-	idx := counter.Add(1) - 1
-
-	return healthy[idx%uint64(len(healthy))]
+	return h.pick(network, healthy)
 }
 
 // jsonRPCRequest represents a JSON-RPC request.
@@ -290,6 +360,10 @@ type jsonRPCError struct {
 	Message string `json:"message"`
 }
 
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
 // SimulateBlockRequest is the REST request for block simulation.
 type SimulateBlockRequest struct {
 	BlockNumber uint64         `json:"blockNumber"`
@@ -310,23 +384,23 @@ func (h *GasProfilerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Extract network from path
 	network := r.PathValue("network")
 	if network == "" {
-		h.errorResponse(w, http.StatusBadRequest, "network parameter required")
+		h.errorResponse(w, r, http.StatusBadRequest, "network parameter required")
 
 		return
 	}
 
-	// Get a healthy endpoint for the network (round-robin if multiple)
+	// Get a healthy endpoint for the network (load balanced if multiple)
 	endpoint := h.getEndpoint(network)
 	if endpoint == nil {
 		// Distinguish between "not configured" and "all syncing"
 		if len(h.cfg.GetEndpointsForNetwork(network)) > 0 {
-			h.errorResponse(w, http.StatusServiceUnavailable,
+			h.errorResponse(w, r, http.StatusServiceUnavailable,
 				fmt.Sprintf("all backends for network %s are currently syncing", network))
 
 			return
 		}
 
-		h.errorResponse(w, http.StatusNotFound,
+		h.errorResponse(w, r, http.StatusNotFound,
 			fmt.Sprintf("network %s not configured for gas profiler", network))
 
 		return
@@ -343,21 +417,21 @@ func (h *GasProfilerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case "gas-schedule":
 		h.handleGasSchedule(w, r, endpoint)
 	default:
-		h.errorResponse(w, http.StatusNotFound, fmt.Sprintf("unknown action: %s", action))
+		h.errorResponse(w, r, http.StatusNotFound, fmt.Sprintf("unknown action: %s", action))
 	}
 }
 
 // handleSimulateBlock handles POST /api/v1/gas-profiler/{network}/simulate-block.
 func (h *GasProfilerHandler) handleSimulateBlock(w http.ResponseWriter, r *http.Request, endpoint *config.GasProfilerEndpoint) {
 	if r.Method != http.MethodPost {
-		h.errorResponse(w, http.StatusMethodNotAllowed, "POST required")
+		h.errorResponse(w, r, http.StatusMethodNotAllowed, "POST required")
 
 		return
 	}
 
 	var req SimulateBlockRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.errorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		h.errorResponse(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
 
 		return
 	}
@@ -385,14 +459,14 @@ func (h *GasProfilerHandler) handleSimulateBlock(w http.ResponseWriter, r *http.
 // handleSimulateTx handles POST /api/v1/gas-profiler/{network}/simulate-transaction.
 func (h *GasProfilerHandler) handleSimulateTx(w http.ResponseWriter, r *http.Request, endpoint *config.GasProfilerEndpoint) {
 	if r.Method != http.MethodPost {
-		h.errorResponse(w, http.StatusMethodNotAllowed, "POST required")
+		h.errorResponse(w, r, http.StatusMethodNotAllowed, "POST required")
 
 		return
 	}
 
 	var req SimulateTransactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.errorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		h.errorResponse(w, r, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
 
 		return
 	}
@@ -426,7 +500,7 @@ func (h *GasProfilerHandler) handleSimulateTx(w http.ResponseWriter, r *http.Req
 //   - block: block number (required) - determines which fork's gas parameters to return
 func (h *GasProfilerHandler) handleGasSchedule(w http.ResponseWriter, r *http.Request, endpoint *config.GasProfilerEndpoint) {
 	if r.Method != http.MethodGet {
-		h.errorResponse(w, http.StatusMethodNotAllowed, "GET required")
+		h.errorResponse(w, r, http.StatusMethodNotAllowed, "GET required")
 
 		return
 	}
@@ -434,14 +508,14 @@ func (h *GasProfilerHandler) handleGasSchedule(w http.ResponseWriter, r *http.Re
 	// Parse block number from query params
 	blockStr := r.URL.Query().Get("block")
 	if blockStr == "" {
-		h.errorResponse(w, http.StatusBadRequest, "block query parameter is required")
+		h.errorResponse(w, r, http.StatusBadRequest, "block query parameter is required")
 
 		return
 	}
 
 	blockNumber, err := strconv.ParseUint(blockStr, 10, 64)
 	if err != nil {
-		h.errorResponse(w, http.StatusBadRequest, "invalid block number")
+		h.errorResponse(w, r, http.StatusBadRequest, "invalid block number")
 
 		return
 	}
@@ -458,87 +532,126 @@ func (h *GasProfilerHandler) handleGasSchedule(w http.ResponseWriter, r *http.Re
 }
 
 // proxyRPC sends a JSON-RPC request to the endpoint and returns the result.
+// With caching enabled, results are served from and stored in the cache.
 func (h *GasProfilerHandler) proxyRPC(w http.ResponseWriter, r *http.Request, endpoint *config.GasProfilerEndpoint, rpcReq *jsonRPCRequest) {
-	// Encode request
-	reqBody, err := json.Marshal(rpcReq)
+	log := requestid.Logger(r.Context(), h.logger)
+
+	var cacheKey string
+
+	if h.cache != nil {
+		key, cached, status := h.lookup(r.Context(), endpoint.Network, rpcReq)
+		gasProfilerCacheTotal.WithLabelValues(endpoint.Network, status).Inc()
+		w.Header().Set(GasProfilerCacheHeader, status)
+
+		if cached != nil {
+			h.writeResult(w, r, cached)
+
+			return
+		}
+
+		cacheKey = key
+	}
+
+	start := time.Now()
+	result, err := h.call(r.Context(), endpoint, rpcReq)
+
+	var rpcErr *jsonRPCError
+
+	h.record(endpoint, time.Since(start), err != nil && !errors.As(err, &rpcErr))
+
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to encode RPC request")
-		h.errorResponse(w, http.StatusInternalServerError, "internal error")
+
+		switch {
+		case errors.As(err, &rpcErr):
+			log.WithFields(logrus.Fields{
+				"code":    rpcErr.Code,
+				"message": rpcErr.Message,
+			}).Warn("RPC error from upstream")
+			h.errorResponse(w, r, http.StatusBadRequest, rpcErr.Message)
+		case errors.Is(err, errInvalidRPCResponse):
+			log.WithError(err).Error("Failed to parse RPC response")
+			h.errorResponse(w, r, http.StatusBadGateway, "invalid upstream response")
+		default:
+			log.WithError(err).WithField("endpoint", endpoint.Name).Error("Failed to send RPC request")
+			h.errorResponse(w, r, http.StatusBadGateway, "upstream error")
+		}
 
 		return
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(r.Context(), http.MethodPost, endpoint.URL, bytes.NewReader(reqBody))
+	if cacheKey != "" {
+		if err := h.cache.set(r.Context(), cacheKey, result); err != nil {
+			log.WithError(err).Warn("Failed to cache result")
+		}
+	}
+
+	h.writeResult(w, r, result)
+
+	log.WithFields(logrus.Fields{
+		"network": endpoint.Network,
+		"method":  rpcReq.Method,
+	}).Debug("Proxied RPC request")
+}
+
+// call sends a JSON-RPC request to the endpoint and returns its result. RPC
+// errors are returned as *jsonRPCError.
+func (h *GasProfilerHandler) call(ctx context.Context, endpoint *config.GasProfilerEndpoint, rpcReq *jsonRPCRequest) (json.RawMessage, error) {
+	// Encode request
+	reqBody, err := json.Marshal(rpcReq)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to create HTTP request")
-		h.errorResponse(w, http.StatusInternalServerError, "internal error")
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
 
-		return
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	endpoint.Auth.Apply(httpReq)
+
+	if id := requestid.FromContext(ctx); id != "" {
+		httpReq.Header.Set(requestid.Header, id)
+	}
 
 	// Send request
 	resp, err := h.client.Do(httpReq)
 	if err != nil {
-		h.logger.WithError(err).WithField("endpoint", endpoint.Name).Error("Failed to send RPC request")
-		h.errorResponse(w, http.StatusBadGateway, "upstream error")
-
-		return
+		return nil, fmt.Errorf("send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to read RPC response")
-		h.errorResponse(w, http.StatusBadGateway, "upstream error")
-
-		return
+		return nil, fmt.Errorf("read response: %w", err)
 	}
 
 	// Parse JSON-RPC response
 	var rpcResp jsonRPCResponse
 	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
-		h.logger.WithError(err).Error("Failed to parse RPC response")
-		h.errorResponse(w, http.StatusBadGateway, "invalid upstream response")
-
-		return
+		return nil, fmt.Errorf("%w: %w", errInvalidRPCResponse, err)
 	}
 
 	// Check for RPC error
 	if rpcResp.Error != nil {
-		h.logger.WithFields(logrus.Fields{
-			"code":    rpcResp.Error.Code,
-			"message": rpcResp.Error.Message,
-		}).Warn("RPC error from upstream")
-		h.errorResponse(w, http.StatusBadRequest, rpcResp.Error.Message)
-
-		return
-	}
-
-	// Return just the result
-	w.Header().Set("Content-Type", "application/json")
-
-	if _, err := w.Write(rpcResp.Result); err != nil {
-		h.logger.WithError(err).Error("Failed to write response")
+		return nil, rpcResp.Error
 	}
 
-	h.logger.WithFields(logrus.Fields{
-		"network": endpoint.Network,
-		"method":  rpcReq.Method,
-	}).Debug("Proxied RPC request")
+	return rpcResp.Result, nil
 }
 
-// errorResponse writes a JSON error response.
-func (h *GasProfilerHandler) errorResponse(w http.ResponseWriter, status int, message string) {
+// writeResult writes an RPC result as the response.
+func (h *GasProfilerHandler) writeResult(w http.ResponseWriter, r *http.Request, result []byte) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	resp := map[string]string{"error": message}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.WithError(err).Error("Failed to encode error response")
+	if _, err := w.Write(result); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to write response")
 	}
 }
+
+// errorResponse writes a problem response with the default code of status.
+func (h *GasProfilerHandler) errorResponse(w http.ResponseWriter, r *http.Request, status int, message string) {
+	httperr.Write(w, r, status, httperr.CodeForStatus(status), message)
+}
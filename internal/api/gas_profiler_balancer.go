@@ -0,0 +1,199 @@
+//nolint:tagliatelle // superior snake-case yo.
+package api
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// latencyEWMAAlpha is the weight of the newest request duration in an
+// endpoint's latency average.
+const latencyEWMAAlpha = 0.2
+
+// endpointStats tracks the simulation requests proxied to one endpoint.
+type endpointStats struct {
+	requests    uint64
+	failures    uint64
+	lastFailure time.Time
+	latency     float64 // Moving average of request durations in milliseconds, 0 before the first request
+}
+
+// GasProfilerEndpointStats reports the load balancing state of one endpoint.
+type GasProfilerEndpointStats struct {
+	Name        string     `json:"name"`
+	Network     string     `json:"network"`
+	Healthy     bool       `json:"healthy"`
+	Weight      int        `json:"weight"`
+	Requests    uint64     `json:"requests"`
+	Failures    uint64     `json:"failures"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+	LatencyMS   float64    `json:"latency_ms"` // Moving average of request durations
+}
+
+// record accounts a proxied request to an endpoint. Only transport and
+// protocol failures count as failed; RPC errors are the client's.
+func (h *GasProfilerHandler) record(endpoint *config.GasProfilerEndpoint, duration time.Duration, failed bool) {
+	ms := float64(duration) / float64(time.Millisecond)
+
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+
+	stats := h.stats[endpoint.Name]
+	if stats == nil {
+		stats = &endpointStats{}
+		h.stats[endpoint.Name] = stats
+	}
+
+	stats.requests++
+
+	if failed {
+		stats.failures++
+		stats.lastFailure = time.Now()
+	}
+
+	if stats.latency == 0 {
+		stats.latency = ms
+	} else {
+		stats.latency = latencyEWMAAlpha*ms + (1-latencyEWMAAlpha)*stats.latency
+	}
+}
+
+// EndpointStats returns the load balancing state of every endpoint, in
+// configuration order.
+func (h *GasProfilerHandler) EndpointStats() []GasProfilerEndpointStats {
+	h.healthMu.RLock()
+	defer h.healthMu.RUnlock()
+
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+
+	result := make([]GasProfilerEndpointStats, 0, len(h.cfg.Endpoints))
+
+	for _, ep := range h.cfg.Endpoints {
+		entry := GasProfilerEndpointStats{
+			Name:    ep.Name,
+			Network: ep.Network,
+			Healthy: h.healthy[ep.Name],
+			Weight:  ep.Weight,
+		}
+
+		if stats := h.stats[ep.Name]; stats != nil {
+			entry.Requests = stats.requests
+			entry.Failures = stats.failures
+			entry.LatencyMS = stats.latency
+
+			if !stats.lastFailure.IsZero() {
+				lastFailure := stats.lastFailure
+				entry.LastFailure = &lastFailure
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+// pick selects one of a network's healthy endpoints with the configured
+// strategy. Ties are broken round-robin.
+func (h *GasProfilerHandler) pick(network string, healthy []*config.GasProfilerEndpoint) *config.GasProfilerEndpoint {
+	if len(healthy) == 1 {
+		return healthy[0]
+	}
+
+	switch h.cfg.Strategy {
+	case config.GasProfilerStrategyWeighted:
+		return h.pickWeighted(network, healthy)
+	case config.GasProfilerStrategyLeastFailed:
+		healthy = h.leastRecentlyFailed(healthy)
+	case config.GasProfilerStrategyLatency:
+		healthy = h.fastest(healthy)
+	}
+
+	return healthy[h.next(network)%uint64(len(healthy))]
+}
+
+// next returns the network's round-robin counter and advances it.
+func (h *GasProfilerHandler) next(network string) uint64 {
+	h.countersMu.RLock()
+	counter := h.counters[network]
+	h.countersMu.RUnlock()
+
+	if counter == nil {
+		return 0
+	}
+
+	return counter.Add(1) - 1
+}
+
+// pickWeighted takes turns between endpoints, giving each as many turns per
+// round as its weight.
+func (h *GasProfilerHandler) pickWeighted(network string, healthy []*config.GasProfilerEndpoint) *config.GasProfilerEndpoint {
+	var total uint64
+
+	for _, ep := range healthy {
+		total += uint64(max(ep.Weight, 1))
+	}
+
+	turn := h.next(network) % total
+
+	for _, ep := range healthy {
+		weight := uint64(max(ep.Weight, 1))
+		if turn < weight {
+			return ep
+		}
+
+		turn -= weight
+	}
+
+	return healthy[0]
+}
+
+// leastRecentlyFailed returns the endpoints whose last failure is the oldest.
+// Endpoints that never failed beat any that did.
+func (h *GasProfilerHandler) leastRecentlyFailed(healthy []*config.GasProfilerEndpoint) []*config.GasProfilerEndpoint {
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+
+	lastFailure := func(ep *config.GasProfilerEndpoint) time.Time {
+		if stats := h.stats[ep.Name]; stats != nil {
+			return stats.lastFailure
+		}
+
+		return time.Time{}
+	}
+
+	oldest := lastFailure(slices.MinFunc(healthy, func(a, b *config.GasProfilerEndpoint) int {
+		return lastFailure(a).Compare(lastFailure(b))
+	}))
+
+	return slices.DeleteFunc(slices.Clone(healthy), func(ep *config.GasProfilerEndpoint) bool {
+		return !lastFailure(ep).Equal(oldest)
+	})
+}
+
+// fastest returns the endpoints with the lowest latency average. Endpoints
+// without requests yet are tried first, so every endpoint gets measured.
+func (h *GasProfilerHandler) fastest(healthy []*config.GasProfilerEndpoint) []*config.GasProfilerEndpoint {
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+
+	latency := func(ep *config.GasProfilerEndpoint) float64 {
+		if stats := h.stats[ep.Name]; stats != nil {
+			return stats.latency
+		}
+
+		return 0
+	}
+
+	lowest := latency(slices.MinFunc(healthy, func(a, b *config.GasProfilerEndpoint) int {
+		return cmp.Compare(latency(a), latency(b))
+	}))
+
+	return slices.DeleteFunc(slices.Clone(healthy), func(ep *config.GasProfilerEndpoint) bool {
+		return latency(ep) != lowest
+	})
+}
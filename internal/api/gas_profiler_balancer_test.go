@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func newTestBalancedGasProfiler(t *testing.T, strategy string, weights ...int) *GasProfilerHandler {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := &config.GasProfilerConfig{Enabled: true, Strategy: strategy}
+	for i, weight := range weights {
+		cfg.Endpoints = append(cfg.Endpoints, config.GasProfilerEndpoint{
+			Name:    fmt.Sprintf("mainnet-%d", i+1),
+			Network: "mainnet",
+			URL:     "http://127.0.0.1:0",
+			Weight:  weight,
+		})
+	}
+	require.NoError(t, cfg.Validate())
+
+	h := NewGasProfilerHandler(cfg, nil, logger)
+
+	for _, ep := range cfg.Endpoints {
+		h.healthy[ep.Name] = true
+	}
+
+	return h
+}
+
+// picks returns how often each endpoint is picked in n requests.
+func picks(h *GasProfilerHandler, n int) map[string]int {
+	counts := make(map[string]int)
+
+	for range n {
+		counts[h.getEndpoint("mainnet").Name]++
+	}
+
+	return counts
+}
+
+func TestGasProfilerBalancer_RoundRobin(t *testing.T) {
+	h := newTestBalancedGasProfiler(t, "", 5, 1)
+
+	// Weights are ignored
+	assert.Equal(t, map[string]int{"mainnet-1": 3, "mainnet-2": 3}, picks(h, 6))
+}
+
+func TestGasProfilerBalancer_Weighted(t *testing.T) {
+	h := newTestBalancedGasProfiler(t, config.GasProfilerStrategyWeighted, 3, 0, 1)
+
+	assert.Equal(t, map[string]int{"mainnet-1": 30, "mainnet-2": 10, "mainnet-3": 10}, picks(h, 50))
+
+	// Unhealthy endpoints leave the rotation
+	h.healthy["mainnet-1"] = false
+	assert.Equal(t, map[string]int{"mainnet-2": 5, "mainnet-3": 5}, picks(h, 10))
+}
+
+func TestGasProfilerBalancer_LeastFailed(t *testing.T) {
+	h := newTestBalancedGasProfiler(t, config.GasProfilerStrategyLeastFailed, 1, 1, 1)
+	endpoints := h.cfg.GetEndpointsForNetwork("mainnet")
+
+	h.record(endpoints[0], time.Millisecond, true)
+	h.record(endpoints[1], time.Millisecond, false)
+
+	// Endpoints that never failed share the traffic
+	assert.Equal(t, map[string]int{"mainnet-2": 2, "mainnet-3": 2}, picks(h, 4))
+
+	h.record(endpoints[1], time.Millisecond, true)
+	h.record(endpoints[2], time.Millisecond, true)
+
+	// Then the one whose failure is the oldest
+	assert.Equal(t, map[string]int{"mainnet-1": 4}, picks(h, 4))
+}
+
+func TestGasProfilerBalancer_Latency(t *testing.T) {
+	h := newTestBalancedGasProfiler(t, config.GasProfilerStrategyLatency, 1, 1)
+	endpoints := h.cfg.GetEndpointsForNetwork("mainnet")
+
+	h.record(endpoints[0], 100*time.Millisecond, false)
+
+	// Unmeasured endpoints are tried first
+	assert.Equal(t, "mainnet-2", h.getEndpoint("mainnet").Name)
+
+	h.record(endpoints[1], 300*time.Millisecond, false)
+	assert.Equal(t, map[string]int{"mainnet-1": 4}, picks(h, 4))
+
+	// The average follows recent durations
+	for range 10 {
+		h.record(endpoints[0], 500*time.Millisecond, false)
+	}
+
+	assert.Equal(t, "mainnet-2", h.getEndpoint("mainnet").Name)
+}
+
+func TestGasProfilerHandler_EndpointStats(t *testing.T) {
+	h := newTestBalancedGasProfiler(t, config.GasProfilerStrategyLatency, 2, 1)
+	endpoints := h.cfg.GetEndpointsForNetwork("mainnet")
+	h.healthy["mainnet-2"] = false
+
+	h.record(endpoints[0], 100*time.Millisecond, false)
+	h.record(endpoints[0], 200*time.Millisecond, true)
+
+	stats := h.EndpointStats()
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "mainnet-1", stats[0].Name)
+	assert.True(t, stats[0].Healthy)
+	assert.Equal(t, 2, stats[0].Weight)
+	assert.Equal(t, uint64(2), stats[0].Requests)
+	assert.Equal(t, uint64(1), stats[0].Failures)
+	assert.NotNil(t, stats[0].LastFailure)
+	assert.InDelta(t, 120, stats[0].LatencyMS, 0.001)
+
+	assert.False(t, stats[1].Healthy)
+	assert.Zero(t, stats[1].Requests)
+	assert.Nil(t, stats[1].LastFailure)
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+// GasProfilerCacheHeader reports on gas profiler responses whether the result
+// was served from the cache: hit, miss or bypass.
+const GasProfilerCacheHeader = "X-Lab-Cache"
+
+// Cache statuses reported in GasProfilerCacheHeader.
+const (
+	cacheHit    = "hit"
+	cacheMiss   = "miss"
+	cacheBypass = "bypass" // Head block unknown or Redis unavailable
+)
+
+// cacheTimeout bounds each cache Redis call, so a slow Redis degrades to
+// uncached simulations instead of delaying them.
+const cacheTimeout = time.Second
+
+var gasProfilerCacheTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gas_profiler_cache_requests_total",
+		Help: "Total number of gas profiler requests by cache status",
+	},
+	[]string{"network", "status"},
+)
+
+// gasProfilerCache caches simulation results in Redis, keyed by network, head
+// block, RPC method and a hash of the params. When a new head is observed the
+// entries of the previous head are deleted.
+type gasProfilerCache struct {
+	cfg    config.GasProfilerCacheConfig
+	redis  redis.Client
+	logger logrus.FieldLogger
+
+	// Head block last observed per network
+	heads   map[string]uint64
+	headsMu sync.RWMutex
+}
+
+// newGasProfilerCache creates a result cache, or nil when caching is disabled.
+func newGasProfilerCache(cfg config.GasProfilerCacheConfig, redisClient redis.Client, logger logrus.FieldLogger) *gasProfilerCache {
+	if !cfg.Enabled || redisClient == nil {
+		return nil
+	}
+
+	return &gasProfilerCache{
+		cfg:    cfg,
+		redis:  redisClient,
+		logger: logger.WithField("component", "gas_profiler_cache"),
+		heads:  make(map[string]uint64),
+	}
+}
+
+// key returns the cache key of an RPC request at the network's current head,
+// or "" while the head is unknown.
+func (c *gasProfilerCache) key(network string, rpcReq *jsonRPCRequest) (string, error) {
+	c.headsMu.RLock()
+	head, ok := c.heads[network]
+	c.headsMu.RUnlock()
+
+	if !ok {
+		return "", nil
+	}
+
+	params, err := json.Marshal(rpcReq.Params)
+	if err != nil {
+		return "", fmt.Errorf("marshal params: %w", err)
+	}
+
+	hash := sha256.Sum256(params)
+
+	return fmt.Sprintf("%s%s:%d:%s:%s", c.cfg.KeyPrefix, network, head, rpcReq.Method, hex.EncodeToString(hash[:])), nil
+}
+
+// get returns the cached result under key.
+func (c *gasProfilerCache) get(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
+	defer cancel()
+
+	value, err := c.redis.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(value), nil
+}
+
+// set caches result under key.
+func (c *gasProfilerCache) set(ctx context.Context, key string, result []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
+	defer cancel()
+
+	return c.redis.Set(ctx, key, string(result), c.cfg.TTL)
+}
+
+// observeHead records the head block of a network. When it moved, the
+// results cached at the previous head are deleted.
+func (c *gasProfilerCache) observeHead(ctx context.Context, network string, head uint64) {
+	c.headsMu.Lock()
+	previous, known := c.heads[network]
+	c.heads[network] = head
+	c.headsMu.Unlock()
+
+	if !known || previous == head {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
+	defer cancel()
+
+	log := c.logger.WithFields(logrus.Fields{
+		"network":  network,
+		"previous": previous,
+		"head":     head,
+	})
+
+	keys, err := c.redis.Keys(ctx, fmt.Sprintf("%s%s:%d:*", c.cfg.KeyPrefix, network, previous))
+	if err != nil {
+		log.WithError(err).Warn("Failed to list cached results of previous head")
+
+		return
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := c.redis.Del(ctx, keys...); err != nil {
+		log.WithError(err).Warn("Failed to invalidate cached results of previous head")
+
+		return
+	}
+
+	log.WithField("entries", len(keys)).Debug("Invalidated cached results of previous head")
+}
+
+// lookup returns the cache key of a request, its cached result if any, and
+// the cache status. An empty key means the result must not be cached.
+func (h *GasProfilerHandler) lookup(ctx context.Context, network string, rpcReq *jsonRPCRequest) (string, []byte, string) {
+	key, err := h.cache.key(network, rpcReq)
+	if err != nil || key == "" {
+		return "", nil, cacheBypass
+	}
+
+	result, err := h.cache.get(ctx, key)
+
+	switch {
+	case err == nil:
+		return key, result, cacheHit
+	case errors.Is(err, redis.ErrNotFound):
+		return key, nil, cacheMiss
+	default:
+		h.cache.logger.WithError(err).Warn("Failed to read cached result")
+
+		return "", nil, cacheBypass
+	}
+}
+
+// pollHeads observes the head block of every network on every head interval
+// until stopped.
+func (h *GasProfilerHandler) pollHeads(ctx context.Context) {
+	ticker := jitter.NewTicker(h.cfg.Cache.HeadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = h.headTask.Run(func() error { return h.checkHeads(ctx) })
+		case <-h.stopCh:
+			return
+		}
+	}
+}
+
+// checkHeads fetches the head block of every network from a healthy
+// endpoint and hands it to the cache.
+func (h *GasProfilerHandler) checkHeads(ctx context.Context) error {
+	var failed []string
+
+	for _, network := range h.cfg.GetNetworks() {
+		endpoint := h.getEndpoint(network)
+		if endpoint == nil {
+			continue
+		}
+
+		head, err := h.blockNumber(ctx, endpoint)
+		if err != nil {
+			h.logger.WithError(err).WithField("endpoint", endpoint.Name).Debug("Failed to fetch head block")
+
+			failed = append(failed, network)
+
+			continue
+		}
+
+		h.cache.observeHead(ctx, network, head)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("head block unavailable for %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// blockNumber returns the head block of an endpoint with eth_blockNumber.
+func (h *GasProfilerHandler) blockNumber(ctx context.Context, endpoint *config.GasProfilerEndpoint) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	result, err := h.call(ctx, endpoint, &jsonRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_blockNumber",
+		Params:  []any{},
+		ID:      1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var hexNumber string
+	if err := json.Unmarshal(result, &hexNumber); err != nil {
+		return 0, fmt.Errorf("parse block number: %w", err)
+	}
+
+	head, err := strconv.ParseUint(strings.TrimPrefix(hexNumber, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse block number %q: %w", hexNumber, err)
+	}
+
+	return head, nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+// fakeErigon answers eth_syncing, eth_blockNumber at head and simulations,
+// counting the simulations.
+type fakeErigon struct {
+	head        atomic.Uint64
+	simulations atomic.Int32
+}
+
+func (e *fakeErigon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req jsonRPCRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	var result string
+
+	switch req.Method {
+	case "eth_syncing":
+		result = "false"
+	case "eth_blockNumber":
+		result = fmt.Sprintf(`"0x%x"`, e.head.Load())
+	default:
+		e.simulations.Add(1)
+		result = `{"gasUsed":21000}`
+	}
+
+	_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":%s}`, result)
+}
+
+func newTestCachedGasProfiler(t *testing.T, erigon *fakeErigon) (*GasProfilerHandler, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	upstream := httptest.NewServer(erigon)
+	t.Cleanup(upstream.Close)
+
+	client, mr := redistest.New(t, logger)
+
+	cfg := &config.GasProfilerConfig{
+		Enabled:   true,
+		Endpoints: []config.GasProfilerEndpoint{{Name: "mainnet-1", Network: "mainnet", URL: upstream.URL}},
+		Cache:     config.GasProfilerCacheConfig{Enabled: true, TTL: time.Minute},
+	}
+	require.NoError(t, cfg.Validate())
+
+	h := NewGasProfilerHandler(cfg, client, logger)
+	require.NoError(t, h.checkHealth(t.Context()))
+
+	return h, mr
+}
+
+func simulateBlock(h http.Handler, body string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/gas-profiler/{network}/{action}", h)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/gas-profiler/mainnet/simulate-block", strings.NewReader(body)))
+
+	return rec
+}
+
+func TestGasProfilerHandler_Cache(t *testing.T) {
+	erigon := &fakeErigon{}
+	erigon.head.Store(100)
+
+	h, mr := newTestCachedGasProfiler(t, erigon)
+
+	// Without a known head, results are not cached
+	rec := simulateBlock(h, `{"blockNumber":90,"gasSchedule":{"SLOAD":100}}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cacheBypass, rec.Header().Get(GasProfilerCacheHeader))
+
+	require.NoError(t, h.checkHeads(t.Context()))
+
+	rec = simulateBlock(h, `{"blockNumber":90,"gasSchedule":{"SLOAD":100}}`)
+	assert.Equal(t, cacheMiss, rec.Header().Get(GasProfilerCacheHeader))
+
+	rec = simulateBlock(h, `{"blockNumber":90,"gasSchedule":{"SLOAD":100}}`)
+	assert.Equal(t, cacheHit, rec.Header().Get(GasProfilerCacheHeader))
+	assert.JSONEq(t, `{"gasUsed":21000}`, rec.Body.String())
+
+	// A different gas schedule is a different entry
+	rec = simulateBlock(h, `{"blockNumber":90,"gasSchedule":{"SLOAD":200}}`)
+	assert.Equal(t, cacheMiss, rec.Header().Get(GasProfilerCacheHeader))
+	assert.Equal(t, int32(3), erigon.simulations.Load())
+
+	// A new head drops the results cached at the previous one
+	erigon.head.Store(101)
+	require.NoError(t, h.checkHeads(t.Context()))
+	assert.Empty(t, mr.Keys())
+
+	rec = simulateBlock(h, `{"blockNumber":90,"gasSchedule":{"SLOAD":100}}`)
+	assert.Equal(t, cacheMiss, rec.Header().Get(GasProfilerCacheHeader))
+	assert.Equal(t, int32(4), erigon.simulations.Load())
+}
+
+func TestGasProfilerHandler_CacheDisabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	erigon := &fakeErigon{}
+
+	upstream := httptest.NewServer(erigon)
+	defer upstream.Close()
+
+	cfg := &config.GasProfilerConfig{
+		Enabled:   true,
+		Endpoints: []config.GasProfilerEndpoint{{Name: "mainnet-1", Network: "mainnet", URL: upstream.URL}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	h := NewGasProfilerHandler(cfg, nil, logger)
+	require.NoError(t, h.checkHealth(t.Context()))
+
+	rec := simulateBlock(h, `{"blockNumber":90,"gasSchedule":{}}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(GasProfilerCacheHeader))
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*GasProfilerEndpointsHandler)(nil)
+
+// GasProfilerEndpointsResponse is the response for
+// GET /admin/v1/gas-profiler/endpoints.
+type GasProfilerEndpointsResponse struct {
+	Strategy  string                     `json:"strategy"`
+	Endpoints []GasProfilerEndpointStats `json:"endpoints"`
+}
+
+// GasProfilerEndpointsHandler handles GET /admin/v1/gas-profiler/endpoints
+// requests.
+type GasProfilerEndpointsHandler struct {
+	profiler GasProfiler
+	strategy string
+	logger   logrus.FieldLogger
+}
+
+// NewGasProfilerEndpointsHandler creates a new gas profiler endpoints handler.
+func NewGasProfilerEndpointsHandler(profiler GasProfiler, strategy string, logger logrus.FieldLogger) *GasProfilerEndpointsHandler {
+	return &GasProfilerEndpointsHandler{
+		profiler: profiler,
+		strategy: strategy,
+		logger:   logger.WithField("handler", "gas_profiler_endpoints"),
+	}
+}
+
+// ServeHTTP returns the health and load balancing stats of every gas profiler
+// endpoint.
+func (h *GasProfilerEndpointsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := GasProfilerEndpointsResponse{
+		Strategy:  h.strategy,
+		Endpoints: h.profiler.EndpointStats(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*LeaderStatusHandler)(nil)
+
+// LeaderStatusResponse is the response for GET /api/v1/status/leader.
+type LeaderStatusResponse struct {
+	InstanceID string `json:"instance_id"`
+	IsLeader   bool   `json:"is_leader"`
+	LeaderID   string `json:"leader_id"`
+	Term       int64  `json:"term"`
+}
+
+// LeaderStatusHandler handles GET /api/v1/status/leader requests.
+type LeaderStatusHandler struct {
+	elector leader.Elector
+	logger  logrus.FieldLogger
+}
+
+// NewLeaderStatusHandler creates a new leader status handler.
+func NewLeaderStatusHandler(elector leader.Elector, logger logrus.FieldLogger) *LeaderStatusHandler {
+	return &LeaderStatusHandler{
+		elector: elector,
+		logger:  logger.WithField("handler", "leader_status"),
+	}
+}
+
+// ServeHTTP returns the current leader, its term and whether the serving
+// instance is the leader.
+func (h *LeaderStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status, err := h.elector.Status(r.Context())
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Warn("Failed to read leader status")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "leader status unavailable")
+
+		return
+	}
+
+	response := LeaderStatusResponse{
+		InstanceID: status.InstanceID,
+		IsLeader:   status.IsLeader,
+		LeaderID:   status.LeaderID,
+		Term:       status.Term,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
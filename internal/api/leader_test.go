@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
+)
+
+func TestLeaderStatusHandler(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	t.Run("returns leader and term", func(t *testing.T) {
+		elector := leadermocks.NewMockElector(gomock.NewController(t))
+		elector.EXPECT().Status(gomock.Any()).Return(leader.Status{
+			InstanceID: "follower",
+			LeaderID:   "leader",
+			Term:       7,
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		NewLeaderStatusHandler(elector, logger).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status/leader", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp LeaderStatusResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, LeaderStatusResponse{InstanceID: "follower", LeaderID: "leader", Term: 7}, resp)
+	})
+
+	t.Run("redis unavailable", func(t *testing.T) {
+		elector := leadermocks.NewMockElector(gomock.NewController(t))
+		elector.EXPECT().Status(gomock.Any()).Return(leader.Status{}, assert.AnError)
+
+		rec := httptest.NewRecorder()
+		NewLeaderStatusHandler(elector, logger).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status/leader", http.NoBody))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -0,0 +1,150 @@
+//nolint:tagliatelle // superior snake-case yo.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*LimitsHandler)(nil)
+
+// LimitsResponse is the response for GET /api/v1/limits.
+type LimitsResponse struct {
+	Enabled      bool         `json:"enabled"` // Whether rate limiting is enabled at all
+	Client       LimitsClient `json:"client"`
+	Exempt       bool         `json:"exempt"`
+	ExemptReason string       `json:"exempt_reason,omitempty"` // "ip" or "tier"
+	Rules        []LimitRule  `json:"rules"`                   // In match order; a request counts against the first match
+}
+
+// LimitsClient is who the caller is rate limited as.
+type LimitsClient struct {
+	IP            string `json:"ip"`
+	Authenticated bool   `json:"authenticated"`
+	Tier          string `json:"tier,omitempty"` // API key tier, when authenticated
+}
+
+// LimitRule is a rate limit rule as it applies to the caller.
+type LimitRule struct {
+	Name          string     `json:"name"`
+	PathPattern   string     `json:"path_pattern"`
+	Limit         int        `json:"limit"` // Limit for the caller, including tier limits
+	WindowSeconds int64      `json:"window_seconds"`
+	Burst         int        `json:"burst,omitempty"`     // Extra requests allowed in a burst, refilled at limit per window
+	Remaining     *int       `json:"remaining,omitempty"` // Requests left in the current window; absent when exempt or unknown
+	ResetAt       *time.Time `json:"reset_at,omitempty"`  // When the current window ends; absent before the first request
+}
+
+// LimitsHandler handles GET /api/v1/limits requests. It reports the rate
+// limit rules that apply to the caller and their remaining budgets, without
+// consuming any.
+type LimitsHandler struct {
+	enabled  bool
+	policy   *ratelimit.Policy // nil when rate limiting is disabled
+	limiter  ratelimit.Service // nil when rate limiting is disabled
+	resolver *ratelimit.IPResolver
+	logger   logrus.FieldLogger
+}
+
+// NewLimitsHandler creates a new limits handler.
+func NewLimitsHandler(
+	cfg config.RateLimitingConfig,
+	limiter ratelimit.Service,
+	resolver *ratelimit.IPResolver,
+	logger logrus.FieldLogger,
+) *LimitsHandler {
+	h := &LimitsHandler{
+		enabled:  cfg.Enabled && limiter != nil,
+		limiter:  limiter,
+		resolver: resolver,
+		logger:   logger.WithField("handler", "limits"),
+	}
+
+	// Rules are only validated when rate limiting is enabled
+	if h.enabled {
+		h.policy = ratelimit.NewPolicy(cfg)
+	}
+
+	return h
+}
+
+// ServeHTTP writes the caller's effective rate limit policy.
+func (h *LimitsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	client := h.resolver.Client(r)
+
+	response := LimitsResponse{
+		Enabled: h.enabled,
+		Client: LimitsClient{
+			IP:            client.IP,
+			Authenticated: client.Authenticated,
+			Tier:          client.Identity.Tier,
+		},
+		Rules: []LimitRule{},
+	}
+
+	if h.enabled {
+		response.ExemptReason = h.policy.Exempt(client)
+		response.Exempt = response.ExemptReason != ""
+		response.Rules = h.rules(r, client, response.Exempt)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
+
+// rules returns every rule with the caller's limit and, unless exempt, the
+// remaining budget.
+func (h *LimitsHandler) rules(r *http.Request, client ratelimit.Client, exempt bool) []LimitRule {
+	rules := make([]LimitRule, 0, len(h.policy.Rules()))
+
+	for _, rule := range h.policy.Rules() {
+		limit := rule.LimitFor(client)
+
+		info := LimitRule{
+			Name:          rule.Name,
+			PathPattern:   rule.Pattern.String(),
+			Limit:         limit,
+			WindowSeconds: int64(rule.Window.Seconds()),
+			Burst:         rule.Burst,
+		}
+
+		if !exempt {
+			remaining, resetAt, err := h.peek(r, client, rule, limit)
+			if err != nil {
+				requestid.Logger(r.Context(), h.logger).WithError(err).WithField("rule", rule.Name).Warn("Failed to read rate limit budget")
+			} else {
+				info.Remaining = &remaining
+
+				if !resetAt.IsZero() {
+					resetAt = resetAt.UTC().Truncate(time.Second)
+					info.ResetAt = &resetAt
+				}
+			}
+		}
+
+		rules = append(rules, info)
+	}
+
+	return rules
+}
+
+// peek reads the remaining budget of a rule the way the middleware counts it.
+func (h *LimitsHandler) peek(r *http.Request, client ratelimit.Client, rule ratelimit.Rule, limit int) (int, time.Time, error) {
+	if rule.Burst > 0 {
+		return h.limiter.PeekBurst(r.Context(), client.Subject(), rule.Name, limit, rule.Burst, rule.Window)
+	}
+
+	return h.limiter.Peek(r.Context(), client.Subject(), rule.Name, limit)
+}
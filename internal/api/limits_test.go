@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+)
+
+func newTestLimitsHandler(t *testing.T, cfg config.RateLimitingConfig) (*LimitsHandler, ratelimit.Service) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	mr := miniredis.RunT(t)
+
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	limiter := ratelimit.NewService(logger, client, "fail_open")
+
+	return NewLimitsHandler(cfg, limiter, nil, logger), limiter
+}
+
+func getLimits(t *testing.T, h http.Handler, req *http.Request) LimitsResponse {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+
+	var resp LimitsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+
+	return resp
+}
+
+func TestLimitsHandler(t *testing.T) {
+	cfg := config.RateLimitingConfig{
+		Enabled:     true,
+		ExemptIPs:   []string{"10.0.0.0/8"},
+		ExemptTiers: []string{"internal"},
+		Rules: []config.RateLimitRule{
+			{Name: "proxy", PathPattern: "^/api/v1/[^/]+/fct_", Limit: 10, Window: time.Minute, TierLimits: map[string]int{"partner": 100}},
+			{Name: "api", PathPattern: "^/api/", Limit: 60, Window: time.Minute},
+		},
+	}
+
+	h, limiter := newTestLimitsHandler(t, cfg)
+
+	// Two requests already counted against the proxy rule
+	for range 2 {
+		_, _, _, err := limiter.Allow(context.Background(), "192.0.2.1", "proxy", 10, time.Minute)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/limits", http.NoBody)
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	resp := getLimits(t, h, req)
+	assert.True(t, resp.Enabled)
+	assert.False(t, resp.Exempt)
+	assert.Equal(t, "192.0.2.1", resp.Client.IP)
+	require.Len(t, resp.Rules, 2)
+
+	assert.Equal(t, "proxy", resp.Rules[0].Name)
+	assert.Equal(t, 10, resp.Rules[0].Limit)
+	assert.Equal(t, int64(60), resp.Rules[0].WindowSeconds)
+	require.NotNil(t, resp.Rules[0].Remaining)
+	assert.Equal(t, 8, *resp.Rules[0].Remaining)
+	require.NotNil(t, resp.Rules[0].ResetAt)
+
+	// Unused rules report the full budget
+	require.NotNil(t, resp.Rules[1].Remaining)
+	assert.Equal(t, 60, *resp.Rules[1].Remaining)
+	assert.Nil(t, resp.Rules[1].ResetAt)
+
+	// Reporting does not consume the budget
+	resp = getLimits(t, h, req)
+	assert.Equal(t, 8, *resp.Rules[0].Remaining)
+
+	// Keyed clients get their tier limit and their own bucket
+	keyed := req.Clone(auth.WithIdentity(context.Background(), auth.Identity{KeyID: "k1", Tier: "partner"}))
+
+	resp = getLimits(t, h, keyed)
+	assert.True(t, resp.Client.Authenticated)
+	assert.Equal(t, "partner", resp.Client.Tier)
+	assert.Equal(t, 100, resp.Rules[0].Limit)
+	assert.Equal(t, 100, *resp.Rules[0].Remaining)
+
+	// Exempt clients see the rules but no budgets
+	internal := req.Clone(auth.WithIdentity(context.Background(), auth.Identity{KeyID: "k2", Tier: "internal"}))
+
+	resp = getLimits(t, h, internal)
+	assert.True(t, resp.Exempt)
+	assert.Equal(t, "tier", resp.ExemptReason)
+	require.Len(t, resp.Rules, 2)
+	assert.Nil(t, resp.Rules[0].Remaining)
+
+	req.RemoteAddr = "10.1.2.3:1234"
+	resp = getLimits(t, h, req)
+	assert.Equal(t, "ip", resp.ExemptReason)
+}
+
+func TestLimitsHandler_Disabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Rules are not validated while disabled and must not be compiled
+	h := NewLimitsHandler(config.RateLimitingConfig{
+		Rules: []config.RateLimitRule{{Name: "broken", PathPattern: "("}},
+	}, nil, nil, logger)
+
+	resp := getLimits(t, h, httptest.NewRequest(http.MethodGet, "/api/v1/limits", http.NoBody))
+	assert.False(t, resp.Enabled)
+	assert.Empty(t, resp.Rules)
+}
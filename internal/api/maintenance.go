@@ -0,0 +1,146 @@
+//nolint:tagliatelle // superior snake-case yo.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/maintenance"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// maxMaintenanceBody bounds PUT /admin/v1/maintenance request bodies.
+const maxMaintenanceBody = 16 << 10
+
+// MaintenanceScheduler schedules and clears maintenance windows.
+type MaintenanceScheduler interface {
+	Window() *maintenance.Window
+	Active() *maintenance.Window
+	Schedule(ctx context.Context, w maintenance.Window) error
+	Clear(ctx context.Context) error
+}
+
+// MaintenanceResponse is the response for GET and PUT /admin/v1/maintenance.
+type MaintenanceResponse struct {
+	Active bool                `json:"active"`
+	Window *maintenance.Window `json:"window"` // Scheduled window, active or upcoming; null when none
+}
+
+// MaintenanceRequest is the body of PUT /admin/v1/maintenance.
+type MaintenanceRequest struct {
+	Message  string         `json:"message"`   // Empty uses maintenance.message
+	Details  map[string]any `json:"details"`   // Added to API responses as is
+	StartsAt time.Time      `json:"starts_at"` // RFC 3339; empty starts now
+	EndsAt   time.Time      `json:"ends_at"`   // RFC 3339; empty lasts until cleared
+	Duration string         `json:"duration"`  // Alternative to ends_at, e.g. "30m" after starts_at
+}
+
+// MaintenanceHandler handles the /admin/v1/maintenance endpoints.
+type MaintenanceHandler struct {
+	mode   MaintenanceScheduler
+	logger logrus.FieldLogger
+}
+
+// NewMaintenanceHandler creates a new maintenance handler.
+func NewMaintenanceHandler(mode MaintenanceScheduler, logger logrus.FieldLogger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		mode:   mode,
+		logger: logger.WithField("handler", "maintenance"),
+	}
+}
+
+// Get handles GET /admin/v1/maintenance.
+func (h *MaintenanceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, http.StatusOK, h.response())
+}
+
+// Put handles PUT /admin/v1/maintenance, scheduling a window for every instance.
+func (h *MaintenanceHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxMaintenanceBody)).Decode(&req); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid request body")
+
+		return
+	}
+
+	window := maintenance.Window{
+		Message:  req.Message,
+		Details:  req.Details,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	}
+
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 || !req.EndsAt.IsZero() {
+			httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "duration must be a positive duration and excludes ends_at")
+
+			return
+		}
+
+		start := req.StartsAt
+		if start.IsZero() {
+			start = time.Now()
+		}
+
+		window.EndsAt = start.Add(duration)
+	}
+
+	err := h.mode.Schedule(r.Context(), window)
+
+	switch {
+	case errors.Is(err, maintenance.ErrInvalidWindow):
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "ends_at must be in the future and after starts_at")
+
+		return
+	case err != nil:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to schedule maintenance")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to schedule maintenance")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).WithFields(logrus.Fields{
+		"starts_at": window.StartsAt,
+		"ends_at":   window.EndsAt,
+	}).Warn("Maintenance scheduled")
+
+	h.writeJSON(w, r, http.StatusOK, h.response())
+}
+
+// Delete handles DELETE /admin/v1/maintenance, ending or cancelling the window.
+func (h *MaintenanceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.mode.Clear(r.Context()); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to clear maintenance")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to clear maintenance")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).Warn("Maintenance cleared")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MaintenanceHandler) response() MaintenanceResponse {
+	return MaintenanceResponse{
+		Active: h.mode.Active() != nil,
+		Window: h.mode.Window(),
+	}
+}
+
+func (h *MaintenanceHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ethpandaops/lab-backend/internal/api (interfaces: GasProfiler)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_gas_profiler.go github.com/ethpandaops/lab-backend/internal/api GasProfiler
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	api "github.com/ethpandaops/lab-backend/internal/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGasProfiler is a mock of GasProfiler interface.
+type MockGasProfiler struct {
+	ctrl     *gomock.Controller
+	recorder *MockGasProfilerMockRecorder
+	isgomock struct{}
+}
+
+// MockGasProfilerMockRecorder is the mock recorder for MockGasProfiler.
+type MockGasProfilerMockRecorder struct {
+	mock *MockGasProfiler
+}
+
+// NewMockGasProfiler creates a new mock instance.
+func NewMockGasProfiler(ctrl *gomock.Controller) *MockGasProfiler {
+	mock := &MockGasProfiler{ctrl: ctrl}
+	mock.recorder = &MockGasProfilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGasProfiler) EXPECT() *MockGasProfilerMockRecorder {
+	return m.recorder
+}
+
+// EndpointStats mocks base method.
+func (m *MockGasProfiler) EndpointStats() []api.GasProfilerEndpointStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndpointStats")
+	ret0, _ := ret[0].([]api.GasProfilerEndpointStats)
+	return ret0
+}
+
+// EndpointStats indicates an expected call of EndpointStats.
+func (mr *MockGasProfilerMockRecorder) EndpointStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndpointStats", reflect.TypeOf((*MockGasProfiler)(nil).EndpointStats))
+}
+
+// ServeHTTP mocks base method.
+func (m *MockGasProfiler) ServeHTTP(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ServeHTTP", arg0, arg1)
+}
+
+// ServeHTTP indicates an expected call of ServeHTTP.
+func (mr *MockGasProfilerMockRecorder) ServeHTTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeHTTP", reflect.TypeOf((*MockGasProfiler)(nil).ServeHTTP), arg0, arg1)
+}
+
+// Start mocks base method.
+func (m *MockGasProfiler) Start(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", ctx)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockGasProfilerMockRecorder) Start(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockGasProfiler)(nil).Start), ctx)
+}
+
+// Stop mocks base method.
+func (m *MockGasProfiler) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockGasProfilerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockGasProfiler)(nil).Stop))
+}
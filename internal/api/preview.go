@@ -0,0 +1,85 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// previewQueryParam lets testers open a frontend link that sets the preview cookie.
+const previewQueryParam = "preview"
+
+// previewCookieMaxAge is how long a preview cookie set from the query parameter lasts.
+const previewCookieMaxAge = 30 * 24 * 60 * 60
+
+// previewGate recognises requests allowed to see hidden networks.
+type previewGate struct {
+	tokens     [][]byte
+	cookieName string
+}
+
+func newPreviewGate(cfg config.PreviewConfig) *previewGate {
+	tokens := make([][]byte, 0, len(cfg.Tokens))
+	for _, token := range cfg.Tokens {
+		tokens = append(tokens, []byte(token))
+	}
+
+	return &previewGate{
+		tokens:     tokens,
+		cookieName: cfg.CookieName,
+	}
+}
+
+// valid reports whether token matches a configured preview token.
+func (g *previewGate) valid(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	match := 0
+	for _, candidate := range g.tokens {
+		match |= subtle.ConstantTimeCompare([]byte(token), candidate)
+	}
+
+	return match == 1
+}
+
+// allowed reports whether r carries a valid preview token in the header,
+// cookie or query parameter.
+func (g *previewGate) allowed(r *http.Request) bool {
+	if len(g.tokens) == 0 {
+		return false
+	}
+
+	if g.valid(r.Header.Get(config.PreviewHeaderName)) {
+		return true
+	}
+
+	if g.cookieName != "" {
+		if cookie, err := r.Cookie(g.cookieName); err == nil && g.valid(cookie.Value) {
+			return true
+		}
+	}
+
+	return g.valid(r.URL.Query().Get(previewQueryParam))
+}
+
+// cookie returns the preview cookie to set when r carries a valid token in the
+// query parameter, or nil.
+func (g *previewGate) cookie(r *http.Request) *http.Cookie {
+	token := r.URL.Query().Get(previewQueryParam)
+	if g.cookieName == "" || !g.valid(token) {
+		return nil
+	}
+
+	return &http.Cookie{
+		Name:     g.cookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   previewCookieMaxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
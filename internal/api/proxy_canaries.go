@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/proxy"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// CanaryManager lists, promotes and rolls back the canaries of changed
+// network target URLs.
+type CanaryManager interface {
+	Canaries() []proxy.Canary
+	PromoteCanary(ctx context.Context, network string) error
+	RollbackCanary(ctx context.Context, network string) error
+}
+
+// ProxyCanariesResponse is the response for GET /admin/v1/proxy/canaries.
+type ProxyCanariesResponse struct {
+	Canaries []proxy.Canary `json:"canaries"`
+}
+
+// ProxyCanariesHandler handles the /admin/v1/proxy/canaries endpoints.
+type ProxyCanariesHandler struct {
+	manager CanaryManager
+	logger  logrus.FieldLogger
+}
+
+// NewProxyCanariesHandler creates a new proxy canaries handler.
+func NewProxyCanariesHandler(manager CanaryManager, logger logrus.FieldLogger) *ProxyCanariesHandler {
+	return &ProxyCanariesHandler{
+		manager: manager,
+		logger:  logger.WithField("handler", "proxy_canaries"),
+	}
+}
+
+// List handles GET /admin/v1/proxy/canaries.
+func (h *ProxyCanariesHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, ProxyCanariesResponse{Canaries: h.manager.Canaries()})
+}
+
+// Promote handles POST /admin/v1/proxy/canaries/{network}/promote.
+func (h *ProxyCanariesHandler) Promote(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, proxy.CanaryPromote, h.manager.PromoteCanary)
+}
+
+// Rollback handles POST /admin/v1/proxy/canaries/{network}/rollback.
+func (h *ProxyCanariesHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, proxy.CanaryRollback, h.manager.RollbackCanary)
+}
+
+func (h *ProxyCanariesHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	apply func(ctx context.Context, network string) error,
+) {
+	network := r.PathValue("network")
+
+	if err := apply(r.Context(), network); err != nil {
+		if errors.Is(err, proxy.ErrNoCanary) {
+			httperr.New(http.StatusNotFound, httperr.CodeNotFound, "no canary for network").
+				With("network", network).
+				Write(w, r)
+
+			return
+		}
+
+		requestid.Logger(r.Context(), h.logger).WithError(err).WithFields(logrus.Fields{
+			"network": network,
+			"action":  action,
+		}).Error("Failed to apply canary decision")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to apply canary decision")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).WithFields(logrus.Fields{
+		"network": network,
+		"action":  action,
+	}).Info("Applied canary decision")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ProxyCanariesHandler) writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
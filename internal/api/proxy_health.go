@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/proxy"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*ProxyHealthHandler)(nil)
+
+// NetworkHealthSource provides the probed health of proxied networks.
+type NetworkHealthSource interface {
+	NetworkHealth() map[string]proxy.NetworkHealth
+}
+
+// ProxyHealthResponse is the response for GET /admin/v1/proxy/health.
+type ProxyHealthResponse struct {
+	Networks map[string]proxy.NetworkHealth `json:"networks"`
+}
+
+// ProxyHealthHandler handles GET /admin/v1/proxy/health requests.
+type ProxyHealthHandler struct {
+	source NetworkHealthSource
+	logger logrus.FieldLogger
+}
+
+// NewProxyHealthHandler creates a new proxy health handler.
+func NewProxyHealthHandler(source NetworkHealthSource, logger logrus.FieldLogger) *ProxyHealthHandler {
+	return &ProxyHealthHandler{
+		source: source,
+		logger: logger.WithField("handler", "proxy_health"),
+	}
+}
+
+// ServeHTTP returns the probed health of every network's backends.
+func (h *ProxyHealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := ProxyHealthResponse{Networks: h.source.NetworkHealth()}
+
+	if response.Networks == nil {
+		response.Networks = map[string]proxy.NetworkHealth{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
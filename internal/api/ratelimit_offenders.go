@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*OffendersHandler)(nil)
+
+// OffenderReporter ranks the clients denied most by rate limit rules.
+type OffenderReporter interface {
+	Top(ctx context.Context, window time.Duration, rule string, limit int) ([]ratelimit.Offender, error)
+}
+
+// OffendersResponse is the response for GET /admin/v1/ratelimit/offenders.
+type OffendersResponse struct {
+	Window    string               `json:"window"`
+	Rule      string               `json:"rule,omitempty"`
+	Offenders []ratelimit.Offender `json:"offenders"` // Most denied first
+}
+
+// OffendersHandler handles GET /admin/v1/ratelimit/offenders requests.
+// Query parameters: window (one of the configured windows), rule, limit.
+type OffendersHandler struct {
+	reporter OffenderReporter
+	cfg      config.RateLimitOffendersConfig
+	logger   logrus.FieldLogger
+}
+
+// NewOffendersHandler creates a new rate limit offenders handler.
+func NewOffendersHandler(
+	reporter OffenderReporter,
+	cfg config.RateLimitOffendersConfig,
+	logger logrus.FieldLogger,
+) *OffendersHandler {
+	return &OffendersHandler{
+		reporter: reporter,
+		cfg:      cfg,
+		logger:   logger.WithField("handler", "ratelimit_offenders"),
+	}
+}
+
+// ServeHTTP returns the top offenders over the requested window.
+func (h *OffendersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	window := h.cfg.Windows[0]
+
+	if raw := query.Get("window"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || !slices.Contains(h.cfg.Windows, parsed) {
+			httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "window must be one of "+h.windows())
+
+			return
+		}
+
+		window = parsed
+	}
+
+	limit := h.cfg.DefaultLimit
+
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > h.cfg.MaxLimit {
+			httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "limit must be between 1 and "+strconv.Itoa(h.cfg.MaxLimit))
+
+			return
+		}
+
+		limit = parsed
+	}
+
+	rule := query.Get("rule")
+
+	offenders, err := h.reporter.Top(r.Context(), window, rule, limit)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to read rate limit offenders")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "rate limit offenders unavailable")
+
+		return
+	}
+
+	response := OffendersResponse{
+		Window:    window.String(),
+		Rule:      rule,
+		Offenders: offenders,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
+
+// windows lists the configured windows for error messages.
+func (h *OffendersHandler) windows() string {
+	names := make([]string, 0, len(h.cfg.Windows))
+
+	for _, window := range h.cfg.Windows {
+		names = append(names, window.String())
+	}
+
+	return strings.Join(names, ", ")
+}
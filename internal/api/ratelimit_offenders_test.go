@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+)
+
+type fakeOffenderReporter struct {
+	window time.Duration
+	rule   string
+	limit  int
+	err    error
+}
+
+func (f *fakeOffenderReporter) Top(_ context.Context, window time.Duration, rule string, limit int) ([]ratelimit.Offender, error) {
+	f.window, f.rule, f.limit = window, rule, limit
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []ratelimit.Offender{{Client: "192.0.2.1", Rule: "api_proxy", Denied: 42}}, nil
+}
+
+func TestOffendersHandler_ServeHTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.RateLimitOffendersConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		name           string
+		query          string
+		err            error
+		expectedStatus int
+		expectedWindow time.Duration
+		expectedRule   string
+		expectedLimit  int
+	}{
+		{
+			name:           "defaults",
+			expectedStatus: http.StatusOK,
+			expectedWindow: time.Hour,
+			expectedLimit:  20,
+		},
+		{
+			name:           "window, rule and limit",
+			query:          "?window=24h&rule=api_proxy&limit=5",
+			expectedStatus: http.StatusOK,
+			expectedWindow: 24 * time.Hour,
+			expectedRule:   "api_proxy",
+			expectedLimit:  5,
+		},
+		{
+			name:           "unconfigured window",
+			query:          "?window=2h",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "limit above max",
+			query:          "?limit=1000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "redis failure",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &fakeOffenderReporter{err: tt.err}
+			handler := NewOffendersHandler(reporter, cfg, logger)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/v1/ratelimit/offenders"+tt.query, http.NoBody))
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.expectedWindow, reporter.window)
+			assert.Equal(t, tt.expectedRule, reporter.rule)
+			assert.Equal(t, tt.expectedLimit, reporter.limit)
+
+			var response OffendersResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Equal(t, tt.expectedWindow.String(), response.Window)
+			require.Len(t, response.Offenders, 1)
+			assert.Equal(t, int64(42), response.Offenders[0].Denied)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+//nolint:tagliatelle // superior snake-case yo.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// maxReadOnlyBody bounds PUT /admin/v1/read-only request bodies.
+const maxReadOnlyBody = 4 << 10
+
+// ReadOnlySwitch reports and toggles runtime read-only mode.
+type ReadOnlySwitch interface {
+	Enabled() (string, bool)
+	Set(ctx context.Context, message string) error
+	Clear(ctx context.Context) error
+}
+
+// ReadOnlyResponse is the response for GET and PUT /admin/v1/read-only.
+type ReadOnlyResponse struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// ReadOnlyRequest is the body of PUT /admin/v1/read-only.
+type ReadOnlyRequest struct {
+	Message string `json:"message"` // Empty uses read_only.message
+}
+
+// ReadOnlyHandler handles the /admin/v1/read-only endpoints.
+type ReadOnlyHandler struct {
+	mode   ReadOnlySwitch
+	logger logrus.FieldLogger
+}
+
+// NewReadOnlyHandler creates a new read-only mode handler.
+func NewReadOnlyHandler(mode ReadOnlySwitch, logger logrus.FieldLogger) *ReadOnlyHandler {
+	return &ReadOnlyHandler{
+		mode:   mode,
+		logger: logger.WithField("handler", "read_only"),
+	}
+}
+
+// Get handles GET /admin/v1/read-only.
+func (h *ReadOnlyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, http.StatusOK, h.response())
+}
+
+// Put handles PUT /admin/v1/read-only, turning read-only mode on for every
+// instance. An empty body uses the configured message.
+func (h *ReadOnlyHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req ReadOnlyRequest
+
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReadOnlyBody)).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid request body")
+
+		return
+	}
+
+	if err := h.mode.Set(r.Context(), req.Message); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to turn read-only mode on")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to turn read-only mode on")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).Warn("Read-only mode turned on")
+
+	h.writeJSON(w, r, http.StatusOK, h.response())
+}
+
+// Delete handles DELETE /admin/v1/read-only, lifting runtime read-only mode.
+// It is the one write accepted while read-only; read_only.enabled in config
+// stays in force.
+func (h *ReadOnlyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.mode.Clear(r.Context()); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to turn read-only mode off")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to turn read-only mode off")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).Warn("Read-only mode turned off")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ReadOnlyHandler) response() ReadOnlyResponse {
+	message, enabled := h.mode.Enabled()
+
+	return ReadOnlyResponse{Enabled: enabled, Message: message}
+}
+
+func (h *ReadOnlyHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReadOnlySwitch struct {
+	message string
+	enabled bool
+}
+
+func (f *fakeReadOnlySwitch) Enabled() (string, bool) { return f.message, f.enabled }
+
+func (f *fakeReadOnlySwitch) Set(_ context.Context, message string) error {
+	f.message, f.enabled = message, true
+
+	return nil
+}
+
+func (f *fakeReadOnlySwitch) Clear(context.Context) error {
+	f.message, f.enabled = "", false
+
+	return nil
+}
+
+func TestReadOnlyHandler(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	mode := &fakeReadOnlySwitch{}
+	h := NewReadOnlyHandler(mode, logger)
+
+	rec := httptest.NewRecorder()
+	h.Put(rec, httptest.NewRequest(http.MethodPut, "/admin/v1/read-only", strings.NewReader(`{"message":"migrating"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp ReadOnlyResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, ReadOnlyResponse{Enabled: true, Message: "migrating"}, resp)
+
+	rec = httptest.NewRecorder()
+	h.Delete(rec, httptest.NewRequest(http.MethodDelete, "/admin/v1/read-only", http.NoBody))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.False(t, mode.enabled)
+
+	// An empty body uses the configured message
+	rec = httptest.NewRecorder()
+	h.Put(rec, httptest.NewRequest(http.MethodPut, "/admin/v1/read-only", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, mode.enabled)
+
+	rec = httptest.NewRecorder()
+	h.Put(rec, httptest.NewRequest(http.MethodPut, "/admin/v1/read-only", strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/scheduler"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*ScheduledTasksHandler)(nil)
+
+// ScheduleReporter reports scheduled jobs and the outcome of their runs.
+type ScheduleReporter interface {
+	Statuses(ctx context.Context) ([]scheduler.Status, error)
+}
+
+// ScheduledTasksResponse is the response for GET /admin/v1/tasks.
+type ScheduledTasksResponse struct {
+	Tasks []scheduler.Status `json:"tasks"`
+}
+
+// ScheduledTasksHandler handles GET /admin/v1/tasks requests. Unlike
+// /admin/v1/runtime/tasks, which shows this instance's loops, it reports
+// the last run of each leader job, whichever instance ran it.
+type ScheduledTasksHandler struct {
+	reporter ScheduleReporter
+	logger   logrus.FieldLogger
+}
+
+// NewScheduledTasksHandler creates a new scheduled tasks handler.
+func NewScheduledTasksHandler(reporter ScheduleReporter, logger logrus.FieldLogger) *ScheduledTasksHandler {
+	return &ScheduledTasksHandler{
+		reporter: reporter,
+		logger:   logger.WithField("handler", "scheduled_tasks"),
+	}
+}
+
+// ServeHTTP returns every scheduled job with its last run.
+func (h *ScheduledTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.reporter.Statuses(r.Context())
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to read scheduled tasks")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "scheduled tasks unavailable")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(ScheduledTasksResponse{Tasks: statuses}); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/scheduler"
+)
+
+type fakeScheduleReporter struct {
+	err error
+}
+
+func (f *fakeScheduleReporter) Statuses(context.Context) ([]scheduler.Status, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []scheduler.Status{{
+		Name:     "summary.refresh",
+		Interval: "1m0s",
+		Record:   scheduler.Record{Runs: 3, Failures: 1, LastError: "timeout"},
+	}}, nil
+}
+
+func TestScheduledTasksHandler_ServeHTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	t.Run("lists jobs", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewScheduledTasksHandler(&fakeScheduleReporter{}, logger).
+			ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/v1/tasks", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+		var body map[string][]map[string]any
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		require.Len(t, body["tasks"], 1)
+
+		task := body["tasks"][0]
+		assert.Equal(t, "summary.refresh", task["name"])
+		assert.InDelta(t, 3, task["runs"], 0)
+		assert.Equal(t, "timeout", task["last_error"])
+	})
+
+	t.Run("redis unavailable", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewScheduledTasksHandler(&fakeScheduleReporter{err: errors.New("connection refused")}, logger).
+			ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/v1/tasks", http.NoBody))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/slo"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*SLOHandler)(nil)
+
+// SLOResponse is the response for GET /admin/v1/slo.
+type SLOResponse struct {
+	Window    string       `json:"window"`
+	Upstreams []slo.Report `json:"upstreams"`
+}
+
+// SLOHandler handles GET /admin/v1/slo requests.
+type SLOHandler struct {
+	service *slo.Service
+	window  string
+	logger  logrus.FieldLogger
+}
+
+// NewSLOHandler creates a new SLO handler.
+func NewSLOHandler(service *slo.Service, window string, logger logrus.FieldLogger) *SLOHandler {
+	return &SLOHandler{
+		service: service,
+		window:  window,
+		logger:  logger.WithField("handler", "slo"),
+	}
+}
+
+// ServeHTTP returns the rolling SLO status and burn rates of every upstream.
+func (h *SLOHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := SLOResponse{
+		Window:    h.window,
+		Upstreams: h.service.Reports(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+)
+
+// maxSlotTransformBody bounds PUT /admin/v1/slot-transform request bodies.
+const maxSlotTransformBody = 64 << 10
+
+// SlotTransformOverrider reports and overrides the slot transform policy.
+type SlotTransformOverrider interface {
+	Status() slottransform.Status
+	Set(ctx context.Context, policy config.SlotTransformPolicy) error
+	Clear(ctx context.Context) error
+}
+
+// SlotTransformHandler handles the /admin/v1/slot-transform endpoints.
+type SlotTransformHandler struct {
+	service SlotTransformOverrider
+	logger  logrus.FieldLogger
+}
+
+// NewSlotTransformHandler creates a new slot transform handler.
+func NewSlotTransformHandler(service SlotTransformOverrider, logger logrus.FieldLogger) *SlotTransformHandler {
+	return &SlotTransformHandler{
+		service: service,
+		logger:  logger.WithField("handler", "slot_transform"),
+	}
+}
+
+// Get handles GET /admin/v1/slot-transform, returning the configured policy
+// and the runtime override, if any.
+func (h *SlotTransformHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, http.StatusOK, h.service.Status())
+}
+
+// Put handles PUT /admin/v1/slot-transform, overriding the policy on every
+// instance with the policy in the body.
+func (h *SlotTransformHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var policy config.SlotTransformPolicy
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSlotTransformBody)).Decode(&policy); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid request body")
+
+		return
+	}
+
+	err := h.service.Set(r.Context(), policy)
+
+	switch {
+	case errors.Is(err, slottransform.ErrInvalidPolicy):
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, err.Error())
+
+		return
+	case err != nil:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to set slot transform override")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to set slot transform override")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).WithField("mode", policy.Mode).Warn("Slot transform override set")
+
+	h.writeJSON(w, r, http.StatusOK, h.service.Status())
+}
+
+// Delete handles DELETE /admin/v1/slot-transform, reverting every instance to
+// the configured policy.
+func (h *SlotTransformHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Clear(r.Context()); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to clear slot transform override")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "failed to clear slot transform override")
+
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).Warn("Slot transform override cleared")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SlotTransformHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to encode response")
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+)
+
+type fakeSlotTransform struct {
+	override *config.SlotTransformPolicy
+	err      error
+}
+
+func (f *fakeSlotTransform) Status() slottransform.Status {
+	return slottransform.Status{Override: f.override}
+}
+
+func (f *fakeSlotTransform) Set(_ context.Context, policy config.SlotTransformPolicy) error {
+	if policy.Mode == "sometimes" {
+		return fmt.Errorf("%w: unknown mode", slottransform.ErrInvalidPolicy)
+	}
+
+	if f.err != nil {
+		return f.err
+	}
+
+	f.override = &policy
+
+	return nil
+}
+
+func (f *fakeSlotTransform) Clear(context.Context) error {
+	f.override = nil
+
+	return f.err
+}
+
+func TestSlotTransformHandler(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	put := func(h *SlotTransformHandler, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.Put(rec, httptest.NewRequest(http.MethodPut, "/admin/v1/slot-transform", strings.NewReader(body)))
+
+		return rec
+	}
+
+	service := &fakeSlotTransform{}
+	h := NewSlotTransformHandler(service, logger)
+
+	rec := put(h, `{"mode":"passthrough"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"override":{"mode":"passthrough"}`)
+
+	assert.Equal(t, http.StatusBadRequest, put(h, `{"mode":"sometimes"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(h, `not json`).Code)
+
+	rec = httptest.NewRecorder()
+	h.Delete(rec, httptest.NewRequest(http.MethodDelete, "/admin/v1/slot-transform", http.NoBody))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Nil(t, service.override)
+
+	// Redis failures are not the caller's fault
+	service.err = errors.New("redis down")
+	assert.Equal(t, http.StatusServiceUnavailable, put(h, `{"mode":"transform"}`).Code)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/summary"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*SummaryHandler)(nil)
+
+// SummaryProvider returns the liveness summary of a network.
+type SummaryProvider interface {
+	Get(ctx context.Context, network string) (*summary.Summary, error)
+}
+
+// SummaryHandler handles GET /api/v1/{network}/summary requests.
+type SummaryHandler struct {
+	provider SummaryProvider
+	logger   logrus.FieldLogger
+}
+
+// NewSummaryHandler creates a new summary handler.
+func NewSummaryHandler(provider SummaryProvider, logger logrus.FieldLogger) *SummaryHandler {
+	return &SummaryHandler{
+		provider: provider,
+		logger:   logger.WithField("handler", "summary"),
+	}
+}
+
+// ServeHTTP returns the summary the leader last stored for the network.
+func (h *SummaryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	network := r.PathValue("network")
+	if network == "" {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "network parameter required")
+
+		return
+	}
+
+	networkSummary, err := h.provider.Get(r.Context(), network)
+
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found or summary unavailable")
+
+		return
+	case err != nil:
+		h.logger.WithError(err).WithField("network", network).Warn("Failed to get summary for network")
+		httperr.WriteErr(w, r, err, "network not found or summary unavailable")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(networkSummary); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
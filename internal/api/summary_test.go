@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/summary"
+)
+
+type fakeSummaryProvider map[string]*summary.Summary
+
+func (f fakeSummaryProvider) Get(_ context.Context, network string) (*summary.Summary, error) {
+	if network == "broken" {
+		return nil, fmt.Errorf("read summary: %w", errs.ErrUpstreamUnavailable)
+	}
+
+	s, ok := f[network]
+	if !ok {
+		return nil, fmt.Errorf("summary for %s: %w", network, errs.ErrNotFound)
+	}
+
+	return s, nil
+}
+
+func TestSummaryHandler_ServeHTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	provider := fakeSummaryProvider{
+		"mainnet": {
+			Network:     "mainnet",
+			Counts:      map[string]int64{"nodes": 42},
+			LastUpdated: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/{network}/summary", NewSummaryHandler(provider, logger))
+
+	tests := []struct {
+		network        string
+		expectedStatus int
+	}{
+		{network: "mainnet", expectedStatus: http.StatusOK},
+		{network: "unknown", expectedStatus: http.StatusNotFound},
+		{network: "broken", expectedStatus: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/"+tt.network+"/summary", http.NoBody))
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var got summary.Summary
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+			assert.Equal(t, "mainnet", got.Network)
+			assert.Equal(t, int64(42), got.Counts["nodes"])
+		})
+	}
+}
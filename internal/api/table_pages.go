@@ -0,0 +1,278 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*TablePagesHandler)(nil)
+
+// tablePagesReservedParams are query parameters read by the pages endpoint
+// itself; all others are added to every page URL.
+var tablePagesReservedParams = []string{"slot_gte", "slot_lte", "page_size", "page_token"}
+
+// TablePage is one page of a table's slot range, with the proxy URL that
+// queries it. Timestamps cover the page's slots, the end is exclusive.
+type TablePage struct {
+	SlotGTE      uint64 `json:"slot_gte"`
+	SlotLTE      uint64 `json:"slot_lte"`
+	TimestampGTE int64  `json:"timestamp_gte"`
+	TimestampLT  int64  `json:"timestamp_lt"`
+	URL          string `json:"url"`
+}
+
+// TablePagesResponse is the response for GET /api/v1/{network}/tables/{table}/pages.
+type TablePagesResponse struct {
+	Network     string      `json:"network"`
+	Table       string      `json:"table"`
+	SlotGTE     uint64      `json:"slot_gte"`  // Requested range clamped to the table's bounds
+	SlotLTE     uint64      `json:"slot_lte"`  // Inclusive
+	PageSize    uint64      `json:"page_size"` // Slots per page
+	Pages       []TablePage `json:"pages"`
+	Truncated   bool        `json:"truncated"`
+	NextSlotGTE *uint64     `json:"next_slot_gte,omitempty"` // Where to continue a truncated list
+}
+
+// TablePagesHandler handles GET /api/v1/{network}/tables/{table}/pages
+// requests. It splits a slot range of a time-based table into pages of
+// page_size slots, clamped to the table's bounds, and returns the proxy URL
+// querying each page.
+type TablePagesHandler struct {
+	cfg       config.TablePagesConfig
+	provider  bounds.Provider
+	wallclock *wallclock.Service
+	logger    logrus.FieldLogger
+}
+
+// NewTablePagesHandler creates a new table pages handler.
+func NewTablePagesHandler(
+	cfg config.TablePagesConfig,
+	provider bounds.Provider,
+	wallclockSvc *wallclock.Service,
+	logger logrus.FieldLogger,
+) *TablePagesHandler {
+	return &TablePagesHandler{
+		cfg:       cfg,
+		provider:  provider,
+		wallclock: wallclockSvc,
+		logger:    logger.WithField("handler", "table_pages"),
+	}
+}
+
+// pagesQuery is a parsed pages request. Unset slots default to the table's bounds.
+type pagesQuery struct {
+	slotGTE  *uint64
+	slotLTE  *uint64
+	pageSize uint64
+	extra    url.Values // Added to every page URL
+}
+
+// ServeHTTP computes the pages of the requested table and slot range.
+func (h *TablePagesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	network := r.PathValue("network")
+	table := r.PathValue("table")
+
+	if !tableNamePattern.MatchString(table) {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, fmt.Sprintf("invalid table name %q", table))
+
+		return
+	}
+
+	query, err := h.parseQuery(r.URL.Query())
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, err.Error())
+
+		return
+	}
+
+	boundsData, err := h.provider.GetBounds(r.Context(), network)
+
+	switch {
+	case errors.Is(err, errs.ErrStale):
+		w.Header().Set("Warning", staleWarning)
+	case errors.Is(err, errs.ErrNotFound):
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found or bounds unavailable")
+
+		return
+	case err != nil:
+		h.logger.WithError(err).WithField("network", network).Warn("Failed to get bounds for network")
+		httperr.WriteErr(w, r, err, "network not found or bounds unavailable")
+
+		return
+	}
+
+	tb, ok := boundsData.Tables[table]
+	if !ok {
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNotFound, fmt.Sprintf("no bounds for table %s", table))
+
+		return
+	}
+
+	translator, ok := newBoundsTranslator(h.wallclock, network)
+	if !ok {
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "slot timing unavailable for network")
+
+		return
+	}
+
+	slots, ok := translator.translate(tb, BoundsUnitSlot)
+	if !ok {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, fmt.Sprintf("table %s is not slot-based", table))
+
+		return
+	}
+
+	response := h.pages(network, table, translator, slots, query)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
+
+// parseQuery validates the slot range and page size of a request.
+func (h *TablePagesHandler) parseQuery(values url.Values) (*pagesQuery, error) {
+	query := &pagesQuery{pageSize: h.cfg.DefaultPageSize}
+
+	if raw := values.Get("slot_gte"); raw != "" {
+		slot, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("slot_gte must be a slot number")
+		}
+
+		query.slotGTE = &slot
+	}
+
+	if raw := values.Get("slot_lte"); raw != "" {
+		slot, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("slot_lte must be a slot number")
+		}
+
+		query.slotLTE = &slot
+	}
+
+	if query.slotGTE != nil && query.slotLTE != nil && *query.slotLTE < *query.slotGTE {
+		return nil, fmt.Errorf("slot_lte must not be below slot_gte")
+	}
+
+	if raw := values.Get("page_size"); raw != "" {
+		size, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || size == 0 {
+			return nil, fmt.Errorf("page_size must be a positive number of slots")
+		}
+
+		query.pageSize = size
+	}
+
+	query.extra = make(url.Values, len(values))
+
+	for key, vals := range values {
+		if !slices.Contains(tablePagesReservedParams, key) {
+			query.extra[key] = vals
+		}
+	}
+
+	return query, nil
+}
+
+// pages splits the requested range, clamped to the table's slots (max
+// exclusive), into at most max_pages pages.
+func (h *TablePagesHandler) pages(
+	network string,
+	table string,
+	translator *boundsTranslator,
+	slots bounds.TableBounds,
+	query *pagesQuery,
+) TablePagesResponse {
+	response := TablePagesResponse{
+		Network:  network,
+		Table:    table,
+		PageSize: query.pageSize,
+		Pages:    []TablePage{},
+	}
+
+	// An empty table has no slots to page through
+	if slots.Max <= slots.Min || slots.Max <= 0 {
+		return response
+	}
+
+	first := uint64(max(slots.Min, 0)) //nolint:gosec // clamped to non-negative
+	last := uint64(slots.Max - 1)      //nolint:gosec // positive
+
+	if query.slotGTE != nil {
+		first = max(first, *query.slotGTE)
+	}
+
+	if query.slotLTE != nil {
+		last = min(last, *query.slotLTE)
+	}
+
+	// The requested range is outside the table's bounds
+	if first > last {
+		return response
+	}
+
+	response.SlotGTE, response.SlotLTE = first, last
+
+	for start := first; start <= last; start += query.pageSize {
+		if len(response.Pages) == h.cfg.MaxPages {
+			next := start
+			response.Truncated = true
+			response.NextSlotGTE = &next
+
+			break
+		}
+
+		end := last
+		if last-start >= query.pageSize {
+			end = start + query.pageSize - 1
+		}
+
+		response.Pages = append(response.Pages, translator.page(network, table, start, end, query.extra))
+
+		// Stop before start wraps around
+		if end == last {
+			break
+		}
+	}
+
+	return response
+}
+
+// page returns the page of slots start to end, with its proxy URL.
+func (t *boundsTranslator) page(network, table string, start, end uint64, extra url.Values) TablePage {
+	values := make(url.Values, len(extra)+2)
+	for key, vals := range extra {
+		values[key] = vals
+	}
+
+	values.Set("slot_gte", strconv.FormatUint(start, 10))
+	values.Set("slot_lte", strconv.FormatUint(end, 10))
+
+	genesis := t.timing.GenesisTime.Unix()
+	secondsPerSlot := int64(t.timing.SecondsPerSlot) //nolint:gosec // small config values
+
+	return TablePage{
+		SlotGTE:      start,
+		SlotLTE:      end,
+		TimestampGTE: genesis + int64(start)*secondsPerSlot, //nolint:gosec // slots within bounds
+		TimestampLT:  genesis + int64(end+1)*secondsPerSlot, //nolint:gosec // slots within bounds
+		URL:          "/api/v1/" + network + "/" + table + "?" + values.Encode(),
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+func TestTablePagesHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	genesis := int64(1606824023)
+
+	wallclockSvc := wallclock.New(logger)
+	require.NoError(t, wallclockSvc.AddNetwork(wallclock.NetworkConfig{Name: "mainnet", GenesisTime: time.Unix(genesis, 0)}))
+
+	mockProvider := boundsmocks.NewMockProvider(ctrl)
+	mockProvider.EXPECT().
+		GetBounds(gomock.Any(), "mainnet").
+		Return(&bounds.BoundsData{
+			Tables: map[string]bounds.TableBounds{
+				// Slots 64 to 99
+				"fct_block": {Min: genesis + 64*12, Max: genesis + 99*12 + 5},
+				// Block numbers
+				"canonical_execution_block": {Min: 0, Max: 21000000},
+			},
+		}, nil).
+		AnyTimes()
+	mockProvider.EXPECT().
+		GetBounds(gomock.Any(), "sepolia").
+		Return(nil, errs.ErrNotFound).
+		AnyTimes()
+
+	cfg := config.TablePagesConfig{MaxPages: 3}
+	require.NoError(t, cfg.Validate())
+
+	handler := NewTablePagesHandler(cfg, mockProvider, wallclockSvc, logger)
+
+	serve := func(network, table, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/"+network+"/tables/"+table+"/pages?"+query, http.NoBody)
+		req.SetPathValue("network", network)
+		req.SetPathValue("table", table)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	decode := func(rec *httptest.ResponseRecorder) TablePagesResponse {
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response TablePagesResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+		return response
+	}
+
+	t.Run("clamped to bounds", func(t *testing.T) {
+		response := decode(serve("mainnet", "fct_block", "slot_gte=0&slot_lte=1000&page_size=16&order_by=slot"))
+
+		assert.Equal(t, uint64(64), response.SlotGTE)
+		assert.Equal(t, uint64(99), response.SlotLTE)
+		assert.False(t, response.Truncated)
+		require.Len(t, response.Pages, 3)
+
+		assert.Equal(t, TablePage{
+			SlotGTE:      64,
+			SlotLTE:      79,
+			TimestampGTE: genesis + 64*12,
+			TimestampLT:  genesis + 80*12,
+			URL:          "/api/v1/mainnet/fct_block?order_by=slot&slot_gte=64&slot_lte=79",
+		}, response.Pages[0])
+		assert.Equal(t, uint64(96), response.Pages[2].SlotGTE)
+		assert.Equal(t, uint64(99), response.Pages[2].SlotLTE)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		response := decode(serve("mainnet", "fct_block", "page_size=4"))
+
+		assert.True(t, response.Truncated)
+		require.Len(t, response.Pages, 3)
+		require.NotNil(t, response.NextSlotGTE)
+		assert.Equal(t, uint64(76), *response.NextSlotGTE)
+	})
+
+	t.Run("outside bounds", func(t *testing.T) {
+		response := decode(serve("mainnet", "fct_block", "slot_gte=500"))
+
+		assert.Empty(t, response.Pages)
+	})
+
+	assert.Equal(t, http.StatusBadRequest, serve("mainnet", "canonical_execution_block", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("mainnet", "fct_block", "slot_gte=10&slot_lte=5").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("mainnet", "fct_block", "page_size=0").Code)
+	assert.Equal(t, http.StatusNotFound, serve("mainnet", "fct_missing", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve("sepolia", "fct_block", "").Code)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*TasksHandler)(nil)
+
+// TasksResponse is the response for GET /admin/v1/runtime/tasks.
+type TasksResponse struct {
+	Tasks []tasks.Status `json:"tasks"`
+}
+
+// TasksHandler handles GET /admin/v1/runtime/tasks requests.
+type TasksHandler struct {
+	registry *tasks.Registry
+	logger   logrus.FieldLogger
+}
+
+// NewTasksHandler creates a new tasks handler.
+func NewTasksHandler(registry *tasks.Registry, logger logrus.FieldLogger) *TasksHandler {
+	return &TasksHandler{
+		registry: registry,
+		logger:   logger.WithField("handler", "tasks"),
+	}
+}
+
+// ServeHTTP returns the state of every registered background loop.
+func (h *TasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := TasksResponse{
+		Tasks: h.registry.Snapshot(time.Now()),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
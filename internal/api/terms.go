@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/terms"
+)
+
+// TermsResponse is the response for GET /api/v1/terms.
+type TermsResponse struct {
+	Version string   `json:"version"`
+	URL     string   `json:"url,omitempty"`
+	Classes []string `json:"classes"`
+}
+
+// TermsAcceptResponse is the response for POST /api/v1/terms/accept.
+type TermsAcceptResponse struct {
+	Version   string    `json:"version"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TermsHandler handles the terms of use acknowledgment flow.
+type TermsHandler struct {
+	manager *terms.Manager
+	logger  logrus.FieldLogger
+}
+
+// NewTermsHandler creates a new terms handler.
+func NewTermsHandler(manager *terms.Manager, logger logrus.FieldLogger) *TermsHandler {
+	return &TermsHandler{
+		manager: manager,
+		logger:  logger.WithField("handler", "terms"),
+	}
+}
+
+// Info handles GET /api/v1/terms, describing the current terms and gated endpoint classes.
+func (h *TermsHandler) Info(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, TermsResponse{
+		Version: h.manager.Version(),
+		URL:     h.manager.URL(),
+		Classes: h.manager.Classes(),
+	})
+}
+
+// Accept handles POST /api/v1/terms/accept, issuing a signed acknowledgment.
+// The token is set as a cookie for browsers and returned in the body for
+// programmatic clients, which send it back in the X-Lab-Terms-Token header.
+func (h *TermsHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	token, expiresAt := h.manager.Issue(time.Now())
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     terms.CookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	h.logger.WithField("version", h.manager.Version()).Debug("Terms of use accepted")
+
+	h.writeJSON(w, r, TermsAcceptResponse{
+		Version:   h.manager.Version(),
+		Token:     token,
+		ExpiresAt: expiresAt.UTC(),
+	})
+}
+
+func (h *TermsHandler) writeJSON(w http.ResponseWriter, r *http.Request, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
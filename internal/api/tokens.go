@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// maxTokenRequestBody bounds token request and verify bodies.
+const maxTokenRequestBody = 4 << 10
+
+// TokenIssuer issues API keys to verified email addresses.
+type TokenIssuer interface {
+	Request(ctx context.Context, email string) error
+	Verify(ctx context.Context, code string) (string, auth.Key, error)
+}
+
+// IssuedTokenResponse is the response for POST /api/v1/tokens/verify.
+type IssuedTokenResponse struct {
+	Key       string     `json:"key"`
+	ID        string     `json:"id"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Networks  []string   `json:"networks,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+}
+
+// TokensHandler handles self-service API key issuance:
+// POST /api/v1/tokens/request with {"email": ...} and
+// POST /api/v1/tokens/verify with {"code": ...}.
+type TokensHandler struct {
+	issuer TokenIssuer
+	logger logrus.FieldLogger
+}
+
+// NewTokensHandler creates a new token issuance handler.
+func NewTokensHandler(issuer TokenIssuer, logger logrus.FieldLogger) *TokensHandler {
+	return &TokensHandler{
+		issuer: issuer,
+		logger: logger.WithField("handler", "tokens"),
+	}
+}
+
+// Request emails a verification code. It answers 202 whether or not an
+// email was sent, so callers can't probe for pending requests.
+func (h *TokensHandler) Request(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Email string `json:"email"`
+	}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTokenRequestBody)).Decode(&body); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid request body")
+
+		return
+	}
+
+	err := h.issuer.Request(r.Context(), body.Email)
+
+	switch {
+	case errors.Is(err, auth.ErrInvalidEmail):
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid or disallowed email address")
+
+		return
+	case err != nil:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to send verification code")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "token issuance unavailable")
+
+		return
+	}
+
+	h.writeJSON(w, http.StatusAccepted, map[string]string{"status": "verification_sent"})
+}
+
+// Verify exchanges a verification code for an API key.
+func (h *TokensHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Code string `json:"code"`
+	}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTokenRequestBody)).Decode(&body); err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid request body")
+
+		return
+	}
+
+	token, key, err := h.issuer.Verify(r.Context(), body.Code)
+
+	switch {
+	case errors.Is(err, auth.ErrInvalidCode):
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid or expired verification code")
+
+		return
+	case err != nil:
+		requestid.Logger(r.Context(), h.logger).WithError(err).Error("Failed to issue API key")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "token issuance unavailable")
+
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, IssuedTokenResponse{
+		Key:       token,
+		ID:        key.ID,
+		ExpiresAt: key.ExpiresAt,
+		Networks:  key.Networks,
+		Scopes:    key.Scopes,
+	})
+}
+
+func (h *TokensHandler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
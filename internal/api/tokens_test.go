@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+)
+
+type fakeIssuer struct {
+	err error
+}
+
+func (f *fakeIssuer) Request(_ context.Context, email string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if !strings.Contains(email, "@") {
+		return auth.ErrInvalidEmail
+	}
+
+	return nil
+}
+
+func (f *fakeIssuer) Verify(_ context.Context, code string) (string, auth.Key, error) {
+	if f.err != nil {
+		return "", auth.Key{}, f.err
+	}
+
+	if code != "good" {
+		return "", auth.Key{}, auth.ErrInvalidCode
+	}
+
+	return "lab_secret", auth.Key{ID: "self:abc", Networks: []string{"hoodi"}}, nil
+}
+
+func TestTokensHandler(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	tests := []struct {
+		name           string
+		verify         bool
+		body           string
+		issuerErr      error
+		expectedStatus int
+	}{
+		{name: "request accepted", body: `{"email":"a@example.org"}`, expectedStatus: http.StatusAccepted},
+		{name: "request invalid email", body: `{"email":"nope"}`, expectedStatus: http.StatusBadRequest},
+		{name: "request bad json", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "request redis down", body: `{"email":"a@example.org"}`, issuerErr: errors.New("down"), expectedStatus: http.StatusServiceUnavailable},
+		{name: "verify issues key", verify: true, body: `{"code":"good"}`, expectedStatus: http.StatusCreated},
+		{name: "verify bad code", verify: true, body: `{"code":"bad"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTokensHandler(&fakeIssuer{err: tt.issuerErr}, logger)
+
+			serve, path := handler.Request, "/api/v1/tokens/request"
+			if tt.verify {
+				serve, path = handler.Verify, "/api/v1/tokens/verify"
+			}
+
+			rec := httptest.NewRecorder()
+			serve(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if rec.Code == http.StatusCreated {
+				var resp IssuedTokenResponse
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, "lab_secret", resp.Key)
+				assert.Equal(t, []string{"hoodi"}, resp.Networks)
+				assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*UpstreamsHandler)(nil)
+
+// UpstreamsResponse is the response for GET /admin/v1/upstreams.
+type UpstreamsResponse struct {
+	Since     time.Time            `json:"since"`
+	Upstreams []upstream.HostStats `json:"upstreams"`
+}
+
+// UpstreamsHandler handles GET /admin/v1/upstreams requests.
+type UpstreamsHandler struct {
+	tracker *upstream.Tracker
+	logger  logrus.FieldLogger
+}
+
+// NewUpstreamsHandler creates a new upstreams handler.
+func NewUpstreamsHandler(tracker *upstream.Tracker, logger logrus.FieldLogger) *UpstreamsHandler {
+	return &UpstreamsHandler{
+		tracker: tracker,
+		logger:  logger.WithField("handler", "upstreams"),
+	}
+}
+
+// ServeHTTP returns outbound request stats per subsystem and upstream host.
+func (h *UpstreamsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := UpstreamsResponse{
+		Since:     h.tracker.Since(),
+		Upstreams: h.tracker.Snapshot(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "internal server error")
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*VersionHandler)(nil)
+
+// VersionEpochResponse is the response for GET /api/v1/version/epoch.
+type VersionEpochResponse struct {
+	Epoch int64 `json:"epoch"` // Build epoch, 0 when unknown
+}
+
+// VersionHandler handles GET /api/v1/version and /api/v1/version/epoch requests.
+type VersionHandler struct {
+	summary version.Summary
+	epoch   int64
+	logger  logrus.FieldLogger
+}
+
+// NewVersionHandler creates a new version handler for the build summary,
+// which cannot change while the process runs.
+func NewVersionHandler(summary version.Summary, logger logrus.FieldLogger) *VersionHandler {
+	return &VersionHandler{
+		summary: summary,
+		epoch:   version.BuildEpoch(),
+		logger:  logger.WithField("handler", "version"),
+	}
+}
+
+// ServeHTTP returns the version, build and enabled features of the backend.
+func (h *VersionHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.writeJSON(w, h.summary)
+}
+
+// Epoch returns the build epoch alone, for clients polling for deploys.
+func (h *VersionHandler) Epoch(w http.ResponseWriter, _ *http.Request) {
+	h.writeJSON(w, VersionEpochResponse{Epoch: h.epoch})
+}
+
+func (h *VersionHandler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
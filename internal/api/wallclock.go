@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/ethwallclock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+// conversionCacheControl lets clients cache conversions, which only change
+// if a network is re-created with a new genesis.
+const conversionCacheControl = "public, max-age=300"
+
+// WallclockResponse is the response for GET /api/v1/{network}/wallclock.
+// Slot and epoch are omitted before genesis.
+type WallclockResponse struct {
+	Network        string     `json:"network"`
+	GenesisTime    int64      `json:"genesis_time"`
+	SecondsPerSlot int64      `json:"seconds_per_slot"`
+	SlotsPerEpoch  uint64     `json:"slots_per_epoch"`
+	Slot           *SlotInfo  `json:"slot,omitempty"`
+	Epoch          *EpochInfo `json:"epoch,omitempty"`
+}
+
+// SlotInfo describes a slot and its time window, as unix timestamps in seconds.
+type SlotInfo struct {
+	Slot      uint64 `json:"slot"`
+	Epoch     uint64 `json:"epoch"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+}
+
+// EpochInfo describes an epoch's slot range and time window, as unix timestamps in seconds.
+type EpochInfo struct {
+	Epoch     uint64 `json:"epoch"`
+	FirstSlot uint64 `json:"first_slot"`
+	LastSlot  uint64 `json:"last_slot"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+}
+
+// WallclockHandler exposes slot and epoch conversions from the wallclock service.
+type WallclockHandler struct {
+	service *wallclock.Service
+	logger  logrus.FieldLogger
+}
+
+// NewWallclockHandler creates a new wallclock handler.
+func NewWallclockHandler(service *wallclock.Service, logger logrus.FieldLogger) *WallclockHandler {
+	return &WallclockHandler{
+		service: service,
+		logger:  logger.WithField("handler", "wallclock"),
+	}
+}
+
+// Current handles GET /api/v1/{network}/wallclock, returning the current slot and epoch.
+func (h *WallclockHandler) Current(w http.ResponseWriter, r *http.Request) {
+	network := r.PathValue("network")
+
+	wc, ok := h.wallclock(w, r, network)
+	if !ok {
+		return
+	}
+
+	response := WallclockResponse{
+		Network:        network,
+		GenesisTime:    wc.cfg.GenesisTime.Unix(),
+		SecondsPerSlot: int64(wc.cfg.SecondsPerSlot), //nolint:gosec // slot durations are small.
+		SlotsPerEpoch:  wc.cfg.SlotsPerEpoch,
+	}
+
+	if now := time.Now(); !now.Before(wc.cfg.GenesisTime) {
+		slot := wc.slotInfo(wc.slotAt(now))
+		epoch := wc.epochInfo(slot.Epoch)
+
+		response.Slot = &slot
+		response.Epoch = &epoch
+	}
+
+	h.writeJSON(w, "no-store", response)
+}
+
+// Slot handles GET /api/v1/{network}/wallclock/slots/{slot}, returning the slot's time window.
+func (h *WallclockHandler) Slot(w http.ResponseWriter, r *http.Request) {
+	slot, err := strconv.ParseUint(r.PathValue("slot"), 10, 64)
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "slot must be a non-negative integer")
+
+		return
+	}
+
+	wc, ok := h.wallclock(w, r, r.PathValue("network"))
+	if !ok {
+		return
+	}
+
+	if slot >= wc.maxSlot() {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "slot out of range")
+
+		return
+	}
+
+	h.writeJSON(w, conversionCacheControl, wc.slotInfo(slot))
+}
+
+// Epoch handles GET /api/v1/{network}/wallclock/epochs/{epoch}, returning the
+// epoch's slot range and time window.
+func (h *WallclockHandler) Epoch(w http.ResponseWriter, r *http.Request) {
+	epoch, err := strconv.ParseUint(r.PathValue("epoch"), 10, 64)
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "epoch must be a non-negative integer")
+
+		return
+	}
+
+	wc, ok := h.wallclock(w, r, r.PathValue("network"))
+	if !ok {
+		return
+	}
+
+	if epoch >= wc.maxSlot()/wc.cfg.SlotsPerEpoch {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "epoch out of range")
+
+		return
+	}
+
+	h.writeJSON(w, conversionCacheControl, wc.epochInfo(epoch))
+}
+
+// Timestamp handles GET /api/v1/{network}/wallclock/timestamps/{timestamp},
+// returning the slot containing a unix timestamp in seconds.
+func (h *WallclockHandler) Timestamp(w http.ResponseWriter, r *http.Request) {
+	timestamp, err := strconv.ParseInt(r.PathValue("timestamp"), 10, 64)
+	if err != nil {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "timestamp must be a unix timestamp in seconds")
+
+		return
+	}
+
+	wc, ok := h.wallclock(w, r, r.PathValue("network"))
+	if !ok {
+		return
+	}
+
+	t := time.Unix(timestamp, 0)
+	if t.Before(wc.cfg.GenesisTime) {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "timestamp is before genesis")
+
+		return
+	}
+
+	slot := wc.slotAt(t)
+	if slot >= wc.maxSlot() {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "timestamp out of range")
+
+		return
+	}
+
+	h.writeJSON(w, conversionCacheControl, wc.slotInfo(slot))
+}
+
+// networkClock is a network's wallclock and the timing it was created with.
+type networkClock struct {
+	wc  *ethwallclock.EthereumBeaconChain
+	cfg wallclock.NetworkConfig
+}
+
+// wallclock returns the network's wallclock, writing an error response if unavailable.
+func (h *WallclockHandler) wallclock(w http.ResponseWriter, r *http.Request, network string) (*networkClock, bool) {
+	if h.service == nil {
+		h.logger.Error("Wallclock service not available")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeServiceUnavailable, "wallclock service unavailable")
+
+		return nil, false
+	}
+
+	wc := h.service.GetWallclock(network)
+	cfg, exists := h.service.GetConfig(network)
+
+	if wc == nil || !exists {
+		httperr.Write(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found or wallclock unavailable")
+
+		return nil, false
+	}
+
+	return &networkClock{wc: wc, cfg: cfg}, true
+}
+
+func (h *WallclockHandler) writeJSON(w http.ResponseWriter, cacheControl string, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.WithError(err).Error("Failed to encode response")
+	}
+}
+
+// maxSlot is the first slot whose end time no longer fits in a time.Duration from genesis.
+func (c *networkClock) maxSlot() uint64 {
+	slotDuration := time.Duration(c.cfg.SecondsPerSlot) * time.Second //nolint:gosec // slot durations are small.
+
+	return uint64(math.MaxInt64/slotDuration) - 1 //nolint:gosec // slot duration is positive.
+}
+
+func (c *networkClock) slotAt(t time.Time) uint64 {
+	slot := c.wc.Slots().FromTime(t)
+
+	return slot.Number()
+}
+
+func (c *networkClock) slotInfo(number uint64) SlotInfo {
+	slot := c.wc.Slots().FromNumber(number)
+	window := slot.TimeWindow()
+
+	return SlotInfo{
+		Slot:      number,
+		Epoch:     number / c.cfg.SlotsPerEpoch,
+		StartTime: window.Start().Unix(),
+		EndTime:   window.End().Unix(),
+	}
+}
+
+func (c *networkClock) epochInfo(number uint64) EpochInfo {
+	epoch := c.wc.Epochs().FromNumber(number)
+	window := epoch.TimeWindow()
+
+	return EpochInfo{
+		Epoch:     number,
+		FirstSlot: number * c.cfg.SlotsPerEpoch,
+		LastSlot:  (number+1)*c.cfg.SlotsPerEpoch - 1,
+		StartTime: window.Start().Unix(),
+		EndTime:   window.End().Unix(),
+	}
+}
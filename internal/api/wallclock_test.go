@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+// mainnetGenesis is the mainnet beacon chain genesis time.
+const mainnetGenesis = 1606824023
+
+func newTestWallclockMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	service := wallclock.New(logger)
+	require.NoError(t, service.AddNetwork(wallclock.NetworkConfig{
+		Name:        "mainnet",
+		GenesisTime: time.Unix(mainnetGenesis, 0),
+	}))
+	require.NoError(t, service.AddNetwork(wallclock.NetworkConfig{
+		Name:           "devnet",
+		GenesisTime:    time.Unix(mainnetGenesis, 0),
+		SecondsPerSlot: 6,
+		SlotsPerEpoch:  8,
+	}))
+	require.NoError(t, service.AddNetwork(wallclock.NetworkConfig{
+		Name:        "future",
+		GenesisTime: time.Now().Add(time.Hour),
+	}))
+	t.Cleanup(func() { _ = service.Stop() })
+
+	handler := NewWallclockHandler(service, logger)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/{network}/wallclock", handler.Current)
+	mux.HandleFunc("GET /api/v1/{network}/wallclock/slots/{slot}", handler.Slot)
+	mux.HandleFunc("GET /api/v1/{network}/wallclock/epochs/{epoch}", handler.Epoch)
+	mux.HandleFunc("GET /api/v1/{network}/wallclock/timestamps/{timestamp}", handler.Timestamp)
+
+	return mux
+}
+
+func serveWallclock(t *testing.T, mux *http.ServeMux, path string, resp any) int {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	if rec.Code == http.StatusOK && resp != nil {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(resp))
+	}
+
+	return rec.Code
+}
+
+func TestWallclockHandler_Current(t *testing.T) {
+	mux := newTestWallclockMux(t)
+
+	var resp WallclockResponse
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/mainnet/wallclock", &resp))
+	assert.Equal(t, int64(mainnetGenesis), resp.GenesisTime)
+	assert.Equal(t, int64(12), resp.SecondsPerSlot)
+	assert.Equal(t, uint64(32), resp.SlotsPerEpoch)
+
+	require.NotNil(t, resp.Slot)
+	require.NotNil(t, resp.Epoch)
+
+	now := time.Now().Unix()
+	assert.LessOrEqual(t, resp.Slot.StartTime, now)
+	assert.Greater(t, resp.Slot.EndTime, now-1)
+	assert.Equal(t, resp.Slot.Epoch, resp.Epoch.Epoch)
+
+	// Before genesis only the network parameters are returned
+	resp = WallclockResponse{}
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/future/wallclock", &resp))
+	assert.Nil(t, resp.Slot)
+	assert.Nil(t, resp.Epoch)
+
+	assert.Equal(t, http.StatusNotFound, serveWallclock(t, mux, "/api/v1/unknown/wallclock", nil))
+}
+
+func TestWallclockHandler_Conversions(t *testing.T) {
+	mux := newTestWallclockMux(t)
+
+	var slot SlotInfo
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/mainnet/wallclock/slots/100", &slot))
+	assert.Equal(t, SlotInfo{
+		Slot:      100,
+		Epoch:     3,
+		StartTime: mainnetGenesis + 1200,
+		EndTime:   mainnetGenesis + 1212,
+	}, slot)
+
+	slot = SlotInfo{}
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/mainnet/wallclock/timestamps/1606825229", &slot))
+	assert.Equal(t, uint64(100), slot.Slot)
+
+	var epoch EpochInfo
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/mainnet/wallclock/epochs/3", &epoch))
+	assert.Equal(t, EpochInfo{
+		Epoch:     3,
+		FirstSlot: 96,
+		LastSlot:  127,
+		StartTime: mainnetGenesis + 96*12,
+		EndTime:   mainnetGenesis + 128*12,
+	}, epoch)
+}
+
+func TestWallclockHandler_InvalidInput(t *testing.T) {
+	mux := newTestWallclockMux(t)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "non-numeric slot", path: "/api/v1/mainnet/wallclock/slots/head"},
+		{name: "negative slot", path: "/api/v1/mainnet/wallclock/slots/-1"},
+		{name: "slot overflow", path: "/api/v1/mainnet/wallclock/slots/18446744073709551615"},
+		{name: "epoch overflow", path: "/api/v1/mainnet/wallclock/epochs/18446744073709551615"},
+		{name: "timestamp before genesis", path: "/api/v1/mainnet/wallclock/timestamps/1000"},
+		{name: "timestamp overflow", path: "/api/v1/mainnet/wallclock/timestamps/9223372036854775807"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, http.StatusBadRequest, serveWallclock(t, mux, tt.path, nil))
+		})
+	}
+}
+
+func TestWallclockHandler_CustomTiming(t *testing.T) {
+	mux := newTestWallclockMux(t)
+
+	var resp WallclockResponse
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/devnet/wallclock", &resp))
+	assert.Equal(t, int64(6), resp.SecondsPerSlot)
+	assert.Equal(t, uint64(8), resp.SlotsPerEpoch)
+
+	var epoch EpochInfo
+
+	require.Equal(t, http.StatusOK, serveWallclock(t, mux, "/api/v1/devnet/wallclock/epochs/3", &epoch))
+	assert.Equal(t, EpochInfo{
+		Epoch:     3,
+		FirstSlot: 24,
+		LastSlot:  31,
+		StartTime: mainnetGenesis + 24*6,
+		EndTime:   mainnetGenesis + 32*6,
+	}, epoch)
+}
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+// maxCacheEntries bounds the in-memory lookup cache.
+const maxCacheEntries = 10000
+
+var (
+	// ErrInvalidKey is returned for unknown, disabled or expired API keys.
+	ErrInvalidKey = errors.New("invalid api key")
+
+	// ErrInvalidTier is returned for key records with an unknown tier.
+	ErrInvalidTier = errors.New("invalid api key tier")
+
+	// ErrInvalidScope is returned for key records scoped to unknown endpoint classes.
+	ErrInvalidScope = errors.New("invalid api key scope")
+)
+
+// Key is an API key record, stored in Redis as JSON under the configured
+// key prefix followed by HashToken of the key itself.
+// Networks and Scopes narrow what the key may access; empty allows everything.
+type Key struct {
+	ID        string     `json:"id"`
+	Tier      string     `json:"tier"`
+	Name      string     `json:"name,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Networks  []string   `json:"networks,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+}
+
+// Validate checks the key's tier and scopes against the known values, so a
+// typo does not create a key that can reach nothing.
+func (k *Key) Validate() error {
+	if !slices.Contains(config.Tiers, k.Tier) {
+		return fmt.Errorf("%w %q (valid: %v)", ErrInvalidTier, k.Tier, config.Tiers)
+	}
+
+	for _, scope := range k.Scopes {
+		if !slices.Contains(config.Scopes, scope) {
+			return fmt.Errorf("%w %q (valid: %v)", ErrInvalidScope, scope, config.Scopes)
+		}
+	}
+
+	return nil
+}
+
+// Identity is the authenticated caller attached to a request.
+type Identity struct {
+	KeyID    string
+	Tier     string
+	Networks []string
+	Scopes   []string
+}
+
+// AllowsScope reports whether the key may use endpoints of the given class.
+func (id Identity) AllowsScope(scope string) bool {
+	return len(id.Scopes) == 0 || slices.Contains(id.Scopes, scope)
+}
+
+// AllowsNetwork reports whether the key may access the given network.
+func (id Identity) AllowsNetwork(network string) bool {
+	return len(id.Networks) == 0 || slices.Contains(id.Networks, network)
+}
+
+type identityContextKey struct{}
+
+// WithIdentity returns a context carrying id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, id)
+}
+
+// IdentityFromContext returns the identity attached by the auth middleware.
+// Returns false for anonymous requests.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityContextKey{}).(Identity)
+
+	return id, ok
+}
+
+// HashToken returns the hex SHA-256 of an API key. Only hashes are stored,
+// so a Redis dump does not leak usable keys.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+type cacheEntry struct {
+	key       *Key // nil caches a miss
+	expiresAt time.Time
+}
+
+// Store validates API keys against Redis, caching lookups in memory.
+type Store struct {
+	log      logrus.FieldLogger
+	redis    redis.Client
+	prefix   string
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewStore creates an API key store.
+func NewStore(log logrus.FieldLogger, cfg config.AuthConfig, redisClient redis.Client) *Store {
+	return &Store{
+		log:      log.WithField("component", "auth"),
+		redis:    redisClient,
+		prefix:   cfg.KeyPrefix,
+		cacheTTL: cfg.CacheTTL,
+		now:      time.Now,
+		cache:    make(map[string]cacheEntry),
+	}
+}
+
+// Authenticate resolves an API key to an identity.
+// Returns ErrInvalidKey if the key is not usable, or another error if Redis
+// could not be queried.
+func (s *Store) Authenticate(ctx context.Context, token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, ErrInvalidKey
+	}
+
+	hash := HashToken(token)
+	now := s.now()
+
+	key, err := s.lookup(ctx, hash, now)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if key == nil || key.Disabled {
+		return Identity{}, ErrInvalidKey
+	}
+
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return Identity{}, ErrInvalidKey
+	}
+
+	return Identity{KeyID: key.ID, Tier: key.Tier, Networks: key.Networks, Scopes: key.Scopes}, nil
+}
+
+// Put stores an API key record. Intended for provisioning tools and tests.
+// Returns ErrInvalidTier or ErrInvalidScope for unknown tiers and scopes.
+func (s *Store) Put(ctx context.Context, token string, key Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshal key: %w", err)
+	}
+
+	hash := HashToken(token)
+
+	if err := s.redis.Set(ctx, s.prefix+hash, string(data), 0); err != nil {
+		return fmt.Errorf("store key: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.cache, hash)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// lookup returns the key record for hash, or nil if it does not exist.
+func (s *Store) lookup(ctx context.Context, hash string, now time.Time) (*Key, error) {
+	s.mu.Lock()
+	entry, ok := s.cache[hash]
+	s.mu.Unlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		return entry.key, nil
+	}
+
+	var key *Key
+
+	data, err := s.redis.Get(ctx, s.prefix+hash)
+
+	switch {
+	case errors.Is(err, redis.ErrNotFound):
+		// Cache the miss so repeated bad keys don't hit Redis
+	case err != nil:
+		return nil, fmt.Errorf("lookup key: %w", err)
+	default:
+		key = &Key{}
+		if err := json.Unmarshal([]byte(data), key); err != nil {
+			s.log.WithError(err).Warn("Malformed API key record")
+
+			key = nil
+		} else if err := key.Validate(); err != nil {
+			s.log.WithError(err).WithField("key_id", key.ID).Warn("Rejected API key record")
+
+			key = nil
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Bound the cache, e.g. against floods of random keys
+	if len(s.cache) >= maxCacheEntries {
+		for cached, e := range s.cache {
+			if !now.Before(e.expiresAt) {
+				delete(s.cache, cached)
+			}
+		}
+
+		if len(s.cache) >= maxCacheEntries {
+			clear(s.cache)
+		}
+	}
+
+	s.cache[hash] = cacheEntry{key: key, expiresAt: now.Add(s.cacheTTL)}
+
+	return key, nil
+}
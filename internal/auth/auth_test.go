@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func newTestStore(t *testing.T) (*Store, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	cfg := config.AuthConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	return NewStore(logger, cfg, client), mr
+}
+
+func TestStore_Authenticate(t *testing.T) {
+	store, mr := newTestStore(t)
+	ctx := t.Context()
+
+	past := time.Now().Add(-time.Hour)
+
+	require.NoError(t, store.Put(ctx, "pro-key", Key{ID: "k1", Tier: config.TierPro}))
+	require.NoError(t, store.Put(ctx, "disabled-key", Key{ID: "k2", Tier: config.TierPro, Disabled: true}))
+	require.NoError(t, store.Put(ctx, "expired-key", Key{ID: "k3", Tier: config.TierFree, ExpiresAt: &past}))
+	require.ErrorIs(t, store.Put(ctx, "unknown-tier-key", Key{ID: "k4", Tier: "platinum"}), ErrInvalidTier)
+
+	// Records written to Redis directly are checked on load too
+	mr.Set(store.prefix+HashToken("unknown-tier-key"), `{"id":"k4","tier":"platinum"}`)
+	mr.Set(store.prefix+HashToken("unknown-scope-key"), `{"id":"k5","tier":"free","scopes":["proxies"]}`)
+
+	identity, err := store.Authenticate(ctx, "pro-key")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{KeyID: "k1", Tier: config.TierPro}, identity)
+
+	for _, token := range []string{"", "missing-key", "disabled-key", "expired-key", "unknown-tier-key", "unknown-scope-key"} {
+		_, err := store.Authenticate(ctx, token)
+		require.ErrorIs(t, err, ErrInvalidKey, token)
+	}
+}
+
+func TestStore_Scopes(t *testing.T) {
+	store, _ := newTestStore(t)
+	ctx := t.Context()
+
+	require.ErrorIs(t, store.Put(ctx, "typo-key", Key{ID: "k1", Tier: config.TierFree, Scopes: []string{"gas-profiler"}}), ErrInvalidScope)
+
+	require.NoError(t, store.Put(ctx, "scoped-key", Key{
+		ID: "k2", Tier: config.TierFree, Networks: []string{"hoodi"}, Scopes: []string{config.ScopeProxy},
+	}))
+
+	identity, err := store.Authenticate(ctx, "scoped-key")
+	require.NoError(t, err)
+	assert.True(t, identity.AllowsNetwork("hoodi"))
+	assert.False(t, identity.AllowsNetwork("mainnet"))
+	assert.True(t, identity.AllowsScope(config.ScopeProxy))
+	assert.False(t, identity.AllowsScope(config.ScopeGasProfiler))
+
+	// Unscoped keys reach everything
+	unscoped := Identity{KeyID: "k3"}
+	assert.True(t, unscoped.AllowsNetwork("mainnet"))
+	assert.True(t, unscoped.AllowsScope(config.ScopeConfig))
+}
+
+func TestStore_CachesLookups(t *testing.T) {
+	store, mr := newTestStore(t)
+	ctx := t.Context()
+
+	require.NoError(t, store.Put(ctx, "pro-key", Key{ID: "k1", Tier: config.TierPro}))
+
+	_, err := store.Authenticate(ctx, "pro-key")
+	require.NoError(t, err)
+
+	// Revocation in Redis is only seen once the cache entry expires
+	mr.Del(store.prefix + HashToken("pro-key"))
+
+	_, err = store.Authenticate(ctx, "pro-key")
+	require.NoError(t, err)
+
+	store.now = func() time.Time { return time.Now().Add(time.Minute) }
+
+	_, err = store.Authenticate(ctx, "pro-key")
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestStore_RedisUnavailable(t *testing.T) {
+	store, mr := newTestStore(t)
+
+	mr.Close()
+
+	_, err := store.Authenticate(t.Context(), "any-key")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+var (
+	// ErrInvalidEmail is returned for malformed addresses and domains that may not request keys.
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrInvalidCode is returned for unknown, used or expired verification codes.
+	ErrInvalidCode = errors.New("invalid or expired verification code")
+)
+
+// Mailer sends verification emails.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// pendingIssuance is a verification code waiting to be exchanged for a key.
+type pendingIssuance struct {
+	Email       string    `json:"email"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// Issuer runs self-service key issuance: Request emails a one-time code,
+// Verify exchanges it for a new key. Pending codes live in Redis until they
+// expire or are used. Every step is logged with the "audit" field so operators
+// can review who was issued which key.
+type Issuer struct {
+	log    logrus.FieldLogger
+	cfg    config.TokenIssuanceConfig
+	store  *Store
+	redis  redis.Client
+	mailer Mailer
+	now    func() time.Time
+}
+
+// NewIssuer creates a key issuer storing issued keys in store.
+func NewIssuer(
+	log logrus.FieldLogger,
+	cfg config.TokenIssuanceConfig,
+	store *Store,
+	redisClient redis.Client,
+	mailer Mailer,
+) *Issuer {
+	return &Issuer{
+		log:    log.WithFields(logrus.Fields{"component": "token_issuance", "audit": true}),
+		cfg:    cfg,
+		store:  store,
+		redis:  redisClient,
+		mailer: mailer,
+		now:    time.Now,
+	}
+}
+
+// Request emails a verification code to address. While a code for the
+// address is pending further requests are accepted but send nothing, so the
+// endpoint can't be used to flood an inbox.
+// Returns ErrInvalidEmail for malformed or disallowed addresses.
+func (i *Issuer) Request(ctx context.Context, address string) error {
+	email, err := i.normalizeEmail(address)
+	if err != nil {
+		return err
+	}
+
+	emailKey := i.cfg.RedisPrefix + "email:" + HashToken(email)
+
+	first, err := i.redis.SetNX(ctx, emailKey, "1", i.cfg.CodeTTL)
+	if err != nil {
+		return fmt.Errorf("reserve email: %w", err)
+	}
+
+	if !first {
+		i.log.WithField("email", email).Info("Key requested while a code is pending, nothing sent")
+
+		return nil
+	}
+
+	code, err := randomToken("")
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(pendingIssuance{Email: email, RequestedAt: i.now().UTC()})
+	if err != nil {
+		return fmt.Errorf("marshal pending issuance: %w", err)
+	}
+
+	codeKey := i.cfg.RedisPrefix + "code:" + HashToken(code)
+
+	if err := i.redis.Set(ctx, codeKey, string(data), i.cfg.CodeTTL); err != nil {
+		_ = i.redis.Del(ctx, emailKey)
+
+		return fmt.Errorf("store code: %w", err)
+	}
+
+	if err := i.mailer.Send(ctx, email, "Your lab API key verification code", i.emailBody(code)); err != nil {
+		_ = i.redis.Del(ctx, emailKey, codeKey)
+
+		return fmt.Errorf("send verification email: %w", err)
+	}
+
+	i.log.WithField("email", email).Info("Sent API key verification code")
+
+	return nil
+}
+
+// Verify exchanges a verification code for a new API key with the configured
+// networks and scopes. Codes can be used once. Returns the key, shown to the
+// caller only this once, and its record.
+// Returns ErrInvalidCode for unknown, used or expired codes.
+func (i *Issuer) Verify(ctx context.Context, code string) (string, Key, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", Key{}, ErrInvalidCode
+	}
+
+	// GETDEL so concurrent requests can't exchange one code twice
+	data, err := i.redis.GetClient().GetDel(ctx, i.cfg.RedisPrefix+"code:"+HashToken(code)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", Key{}, ErrInvalidCode
+	}
+
+	if err != nil {
+		return "", Key{}, fmt.Errorf("lookup code: %w", err)
+	}
+
+	var pending pendingIssuance
+	if err := json.Unmarshal([]byte(data), &pending); err != nil {
+		return "", Key{}, fmt.Errorf("decode pending issuance: %w", err)
+	}
+
+	token, err := randomToken("lab_")
+	if err != nil {
+		return "", Key{}, err
+	}
+
+	// Keys issued to one address share an ID, so re-issuing does not multiply quotas
+	key := Key{
+		ID:       "self:" + HashToken(pending.Email)[:16],
+		Tier:     i.cfg.Tier,
+		Name:     pending.Email,
+		Networks: i.cfg.Networks,
+		Scopes:   i.cfg.Scopes,
+	}
+
+	if i.cfg.KeyTTL > 0 {
+		expiresAt := i.now().Add(i.cfg.KeyTTL).UTC()
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := i.store.Put(ctx, token, key); err != nil {
+		return "", Key{}, fmt.Errorf("store key: %w", err)
+	}
+
+	// A new key may be requested straight away, e.g. after losing this one
+	_ = i.redis.Del(ctx, i.cfg.RedisPrefix+"email:"+HashToken(pending.Email))
+
+	i.log.WithFields(logrus.Fields{
+		"email":      pending.Email,
+		"key_id":     key.ID,
+		"networks":   key.Networks,
+		"scopes":     key.Scopes,
+		"expires_at": key.ExpiresAt,
+	}).Info("Issued API key")
+
+	return token, key, nil
+}
+
+// normalizeEmail parses a bare address, lowercases it and checks its domain.
+func (i *Issuer) normalizeEmail(address string) (string, error) {
+	parsed, err := mail.ParseAddress(strings.TrimSpace(address))
+	if err != nil || parsed.Name != "" {
+		return "", ErrInvalidEmail
+	}
+
+	email := strings.ToLower(parsed.Address)
+
+	_, domain, _ := strings.Cut(email, "@")
+
+	if len(i.cfg.AllowedDomains) > 0 && !slices.Contains(i.cfg.AllowedDomains, domain) {
+		return "", ErrInvalidEmail
+	}
+
+	return email, nil
+}
+
+func (i *Issuer) emailBody(code string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Your lab API key verification code is:\n\n    %s\n\n", code)
+
+	// verify_url is checked when the config is loaded
+	if link, err := url.Parse(i.cfg.VerifyURL); err == nil && i.cfg.VerifyURL != "" {
+		query := link.Query()
+		query.Set("code", code)
+		link.RawQuery = query.Encode()
+
+		fmt.Fprintf(&b, "Open %s to get your key.\n\n", link)
+	}
+
+	fmt.Fprintf(&b, "The code expires in %s. If you did not ask for a key, ignore this email.\n", i.cfg.CodeTTL)
+
+	return b.String()
+}
+
+// randomToken returns prefix followed by 32 random hex-encoded bytes.
+func randomToken(prefix string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+
+	return prefix + hex.EncodeToString(buf), nil
+}
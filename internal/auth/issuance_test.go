@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+// fakeMailer records sent emails instead of delivering them.
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []string
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, to, _, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, to+"\n"+body)
+
+	return nil
+}
+
+var codePattern = regexp.MustCompile(`code=([0-9a-f]{64})`)
+
+func TestIssuer(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	authCfg := config.AuthConfig{Enabled: true}
+	require.NoError(t, authCfg.Validate())
+
+	cfg := config.TokenIssuanceConfig{
+		Enabled:        true,
+		VerifyURL:      "https://lab.example.com/keys/verify",
+		Networks:       []string{"hoodi"},
+		AllowedDomains: []string{"example.org"},
+		SMTP:           config.SMTPConfig{Host: "localhost", From: "lab@example.com"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	store := NewStore(logger, authCfg, client)
+	mailer := &fakeMailer{}
+	issuer := NewIssuer(logger, cfg, store, client, mailer)
+	ctx := t.Context()
+
+	// Malformed and disallowed addresses are rejected before anything is sent
+	for _, address := range []string{"not-an-email", "Someone <someone@example.org>", "someone@example.com"} {
+		require.ErrorIs(t, issuer.Request(ctx, address), ErrInvalidEmail, address)
+	}
+
+	require.NoError(t, issuer.Request(ctx, "Researcher@Example.org"))
+
+	// Pending codes suppress further emails to the address
+	require.NoError(t, issuer.Request(ctx, "researcher@example.org"))
+	require.Len(t, mailer.sent, 1)
+
+	match := codePattern.FindStringSubmatch(mailer.sent[0])
+	require.NotNil(t, match, mailer.sent[0])
+
+	_, _, err := issuer.Verify(ctx, "0000")
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	token, key, err := issuer.Verify(ctx, match[1])
+	require.NoError(t, err)
+	assert.Equal(t, "researcher@example.org", key.Name)
+	assert.Equal(t, config.TierFree, key.Tier)
+	assert.Equal(t, []string{"hoodi"}, key.Networks)
+	assert.Equal(t, []string{config.ScopeConfig, config.ScopeProxy}, key.Scopes)
+	require.NotNil(t, key.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(90*24*time.Hour), *key.ExpiresAt, time.Minute)
+
+	identity, err := store.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, identity.KeyID)
+	assert.False(t, identity.AllowsNetwork("mainnet"))
+
+	// Codes work once
+	_, _, err = issuer.Verify(ctx, match[1])
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	// After verifying, a new key can be requested right away
+	require.NoError(t, issuer.Request(ctx, "researcher@example.org"))
+	require.Len(t, mailer.sent, 2)
+
+	// Codes expire
+	mr.FastForward(cfg.CodeTTL)
+
+	match = codePattern.FindStringSubmatch(mailer.sent[1])
+	require.NotNil(t, match)
+
+	_, _, err = issuer.Verify(ctx, match[1])
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	// Failed sends release the address for another attempt
+	mailer.err = errors.New("smtp down")
+	require.Error(t, issuer.Request(ctx, "other@example.org"))
+
+	mailer.err = nil
+	require.NoError(t, issuer.Request(ctx, "other@example.org"))
+	require.Len(t, mailer.sent, 3)
+}
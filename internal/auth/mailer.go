@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// Verify interface compliance at compile time.
+var _ Mailer = (*SMTPMailer)(nil)
+
+// SMTPMailer sends plain text emails through an SMTP server, upgrading to
+// STARTTLS when the server offers it.
+type SMTPMailer struct {
+	cfg config.SMTPConfig
+}
+
+// NewSMTPMailer creates a mailer for the configured server.
+func NewSMTPMailer(cfg config.SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send sends a plain text email to a single recipient.
+func (m *SMTPMailer) Send(_ context.Context, to, subject, body string) error {
+	var smtpAuth smtp.Auth
+	if m.cfg.Username != "" {
+		smtpAuth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.cfg.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().UTC().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		body,
+	}, "\r\n")
+
+	// from may carry a display name, the envelope takes the bare address
+	sender, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return fmt.Errorf("sender: %w", err)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+
+	if err := smtp.SendMail(addr, smtpAuth, sender.Address, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	return nil
+}
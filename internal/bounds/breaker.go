@@ -0,0 +1,222 @@
+//nolint:tagliatelle // superior snake-case yo.
+package bounds
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// Circuit breaker states.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+var breakerState = metrics.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "bounds_circuit_breaker_state",
+		Help: "Bounds circuit breaker state per network (0 = closed, 1 = half-open, 2 = open)",
+	},
+	[]string{"network"},
+)
+
+// BreakerStatus is a point-in-time view of one network's circuit breaker.
+type BreakerStatus struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	NextProbeAt         *time.Time `json:"next_probe_at,omitempty"`
+}
+
+// Status is the circuit breaker state of every network, as of the last refresh.
+type Status struct {
+	Networks  map[string]BreakerStatus `json:"networks"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+// breaker tracks consecutive fetch failures for one network.
+type breaker struct {
+	state     string
+	failures  int
+	lastError string
+	backoff   time.Duration
+
+	lastFailure time.Time
+	lastSuccess time.Time
+	openedAt    time.Time
+	nextProbeAt time.Time
+}
+
+// breakers holds a circuit breaker per network.
+type breakers struct {
+	cfg config.CircuitBreakerConfig
+
+	mu       sync.Mutex
+	networks map[string]*breaker
+}
+
+func newBreakers(cfg config.CircuitBreakerConfig) *breakers {
+	return &breakers{
+		cfg:      cfg,
+		networks: make(map[string]*breaker),
+	}
+}
+
+// allow reports whether network may be fetched at now. An open breaker whose
+// backoff has elapsed moves to half-open and lets exactly one probe through.
+func (b *breakers) allow(network string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(network)
+
+	switch br.state {
+	case BreakerOpen:
+		if now.Before(br.nextProbeAt) {
+			return false
+		}
+
+		b.setState(network, br, BreakerHalfOpen)
+
+		return true
+	case BreakerHalfOpen:
+		// A probe is already in flight
+		return false
+	default:
+		return true
+	}
+}
+
+// success records a successful fetch and closes the breaker.
+func (b *breakers) success(network string, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(network)
+	br.failures = 0
+	br.lastError = ""
+	br.backoff = 0
+	br.lastSuccess = now
+	br.openedAt = time.Time{}
+	br.nextProbeAt = time.Time{}
+
+	b.setState(network, br, BreakerClosed)
+}
+
+// failure records a failed fetch. The breaker opens once the failure threshold
+// is reached; a failed half-open probe reopens it with double the backoff.
+// Returns true if the breaker opened.
+func (b *breakers) failure(network string, err error, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(network)
+	br.failures++
+	br.lastError = err.Error()
+	br.lastFailure = now
+
+	switch {
+	case br.state == BreakerHalfOpen:
+		br.backoff = min(2*br.backoff, b.cfg.MaxOpenDuration)
+	case br.failures >= b.cfg.FailureThreshold:
+		br.backoff = b.cfg.OpenDuration
+		br.openedAt = now
+	default:
+		return false
+	}
+
+	br.nextProbeAt = now.Add(br.backoff)
+	b.setState(network, br, BreakerOpen)
+
+	return true
+}
+
+// abort records a fetch that ended without a verdict, such as one cancelled
+// on shutdown. An interrupted probe leaves the breaker open so the next
+// refresh probes again.
+func (b *breakers) abort(network string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if br := b.get(network); br.state == BreakerHalfOpen {
+		b.setState(network, br, BreakerOpen)
+	}
+}
+
+// prune drops breakers for networks that are no longer fetched.
+func (b *breakers) prune(active map[string]bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for network := range b.networks {
+		if !active[network] {
+			delete(b.networks, network)
+			breakerState.DeleteLabelValues(network)
+		}
+	}
+}
+
+// snapshot returns the status of every breaker.
+func (b *breakers) snapshot() map[string]BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make(map[string]BreakerStatus, len(b.networks))
+
+	for network, br := range b.networks {
+		result[network] = BreakerStatus{
+			State:               br.state,
+			ConsecutiveFailures: br.failures,
+			LastError:           br.lastError,
+			LastFailure:         timePtr(br.lastFailure),
+			LastSuccess:         timePtr(br.lastSuccess),
+			OpenedAt:            timePtr(br.openedAt),
+			NextProbeAt:         timePtr(br.nextProbeAt),
+		}
+	}
+
+	return result
+}
+
+// get returns the breaker for network, creating a closed one if needed.
+// Callers must hold b.mu.
+func (b *breakers) get(network string) *breaker {
+	br, exists := b.networks[network]
+	if !exists {
+		br = &breaker{state: BreakerClosed}
+		b.networks[network] = br
+		breakerState.WithLabelValues(network).Set(0)
+	}
+
+	return br
+}
+
+func (b *breakers) setState(network string, br *breaker, state string) {
+	br.state = state
+
+	switch state {
+	case BreakerHalfOpen:
+		breakerState.WithLabelValues(network).Set(1)
+	case BreakerOpen:
+		breakerState.WithLabelValues(network).Set(2)
+	default:
+		breakerState.WithLabelValues(network).Set(0)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,102 @@
+package bounds
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestBreakers_Transitions(t *testing.T) {
+	b := newBreakers(config.CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenDuration:     time.Minute,
+		MaxOpenDuration:  3 * time.Minute,
+	})
+
+	var (
+		now = time.Unix(1700000000, 0)
+		err = errors.New("unexpected status 502")
+	)
+
+	// Stays closed below the threshold
+	for range 2 {
+		require.True(t, b.allow("mainnet", now))
+		assert.False(t, b.failure("mainnet", err, now))
+	}
+
+	// Opens on reaching it
+	require.True(t, b.allow("mainnet", now))
+	assert.True(t, b.failure("mainnet", err, now))
+
+	status := b.snapshot()["mainnet"]
+	assert.Equal(t, BreakerOpen, status.State)
+	assert.Equal(t, 3, status.ConsecutiveFailures)
+	assert.Equal(t, "unexpected status 502", status.LastError)
+	require.NotNil(t, status.NextProbeAt)
+	assert.Equal(t, now.Add(time.Minute), *status.NextProbeAt)
+
+	// Skipped until the backoff elapses, then a single probe is allowed
+	assert.False(t, b.allow("mainnet", now.Add(30*time.Second)))
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow("mainnet", now))
+	assert.Equal(t, BreakerHalfOpen, b.snapshot()["mainnet"].State)
+	assert.False(t, b.allow("mainnet", now))
+
+	// A failed probe doubles the backoff, capped at the maximum
+	assert.True(t, b.failure("mainnet", err, now))
+	assert.Equal(t, now.Add(2*time.Minute), *b.snapshot()["mainnet"].NextProbeAt)
+
+	now = now.Add(2 * time.Minute)
+	require.True(t, b.allow("mainnet", now))
+	b.failure("mainnet", err, now)
+	assert.Equal(t, now.Add(3*time.Minute), *b.snapshot()["mainnet"].NextProbeAt)
+
+	// A successful probe closes the breaker
+	now = now.Add(3 * time.Minute)
+	require.True(t, b.allow("mainnet", now))
+	b.success("mainnet", now)
+
+	status = b.snapshot()["mainnet"]
+	assert.Equal(t, BreakerClosed, status.State)
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Nil(t, status.NextProbeAt)
+	assert.True(t, b.allow("mainnet", now))
+}
+
+func TestBreakers_AbortedProbe(t *testing.T) {
+	b := newBreakers(config.CircuitBreakerConfig{
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+		MaxOpenDuration:  time.Minute,
+	})
+
+	now := time.Unix(1700000000, 0)
+
+	b.failure("mainnet", errors.New("timeout"), now)
+
+	now = now.Add(time.Minute)
+	require.True(t, b.allow("mainnet", now))
+
+	// An interrupted probe is retried on the next refresh
+	b.abort("mainnet")
+	assert.Equal(t, BreakerOpen, b.snapshot()["mainnet"].State)
+	assert.True(t, b.allow("mainnet", now))
+}
+
+func TestBreakers_Prune(t *testing.T) {
+	b := newBreakers(config.CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Minute})
+
+	b.success("mainnet", time.Now())
+	b.success("holesky", time.Now())
+
+	b.prune(map[string]bool{"mainnet": true})
+
+	assert.Contains(t, b.snapshot(), "mainnet")
+	assert.NotContains(t, b.snapshot(), "holesky")
+}
@@ -0,0 +1,138 @@
+package bounds
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+const (
+	// redisChangesChannel carries the bounds changes of every refresh from
+	// the leader to followers.
+	redisChangesChannel = "lab:bounds_changes"
+
+	// changesBuffer is how many changes a subscriber may fall behind by
+	// before it starts missing some.
+	changesBuffer = 16
+)
+
+var changesDroppedTotal = metrics.NewCounter(prometheus.CounterOpts{
+	Name: "bounds_changes_dropped_total",
+	Help: "Bounds changes not delivered to a subscriber that fell behind",
+})
+
+// changeFeed fans bounds changes out to local subscribers. A subscriber that
+// falls behind misses changes rather than holding up refreshes.
+type changeFeed struct {
+	mu          sync.Mutex
+	subscribers map[chan *Changes]struct{}
+	last        *Changes
+}
+
+// subscribe returns a channel receiving every change from now on, and a
+// function that ends the subscription.
+func (f *changeFeed) subscribe() (<-chan *Changes, func()) {
+	ch := make(chan *Changes, changesBuffer)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.subscribers == nil {
+		f.subscribers = make(map[chan *Changes]struct{})
+	}
+
+	f.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		delete(f.subscribers, ch)
+	}
+}
+
+// publish delivers changes to every subscriber without blocking.
+func (f *changeFeed) publish(changes *Changes) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.last = changes
+
+	for ch := range f.subscribers {
+		select {
+		case ch <- changes:
+		default:
+			changesDroppedTotal.Inc()
+		}
+	}
+}
+
+// lastChanges returns the most recently published changes, or nil.
+func (f *changeFeed) lastChanges() *Changes {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.last
+}
+
+// SubscribeChanges returns a channel receiving the bounds changes of every
+// refresh from now on, and a function that ends the subscription.
+func (r *RedisProvider) SubscribeChanges() (<-chan *Changes, func()) {
+	return r.changes.subscribe()
+}
+
+// LastChanges returns the most recent bounds changes seen by this instance,
+// or nil before the first.
+func (r *RedisProvider) LastChanges() *Changes {
+	return r.changes.lastChanges()
+}
+
+// publishChanges sends changes to followers, which pass them on to their
+// own subscribers.
+func (r *RedisProvider) publishChanges(ctx context.Context, changes *Changes) {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to marshal bounds changes")
+
+		return
+	}
+
+	if err := r.redis.Publish(ctx, redisChangesChannel, string(data)); err != nil {
+		r.log.WithError(err).Warn("Failed to publish bounds changes")
+	}
+}
+
+// changesLoop receives the changes published by the leader and passes them
+// on to this follower's subscribers.
+func (r *RedisProvider) changesLoop(ctx context.Context, messages <-chan string) {
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			// The leader already delivered to its own subscribers
+			if r.elector.IsLeader() {
+				continue
+			}
+
+			var changes Changes
+			if err := json.Unmarshal([]byte(msg), &changes); err != nil {
+				r.log.WithError(err).Warn("Failed to unmarshal bounds changes")
+
+				continue
+			}
+
+			r.changes.publish(&changes)
+		}
+	}
+}
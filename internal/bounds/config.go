@@ -7,4 +7,5 @@ type Config struct {
 	RefreshInterval time.Duration
 	PageSize        int
 	BoundsTTL       time.Duration
+	StaleRetention  time.Duration // How long last known bounds of a failing network are kept (0 = until BoundsTTL)
 }
@@ -0,0 +1,162 @@
+//nolint:tagliatelle // superior snake-case yo.
+package bounds
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Bounds of a table, as named in BoundChange.
+const (
+	BoundMin = "min"
+	BoundMax = "max"
+)
+
+// Changes summarises how bounds changed between two refreshes. Only
+// networks with changes are listed.
+type Changes struct {
+	At       time.Time        `json:"at"`
+	Networks []NetworkChanges `json:"networks"`
+}
+
+// NetworkChanges lists how the tables of one network changed.
+type NetworkChanges struct {
+	Network   string        `json:"network"`
+	Added     []string      `json:"added,omitempty"`     // Tables that appeared
+	Removed   []string      `json:"removed,omitempty"`   // Tables that disappeared
+	Advanced  []BoundChange `json:"advanced,omitempty"`  // Bounds that moved forward
+	Regressed []BoundChange `json:"regressed,omitempty"` // Bounds that moved backward
+}
+
+// BoundChange is a table bound that moved.
+type BoundChange struct {
+	Table string `json:"table"`
+	Bound string `json:"bound"` // BoundMin or BoundMax
+	From  int64  `json:"from"`
+	To    int64  `json:"to"`
+}
+
+// Diff returns the changes from previous to current. Only networks in both
+// are compared: a network that failed to refresh is not reported as having
+// lost its tables, and one seen for the first time has nothing to compare to.
+func Diff(previous, current map[string]*BoundsData) *Changes {
+	changes := &Changes{At: time.Now().UTC()}
+
+	for _, network := range slices.Sorted(maps.Keys(current)) {
+		prev, ok := previous[network]
+		if !ok || prev == nil || current[network] == nil {
+			continue
+		}
+
+		if nc := diffNetwork(network, prev.Tables, current[network].Tables); !nc.Empty() {
+			changes.Networks = append(changes.Networks, nc)
+		}
+	}
+
+	return changes
+}
+
+func diffNetwork(network string, previous, current map[string]TableBounds) NetworkChanges {
+	nc := NetworkChanges{Network: network}
+
+	for _, table := range slices.Sorted(maps.Keys(current)) {
+		prev, ok := previous[table]
+		if !ok {
+			nc.Added = append(nc.Added, table)
+
+			continue
+		}
+
+		cur := current[table]
+
+		for _, change := range []BoundChange{
+			{Table: table, Bound: BoundMin, From: prev.Min, To: cur.Min},
+			{Table: table, Bound: BoundMax, From: prev.Max, To: cur.Max},
+		} {
+			switch {
+			case change.To > change.From:
+				nc.Advanced = append(nc.Advanced, change)
+			case change.To < change.From:
+				nc.Regressed = append(nc.Regressed, change)
+			}
+		}
+	}
+
+	for _, table := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[table]; !ok {
+			nc.Removed = append(nc.Removed, table)
+		}
+	}
+
+	return nc
+}
+
+// Empty reports whether nothing changed.
+func (c *Changes) Empty() bool {
+	return len(c.Networks) == 0
+}
+
+// Network returns the changes with only the given network left in.
+func (c *Changes) Network(network string) *Changes {
+	return c.Filter(func(name string) bool { return name == network })
+}
+
+// Filter returns the changes of the networks keep returns true for.
+func (c *Changes) Filter(keep func(network string) bool) *Changes {
+	filtered := &Changes{At: c.At}
+
+	for _, nc := range c.Networks {
+		if keep(nc.Network) {
+			filtered.Networks = append(filtered.Networks, nc)
+		}
+	}
+
+	return filtered
+}
+
+// Empty reports whether nothing changed in the network.
+func (nc NetworkChanges) Empty() bool {
+	return len(nc.Added) == 0 && len(nc.Removed) == 0 && len(nc.Advanced) == 0 && len(nc.Regressed) == 0
+}
+
+// String returns a one-line summary for logs, e.g.
+// "mainnet (added 1, advanced 42, regressed 1: fct_block max)".
+func (c *Changes) String() string {
+	if c.Empty() {
+		return "no changes"
+	}
+
+	parts := make([]string, 0, len(c.Networks))
+
+	for _, nc := range c.Networks {
+		var counts []string
+
+		if len(nc.Added) > 0 {
+			counts = append(counts, fmt.Sprintf("added %d", len(nc.Added)))
+		}
+
+		if len(nc.Removed) > 0 {
+			counts = append(counts, fmt.Sprintf("removed %d", len(nc.Removed)))
+		}
+
+		if len(nc.Advanced) > 0 {
+			counts = append(counts, fmt.Sprintf("advanced %d", len(nc.Advanced)))
+		}
+
+		if len(nc.Regressed) > 0 {
+			regressed := make([]string, 0, len(nc.Regressed))
+			for _, change := range nc.Regressed {
+				regressed = append(regressed, change.Table+" "+change.Bound)
+			}
+
+			counts = append(counts, fmt.Sprintf("regressed %d: %s", len(nc.Regressed), strings.Join(regressed, ", ")))
+		}
+
+		parts = append(parts, fmt.Sprintf("%s (%s)", nc.Network, strings.Join(counts, ", ")))
+	}
+
+	return strings.Join(parts, "; ")
+}
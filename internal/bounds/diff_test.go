@@ -0,0 +1,63 @@
+package bounds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	previous := map[string]*BoundsData{
+		"mainnet": {Tables: map[string]TableBounds{
+			"fct_block":       {Min: 100, Max: 200},
+			"fct_attestation": {Min: 100, Max: 200},
+			"fct_old":         {Min: 1, Max: 2},
+		}},
+		"sepolia": {Tables: map[string]TableBounds{
+			"fct_block": {Min: 10, Max: 20},
+		}},
+		"holesky": {Tables: map[string]TableBounds{
+			"fct_block": {Min: 10, Max: 20},
+		}},
+	}
+
+	current := map[string]*BoundsData{
+		"mainnet": {Tables: map[string]TableBounds{
+			"fct_block":       {Min: 110, Max: 210}, // Advanced
+			"fct_attestation": {Min: 90, Max: 150},  // Backfilled, and regressed
+			"fct_new":         {Min: 1, Max: 2},
+		}},
+		"sepolia": {Tables: map[string]TableBounds{
+			"fct_block": {Min: 10, Max: 20}, // Stalled
+		}},
+		"hoodi": {Tables: map[string]TableBounds{
+			"fct_block": {Min: 1, Max: 2}, // Nothing to compare to
+		}},
+		// holesky failed to refresh and is not reported
+	}
+
+	changes := Diff(previous, current)
+	require.Len(t, changes.Networks, 1)
+
+	mainnet := changes.Networks[0]
+	assert.Equal(t, "mainnet", mainnet.Network)
+	assert.Equal(t, []string{"fct_new"}, mainnet.Added)
+	assert.Equal(t, []string{"fct_old"}, mainnet.Removed)
+	assert.Equal(t, []BoundChange{
+		{Table: "fct_block", Bound: BoundMin, From: 100, To: 110},
+		{Table: "fct_block", Bound: BoundMax, From: 200, To: 210},
+	}, mainnet.Advanced)
+	assert.Equal(t, []BoundChange{
+		{Table: "fct_attestation", Bound: BoundMin, From: 100, To: 90},
+		{Table: "fct_attestation", Bound: BoundMax, From: 200, To: 150},
+	}, mainnet.Regressed)
+
+	assert.Equal(t, "mainnet (added 1, removed 1, advanced 2, regressed 2: fct_attestation min, fct_attestation max)", changes.String())
+
+	assert.True(t, changes.Network("sepolia").Empty())
+	assert.Len(t, changes.Network("mainnet").Networks, 1)
+
+	assert.True(t, Diff(current, current).Empty())
+	assert.Equal(t, "no changes", Diff(current, current).String())
+}
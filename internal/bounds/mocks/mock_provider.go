@@ -56,11 +56,11 @@ func (mr *MockProviderMockRecorder) GetAllBounds(ctx any) *gomock.Call {
 }
 
 // GetBounds mocks base method.
-func (m *MockProvider) GetBounds(ctx context.Context, network string) (*bounds.BoundsData, bool) {
+func (m *MockProvider) GetBounds(ctx context.Context, network string) (*bounds.BoundsData, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBounds", ctx, network)
 	ret0, _ := ret[0].(*bounds.BoundsData)
-	ret1, _ := ret[1].(bool)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -70,6 +70,35 @@ func (mr *MockProviderMockRecorder) GetBounds(ctx, network any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBounds", reflect.TypeOf((*MockProvider)(nil).GetBounds), ctx, network)
 }
 
+// GetStatus mocks base method.
+func (m *MockProvider) GetStatus(ctx context.Context) (*bounds.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx)
+	ret0, _ := ret[0].(*bounds.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockProviderMockRecorder) GetStatus(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockProvider)(nil).GetStatus), ctx)
+}
+
+// LastChanges mocks base method.
+func (m *MockProvider) LastChanges() *bounds.Changes {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastChanges")
+	ret0, _ := ret[0].(*bounds.Changes)
+	return ret0
+}
+
+// LastChanges indicates an expected call of LastChanges.
+func (mr *MockProviderMockRecorder) LastChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastChanges", reflect.TypeOf((*MockProvider)(nil).LastChanges))
+}
+
 // NotifyChannel mocks base method.
 func (m *MockProvider) NotifyChannel() <-chan struct{} {
 	m.ctrl.T.Helper()
@@ -111,3 +140,18 @@ func (mr *MockProviderMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockProvider)(nil).Stop))
 }
+
+// SubscribeChanges mocks base method.
+func (m *MockProvider) SubscribeChanges() (<-chan *bounds.Changes, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeChanges")
+	ret0, _ := ret[0].(<-chan *bounds.Changes)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// SubscribeChanges indicates an expected call of SubscribeChanges.
+func (mr *MockProviderMockRecorder) SubscribeChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChanges", reflect.TypeOf((*MockProvider)(nil).SubscribeChanges))
+}
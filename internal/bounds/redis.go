@@ -3,30 +3,51 @@ package bounds
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/lab-backend/internal/compat"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
 	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/notify"
 	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Compile-time interface compliance check.
 var _ Provider = (*RedisProvider)(nil)
 
-const redisKeyPrefix = "lab:bounds:"
+const (
+	redisKeyPrefix = "lab:bounds:"
+
+	// redisStatusKey holds the leader's circuit breaker status. It must not
+	// match redisKeyPrefix, which is scanned for per-network bounds.
+	redisStatusKey = "lab:bounds_status"
+
+	// staleRefreshes is how many refresh intervals bounds may miss before
+	// they are reported stale.
+	staleRefreshes = 3
+)
 
 // RedisProvider implements Provider interface using Redis as storage.
 type RedisProvider struct {
-	log        logrus.FieldLogger
-	cfg        Config
-	redis      redis.Client
-	elector    leader.Elector
-	upstream   *Service
-	done       chan struct{}
-	notifyChan chan struct{} // Signals when bounds data has been updated
-	wg         sync.WaitGroup
+	log      logrus.FieldLogger
+	cfg      Config
+	redis    redis.Client
+	elector  leader.Elector
+	compat   compat.Gate
+	upstream *Service
+	done     chan struct{}
+	notifier *notify.Broadcaster // Signals when bounds data has been updated
+	changes  changeFeed          // Bounds changes of every refresh
+	wg       sync.WaitGroup
+	task     *tasks.Task
 }
 
 // NewRedisProvider creates a Redis-backed bounds provider.
@@ -35,16 +56,18 @@ func NewRedisProvider(
 	cfg Config,
 	redisClient redis.Client,
 	elector leader.Elector,
+	gate compat.Gate,
 	upstream *Service,
 ) Provider {
 	return &RedisProvider{
-		log:        log.WithField("component", "bounds_redis"),
-		cfg:        cfg,
-		redis:      redisClient,
-		elector:    elector,
-		upstream:   upstream,
-		done:       make(chan struct{}),
-		notifyChan: make(chan struct{}, 1), // Buffered so we don't block
+		log:      log.WithField("component", "bounds_redis"),
+		cfg:      cfg,
+		redis:    redisClient,
+		elector:  elector,
+		compat:   gate,
+		upstream: upstream,
+		done:     make(chan struct{}),
+		notifier: notify.New(),
 	}
 }
 
@@ -53,7 +76,24 @@ func NewRedisProvider(
 func (r *RedisProvider) Start(ctx context.Context) error {
 	r.log.Info("Starting bounds provider")
 
+	// Receive bounds changes from the leader. Without the subscription
+	// followers still serve fresh bounds, but report no changes.
+	subCtx, subCancel := context.WithCancel(ctx)
+
+	messages, err := r.redis.Subscribe(subCtx, redisChangesChannel)
+	if err != nil {
+		subCancel()
+		r.log.WithError(err).Warn("Failed to subscribe to bounds changes")
+	} else {
+		r.wg.Go(func() {
+			defer subCancel()
+
+			r.changesLoop(subCtx, messages)
+		})
+	}
+
 	// Start background refresh loop
+	r.task = tasks.Default().Register("bounds.refresh", r.cfg.RefreshInterval)
 	r.wg.Add(1)
 
 	go r.refreshLoop(ctx)
@@ -105,22 +145,37 @@ func (r *RedisProvider) Stop() error {
 func (r *RedisProvider) GetBounds(
 	ctx context.Context,
 	network string,
-) (*BoundsData, bool) {
+) (*BoundsData, error) {
 	data, err := r.redis.Get(ctx, fmt.Sprintf("%s%s", redisKeyPrefix, network))
-	if err != nil {
-		r.log.WithError(err).WithField("network", network).Debug("Failed to get bounds from Redis")
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, fmt.Errorf("bounds for %s: %w", network, errs.ErrNotFound)
+	}
 
-		return nil, false
+	if err != nil {
+		return nil, fmt.Errorf("read bounds for %s: %w: %w", network, errs.ErrUpstreamUnavailable, err)
 	}
 
 	var boundsData BoundsData
 	if err := json.Unmarshal([]byte(data), &boundsData); err != nil {
-		r.log.WithError(err).WithField("network", network).Error("Failed to unmarshal bounds")
+		return nil, fmt.Errorf("unmarshal bounds for %s: %w", network, err)
+	}
 
-		return nil, false
+	// Served anyway, but the network failed its latest refreshes
+	if boundsData.Stale {
+		return &boundsData, fmt.Errorf("bounds for %s not refreshed since %s: %w", network, boundsData.LastSuccess.Format(time.RFC3339), errs.ErrStale)
 	}
 
-	return &boundsData, true
+	// Served anyway, but the leader has stopped refreshing it
+	if age := time.Since(boundsData.LastUpdated); r.cfg.RefreshInterval > 0 && age > r.staleAfter() {
+		return &boundsData, fmt.Errorf("bounds for %s last updated %s ago: %w", network, age.Round(time.Second), errs.ErrStale)
+	}
+
+	return &boundsData, nil
+}
+
+// staleAfter is how old bounds may get before they are reported stale.
+func (r *RedisProvider) staleAfter() time.Duration {
+	return staleRefreshes * r.cfg.RefreshInterval
 }
 
 // GetAllBounds returns bounds for all networks by reading directly from Redis.
@@ -128,9 +183,7 @@ func (r *RedisProvider) GetAllBounds(
 	ctx context.Context,
 ) map[string]*BoundsData {
 	// Get all bounds keys matching the pattern
-	client := r.redis.GetClient()
-
-	keys, err := client.Keys(ctx, redisKeyPrefix+"*").Result()
+	keys, err := r.redis.Keys(ctx, redisKeyPrefix+"*")
 	if err != nil {
 		r.log.WithError(err).Error("Failed to list bounds keys")
 
@@ -162,26 +215,46 @@ func (r *RedisProvider) GetAllBounds(
 	return result
 }
 
+// GetStatus returns the circuit breaker status last stored by the leader.
+func (r *RedisProvider) GetStatus(ctx context.Context) (*Status, error) {
+	data, err := r.redis.Get(ctx, redisStatusKey)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, fmt.Errorf("bounds status: %w", errs.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read bounds status: %w: %w", errs.ErrUpstreamUnavailable, err)
+	}
+
+	var status Status
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		return nil, fmt.Errorf("unmarshal bounds status: %w", err)
+	}
+
+	return &status, nil
+}
+
 // NotifyChannel returns a channel that signals when bounds data has been updated.
+// Each call returns a new subscription, so multiple consumers can listen.
 func (r *RedisProvider) NotifyChannel() <-chan struct{} {
-	return r.notifyChan
+	return r.notifier.Subscribe()
 }
 
+// refreshLoop runs the refresh loop under the task supervisor, which
+// restarts it with backoff if it panics.
 func (r *RedisProvider) refreshLoop(ctx context.Context) {
-	defer func() {
-		if rec := recover(); rec != nil {
-			r.log.WithField("panic", rec).Error("Bounds refresh loop panicked")
-		}
+	defer r.wg.Done()
 
-		r.wg.Done()
-	}()
+	r.task.Supervise(r.log, r.done, func() { r.runRefreshLoop(ctx) })
+}
 
-	ticker := time.NewTicker(r.cfg.RefreshInterval)
+func (r *RedisProvider) runRefreshLoop(ctx context.Context) {
+	ticker := jitter.NewTicker(r.cfg.RefreshInterval)
 	defer ticker.Stop()
 
 	// Follower polling ticker - notifies frontend to check Redis for updates
 	// This ensures follower pods update their in-memory cache when leader updates Redis
-	followerPollTicker := time.NewTicker(r.cfg.RefreshInterval)
+	followerPollTicker := jitter.NewTicker(r.cfg.RefreshInterval)
 	defer followerPollTicker.Stop()
 
 	// Give leader election a moment to settle.
@@ -189,7 +262,7 @@ func (r *RedisProvider) refreshLoop(ctx context.Context) {
 
 	// Immediate refresh on startup if leader.
 	if r.elector.IsLeader() {
-		r.refreshData(ctx)
+		_ = r.task.Run(func() error { return r.refreshData(ctx) })
 	}
 
 	for {
@@ -201,12 +274,13 @@ func (r *RedisProvider) refreshLoop(ctx context.Context) {
 		case <-ticker.C:
 			// Only leader refreshes from upstream.
 			if r.elector.IsLeader() {
-				r.refreshData(ctx)
+				_ = r.task.Run(func() error { return r.refreshData(ctx) })
 			}
 		case <-followerPollTicker.C:
 			// Followers notify their frontend to re-read from Redis
 			// This ensures all pods stay in sync with Redis state
 			if !r.elector.IsLeader() {
+				r.task.Tick()
 				r.notifyFollowers()
 			}
 		}
@@ -216,15 +290,22 @@ func (r *RedisProvider) refreshLoop(ctx context.Context) {
 // notifyFollowers sends a notification to the frontend to refresh from Redis.
 // This is used by follower pods to stay in sync with Redis updates from the leader.
 func (r *RedisProvider) notifyFollowers() {
-	select {
-	case r.notifyChan <- struct{}{}:
+	if r.notifier.Notify() {
 		r.log.Debug("Notified frontend to refresh from Redis (follower)")
-	default:
-		// Channel already has a pending notification, skip
 	}
 }
 
-func (r *RedisProvider) refreshData(ctx context.Context) {
+// refreshData fetches bounds from upstream and stores them in Redis.
+// Failures are logged and also returned for task introspection.
+func (r *RedisProvider) refreshData(ctx context.Context) (err error) {
+	// Leadership may have been lost since the caller checked
+	if !r.elector.IsLeader() {
+		return errs.ErrNotLeader
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "bounds.refresh", trace.SpanKindInternal)
+	defer func() { tracing.EndSpan(span, err) }()
+
 	r.log.Debug("Refreshing bounds data from upstream")
 
 	// Fetch fresh data from upstream.
@@ -234,17 +315,40 @@ func (r *RedisProvider) refreshData(ctx context.Context) {
 		r.log.WithError(err).Warn("Unexpected error fetching bounds from upstream")
 	}
 
+	// Only write formats every active replica reads
+	version, err := r.compat.WriteVersion()
+	if err != nil {
+		r.log.WithError(err).Error("Refusing to write bounds")
+
+		return fmt.Errorf("write version: %w", err)
+	}
+
+	// Publish circuit breaker state so every pod can serve it
+	r.storeStatus(ctx, version)
+
+	// Networks that failed keep their last known bounds for a while
+	for network := range r.upstream.BreakerStatus() {
+		if _, ok := allBounds[network]; !ok {
+			r.retainStale(ctx, network, version)
+		}
+	}
+
 	if len(allBounds) == 0 {
 		r.log.Warn("No bounds data fetched from upstream")
 
-		return
+		return fmt.Errorf("no bounds data fetched: %w", errs.ErrUpstreamUnavailable)
 	}
 
+	// Bounds as of the previous refresh, to report what changed
+	previous := r.GetAllBounds(ctx)
+
 	// Store each network's bounds in Redis
-	successCount := 0
+	stored := make(map[string]*BoundsData, len(allBounds))
 
 	for network, boundsData := range allBounds {
-		data, err := json.Marshal(boundsData)
+		boundsData.LastSuccess = boundsData.LastUpdated
+
+		data, err := compat.Marshal(version, boundsData)
 		if err != nil {
 			r.log.WithError(err).WithField("network", network).Error("Failed to marshal bounds")
 
@@ -262,16 +366,93 @@ func (r *RedisProvider) refreshData(ctx context.Context) {
 			continue
 		}
 
-		successCount++
+		stored[network] = boundsData
+	}
+
+	if len(stored) == 0 {
+		return fmt.Errorf("failed to store bounds for any of %d networks", len(allBounds))
+	}
+
+	if changes := Diff(previous, stored); !changes.Empty() {
+		r.log.Debugf("Bounds changed: %s", changes)
+		r.changes.publish(changes)
+		r.publishChanges(ctx, changes)
 	}
 
 	// Notify listeners that bounds data has been updated (non-blocking)
-	if successCount > 0 {
-		select {
-		case r.notifyChan <- struct{}{}:
-			r.log.Debug("Notified listeners of bounds update")
-		default:
-			// Channel already has a pending notification, skip
+	if r.notifier.Notify() {
+		r.log.Debug("Notified listeners of bounds update")
+	}
+
+	return nil
+}
+
+// retainStale marks the stored bounds of a network that failed to refresh as
+// stale, renewing their TTL, until they are older than StaleRetention.
+func (r *RedisProvider) retainStale(ctx context.Context, network string, version int) {
+	var (
+		key = redisKeyPrefix + network
+		log = r.log.WithField("network", network)
+	)
+
+	data, err := r.redis.Get(ctx, key)
+	if err != nil {
+		return
+	}
+
+	var boundsData BoundsData
+	if err := json.Unmarshal([]byte(data), &boundsData); err != nil {
+		log.WithError(err).Warn("Failed to unmarshal bounds to retain")
+
+		return
+	}
+
+	// Bounds stored before last_success existed
+	if boundsData.LastSuccess.IsZero() {
+		boundsData.LastSuccess = boundsData.LastUpdated
+	}
+
+	if age := time.Since(boundsData.LastSuccess); r.cfg.StaleRetention > 0 && age > r.cfg.StaleRetention {
+		log.WithField("last_success", boundsData.LastSuccess).Warn("Dropping stale bounds past retention")
+
+		if err := r.redis.Del(ctx, key); err != nil {
+			log.WithError(err).Error("Failed to delete stale bounds")
 		}
+
+		return
+	}
+
+	if !boundsData.Stale {
+		log.WithField("last_success", boundsData.LastSuccess).Warn("Bounds refresh failed, serving last known bounds as stale")
+	}
+
+	boundsData.Stale = true
+
+	updated, err := compat.Marshal(version, boundsData)
+	if err != nil {
+		log.WithError(err).Error("Failed to marshal stale bounds")
+
+		return
+	}
+
+	if err := r.redis.Set(ctx, key, string(updated), r.cfg.BoundsTTL); err != nil {
+		log.WithError(err).Error("Failed to store stale bounds")
+	}
+}
+
+// storeStatus writes the upstream service's circuit breaker status to Redis.
+func (r *RedisProvider) storeStatus(ctx context.Context, version int) {
+	data, err := compat.Marshal(version, Status{
+		Networks:  r.upstream.BreakerStatus(),
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		r.log.WithError(err).Error("Failed to marshal bounds status")
+
+		return
+	}
+
+	if err := r.redis.Set(ctx, redisStatusKey, string(data), r.cfg.BoundsTTL); err != nil {
+		r.log.WithError(err).Error("Failed to store bounds status in Redis")
 	}
 }
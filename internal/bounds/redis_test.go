@@ -3,8 +3,13 @@ package bounds
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,8 +18,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethpandaops/lab-backend/internal/compat"
+	compatmocks "github.com/ethpandaops/lab-backend/internal/compat/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
 	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
+	"github.com/ethpandaops/lab-backend/internal/redis"
 	redismocks "github.com/ethpandaops/lab-backend/internal/redis/mocks"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
 )
 
 func TestRedisProvider_GetBounds(t *testing.T) {
@@ -23,7 +34,8 @@ func TestRedisProvider_GetBounds(t *testing.T) {
 		network      string
 		redisData    string
 		redisError   error
-		expectFound  bool
+		expectErr    bool
+		expectErrIs  error
 		validateData func(t *testing.T, data *BoundsData)
 	}{
 		{
@@ -35,8 +47,7 @@ func TestRedisProvider_GetBounds(t *testing.T) {
 				},
 				LastUpdated: time.Now(),
 			}),
-			redisError:  nil,
-			expectFound: true,
+			redisError: nil,
 			validateData: func(t *testing.T, data *BoundsData) {
 				t.Helper()
 
@@ -50,15 +61,39 @@ func TestRedisProvider_GetBounds(t *testing.T) {
 			name:        "bounds do not exist for network",
 			network:     "nonexistent",
 			redisData:   "",
-			redisError:  fmt.Errorf("redis: nil"),
-			expectFound: false,
+			redisError:  fmt.Errorf("%w: lab:bounds:nonexistent", redis.ErrNotFound),
+			expectErr:   true,
+			expectErrIs: errs.ErrNotFound,
 		},
 		{
-			name:        "invalid JSON returns not found",
+			name:        "redis failure returns upstream unavailable",
 			network:     "mainnet",
-			redisData:   "invalid json",
-			redisError:  nil,
-			expectFound: false,
+			redisError:  errors.New("connection refused"),
+			expectErr:   true,
+			expectErrIs: errs.ErrUpstreamUnavailable,
+		},
+		{
+			name:       "invalid JSON returns error",
+			network:    "mainnet",
+			redisData:  "invalid json",
+			redisError: nil,
+			expectErr:  true,
+		},
+		{
+			name:    "stale bounds are returned with ErrStale",
+			network: "mainnet",
+			redisData: mustMarshal(t, BoundsData{
+				Tables:      map[string]TableBounds{"beacon_block": {Min: 100, Max: 200}},
+				LastUpdated: time.Now().Add(-time.Hour),
+			}),
+			expectErr:   true,
+			expectErrIs: errs.ErrStale,
+			validateData: func(t *testing.T, data *BoundsData) {
+				t.Helper()
+
+				require.NotNil(t, data)
+				assert.Contains(t, data.Tables, "beacon_block")
+			},
 		},
 	}
 
@@ -81,20 +116,29 @@ func TestRedisProvider_GetBounds(t *testing.T) {
 
 			provider := NewRedisProvider(
 				logger,
-				Config{},
+				Config{RefreshInterval: time.Minute},
 				mockRedis,
 				mockElector,
+				compat.Latest,
 				nil, // upstream not needed for Get test
 			)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
-			data, found := provider.GetBounds(ctx, tt.network)
+			data, err := provider.GetBounds(ctx, tt.network)
 
-			assert.Equal(t, tt.expectFound, found)
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.expectErrIs != nil {
+				require.ErrorIs(t, err, tt.expectErrIs)
+			}
 
-			if tt.expectFound && tt.validateData != nil {
+			if tt.validateData != nil {
 				tt.validateData(t, data)
 			}
 		})
@@ -116,6 +160,7 @@ func TestRedisProvider_NotifyChannel(t *testing.T) {
 		Config{},
 		mockRedis,
 		mockElector,
+		compat.Latest,
 		nil,
 	)
 
@@ -146,8 +191,8 @@ func TestRedisProvider_FollowerPolling(t *testing.T) {
 
 	// Mock Redis.Keys for Start() readiness check
 	mockRedis.EXPECT().
-		GetClient().
-		Return(nil).
+		Keys(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
 		AnyTimes()
 
 	providerInterface := NewRedisProvider(
@@ -158,6 +203,7 @@ func TestRedisProvider_FollowerPolling(t *testing.T) {
 		},
 		mockRedis,
 		mockElector,
+		compat.Latest,
 		nil, // No upstream service needed for this test
 	)
 
@@ -206,6 +252,7 @@ func TestRedisProvider_PanicRecovery(t *testing.T) {
 		},
 		mockRedis,
 		mockElector,
+		compat.Latest,
 		nil,
 	)
 
@@ -249,3 +296,200 @@ func mustMarshal(t *testing.T, v any) string {
 
 	return string(data)
 }
+
+func TestRedisProvider_RetainsStaleBounds(t *testing.T) {
+	var failing atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/sepolia") && failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AdminCBTIncrementalResponse{ //nolint:errcheck // test.
+			AdminCBTIncremental: []IncrementalTableRecord{{Table: "fct_block", Position: 100, Interval: 10}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: server.URL + "/mainnet"},
+			{Name: "sepolia", TargetURL: server.URL + "/sepolia"},
+		},
+		Bounds: config.BoundsConfig{CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 100}},
+	}
+
+	upstream, err := New(logger, cfg, nil)
+	require.NoError(t, err)
+
+	elector := leadermocks.NewMockElector(gomock.NewController(t))
+	elector.EXPECT().IsLeader().Return(true).AnyTimes()
+
+	provider, ok := NewRedisProvider(logger, Config{
+		RefreshInterval: time.Minute,
+		StaleRetention:  time.Hour,
+	}, client, elector, compat.Latest, upstream).(*RedisProvider)
+	require.True(t, ok)
+
+	ctx := context.Background()
+
+	require.NoError(t, provider.refreshData(ctx))
+
+	data, err := provider.GetBounds(ctx, "sepolia")
+	require.NoError(t, err)
+	assert.False(t, data.Stale)
+	assert.False(t, data.LastSuccess.IsZero())
+
+	// sepolia fails: its last known bounds are kept, marked stale
+	failing.Store(true)
+	require.NoError(t, provider.refreshData(ctx))
+
+	data, err = provider.GetBounds(ctx, "sepolia")
+	require.ErrorIs(t, err, errs.ErrStale)
+	require.NotNil(t, data)
+	assert.True(t, data.Stale)
+	assert.Equal(t, TableBounds{Min: 100, Max: 110}, data.Tables["fct_block"])
+
+	data, err = provider.GetBounds(ctx, "mainnet")
+	require.NoError(t, err)
+	assert.False(t, data.Stale)
+
+	// Past retention the bounds are dropped
+	provider.cfg.StaleRetention = time.Nanosecond
+	require.NoError(t, provider.refreshData(ctx))
+
+	_, err = provider.GetBounds(ctx, "sepolia")
+	require.ErrorIs(t, err, errs.ErrNotFound)
+
+	// A successful fetch clears the flag
+	failing.Store(false)
+	require.NoError(t, provider.refreshData(ctx))
+
+	data, err = provider.GetBounds(ctx, "sepolia")
+	require.NoError(t, err)
+	assert.False(t, data.Stale)
+}
+
+func TestRedisProvider_PublishesChanges(t *testing.T) {
+	var position atomic.Int64
+
+	position.Store(100)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AdminCBTIncrementalResponse{ //nolint:errcheck // test.
+			AdminCBTIncremental: []IncrementalTableRecord{
+				{Table: "fct_block", Position: position.Load(), Interval: 10},
+				{Table: "fct_head", Position: 100, Interval: 10},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{{Name: "mainnet", TargetURL: server.URL + "/mainnet"}},
+		Bounds:   config.BoundsConfig{CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 100}},
+	}
+
+	upstream, err := New(logger, cfg, nil)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+
+	leaderElector := leadermocks.NewMockElector(ctrl)
+	leaderElector.EXPECT().IsLeader().Return(true).AnyTimes()
+
+	followerElector := leadermocks.NewMockElector(ctrl)
+	followerElector.EXPECT().IsLeader().Return(false).AnyTimes()
+
+	providerCfg := Config{RefreshInterval: time.Hour}
+
+	leaderProvider, ok := NewRedisProvider(logger, providerCfg, client, leaderElector, compat.Latest, upstream).(*RedisProvider)
+	require.True(t, ok)
+
+	ctx := context.Background()
+
+	// The first refresh has nothing to compare to
+	require.NoError(t, leaderProvider.refreshData(ctx))
+	assert.Nil(t, leaderProvider.LastChanges())
+
+	// A follower receives the leader's changes through Redis
+	follower := NewRedisProvider(logger, providerCfg, client, followerElector, compat.Latest, upstream)
+	require.NoError(t, follower.Start(ctx))
+	t.Cleanup(func() { _ = follower.Stop() })
+
+	followerChanges, unsubscribe := follower.SubscribeChanges()
+	defer unsubscribe()
+
+	leaderChanges, unsubscribeLeader := leaderProvider.SubscribeChanges()
+	defer unsubscribeLeader()
+
+	position.Store(150)
+	require.NoError(t, leaderProvider.refreshData(ctx))
+
+	expected := []BoundChange{
+		{Table: "fct_block", Bound: BoundMin, From: 100, To: 150},
+		{Table: "fct_block", Bound: BoundMax, From: 110, To: 160},
+	}
+
+	select {
+	case changes := <-leaderChanges:
+		require.Len(t, changes.Networks, 1)
+		assert.Equal(t, expected, changes.Networks[0].Advanced)
+	case <-time.After(time.Second):
+		t.Fatal("leader subscriber got no changes")
+	}
+
+	select {
+	case changes := <-followerChanges:
+		require.Len(t, changes.Networks, 1)
+		assert.Equal(t, "mainnet", changes.Networks[0].Network)
+		assert.Equal(t, expected, changes.Networks[0].Advanced)
+	case <-time.After(5 * time.Second):
+		t.Fatal("follower subscriber got no changes")
+	}
+
+	// Nothing moved: no changes are published
+	require.NoError(t, leaderProvider.refreshData(ctx))
+
+	select {
+	case changes := <-leaderChanges:
+		t.Fatalf("unexpected changes: %s", changes)
+	default:
+	}
+}
+
+func TestRedisProvider_RefusesIncompatibleWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	upstream, err := New(logger, &config.Config{}, nil)
+	require.NoError(t, err)
+
+	elector := leadermocks.NewMockElector(ctrl)
+	elector.EXPECT().IsLeader().Return(true)
+
+	gate := compatmocks.NewMockGate(ctrl)
+	gate.EXPECT().WriteVersion().Return(0, compat.ErrIncompatible)
+
+	// Nothing may be written while a replica cannot read it
+	provider, ok := NewRedisProvider(logger, Config{RefreshInterval: time.Minute}, redismocks.NewMockClient(ctrl), elector, gate, upstream).(*RedisProvider)
+	require.True(t, ok)
+
+	require.ErrorIs(t, provider.refreshData(context.Background()), compat.ErrIncompatible)
+}
@@ -13,15 +13,19 @@ import (
 
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
 	"github.com/sirupsen/logrus"
 )
 
-// Service is a stateless fetcher that retrieves bounds data from Xatu CBT APIs.
+// Service fetches bounds data from Xatu CBT APIs. The only state it keeps is
+// a circuit breaker per network, so a failing target_url is not called on
+// every refresh.
 type Service struct {
 	config                *config.Config
 	cartographoorProvider cartographoor.Provider
 	logger                logrus.FieldLogger
 	httpClient            *http.Client
+	breakers              *breakers
 }
 
 // New creates a new bounds service.
@@ -39,9 +43,15 @@ func New(
 		cartographoorProvider: cartographoorProvider,
 		logger:                logger.WithField("component", "bounds"),
 		httpClient:            cfg.Bounds.HTTPClient(),
+		breakers:              newBreakers(cfg.Bounds.CircuitBreaker),
 	}, nil
 }
 
+// BreakerStatus returns the circuit breaker state of every fetched network.
+func (s *Service) BreakerStatus() map[string]BreakerStatus {
+	return s.breakers.snapshot()
+}
+
 // FetchBounds fetches bounds data for all enabled networks and returns it.
 func (s *Service) FetchBounds(
 	ctx context.Context,
@@ -57,15 +67,21 @@ func (s *Service) FetchBounds(
 	)
 
 	// Convert map to slice of enabled networks only
-	networks := make([]config.NetworkConfig, 0, len(mergedNetworks))
+	var (
+		networks = make([]config.NetworkConfig, 0, len(mergedNetworks))
+		active   = make(map[string]bool, len(mergedNetworks))
+	)
 
 	for _, network := range mergedNetworks {
 		// Only include enabled networks
 		if network.Enabled == nil || *network.Enabled {
 			networks = append(networks, network)
+			active[network.Name] = true
 		}
 	}
 
+	s.breakers.prune(active)
+
 	if len(networks) == 0 {
 		s.logger.Warn("No enabled networks found")
 
@@ -81,10 +97,21 @@ func (s *Service) FetchBounds(
 
 	resultsChan := make(chan result, len(networks))
 
-	var fetchWg sync.WaitGroup
+	var (
+		fetchWg      sync.WaitGroup
+		skippedCount = 0
+	)
 
-	// Launch goroutine for each network
+	// Launch goroutine for each network whose circuit breaker allows a fetch
 	for _, network := range networks {
+		if !s.breakers.allow(network.Name, time.Now()) {
+			s.logger.WithField("network", network.Name).Debug("Circuit breaker open, skipping bounds fetch")
+
+			skippedCount++
+
+			continue
+		}
+
 		fetchWg.Add(1)
 
 		go func(net config.NetworkConfig) {
@@ -121,9 +148,19 @@ func (s *Service) FetchBounds(
 
 			errorCount++
 
+			// Don't count failures caused by our own cancellation (e.g. shutdown)
+			switch {
+			case ctx.Err() != nil:
+				s.breakers.abort(res.network)
+			case s.breakers.failure(res.network, res.err, time.Now()):
+				s.logger.WithField("network", res.network).Warn("Circuit breaker opened for network")
+			}
+
 			continue
 		}
 
+		s.breakers.success(res.network, time.Now())
+
 		boundsData[res.network] = res.bounds
 		successCount++
 	}
@@ -133,6 +170,7 @@ func (s *Service) FetchBounds(
 		"success": successCount,
 		"total":   len(networks),
 		"errors":  errorCount,
+		"skipped": skippedCount,
 	}
 
 	if errorCount > 0 {
@@ -157,12 +195,12 @@ func (s *Service) fetchBoundsForNetwork(
 
 	// If no local overrides, fetch from primary source only.
 	if network.LocalOverrides == nil {
-		return s.fetchBoundsFromURL(ctx, network.TargetURL, network.Name)
+		return s.fetchBoundsFromURL(ctx, network.TargetURL, network.Name, network.Auth)
 	}
 
 	// Hybrid mode: fetch both external and local, merge results.
 	externalBounds, externalErr := s.fetchBoundsFromURL(
-		ctx, network.TargetURL, network.Name,
+		ctx, network.TargetURL, network.Name, network.Auth,
 	)
 	if externalErr != nil {
 		s.logger.WithFields(logrus.Fields{
@@ -172,7 +210,7 @@ func (s *Service) fetchBoundsForNetwork(
 	}
 
 	localBounds, localErr := s.fetchBoundsFromURL(
-		ctx, network.LocalOverrides.TargetURL, network.Name,
+		ctx, network.LocalOverrides.TargetURL, network.Name, nil,
 	)
 	if localErr != nil {
 		s.logger.WithFields(logrus.Fields{
@@ -228,11 +266,13 @@ func (s *Service) fetchBoundsForNetwork(
 	return merged, nil
 }
 
-// fetchBoundsFromURL fetches bounds from a single cbt-api URL with pagination.
+// fetchBoundsFromURL fetches bounds from a single cbt-api URL with pagination,
+// sending auth's credentials if set.
 func (s *Service) fetchBoundsFromURL(
 	ctx context.Context,
 	targetURL string,
 	networkName string,
+	auth *config.UpstreamAuthConfig,
 ) (*BoundsData, error) {
 	var (
 		allRecords    = make([]IncrementalTableRecord, 0)
@@ -256,16 +296,18 @@ func (s *Service) fetchBoundsFromURL(
 			return nil, fmt.Errorf("create request: %w", err)
 		}
 
+		auth.Apply(req)
+
 		resp, err := s.httpClient.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("fetch data: %w", err)
+			return nil, fmt.Errorf("fetch data: %w: %w", errs.ErrUpstreamUnavailable, err)
 		}
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
 
-			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+			return nil, fmt.Errorf("%w: unexpected status %d: %s", errs.ErrUpstreamUnavailable, resp.StatusCode, string(body))
 		}
 
 		body, err := io.ReadAll(resp.Body)
@@ -18,6 +18,7 @@ import (
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
 )
 
 func TestService_calculateBounds(t *testing.T) {
@@ -111,6 +112,7 @@ func TestService_fetchBoundsForNetwork(t *testing.T) {
 		mockResponse  func(w http.ResponseWriter, r *http.Request)
 		expectError   bool
 		errorContains string
+		errorIs       error
 		validateData  func(t *testing.T, data *BoundsData)
 	}{
 		{
@@ -219,8 +221,8 @@ func TestService_fetchBoundsForNetwork(t *testing.T) {
 				w.WriteHeader(http.StatusInternalServerError)
 				w.Write([]byte("Internal Server Error")) //nolint:errcheck // test.
 			},
-			expectError:   true,
-			errorContains: "unexpected status 500",
+			expectError: true,
+			errorIs:     errs.ErrUpstreamUnavailable,
 		},
 		{
 			name: "invalid JSON returns error",
@@ -285,6 +287,10 @@ func TestService_fetchBoundsForNetwork(t *testing.T) {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
 
+				if tt.errorIs != nil {
+					require.ErrorIs(t, err, tt.errorIs)
+				}
+
 				return
 			}
 
@@ -536,6 +542,7 @@ func TestService_FetchBounds(t *testing.T) {
 				cartographoorProvider: mockProvider,
 				logger:                logger,
 				httpClient:            cfg.Bounds.HTTPClient(),
+				breakers:              newBreakers(config.CircuitBreakerConfig{FailureThreshold: 5, OpenDuration: time.Minute, MaxOpenDuration: time.Minute}),
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -558,3 +565,41 @@ func TestService_FetchBounds(t *testing.T) {
 		})
 	}
 }
+
+func TestService_FetchBounds_CircuitBreaker(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{{Name: "mainnet", TargetURL: server.URL}},
+		Bounds:   config.BoundsConfig{RequestTimeout: 10 * time.Second},
+	}
+	require.NoError(t, cfg.Bounds.Validate())
+
+	cfg.Bounds.CircuitBreaker.FailureThreshold = 2
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc, err := New(logger, cfg, nil)
+	require.NoError(t, err)
+
+	// The breaker opens after two failures and the upstream is no longer called
+	for range 4 {
+		_, err := svc.FetchBounds(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, calls)
+
+	status := svc.BreakerStatus()
+	require.Contains(t, status, "mainnet")
+	assert.Equal(t, BreakerOpen, status["mainnet"].State)
+	assert.Contains(t, status["mainnet"].LastError, "unexpected status 502")
+}
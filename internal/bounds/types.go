@@ -18,6 +18,8 @@ type TableBounds struct {
 type BoundsData struct {
 	Tables      map[string]TableBounds `json:"tables"`       // Map of table name to bounds
 	LastUpdated time.Time              `json:"last_updated"` // When this data was last fetched
+	LastSuccess time.Time              `json:"last_success"` // When the network was last fetched successfully
+	Stale       bool                   `json:"stale"`        // Fetching failed since LastSuccess; last known bounds are served
 }
 
 // Provider defines the interface for bounds data providers.
@@ -25,11 +27,23 @@ type BoundsData struct {
 type Provider interface {
 	Start(ctx context.Context) error
 	Stop() error
-	GetBounds(ctx context.Context, network string) (*BoundsData, bool)
+	// GetBounds returns the bounds of a network. Errors wrap errs.ErrNotFound,
+	// errs.ErrUpstreamUnavailable, or errs.ErrStale, which comes with the data.
+	GetBounds(ctx context.Context, network string) (*BoundsData, error)
 	GetAllBounds(ctx context.Context) map[string]*BoundsData
+	// GetStatus returns the per-network circuit breaker status of the bounds fetcher.
+	GetStatus(ctx context.Context) (*Status, error)
 	// NotifyChannel returns a channel that signals when bounds data has been updated.
 	// Consumers should listen on this channel to refresh cached data.
+	// Each call returns a new subscription channel.
 	NotifyChannel() <-chan struct{}
+	// SubscribeChanges returns a channel receiving the bounds changes of every
+	// refresh, and a function that ends the subscription. Subscribers that
+	// fall behind miss changes.
+	SubscribeChanges() (<-chan *Changes, func())
+	// LastChanges returns the changes of the most recent refresh seen by this
+	// instance, or nil before the first.
+	LastChanges() *Changes
 }
 
 // IncrementalTableRecord represents a single row from admin_cbt_incremental.
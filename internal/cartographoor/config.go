@@ -4,26 +4,75 @@ package cartographoor
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"path/filepath"
 	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 )
 
 const DefaultCartographoorURL = "https://ethpandaops-platform-production-cartographoor.ams3.cdn.digitaloceanspaces.com/networks.json"
 
 // Config holds cartographoor service configuration.
 type Config struct {
-	SourceURL       string        `yaml:"source_url"`       // Cartographoor JSON URL
-	RefreshInterval time.Duration `yaml:"refresh_interval"` // How often to refresh
-	RequestTimeout  time.Duration `yaml:"request_timeout"`  // HTTP request timeout
-	NetworksTTL     time.Duration `yaml:"networks_ttl"`     // Redis TTL for networks data (0 = no expiration)
+	SourceURL       string         `yaml:"source_url"`       // Cartographoor JSON URL, shorthand for a single source
+	Sources         []SourceConfig `yaml:"sources"`          // Sources in priority order, merged; replaces source_url
+	RefreshInterval time.Duration  `yaml:"refresh_interval"` // How often to refresh
+	RequestTimeout  time.Duration  `yaml:"request_timeout"`  // HTTP request timeout
+	NetworksTTL     time.Duration  `yaml:"networks_ttl"`     // Redis TTL for networks data (0 = no expiration)
+	CacheTTL        time.Duration  `yaml:"cache_ttl"`        // How long networks read from Redis are reused in memory, dropped early on updates (default 5s)
+}
+
+// SourceConfig is one cartographoor document. Networks listed by several
+// sources are taken from the first one listing them.
+type SourceConfig struct {
+	Name string `yaml:"name"` // Label used in logs (default: the URL's host or file name)
+	URL  string `yaml:"url"`  // http(s) URL, or a file path (optionally file://) of a networks.json document
+}
+
+// IsFile reports whether the source is read from the local filesystem.
+func (s *SourceConfig) IsFile() bool {
+	parsed, err := url.Parse(s.URL)
+
+	return err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https")
+}
+
+// Path returns the filesystem path of a file source.
+func (s *SourceConfig) Path() string {
+	if parsed, err := url.Parse(s.URL); err == nil && parsed.Scheme == "file" {
+		return parsed.Path
+	}
+
+	return s.URL
 }
 
 // Validate validates and sets defaults for Config.
 func (c *Config) Validate() error {
 	// Set defaults
-	if c.SourceURL == "" {
+	if len(c.Sources) > 0 && c.SourceURL != "" {
+		return fmt.Errorf("set either source_url or sources, not both")
+	}
+
+	if len(c.Sources) == 0 && c.SourceURL == "" {
 		c.SourceURL = DefaultCartographoorURL
 	}
 
+	for i := range c.Sources {
+		if c.Sources[i].URL == "" {
+			return fmt.Errorf("sources[%d] url cannot be empty", i)
+		}
+	}
+
+	names := make(map[string]bool, len(c.Sources))
+
+	for i, source := range c.SourceList() {
+		if names[source.Name] {
+			return fmt.Errorf("sources[%d] duplicate name %q", i, source.Name)
+		}
+
+		names[source.Name] = true
+	}
+
 	if c.RefreshInterval == 0 {
 		c.RefreshInterval = 5 * time.Minute
 	}
@@ -32,6 +81,10 @@ func (c *Config) Validate() error {
 		c.RequestTimeout = 30 * time.Second
 	}
 
+	if c.CacheTTL == 0 {
+		c.CacheTTL = 5 * time.Second
+	}
+
 	// Validate ranges
 	if c.RefreshInterval < 1*time.Minute {
 		return fmt.Errorf("refresh_interval must be at least 1 minute, got %v", c.RefreshInterval)
@@ -41,12 +94,50 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("request_timeout must be at least 1 second, got %v", c.RequestTimeout)
 	}
 
+	if c.CacheTTL < 0 || c.CacheTTL > c.RefreshInterval {
+		return fmt.Errorf("cache_ttl must be between 0 and refresh_interval, got %v", c.CacheTTL)
+	}
+
 	return nil
 }
 
-// HTTPClient creates an HTTP client with configured timeout.
+// HTTPClient creates an HTTP client with configured timeout. Identical
+// requests in flight at the same time are sent once.
 func (c *Config) HTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: c.RequestTimeout,
+		Timeout:   c.RequestTimeout,
+		Transport: upstream.Coalesced(upstream.NewTransport(upstream.SubsystemCartographoor, nil)),
 	}
 }
+
+// SourceList returns the sources in priority order, with names defaulted:
+// sources if set, otherwise source_url alone.
+func (c *Config) SourceList() []SourceConfig {
+	sources := c.Sources
+	if len(sources) == 0 {
+		sources = []SourceConfig{{URL: c.SourceURL}}
+	}
+
+	out := make([]SourceConfig, 0, len(sources))
+
+	for _, source := range sources {
+		if source.Name == "" {
+			source.Name = source.defaultName()
+		}
+
+		out = append(out, source)
+	}
+
+	return out
+}
+
+// defaultName names a source after its URL's host, or its file name.
+func (s *SourceConfig) defaultName() string {
+	if s.IsFile() {
+		return filepath.Base(s.Path())
+	}
+
+	parsed, _ := url.Parse(s.URL) //nolint:errcheck // IsFile covers invalid URLs.
+
+	return parsed.Host
+}
@@ -0,0 +1,155 @@
+//nolint:tagliatelle // superior snake-case yo.
+package cartographoor
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Changes summarises how the network list changed between two updates.
+type Changes struct {
+	Added   []string        `json:"added,omitempty"`   // Networks that appeared
+	Removed []string        `json:"removed,omitempty"` // Networks that disappeared or failed health checks
+	Changed []NetworkChange `json:"changed,omitempty"` // Networks whose data changed
+}
+
+// NetworkChange lists the fields that changed for one network.
+type NetworkChange struct {
+	Network string   `json:"network"`
+	Fields  []string `json:"fields"` // e.g. "target_url", "service_urls", "forks"
+}
+
+// Diff returns the changes from previous to current. LastUpdated is ignored,
+// so a republished but otherwise identical document yields no changes.
+func Diff(previous, current map[string]*Network) *Changes {
+	changes := &Changes{}
+
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		prev, ok := previous[name]
+		if !ok {
+			changes.Added = append(changes.Added, name)
+
+			continue
+		}
+
+		if fields := changedFields(prev, current[name]); len(fields) > 0 {
+			changes.Changed = append(changes.Changed, NetworkChange{Network: name, Fields: fields})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[name]; !ok {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+
+	return changes
+}
+
+// changedFields returns the names of the fields that differ between a and b.
+func changedFields(a, b *Network) []string {
+	var fields []string
+
+	if a.Status != b.Status {
+		fields = append(fields, "status")
+	}
+
+	if a.DisplayName != b.DisplayName || a.Description != b.Description {
+		fields = append(fields, "metadata")
+	}
+
+	if a.ChainID != b.ChainID {
+		fields = append(fields, "chain_id")
+	}
+
+	if a.GenesisTime != b.GenesisTime || a.GenesisDelay != b.GenesisDelay ||
+		a.SecondsPerSlot != b.SecondsPerSlot || a.SlotsPerEpoch != b.SlotsPerEpoch ||
+		a.GenesisForkVersion != b.GenesisForkVersion {
+		fields = append(fields, "genesis")
+	}
+
+	if a.TargetURL != b.TargetURL {
+		fields = append(fields, "target_url")
+	}
+
+	if !maps.Equal(a.ServiceUrls, b.ServiceUrls) {
+		fields = append(fields, "service_urls")
+	}
+
+	if !reflect.DeepEqual(a.Forks, b.Forks) {
+		fields = append(fields, "forks")
+	}
+
+	if !slices.Equal(a.BlobSchedule, b.BlobSchedule) {
+		fields = append(fields, "blob_schedule")
+	}
+
+	return fields
+}
+
+// Empty reports whether nothing changed.
+func (c *Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// Without returns the changes with the given networks left out.
+func (c *Changes) Without(networks map[string]bool) *Changes {
+	return c.Filter(func(name string) bool { return !networks[name] })
+}
+
+// Filter returns the changes of the networks keep returns true for.
+func (c *Changes) Filter(keep func(network string) bool) *Changes {
+	filtered := &Changes{}
+
+	for _, name := range c.Added {
+		if keep(name) {
+			filtered.Added = append(filtered.Added, name)
+		}
+	}
+
+	for _, name := range c.Removed {
+		if keep(name) {
+			filtered.Removed = append(filtered.Removed, name)
+		}
+	}
+
+	for _, change := range c.Changed {
+		if keep(change.Network) {
+			filtered.Changed = append(filtered.Changed, change)
+		}
+	}
+
+	return filtered
+}
+
+// String returns a one-line summary for logs, e.g.
+// "added: holesky; removed: goerli; changed: sepolia (forks, service_urls)".
+func (c *Changes) String() string {
+	if c.Empty() {
+		return "no changes"
+	}
+
+	var parts []string
+
+	if len(c.Added) > 0 {
+		parts = append(parts, "added: "+strings.Join(c.Added, ", "))
+	}
+
+	if len(c.Removed) > 0 {
+		parts = append(parts, "removed: "+strings.Join(c.Removed, ", "))
+	}
+
+	if len(c.Changed) > 0 {
+		changed := make([]string, 0, len(c.Changed))
+		for _, change := range c.Changed {
+			changed = append(changed, fmt.Sprintf("%s (%s)", change.Network, strings.Join(change.Fields, ", ")))
+		}
+
+		parts = append(parts, "changed: "+strings.Join(changed, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
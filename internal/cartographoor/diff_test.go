@@ -0,0 +1,56 @@
+package cartographoor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	previous := map[string]*Network{
+		"mainnet": {Name: "mainnet", ChainID: 1, TargetURL: "https://a", LastUpdated: time.Unix(1, 0)},
+		"sepolia": {
+			Name:        "sepolia",
+			ServiceUrls: map[string]string{"beacon": "https://beacon"},
+			Forks:       Forks{Consensus: map[string]ConsensusFork{"electra": {Epoch: 1}}},
+		},
+		"goerli": {Name: "goerli"},
+	}
+
+	current := map[string]*Network{
+		// Only LastUpdated differs
+		"mainnet": {Name: "mainnet", ChainID: 1, TargetURL: "https://a", LastUpdated: time.Unix(2, 0)},
+		"sepolia": {
+			Name:        "sepolia",
+			ServiceUrls: map[string]string{"beacon": "https://beacon-2"},
+			Forks:       Forks{Consensus: map[string]ConsensusFork{"electra": {Epoch: 2}}},
+		},
+		"holesky": {Name: "holesky"},
+	}
+
+	changes := Diff(previous, current)
+
+	assert.Equal(t, []string{"holesky"}, changes.Added)
+	assert.Equal(t, []string{"goerli"}, changes.Removed)
+	assert.Equal(t, []NetworkChange{{Network: "sepolia", Fields: []string{"service_urls", "forks"}}}, changes.Changed)
+	assert.Equal(t, "added: holesky; removed: goerli; changed: sepolia (service_urls, forks)", changes.String())
+
+	assert.True(t, Diff(current, current).Empty())
+	assert.Equal(t, []string{"holesky", "mainnet", "sepolia"}, Diff(nil, current).Added)
+}
+
+func TestChanges_Without(t *testing.T) {
+	changes := &Changes{
+		Added:   []string{"devnet-1", "holesky"},
+		Removed: []string{"devnet-0"},
+		Changed: []NetworkChange{{Network: "devnet-2", Fields: []string{"forks"}}},
+	}
+
+	filtered := changes.Without(map[string]bool{"devnet-0": true, "devnet-1": true, "devnet-2": true})
+
+	assert.Equal(t, []string{"holesky"}, filtered.Added)
+	assert.Empty(t, filtered.Removed)
+	assert.Empty(t, filtered.Changed)
+	assert.Len(t, changes.Added, 2, "the original is left untouched")
+}
@@ -0,0 +1,66 @@
+package cartographoor
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sanity limits for genesis data. Networks outside them are quarantined, as
+// their wallclocks would convert slots to wrong times.
+const (
+	// minGenesisTime predates every beacon chain, mainnet launched in December 2020.
+	minGenesisTime = int64(1577836800) // 2020-01-01T00:00:00Z
+
+	// maxGenesisLead is how far in the future a genesis may be scheduled.
+	maxGenesisLead = 365 * 24 * time.Hour
+
+	// maxGenesisDelay is the longest genesis delay accepted; mainnet used 7 days.
+	maxGenesisDelay = 30 * 24 * time.Hour
+
+	// maxSecondsPerSlot is the longest slot duration accepted.
+	maxSecondsPerSlot = 60
+)
+
+// validateGenesis checks that the genesis data of a network is usable for
+// slot conversions at now.
+func validateGenesis(network *Network, now time.Time) error {
+	if network.GenesisTime == 0 {
+		return fmt.Errorf("genesis time is not set")
+	}
+
+	if network.GenesisTime < minGenesisTime {
+		return fmt.Errorf("genesis time %d is before %s", network.GenesisTime, time.Unix(minGenesisTime, 0).UTC().Format(time.DateOnly))
+	}
+
+	if network.GenesisDelay < 0 || time.Duration(network.GenesisDelay)*time.Second > maxGenesisDelay {
+		return fmt.Errorf("genesis delay %ds is outside 0s to %s", network.GenesisDelay, maxGenesisDelay)
+	}
+
+	genesis := time.Unix(network.GenesisTime+network.GenesisDelay, 0)
+	if genesis.After(now.Add(maxGenesisLead)) {
+		return fmt.Errorf("genesis %s is more than %s in the future", genesis.UTC().Format(time.RFC3339), maxGenesisLead)
+	}
+
+	if network.SecondsPerSlot > maxSecondsPerSlot {
+		return fmt.Errorf("seconds per slot %d exceeds %d", network.SecondsPerSlot, maxSecondsPerSlot)
+	}
+
+	return nil
+}
+
+// quarantine marks active networks with invalid genesis data as quarantined,
+// so they are not served until cartographoor publishes valid data.
+func (s *Service) quarantine(networks map[string]*Network, now time.Time) {
+	for name, network := range networks {
+		if network.Status != NetworkStatusActive {
+			continue
+		}
+
+		if err := validateGenesis(network, now); err != nil {
+			network.Status = NetworkStatusQuarantined
+			network.StatusReason = err.Error()
+
+			s.logger.WithField("network", name).WithError(err).Warn("Quarantined network with invalid genesis data")
+		}
+	}
+}
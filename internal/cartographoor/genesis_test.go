@@ -0,0 +1,51 @@
+package cartographoor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	now := time.Unix(1760000000, 0)
+
+	tests := []struct {
+		name          string
+		network       Network
+		errorContains string
+	}{
+		{name: "mainnet", network: Network{GenesisTime: 1606824000, GenesisDelay: 23}},
+		{name: "upcoming devnet", network: Network{GenesisTime: now.Unix() + 3600, GenesisDelay: 300, SecondsPerSlot: 6}},
+		{name: "unset", network: Network{}, errorContains: "genesis time is not set"},
+		{name: "too far past", network: Network{GenesisTime: 1000}, errorContains: "is before 2020-01-01"},
+		{name: "negative delay", network: Network{GenesisTime: 1606824000, GenesisDelay: -1}, errorContains: "genesis delay -1s"},
+		{name: "huge delay", network: Network{GenesisTime: 1606824000, GenesisDelay: 90 * 24 * 3600}, errorContains: "genesis delay"},
+		{
+			name:          "too far future",
+			network:       Network{GenesisTime: now.Add(2 * maxGenesisLead).Unix()},
+			errorContains: "in the future",
+		},
+		{
+			name:          "millisecond timestamp",
+			network:       Network{GenesisTime: 1606824000000},
+			errorContains: "in the future",
+		},
+		{name: "slow slots", network: Network{GenesisTime: 1606824000, SecondsPerSlot: 120}, errorContains: "seconds per slot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGenesis(&tt.network, now)
+			if tt.errorContains == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
@@ -56,11 +56,11 @@ func (mr *MockProviderMockRecorder) GetActiveNetworks(ctx any) *gomock.Call {
 }
 
 // GetNetwork mocks base method.
-func (m *MockProvider) GetNetwork(ctx context.Context, name string) (*cartographoor.Network, bool) {
+func (m *MockProvider) GetNetwork(ctx context.Context, name string) (*cartographoor.Network, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetNetwork", ctx, name)
 	ret0, _ := ret[0].(*cartographoor.Network)
-	ret1, _ := ret[1].(bool)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -84,6 +84,20 @@ func (mr *MockProviderMockRecorder) GetNetworks(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworks", reflect.TypeOf((*MockProvider)(nil).GetNetworks), ctx)
 }
 
+// LastChanges mocks base method.
+func (m *MockProvider) LastChanges() *cartographoor.Changes {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastChanges")
+	ret0, _ := ret[0].(*cartographoor.Changes)
+	return ret0
+}
+
+// LastChanges indicates an expected call of LastChanges.
+func (mr *MockProviderMockRecorder) LastChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastChanges", reflect.TypeOf((*MockProvider)(nil).LastChanges))
+}
+
 // NotifyChannel mocks base method.
 func (m *MockProvider) NotifyChannel() <-chan struct{} {
 	m.ctrl.T.Helper()
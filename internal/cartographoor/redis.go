@@ -3,32 +3,65 @@ package cartographoor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethpandaops/lab-backend/internal/compat"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
 	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/notify"
 	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Compile-time interface compliance check.
 var _ Provider = (*RedisProvider)(nil)
 
-const redisNetworksKey = "lab:config:networks"
+const (
+	redisNetworksKey = "lab:config:networks"
+
+	// redisChangesChannel carries the network changes of every update from
+	// the leader to followers.
+	redisChangesChannel = "lab:config:networks:changes"
+)
 
 // RedisProvider implements Provider interface using Redis as storage.
 type RedisProvider struct {
-	log        logrus.FieldLogger
-	cfg        Config
-	redis      redis.Client
-	elector    leader.Elector
-	upstream   *Service
-	done       chan struct{}
-	notifyChan chan struct{} // Signals when network data has been updated
-	wg         sync.WaitGroup
+	log      logrus.FieldLogger
+	cfg      Config
+	redis    redis.Client
+	elector  leader.Elector
+	compat   compat.Gate
+	upstream *Service
+	done     chan struct{}
+	notifier *notify.Broadcaster // Signals when network data has been updated
+	wg       sync.WaitGroup
+	task     *tasks.Task
+
+	lastChanges atomic.Pointer[Changes] // Changes of the most recent update
+
+	// Networks last read from Redis, reused until cache_ttl passes or they
+	// are updated
+	cacheMu    sync.Mutex
+	cache      *cachedNetworks
+	cacheEpoch uint64 // Bumped on every invalidation
+}
+
+// cachedNetworks is a decoded copy of the networks in Redis.
+type cachedNetworks struct {
+	networks map[string]*Network
+	expires  time.Time
 }
 
 // NewRedisProvider creates a Redis-backed cartographoor provider.
@@ -37,16 +70,18 @@ func NewRedisProvider(
 	cfg Config,
 	redisClient redis.Client,
 	elector leader.Elector,
+	gate compat.Gate,
 	upstream *Service,
 ) Provider {
 	return &RedisProvider{
-		log:        log.WithField("component", "cartographoor_redis"),
-		cfg:        cfg,
-		redis:      redisClient,
-		elector:    elector,
-		upstream:   upstream,
-		done:       make(chan struct{}),
-		notifyChan: make(chan struct{}, 1), // Buffered so we don't block
+		log:      log.WithField("component", "cartographoor_redis"),
+		cfg:      cfg,
+		redis:    redisClient,
+		elector:  elector,
+		compat:   gate,
+		upstream: upstream,
+		done:     make(chan struct{}),
+		notifier: notify.New(),
 	}
 }
 
@@ -55,7 +90,24 @@ func NewRedisProvider(
 func (r *RedisProvider) Start(ctx context.Context) error {
 	r.log.Info("Starting cartographoor provider")
 
+	// Receive network changes from the leader. Without the subscription
+	// followers still pick up new data on their next poll.
+	subCtx, cancel := context.WithCancel(ctx)
+
+	messages, err := r.redis.Subscribe(subCtx, redisChangesChannel)
+	if err != nil {
+		cancel()
+		r.log.WithError(err).Warn("Failed to subscribe to network changes")
+	} else {
+		r.wg.Go(func() {
+			defer cancel()
+
+			r.changesLoop(subCtx, messages)
+		})
+	}
+
 	// Start background refresh loop
+	r.task = tasks.Default().Register("cartographoor.refresh", r.cfg.RefreshInterval)
 	r.wg.Add(1)
 
 	go r.refreshLoop(ctx)
@@ -103,33 +155,32 @@ func (r *RedisProvider) Stop() error {
 	return nil
 }
 
-// GetNetworks returns all networks by reading directly from Redis.
+// GetNetworks returns all networks, read from Redis or the in-memory cache.
 func (r *RedisProvider) GetNetworks(ctx context.Context) map[string]*Network {
-	data, err := r.redis.Get(ctx, redisNetworksKey)
+	networks, err := r.loadNetworks(ctx)
 	if err != nil {
 		r.log.WithError(err).Debug("Failed to get networks from Redis")
 
 		return make(map[string]*Network)
 	}
 
-	var networks map[string]*Network
-	if err := json.Unmarshal([]byte(data), &networks); err != nil {
-		r.log.WithError(err).Error("Failed to unmarshal networks")
-
-		return make(map[string]*Network)
-	}
-
-	return networks
+	return maps.Clone(networks)
 }
 
-// GetActiveNetworks returns only active networks by reading directly from Redis.
+// GetActiveNetworks returns only active networks, read from Redis or the
+// in-memory cache.
 func (r *RedisProvider) GetActiveNetworks(
 	ctx context.Context,
 ) map[string]*Network {
-	allNetworks := r.GetNetworks(ctx)
-
 	result := make(map[string]*Network)
 
+	allNetworks, err := r.loadNetworks(ctx)
+	if err != nil {
+		r.log.WithError(err).Debug("Failed to get networks from Redis")
+
+		return result
+	}
+
 	for name, network := range allNetworks {
 		if network.Status == NetworkStatusActive {
 			result[name] = network
@@ -139,37 +190,92 @@ func (r *RedisProvider) GetActiveNetworks(
 	return result
 }
 
-// GetNetwork returns a specific network by reading directly from Redis.
+// GetNetwork returns a specific network, read from Redis or the in-memory
+// cache. Errors wrap errs.ErrNotFound or errs.ErrUpstreamUnavailable.
 func (r *RedisProvider) GetNetwork(
 	ctx context.Context,
 	name string,
-) (*Network, bool) {
-	allNetworks := r.GetNetworks(ctx)
-	network, ok := allNetworks[name]
+) (*Network, error) {
+	networks, err := r.loadNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	network, ok := networks[name]
+	if !ok {
+		return nil, fmt.Errorf("network %s: %w", name, errs.ErrNotFound)
+	}
+
+	return network, nil
+}
+
+// loadNetworks returns the decoded networks in Redis, from memory while the
+// last read is younger than cache_ttl. The networks are shared between
+// callers and must not be modified. Errors wrap errs.ErrNotFound or
+// errs.ErrUpstreamUnavailable.
+func (r *RedisProvider) loadNetworks(ctx context.Context) (map[string]*Network, error) {
+	r.cacheMu.Lock()
+	cached, epoch := r.cache, r.cacheEpoch
+	r.cacheMu.Unlock()
+
+	if cached != nil && time.Now().Before(cached.expires) {
+		return cached.networks, nil
+	}
+
+	data, err := r.redis.Get(ctx, redisNetworksKey)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, fmt.Errorf("networks: %w", errs.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read networks: %w: %w", errs.ErrUpstreamUnavailable, err)
+	}
+
+	var networks map[string]*Network
+	if err := json.Unmarshal([]byte(data), &networks); err != nil {
+		return nil, fmt.Errorf("unmarshal networks: %w", err)
+	}
+
+	// Networks read before an update are not cached
+	r.cacheMu.Lock()
+	if r.cacheEpoch == epoch {
+		r.cache = &cachedNetworks{networks: networks, expires: time.Now().Add(r.cfg.CacheTTL)}
+	}
+	r.cacheMu.Unlock()
+
+	return networks, nil
+}
+
+// invalidateCache drops the cached networks, so the next read goes to Redis.
+func (r *RedisProvider) invalidateCache() {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
 
-	return network, ok
+	r.cache = nil
+	r.cacheEpoch++
 }
 
 // NotifyChannel returns a channel that signals when network data has been updated.
+// Each call returns a new subscription, so multiple consumers can listen.
 func (r *RedisProvider) NotifyChannel() <-chan struct{} {
-	return r.notifyChan
+	return r.notifier.Subscribe()
 }
 
+// refreshLoop runs the refresh loop under the task supervisor, which
+// restarts it with backoff if it panics.
 func (r *RedisProvider) refreshLoop(ctx context.Context) {
-	defer func() {
-		if rec := recover(); rec != nil {
-			r.log.WithField("panic", rec).Error("Cartographoor refresh loop panicked")
-		}
+	defer r.wg.Done()
 
-		r.wg.Done()
-	}()
+	r.task.Supervise(r.log, r.done, func() { r.runRefreshLoop(ctx) })
+}
 
-	ticker := time.NewTicker(r.cfg.RefreshInterval)
+func (r *RedisProvider) runRefreshLoop(ctx context.Context) {
+	ticker := jitter.NewTicker(r.cfg.RefreshInterval)
 	defer ticker.Stop()
 
 	// Follower polling ticker - notifies frontend to check Redis for updates
 	// This ensures follower pods update their in-memory cache when leader updates Redis
-	followerPollTicker := time.NewTicker(r.cfg.RefreshInterval)
+	followerPollTicker := jitter.NewTicker(r.cfg.RefreshInterval)
 	defer followerPollTicker.Stop()
 
 	// Give leader election a moment to settle (it tries immediately on boot)
@@ -177,7 +283,7 @@ func (r *RedisProvider) refreshLoop(ctx context.Context) {
 
 	// Immediate refresh on startup if leader
 	if r.elector.IsLeader() {
-		r.refreshData(ctx)
+		_ = r.task.Run(func() error { return r.refreshData(ctx) })
 	}
 
 	for {
@@ -189,12 +295,13 @@ func (r *RedisProvider) refreshLoop(ctx context.Context) {
 		case <-ticker.C:
 			// Only leader refreshes from upstream
 			if r.elector.IsLeader() {
-				r.refreshData(ctx)
+				_ = r.task.Run(func() error { return r.refreshData(ctx) })
 			}
 		case <-followerPollTicker.C:
 			// Followers notify their frontend/consumers to re-read from Redis
 			// This ensures all pods stay in sync with Redis state
 			if !r.elector.IsLeader() {
+				r.task.Tick()
 				r.notifyFollowers()
 			}
 		}
@@ -204,15 +311,24 @@ func (r *RedisProvider) refreshLoop(ctx context.Context) {
 // notifyFollowers sends a notification to consumers to refresh from Redis.
 // This is used by follower pods to stay in sync with Redis updates from the leader.
 func (r *RedisProvider) notifyFollowers() {
-	select {
-	case r.notifyChan <- struct{}{}:
+	r.invalidateCache()
+
+	if r.notifier.Notify() {
 		r.log.Debug("Notified consumers to refresh from Redis (follower)")
-	default:
-		// Channel already has a pending notification, skip
 	}
 }
 
-func (r *RedisProvider) refreshData(ctx context.Context) {
+// refreshData fetches networks from upstream and stores the healthy ones in Redis.
+// Failures are logged and also returned for task introspection.
+func (r *RedisProvider) refreshData(ctx context.Context) (err error) {
+	// Leadership may have been lost since the caller checked
+	if !r.elector.IsLeader() {
+		return errs.ErrNotLeader
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "cartographoor.refresh", trace.SpanKindInternal)
+	defer func() { tracing.EndSpan(span, err) }()
+
 	r.log.Debug("Refreshing cartographoor data from upstream")
 
 	// Fetch fresh data from upstream (no caching, just HTTP call)
@@ -220,7 +336,7 @@ func (r *RedisProvider) refreshData(ctx context.Context) {
 	if err != nil {
 		r.log.WithError(err).Error("Failed to fetch networks from upstream")
 
-		return
+		return fmt.Errorf("fetch networks: %w", err)
 	}
 
 	// Filter for active networks only
@@ -235,7 +351,7 @@ func (r *RedisProvider) refreshData(ctx context.Context) {
 	if len(activeNetworks) == 0 {
 		r.log.Warn("No active networks found in upstream data")
 
-		return
+		return fmt.Errorf("no active networks found in upstream data")
 	}
 
 	// Filter for healthy networks only (health check each backend)
@@ -244,7 +360,7 @@ func (r *RedisProvider) refreshData(ctx context.Context) {
 	if len(healthyNetworks) == 0 {
 		r.log.Warn("No healthy networks found after health checks")
 
-		return
+		return fmt.Errorf("no healthy networks found after health checks")
 	}
 
 	r.log.WithFields(logrus.Fields{
@@ -252,28 +368,130 @@ func (r *RedisProvider) refreshData(ctx context.Context) {
 		"healthy": len(healthyNetworks),
 	}).Debug("Filtered networks by health")
 
-	// Serialize to JSON
-	data, err := json.Marshal(healthyNetworks)
+	// Serialize in a format every active replica reads
+	version, err := r.compat.WriteVersion()
+	if err != nil {
+		r.log.WithError(err).Error("Refusing to write networks")
+
+		return fmt.Errorf("write version: %w", err)
+	}
+
+	data, err := compat.Marshal(version, healthyNetworks)
 	if err != nil {
 		r.log.WithError(err).Error("Failed to marshal networks")
 
-		return
+		return fmt.Errorf("marshal networks: %w", err)
 	}
 
-	// Store in Redis with configured TTL
+	// Compare with the stored networks. Unchanged data is only rewritten to
+	// renew its TTL, and listeners are only notified of actual changes
 	ttl := r.cfg.NetworksTTL // 0 = no TTL (configurable)
+	stored, previous := r.storedNetworks(ctx)
+	changes := Diff(previous, healthyNetworks)
+
+	if stored == string(data) && ttl == 0 {
+		r.log.Debug("Cartographoor networks unchanged")
+
+		return nil
+	}
+
+	// Store in Redis with configured TTL
 	if err := r.redis.Set(ctx, redisNetworksKey, string(data), ttl); err != nil {
 		r.log.WithError(err).Error("Failed to store networks in Redis")
 
-		return
+		return fmt.Errorf("store networks: %w", err)
 	}
 
+	r.invalidateCache()
+
+	if changes.Empty() {
+		return nil
+	}
+
+	r.log.WithFields(logrus.Fields{
+		"added":   len(changes.Added),
+		"removed": len(changes.Removed),
+		"changed": len(changes.Changed),
+	}).Infof("Cartographoor networks changed: %s", changes)
+
+	r.lastChanges.Store(changes)
+	r.publishChanges(ctx, changes)
+
 	// Notify listeners that network data has been updated (non-blocking)
-	select {
-	case r.notifyChan <- struct{}{}:
+	if r.notifier.Notify() {
 		r.log.Debug("Notified listeners of cartographoor update")
-	default:
-		// Channel already has a pending notification, skip
+	}
+
+	return nil
+}
+
+// storedNetworks returns the raw and decoded networks currently in Redis.
+func (r *RedisProvider) storedNetworks(ctx context.Context) (string, map[string]*Network) {
+	data, err := r.redis.Get(ctx, redisNetworksKey)
+	if err != nil {
+		return "", nil
+	}
+
+	var networks map[string]*Network
+	if err := json.Unmarshal([]byte(data), &networks); err != nil {
+		return data, nil
+	}
+
+	return data, networks
+}
+
+// publishChanges sends changes to followers, which pass them on to their
+// listeners.
+func (r *RedisProvider) publishChanges(ctx context.Context, changes *Changes) {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to marshal network changes")
+
+		return
+	}
+
+	if err := r.redis.Publish(ctx, redisChangesChannel, string(data)); err != nil {
+		r.log.WithError(err).Warn("Failed to publish network changes")
+	}
+}
+
+// LastChanges returns the most recent network changes seen by this instance,
+// or nil before the first.
+func (r *RedisProvider) LastChanges() *Changes {
+	return r.lastChanges.Load()
+}
+
+// changesLoop receives the changes published by the leader. Followers record
+// them and notify their listeners straight away, rather than on their next poll.
+func (r *RedisProvider) changesLoop(ctx context.Context, messages <-chan string) {
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			// The leader already notified its own listeners
+			if r.elector.IsLeader() {
+				continue
+			}
+
+			var changes Changes
+			if err := json.Unmarshal([]byte(msg), &changes); err != nil {
+				r.log.WithError(err).Warn("Failed to unmarshal network changes")
+
+				continue
+			}
+
+			r.log.Infof("Cartographoor networks changed: %s", &changes)
+			r.lastChanges.Store(&changes)
+			r.invalidateCache()
+			r.notifier.Notify()
+		}
 	}
 }
 
@@ -356,7 +574,8 @@ func (r *RedisProvider) checkNetworkHealth(targetURL string) (bool, string) {
 
 	// Create HTTP client with short timeout for health checks
 	client := &http.Client{
-		Timeout: 5 * time.Second,
+		Timeout:   5 * time.Second,
+		Transport: upstream.NewTransport(upstream.SubsystemCartographoor, nil),
 	}
 
 	// Perform health check
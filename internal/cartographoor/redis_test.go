@@ -8,12 +8,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethpandaops/lab-backend/internal/compat"
+	"github.com/ethpandaops/lab-backend/internal/errs"
 	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
 	redismocks "github.com/ethpandaops/lab-backend/internal/redis/mocks"
 )
@@ -70,6 +73,7 @@ func TestRedisProvider_GetNetworks(t *testing.T) {
 				Config{},
 				mockRedis,
 				mockElector,
+				compat.Latest,
 				nil,
 			)
 
@@ -137,6 +141,7 @@ func TestRedisProvider_GetActiveNetworks(t *testing.T) {
 				Config{},
 				mockRedis,
 				mockElector,
+				compat.Latest,
 				nil,
 			)
 
@@ -201,22 +206,70 @@ func TestRedisProvider_GetNetwork(t *testing.T) {
 				Config{},
 				mockRedis,
 				mockElector,
+				compat.Latest,
 				nil,
 			)
 
 			ctx := context.Background()
-			network, found := provider.GetNetwork(ctx, tt.requestedNet)
+			network, err := provider.GetNetwork(ctx, tt.requestedNet)
 
-			assert.Equal(t, tt.expectFound, found)
+			if !tt.expectFound {
+				require.ErrorIs(t, err, errs.ErrNotFound)
 
-			if tt.expectFound {
-				require.NotNil(t, network)
-				assert.Equal(t, tt.requestedNet, network.Name)
+				return
 			}
+
+			require.NoError(t, err)
+			require.NotNil(t, network)
+			assert.Equal(t, tt.requestedNet, network.Name)
 		})
 	}
 }
 
+func TestRedisProvider_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	networks := map[string]*Network{
+		"mainnet": {Name: "mainnet", Status: NetworkStatusActive},
+	}
+
+	// Read once per update, however often networks are looked up
+	mockRedis := redismocks.NewMockClient(ctrl)
+	mockRedis.EXPECT().
+		Get(gomock.Any(), redisNetworksKey).
+		Return(mustMarshalCarto(t, networks), nil).
+		Times(2)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	provider := NewRedisProvider(
+		logger,
+		Config{CacheTTL: time.Minute},
+		mockRedis,
+		leadermocks.NewMockElector(ctrl),
+		compat.Latest,
+		nil,
+	).(*RedisProvider)
+
+	ctx := context.Background()
+
+	assert.Len(t, provider.GetActiveNetworks(ctx), 1)
+
+	network, err := provider.GetNetwork(ctx, "mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", network.Name)
+
+	// Callers get their own map
+	delete(provider.GetNetworks(ctx), "mainnet")
+	assert.Len(t, provider.GetNetworks(ctx), 1)
+
+	provider.invalidateCache()
+
+	assert.Len(t, provider.GetNetworks(ctx), 1)
+}
+
 func TestRedisProvider_checkNetworkHealth(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -317,6 +370,7 @@ func TestRedisProvider_NotifyChannel(t *testing.T) {
 		Config{},
 		mockRedis,
 		mockElector,
+		compat.Latest,
 		nil,
 	)
 
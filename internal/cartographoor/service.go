@@ -3,19 +3,40 @@ package cartographoor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
 )
 
-// Service is a stateless fetcher that retrieves network data from Cartographoor API.
+// Service fetches network data from one or more cartographoor sources and
+// merges them in priority order. It remembers the validators of each source's
+// last document and sends conditional requests, reusing the last result when
+// upstream reports it unchanged.
 type Service struct {
 	config     *Config
 	logger     logrus.FieldLogger
 	httpClient *http.Client
+	sources    []*source
+}
+
+// source is one cartographoor document and the state of its last fetch.
+type source struct {
+	cfg SourceConfig
+
+	mu           sync.Mutex
+	etag         string              // ETag of the last document
+	lastModified string              // Last-Modified of the last document
+	last         map[string]*Network // Networks parsed from the last document
 }
 
 // New creates a new cartographoor service.
@@ -28,32 +49,135 @@ func New(cfg *Config, logger logrus.FieldLogger) (*Service, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	sourceList := cfg.SourceList()
+	sources := make([]*source, 0, len(sourceList))
+
+	for _, sourceCfg := range sourceList {
+		sources = append(sources, &source{cfg: sourceCfg})
+	}
+
 	return &Service{
 		config:     cfg,
 		logger:     logger.WithField("component", "cartographoor"),
 		httpClient: cfg.HTTPClient(),
+		sources:    sources,
 	}, nil
 }
 
-// FetchNetworks fetches network data from Cartographoor API and returns it.
+// FetchNetworks fetches network data from every source and returns it merged:
+// a network listed by several sources is taken from the first one. A failing
+// source is replaced by its last document while any other source succeeds;
+// an error is returned only when every source fails.
 func (s *Service) FetchNetworks(
 	ctx context.Context,
 ) (map[string]*Network, error) {
 	s.logger.Debug("Fetching cartographoor data")
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.SourceURL, http.NoBody)
+	results := make([]map[string]*Network, len(s.sources))
+	failures := make([]error, 0)
+
+	for i, src := range s.sources {
+		networks, err := s.fetchSource(ctx, src)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("source %s: %w", src.cfg.Name, err))
+
+			continue
+		}
+
+		results[i] = networks
+	}
+
+	if len(failures) == len(s.sources) {
+		return nil, errors.Join(failures...)
+	}
+
+	merged := make(map[string]*Network)
+
+	// Lowest priority first, so earlier sources win
+	for i := len(s.sources) - 1; i >= 0; i-- {
+		networks := results[i]
+
+		if networks == nil {
+			src := s.sources[i]
+			log := s.logger.WithField("source", src.cfg.Name)
+
+			networks = src.lastNetworks()
+			if networks == nil {
+				log.Warn("Cartographoor source unavailable, skipping it")
+
+				continue
+			}
+
+			log.Warn("Cartographoor source unavailable, using its last document")
+		}
+
+		maps.Copy(merged, networks)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"sources":         len(s.sources),
+		"failed_sources":  len(failures),
+		"total_networks":  len(merged),
+		"active_networks": s.countActive(merged),
+	}).Debug("Fetched cartographoor data")
+
+	return merged, nil
+}
+
+// fetchSource fetches and parses one source's document.
+func (s *Service) fetchSource(ctx context.Context, src *source) (map[string]*Network, error) {
+	if src.cfg.IsFile() {
+		body, err := os.ReadFile(src.cfg.Path())
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+
+		networks, err := s.parse(body)
+		if err != nil {
+			return nil, err
+		}
+
+		src.store(networks, "", "")
+
+		return maps.Clone(networks), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.cfg.URL, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
+	src.mu.Lock()
+	if src.last != nil {
+		if src.etag != "" {
+			req.Header.Set("If-None-Match", src.etag)
+		}
+
+		if src.lastModified != "" {
+			req.Header.Set("If-Modified-Since", src.lastModified)
+		}
+	}
+	src.mu.Unlock()
+
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetch data: %w", err)
+		return nil, fmt.Errorf("fetch data: %w: %w", errs.ErrUpstreamUnavailable, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		last := src.lastNetworks()
+		if last == nil {
+			return nil, fmt.Errorf("%w: not modified without a previous document", errs.ErrUpstreamUnavailable)
+		}
+
+		s.logger.WithField("source", src.cfg.Name).Debug("Cartographoor data not modified")
+
+		return last, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: unexpected status code: %d", errs.ErrUpstreamUnavailable, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -61,21 +185,49 @@ func (s *Service) FetchNetworks(
 		return nil, fmt.Errorf("read response: %w", err)
 	}
 
+	networks, err := s.parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	src.store(networks, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+
+	return maps.Clone(networks), nil
+}
+
+// parse converts a cartographoor document to networks, quarantining those
+// with invalid genesis data.
+func (s *Service) parse(body []byte) (map[string]*Network, error) {
 	var rawResponse CartographoorResponse
 	if err := json.Unmarshal(body, &rawResponse); err != nil {
 		return nil, fmt.Errorf("parse JSON: %w", err)
 	}
 
 	networks := s.processNetworks(&rawResponse)
-
-	s.logger.WithFields(logrus.Fields{
-		"total_networks":  len(networks),
-		"active_networks": s.countActive(networks),
-	}).Debug("Fetched cartographoor data")
+	s.quarantine(networks, time.Now())
 
 	return networks, nil
 }
 
+// store remembers a source's document and its validators.
+func (src *source) store(networks map[string]*Network, etag, lastModified string) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	src.etag = etag
+	src.lastModified = lastModified
+	src.last = maps.Clone(networks)
+}
+
+// lastNetworks returns the networks of the source's last document, nil if
+// none was fetched yet.
+func (src *source) lastNetworks() map[string]*Network {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	return maps.Clone(src.last)
+}
+
 // processNetworks converts raw cartographoor data to Network structs.
 func (s *Service) processNetworks(
 	response *CartographoorResponse,
@@ -99,18 +251,21 @@ func (s *Service) processNetworks(
 		targetURL := s.constructTargetURL(networkName)
 
 		networks[networkName] = &Network{
-			Name:         networkName,
-			DisplayName:  displayName,
-			Description:  description,
-			Status:       rawNet.Status,
-			ChainID:      rawNet.ChainID,
-			GenesisTime:  rawNet.GenesisConfig.GenesisTime,
-			GenesisDelay: rawNet.GenesisConfig.GenesisDelay,
-			Forks:        rawNet.Forks,
-			TargetURL:    targetURL,
-			ServiceUrls:  rawNet.ServiceUrls,
-			BlobSchedule: rawNet.BlobSchedule,
-			LastUpdated:  rawNet.LastUpdated,
+			Name:               networkName,
+			DisplayName:        displayName,
+			Description:        description,
+			Status:             rawNet.Status,
+			ChainID:            rawNet.ChainID,
+			GenesisTime:        rawNet.GenesisConfig.GenesisTime,
+			GenesisDelay:       rawNet.GenesisConfig.GenesisDelay,
+			SecondsPerSlot:     rawNet.GenesisConfig.SecondsPerSlot,
+			SlotsPerEpoch:      rawNet.GenesisConfig.SlotsPerEpoch,
+			GenesisForkVersion: rawNet.GenesisConfig.GenesisForkVersion,
+			Forks:              rawNet.Forks,
+			TargetURL:          targetURL,
+			ServiceUrls:        rawNet.ServiceUrls,
+			BlobSchedule:       rawNet.BlobSchedule,
+			LastUpdated:        rawNet.LastUpdated,
 		}
 	}
 
@@ -6,12 +6,16 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
 )
 
 func TestService_FetchNetworks(t *testing.T) {
@@ -20,6 +24,7 @@ func TestService_FetchNetworks(t *testing.T) {
 		mockResponse  func(w http.ResponseWriter, r *http.Request)
 		expectError   bool
 		errorContains string
+		errorIs       error
 		validateData  func(t *testing.T, networks map[string]*Network)
 	}{
 		{
@@ -93,6 +98,44 @@ func TestService_FetchNetworks(t *testing.T) {
 				assert.Equal(t, "Sepolia", networks["sepolia"].DisplayName)
 			},
 		},
+		{
+			name: "custom slot timing is carried through",
+			mockResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"networks":{"fast-devnet-1":{"status":"active","genesisConfig":{` + //nolint:errcheck // test.
+					`"genesisTime":1700000000,"secondsPerSlot":6,"slotsPerEpoch":8,"genesisForkVersion":"0x10000038"}}}}`))
+			},
+			expectError: false,
+			validateData: func(t *testing.T, networks map[string]*Network) {
+				t.Helper()
+
+				require.Contains(t, networks, "fast-devnet-1")
+
+				devnet := networks["fast-devnet-1"]
+				assert.Equal(t, uint64(6), devnet.SecondsPerSlot)
+				assert.Equal(t, uint64(8), devnet.SlotsPerEpoch)
+				assert.Equal(t, "0x10000038", devnet.GenesisForkVersion)
+			},
+		},
+		{
+			name: "active network with invalid genesis is quarantined",
+			mockResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"networks":{"broken-devnet-1":{"status":"active","genesisConfig":{"genesisTime":0}},` + //nolint:errcheck // test.
+					`"old-devnet-1":{"status":"inactive","genesisConfig":{"genesisTime":0}}}}`))
+			},
+			expectError: false,
+			validateData: func(t *testing.T, networks map[string]*Network) {
+				t.Helper()
+
+				require.Contains(t, networks, "broken-devnet-1")
+				assert.Equal(t, NetworkStatusQuarantined, networks["broken-devnet-1"].Status)
+				assert.Equal(t, "genesis time is not set", networks["broken-devnet-1"].StatusReason)
+
+				// Inactive networks are not served, so they are left alone
+				assert.Equal(t, NetworkStatusInactive, networks["old-devnet-1"].Status)
+			},
+		},
 		{
 			name: "empty response returns empty map",
 			mockResponse: func(w http.ResponseWriter, r *http.Request) {
@@ -116,8 +159,8 @@ func TestService_FetchNetworks(t *testing.T) {
 			mockResponse: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusInternalServerError)
 			},
-			expectError:   true,
-			errorContains: "unexpected status code: 500",
+			expectError: true,
+			errorIs:     errs.ErrUpstreamUnavailable,
 		},
 		{
 			name: "invalid JSON returns error",
@@ -156,6 +199,10 @@ func TestService_FetchNetworks(t *testing.T) {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
 
+				if tt.errorIs != nil {
+					require.ErrorIs(t, err, tt.errorIs)
+				}
+
 				return
 			}
 
@@ -168,6 +215,130 @@ func TestService_FetchNetworks(t *testing.T) {
 	}
 }
 
+func TestService_FetchNetworksConditional(t *testing.T) {
+	var (
+		requests    int
+		notModified int
+		etag        = `"v1"`
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("If-None-Match") == etag {
+			assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", r.Header.Get("If-Modified-Since"))
+
+			notModified++
+
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		json.NewEncoder(w).Encode(CartographoorResponse{ //nolint:errcheck // test.
+			Networks: map[string]RawNetwork{"mainnet": {Status: NetworkStatusActive, ChainID: 1}},
+		})
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc, err := New(&Config{SourceURL: server.URL, RequestTimeout: 10 * time.Second}, logger)
+	require.NoError(t, err)
+
+	first, err := svc.FetchNetworks(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, first, "mainnet")
+
+	// Unchanged documents are not downloaded again; the last result is reused
+	second, err := svc.FetchNetworks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified)
+
+	// A new document replaces the cached one
+	etag = `"v2"`
+
+	third, err := svc.FetchNetworks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, third)
+	assert.Equal(t, 1, notModified)
+}
+
+func TestService_FetchNetworksMultipleSources(t *testing.T) {
+	failing := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		json.NewEncoder(w).Encode(CartographoorResponse{ //nolint:errcheck // test.
+			Networks: map[string]RawNetwork{
+				"mainnet":  {Status: NetworkStatusActive, ChainID: 1},
+				"devnet-0": {Status: NetworkStatusActive, ChainID: 100},
+			},
+		})
+	}))
+	defer server.Close()
+
+	private, err := json.Marshal(CartographoorResponse{
+		Networks: map[string]RawNetwork{
+			"devnet-0": {Status: NetworkStatusActive, ChainID: 200},
+			"devnet-1": {Status: NetworkStatusActive, ChainID: 201},
+		},
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "private.json")
+	require.NoError(t, os.WriteFile(path, private, 0o600))
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc, err := New(&Config{
+		Sources: []SourceConfig{
+			{URL: "file://" + path},
+			{Name: "production", URL: server.URL},
+		},
+		RequestTimeout: 10 * time.Second,
+	}, logger)
+	require.NoError(t, err)
+
+	chainIDs := func(networks map[string]*Network) map[string]int64 {
+		out := make(map[string]int64, len(networks))
+		for name, network := range networks {
+			out[name] = network.ChainID
+		}
+
+		return out
+	}
+
+	// Earlier sources win
+	networks, err := svc.FetchNetworks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"mainnet": 1, "devnet-0": 200, "devnet-1": 201}, chainIDs(networks))
+
+	// A failing source falls back to its last document
+	failing = true
+
+	networks, err = svc.FetchNetworks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"mainnet": 1, "devnet-0": 200, "devnet-1": 201}, chainIDs(networks))
+
+	// Every source failing is an error
+	require.NoError(t, os.Remove(path))
+
+	_, err = svc.FetchNetworks(context.Background())
+	require.ErrorIs(t, err, errs.ErrUpstreamUnavailable)
+}
+
 func TestService_formatDisplayName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -10,6 +10,9 @@ import (
 const (
 	NetworkStatusActive   = "active"
 	NetworkStatusInactive = "inactive"
+	// NetworkStatusQuarantined marks networks cartographoor lists as active
+	// whose genesis data failed validation. StatusReason says why.
+	NetworkStatusQuarantined = "quarantined"
 )
 
 // CartographoorResponse represents the top-level JSON structure from networks.json.
@@ -32,9 +35,13 @@ type RawNetwork struct {
 }
 
 // GenesisConfig contains genesis configuration.
+// Slot timing is only published for networks that differ from mainnet.
 type GenesisConfig struct {
-	GenesisTime  int64 `json:"genesisTime"`  // Unix timestamp
-	GenesisDelay int64 `json:"genesisDelay"` // Genesis delay in seconds
+	GenesisTime        int64  `json:"genesisTime"`                  // Unix timestamp
+	GenesisDelay       int64  `json:"genesisDelay"`                 // Genesis delay in seconds
+	SecondsPerSlot     uint64 `json:"secondsPerSlot,omitempty"`     // Slot duration (0 = 12)
+	SlotsPerEpoch      uint64 `json:"slotsPerEpoch,omitempty"`      // Slots per epoch (0 = 32)
+	GenesisForkVersion string `json:"genesisForkVersion,omitempty"` // e.g. "0x10000038"
 }
 
 // Forks contains fork information for a network.
@@ -72,18 +79,22 @@ type NetworkMetadata struct {
 
 // Network is the processed network data used internally.
 type Network struct {
-	Name         string
-	DisplayName  string
-	Description  string
-	Status       string
-	ChainID      int64               // Integer chain ID
-	GenesisTime  int64               // Unix timestamp
-	GenesisDelay int64               // Genesis delay in seconds
-	Forks        Forks               // Fork information
-	TargetURL    string              // CBT API URL constructed from network name
-	ServiceUrls  map[string]string   // Map of service name to URL
-	BlobSchedule []BlobScheduleEntry // Optional blob schedule defining max blobs per block at different epochs
-	LastUpdated  time.Time
+	Name               string
+	DisplayName        string
+	Description        string
+	Status             string
+	StatusReason       string              // Why the network is quarantined, if it is
+	ChainID            int64               // Integer chain ID
+	GenesisTime        int64               // Unix timestamp
+	GenesisDelay       int64               // Genesis delay in seconds
+	SecondsPerSlot     uint64              // Slot duration in seconds (0 = 12)
+	SlotsPerEpoch      uint64              // Slots per epoch (0 = 32)
+	GenesisForkVersion string              // Genesis fork version, if published
+	Forks              Forks               // Fork information
+	TargetURL          string              // CBT API URL constructed from network name
+	ServiceUrls        map[string]string   // Map of service name to URL
+	BlobSchedule       []BlobScheduleEntry // Optional blob schedule defining max blobs per block at different epochs
+	LastUpdated        time.Time
 }
 
 // Provider defines the interface for network data providers.
@@ -93,8 +104,14 @@ type Provider interface {
 	Stop() error
 	GetNetworks(ctx context.Context) map[string]*Network
 	GetActiveNetworks(ctx context.Context) map[string]*Network
-	GetNetwork(ctx context.Context, name string) (*Network, bool)
+	// GetNetwork returns a network. Errors wrap errs.ErrNotFound or
+	// errs.ErrUpstreamUnavailable.
+	GetNetwork(ctx context.Context, name string) (*Network, error)
 	// NotifyChannel returns a channel that signals when network data has been updated.
 	// Consumers should listen on this channel to refresh cached data.
+	// Each call returns a new subscription channel.
 	NotifyChannel() <-chan struct{}
+	// LastChanges returns the changes of the most recent network update seen
+	// by this instance, or nil before the first.
+	LastChanges() *Changes
 }
@@ -0,0 +1,254 @@
+// Package compat keeps replicas of different versions able to read each
+// other's Redis data during rolling upgrades. Every replica publishes the data
+// format version it reads; the leader writes the newest format every active
+// replica supports, and refuses to write when no such format exists.
+package compat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+const (
+	// SchemaVersion is the newest Redis data format version this build reads
+	// and writes. Bump it when a stored format changes incompatibly, and teach
+	// Marshal to still produce the previous one.
+	SchemaVersion = 1
+
+	// MinSchemaVersion is the oldest data format version this build can still
+	// write for replicas that have not been upgraded yet.
+	MinSchemaVersion = 1
+)
+
+// heartbeatTimeout bounds each heartbeat's Redis calls.
+const heartbeatTimeout = 5 * time.Second
+
+// ErrIncompatible is returned by WriteVersion when an active replica only
+// reads formats older than MinSchemaVersion.
+var ErrIncompatible = errors.New("active replica cannot read any writable data format")
+
+var writeVersionGauge = metrics.NewGauge(prometheus.GaugeOpts{
+	Name: "compat_write_schema_version",
+	Help: "Redis data format version written for the oldest active replica, 0 while writes are refused",
+})
+
+// Compile-time interface compliance check.
+var _ Gate = (*Tracker)(nil)
+
+// Tracker publishes this replica's versions and tracks those of every other
+// active replica.
+type Tracker struct {
+	cfg   Config
+	log   logrus.FieldLogger
+	redis redis.Client
+	self  Replica
+
+	// replicas holds the active replicas seen by the last heartbeat
+	replicas atomic.Pointer[[]Replica]
+	// lastVersion is the write version of the previous heartbeat, -1 before the first
+	lastVersion int
+
+	task *tasks.Task
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a compatibility tracker for a replica running version.
+func New(log logrus.FieldLogger, cfg Config, redisClient redis.Client, version string) *Tracker {
+	hostname, _ := os.Hostname()
+
+	return &Tracker{
+		cfg:   cfg,
+		log:   log.WithField("component", "compat"),
+		redis: redisClient,
+		self: Replica{
+			InstanceID:    uuid.New().String(),
+			Hostname:      hostname,
+			Version:       version,
+			SchemaVersion: SchemaVersion,
+		},
+		lastVersion: -1,
+		done:        make(chan struct{}),
+	}
+}
+
+// Start publishes this replica once, so the leader already accounts for it,
+// and then keeps publishing in the background.
+func (t *Tracker) Start() error {
+	t.task = tasks.Default().Register("compat.heartbeat", t.cfg.HeartbeatInterval)
+
+	if err := t.task.Run(t.heartbeat); err != nil {
+		return fmt.Errorf("publish replica version: %w", err)
+	}
+
+	t.wg.Go(func() {
+		t.task.Supervise(t.log, t.done, t.heartbeatLoop)
+	})
+
+	return nil
+}
+
+// Stop stops publishing and removes this replica, so it no longer holds
+// writes back once it is gone.
+func (t *Tracker) Stop() {
+	close(t.done)
+	t.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), heartbeatTimeout)
+	defer cancel()
+
+	if err := t.redis.Del(ctx, t.cfg.KeyPrefix+t.self.InstanceID); err != nil {
+		t.log.WithError(err).Warn("Failed to remove replica version")
+	}
+}
+
+// Replicas returns the active replicas seen by the last heartbeat, including
+// this one.
+func (t *Tracker) Replicas() []Replica {
+	if replicas := t.replicas.Load(); replicas != nil {
+		return *replicas
+	}
+
+	return []Replica{t.self}
+}
+
+// WriteVersion returns the newest format version every active replica reads.
+func (t *Tracker) WriteVersion() (int, error) {
+	return writeVersion(t.Replicas())
+}
+
+// writeVersion returns the lowest schema version of replicas, capped at
+// SchemaVersion.
+func writeVersion(replicas []Replica) (int, error) {
+	version := SchemaVersion
+
+	for _, replica := range replicas {
+		if replica.SchemaVersion >= MinSchemaVersion {
+			version = min(version, replica.SchemaVersion)
+
+			continue
+		}
+
+		return 0, fmt.Errorf(
+			"replica %s (%s) reads schema version %d, oldest writable is %d: %w",
+			replica.InstanceID, replica.Version, replica.SchemaVersion, MinSchemaVersion, ErrIncompatible,
+		)
+	}
+
+	return version, nil
+}
+
+// Marshal encodes v in the given format version.
+func Marshal(version int, v any) ([]byte, error) {
+	switch version {
+	case 1:
+		return json.Marshal(v)
+	default:
+		return nil, fmt.Errorf("unsupported schema version %d", version)
+	}
+}
+
+// heartbeatLoop runs heartbeat on every heartbeat interval until stopped.
+func (t *Tracker) heartbeatLoop() {
+	ticker := jitter.NewTicker(t.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.task.Run(t.heartbeat); err != nil {
+				t.log.WithError(err).Warn("Compatibility heartbeat failed")
+			}
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// heartbeat publishes this replica and reads every active one. On Redis
+// errors the last known replicas are kept.
+func (t *Tracker) heartbeat() error {
+	ctx, cancel := context.WithTimeout(context.Background(), heartbeatTimeout)
+	defer cancel()
+
+	self := t.self
+	self.LastSeen = time.Now().UTC()
+
+	data, err := json.Marshal(self)
+	if err != nil {
+		return fmt.Errorf("marshal replica: %w", err)
+	}
+
+	if err := t.redis.Set(ctx, t.cfg.KeyPrefix+self.InstanceID, string(data), t.cfg.ReplicaTTL); err != nil {
+		return fmt.Errorf("publish replica: %w", err)
+	}
+
+	keys, err := t.redis.Keys(ctx, t.cfg.KeyPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("list replicas: %w", err)
+	}
+
+	replicas := make([]Replica, 0, len(keys))
+
+	for _, key := range keys {
+		value, err := t.redis.Get(ctx, key)
+		if errors.Is(err, redis.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("get replica %s: %w", strings.TrimPrefix(key, t.cfg.KeyPrefix), err)
+		}
+
+		var replica Replica
+		if err := json.Unmarshal([]byte(value), &replica); err != nil {
+			t.log.WithError(err).WithField("key", key).Warn("Ignoring undecodable replica version")
+
+			continue
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	t.replicas.Store(&replicas)
+	t.recordVersion(replicas)
+
+	return nil
+}
+
+// recordVersion records the write version and logs when it changes.
+func (t *Tracker) recordVersion(replicas []Replica) {
+	version, err := writeVersion(replicas)
+	writeVersionGauge.Set(float64(version))
+
+	if version == t.lastVersion {
+		return
+	}
+
+	t.lastVersion = version
+
+	switch {
+	case err != nil:
+		t.log.WithError(err).Error("Refusing Redis writes until incompatible replicas are gone")
+	case version < SchemaVersion:
+		t.log.WithField("schema_version", version).Warn("Writing older data format for replicas not yet upgraded")
+	default:
+		t.log.WithField("schema_version", version).Info("All active replicas read the current data format")
+	}
+}
@@ -0,0 +1,102 @@
+package compat
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func newTestTracker(t *testing.T) (*Tracker, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	tracker := New(logger, Config{
+		KeyPrefix:         "lab:compat:replica:",
+		HeartbeatInterval: time.Hour,
+		ReplicaTTL:        3 * time.Hour,
+	}, client, "v1.2.3")
+
+	return tracker, mr
+}
+
+func setReplica(t *testing.T, mr *miniredis.Miniredis, replica Replica) {
+	t.Helper()
+
+	data, err := json.Marshal(replica)
+	require.NoError(t, err)
+	require.NoError(t, mr.Set("lab:compat:replica:"+replica.InstanceID, string(data)))
+}
+
+func TestTracker_Handshake(t *testing.T) {
+	tracker, mr := newTestTracker(t)
+
+	require.NoError(t, tracker.Start())
+
+	// This replica is published with a TTL
+	key := "lab:compat:replica:" + tracker.self.InstanceID
+	require.True(t, mr.Exists(key))
+	assert.Equal(t, 3*time.Hour, mr.TTL(key))
+
+	version, err := tracker.WriteVersion()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, version)
+
+	// A replica of a newer build does not raise the version
+	setReplica(t, mr, Replica{InstanceID: "newer", Version: "v1.3.0", SchemaVersion: SchemaVersion + 1})
+	require.NoError(t, tracker.heartbeat())
+
+	assert.Len(t, tracker.Replicas(), 2)
+
+	version, err = tracker.WriteVersion()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, version)
+
+	// A replica that reads nothing this build writes blocks writes until it is gone
+	setReplica(t, mr, Replica{InstanceID: "ancient", Version: "v0.1.0", SchemaVersion: MinSchemaVersion - 1})
+	require.NoError(t, tracker.heartbeat())
+
+	_, err = tracker.WriteVersion()
+	require.ErrorIs(t, err, ErrIncompatible)
+
+	mr.Del("lab:compat:replica:ancient")
+	require.NoError(t, tracker.heartbeat())
+
+	_, err = tracker.WriteVersion()
+	require.NoError(t, err)
+
+	// Stopping removes this replica
+	tracker.Stop()
+	assert.False(t, mr.Exists(key))
+}
+
+func TestTracker_KeepsReplicasOnRedisError(t *testing.T) {
+	tracker, mr := newTestTracker(t)
+
+	require.NoError(t, tracker.heartbeat())
+
+	mr.SetError("unavailable")
+	require.Error(t, tracker.heartbeat())
+
+	assert.Len(t, tracker.Replicas(), 1)
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(SchemaVersion, map[string]int{"a": 1})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(data))
+
+	_, err = Marshal(SchemaVersion+1, nil)
+	require.Error(t, err)
+}
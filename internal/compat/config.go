@@ -0,0 +1,10 @@
+package compat
+
+import "time"
+
+// Config holds compatibility handshake configuration.
+type Config struct {
+	KeyPrefix         string        // Prefix of the per-replica keys
+	HeartbeatInterval time.Duration // How often the replica republishes its version
+	ReplicaTTL        time.Duration // How long a replica counts as active after its last heartbeat
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ethpandaops/lab-backend/internal/compat (interfaces: Gate)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_gate.go github.com/ethpandaops/lab-backend/internal/compat Gate
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGate is a mock of Gate interface.
+type MockGate struct {
+	ctrl     *gomock.Controller
+	recorder *MockGateMockRecorder
+	isgomock struct{}
+}
+
+// MockGateMockRecorder is the mock recorder for MockGate.
+type MockGateMockRecorder struct {
+	mock *MockGate
+}
+
+// NewMockGate creates a new mock instance.
+func NewMockGate(ctrl *gomock.Controller) *MockGate {
+	mock := &MockGate{ctrl: ctrl}
+	mock.recorder = &MockGateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGate) EXPECT() *MockGateMockRecorder {
+	return m.recorder
+}
+
+// WriteVersion mocks base method.
+func (m *MockGate) WriteVersion() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteVersion")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteVersion indicates an expected call of WriteVersion.
+func (mr *MockGateMockRecorder) WriteVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteVersion", reflect.TypeOf((*MockGate)(nil).WriteVersion))
+}
@@ -0,0 +1,32 @@
+//nolint:tagliatelle // superior snake-case yo.
+package compat
+
+//go:generate mockgen -package mocks -destination mocks/mock_gate.go github.com/ethpandaops/lab-backend/internal/compat Gate
+
+import "time"
+
+// Gate decides which data format version the leader writes.
+type Gate interface {
+	// WriteVersion returns the newest format version every active replica
+	// reads, or an error wrapping ErrIncompatible.
+	WriteVersion() (int, error)
+}
+
+// Replica is the version information a replica publishes.
+type Replica struct {
+	InstanceID    string    `json:"instance_id"`
+	Hostname      string    `json:"hostname"`
+	Version       string    `json:"version"`
+	SchemaVersion int       `json:"schema_version"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// Latest is a Gate that always writes SchemaVersion, for single-version
+// deployments and tests.
+var Latest Gate = latestGate{}
+
+type latestGate struct{}
+
+func (latestGate) WriteVersion() (int, error) {
+	return SchemaVersion, nil
+}
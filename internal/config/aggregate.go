@@ -0,0 +1,75 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// AggregateConfig controls GET /api/v1/{network}/aggregate, which fetches
+// several tables for a slot range through the proxy and returns them in one
+// response.
+type AggregateConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	MaxTables      int           `yaml:"max_tables"`      // Tables allowed per request (default 10)
+	MaxSlotRange   uint64        `yaml:"max_slot_range"`  // Slots allowed per request (default 7200, one day)
+	MaxPages       int           `yaml:"max_pages"`       // Pages fetched per table before the result is truncated (default 10)
+	PageSize       int           `yaml:"page_size"`       // Rows requested per page (default 10000)
+	Concurrency    int           `yaml:"concurrency"`     // Tables fetched in parallel (default 4)
+	RequestTimeout time.Duration `yaml:"request_timeout"` // Timeout for the whole aggregation (default 30s)
+}
+
+// Validate validates the aggregate endpoint configuration and sets defaults.
+func (c *AggregateConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.MaxTables == 0 {
+		c.MaxTables = 10
+	}
+
+	if c.MaxSlotRange == 0 {
+		c.MaxSlotRange = 7200
+	}
+
+	if c.MaxPages == 0 {
+		c.MaxPages = 10
+	}
+
+	if c.PageSize == 0 {
+		c.PageSize = 10000
+	}
+
+	if c.Concurrency == 0 {
+		c.Concurrency = 4
+	}
+
+	if c.RequestTimeout == 0 {
+		c.RequestTimeout = 30 * time.Second
+	}
+
+	// Validate ranges
+	if c.MaxTables < 1 {
+		return fmt.Errorf("max_tables must be at least 1, got %d", c.MaxTables)
+	}
+
+	if c.MaxPages < 1 {
+		return fmt.Errorf("max_pages must be at least 1, got %d", c.MaxPages)
+	}
+
+	if c.PageSize < 1 {
+		return fmt.Errorf("page_size must be at least 1, got %d", c.PageSize)
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", c.Concurrency)
+	}
+
+	if c.RequestTimeout < time.Second {
+		return fmt.Errorf("request_timeout must be at least 1 second, got %v", c.RequestTimeout)
+	}
+
+	return nil
+}
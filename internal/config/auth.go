@@ -0,0 +1,59 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// API key tiers, from least to most privileged.
+const (
+	TierFree     = "free"
+	TierPro      = "pro"
+	TierInternal = "internal"
+)
+
+// Tiers lists every valid API key tier.
+var Tiers = []string{TierFree, TierPro, TierInternal}
+
+// Endpoint classes an API key can be scoped to.
+const (
+	ScopeConfig      = "config"       // GET /api/v1/config
+	ScopeProxy       = "proxy"        // Network data: proxied tables and the other /api/v1/{network}/ reads
+	ScopeGasProfiler = "gas_profiler" // /api/v1/gas-profiler/{network}/
+)
+
+// Scopes lists every valid API key scope.
+var Scopes = []string{ScopeConfig, ScopeProxy, ScopeGasProfiler}
+
+// AuthConfig controls API key authentication.
+// Keys are sent as "Authorization: Bearer <key>" and looked up in Redis, where
+// each key is stored as JSON under key_prefix + the hex SHA-256 of the key.
+type AuthConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	Required  bool          `yaml:"required"`   // Reject requests without an API key (default: anonymous requests use IP-based limits)
+	KeyPrefix string        `yaml:"key_prefix"` // Redis key prefix for API key records (default "lab:auth:key:")
+	CacheTTL  time.Duration `yaml:"cache_ttl"`  // How long lookups are cached in memory (default 30s)
+}
+
+// Validate validates the auth configuration and sets defaults.
+func (c *AuthConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = "lab:auth:key:"
+	}
+
+	if c.CacheTTL == 0 {
+		c.CacheTTL = 30 * time.Second
+	}
+
+	if c.CacheTTL < 0 {
+		return fmt.Errorf("cache_ttl must not be negative, got %v", c.CacheTTL)
+	}
+
+	return nil
+}
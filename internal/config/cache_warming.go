@@ -0,0 +1,74 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CacheWarmingConfig controls replaying popular queries through the proxy
+// after startup, so upstream caches are warm before traffic arrives.
+// Only the leader warms, so a deploy doesn't multiply the load.
+type CacheWarmingConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	Queries        []WarmQuery   `yaml:"queries"`
+	Concurrency    int           `yaml:"concurrency"`     // Queries replayed in parallel (default 4)
+	RequestTimeout time.Duration `yaml:"request_timeout"` // Timeout per query (default 30s)
+	LeaderWait     time.Duration `yaml:"leader_wait"`     // How long to wait for leadership before skipping (default 30s)
+}
+
+// WarmQuery is a query template replayed for each of its networks.
+type WarmQuery struct {
+	Path     string   `yaml:"path"`     // Path and query below /api/v1/{network}/; {network} is substituted
+	Networks []string `yaml:"networks"` // Networks to warm (empty = all proxied networks)
+}
+
+// Validate validates the cache warming configuration and sets defaults.
+func (c *CacheWarmingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.Concurrency == 0 {
+		c.Concurrency = 4
+	}
+
+	if c.RequestTimeout == 0 {
+		c.RequestTimeout = 30 * time.Second
+	}
+
+	if c.LeaderWait == 0 {
+		c.LeaderWait = 30 * time.Second
+	}
+
+	// Validate ranges
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", c.Concurrency)
+	}
+
+	if c.RequestTimeout < time.Second {
+		return fmt.Errorf("request_timeout must be at least 1 second, got %v", c.RequestTimeout)
+	}
+
+	if c.LeaderWait < 0 {
+		return fmt.Errorf("leader_wait must not be negative, got %v", c.LeaderWait)
+	}
+
+	if len(c.Queries) == 0 {
+		return fmt.Errorf("at least one query is required when enabled")
+	}
+
+	for i, query := range c.Queries {
+		if query.Path == "" || strings.HasPrefix(query.Path, "/") {
+			return fmt.Errorf("queries[%d]: path must be relative to /api/v1/{network}/, got %q", i, query.Path)
+		}
+
+		if strings.Contains(query.Path, "..") {
+			return fmt.Errorf("queries[%d]: path must not contain '..'", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// CompatConfig controls the rolling-upgrade compatibility handshake. Every
+// replica publishes the Redis data format version it reads under key_prefix,
+// and the leader only writes formats every active replica can decode.
+type CompatConfig struct {
+	KeyPrefix         string        `yaml:"key_prefix"`         // Prefix of the per-replica keys (default "lab:compat:replica:")
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"` // How often replicas republish their version (default 10s)
+	ReplicaTTL        time.Duration `yaml:"replica_ttl"`        // How long a replica counts as active after its last heartbeat (default 3x heartbeat_interval)
+}
+
+// Validate validates the compatibility configuration and sets defaults.
+func (c *CompatConfig) Validate() error {
+	// Set defaults
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = "lab:compat:replica:"
+	}
+
+	if c.HeartbeatInterval == 0 {
+		c.HeartbeatInterval = 10 * time.Second
+	}
+
+	if c.ReplicaTTL == 0 {
+		c.ReplicaTTL = 3 * c.HeartbeatInterval
+	}
+
+	// Validate ranges
+	if c.HeartbeatInterval < 0 {
+		return fmt.Errorf("heartbeat_interval must be positive, got %v", c.HeartbeatInterval)
+	}
+
+	if c.ReplicaTTL <= c.HeartbeatInterval {
+		return fmt.Errorf("replica_ttl (%v) must be greater than heartbeat_interval (%v)", c.ReplicaTTL, c.HeartbeatInterval)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"mime"
+)
+
+// DefaultCompressionContentTypes are the response types compressed when
+// compression.content_types is not configured.
+var DefaultCompressionContentTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/x-ndjson",
+	"text/csv",
+	"text/plain",
+}
+
+// CompressionConfig controls the brotli and gzip compression of API
+// responses, for clients that accept it. Responses the handler already
+// encoded, such as pre-compressed frontend assets, are sent as they are.
+type CompressionConfig struct {
+	Enabled      *bool    `yaml:"enabled"`       // Compress responses (default true)
+	MinSize      int      `yaml:"min_size"`      // Smallest body, in bytes, worth compressing (default 1024)
+	ContentTypes []string `yaml:"content_types"` // Media types compressed, without parameters (default JSON, NDJSON, CSV and plain text)
+	GzipLevel    int      `yaml:"gzip_level"`    // 1 (fastest) to 9 (smallest) (default 5)
+	BrotliLevel  int      `yaml:"brotli_level"`  // 1 (fastest) to 11 (smallest) (default 4)
+}
+
+// Validate validates the compression configuration and sets defaults.
+func (c *CompressionConfig) Validate() error {
+	// Set defaults
+	if c.Enabled == nil {
+		enabled := true
+		c.Enabled = &enabled
+	}
+
+	if c.MinSize == 0 {
+		c.MinSize = 1024
+	}
+
+	if c.ContentTypes == nil {
+		c.ContentTypes = DefaultCompressionContentTypes
+	}
+
+	if c.GzipLevel == 0 {
+		c.GzipLevel = 5
+	}
+
+	if c.BrotliLevel == 0 {
+		c.BrotliLevel = 4
+	}
+
+	// Validate ranges
+	if c.MinSize < 0 {
+		return fmt.Errorf("min_size must be positive, got %d", c.MinSize)
+	}
+
+	if c.GzipLevel < 1 || c.GzipLevel > 9 {
+		return fmt.Errorf("gzip_level must be between 1 and 9, got %d", c.GzipLevel)
+	}
+
+	if c.BrotliLevel < 1 || c.BrotliLevel > 11 {
+		return fmt.Errorf("brotli_level must be between 1 and 11, got %d", c.BrotliLevel)
+	}
+
+	for _, contentType := range c.ContentTypes {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil || len(params) > 0 || mediaType != contentType {
+			return fmt.Errorf("content_types: invalid media type %q", contentType)
+		}
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether responses are compressed.
+func (c *CompressionConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"slices"
 	"time"
 
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,12 +21,46 @@ type Config struct {
 	Redis         RedisConfig          `yaml:"redis"`
 	Leader        LeaderConfig         `yaml:"leader"`
 	Networks      []NetworkConfig      `yaml:"networks"`
+	NetworksFile  string               `yaml:"networks_file"` // YAML or JSON file of further networks, watched for changes
 	Features      []FeatureSettings    `yaml:"features"`
 	Cartographoor cartographoor.Config `yaml:"cartographoor"`
 	Bounds        BoundsConfig         `yaml:"bounds"`
 	RateLimiting  RateLimitingConfig   `yaml:"rate_limiting"`
 	Headers       HeadersConfig        `yaml:"headers"`
 	GasProfiler   GasProfilerConfig    `yaml:"gas_profiler"`
+	Proxy         ProxyConfig          `yaml:"proxy"`
+	SLO           SLOConfig            `yaml:"slo"`
+	TokenIssuance TokenIssuanceConfig  `yaml:"token_issuance"`
+	Terms         TermsConfig          `yaml:"terms"`
+	Tracing       TracingConfig        `yaml:"tracing"`
+	Profiling     ProfilingConfig      `yaml:"profiling"`
+	Auth          AuthConfig           `yaml:"auth"`
+	Push          PushConfig           `yaml:"push"`
+	Preview       PreviewConfig        `yaml:"preview"`
+	Synthetic     SyntheticConfig      `yaml:"synthetic"`
+
+	SchemaValidation   SchemaValidationConfig   `yaml:"schema_validation"`
+	CacheWarming       CacheWarmingConfig       `yaml:"cache_warming"`
+	Timers             TimersConfig             `yaml:"timers"`
+	GRPC               GRPCConfig               `yaml:"grpc"`
+	ReadOnly           ReadOnlyConfig           `yaml:"read_only"`
+	Maintenance        MaintenanceConfig        `yaml:"maintenance"`
+	StartupGate        StartupGateConfig        `yaml:"startup_gate"`
+	NegativeCache      NegativeCacheConfig      `yaml:"negative_cache"`
+	Compression        CompressionConfig        `yaml:"compression"`
+	Frontend           FrontendConfig           `yaml:"frontend"`
+	Aggregate          AggregateConfig          `yaml:"aggregate"`
+	TablePages         TablePagesConfig         `yaml:"table_pages"`
+	Summary            SummaryConfig            `yaml:"summary"`
+	DenyList           DenyListConfig           `yaml:"deny_list"`
+	FreshnessAlerts    FreshnessAlertsConfig    `yaml:"freshness_alerts"`
+	Compat             CompatConfig             `yaml:"compat"`
+	UpstreamLimits     UpstreamLimitsConfig     `yaml:"upstream_limits"`
+	UpstreamCoalescing UpstreamCoalescingConfig `yaml:"upstream_coalescing"`
+	RequestTimeouts    RequestTimeoutsConfig    `yaml:"request_timeouts"`
+	Tenants            []TenantConfig           `yaml:"tenants"` // Lab variants selected by Host header or path prefix
+
+	networksFile *networksFile
 }
 
 // ServerConfig contains HTTP server settings.
@@ -34,18 +70,41 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration `yaml:"read_timeout"`
 	WriteTimeout    time.Duration `yaml:"write_timeout"`
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	DrainTimeout    time.Duration `yaml:"drain_timeout"` // How long SSE and WebSocket connections may stay open on shutdown (default 5s)
 	LogLevel        string        `yaml:"log_level"`
+	LogFormat       string        `yaml:"log_format"`      // "text" (default) or "json"
+	TrustedProxies  []string      `yaml:"trusted_proxies"` // IPs or CIDR ranges whose forwarding headers are honored
+	HTTP2           *bool         `yaml:"http2"`           // Serve HTTP/2 to TLS clients that negotiate it (default true)
+	H2C             bool          `yaml:"h2c"`             // Also serve HTTP/2 without TLS, to clients with prior knowledge
+	TLS             TLSConfig     `yaml:"tls"`             // Terminate TLS instead of serving plain HTTP
 }
 
+// HTTP2Enabled reports whether HTTP/2 is served over TLS.
+func (c *ServerConfig) HTTP2Enabled() bool {
+	return c.HTTP2 == nil || *c.HTTP2
+}
+
+// Redis topologies.
+const (
+	RedisModeStandalone = "standalone" // A single server at address
+	RedisModeSentinel   = "sentinel"   // The master of master_name, discovered through sentinel_addresses
+	RedisModeCluster    = "cluster"    // A Redis Cluster, seeded from cluster_addresses
+)
+
 // RedisConfig holds Redis client configuration.
 type RedisConfig struct {
-	Address      string        `yaml:"address"`
-	Password     string        `yaml:"password"`
-	DB           int           `yaml:"db"`
-	DialTimeout  time.Duration `yaml:"dial_timeout"`
-	ReadTimeout  time.Duration `yaml:"read_timeout"`
-	WriteTimeout time.Duration `yaml:"write_timeout"`
-	PoolSize     int           `yaml:"pool_size"`
+	Mode              string        `yaml:"mode"` // "standalone" (default), "sentinel" or "cluster"
+	Address           string        `yaml:"address"`
+	Password          string        `yaml:"password"`
+	DB                int           `yaml:"db"`
+	MasterName        string        `yaml:"master_name"`        // Sentinel master set name
+	SentinelAddresses []string      `yaml:"sentinel_addresses"` // Sentinel host:port list
+	SentinelPassword  string        `yaml:"sentinel_password"`  // Password of the sentinels, if different from the data nodes
+	ClusterAddresses  []string      `yaml:"cluster_addresses"`  // Cluster seed nodes, host:port
+	DialTimeout       time.Duration `yaml:"dial_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	PoolSize          int           `yaml:"pool_size"`
 }
 
 // LeaderConfig holds leader election configuration.
@@ -61,6 +120,18 @@ type BoundsConfig struct {
 	RefreshInterval time.Duration `yaml:"refresh_interval"` // How often to refresh bounds
 	RequestTimeout  time.Duration `yaml:"request_timeout"`  // HTTP request timeout
 	BoundsTTL       time.Duration `yaml:"bounds_ttl"`       // Redis TTL for bounds data (0 = no expiration)
+	StaleRetention  time.Duration `yaml:"stale_retention"`  // How long last known bounds of a failing network are served, marked stale
+
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+}
+
+// CircuitBreakerConfig controls when bounds fetching stops calling a failing network.
+// After FailureThreshold consecutive failures the network is skipped for OpenDuration,
+// then a single probe is allowed. Each failed probe doubles the wait, up to MaxOpenDuration.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold"`
+	OpenDuration     time.Duration `yaml:"open_duration"`
+	MaxOpenDuration  time.Duration `yaml:"max_open_duration"`
 }
 
 // RateLimitingConfig holds rate limiting configuration.
@@ -68,15 +139,22 @@ type RateLimitingConfig struct {
 	Enabled     bool            `yaml:"enabled"`
 	FailureMode string          `yaml:"failure_mode"` // "fail_open" or "fail_closed"
 	ExemptIPs   []string        `yaml:"exempt_ips"`   // CIDR ranges to whitelist
+	ExemptTiers []string        `yaml:"exempt_tiers"` // API key tiers that bypass rate limiting
 	Rules       []RateLimitRule `yaml:"rules"`
+
+	Offenders RateLimitOffendersConfig `yaml:"offenders"`
 }
 
 // RateLimitRule defines a single rate limit rule.
+// Anonymous requests are limited per IP; requests with an API key are limited
+// per key, using the key tier's limit when one is set.
 type RateLimitRule struct {
-	Name        string        `yaml:"name"`
-	PathPattern string        `yaml:"path_pattern"` // Regex pattern
-	Limit       int           `yaml:"limit"`        // Max requests
-	Window      time.Duration `yaml:"window"`       // Time window
+	Name        string         `yaml:"name"`
+	PathPattern string         `yaml:"path_pattern"` // Regex pattern
+	Limit       int            `yaml:"limit"`        // Max requests
+	Window      time.Duration  `yaml:"window"`       // Time window
+	Burst       int            `yaml:"burst"`        // Optional: extra requests allowed above limit in a burst, refilled at limit per window
+	TierLimits  map[string]int `yaml:"tier_limits"`  // Optional: max requests per API key tier
 }
 
 // HeadersConfig holds HTTP headers configuration.
@@ -88,7 +166,7 @@ type HeadersConfig struct {
 type HeaderPolicy struct {
 	Name        string            `yaml:"name"`         // Policy name for logging/debugging
 	PathPattern string            `yaml:"path_pattern"` // Regex pattern to match request paths
-	Headers     map[string]string `yaml:"headers"`      // Headers to set (key: value)
+	Headers     map[string]string `yaml:"headers"`      // Headers to set (key: value); {{nonce}} in a value is a fresh per-request CSP nonce
 }
 
 // Validate validates the configuration and sets defaults.
@@ -102,6 +180,10 @@ func (c *BoundsConfig) Validate() error {
 		c.RequestTimeout = 30 * time.Second
 	}
 
+	if c.StaleRetention == 0 {
+		c.StaleRetention = time.Hour
+	}
+
 	// Validate ranges
 	if c.RefreshInterval < 5*time.Second {
 		return fmt.Errorf(
@@ -117,13 +199,58 @@ func (c *BoundsConfig) Validate() error {
 		)
 	}
 
+	if c.StaleRetention < 0 {
+		return fmt.Errorf("stale_retention must not be negative, got %v", c.StaleRetention)
+	}
+
+	if err := c.CircuitBreaker.Validate(); err != nil {
+		return fmt.Errorf("circuit_breaker: %w", err)
+	}
+
+	return nil
+}
+
+// Validate validates the circuit breaker configuration and sets defaults.
+func (c *CircuitBreakerConfig) Validate() error {
+	// Set defaults
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = 5
+	}
+
+	if c.OpenDuration == 0 {
+		c.OpenDuration = time.Minute
+	}
+
+	if c.MaxOpenDuration == 0 {
+		c.MaxOpenDuration = max(10*time.Minute, c.OpenDuration)
+	}
+
+	// Validate ranges
+	if c.FailureThreshold < 1 {
+		return fmt.Errorf("failure_threshold must be at least 1, got %d", c.FailureThreshold)
+	}
+
+	if c.OpenDuration < time.Second {
+		return fmt.Errorf("open_duration must be at least 1 second, got %v", c.OpenDuration)
+	}
+
+	if c.MaxOpenDuration < c.OpenDuration {
+		return fmt.Errorf(
+			"max_open_duration (%v) must be at least open_duration (%v)",
+			c.MaxOpenDuration, c.OpenDuration,
+		)
+	}
+
 	return nil
 }
 
-// HTTPClient returns a configured HTTP client for upstream requests.
+// HTTPClient returns a configured HTTP client for upstream requests. Requests
+// share the per-host concurrency limits of upstream_limits with the proxy, and
+// identical pages requested at the same time are fetched once.
 func (c *BoundsConfig) HTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: c.RequestTimeout,
+		Timeout:   c.RequestTimeout,
+		Transport: upstream.Coalesced(upstream.Limited(upstream.NewTransport(upstream.SubsystemBounds, nil))),
 	}
 }
 
@@ -136,8 +263,24 @@ func Load(path string) (*Config, error) {
 	}
 
 	// Parse YAML
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+
+	// Empty file
+	if document.Kind == 0 {
+		return &cfg, nil
+	}
+
+	// Fill in ${VAR} references, e.g. secrets provided through the environment
+	if err := expandEnv(&document); err != nil {
+		return nil, fmt.Errorf("failed to expand environment variables: %w", err)
+	}
+
+	if err := document.Decode(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
@@ -167,6 +310,15 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("shutdown_timeout must be positive")
 	}
 
+	// Streams are closed within the shutdown timeout, leaving time for them to end
+	if c.Server.DrainTimeout == 0 {
+		c.Server.DrainTimeout = min(5*time.Second, c.Server.ShutdownTimeout/2)
+	}
+
+	if c.Server.DrainTimeout < 0 || c.Server.DrainTimeout >= c.Server.ShutdownTimeout {
+		return fmt.Errorf("drain_timeout must be between 0 and shutdown_timeout, got %v", c.Server.DrainTimeout)
+	}
+
 	// Validate log level
 	validLogLevels := map[string]bool{
 		"trace": true, "debug": true, "info": true,
@@ -176,9 +328,57 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid log level: %s", c.Server.LogLevel)
 	}
 
+	// Validate log format
+	if c.Server.LogFormat == "" {
+		c.Server.LogFormat = "text"
+	}
+
+	if c.Server.LogFormat != "text" && c.Server.LogFormat != "json" {
+		return fmt.Errorf("invalid log format: %s (must be text or json)", c.Server.LogFormat)
+	}
+
+	for i, cidr := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil && net.ParseIP(cidr) == nil {
+			return fmt.Errorf("server.trusted_proxies[%d] invalid IP or CIDR: %s", i, cidr)
+		}
+	}
+
+	if err := c.Server.TLS.Validate(); err != nil {
+		return fmt.Errorf("server.tls: %w", err)
+	}
+
+	if c.Server.H2C && (c.Server.TLS.Enabled || !c.Server.HTTP2Enabled()) {
+		return fmt.Errorf("server.h2c requires http2 and plain HTTP, tls.enabled is %t", c.Server.TLS.Enabled)
+	}
+
 	// Redis is mandatory infrastructure
-	if c.Redis.Address == "" {
-		return fmt.Errorf("redis.address is required")
+	if c.Redis.Mode == "" {
+		c.Redis.Mode = RedisModeStandalone
+	}
+
+	switch c.Redis.Mode {
+	case RedisModeStandalone:
+		if c.Redis.Address == "" {
+			return fmt.Errorf("redis.address is required")
+		}
+	case RedisModeSentinel:
+		if c.Redis.MasterName == "" {
+			return fmt.Errorf("redis.master_name is required in sentinel mode")
+		}
+
+		if len(c.Redis.SentinelAddresses) == 0 {
+			return fmt.Errorf("redis.sentinel_addresses is required in sentinel mode")
+		}
+	case RedisModeCluster:
+		if len(c.Redis.ClusterAddresses) == 0 {
+			return fmt.Errorf("redis.cluster_addresses is required in cluster mode")
+		}
+
+		if c.Redis.DB != 0 {
+			return fmt.Errorf("redis.db must be 0 in cluster mode")
+		}
+	default:
+		return fmt.Errorf("invalid redis.mode: %s (must be standalone, sentinel or cluster)", c.Redis.Mode)
 	}
 
 	if c.Redis.DialTimeout <= 0 {
@@ -209,7 +409,9 @@ func (c *Config) Validate() error {
 	// Validate individual network configs if any are provided
 	networkNames := make(map[string]bool)
 
-	for i, network := range c.Networks {
+	for i := range c.Networks {
+		network := &c.Networks[i]
+
 		if err := network.Validate(); err != nil {
 			return fmt.Errorf("network %d: %w", i, err)
 		}
@@ -222,6 +424,43 @@ func (c *Config) Validate() error {
 		networkNames[network.Name] = true
 	}
 
+	if err := c.validateNetworkAliases(networkNames); err != nil {
+		return err
+	}
+
+	// Read the networks file once, WatchNetworksFile picks up later changes
+	if c.NetworksFile != "" && c.networksFile == nil {
+		networksFile, err := loadNetworksFile(c.NetworksFile)
+		if err != nil {
+			return fmt.Errorf("networks_file: %w", err)
+		}
+
+		c.networksFile = networksFile
+	}
+
+	// Validate feature settings
+	for i := range c.Features {
+		if err := c.Features[i].Validate(); err != nil {
+			return fmt.Errorf("features[%d]: %w", i, err)
+		}
+	}
+
+	// Validate tenant overlays
+	if err := c.validateTenants(); err != nil {
+		return err
+	}
+
+	// Validate synthetic test network config
+	if err := c.Synthetic.Validate(); err != nil {
+		return fmt.Errorf("synthetic: %w", err)
+	}
+
+	if c.Synthetic.Enabled {
+		if _, aliased := c.NetworkAliases()[c.Synthetic.Name]; aliased || networkNames[c.Synthetic.Name] {
+			return fmt.Errorf("synthetic: name %q conflicts with a configured network or alias", c.Synthetic.Name)
+		}
+	}
+
 	// Validate cartographoor config
 	if err := c.Cartographoor.Validate(); err != nil {
 		return fmt.Errorf("cartographoor: %w", err)
@@ -244,6 +483,149 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("gas_profiler: %w", err)
 	}
 
+	// Validate proxy config
+	if err := c.Proxy.Validate(); err != nil {
+		return fmt.Errorf("proxy: %w", err)
+	}
+
+	// Validate SLO config
+	if err := c.SLO.Validate(); err != nil {
+		return fmt.Errorf("slo: %w", err)
+	}
+
+	// Validate token issuance config
+	if err := c.TokenIssuance.Validate(); err != nil {
+		return fmt.Errorf("token_issuance: %w", err)
+	}
+
+	if c.TokenIssuance.Enabled && !c.Auth.Enabled {
+		return fmt.Errorf("token_issuance requires auth to be enabled")
+	}
+
+	// Validate terms of use config
+	if err := c.Terms.Validate(); err != nil {
+		return fmt.Errorf("terms: %w", err)
+	}
+
+	// Validate tracing config
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("tracing: %w", err)
+	}
+
+	// Validate profiling config
+	if err := c.Profiling.Validate(); err != nil {
+		return fmt.Errorf("profiling: %w", err)
+	}
+
+	// Validate auth config
+	if err := c.Auth.Validate(); err != nil {
+		return fmt.Errorf("auth: %w", err)
+	}
+
+	// Validate WebSocket push config
+	if err := c.Push.Validate(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	// Validate hidden network preview config
+	if err := c.Preview.Validate(); err != nil {
+		return fmt.Errorf("preview: %w", err)
+	}
+
+	// Validate dev-mode response schema validation config
+	if err := c.SchemaValidation.Validate(); err != nil {
+		return fmt.Errorf("schema_validation: %w", err)
+	}
+
+	// Validate post-deploy cache warming config
+	if err := c.CacheWarming.Validate(); err != nil {
+		return fmt.Errorf("cache_warming: %w", err)
+	}
+
+	// Validate periodic timer jitter config
+	if err := c.Timers.Validate(); err != nil {
+		return fmt.Errorf("timers: %w", err)
+	}
+
+	// Validate gRPC API config
+	if err := c.GRPC.Validate(); err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
+
+	// Validate read-only mode config
+	if err := c.ReadOnly.Validate(); err != nil {
+		return fmt.Errorf("read_only: %w", err)
+	}
+
+	// Validate maintenance mode config
+	if err := c.Maintenance.Validate(); err != nil {
+		return fmt.Errorf("maintenance: %w", err)
+	}
+
+	// Validate startup gate config
+	if err := c.StartupGate.Validate(); err != nil {
+		return fmt.Errorf("startup_gate: %w", err)
+	}
+
+	// Validate negative cache config
+	if err := c.NegativeCache.Validate(); err != nil {
+		return fmt.Errorf("negative_cache: %w", err)
+	}
+
+	// Validate per-route request deadlines
+	if err := c.RequestTimeouts.Validate(); err != nil {
+		return fmt.Errorf("request_timeouts: %w", err)
+	}
+
+	// Validate response compression config
+	if err := c.Compression.Validate(); err != nil {
+		return fmt.Errorf("compression: %w", err)
+	}
+
+	// Validate upstream concurrency limits config
+	if err := c.UpstreamLimits.Validate(); err != nil {
+		return fmt.Errorf("upstream_limits: %w", err)
+	}
+
+	// Validate upstream request coalescing config
+	if err := c.UpstreamCoalescing.Validate(); err != nil {
+		return fmt.Errorf("upstream_coalescing: %w", err)
+	}
+
+	// Validate frontend bundle config
+	if err := c.Frontend.Validate(); err != nil {
+		return fmt.Errorf("frontend: %w", err)
+	}
+
+	// Validate slot range aggregation config
+	if err := c.Aggregate.Validate(); err != nil {
+		return fmt.Errorf("aggregate: %w", err)
+	}
+
+	if err := c.TablePages.Validate(); err != nil {
+		return fmt.Errorf("table_pages: %w", err)
+	}
+
+	// Validate network summary config
+	if err := c.Summary.Validate(); err != nil {
+		return fmt.Errorf("summary: %w", err)
+	}
+
+	// Validate deny list config
+	if err := c.DenyList.Validate(); err != nil {
+		return fmt.Errorf("deny_list: %w", err)
+	}
+
+	// Validate bounds freshness alerts config
+	if err := c.FreshnessAlerts.Validate(); err != nil {
+		return fmt.Errorf("freshness_alerts: %w", err)
+	}
+
+	// Validate rolling-upgrade compatibility config
+	if err := c.Compat.Validate(); err != nil {
+		return fmt.Errorf("compat: %w", err)
+	}
+
 	return nil
 }
 
@@ -273,12 +655,32 @@ func (c *Config) validateRateLimiting() error {
 			return fmt.Errorf("rules[%d].window must be positive", i)
 		}
 
+		if rule.Burst < 0 {
+			return fmt.Errorf("rules[%d].burst must not be negative", i)
+		}
+
+		for tier, limit := range rule.TierLimits {
+			if !slices.Contains(Tiers, tier) {
+				return fmt.Errorf("rules[%d].tier_limits: unknown tier %q (valid: %v)", i, tier, Tiers)
+			}
+
+			if limit <= 0 {
+				return fmt.Errorf("rules[%d].tier_limits.%s must be positive", i, tier)
+			}
+		}
+
 		// Validate regex pattern compiles
 		if _, err := regexp.Compile(rule.PathPattern); err != nil {
 			return fmt.Errorf("rules[%d].path_pattern invalid regex: %w", i, err)
 		}
 	}
 
+	for i, tier := range c.RateLimiting.ExemptTiers {
+		if !slices.Contains(Tiers, tier) {
+			return fmt.Errorf("exempt_tiers[%d]: unknown tier %q (valid: %v)", i, tier, Tiers)
+		}
+	}
+
 	// Validate CIDR ranges
 	for i, cidr := range c.RateLimiting.ExemptIPs {
 		if _, _, err := net.ParseCIDR(cidr); err != nil {
@@ -289,5 +691,9 @@ func (c *Config) validateRateLimiting() error {
 		}
 	}
 
+	if err := c.RateLimiting.Offenders.Validate(); err != nil {
+		return fmt.Errorf("offenders: %w", err)
+	}
+
 	return nil
 }
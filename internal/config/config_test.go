@@ -169,6 +169,130 @@ func TestConfig_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "redis.address is required",
 		},
+		{
+			name: "sentinel without master name",
+			config: &Config{
+				Server: ServerConfig{
+					Host:            "localhost",
+					Port:            8080,
+					ReadTimeout:     time.Second,
+					WriteTimeout:    time.Second,
+					ShutdownTimeout: time.Second,
+					LogLevel:        "info",
+				},
+				Redis: RedisConfig{
+					Mode:              RedisModeSentinel,
+					SentinelAddresses: []string{"sentinel:26379"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "redis.master_name is required",
+		},
+		{
+			name: "sentinel without sentinels",
+			config: &Config{
+				Server: ServerConfig{
+					Host:            "localhost",
+					Port:            8080,
+					ReadTimeout:     time.Second,
+					WriteTimeout:    time.Second,
+					ShutdownTimeout: time.Second,
+					LogLevel:        "info",
+				},
+				Redis: RedisConfig{
+					Mode:       RedisModeSentinel,
+					MasterName: "mymaster",
+				},
+			},
+			expectError: true,
+			errorMsg:    "redis.sentinel_addresses is required",
+		},
+		{
+			name: "cluster without nodes",
+			config: &Config{
+				Server: ServerConfig{
+					Host:            "localhost",
+					Port:            8080,
+					ReadTimeout:     time.Second,
+					WriteTimeout:    time.Second,
+					ShutdownTimeout: time.Second,
+					LogLevel:        "info",
+				},
+				Redis: RedisConfig{
+					Mode: RedisModeCluster,
+				},
+			},
+			expectError: true,
+			errorMsg:    "redis.cluster_addresses is required",
+		},
+		{
+			name: "cluster with db",
+			config: &Config{
+				Server: ServerConfig{
+					Host:            "localhost",
+					Port:            8080,
+					ReadTimeout:     time.Second,
+					WriteTimeout:    time.Second,
+					ShutdownTimeout: time.Second,
+					LogLevel:        "info",
+				},
+				Redis: RedisConfig{
+					Mode:             RedisModeCluster,
+					ClusterAddresses: []string{"redis-0:6379"},
+					DB:               1,
+				},
+			},
+			expectError: true,
+			errorMsg:    "redis.db must be 0 in cluster mode",
+		},
+		{
+			name: "unknown redis mode",
+			config: &Config{
+				Server: ServerConfig{
+					Host:            "localhost",
+					Port:            8080,
+					ReadTimeout:     time.Second,
+					WriteTimeout:    time.Second,
+					ShutdownTimeout: time.Second,
+					LogLevel:        "info",
+				},
+				Redis: RedisConfig{
+					Mode:    "replicated",
+					Address: "localhost:6379",
+				},
+			},
+			expectError: true,
+			errorMsg:    "invalid redis.mode",
+		},
+		{
+			name: "feature rollout above 100",
+			config: &Config{
+				Server: ServerConfig{
+					Host:            "localhost",
+					Port:            8080,
+					ReadTimeout:     time.Second,
+					WriteTimeout:    time.Second,
+					ShutdownTimeout: time.Second,
+					LogLevel:        "info",
+				},
+				Redis: RedisConfig{
+					Address:     "localhost:6379",
+					DialTimeout: 5 * time.Second,
+					PoolSize:    10,
+				},
+				Leader: LeaderConfig{
+					LockKey:       "lab:leader",
+					LockTTL:       10 * time.Second,
+					RenewInterval: 3 * time.Second,
+					RetryInterval: 5 * time.Second,
+				},
+				Features: []FeatureSettings{
+					{Path: "/ethereum/live-slots", Rollout: map[string]int{"mainnet": 150}},
+				},
+			},
+			expectError: true,
+			errorMsg:    "features[0]: rollout.mainnet must be between 0 and 100",
+		},
 		{
 			name: "zero read timeout",
 			config: &Config{
@@ -360,3 +484,198 @@ func TestConfig_Load_NonExistentFile(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read config file")
 }
+
+func TestOutboundHeadersConfig_Validate(t *testing.T) {
+	cfg := OutboundHeadersConfig{}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, DefaultOutboundStripHeaders, cfg.Strip)
+
+	cfg = OutboundHeadersConfig{Strip: []string{}}
+	require.NoError(t, cfg.Validate())
+	assert.Empty(t, cfg.Strip)
+
+	cfg = OutboundHeadersConfig{Strip: []string{"Bad Header"}}
+	require.Error(t, cfg.Validate())
+
+	cfg = OutboundHeadersConfig{Set: map[string]string{"host": "example.com"}}
+	require.Error(t, cfg.Validate())
+}
+
+func TestUpstreamAuthConfig_Validate(t *testing.T) {
+	cfg := &UpstreamAuthConfig{Headers: map[string]string{"X-Api-Key": "key"}, BearerToken: "token"}
+	require.NoError(t, cfg.Validate())
+
+	cfg = &UpstreamAuthConfig{BasicAuth: &BasicAuthConfig{Password: "secret"}}
+	require.Error(t, cfg.Validate())
+
+	cfg = &UpstreamAuthConfig{BearerToken: "token", BasicAuth: &BasicAuthConfig{Username: "lab"}}
+	require.Error(t, cfg.Validate())
+
+	cfg = &UpstreamAuthConfig{BearerToken: "token", Headers: map[string]string{"authorization": "x"}}
+	require.Error(t, cfg.Validate())
+
+	var unset *UpstreamAuthConfig
+	require.NoError(t, unset.Validate())
+}
+
+func TestCompressionConfig_Validate(t *testing.T) {
+	cfg := CompressionConfig{}
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.IsEnabled())
+	assert.Equal(t, DefaultCompressionContentTypes, cfg.ContentTypes)
+
+	cfg = CompressionConfig{GzipLevel: 10}
+	require.Error(t, cfg.Validate())
+
+	cfg = CompressionConfig{BrotliLevel: 12}
+	require.Error(t, cfg.Validate())
+
+	cfg = CompressionConfig{ContentTypes: []string{"application/json; charset=utf-8"}}
+	require.Error(t, cfg.Validate())
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	cfg := TLSConfig{}
+	require.NoError(t, cfg.Validate())
+
+	cfg = TLSConfig{Enabled: true, CertFile: "tls.crt", KeyFile: "tls.key"}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, TLSVersion12, cfg.MinVersion)
+	assert.False(t, cfg.UsesACME())
+
+	cfg = TLSConfig{Enabled: true, ACME: ACMEConfig{Domains: []string{"lab.example.com"}}}
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.UsesACME())
+	assert.NotEmpty(t, cfg.ACME.CacheDir)
+
+	cfg = TLSConfig{Enabled: true}
+	require.Error(t, cfg.Validate())
+
+	cfg = TLSConfig{Enabled: true, CertFile: "tls.crt"}
+	require.Error(t, cfg.Validate())
+
+	cfg = TLSConfig{Enabled: true, CertFile: "tls.crt", KeyFile: "tls.key", ACME: ACMEConfig{Domains: []string{"lab.example.com"}}}
+	require.Error(t, cfg.Validate())
+
+	cfg = TLSConfig{Enabled: true, CertFile: "tls.crt", KeyFile: "tls.key", MinVersion: "1.1"}
+	require.Error(t, cfg.Validate())
+}
+
+func TestRequestTimeoutsConfig_Validate(t *testing.T) {
+	cfg := RequestTimeoutsConfig{Policies: []RequestTimeoutPolicy{{Name: "api", PathPattern: "^/api/", Timeout: 5 * time.Second}}}
+	require.NoError(t, cfg.Validate())
+
+	cfg = RequestTimeoutsConfig{Policies: []RequestTimeoutPolicy{{Name: "api", PathPattern: "^/api/("}}}
+	require.Error(t, cfg.Validate())
+
+	cfg = RequestTimeoutsConfig{Policies: []RequestTimeoutPolicy{{Name: "api", PathPattern: "^/api/", Timeout: -time.Second}}}
+	require.Error(t, cfg.Validate())
+}
+
+func TestConfig_Load_EnvInterpolation(t *testing.T) {
+	t.Setenv("LAB_TEST_PORT", "9090")
+	t.Setenv("LAB_TEST_PASSWORD", "s3cret")
+	t.Setenv("LAB_TEST_EMPTY", "")
+
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		return path
+	}
+
+	cfg, err := Load(write(t, `
+# Comments may mention ${LAB_TEST_UNSET}
+server:
+  port: ${LAB_TEST_PORT}
+  host: ${LAB_TEST_HOST:-0.0.0.0}
+  log_level: "${LAB_TEST_EMPTY:-info}"
+redis:
+  address: localhost:6379
+  password: pre-${LAB_TEST_PASSWORD}
+networks:
+  - name: mainnet
+    path_mapping:
+      rewrites:
+        - match: "^/fct_(.*)"
+          replace: "/tables/fct_$1-$${LAB_TEST_PASSWORD}"
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, 9090, cfg.Server.Port)
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host)
+	assert.Equal(t, "info", cfg.Server.LogLevel)
+	assert.Equal(t, "pre-s3cret", cfg.Redis.Password)
+	assert.Equal(t, "/tables/fct_$1-${LAB_TEST_PASSWORD}", cfg.Networks[0].PathMapping.Rewrites[0].Replace)
+
+	_, err = Load(write(t, "redis:\n  password: ${LAB_TEST_UNSET}\n"))
+	require.ErrorContains(t, err, "line 2: environment variable LAB_TEST_UNSET is not set")
+}
+
+func TestCanaryConfig_Validate(t *testing.T) {
+	cfg := CanaryConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, CanaryModeSplit, cfg.Mode)
+	assert.Equal(t, 10, cfg.Percent)
+
+	cfg = CanaryConfig{Mode: "mirror"}
+	require.Error(t, cfg.Validate())
+
+	cfg = CanaryConfig{Percent: 100}
+	require.Error(t, cfg.Validate())
+
+	cfg = CanaryConfig{DecisionTTL: time.Second}
+	require.Error(t, cfg.Validate())
+}
+
+func TestMirroringConfig_Validate(t *testing.T) {
+	cfg := MirroringConfig{}
+	require.NoError(t, cfg.Validate())
+	assert.InDelta(t, 0.01, cfg.SampleRate, 0)
+	assert.Equal(t, int64(1<<20), cfg.MaxCompareBytes)
+
+	cfg = MirroringConfig{SampleRate: 1.5}
+	require.Error(t, cfg.Validate())
+
+	cfg = MirroringConfig{Timeout: time.Millisecond}
+	require.Error(t, cfg.Validate())
+}
+
+func TestConfig_ValidateTenants(t *testing.T) {
+	cfg := &Config{Tenants: []TenantConfig{
+		{Name: "acme", Hosts: []string{"Lab.Acme.io"}, Networks: []string{"mainnet"}},
+		{Name: "devs", PathPrefix: "/devs"},
+	}}
+	require.NoError(t, cfg.validateTenants())
+	assert.Equal(t, []string{"lab.acme.io"}, cfg.Tenants[0].Hosts)
+
+	tests := []struct {
+		name    string
+		tenants []TenantConfig
+		err     string
+	}{
+		{name: "no selector", tenants: []TenantConfig{{Name: "acme"}}, err: "hosts or path_prefix is required"},
+		{name: "host with port", tenants: []TenantConfig{{Name: "acme", Hosts: []string{"acme.io:443"}}}, err: "without port"},
+		{name: "trailing slash", tenants: []TenantConfig{{Name: "acme", PathPrefix: "/acme/"}}, err: "must start and not end with /"},
+		{name: "api prefix", tenants: []TenantConfig{{Name: "acme", PathPrefix: "/api/acme"}}, err: "must not be under /api"},
+		{
+			name:    "shared host",
+			tenants: []TenantConfig{{Name: "a", Hosts: []string{"lab.io"}}, {Name: "b", Hosts: []string{"LAB.io"}}},
+			err:     "share host lab.io",
+		},
+		{
+			name:    "duplicate name",
+			tenants: []TenantConfig{{Name: "a", PathPrefix: "/a"}, {Name: "a", PathPrefix: "/b"}},
+			err:     "duplicate tenant name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Tenants: tt.tenants}
+			require.ErrorContains(t, cfg.validateTenants(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DenyListConfig controls the deny list, which rejects requests from IPs and
+// CIDR ranges with 403 before they reach rate limiting. Entries are managed at
+// runtime through /admin/v1/denylist and stored in Redis, one key per entry
+// expiring with it, so every instance blocks the same clients.
+type DenyListConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	KeyPrefix    string        `yaml:"key_prefix"`    // Redis key prefix of entries (default "lab:denylist:")
+	PollInterval time.Duration `yaml:"poll_interval"` // How often entries are reloaded from Redis (default 5s)
+	MaxEntries   int           `yaml:"max_entries"`   // Entries loaded at most; extra entries are ignored (default 10000)
+}
+
+// Validate validates the deny list configuration and sets defaults.
+func (c *DenyListConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = "lab:denylist:"
+	}
+
+	if c.PollInterval == 0 {
+		c.PollInterval = 5 * time.Second
+	}
+
+	if c.MaxEntries == 0 {
+		c.MaxEntries = 10000
+	}
+
+	// Validate ranges
+	if c.PollInterval < 100*time.Millisecond {
+		return fmt.Errorf("poll_interval must be at least 100ms, got %v", c.PollInterval)
+	}
+
+	if c.MaxEntries < 1 {
+		return fmt.Errorf("max_entries must be at least 1, got %d", c.MaxEntries)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Command docgen extracts the doc and trailing comments of config struct
+// fields into a Go map, so the config reference printed by the server
+// (-print-schema, -print-defaults) stays in sync with the source.
+//
+// Usage: go run ./docgen -output docs_gen.go <package dir>...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "docs_gen.go", "File to write")
+	pkg := flag.String("package", "config", "Package of the written file")
+
+	flag.Parse()
+
+	docs := make(map[string]string)
+
+	for _, dir := range flag.Args() {
+		if err := collect(dir, docs); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by docgen. DO NOT EDIT.\n\npackage %s\n\n", *pkg)
+	buf.WriteString("// fieldDocs holds the comments of config types and fields, keyed by\n")
+	buf.WriteString("// \"<package>.<Type>\" and \"<package>.<Type>.<Field>\".\n")
+	buf.WriteString("var fieldDocs = map[string]string{\n")
+
+	for _, key := range slices.Sorted(maps.Keys(docs)) {
+		fmt.Fprintf(&buf, "\t%s: %s,\n", strconv.Quote(key), strconv.Quote(docs[key]))
+	}
+
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil { //nolint:gosec // generated source.
+		log.Fatal(err)
+	}
+}
+
+// collect adds the comments of every struct type declared in dir.
+func collect(dir string, docs map[string]string) error {
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", dir, err)
+	}
+
+	for name, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec) //nolint:errcheck // type declarations only hold type specs.
+
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+
+					typeKey := name + "." + typeSpec.Name.Name
+
+					typeDoc := typeSpec.Doc
+					if typeDoc == nil && len(gen.Specs) == 1 {
+						typeDoc = gen.Doc
+					}
+
+					if text := commentText(typeDoc, nil); text != "" {
+						docs[typeKey] = text
+					}
+
+					for _, field := range structType.Fields.List {
+						text := commentText(field.Doc, field.Comment)
+						if text == "" {
+							continue
+						}
+
+						for _, fieldName := range field.Names {
+							docs[typeKey+"."+fieldName.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// commentText joins a doc comment and a trailing comment into one line.
+func commentText(doc, trailing *ast.CommentGroup) string {
+	var parts []string
+
+	for _, group := range []*ast.CommentGroup{doc, trailing} {
+		if text := strings.Join(strings.Fields(group.Text()), " "); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
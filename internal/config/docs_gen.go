@@ -0,0 +1,437 @@
+// Code generated by docgen. DO NOT EDIT.
+
+package config
+
+// fieldDocs holds the comments of config types and fields, keyed by
+// "<package>.<Type>" and "<package>.<Type>.<Field>".
+var fieldDocs = map[string]string{
+	"cartographoor.BlobScheduleEntry":                 "BlobScheduleEntry represents a single entry in the blob schedule defining the maximum number of blobs per block starting at a specific epoch.",
+	"cartographoor.CartographoorResponse":             "CartographoorResponse represents the top-level JSON structure from networks.json. Only parses fields we actually use - ignores clients, providers, timestamps, etc.",
+	"cartographoor.Changes":                           "Changes summarises how the network list changed between two updates.",
+	"cartographoor.Changes.Added":                     "Networks that appeared",
+	"cartographoor.Changes.Changed":                   "Networks whose data changed",
+	"cartographoor.Changes.Removed":                   "Networks that disappeared or failed health checks",
+	"cartographoor.Config":                            "Config holds cartographoor service configuration.",
+	"cartographoor.Config.CacheTTL":                   "How long networks read from Redis are reused in memory, dropped early on updates (default 5s)",
+	"cartographoor.Config.NetworksTTL":                "Redis TTL for networks data (0 = no expiration)",
+	"cartographoor.Config.RefreshInterval":            "How often to refresh",
+	"cartographoor.Config.RequestTimeout":             "HTTP request timeout",
+	"cartographoor.Config.SourceURL":                  "Cartographoor JSON URL, shorthand for a single source",
+	"cartographoor.Config.Sources":                    "Sources in priority order, merged; replaces source_url",
+	"cartographoor.ConsensusFork":                     "ConsensusFork represents a single consensus fork with epoch and minimum client versions.",
+	"cartographoor.ConsensusFork.MinClientVersions":   "Map of client name to version (camelCase to match cartographoor JSON)",
+	"cartographoor.ExecutionFork":                     "ExecutionFork represents an execution layer fork with block number and timestamp.",
+	"cartographoor.Forks":                             "Forks contains fork information for a network.",
+	"cartographoor.Forks.Consensus":                   "Map of fork name to fork info",
+	"cartographoor.Forks.Execution":                   "Map of execution fork name to fork info",
+	"cartographoor.GenesisConfig":                     "GenesisConfig contains genesis configuration. Slot timing is only published for networks that differ from mainnet.",
+	"cartographoor.GenesisConfig.GenesisDelay":        "Genesis delay in seconds",
+	"cartographoor.GenesisConfig.GenesisForkVersion":  "e.g. \"0x10000038\"",
+	"cartographoor.GenesisConfig.GenesisTime":         "Unix timestamp",
+	"cartographoor.GenesisConfig.SecondsPerSlot":      "Slot duration (0 = 12)",
+	"cartographoor.GenesisConfig.SlotsPerEpoch":       "Slots per epoch (0 = 32)",
+	"cartographoor.Network":                           "Network is the processed network data used internally.",
+	"cartographoor.Network.BlobSchedule":              "Optional blob schedule defining max blobs per block at different epochs",
+	"cartographoor.Network.ChainID":                   "Integer chain ID",
+	"cartographoor.Network.Forks":                     "Fork information",
+	"cartographoor.Network.GenesisDelay":              "Genesis delay in seconds",
+	"cartographoor.Network.GenesisForkVersion":        "Genesis fork version, if published",
+	"cartographoor.Network.GenesisTime":               "Unix timestamp",
+	"cartographoor.Network.SecondsPerSlot":            "Slot duration in seconds (0 = 12)",
+	"cartographoor.Network.ServiceUrls":               "Map of service name to URL",
+	"cartographoor.Network.SlotsPerEpoch":             "Slots per epoch (0 = 32)",
+	"cartographoor.Network.StatusReason":              "Why the network is quarantined, if it is",
+	"cartographoor.Network.TargetURL":                 "CBT API URL constructed from network name",
+	"cartographoor.NetworkChange":                     "NetworkChange lists the fields that changed for one network.",
+	"cartographoor.NetworkChange.Fields":              "e.g. \"target_url\", \"service_urls\", \"forks\"",
+	"cartographoor.NetworkMetadata":                   "NetworkMetadata contains display information for networks.",
+	"cartographoor.RawNetwork":                        "RawNetwork represents a network entry in the cartographoor JSON. Only parses essential fields - network name comes from map key.",
+	"cartographoor.RawNetwork.BlobSchedule":           "Optional blob schedule",
+	"cartographoor.RawNetwork.ServiceUrls":            "Map of service name to URL",
+	"cartographoor.RedisProvider":                     "RedisProvider implements Provider interface using Redis as storage.",
+	"cartographoor.RedisProvider.cacheEpoch":          "Bumped on every invalidation",
+	"cartographoor.RedisProvider.cacheMu":             "Networks last read from Redis, reused until cache_ttl passes or they are updated",
+	"cartographoor.RedisProvider.lastChanges":         "Changes of the most recent update",
+	"cartographoor.RedisProvider.notifier":            "Signals when network data has been updated",
+	"cartographoor.Service":                           "Service fetches network data from one or more cartographoor sources and merges them in priority order. It remembers the validators of each source's last document and sends conditional requests, reusing the last result when upstream reports it unchanged.",
+	"cartographoor.SourceConfig":                      "SourceConfig is one cartographoor document. Networks listed by several sources are taken from the first one listing them.",
+	"cartographoor.SourceConfig.Name":                 "Label used in logs (default: the URL's host or file name)",
+	"cartographoor.SourceConfig.URL":                  "http(s) URL, or a file path (optionally file://) of a networks.json document",
+	"cartographoor.cachedNetworks":                    "cachedNetworks is a decoded copy of the networks in Redis.",
+	"cartographoor.source":                            "source is one cartographoor document and the state of its last fetch.",
+	"cartographoor.source.etag":                       "ETag of the last document",
+	"cartographoor.source.last":                       "Networks parsed from the last document",
+	"cartographoor.source.lastModified":               "Last-Modified of the last document",
+	"config.ACMEConfig":                               "ACMEConfig obtains and renews certificates automatically, answering TLS-ALPN-01 challenges on the server port, which must be reachable on 443.",
+	"config.ACMEConfig.CacheDir":                      "Where account keys and certificates are kept (default .tmp/acme-cache)",
+	"config.ACMEConfig.DirectoryURL":                  "ACME directory (default Let's Encrypt production)",
+	"config.ACMEConfig.Domains":                       "Host names certificates are requested for",
+	"config.ACMEConfig.Email":                         "Contact for expiry notices, optional",
+	"config.AggregateConfig":                          "AggregateConfig controls GET /api/v1/{network}/aggregate, which fetches several tables for a slot range through the proxy and returns them in one response.",
+	"config.AggregateConfig.Concurrency":              "Tables fetched in parallel (default 4)",
+	"config.AggregateConfig.MaxPages":                 "Pages fetched per table before the result is truncated (default 10)",
+	"config.AggregateConfig.MaxSlotRange":             "Slots allowed per request (default 7200, one day)",
+	"config.AggregateConfig.MaxTables":                "Tables allowed per request (default 10)",
+	"config.AggregateConfig.PageSize":                 "Rows requested per page (default 10000)",
+	"config.AggregateConfig.RequestTimeout":           "Timeout for the whole aggregation (default 30s)",
+	"config.AuthConfig":                               "AuthConfig controls API key authentication. Keys are sent as \"Authorization: Bearer <key>\" and looked up in Redis, where each key is stored as JSON under key_prefix + the hex SHA-256 of the key.",
+	"config.AuthConfig.CacheTTL":                      "How long lookups are cached in memory (default 30s)",
+	"config.AuthConfig.KeyPrefix":                     "Redis key prefix for API key records (default \"lab:auth:key:\")",
+	"config.AuthConfig.Required":                      "Reject requests without an API key (default: anonymous requests use IP-based limits)",
+	"config.BasicAuthConfig":                          "BasicAuthConfig is a username and password for HTTP basic auth.",
+	"config.BoundsConfig":                             "BoundsConfig holds bounds service configuration.",
+	"config.BoundsConfig.BoundsTTL":                   "Redis TTL for bounds data (0 = no expiration)",
+	"config.BoundsConfig.RefreshInterval":             "How often to refresh bounds",
+	"config.BoundsConfig.RequestTimeout":              "HTTP request timeout",
+	"config.BoundsConfig.StaleRetention":              "How long last known bounds of a failing network are served, marked stale",
+	"config.CacheWarmingConfig":                       "CacheWarmingConfig controls replaying popular queries through the proxy after startup, so upstream caches are warm before traffic arrives. Only the leader warms, so a deploy doesn't multiply the load.",
+	"config.CacheWarmingConfig.Concurrency":           "Queries replayed in parallel (default 4)",
+	"config.CacheWarmingConfig.LeaderWait":            "How long to wait for leadership before skipping (default 30s)",
+	"config.CacheWarmingConfig.RequestTimeout":        "Timeout per query (default 30s)",
+	"config.CanaryConfig":                             "CanaryConfig controls how a network's changed target_url is rolled out. Instead of switching over on the next sync, the new URL is trialled next to the current one, which keeps serving, until it is promoted or rolled back through the admin API. Networks with several target_urls or DNS discovery switch over right away.",
+	"config.CanaryConfig.DecisionTTL":                 "How long a rolled back URL is kept from being trialled again (default 24h)",
+	"config.CanaryConfig.KeyPrefix":                   "Redis key prefix of promote and rollback decisions (default \"lab:proxy_canary:\")",
+	"config.CanaryConfig.MaxShadowInFlight":           "Shadow requests in flight per instance, further reads are not copied (default 50)",
+	"config.CanaryConfig.Mode":                        "\"split\" (default) sends percent of requests to the new URL, \"shadow\" copies reads to it",
+	"config.CanaryConfig.Percent":                     "Share of requests sent to the new URL in split mode (default 10)",
+	"config.CanaryConfig.PollInterval":                "How often decisions made on other instances are picked up (default 10s)",
+	"config.CircuitBreakerConfig":                     "CircuitBreakerConfig controls when bounds fetching stops calling a failing network. After FailureThreshold consecutive failures the network is skipped for OpenDuration, then a single probe is allowed. Each failed probe doubles the wait, up to MaxOpenDuration.",
+	"config.CompatConfig":                             "CompatConfig controls the rolling-upgrade compatibility handshake. Every replica publishes the Redis data format version it reads under key_prefix, and the leader only writes formats every active replica can decode.",
+	"config.CompatConfig.HeartbeatInterval":           "How often replicas republish their version (default 10s)",
+	"config.CompatConfig.KeyPrefix":                   "Prefix of the per-replica keys (default \"lab:compat:replica:\")",
+	"config.CompatConfig.ReplicaTTL":                  "How long a replica counts as active after its last heartbeat (default 3x heartbeat_interval)",
+	"config.CompressionConfig":                        "CompressionConfig controls the brotli and gzip compression of API responses, for clients that accept it. Responses the handler already encoded, such as pre-compressed frontend assets, are sent as they are.",
+	"config.CompressionConfig.BrotliLevel":            "1 (fastest) to 11 (smallest) (default 4)",
+	"config.CompressionConfig.ContentTypes":           "Media types compressed, without parameters (default JSON, NDJSON, CSV and plain text)",
+	"config.CompressionConfig.Enabled":                "Compress responses (default true)",
+	"config.CompressionConfig.GzipLevel":              "1 (fastest) to 9 (smallest) (default 5)",
+	"config.CompressionConfig.MinSize":                "Smallest body, in bytes, worth compressing (default 1024)",
+	"config.Config":                                   "Config represents the complete application configuration.",
+	"config.Config.NetworksFile":                      "YAML or JSON file of further networks, watched for changes",
+	"config.Config.Tenants":                           "Lab variants selected by Host header or path prefix",
+	"config.DenyListConfig":                           "DenyListConfig controls the deny list, which rejects requests from IPs and CIDR ranges with 403 before they reach rate limiting. Entries are managed at runtime through /admin/v1/denylist and stored in Redis, one key per entry expiring with it, so every instance blocks the same clients.",
+	"config.DenyListConfig.KeyPrefix":                 "Redis key prefix of entries (default \"lab:denylist:\")",
+	"config.DenyListConfig.MaxEntries":                "Entries loaded at most; extra entries are ignored (default 10000)",
+	"config.DenyListConfig.PollInterval":              "How often entries are reloaded from Redis (default 5s)",
+	"config.DiscoveryConfig":                          "DiscoveryConfig enables DNS-based discovery of the instances behind a network's target_url hostname (e.g. a Kubernetes headless service or SRV record). Connections are load balanced across all discovered instances.",
+	"config.DiscoveryConfig.Mode":                     "\"dns\" (all A/AAAA records) or \"srv\"",
+	"config.DiscoveryConfig.Protocol":                 "SRV protocol (default \"tcp\")",
+	"config.DiscoveryConfig.RefreshInterval":          "How often to re-resolve (default 30s)",
+	"config.DiscoveryConfig.Service":                  "SRV service name, e.g. \"http\" (srv mode only)",
+	"config.FailoverConfig":                           "FailoverConfig controls how requests are spread over the upstreams of networks with several target_urls. Upstreams are health checked in the background and skipped after consecutive failed checks or requests. Reads that fail to connect are retried on the next upstream.",
+	"config.FailoverConfig.HealthInterval":            "Interval between health checks (default 10s)",
+	"config.FailoverConfig.HealthPath":                "Path checked on every upstream host (default \"/health\")",
+	"config.FailoverConfig.HealthTimeout":             "Timeout of a single health check (default 5s)",
+	"config.FailoverConfig.Strategy":                  "\"priority\" (default) or \"round_robin\"",
+	"config.FailoverConfig.UnhealthyThreshold":        "Consecutive failures before an upstream is skipped (default 3)",
+	"config.FeatureNetworkRule":                       "FeatureNetworkRule is what a network must reach before a feature is enabled there. The zero value has no requirements.",
+	"config.FeatureNetworkRule.MinEpoch":              "Epoch the network must have reached",
+	"config.FeatureNetworkRule.RequiresFork":          "Consensus fork that must be active",
+	"config.FeatureSettings":                          "FeatureSettings defines settings for a single feature. Features are enabled by default for all networks unless explicitly disabled. Rollout limits a feature on a network to a percentage of clients, bucketed by the X-Lab-Client-ID header; clients without the header are left out until the rollout reaches 100. RequiresFork and per-network rules hold a feature back on a network until a fork or epoch is reached there. Tables are reported by GET /api/v1/availability.",
+	"config.FeatureSettings.DisabledNetworks":         "Networks where this feature is disabled",
+	"config.FeatureSettings.Networks":                 "Per-network requirements, replacing requires_fork",
+	"config.FeatureSettings.Path":                     "Feature path (e.g., \"/ethereum/data-availability/das-custody\")",
+	"config.FeatureSettings.RequiresFork":             "Consensus fork (e.g. \"electra\") that must be active on a network",
+	"config.FeatureSettings.Rollout":                  "Network name to percentage of clients (0-100) the feature is enabled for",
+	"config.FeatureSettings.Tables":                   "CBT tables the feature reads; it is only available where all have data",
+	"config.FreshnessAlertsConfig":                    "FreshnessAlertsConfig controls alerts for CBT tables whose max bound stops advancing. The leader checks the stored bounds every check_interval and notifies the webhooks once when a table stalls for longer than its network's threshold, and once when it advances again.",
+	"config.FreshnessAlertsConfig.CheckInterval":      "How often bounds are checked (default 1m)",
+	"config.FreshnessAlertsConfig.Networks":           "Per-network threshold overrides",
+	"config.FreshnessAlertsConfig.Threshold":          "How long a max bound may stand still (default 30m)",
+	"config.FrontendConfig":                           "FrontendConfig controls where the frontend bundle is served from. The embedded bundle is verified against its SHA256SUMS manifest at startup; when it is missing or corrupted a matching bundle is fetched from fallback_url into cache_dir, rather than release builds serving local dev-mode files.",
+	"config.FrontendConfig.AssetMaxAge":               "Cache lifetime of files not fingerprinted in the build's asset manifest, revalidated once stale (default 5m)",
+	"config.FrontendConfig.CacheDir":                  "Where fetched bundles are extracted (default \".tmp/frontend-cache\")",
+	"config.FrontendConfig.DevServerURL":              "Vite dev server that dev builds proxy the frontend to, e.g. http://localhost:5173 (default: serve the bundle)",
+	"config.FrontendConfig.FallbackURL":               "Bundle tarball URL; {version}, {os} and {arch} are substituted",
+	"config.FrontendConfig.FetchTimeout":              "Timeout for downloading the bundle (default 60s)",
+	"config.FrontendConfig.MinCompress":               "Smallest asset, in bytes, worth compressing (default 1024)",
+	"config.FrontendConfig.Precompress":               "Serve brotli/gzip variants of text assets, compressed at startup (default true)",
+	"config.FrontendConfig.Version":                   "Frontend version to fetch (default: the build's frontend version)",
+	"config.FrontendConfig.WatchInterval":             "How often dev mode checks index.html and head.json for changes (default 1s)",
+	"config.GRPCConfig":                               "GRPCConfig controls the gRPC API, which mirrors the REST config and bounds endpoints on a separate port for other backends.",
+	"config.GRPCConfig.ListenAddress":                 "Address the gRPC server listens on (default \":9090\")",
+	"config.GRPCConfig.MaxWatchers":                   "Max concurrent WatchNetworks streams (default 100)",
+	"config.GasProfilerCacheConfig":                   "GasProfilerCacheConfig holds the Redis cache of simulation results. Results are cached per head block and dropped once a new head is observed.",
+	"config.GasProfilerCacheConfig.HeadInterval":      "Interval between head block polls (default 12s)",
+	"config.GasProfilerCacheConfig.KeyPrefix":         "Redis key prefix (default \"lab:gas_profiler:cache:\")",
+	"config.GasProfilerCacheConfig.TTL":               "How long results are kept (default 1h)",
+	"config.GasProfilerConfig":                        "GasProfilerConfig holds gas profiler simulation service configuration.",
+	"config.GasProfilerConfig.Endpoints":              "List of Erigon RPC endpoints",
+	"config.GasProfilerConfig.HealthInterval":         "Interval between endpoint health checks (default 30s)",
+	"config.GasProfilerConfig.RequestTimeout":         "HTTP request timeout for RPC calls",
+	"config.GasProfilerConfig.Strategy":               "How requests are spread over a network's healthy endpoints (default \"round_robin\")",
+	"config.GasProfilerEndpoint":                      "GasProfilerEndpoint defines a single Erigon RPC endpoint.",
+	"config.GasProfilerEndpoint.Auth":                 "Credentials sent with every RPC call",
+	"config.GasProfilerEndpoint.Name":                 "Friendly name (e.g., \"mainnet-1\", \"mainnet-2\")",
+	"config.GasProfilerEndpoint.Network":              "Network identifier to match in requests",
+	"config.GasProfilerEndpoint.URL":                  "Erigon JSON-RPC URL",
+	"config.GasProfilerEndpoint.Weight":               "Share of requests with the weighted strategy (default 1)",
+	"config.HeaderPolicy":                             "HeaderPolicy defines headers to set for matching request paths.",
+	"config.HeaderPolicy.Headers":                     "Headers to set (key: value); {{nonce}} in a value is a fresh per-request CSP nonce",
+	"config.HeaderPolicy.Name":                        "Policy name for logging/debugging",
+	"config.HeaderPolicy.PathPattern":                 "Regex pattern to match request paths",
+	"config.HeadersConfig":                            "HeadersConfig holds HTTP headers configuration.",
+	"config.HealthProbeConfig":                        "HealthProbeConfig controls continuous health probing of proxied network backends. Each network is marked degraded after a failed probe and unhealthy after unhealthy_threshold failed probes in a row; requests to an unhealthy network are answered with a 503 until healthy_threshold probes in a row succeed again.",
+	"config.HealthProbeConfig.HealthyThreshold":       "Consecutive successful probes before an unhealthy network recovers (default 2)",
+	"config.HealthProbeConfig.Interval":               "Interval between probes (default 15s)",
+	"config.HealthProbeConfig.Path":                   "Path probed on every backend host (default \"/health\")",
+	"config.HealthProbeConfig.Timeout":                "Timeout of a single probe (default 5s)",
+	"config.HealthProbeConfig.UnhealthyThreshold":     "Consecutive failed probes before a network is unhealthy (default 3)",
+	"config.HedgingConfig":                            "HedgingConfig controls hedged requests for latency-sensitive proxied reads. When the primary upstream has not responded within a percentile-based delay, a second request is sent to the network's hedge_target_url and whichever response arrives first is used.",
+	"config.HedgingConfig.BudgetRatio":                "Max fraction of eligible requests that may be hedged (default 0.05)",
+	"config.HedgingConfig.MaxDelay":                   "Upper bound for the hedge delay (default 2s)",
+	"config.HedgingConfig.MaxInFlight":                "Max concurrent hedge requests across all networks (default 10)",
+	"config.HedgingConfig.MinDelay":                   "Lower bound for the hedge delay (default 50ms)",
+	"config.HedgingConfig.PathPatterns":               "Regex patterns for hedge-eligible paths (empty = all GET requests)",
+	"config.HedgingConfig.Percentile":                 "Primary latency percentile used as hedge delay (default 0.95)",
+	"config.LeaderConfig":                             "LeaderConfig holds leader election configuration.",
+	"config.LocalOverridesConfig":                     "LocalOverridesConfig defines per-table routing overrides for hybrid mode. When set, requests for the specified tables are routed to the local target while all other tables use the default (external) TargetURL.",
+	"config.LocalOverridesConfig.Tables":              "Tables to route locally",
+	"config.LocalOverridesConfig.TargetURL":           "Local cbt-api URL",
+	"config.MaintenanceConfig":                        "MaintenanceConfig controls maintenance mode, scheduled by operators through the admin API and stored in Redis so every instance follows it. While it is active, API routes return 503 with the maintenance message and frontend routes a maintenance page. Health probes and admin routes are not affected.",
+	"config.MaintenanceConfig.Message":                "Message returned when a window sets none",
+	"config.MaintenanceConfig.PageFile":               "HTML file served to frontend routes (default: built-in page)",
+	"config.MaintenanceConfig.PollInterval":           "How often redis_key is checked (default 5s)",
+	"config.MaintenanceConfig.RedisKey":               "Redis key holding the scheduled window (default \"lab:maintenance\")",
+	"config.MaintenanceConfig.RetryAfter":             "Retry-After for windows without an end (default 5m)",
+	"config.MirroringConfig":                          "MirroringConfig controls the copies of proxied reads sent to networks' mirror_url, e.g. a new CBT API deployment validated against production traffic before target_url is switched to it. Mirror responses never reach clients; for a sample of requests they are compared with the primary's and differences are logged.",
+	"config.MirroringConfig.MaxCompareBytes":          "Largest response body compared, larger responses are skipped (default 1MiB)",
+	"config.MirroringConfig.MaxInFlight":              "Mirror requests in flight per instance, further reads are not copied (default 50)",
+	"config.MirroringConfig.SampleRate":               "Share of mirrored requests whose responses are compared (default 0.01)",
+	"config.MirroringConfig.Timeout":                  "Bounds each mirror request including its body (default 30s)",
+	"config.NegativeCacheConfig":                      "NegativeCacheConfig controls the negative cache, which remembers unknown networks and missing frontend assets for a short while, so bot scans and typoed clients don't cause Redis reads and SPA fallbacks on every request.",
+	"config.NegativeCacheConfig.Enabled":              "Cache misses (default true)",
+	"config.NegativeCacheConfig.MaxEntries":           "Max misses remembered per cache (default 10000)",
+	"config.NegativeCacheConfig.TTL":                  "How long a miss is remembered (default 30s)",
+	"config.NetworkConfig":                            "NetworkConfig defines a single network's configuration. When used in config.yaml, all fields except Name are optional. Cartographoor values are used as defaults, config.yaml provides overrides.",
+	"config.NetworkConfig.Aliases":                    "Optional: Former names that resolve to this network",
+	"config.NetworkConfig.Auth":                       "Optional: Credentials sent to target_url, hedge_target_url and mirror_url",
+	"config.NetworkConfig.ChainID":                    "Optional: Numeric chain ID",
+	"config.NetworkConfig.Discovery":                  "Optional: DNS-based discovery of target_url instances",
+	"config.NetworkConfig.DisplayName":                "Optional: Human-readable name",
+	"config.NetworkConfig.Enabled":                    "Optional: Whether this network is active",
+	"config.NetworkConfig.GenesisDelay":               "Optional: Genesis delay in seconds",
+	"config.NetworkConfig.GenesisTime":                "Optional: Unix timestamp",
+	"config.NetworkConfig.HedgeTargetURL":             "Optional: Alternate backend replica for hedged reads",
+	"config.NetworkConfig.Hidden":                     "Optional: Only listed for requests with a preview token",
+	"config.NetworkConfig.LocalOverrides":             "Optional: Hybrid-mode per-table routing",
+	"config.NetworkConfig.MirrorURL":                  "Optional: Backend receiving a copy of proxied reads, responses discarded",
+	"config.NetworkConfig.Name":                       "Required: \"mainnet\", \"sepolia\", etc.",
+	"config.NetworkConfig.PathMapping":                "Optional: Upstream path prefix mapping",
+	"config.NetworkConfig.Retry":                      "Optional: Retry reads on transient upstream errors (default proxy.retry.enabled)",
+	"config.NetworkConfig.TargetURL":                  "Optional: Backend CBT API URL",
+	"config.NetworkConfig.TargetURLs":                 "Optional: Backend CBT API URLs in failover order, the first is target_url",
+	"config.NetworkConfig.Timeout":                    "Optional: Wait for upstream response headers (default proxy.timeouts.default)",
+	"config.OutboundHeadersConfig":                    "OutboundHeadersConfig controls which headers are forwarded to upstream backends. Sensitive inbound headers are stripped so client credentials never reach third-party backends, and required upstream headers are injected.",
+	"config.OutboundHeadersConfig.Set":                "Headers set on every upstream request, replacing inbound values",
+	"config.OutboundHeadersConfig.Strip":              "Inbound headers removed before forwarding (default: cookies, auth and Cloudflare Access tokens; [] keeps all)",
+	"config.PathMappingConfig":                        "PathMappingConfig adapts proxied paths for upstreams that serve their API under a non-standard prefix. It is applied to the default rewritten path (/api/v1/{network}/x → /api/v1/x) in order: strip_prefix, rewrites, add_prefix.",
+	"config.PathMappingConfig.AddPrefix":              "Prefix prepended to the path, e.g. \"/cbt/api/v1\"",
+	"config.PathMappingConfig.Rewrites":               "Regex rewrites applied in order",
+	"config.PathMappingConfig.StripPrefix":            "Prefix removed from the path, e.g. \"/api/v1\"",
+	"config.PathRewriteConfig":                        "PathRewriteConfig is a single regex path rewrite.",
+	"config.PathRewriteConfig.Match":                  "Regex matched against the path",
+	"config.PathRewriteConfig.Replace":                "Replacement, may reference groups ($1)",
+	"config.PathTimeoutConfig":                        "PathTimeoutConfig is the upstream timeout for requests whose path matches.",
+	"config.PathTimeoutConfig.Pattern":                "Regex matched against the request path, e.g. \"^/api/v1/[^/]+/fct_attestation\"",
+	"config.PathTimeoutConfig.Timeout":                "Wait for upstream response headers",
+	"config.PreviewConfig":                            "PreviewConfig controls access to hidden (soft-launched) networks. Hidden networks are left out of /api/v1/config and the config injected into the frontend unless the request carries one of the preview tokens, either in the X-Lab-Preview-Token header or the preview cookie. Opening any frontend page with ?preview=<token> sets the cookie.",
+	"config.PreviewConfig.CookieName":                 "Cookie carrying a token (default \"lab_preview\")",
+	"config.PreviewConfig.Tokens":                     "Accepted preview tokens",
+	"config.ProfilingConfig":                          "ProfilingConfig controls continuous profiling. Profiles are pushed to a Pyroscope-compatible ingest endpoint. Backends that scrape instead (e.g. Parca) can use expose_pprof to serve /debug/pprof.",
+	"config.ProfilingConfig.ApplicationName":          "Application name profiles are stored under (default \"lab-backend\")",
+	"config.ProfilingConfig.BasicAuthPassword":        "Optional basic auth password",
+	"config.ProfilingConfig.BasicAuthUser":            "Optional basic auth user",
+	"config.ProfilingConfig.ExposePprof":              "Serve net/http/pprof under /debug/pprof for scraping backends",
+	"config.ProfilingConfig.Labels":                   "Static labels attached to every profile",
+	"config.ProfilingConfig.ProfileTypes":             "cpu, heap, goroutine (default cpu, heap)",
+	"config.ProfilingConfig.ServerAddress":            "Pyroscope server base URL",
+	"config.ProfilingConfig.TenantID":                 "Optional X-Scope-OrgID for multi-tenant backends",
+	"config.ProfilingConfig.UploadInterval":           "How often profiles are pushed; also the CPU profile duration (default 15s)",
+	"config.ProxyConfig":                              "ProxyConfig holds settings for the network reverse proxy.",
+	"config.ProxyConfig.AliasMode":                    "How network alias requests are served: \"redirect\" (default) or \"rewrite\"",
+	"config.PushConfig":                               "PushConfig controls the /api/v1/ws WebSocket push channel, which streams bounds and network updates to frontends so they don't have to poll.",
+	"config.PushConfig.MaxClients":                    "Max concurrent WebSocket clients (default 1000)",
+	"config.PushConfig.PingInterval":                  "How often idle clients are pinged (default 30s)",
+	"config.QueryValidationConfig":                    "QueryValidationConfig controls validation of proxied table queries. When enabled, malformed queries are rejected with 400 before they reach the upstream ClickHouse-backed APIs. Defaults apply to every table; tables can override them and restrict the filters they accept.",
+	"config.QueryValidationConfig.MaxPageSize":        "Largest page_size accepted (0 = unlimited)",
+	"config.QueryValidationConfig.MaxSlotRange":       "Largest span between lower and upper slot filters (0 = unlimited)",
+	"config.QueryValidationConfig.Tables":             "Per-table rules, by table name",
+	"config.RateLimitOffendersConfig":                 "RateLimitOffendersConfig controls recording denied requests per client and rule, served as top offenders at /admin/v1/ratelimit/offenders to help tune rules and feed abuse blocking. Denials are counted in memory and flushed to Redis sorted sets, one per bucket, so every replica contributes.",
+	"config.RateLimitOffendersConfig.BucketSize":      "Granularity of the recorded counts (default 5m)",
+	"config.RateLimitOffendersConfig.DefaultLimit":    "Offenders returned without ?limit (default 20)",
+	"config.RateLimitOffendersConfig.FlushInterval":   "How often counts are written to Redis (default 10s)",
+	"config.RateLimitOffendersConfig.MaxLimit":        "Largest ?limit accepted (default 100)",
+	"config.RateLimitOffendersConfig.Windows":         "Windows offenders can be reported over; the first is the default (default [1h, 24h])",
+	"config.RateLimitRule":                            "RateLimitRule defines a single rate limit rule. Anonymous requests are limited per IP; requests with an API key are limited per key, using the key tier's limit when one is set.",
+	"config.RateLimitRule.Burst":                      "Optional: extra requests allowed above limit in a burst, refilled at limit per window",
+	"config.RateLimitRule.Limit":                      "Max requests",
+	"config.RateLimitRule.PathPattern":                "Regex pattern",
+	"config.RateLimitRule.TierLimits":                 "Optional: max requests per API key tier",
+	"config.RateLimitRule.Window":                     "Time window",
+	"config.RateLimitingConfig":                       "RateLimitingConfig holds rate limiting configuration.",
+	"config.RateLimitingConfig.ExemptIPs":             "CIDR ranges to whitelist",
+	"config.RateLimitingConfig.ExemptTiers":           "API key tiers that bypass rate limiting",
+	"config.RateLimitingConfig.FailureMode":           "\"fail_open\" or \"fail_closed\"",
+	"config.ReadOnlyConfig":                           "ReadOnlyConfig controls read-only mode, in which mutating endpoints return 503. It is on while enabled is set, or at runtime while redis_key exists in Redis (its value, if any, replaces the message), so operators can flip every instance at once during migrations and incidents.",
+	"config.ReadOnlyConfig.Enabled":                   "Start in read-only mode regardless of Redis",
+	"config.ReadOnlyConfig.Message":                   "Message returned to rejected requests",
+	"config.ReadOnlyConfig.PollInterval":              "How often redis_key is checked (default 5s)",
+	"config.ReadOnlyConfig.RedisKey":                  "Redis key that turns read-only mode on at runtime (default \"lab:read_only\")",
+	"config.RedisConfig":                              "RedisConfig holds Redis client configuration.",
+	"config.RedisConfig.ClusterAddresses":             "Cluster seed nodes, host:port",
+	"config.RedisConfig.MasterName":                   "Sentinel master set name",
+	"config.RedisConfig.Mode":                         "\"standalone\" (default), \"sentinel\" or \"cluster\"",
+	"config.RedisConfig.SentinelAddresses":            "Sentinel host:port list",
+	"config.RedisConfig.SentinelPassword":             "Password of the sentinels, if different from the data nodes",
+	"config.RequestTimeoutPolicy":                     "RequestTimeoutPolicy is the deadline of requests whose path matches.",
+	"config.RequestTimeoutPolicy.Name":                "Policy name for logging and metrics",
+	"config.RequestTimeoutPolicy.PathPattern":         "Regex pattern to match request paths",
+	"config.RequestTimeoutPolicy.Timeout":             "Deadline of matching requests, 0 exempts them",
+	"config.RequestTimeoutsConfig":                    "RequestTimeoutsConfig sets deadlines on inbound requests by path, so slow upstreams can't hold cheap endpoints for the whole server write_timeout.",
+	"config.RequestTimeoutsConfig.Policies":           "Evaluated in order, first match wins",
+	"config.RetirementConfig":                         "RetirementConfig controls how networks dropped from the proxy, e.g. devnets cartographoor no longer lists, are answered. For grace_period after removal, requests to a retired network get a 410 Gone with the time it was retired and its final bounds, read from a tombstone record in Redis shared by every instance. Afterwards the network is unknown (404).",
+	"config.RetirementConfig.GracePeriod":             "How long retired networks are answered with 410 Gone (default 168h)",
+	"config.RetirementConfig.KeyPrefix":               "Redis key prefix of tombstone records (default \"lab:retired_network:\")",
+	"config.RetryConfig":                              "RetryConfig controls retries of proxied reads. GET and HEAD requests without a body are sent again, with exponential backoff, when the upstream fails to respond or answers with one of status_codes. Networks can opt in or out with their own retry setting.",
+	"config.RetryConfig.Enabled":                      "Default for networks without their own retry setting",
+	"config.RetryConfig.InitialBackoff":               "Wait before the first retry, doubled for each further one (default 100ms)",
+	"config.RetryConfig.MaxAttempts":                  "Attempts per request, including the first (default 3)",
+	"config.RetryConfig.MaxBackoff":                   "Upper bound for the wait between attempts (default 1s)",
+	"config.RetryConfig.StatusCodes":                  "Upstream statuses that are retried (default 502, 503, 504)",
+	"config.SLOConfig":                                "SLOConfig controls availability and latency SLO tracking for upstreams. Every outbound request (proxy, bounds, cartographoor, gas profiler) counts towards the SLOs of its subsystem and upstream host.",
+	"config.SLOConfig.EvaluationInterval":             "How often burn rates are evaluated (default 30s)",
+	"config.SLOConfig.MinRequests":                    "Requests required in the window before alerting (default 10)",
+	"config.SLOConfig.Subsystems":                     "Per-subsystem overrides (proxy, bounds, cartographoor, gas_profiler)",
+	"config.SLOConfig.WebhookURL":                     "Optional webhook for budget exhausted/recovered alerts",
+	"config.SLOConfig.Window":                         "Rolling window SLOs are computed over (default 1h)",
+	"config.SLOTargets":                               "SLOTargets holds the objectives for a set of upstreams.",
+	"config.SLOTargets.AvailabilityTarget":            "Fraction of requests that must succeed (default 0.99)",
+	"config.SLOTargets.LatencyTarget":                 "Fraction of successful requests that must be fast (default 0.95)",
+	"config.SLOTargets.LatencyThreshold":              "A request is fast if it completes within this (default 1s)",
+	"config.SMTPConfig":                               "SMTPConfig is an outgoing mail server.",
+	"config.SMTPConfig.From":                          "Sender address",
+	"config.SMTPConfig.Port":                          "Default 587, STARTTLS is used when offered",
+	"config.SMTPConfig.Username":                      "Optional, PLAIN auth",
+	"config.SchemaSpec":                               "SchemaSpec is an OpenAPI spec and the request path it describes.",
+	"config.SchemaSpec.BasePath":                      "Request path prefix of the spec's paths, e.g. /api/v1/{network}",
+	"config.SchemaSpec.Path":                          "OpenAPI 3 spec file, YAML or JSON",
+	"config.SchemaValidationConfig":                   "SchemaValidationConfig enables dev-mode validation of JSON responses, both proxied and local, against OpenAPI specs. Mismatches are only logged; it buffers every JSON response and is not meant for production.",
+	"config.SchemaValidationConfig.MaxBodyBytes":      "Larger responses are not validated (default 10MiB)",
+	"config.ServerConfig":                             "ServerConfig contains HTTP server settings.",
+	"config.ServerConfig.DrainTimeout":                "How long SSE and WebSocket connections may stay open on shutdown (default 5s)",
+	"config.ServerConfig.H2C":                         "Also serve HTTP/2 without TLS, to clients with prior knowledge",
+	"config.ServerConfig.HTTP2":                       "Serve HTTP/2 to TLS clients that negotiate it (default true)",
+	"config.ServerConfig.LogFormat":                   "\"text\" (default) or \"json\"",
+	"config.ServerConfig.TLS":                         "Terminate TLS instead of serving plain HTTP",
+	"config.ServerConfig.TrustedProxies":              "IPs or CIDR ranges whose forwarding headers are honored",
+	"config.SlotTransformConfig":                      "SlotTransformConfig controls whether slot filters are rewritten to timestamps before proxying. The policy can be overridden at runtime through a Redis key shared by every instance.",
+	"config.SlotTransformConfig.PollInterval":         "How often redis_key is checked (default 5s)",
+	"config.SlotTransformConfig.RedisKey":             "Redis key holding a JSON policy override (default \"lab:slot_transform\")",
+	"config.SlotTransformNetworkPolicy":               "SlotTransformNetworkPolicy overrides the slot transform mode for one network.",
+	"config.SlotTransformNetworkPolicy.Mode":          "Mode for every table of the network",
+	"config.SlotTransformNetworkPolicy.Tables":        "Mode per table name",
+	"config.SlotTransformPolicy":                      "SlotTransformPolicy sets the slot transform mode globally, per network and per table. The most specific mode set wins.",
+	"config.SlotTransformPolicy.Mode":                 "Default mode: \"transform\" or \"passthrough\"",
+	"config.SlotTransformPolicy.Networks":             "Per-network overrides",
+	"config.StartupGateConfig":                        "StartupGateConfig controls the startup gate, which answers data endpoints with 503 until the data they serve (networks, bounds) has been loaded once, instead of empty responses or errors.",
+	"config.StartupGateConfig.CheckInterval":          "Minimum time between checks of a warming dependency (default 1s)",
+	"config.StartupGateConfig.Enabled":                "Gate data endpoints while warming up (default true)",
+	"config.StartupGateConfig.RetryAfter":             "Retry-After hint sent while warming (default 5s)",
+	"config.SummaryConfig":                            "SummaryConfig controls the per-network summary served at /api/v1/{network}/summary. The leader periodically counts rows of a few CBT tables per network and stores the counts in Redis, so landing pages can show network liveness without querying the tables themselves.",
+	"config.SummaryConfig.Counts":                     "Count name → CBT table counted (default nodes: fct_node_active_last_24h)",
+	"config.SummaryConfig.MaxPages":                   "Max pages of 10000 rows counted per table (default 10)",
+	"config.SummaryConfig.RefreshInterval":            "How often counts are refreshed (default 1m)",
+	"config.SummaryConfig.RequestTimeout":             "HTTP request timeout (default 30s)",
+	"config.SummaryConfig.TTL":                        "Redis TTL of a network's summary (default 10m)",
+	"config.SyntheticConfig":                          "SyntheticConfig enables a built-in network backed by a deterministic data generator instead of a real CBT API, for end-to-end UI tests and demos. Its bounds advance with the wallclock and table rows are derived from the table name and slot, so the same request always returns the same data.",
+	"config.SyntheticConfig.ChainID":                  "Reported chain ID (default 1337)",
+	"config.SyntheticConfig.DisplayName":              "Human-readable name (default \"Synthetic\")",
+	"config.SyntheticConfig.GenesisTime":              "Unix genesis timestamp (default 1606824023)",
+	"config.SyntheticConfig.ListenAddress":            "Loopback address the generator serves on (default \"127.0.0.1:0\", a random port)",
+	"config.SyntheticConfig.Name":                     "Network name (default \"synthetic\")",
+	"config.SyntheticConfig.Retention":                "How far back table bounds reach (default 24h)",
+	"config.SyntheticConfig.SecondsPerSlot":           "Slot duration (default 12)",
+	"config.SyntheticConfig.Tables":                   "Tables with generated data (default fct_block, fct_block_head, fct_attestation_correctness_head)",
+	"config.TLSConfig":                                "TLSConfig controls TLS termination by the HTTP server, with a certificate from files or obtained from an ACME CA such as Let's Encrypt.",
+	"config.TLSConfig.ACME":                           "Used instead of cert_file and key_file when domains are set",
+	"config.TLSConfig.CertFile":                       "PEM certificate chain",
+	"config.TLSConfig.KeyFile":                        "PEM private key",
+	"config.TLSConfig.MinVersion":                     "\"1.2\" (default) or \"1.3\"",
+	"config.TablePagesConfig":                         "TablePagesConfig controls GET /api/v1/{network}/tables/{table}/pages, which splits a slot range of a table into page queries.",
+	"config.TablePagesConfig.DefaultPageSize":         "Slots per page without ?page_size= (default 100)",
+	"config.TablePagesConfig.Enabled":                 "Serve the endpoint (default true)",
+	"config.TablePagesConfig.MaxPages":                "Pages listed per response before it is truncated (default 1000)",
+	"config.TableQueryRules":                          "TableQueryRules are the query rules of one table. Zero limits inherit the defaults of QueryValidationConfig.",
+	"config.TableQueryRules.AllowedFilters":           "Columns that may be filtered on, e.g. \"slot\" (empty allows any)",
+	"config.TenantConfig":                             "TenantConfig is a variant of the lab served by the same deployment, e.g. a client-specific lab next to lab.ethpandaops.io. Requests select it by their Host header or a path prefix, and see its networks, features and header policies overlaid on the global ones.",
+	"config.TenantConfig.Features":                    "Replace global features with the same path, others are added",
+	"config.TenantConfig.Headers":                     "Header policies evaluated before headers.policies",
+	"config.TenantConfig.Hosts":                       "Host names selecting the tenant, without port",
+	"config.TenantConfig.Networks":                    "Networks shown to the tenant (default: all)",
+	"config.TenantConfig.PathPrefix":                  "Path prefix selecting the tenant, e.g. \"/acme\", stripped before routing",
+	"config.TermsConfig":                              "TermsConfig controls terms-of-use acknowledgment gating for expensive endpoints. Clients must accept the current terms version before requests to any of the configured endpoint classes are served.",
+	"config.TermsConfig.Classes":                      "Endpoint classes that require acknowledgment",
+	"config.TermsConfig.Secret":                       "HMAC key used to sign acknowledgment tokens",
+	"config.TermsConfig.TTL":                          "How long an acknowledgment stays valid (default 720h)",
+	"config.TermsConfig.URL":                          "Where the terms can be read",
+	"config.TermsConfig.Version":                      "Terms version; bumping it invalidates prior acknowledgments",
+	"config.TermsEndpointClass":                       "TermsEndpointClass is a named group of endpoints gated by the terms of use.",
+	"config.TermsEndpointClass.Methods":               "HTTP methods gated (empty = all methods)",
+	"config.TermsEndpointClass.PathPattern":           "Regex pattern to match request paths",
+	"config.TimersConfig":                             "TimersConfig spreads out periodic background work (bounds and cartographoor refreshes, proxy sync, health polls, leader renewal, ...) so replicas and components don't hit upstreams at the same moment.",
+	"config.TimersConfig.Jitter":                      "Jitter moves each tick by up to this fraction of its interval (default 0.1, max 0.5).",
+	"config.TimersConfig.Splay":                       "Splay shifts each timer's phase by up to this fraction of its interval (default 1).",
+	"config.TokenIssuanceConfig":                      "TokenIssuanceConfig controls self-service API key issuance: a caller asks for a key with an email address, receives a one-time code and exchanges it for a key with the default tier, networks and scopes. Requires auth to be enabled.",
+	"config.TokenIssuanceConfig.AllowedDomains":       "Only issue keys to these email domains (default: any)",
+	"config.TokenIssuanceConfig.CodeTTL":              "How long a verification code is valid (default 30m)",
+	"config.TokenIssuanceConfig.KeyTTL":               "Lifetime of issued keys (default 90 days)",
+	"config.TokenIssuanceConfig.Networks":             "Networks of issued keys (default: all)",
+	"config.TokenIssuanceConfig.RedisPrefix":          "Redis key prefix for pending verifications (default \"lab:auth:issuance:\")",
+	"config.TokenIssuanceConfig.SMTP":                 "Mail server the verification emails are sent through",
+	"config.TokenIssuanceConfig.Scopes":               "Scopes of issued keys (default: config, proxy)",
+	"config.TokenIssuanceConfig.Tier":                 "Tier of issued keys (default \"free\")",
+	"config.TokenIssuanceConfig.VerifyURL":            "Page linked in the email, the code is appended as ?code= (default: code only)",
+	"config.TracingConfig":                            "TracingConfig controls request tracing integration.",
+	"config.TracingConfig.Enabled":                    "Enabled honours incoming W3C trace context (traceparent). Latency histogram observations for sampled requests carry the trace ID as an exemplar. Spans are created for incoming requests, upstream HTTP calls, Redis operations and the bounds/cartographoor refresh loops, and exported over OTLP/HTTP when endpoint is set.",
+	"config.TracingConfig.Endpoint":                   "OTLP/HTTP collector host:port, e.g. \"otel-collector:4318\"; empty disables export",
+	"config.TracingConfig.Headers":                    "Extra headers sent to the collector (e.g. auth)",
+	"config.TracingConfig.Insecure":                   "Export over plain HTTP instead of HTTPS",
+	"config.TracingConfig.SampleRatio":                "Fraction of new traces sampled (default 1); incoming decisions are honoured",
+	"config.TracingConfig.ServiceName":                "service.name of exported spans (default \"lab-backend\")",
+	"config.TransformConfig":                          "TransformConfig is one transform of a route.",
+	"config.TransformConfig.Field":                    "network_metadata: top-level field holding the metadata (default \"network\")",
+	"config.TransformConfig.Fields":                   "rename_fields: upstream field name → name in the response",
+	"config.TransformConfig.Type":                     "slot_filters, epoch_to_slot, rename_fields or network_metadata",
+	"config.TransformRouteConfig":                     "TransformRouteConfig is the transform pipeline of the routes matching a pattern.",
+	"config.TransformRouteConfig.Pattern":             "Regex matched against the path after the network, e.g. \"^/fct_block$\" (empty matches all)",
+	"config.TransformsConfig":                         "TransformsConfig registers request and response transforms per route. Every route whose pattern matches a request contributes its transforms, in order.",
+	"config.TransformsConfig.Routes":                  "Default: slot_filters for every route ([] disables all transforms)",
+	"config.UpstreamAuthConfig":                       "UpstreamAuthConfig holds credentials attached to every request sent to an upstream. Credentials are never served to clients.",
+	"config.UpstreamAuthConfig.BasicAuth":             "Sent as \"Authorization: Basic ...\"",
+	"config.UpstreamAuthConfig.BearerToken":           "Sent as \"Authorization: Bearer <token>\"",
+	"config.UpstreamAuthConfig.Headers":               "Headers set on every upstream request",
+	"config.UpstreamCoalescingConfig":                 "UpstreamCoalescingConfig controls the coalescing of identical outbound requests. While a GET to an upstream is in flight, identical ones from the proxy, bounds fetcher or cartographoor refresh wait for its response instead of being sent again.",
+	"config.UpstreamCoalescingConfig.Enabled":         "Coalesce identical requests (default true)",
+	"config.UpstreamCoalescingConfig.MaxBodyBytes":    "Largest response body shared with waiting requests; larger ones make them send their own (default 8MiB)",
+	"config.UpstreamLimitsConfig":                     "UpstreamLimitsConfig bounds the concurrent requests the proxy and bounds fetcher send to each upstream host, so a burst of frontend traffic can't overwhelm small devnet CBT APIs. Requests beyond max_concurrent wait in a queue; once it is full or the wait times out, proxied requests get a 503 with Retry-After.",
+	"config.UpstreamLimitsConfig.Hosts":               "max_concurrent overrides per upstream host[:port]",
+	"config.UpstreamLimitsConfig.MaxConcurrent":       "Requests in flight per upstream host (default 32)",
+	"config.UpstreamLimitsConfig.MaxQueue":            "Requests waiting for a slot per host, beyond which they are rejected (default 100)",
+	"config.UpstreamLimitsConfig.QueueTimeout":        "Longest wait for a slot (default 5s)",
+	"config.UpstreamTimeoutsConfig":                   "UpstreamTimeoutsConfig controls how long the proxy waits for an upstream to start responding. The first matching path timeout wins, then the network's own timeout, then the default. Streaming the response body is not limited.",
+	"config.UpstreamTimeoutsConfig.Default":           "Wait for upstream response headers (default 30s)",
+	"config.UpstreamTimeoutsConfig.Paths":             "Overrides for matching request paths, first match wins",
+	"config.WarmQuery":                                "WarmQuery is a query template replayed for each of its networks.",
+	"config.WarmQuery.Networks":                       "Networks to warm (empty = all proxied networks)",
+	"config.WarmQuery.Path":                           "Path and query below /api/v1/{network}/; {network} is substituted",
+	"config.WebSocketConfig":                          "WebSocketConfig controls passthrough of WebSocket upgrade requests to network backends. When disabled, upgrade requests are rejected.",
+	"config.WebSocketConfig.IdleTimeout":              "Close connections with no traffic in either direction (default 5m)",
+	"config.WebSocketConfig.MaxConnectionsPerNetwork": "Max concurrent connections per network (default 100)",
+	"config.WebhookConfig":                            "WebhookConfig is an alert destination.",
+	"config.WebhookConfig.Type":                       "\"generic\" (default), \"slack\" or \"discord\"",
+	"config.networksFile":                             "networksFile holds the networks last read from networks_file. A file that fails to read or validate leaves the previous networks in place.",
+	"config.networksFile.changed":                     "Closed and replaced when the networks change",
+	"config.networksFileDocument":                     "networksFileDocument is the layout of a networks file. JSON files are read the same way, as JSON is valid YAML.",
+	"config.reference":                                "reference renders config types as commented YAML.",
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envReference matches ${VAR} and ${VAR:-default} in config values, and the
+// $${...} escape for a literal ${...}.
+var envReference = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces environment variable references in every scalar value
+// of a parsed config document. ${VAR} must be set; ${VAR:-default} falls back
+// to default when VAR is unset or empty. Mapping keys and comments are left
+// as they are.
+func expandEnv(node *yaml.Node) error {
+	var errs []error
+
+	var walk func(n *yaml.Node)
+
+	walk = func(n *yaml.Node) {
+		switch n.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range n.Content {
+				walk(child)
+			}
+		case yaml.MappingNode:
+			for i := 1; i < len(n.Content); i += 2 {
+				walk(n.Content[i])
+			}
+		case yaml.ScalarNode:
+			value, err := expandEnvValue(n.Value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", n.Line, err))
+
+				return
+			}
+
+			if value == n.Value {
+				return
+			}
+
+			n.Value = value
+
+			// Let unquoted values resolve to their type again, e.g. port: ${PORT}
+			if n.Style&(yaml.TaggedStyle|yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				n.Tag = ""
+			}
+		case yaml.AliasNode:
+			// Expanded where the anchor is defined
+		}
+	}
+
+	walk(node)
+
+	return errors.Join(errs...)
+}
+
+// expandEnvValue expands the environment variable references of one value.
+func expandEnvValue(value string) (string, error) {
+	var missing []string
+
+	expanded := envReference.ReplaceAllStringFunc(value, func(ref string) string {
+		if ref[1] == '$' {
+			return ref[1:]
+		}
+
+		match := envReference.FindStringSubmatch(ref)
+		name, hasDefault, fallback := match[1], match[2] != "", match[3]
+
+		if v, ok := os.LookupEnv(name); ok && (v != "" || !hasDefault) {
+			return v
+		}
+
+		if hasDefault {
+			return fallback
+		}
+
+		missing = append(missing, name)
+
+		return ref
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", missing[0])
+	}
+
+	return expanded, nil
+}
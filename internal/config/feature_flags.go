@@ -0,0 +1,37 @@
+package config
+
+// FeatureFlags returns the optional backend features turned on in this
+// configuration, sorted by name, so clients can tell which endpoints and
+// behaviours to expect.
+func (c *Config) FeatureFlags() []string {
+	flags := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "aggregate", enabled: c.Aggregate.Enabled},
+		{name: "auth", enabled: c.Auth.Enabled},
+		{name: "compression", enabled: c.Compression.IsEnabled()},
+		{name: "deny_list", enabled: c.DenyList.Enabled},
+		{name: "gas_profiler", enabled: c.GasProfiler.Enabled},
+		{name: "grpc", enabled: c.GRPC.Enabled},
+		{name: "push", enabled: c.Push.Enabled},
+		{name: "rate_limiting", enabled: c.RateLimiting.Enabled},
+		{name: "slo", enabled: c.SLO.Enabled},
+		{name: "summary", enabled: c.Summary.Enabled},
+		{name: "synthetic", enabled: c.Synthetic.Enabled},
+		{name: "table_pages", enabled: c.TablePages.IsEnabled()},
+		{name: "tenants", enabled: len(c.Tenants) > 0},
+		{name: "terms", enabled: c.Terms.Enabled},
+		{name: "tracing", enabled: c.Tracing.Enabled},
+	}
+
+	enabled := make([]string, 0, len(flags))
+
+	for _, flag := range flags {
+		if flag.enabled {
+			enabled = append(enabled, flag.name)
+		}
+	}
+
+	return enabled
+}
@@ -0,0 +1,93 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Webhook formats.
+const (
+	WebhookTypeGeneric = "generic" // The alert as JSON
+	WebhookTypeSlack   = "slack"   // Slack incoming webhook message
+	WebhookTypeDiscord = "discord" // Discord webhook message
+)
+
+// FreshnessAlertsConfig controls alerts for CBT tables whose max bound stops
+// advancing. The leader checks the stored bounds every check_interval and
+// notifies the webhooks once when a table stalls for longer than its
+// network's threshold, and once when it advances again.
+type FreshnessAlertsConfig struct {
+	Enabled       bool                     `yaml:"enabled"`
+	CheckInterval time.Duration            `yaml:"check_interval"` // How often bounds are checked (default 1m)
+	Threshold     time.Duration            `yaml:"threshold"`      // How long a max bound may stand still (default 30m)
+	Networks      map[string]time.Duration `yaml:"networks"`       // Per-network threshold overrides
+	Webhooks      []WebhookConfig          `yaml:"webhooks"`
+}
+
+// WebhookConfig is an alert destination.
+type WebhookConfig struct {
+	Type string `yaml:"type"` // "generic" (default), "slack" or "discord"
+	URL  string `yaml:"url"`
+}
+
+// ThresholdFor returns the threshold of a network.
+func (c *FreshnessAlertsConfig) ThresholdFor(network string) time.Duration {
+	if threshold, ok := c.Networks[network]; ok {
+		return threshold
+	}
+
+	return c.Threshold
+}
+
+// Validate validates the freshness alerts configuration and sets defaults.
+func (c *FreshnessAlertsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.CheckInterval == 0 {
+		c.CheckInterval = time.Minute
+	}
+
+	if c.Threshold == 0 {
+		c.Threshold = 30 * time.Minute
+	}
+
+	// Validate ranges
+	if c.CheckInterval < time.Second {
+		return fmt.Errorf("check_interval must be at least 1 second, got %v", c.CheckInterval)
+	}
+
+	if c.Threshold < c.CheckInterval {
+		return fmt.Errorf("threshold (%v) must be at least check_interval (%v)", c.Threshold, c.CheckInterval)
+	}
+
+	for network, threshold := range c.Networks {
+		if threshold < c.CheckInterval {
+			return fmt.Errorf("networks.%s: threshold (%v) must be at least check_interval (%v)", network, threshold, c.CheckInterval)
+		}
+	}
+
+	for i := range c.Webhooks {
+		webhook := &c.Webhooks[i]
+
+		if webhook.Type == "" {
+			webhook.Type = WebhookTypeGeneric
+		}
+
+		switch webhook.Type {
+		case WebhookTypeGeneric, WebhookTypeSlack, WebhookTypeDiscord:
+		default:
+			return fmt.Errorf("webhooks[%d]: unknown type %q (must be generic, slack or discord)", i, webhook.Type)
+		}
+
+		if _, err := url.ParseRequestURI(webhook.URL); err != nil {
+			return fmt.Errorf("webhooks[%d]: invalid url: %w", i, err)
+		}
+	}
+
+	return nil
+}
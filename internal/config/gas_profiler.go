@@ -5,14 +5,41 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 )
 
 // GasProfilerConfig holds gas profiler simulation service configuration.
 type GasProfilerConfig struct {
-	Enabled        bool                  `yaml:"enabled"`
-	Endpoints      []GasProfilerEndpoint `yaml:"endpoints"`       // List of Erigon RPC endpoints
-	RequestTimeout time.Duration         `yaml:"request_timeout"` // HTTP request timeout for RPC calls
-	HealthInterval time.Duration         `yaml:"health_interval"` // Interval between endpoint health checks (default 30s)
+	Enabled        bool                   `yaml:"enabled"`
+	Endpoints      []GasProfilerEndpoint  `yaml:"endpoints"`       // List of Erigon RPC endpoints
+	RequestTimeout time.Duration          `yaml:"request_timeout"` // HTTP request timeout for RPC calls
+	HealthInterval time.Duration          `yaml:"health_interval"` // Interval between endpoint health checks (default 30s)
+	Strategy       string                 `yaml:"strategy"`        // How requests are spread over a network's healthy endpoints (default "round_robin")
+	Cache          GasProfilerCacheConfig `yaml:"cache"`
+}
+
+// Gas profiler load balancing strategies.
+const (
+	// GasProfilerStrategyRoundRobin takes turns between endpoints.
+	GasProfilerStrategyRoundRobin = "round_robin"
+	// GasProfilerStrategyWeighted takes turns in proportion to endpoint weights.
+	GasProfilerStrategyWeighted = "weighted"
+	// GasProfilerStrategyLeastFailed prefers the endpoint whose last failed
+	// request is the oldest, endpoints that never failed first.
+	GasProfilerStrategyLeastFailed = "least_failed"
+	// GasProfilerStrategyLatency prefers the endpoint with the lowest moving
+	// average of recent request durations.
+	GasProfilerStrategyLatency = "latency"
+)
+
+// GasProfilerCacheConfig holds the Redis cache of simulation results. Results
+// are cached per head block and dropped once a new head is observed.
+type GasProfilerCacheConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	TTL          time.Duration `yaml:"ttl"`           // How long results are kept (default 1h)
+	HeadInterval time.Duration `yaml:"head_interval"` // Interval between head block polls (default 12s)
+	KeyPrefix    string        `yaml:"key_prefix"`    // Redis key prefix (default "lab:gas_profiler:cache:")
 }
 
 // GasProfilerEndpoint defines a single Erigon RPC endpoint.
@@ -20,6 +47,9 @@ type GasProfilerEndpoint struct {
 	Name    string `yaml:"name"`    // Friendly name (e.g., "mainnet-1", "mainnet-2")
 	Network string `yaml:"network"` // Network identifier to match in requests
 	URL     string `yaml:"url"`     // Erigon JSON-RPC URL
+	Weight  int    `yaml:"weight"`  // Share of requests with the weighted strategy (default 1)
+
+	Auth *UpstreamAuthConfig `yaml:"auth,omitempty" json:"-"` // Credentials sent with every RPC call
 }
 
 // Validate validates the gas profiler configuration.
@@ -50,10 +80,28 @@ func (c *GasProfilerConfig) Validate() error {
 		return fmt.Errorf("health_interval must be at least 10 seconds, got %v", c.HealthInterval)
 	}
 
+	// Set default strategy
+	if c.Strategy == "" {
+		c.Strategy = GasProfilerStrategyRoundRobin
+	}
+
+	switch c.Strategy {
+	case GasProfilerStrategyRoundRobin, GasProfilerStrategyWeighted,
+		GasProfilerStrategyLeastFailed, GasProfilerStrategyLatency:
+	default:
+		return fmt.Errorf(
+			"strategy must be one of %s, %s, %s or %s, got %q",
+			GasProfilerStrategyRoundRobin, GasProfilerStrategyWeighted,
+			GasProfilerStrategyLeastFailed, GasProfilerStrategyLatency, c.Strategy,
+		)
+	}
+
 	// Validate each endpoint and check for duplicate names
 	names := make(map[string]bool)
 
-	for i, ep := range c.Endpoints {
+	for i := range c.Endpoints {
+		ep := &c.Endpoints[i]
+
 		if ep.Name == "" {
 			return fmt.Errorf("endpoints[%d].name is required", i)
 		}
@@ -66,6 +114,18 @@ func (c *GasProfilerConfig) Validate() error {
 			return fmt.Errorf("endpoints[%d].url is required", i)
 		}
 
+		if ep.Weight == 0 {
+			ep.Weight = 1
+		}
+
+		if ep.Weight < 0 {
+			return fmt.Errorf("endpoints[%d].weight must be positive, got %d", i, ep.Weight)
+		}
+
+		if err := ep.Auth.Validate(); err != nil {
+			return fmt.Errorf("endpoints[%d].auth: %w", i, err)
+		}
+
 		if names[ep.Name] {
 			return fmt.Errorf("duplicate endpoint name: %s", ep.Name)
 		}
@@ -73,6 +133,41 @@ func (c *GasProfilerConfig) Validate() error {
 		names[ep.Name] = true
 	}
 
+	if err := c.Cache.Validate(); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	return nil
+}
+
+// Validate validates the gas profiler cache configuration.
+func (c *GasProfilerCacheConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.TTL == 0 {
+		c.TTL = time.Hour
+	}
+
+	if c.HeadInterval == 0 {
+		c.HeadInterval = 12 * time.Second
+	}
+
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = "lab:gas_profiler:cache:"
+	}
+
+	// Validate ranges
+	if c.TTL < time.Second {
+		return fmt.Errorf("ttl must be at least 1 second, got %v", c.TTL)
+	}
+
+	if c.HeadInterval < time.Second {
+		return fmt.Errorf("head_interval must be at least 1 second, got %v", c.HeadInterval)
+	}
+
 	return nil
 }
 
@@ -108,6 +203,7 @@ func (c *GasProfilerConfig) GetNetworks() []string {
 // HTTPClient returns a configured HTTP client for RPC requests.
 func (c *GasProfilerConfig) HTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: c.RequestTimeout,
+		Timeout:   c.RequestTimeout,
+		Transport: upstream.NewTransport(upstream.SubsystemGasProfiler, nil),
 	}
 }
@@ -0,0 +1,42 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net"
+)
+
+// GRPCConfig controls the gRPC API, which mirrors the REST config and bounds
+// endpoints on a separate port for other backends.
+type GRPCConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	ListenAddress string `yaml:"listen_address"` // Address the gRPC server listens on (default ":9090")
+	MaxWatchers   int    `yaml:"max_watchers"`   // Max concurrent WatchNetworks streams (default 100)
+}
+
+// Validate validates the gRPC configuration and sets defaults.
+func (c *GRPCConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.ListenAddress == "" {
+		c.ListenAddress = ":9090"
+	}
+
+	if c.MaxWatchers == 0 {
+		c.MaxWatchers = 100
+	}
+
+	// Validate ranges
+	if _, _, err := net.SplitHostPort(c.ListenAddress); err != nil {
+		return fmt.Errorf("invalid listen_address: %w", err)
+	}
+
+	if c.MaxWatchers < 0 {
+		return fmt.Errorf("max_watchers must not be negative, got %d", c.MaxWatchers)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// MaintenanceConfig controls maintenance mode, scheduled by operators through
+// the admin API and stored in Redis so every instance follows it. While it is
+// active, API routes return 503 with the maintenance message and frontend
+// routes a maintenance page. Health probes and admin routes are not affected.
+type MaintenanceConfig struct {
+	Message      string        `yaml:"message"`       // Message returned when a window sets none
+	RetryAfter   time.Duration `yaml:"retry_after"`   // Retry-After for windows without an end (default 5m)
+	PageFile     string        `yaml:"page_file"`     // HTML file served to frontend routes (default: built-in page)
+	RedisKey     string        `yaml:"redis_key"`     // Redis key holding the scheduled window (default "lab:maintenance")
+	PollInterval time.Duration `yaml:"poll_interval"` // How often redis_key is checked (default 5s)
+}
+
+// Validate validates the maintenance configuration and sets defaults.
+func (c *MaintenanceConfig) Validate() error {
+	// Set defaults
+	if c.Message == "" {
+		c.Message = "The lab is down for scheduled maintenance, please try again later"
+	}
+
+	if c.RetryAfter == 0 {
+		c.RetryAfter = 5 * time.Minute
+	}
+
+	if c.RedisKey == "" {
+		c.RedisKey = "lab:maintenance"
+	}
+
+	if c.PollInterval == 0 {
+		c.PollInterval = 5 * time.Second
+	}
+
+	// Validate ranges
+	if c.RetryAfter < time.Second {
+		return fmt.Errorf("retry_after must be at least 1s, got %v", c.RetryAfter)
+	}
+
+	if c.PollInterval < 0 {
+		return fmt.Errorf("poll_interval must be positive, got %v", c.PollInterval)
+	}
+
+	if c.PageFile != "" {
+		if _, err := os.Stat(c.PageFile); err != nil {
+			return fmt.Errorf("page_file: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// NegativeCacheConfig controls the negative cache, which remembers unknown
+// networks and missing frontend assets for a short while, so bot scans and
+// typoed clients don't cause Redis reads and SPA fallbacks on every request.
+type NegativeCacheConfig struct {
+	Enabled    *bool         `yaml:"enabled"`     // Cache misses (default true)
+	TTL        time.Duration `yaml:"ttl"`         // How long a miss is remembered (default 30s)
+	MaxEntries int           `yaml:"max_entries"` // Max misses remembered per cache (default 10000)
+}
+
+// Validate validates the negative cache configuration and sets defaults.
+func (c *NegativeCacheConfig) Validate() error {
+	// Set defaults
+	if c.Enabled == nil {
+		enabled := true
+		c.Enabled = &enabled
+	}
+
+	if c.TTL == 0 {
+		c.TTL = 30 * time.Second
+	}
+
+	if c.MaxEntries == 0 {
+		c.MaxEntries = 10000
+	}
+
+	// Validate ranges
+	if c.TTL < 0 {
+		return fmt.Errorf("ttl must be positive, got %v", c.TTL)
+	}
+
+	if c.MaxEntries < 0 {
+		return fmt.Errorf("max_entries must be positive, got %d", c.MaxEntries)
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether negative caching is on.
+func (c *NegativeCacheConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
@@ -5,6 +5,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	"github.com/sirupsen/logrus"
@@ -22,21 +26,145 @@ type LocalOverridesConfig struct {
 // When used in config.yaml, all fields except Name are optional.
 // Cartographoor values are used as defaults, config.yaml provides overrides.
 type NetworkConfig struct {
-	Name           string                `yaml:"name"`                      // Required: "mainnet", "sepolia", etc.
-	Enabled        *bool                 `yaml:"enabled,omitempty"`         // Optional: Whether this network is active
-	TargetURL      string                `yaml:"target_url,omitempty"`      // Optional: Backend CBT API URL
-	DisplayName    string                `yaml:"display_name,omitempty"`    // Optional: Human-readable name
-	ChainID        *int64                `yaml:"chain_id,omitempty"`        // Optional: Numeric chain ID
-	GenesisTime    *int64                `yaml:"genesis_time,omitempty"`    // Optional: Unix timestamp
-	GenesisDelay   *int64                `yaml:"genesis_delay,omitempty"`   // Optional: Genesis delay in seconds
-	LocalOverrides *LocalOverridesConfig `yaml:"local_overrides,omitempty"` // Optional: Hybrid-mode per-table routing
+	Name           string                `yaml:"name"`                       // Required: "mainnet", "sepolia", etc.
+	Enabled        *bool                 `yaml:"enabled,omitempty"`          // Optional: Whether this network is active
+	TargetURL      string                `yaml:"target_url,omitempty"`       // Optional: Backend CBT API URL
+	TargetURLs     []string              `yaml:"target_urls,omitempty"`      // Optional: Backend CBT API URLs in failover order, the first is target_url
+	DisplayName    string                `yaml:"display_name,omitempty"`     // Optional: Human-readable name
+	ChainID        *int64                `yaml:"chain_id,omitempty"`         // Optional: Numeric chain ID
+	GenesisTime    *int64                `yaml:"genesis_time,omitempty"`     // Optional: Unix timestamp
+	GenesisDelay   *int64                `yaml:"genesis_delay,omitempty"`    // Optional: Genesis delay in seconds
+	LocalOverrides *LocalOverridesConfig `yaml:"local_overrides,omitempty"`  // Optional: Hybrid-mode per-table routing
+	HedgeTargetURL string                `yaml:"hedge_target_url,omitempty"` // Optional: Alternate backend replica for hedged reads
+	MirrorURL      string                `yaml:"mirror_url,omitempty"`       // Optional: Backend receiving a copy of proxied reads, responses discarded
+	Discovery      *DiscoveryConfig      `yaml:"discovery,omitempty"`        // Optional: DNS-based discovery of target_url instances
+	PathMapping    *PathMappingConfig    `yaml:"path_mapping,omitempty"`     // Optional: Upstream path prefix mapping
+	Aliases        []string              `yaml:"aliases,omitempty"`          // Optional: Former names that resolve to this network
+	Hidden         *bool                 `yaml:"hidden,omitempty"`           // Optional: Only listed for requests with a preview token
+	Auth           *UpstreamAuthConfig   `yaml:"auth,omitempty" json:"-"`    // Optional: Credentials sent to target_url, hedge_target_url and mirror_url
+	Retry          *bool                 `yaml:"retry,omitempty"`            // Optional: Retry reads on transient upstream errors (default proxy.retry.enabled)
+	Timeout        time.Duration         `yaml:"timeout,omitempty"`          // Optional: Wait for upstream response headers (default proxy.timeouts.default)
 }
 
+// PathMappingConfig adapts proxied paths for upstreams that serve their API
+// under a non-standard prefix. It is applied to the default rewritten path
+// (/api/v1/{network}/x → /api/v1/x) in order: strip_prefix, rewrites, add_prefix.
+type PathMappingConfig struct {
+	StripPrefix string              `yaml:"strip_prefix,omitempty"` // Prefix removed from the path, e.g. "/api/v1"
+	AddPrefix   string              `yaml:"add_prefix,omitempty"`   // Prefix prepended to the path, e.g. "/cbt/api/v1"
+	Rewrites    []PathRewriteConfig `yaml:"rewrites,omitempty"`     // Regex rewrites applied in order
+}
+
+// PathRewriteConfig is a single regex path rewrite.
+type PathRewriteConfig struct {
+	Match   string `yaml:"match"`   // Regex matched against the path
+	Replace string `yaml:"replace"` // Replacement, may reference groups ($1)
+}
+
+// DiscoveryConfig enables DNS-based discovery of the instances behind a network's
+// target_url hostname (e.g. a Kubernetes headless service or SRV record).
+// Connections are load balanced across all discovered instances.
+type DiscoveryConfig struct {
+	Mode            string        `yaml:"mode"`             // "dns" (all A/AAAA records) or "srv"
+	Service         string        `yaml:"service"`          // SRV service name, e.g. "http" (srv mode only)
+	Protocol        string        `yaml:"protocol"`         // SRV protocol (default "tcp")
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // How often to re-resolve (default 30s)
+}
+
+// ClientIDHeaderName is the request header carrying the stable client
+// identifier feature rollouts are bucketed by.
+const ClientIDHeaderName = "X-Lab-Client-ID"
+
 // FeatureSettings defines settings for a single feature.
 // Features are enabled by default for all networks unless explicitly disabled.
+// Rollout limits a feature on a network to a percentage of clients, bucketed
+// by the X-Lab-Client-ID header; clients without the header are left out
+// until the rollout reaches 100. RequiresFork and per-network rules hold a
+// feature back on a network until a fork or epoch is reached there. Tables
+// are reported by GET /api/v1/availability.
 type FeatureSettings struct {
-	Path             string   `yaml:"path"`                        // Feature path (e.g., "/ethereum/data-availability/das-custody")
-	DisabledNetworks []string `yaml:"disabled_networks,omitempty"` // Networks where this feature is disabled
+	Path             string         `yaml:"path"`                        // Feature path (e.g., "/ethereum/data-availability/das-custody")
+	DisabledNetworks []string       `yaml:"disabled_networks,omitempty"` // Networks where this feature is disabled
+	Rollout          map[string]int `yaml:"rollout,omitempty"`           // Network name to percentage of clients (0-100) the feature is enabled for
+	RequiresFork     string         `yaml:"requires_fork,omitempty"`     // Consensus fork (e.g. "electra") that must be active on a network
+	Tables           []string       `yaml:"tables,omitempty"`            // CBT tables the feature reads; it is only available where all have data
+
+	Networks map[string]FeatureNetworkRule `yaml:"networks,omitempty"` // Per-network requirements, replacing requires_fork
+}
+
+// FeatureNetworkRule is what a network must reach before a feature is
+// enabled there. The zero value has no requirements.
+type FeatureNetworkRule struct {
+	RequiresFork string `yaml:"requires_fork,omitempty"` // Consensus fork that must be active
+	MinEpoch     *int64 `yaml:"min_epoch,omitempty"`     // Epoch the network must have reached
+}
+
+// IsZero reports whether the rule has no requirements.
+func (r FeatureNetworkRule) IsZero() bool {
+	return r.RequiresFork == "" && r.MinEpoch == nil
+}
+
+// Validate validates a feature configuration.
+func (f *FeatureSettings) Validate() error {
+	if f.Path == "" {
+		return fmt.Errorf("feature path cannot be empty")
+	}
+
+	for network, percent := range f.Rollout {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("rollout.%s must be between 0 and 100, got %d", network, percent)
+		}
+	}
+
+	for network, rule := range f.Networks {
+		if rule.MinEpoch != nil && *rule.MinEpoch < 0 {
+			return fmt.Errorf("networks.%s.min_epoch must not be negative, got %d", network, *rule.MinEpoch)
+		}
+	}
+
+	for i, table := range f.Tables {
+		if table == "" {
+			return fmt.Errorf("tables[%d] cannot be empty", i)
+		}
+	}
+
+	return nil
+}
+
+// RolloutPercent returns the percentage of clients the feature is enabled for
+// on network, 100 for networks without a rollout.
+func (f *FeatureSettings) RolloutPercent(network string) int {
+	if percent, ok := f.Rollout[network]; ok {
+		return percent
+	}
+
+	return 100
+}
+
+// Requirement returns what network must reach before the feature is enabled
+// there: its own rule if it has one, otherwise requires_fork.
+func (f *FeatureSettings) Requirement(network string) FeatureNetworkRule {
+	if rule, ok := f.Networks[network]; ok {
+		return rule
+	}
+
+	return FeatureNetworkRule{RequiresFork: f.RequiresFork}
+}
+
+// HasRequirements reports whether the feature waits for a fork or epoch on
+// any network.
+func (f *FeatureSettings) HasRequirements() bool {
+	if f.RequiresFork != "" {
+		return true
+	}
+
+	for _, rule := range f.Networks {
+		if !rule.IsZero() {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Validate validates a network configuration.
@@ -51,6 +179,54 @@ func (n *NetworkConfig) Validate() error {
 		return nil
 	}
 
+	// Validate discovery if set (may apply to a cartographoor-provided target_url)
+	if err := n.validateDiscovery(); err != nil {
+		return err
+	}
+
+	// Validate path mapping if set (may apply to a cartographoor-provided target_url)
+	if err := n.validatePathMapping(); err != nil {
+		return err
+	}
+
+	// Validate auth if set (may apply to a cartographoor-provided target_url)
+	if err := n.Auth.Validate(); err != nil {
+		return fmt.Errorf("network %s: auth: %w", n.Name, err)
+	}
+
+	if n.Timeout < 0 {
+		return fmt.Errorf("network %s: timeout must not be negative, got %v", n.Name, n.Timeout)
+	}
+
+	// Validate hedge_target_url if set
+	if n.HedgeTargetURL != "" {
+		hedgeURL, err := url.Parse(n.HedgeTargetURL)
+		if err != nil {
+			return fmt.Errorf("network %s: invalid hedge_target_url: %w", n.Name, err)
+		}
+
+		if hedgeURL.Scheme != "http" && hedgeURL.Scheme != "https" {
+			return fmt.Errorf("network %s: hedge_target_url must use http or https scheme", n.Name)
+		}
+	}
+
+	// Validate mirror_url if set
+	if n.MirrorURL != "" {
+		mirrorURL, err := url.Parse(n.MirrorURL)
+		if err != nil {
+			return fmt.Errorf("network %s: invalid mirror_url: %w", n.Name, err)
+		}
+
+		if mirrorURL.Scheme != "http" && mirrorURL.Scheme != "https" {
+			return fmt.Errorf("network %s: mirror_url must use http or https scheme", n.Name)
+		}
+	}
+
+	// Validate target_urls if set, and take target_url from it
+	if err := n.validateTargetURLs(); err != nil {
+		return err
+	}
+
 	// If target_url is not set, it's expected to come from cartographoor
 	if n.TargetURL == "" {
 		return nil
@@ -74,6 +250,43 @@ func (n *NetworkConfig) Validate() error {
 	return nil
 }
 
+// validateTargetURLs validates the TargetURLs list if present. Its first entry
+// becomes TargetURL, which every other consumer of the network uses.
+func (n *NetworkConfig) validateTargetURLs() error {
+	if len(n.TargetURLs) == 0 {
+		return nil
+	}
+
+	if n.TargetURL == "" {
+		n.TargetURL = n.TargetURLs[0]
+	}
+
+	if n.TargetURL != n.TargetURLs[0] {
+		return fmt.Errorf("network %s: target_url must be the first of target_urls when both are set", n.Name)
+	}
+
+	if n.Discovery != nil && len(n.TargetURLs) > 1 {
+		return fmt.Errorf("network %s: discovery cannot be combined with several target_urls", n.Name)
+	}
+
+	for i, targetURL := range n.TargetURLs {
+		parsedURL, err := url.Parse(targetURL)
+		if err != nil {
+			return fmt.Errorf("network %s: invalid target_urls[%d]: %w", n.Name, i, err)
+		}
+
+		if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+			return fmt.Errorf("network %s: target_urls[%d] must use http or https scheme", n.Name, i)
+		}
+
+		if slices.Contains(n.TargetURLs[:i], targetURL) {
+			return fmt.Errorf("network %s: duplicate target_urls entry %s", n.Name, targetURL)
+		}
+	}
+
+	return nil
+}
+
 // validateLocalOverrides validates the LocalOverrides config if present.
 func (n *NetworkConfig) validateLocalOverrides() error {
 	if n.LocalOverrides == nil {
@@ -112,7 +325,120 @@ func (n *NetworkConfig) validateLocalOverrides() error {
 	return nil
 }
 
-// GetNetworkByName looks up a network by name.
+// validatePathMapping validates the PathMapping config if present.
+func (n *NetworkConfig) validatePathMapping() error {
+	if n.PathMapping == nil {
+		return nil
+	}
+
+	if prefix := n.PathMapping.StripPrefix; prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("network %s: path_mapping.strip_prefix must start with /, got %q", n.Name, prefix)
+	}
+
+	if prefix := n.PathMapping.AddPrefix; prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("network %s: path_mapping.add_prefix must start with /, got %q", n.Name, prefix)
+	}
+
+	for i, rewrite := range n.PathMapping.Rewrites {
+		if rewrite.Match == "" {
+			return fmt.Errorf("network %s: path_mapping.rewrites[%d].match cannot be empty", n.Name, i)
+		}
+
+		if _, err := regexp.Compile(rewrite.Match); err != nil {
+			return fmt.Errorf("network %s: path_mapping.rewrites[%d] invalid regex: %w", n.Name, i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateDiscovery validates the Discovery config if present and sets defaults.
+func (n *NetworkConfig) validateDiscovery() error {
+	if n.Discovery == nil {
+		return nil
+	}
+
+	if n.Discovery.Mode == "" {
+		n.Discovery.Mode = "dns"
+	}
+
+	if n.Discovery.Protocol == "" {
+		n.Discovery.Protocol = "tcp"
+	}
+
+	if n.Discovery.RefreshInterval == 0 {
+		n.Discovery.RefreshInterval = 30 * time.Second
+	}
+
+	switch n.Discovery.Mode {
+	case "dns":
+	case "srv":
+		if n.Discovery.Service == "" {
+			return fmt.Errorf("network %s: discovery.service is required in srv mode", n.Name)
+		}
+	default:
+		return fmt.Errorf("network %s: discovery.mode must be 'dns' or 'srv'", n.Name)
+	}
+
+	if n.Discovery.RefreshInterval < time.Second {
+		return fmt.Errorf(
+			"network %s: discovery.refresh_interval must be at least 1 second, got %v",
+			n.Name, n.Discovery.RefreshInterval,
+		)
+	}
+
+	return nil
+}
+
+// reservedPathSegments are /api/v1/{segment} routes served by lab-backend
+// itself, which a network alias must not shadow.
+var reservedPathSegments = []string{"config", "admin", "bounds", "terms", "gas-profiler", "ws", "version"}
+
+// validateNetworkAliases checks that aliases are unique, valid path segments
+// and do not collide with network names or reserved routes.
+func (c *Config) validateNetworkAliases(networkNames map[string]bool) error {
+	seen := make(map[string]string)
+
+	for _, network := range c.Networks {
+		for _, alias := range network.Aliases {
+			if alias == "" || strings.ContainsAny(alias, "/?#") {
+				return fmt.Errorf("network %s: invalid alias %q", network.Name, alias)
+			}
+
+			if networkNames[alias] {
+				return fmt.Errorf("network %s: alias %q conflicts with a network name", network.Name, alias)
+			}
+
+			if slices.Contains(reservedPathSegments, alias) {
+				return fmt.Errorf("network %s: alias %q is a reserved path", network.Name, alias)
+			}
+
+			if owner, exists := seen[alias]; exists {
+				return fmt.Errorf("network %s: alias %q is already used by network %s", network.Name, alias, owner)
+			}
+
+			seen[alias] = network.Name
+		}
+	}
+
+	return nil
+}
+
+// NetworkAliases returns a map of alias → canonical network name.
+func (c *Config) NetworkAliases() map[string]string {
+	aliases := make(map[string]string)
+
+	for _, network := range c.Networks {
+		for _, alias := range network.Aliases {
+			aliases[alias] = network.Name
+		}
+	}
+
+	return aliases
+}
+
+// GetNetworkByName looks up a network by name, in config.yaml and then in
+// networks_file.
 func (c *Config) GetNetworkByName(name string) (*NetworkConfig, error) {
 	for i := range c.Networks {
 		if c.Networks[i].Name == name {
@@ -120,13 +446,37 @@ func (c *Config) GetNetworkByName(name string) (*NetworkConfig, error) {
 		}
 	}
 
+	for _, network := range c.networksFile.get() {
+		if network.Name == name {
+			return &network, nil
+		}
+	}
+
 	return nil, fmt.Errorf("network not found: %s", name)
 }
 
+// IsHidden reports whether the network is soft-launched and only visible
+// to requests carrying a preview token.
+func (n *NetworkConfig) IsHidden() bool {
+	return n.Hidden != nil && *n.Hidden
+}
+
+// RetryEnabled reports whether proxied reads to the network are retried,
+// falling back to def when the network has no retry setting.
+func (n *NetworkConfig) RetryEnabled(def bool) bool {
+	if n.Retry == nil {
+		return def
+	}
+
+	return *n.Retry
+}
+
 // GetEnabledNetworks returns only enabled networks.
 func (c *Config) GetEnabledNetworks() []NetworkConfig {
-	enabled := make([]NetworkConfig, 0, len(c.Networks))
-	for _, network := range c.Networks {
+	configured := c.ConfiguredNetworks()
+
+	enabled := make([]NetworkConfig, 0, len(configured))
+	for _, network := range configured {
 		// If Enabled is not set (nil), default to true
 		// If Enabled is set, use its value
 		if network.Enabled == nil || *network.Enabled {
@@ -139,6 +489,7 @@ func (c *Config) GetEnabledNetworks() []NetworkConfig {
 
 // BuildMergedNetworkList creates merged network list: cartographoor base + config.yaml overlay.
 // Priority: cartographoor is the source of truth, config.yaml provides overrides.
+// Networks from networks_file are overlaid like config.yaml entries.
 // Cartographoor provider already filters for healthy networks, so this just merges data.
 func BuildMergedNetworkList(
 	ctx context.Context,
@@ -165,8 +516,8 @@ func BuildMergedNetworkList(
 		}
 	}
 
-	// Step 2: Apply config.yaml overrides and additions
-	for _, configNet := range cfg.Networks {
+	// Step 2: Apply config.yaml and networks_file overrides and additions
+	for _, configNet := range cfg.ConfiguredNetworks() {
 		if existing, exists := networks[configNet.Name]; exists {
 			// Override cartographoor network with config.yaml values
 			// Only override fields that are explicitly set in config.yaml.
@@ -178,6 +529,10 @@ func BuildMergedNetworkList(
 				existing.TargetURL = configNet.TargetURL
 			}
 
+			if len(configNet.TargetURLs) > 0 {
+				existing.TargetURLs = configNet.TargetURLs
+			}
+
 			if configNet.DisplayName != "" {
 				existing.DisplayName = configNet.DisplayName
 			}
@@ -198,6 +553,42 @@ func BuildMergedNetworkList(
 				existing.LocalOverrides = configNet.LocalOverrides
 			}
 
+			if configNet.HedgeTargetURL != "" {
+				existing.HedgeTargetURL = configNet.HedgeTargetURL
+			}
+
+			if configNet.MirrorURL != "" {
+				existing.MirrorURL = configNet.MirrorURL
+			}
+
+			if configNet.Discovery != nil {
+				existing.Discovery = configNet.Discovery
+			}
+
+			if configNet.PathMapping != nil {
+				existing.PathMapping = configNet.PathMapping
+			}
+
+			if len(configNet.Aliases) > 0 {
+				existing.Aliases = configNet.Aliases
+			}
+
+			if configNet.Hidden != nil {
+				existing.Hidden = configNet.Hidden
+			}
+
+			if configNet.Auth != nil {
+				existing.Auth = configNet.Auth
+			}
+
+			if configNet.Retry != nil {
+				existing.Retry = configNet.Retry
+			}
+
+			if configNet.Timeout != 0 {
+				existing.Timeout = configNet.Timeout
+			}
+
 			networks[configNet.Name] = existing
 		} else {
 			// Add standalone network (not in cartographoor)
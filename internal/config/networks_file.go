@@ -0,0 +1,224 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+// networksFilePollInterval is how often the networks file is checked for changes.
+const networksFilePollInterval = 10 * time.Second
+
+// networksFileDocument is the layout of a networks file. JSON files are read
+// the same way, as JSON is valid YAML.
+type networksFileDocument struct {
+	Networks []NetworkConfig `yaml:"networks"`
+}
+
+// networksFile holds the networks last read from networks_file. A file that
+// fails to read or validate leaves the previous networks in place.
+type networksFile struct {
+	path string
+
+	mu       sync.RWMutex
+	networks []NetworkConfig
+	modTime  time.Time
+	size     int64
+	changed  chan struct{} // Closed and replaced when the networks change
+}
+
+// loadNetworksFile reads and validates the networks file at path.
+func loadNetworksFile(path string) (*networksFile, error) {
+	f := &networksFile{path: path, changed: make(chan struct{})}
+
+	if _, err := f.reload(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// reload reads the file again if its modification time or size changed,
+// reporting whether its networks were replaced.
+func (f *networksFile) reload() (bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat networks file: %w", err)
+	}
+
+	f.mu.RLock()
+	unchanged := info.ModTime().Equal(f.modTime) && info.Size() == f.size
+	f.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	networks, err := readNetworksFile(f.path)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Not read again until it changes, a broken file is reported once
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	if err != nil {
+		return false, err
+	}
+
+	f.networks = networks
+
+	close(f.changed)
+	f.changed = make(chan struct{})
+
+	return true, nil
+}
+
+// readNetworksFile parses and validates the networks in the file at path.
+func readNetworksFile(path string) ([]NetworkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read networks file: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse networks file: %w", err)
+	}
+
+	// Empty file
+	if document.Kind == 0 {
+		return nil, nil
+	}
+
+	if err := expandEnv(&document); err != nil {
+		return nil, fmt.Errorf("failed to expand environment variables: %w", err)
+	}
+
+	var doc networksFileDocument
+	if err := document.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse networks file: %w", err)
+	}
+
+	names := make(map[string]bool, len(doc.Networks))
+
+	for i := range doc.Networks {
+		network := &doc.Networks[i]
+
+		if err := network.Validate(); err != nil {
+			return nil, fmt.Errorf("network %d: %w", i, err)
+		}
+
+		// Aliases are routed from startup on, they can't come and go with the file
+		if len(network.Aliases) > 0 {
+			return nil, fmt.Errorf("network %s: aliases are only supported in config.yaml", network.Name)
+		}
+
+		if names[network.Name] {
+			return nil, fmt.Errorf("duplicate network name: %s", network.Name)
+		}
+
+		names[network.Name] = true
+	}
+
+	return doc.Networks, nil
+}
+
+// get returns the networks last read from the file.
+func (f *networksFile) get() []NetworkConfig {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.networks
+}
+
+// ConfiguredNetworks returns the networks of networks_file followed by those
+// of config.yaml. A config.yaml entry replaces a file entry of the same name.
+func (c *Config) ConfiguredNetworks() []NetworkConfig {
+	fileNetworks := c.networksFile.get()
+	if len(fileNetworks) == 0 {
+		return c.Networks
+	}
+
+	names := make(map[string]bool, len(c.Networks))
+	for _, network := range c.Networks {
+		names[network.Name] = true
+	}
+
+	networks := make([]NetworkConfig, 0, len(fileNetworks)+len(c.Networks))
+
+	for _, network := range fileNetworks {
+		if !names[network.Name] {
+			networks = append(networks, network)
+		}
+	}
+
+	return append(networks, c.Networks...)
+}
+
+// NetworksFileChanged returns a channel that is closed the next time the
+// networks of networks_file change. Nil without a networks file.
+func (c *Config) NetworksFileChanged() <-chan struct{} {
+	if c.networksFile == nil {
+		return nil
+	}
+
+	c.networksFile.mu.RLock()
+	defer c.networksFile.mu.RUnlock()
+
+	return c.networksFile.changed
+}
+
+// WatchNetworksFile polls networks_file for changes until ctx is done.
+// Changed files are read and validated again; a broken file is logged and
+// the networks read before it stay in use.
+func (c *Config) WatchNetworksFile(ctx context.Context, logger logrus.FieldLogger) {
+	if c.networksFile == nil {
+		return
+	}
+
+	log := logger.WithFields(logrus.Fields{"component": "networks_file", "path": c.NetworksFile})
+
+	task := tasks.Default().Register("config.networks_file", networksFilePollInterval)
+	defer tasks.Default().Unregister(task)
+
+	task.Supervise(log, ctx.Done(), func() {
+		ticker := jitter.NewTicker(networksFilePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = task.Run(func() error {
+					changed, err := c.networksFile.reload()
+					if err != nil {
+						log.WithError(err).Error("Failed to reload networks file, keeping previous networks")
+
+						return err
+					}
+
+					if changed {
+						log.WithField("networks", len(c.networksFile.get())).Info("Reloaded networks file")
+					}
+
+					return nil
+				})
+			}
+		}
+	})
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -414,6 +416,39 @@ func TestNetworkConfig_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid path mapping",
+			config: NetworkConfig{
+				Name:      "mainnet",
+				TargetURL: "https://example.com",
+				PathMapping: &PathMappingConfig{
+					StripPrefix: "/api/v1",
+					AddPrefix:   "/cbt",
+					Rewrites:    []PathRewriteConfig{{Match: "^/fct_(.*)", Replace: "/tables/fct_$1"}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "path mapping prefix without leading slash returns error",
+			config: NetworkConfig{
+				Name:        "mainnet",
+				TargetURL:   "https://example.com",
+				PathMapping: &PathMappingConfig{AddPrefix: "cbt"},
+			},
+			expectError: true,
+			errorMsg:    "path_mapping.add_prefix must start with /",
+		},
+		{
+			name: "path mapping invalid regex returns error",
+			config: NetworkConfig{
+				Name:        "mainnet",
+				TargetURL:   "https://example.com",
+				PathMapping: &PathMappingConfig{Rewrites: []PathRewriteConfig{{Match: "("}}},
+			},
+			expectError: true,
+			errorMsg:    "path_mapping.rewrites[0] invalid regex",
+		},
 		{
 			name: "valid local overrides",
 			config: NetworkConfig{
@@ -452,6 +487,42 @@ func TestNetworkConfig_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "local_overrides.tables cannot be empty",
 		},
+		{
+			name: "target_urls is valid",
+			config: NetworkConfig{
+				Name:       "mainnet",
+				TargetURLs: []string{"https://a.example.com", "https://b.example.com"},
+			},
+			expectError: false,
+		},
+		{
+			name: "target_url not first of target_urls returns error",
+			config: NetworkConfig{
+				Name:       "mainnet",
+				TargetURL:  "https://b.example.com",
+				TargetURLs: []string{"https://a.example.com", "https://b.example.com"},
+			},
+			expectError: true,
+			errorMsg:    "target_url must be the first of target_urls",
+		},
+		{
+			name: "duplicate target_urls returns error",
+			config: NetworkConfig{
+				Name:       "mainnet",
+				TargetURLs: []string{"https://a.example.com", "https://a.example.com"},
+			},
+			expectError: true,
+			errorMsg:    "duplicate target_urls entry",
+		},
+		{
+			name: "target_urls with invalid URL scheme returns error",
+			config: NetworkConfig{
+				Name:       "mainnet",
+				TargetURLs: []string{"https://a.example.com", "ftp://b.example.com"},
+			},
+			expectError: true,
+			errorMsg:    "target_urls[1] must use http or https",
+		},
 		{
 			name: "local overrides with invalid URL scheme returns error",
 			config: NetworkConfig{
@@ -483,3 +554,148 @@ func TestNetworkConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ValidateNetworkAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks []NetworkConfig
+		errorMsg string
+	}{
+		{
+			name: "valid aliases",
+			networks: []NetworkConfig{
+				{Name: "devnet-5", Aliases: []string{"devnet-4", "devnet-3"}},
+				{Name: "mainnet"},
+			},
+		},
+		{
+			name: "alias conflicts with network name",
+			networks: []NetworkConfig{
+				{Name: "devnet-5", Aliases: []string{"mainnet"}},
+				{Name: "mainnet"},
+			},
+			errorMsg: "conflicts with a network name",
+		},
+		{
+			name: "alias used twice",
+			networks: []NetworkConfig{
+				{Name: "devnet-5", Aliases: []string{"devnet-4"}},
+				{Name: "devnet-6", Aliases: []string{"devnet-4"}},
+			},
+			errorMsg: "already used by network devnet-5",
+		},
+		{
+			name:     "alias is a reserved path",
+			networks: []NetworkConfig{{Name: "devnet-5", Aliases: []string{"admin"}}},
+			errorMsg: "reserved path",
+		},
+		{
+			name:     "alias with slash",
+			networks: []NetworkConfig{{Name: "devnet-5", Aliases: []string{"a/b"}}},
+			errorMsg: "invalid alias",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Networks: tt.networks}
+
+			names := make(map[string]bool)
+			for _, network := range tt.networks {
+				names[network.Name] = true
+			}
+
+			err := cfg.validateNetworkAliases(names)
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestConfig_NetworkAliases(t *testing.T) {
+	cfg := &Config{Networks: []NetworkConfig{
+		{Name: "devnet-5", Aliases: []string{"devnet-4", "devnet-3"}},
+		{Name: "mainnet"},
+	}}
+
+	assert.Equal(t, map[string]string{
+		"devnet-4": "devnet-5",
+		"devnet-3": "devnet-5",
+	}, cfg.NetworkAliases())
+}
+
+func TestConfig_NetworksFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "networks.yaml")
+
+	write := func(content string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	now := time.Now()
+
+	write(`
+networks:
+  - name: devnet-1
+    target_url: http://devnet-1:8080
+  - name: devnet-2
+    target_url: http://devnet-2:8080
+`, now)
+
+	networksFile, err := loadNetworksFile(path)
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Networks:     []NetworkConfig{{Name: "devnet-2", TargetURL: "http://override:8080"}},
+		networksFile: networksFile,
+	}
+
+	// config.yaml entries replace file entries of the same name
+	networks := cfg.ConfiguredNetworks()
+	require.Len(t, networks, 2)
+	assert.Equal(t, "devnet-1", networks[0].Name)
+	assert.Equal(t, "http://override:8080", networks[1].TargetURL)
+
+	network, err := cfg.GetNetworkByName("devnet-1")
+	require.NoError(t, err)
+	assert.Equal(t, "http://devnet-1:8080", network.TargetURL)
+
+	changed := cfg.NetworksFileChanged()
+
+	// A broken file keeps the previous networks
+	write("networks: [{name: devnet-3, target_url: ftp://devnet-3}]", now.Add(time.Second))
+
+	_, err = networksFile.reload()
+	require.Error(t, err)
+	assert.Len(t, cfg.ConfiguredNetworks(), 2)
+
+	// Unchanged files are not read again
+	reloaded, err := networksFile.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	write(`{"networks": [{"name": "devnet-3", "target_url": "http://devnet-3:8080"}]}`, now.Add(2*time.Second))
+
+	reloaded, err = networksFile.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	select {
+	case <-changed:
+	default:
+		t.Fatal("expected networks file change notification")
+	}
+
+	networks = cfg.ConfiguredNetworks()
+	require.Len(t, networks, 2)
+	assert.Equal(t, "devnet-3", networks[0].Name)
+
+	_, err = cfg.GetNetworkByName("devnet-1")
+	require.Error(t, err)
+}
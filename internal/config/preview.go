@@ -0,0 +1,40 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// PreviewHeaderName is the request header carrying a preview token.
+const PreviewHeaderName = "X-Lab-Preview-Token"
+
+// PreviewConfig controls access to hidden (soft-launched) networks.
+// Hidden networks are left out of /api/v1/config and the config injected into
+// the frontend unless the request carries one of the preview tokens, either in
+// the X-Lab-Preview-Token header or the preview cookie. Opening any frontend
+// page with ?preview=<token> sets the cookie.
+type PreviewConfig struct {
+	Tokens     []string `yaml:"tokens"`      // Accepted preview tokens
+	CookieName string   `yaml:"cookie_name"` // Cookie carrying a token (default "lab_preview")
+}
+
+// Validate validates the preview configuration and sets defaults.
+func (c *PreviewConfig) Validate() error {
+	// Set defaults
+	if c.CookieName == "" {
+		c.CookieName = "lab_preview"
+	}
+
+	if (&http.Cookie{Name: c.CookieName, Value: "x"}).Valid() != nil {
+		return fmt.Errorf("cookie_name is not a valid cookie name: %q", c.CookieName)
+	}
+
+	for i, token := range c.Tokens {
+		if len(token) < 16 {
+			return fmt.Errorf("tokens[%d] must be at least 16 characters", i)
+		}
+	}
+
+	return nil
+}
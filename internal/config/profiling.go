@@ -0,0 +1,68 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// Supported profile types for continuous profiling.
+var validProfileTypes = []string{"cpu", "heap", "goroutine"}
+
+// ProfilingConfig controls continuous profiling.
+// Profiles are pushed to a Pyroscope-compatible ingest endpoint. Backends that
+// scrape instead (e.g. Parca) can use expose_pprof to serve /debug/pprof.
+type ProfilingConfig struct {
+	Enabled           bool              `yaml:"enabled"`
+	ServerAddress     string            `yaml:"server_address"`      // Pyroscope server base URL
+	ApplicationName   string            `yaml:"application_name"`    // Application name profiles are stored under (default "lab-backend")
+	UploadInterval    time.Duration     `yaml:"upload_interval"`     // How often profiles are pushed; also the CPU profile duration (default 15s)
+	ProfileTypes      []string          `yaml:"profile_types"`       // cpu, heap, goroutine (default cpu, heap)
+	Labels            map[string]string `yaml:"labels"`              // Static labels attached to every profile
+	TenantID          string            `yaml:"tenant_id"`           // Optional X-Scope-OrgID for multi-tenant backends
+	BasicAuthUser     string            `yaml:"basic_auth_user"`     // Optional basic auth user
+	BasicAuthPassword string            `yaml:"basic_auth_password"` // Optional basic auth password
+	ExposePprof       bool              `yaml:"expose_pprof"`        // Serve net/http/pprof under /debug/pprof for scraping backends
+}
+
+// Validate validates the profiling configuration and sets defaults.
+func (c *ProfilingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.ApplicationName == "" {
+		c.ApplicationName = "lab-backend"
+	}
+
+	if c.UploadInterval == 0 {
+		c.UploadInterval = 15 * time.Second
+	}
+
+	if len(c.ProfileTypes) == 0 {
+		c.ProfileTypes = []string{"cpu", "heap"}
+	}
+
+	if c.ServerAddress == "" {
+		return fmt.Errorf("server_address is required when enabled")
+	}
+
+	if _, err := url.ParseRequestURI(c.ServerAddress); err != nil {
+		return fmt.Errorf("invalid server_address: %w", err)
+	}
+
+	if c.UploadInterval < 5*time.Second {
+		return fmt.Errorf("upload_interval must be at least 5 seconds, got %v", c.UploadInterval)
+	}
+
+	for _, profileType := range c.ProfileTypes {
+		if !slices.Contains(validProfileTypes, profileType) {
+			return fmt.Errorf("unsupported profile type %q (valid: %v)", profileType, validProfileTypes)
+		}
+	}
+
+	return nil
+}
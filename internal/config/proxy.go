@@ -0,0 +1,267 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultOutboundStripHeaders are the inbound headers removed before proxying
+// when outbound_headers.strip is not configured.
+var DefaultOutboundStripHeaders = []string{
+	"Cookie",
+	"Authorization",
+	"Proxy-Authorization",
+	"Cf-Access-Jwt-Assertion",
+	"Cf-Access-Client-Id",
+	"Cf-Access-Client-Secret",
+	"X-Lab-Terms-Token",
+	PreviewHeaderName,
+}
+
+// Network alias handling modes.
+const (
+	AliasModeRedirect = "redirect" // 308 redirect to the canonical network path
+	AliasModeRewrite  = "rewrite"  // Serve the canonical network transparently
+)
+
+// ProxyConfig holds settings for the network reverse proxy.
+type ProxyConfig struct {
+	Hedging         HedgingConfig          `yaml:"hedging"`
+	WebSocket       WebSocketConfig        `yaml:"websocket"`
+	OutboundHeaders OutboundHeadersConfig  `yaml:"outbound_headers"`
+	AliasMode       string                 `yaml:"alias_mode"` // How network alias requests are served: "redirect" (default) or "rewrite"
+	SlotTransform   SlotTransformConfig    `yaml:"slot_transform"`
+	QueryValidation QueryValidationConfig  `yaml:"query_validation"`
+	Transforms      TransformsConfig       `yaml:"transforms"`
+	Failover        FailoverConfig         `yaml:"failover"`
+	HealthProbe     HealthProbeConfig      `yaml:"health_probe"`
+	Retry           RetryConfig            `yaml:"retry"`
+	Timeouts        UpstreamTimeoutsConfig `yaml:"timeouts"`
+	Retirement      RetirementConfig       `yaml:"retirement"`
+	Canary          CanaryConfig           `yaml:"canary"`
+	Mirroring       MirroringConfig        `yaml:"mirroring"`
+}
+
+// OutboundHeadersConfig controls which headers are forwarded to upstream backends.
+// Sensitive inbound headers are stripped so client credentials never reach
+// third-party backends, and required upstream headers are injected.
+type OutboundHeadersConfig struct {
+	Strip []string          `yaml:"strip"` // Inbound headers removed before forwarding (default: cookies, auth and Cloudflare Access tokens; [] keeps all)
+	Set   map[string]string `yaml:"set"`   // Headers set on every upstream request, replacing inbound values
+}
+
+// HedgingConfig controls hedged requests for latency-sensitive proxied reads.
+// When the primary upstream has not responded within a percentile-based delay,
+// a second request is sent to the network's hedge_target_url and whichever
+// response arrives first is used.
+type HedgingConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	PathPatterns []string      `yaml:"path_patterns"` // Regex patterns for hedge-eligible paths (empty = all GET requests)
+	Percentile   float64       `yaml:"percentile"`    // Primary latency percentile used as hedge delay (default 0.95)
+	MinDelay     time.Duration `yaml:"min_delay"`     // Lower bound for the hedge delay (default 50ms)
+	MaxDelay     time.Duration `yaml:"max_delay"`     // Upper bound for the hedge delay (default 2s)
+	BudgetRatio  float64       `yaml:"budget_ratio"`  // Max fraction of eligible requests that may be hedged (default 0.05)
+	MaxInFlight  int           `yaml:"max_in_flight"` // Max concurrent hedge requests across all networks (default 10)
+}
+
+// WebSocketConfig controls passthrough of WebSocket upgrade requests to
+// network backends. When disabled, upgrade requests are rejected.
+type WebSocketConfig struct {
+	Enabled                  bool          `yaml:"enabled"`
+	MaxConnectionsPerNetwork int           `yaml:"max_connections_per_network"` // Max concurrent connections per network (default 100)
+	IdleTimeout              time.Duration `yaml:"idle_timeout"`                // Close connections with no traffic in either direction (default 5m)
+}
+
+// Validate validates the proxy configuration and sets defaults.
+func (c *ProxyConfig) Validate() error {
+	if err := c.Hedging.Validate(); err != nil {
+		return fmt.Errorf("hedging: %w", err)
+	}
+
+	if err := c.WebSocket.Validate(); err != nil {
+		return fmt.Errorf("websocket: %w", err)
+	}
+
+	if err := c.OutboundHeaders.Validate(); err != nil {
+		return fmt.Errorf("outbound_headers: %w", err)
+	}
+
+	if err := c.SlotTransform.Validate(); err != nil {
+		return fmt.Errorf("slot_transform: %w", err)
+	}
+
+	if err := c.QueryValidation.Validate(); err != nil {
+		return fmt.Errorf("query_validation: %w", err)
+	}
+
+	if err := c.Transforms.Validate(); err != nil {
+		return fmt.Errorf("transforms: %w", err)
+	}
+
+	if err := c.Failover.Validate(); err != nil {
+		return fmt.Errorf("failover: %w", err)
+	}
+
+	if err := c.HealthProbe.Validate(); err != nil {
+		return fmt.Errorf("health_probe: %w", err)
+	}
+
+	if err := c.Retry.Validate(); err != nil {
+		return fmt.Errorf("retry: %w", err)
+	}
+
+	if err := c.Timeouts.Validate(); err != nil {
+		return fmt.Errorf("timeouts: %w", err)
+	}
+
+	if err := c.Retirement.Validate(); err != nil {
+		return fmt.Errorf("retirement: %w", err)
+	}
+
+	if err := c.Canary.Validate(); err != nil {
+		return fmt.Errorf("canary: %w", err)
+	}
+
+	if err := c.Mirroring.Validate(); err != nil {
+		return fmt.Errorf("mirroring: %w", err)
+	}
+
+	if c.AliasMode == "" {
+		c.AliasMode = AliasModeRedirect
+	}
+
+	if c.AliasMode != AliasModeRedirect && c.AliasMode != AliasModeRewrite {
+		return fmt.Errorf("alias_mode must be %q or %q, got %q", AliasModeRedirect, AliasModeRewrite, c.AliasMode)
+	}
+
+	return nil
+}
+
+// Validate validates the outbound header policy and sets defaults.
+func (c *OutboundHeadersConfig) Validate() error {
+	// Set defaults
+	if c.Strip == nil {
+		c.Strip = DefaultOutboundStripHeaders
+	}
+
+	for i, name := range c.Strip {
+		if !validHeaderName(name) {
+			return fmt.Errorf("strip[%d] is not a valid header name: %q", i, name)
+		}
+	}
+
+	for name := range c.Set {
+		if !validHeaderName(name) {
+			return fmt.Errorf("set: invalid header name %q", name)
+		}
+
+		if http.CanonicalHeaderKey(name) == "Host" {
+			return fmt.Errorf("set: the Host header cannot be overridden")
+		}
+	}
+
+	return nil
+}
+
+// validHeaderName reports whether name is a non-empty HTTP token.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate validates the WebSocket configuration and sets defaults.
+func (c *WebSocketConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.MaxConnectionsPerNetwork == 0 {
+		c.MaxConnectionsPerNetwork = 100
+	}
+
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = 5 * time.Minute
+	}
+
+	// Validate ranges
+	if c.MaxConnectionsPerNetwork < 0 {
+		return fmt.Errorf("max_connections_per_network must not be negative, got %d", c.MaxConnectionsPerNetwork)
+	}
+
+	if c.IdleTimeout < time.Second {
+		return fmt.Errorf("idle_timeout must be at least 1 second, got %v", c.IdleTimeout)
+	}
+
+	return nil
+}
+
+// Validate validates the hedging configuration and sets defaults.
+func (c *HedgingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.Percentile == 0 {
+		c.Percentile = 0.95
+	}
+
+	if c.MinDelay == 0 {
+		c.MinDelay = 50 * time.Millisecond
+	}
+
+	if c.MaxDelay == 0 {
+		c.MaxDelay = 2 * time.Second
+	}
+
+	if c.BudgetRatio == 0 {
+		c.BudgetRatio = 0.05
+	}
+
+	if c.MaxInFlight == 0 {
+		c.MaxInFlight = 10
+	}
+
+	// Validate ranges
+	if c.Percentile <= 0 || c.Percentile >= 1 {
+		return fmt.Errorf("percentile must be between 0 and 1 (exclusive), got %v", c.Percentile)
+	}
+
+	if c.MinDelay < 0 {
+		return fmt.Errorf("min_delay must not be negative, got %v", c.MinDelay)
+	}
+
+	if c.MaxDelay < c.MinDelay {
+		return fmt.Errorf("max_delay (%v) must be at least min_delay (%v)", c.MaxDelay, c.MinDelay)
+	}
+
+	if c.BudgetRatio <= 0 || c.BudgetRatio > 1 {
+		return fmt.Errorf("budget_ratio must be between 0 (exclusive) and 1, got %v", c.BudgetRatio)
+	}
+
+	if c.MaxInFlight < 0 {
+		return fmt.Errorf("max_in_flight must not be negative, got %d", c.MaxInFlight)
+	}
+
+	for i, pattern := range c.PathPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("path_patterns[%d] invalid regex: %w", i, err)
+		}
+	}
+
+	return nil
+}
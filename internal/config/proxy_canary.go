@@ -0,0 +1,79 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Canary modes.
+const (
+	CanaryModeSplit  = "split"  // Send a share of requests to the new target URL
+	CanaryModeShadow = "shadow" // Copy reads to the new target URL and discard its responses
+)
+
+// CanaryConfig controls how a network's changed target_url is rolled out.
+// Instead of switching over on the next sync, the new URL is trialled next to
+// the current one, which keeps serving, until it is promoted or rolled back
+// through the admin API. Networks with several target_urls or DNS discovery
+// switch over right away.
+type CanaryConfig struct {
+	Enabled           bool          `yaml:"enabled"`
+	Mode              string        `yaml:"mode"`                 // "split" (default) sends percent of requests to the new URL, "shadow" copies reads to it
+	Percent           int           `yaml:"percent"`              // Share of requests sent to the new URL in split mode (default 10)
+	MaxShadowInFlight int           `yaml:"max_shadow_in_flight"` // Shadow requests in flight per instance, further reads are not copied (default 50)
+	DecisionTTL       time.Duration `yaml:"decision_ttl"`         // How long a rolled back URL is kept from being trialled again (default 24h)
+	PollInterval      time.Duration `yaml:"poll_interval"`        // How often decisions made on other instances are picked up (default 10s)
+	KeyPrefix         string        `yaml:"key_prefix"`           // Redis key prefix of promote and rollback decisions (default "lab:proxy_canary:")
+}
+
+// Validate validates the canary configuration and sets defaults.
+func (c *CanaryConfig) Validate() error {
+	// Set defaults
+	if c.Mode == "" {
+		c.Mode = CanaryModeSplit
+	}
+
+	if c.Percent == 0 {
+		c.Percent = 10
+	}
+
+	if c.MaxShadowInFlight == 0 {
+		c.MaxShadowInFlight = 50
+	}
+
+	if c.DecisionTTL == 0 {
+		c.DecisionTTL = 24 * time.Hour
+	}
+
+	if c.PollInterval == 0 {
+		c.PollInterval = 10 * time.Second
+	}
+
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = "lab:proxy_canary:"
+	}
+
+	// Validate ranges
+	if c.Mode != CanaryModeSplit && c.Mode != CanaryModeShadow {
+		return fmt.Errorf("mode must be %q or %q, got %q", CanaryModeSplit, CanaryModeShadow, c.Mode)
+	}
+
+	if c.Percent < 1 || c.Percent > 99 {
+		return fmt.Errorf("percent must be between 1 and 99, got %d", c.Percent)
+	}
+
+	if c.MaxShadowInFlight < 1 {
+		return fmt.Errorf("max_shadow_in_flight must be positive, got %d", c.MaxShadowInFlight)
+	}
+
+	if c.DecisionTTL < time.Minute {
+		return fmt.Errorf("decision_ttl must be at least 1 minute, got %v", c.DecisionTTL)
+	}
+
+	if c.PollInterval < time.Second {
+		return fmt.Errorf("poll_interval must be at least 1 second, got %v", c.PollInterval)
+	}
+
+	return nil
+}
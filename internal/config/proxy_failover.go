@@ -0,0 +1,78 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Upstream selection strategies for networks with several target_urls.
+const (
+	// FailoverStrategyPriority sends every request to the first healthy
+	// upstream, in target_urls order.
+	FailoverStrategyPriority = "priority"
+	// FailoverStrategyRoundRobin takes turns between healthy upstreams.
+	FailoverStrategyRoundRobin = "round_robin"
+)
+
+// FailoverConfig controls how requests are spread over the upstreams of
+// networks with several target_urls. Upstreams are health checked in the
+// background and skipped after consecutive failed checks or requests. Reads
+// that fail to connect are retried on the next upstream.
+type FailoverConfig struct {
+	Strategy           string        `yaml:"strategy"`            // "priority" (default) or "round_robin"
+	HealthPath         string        `yaml:"health_path"`         // Path checked on every upstream host (default "/health")
+	HealthInterval     time.Duration `yaml:"health_interval"`     // Interval between health checks (default 10s)
+	HealthTimeout      time.Duration `yaml:"health_timeout"`      // Timeout of a single health check (default 5s)
+	UnhealthyThreshold int           `yaml:"unhealthy_threshold"` // Consecutive failures before an upstream is skipped (default 3)
+}
+
+// Validate validates the failover configuration and sets defaults.
+func (c *FailoverConfig) Validate() error {
+	// Set defaults
+	if c.Strategy == "" {
+		c.Strategy = FailoverStrategyPriority
+	}
+
+	if c.HealthPath == "" {
+		c.HealthPath = "/health"
+	}
+
+	if c.HealthInterval == 0 {
+		c.HealthInterval = 10 * time.Second
+	}
+
+	if c.HealthTimeout == 0 {
+		c.HealthTimeout = 5 * time.Second
+	}
+
+	if c.UnhealthyThreshold == 0 {
+		c.UnhealthyThreshold = 3
+	}
+
+	// Validate ranges
+	if c.Strategy != FailoverStrategyPriority && c.Strategy != FailoverStrategyRoundRobin {
+		return fmt.Errorf(
+			"strategy must be %q or %q, got %q",
+			FailoverStrategyPriority, FailoverStrategyRoundRobin, c.Strategy,
+		)
+	}
+
+	if c.HealthPath[0] != '/' {
+		return fmt.Errorf("health_path must start with /, got %q", c.HealthPath)
+	}
+
+	if c.HealthInterval < time.Second {
+		return fmt.Errorf("health_interval must be at least 1 second, got %v", c.HealthInterval)
+	}
+
+	if c.HealthTimeout <= 0 || c.HealthTimeout > c.HealthInterval {
+		return fmt.Errorf("health_timeout must be positive and at most health_interval, got %v", c.HealthTimeout)
+	}
+
+	if c.UnhealthyThreshold < 1 {
+		return fmt.Errorf("unhealthy_threshold must be at least 1, got %d", c.UnhealthyThreshold)
+	}
+
+	return nil
+}
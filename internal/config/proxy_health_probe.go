@@ -0,0 +1,68 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// HealthProbeConfig controls continuous health probing of proxied network
+// backends. Each network is marked degraded after a failed probe and
+// unhealthy after unhealthy_threshold failed probes in a row; requests to an
+// unhealthy network are answered with a 503 until healthy_threshold probes in
+// a row succeed again.
+type HealthProbeConfig struct {
+	Enabled            bool          `yaml:"enabled"`
+	Path               string        `yaml:"path"`                // Path probed on every backend host (default "/health")
+	Interval           time.Duration `yaml:"interval"`            // Interval between probes (default 15s)
+	Timeout            time.Duration `yaml:"timeout"`             // Timeout of a single probe (default 5s)
+	UnhealthyThreshold int           `yaml:"unhealthy_threshold"` // Consecutive failed probes before a network is unhealthy (default 3)
+	HealthyThreshold   int           `yaml:"healthy_threshold"`   // Consecutive successful probes before an unhealthy network recovers (default 2)
+}
+
+// Validate validates the health probe configuration and sets defaults.
+func (c *HealthProbeConfig) Validate() error {
+	// Set defaults
+	if c.Path == "" {
+		c.Path = "/health"
+	}
+
+	if c.Interval == 0 {
+		c.Interval = 15 * time.Second
+	}
+
+	if c.Timeout == 0 {
+		c.Timeout = 5 * time.Second
+	}
+
+	if c.UnhealthyThreshold == 0 {
+		c.UnhealthyThreshold = 3
+	}
+
+	if c.HealthyThreshold == 0 {
+		c.HealthyThreshold = 2
+	}
+
+	// Validate ranges
+	if c.Path[0] != '/' {
+		return fmt.Errorf("path must start with /, got %q", c.Path)
+	}
+
+	if c.Interval < time.Second {
+		return fmt.Errorf("interval must be at least 1 second, got %v", c.Interval)
+	}
+
+	if c.Timeout <= 0 || c.Timeout > c.Interval {
+		return fmt.Errorf("timeout must be positive and at most interval, got %v", c.Timeout)
+	}
+
+	if c.UnhealthyThreshold < 1 {
+		return fmt.Errorf("unhealthy_threshold must be at least 1, got %d", c.UnhealthyThreshold)
+	}
+
+	if c.HealthyThreshold < 1 {
+		return fmt.Errorf("healthy_threshold must be at least 1, got %d", c.HealthyThreshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// MirroringConfig controls the copies of proxied reads sent to networks'
+// mirror_url, e.g. a new CBT API deployment validated against production
+// traffic before target_url is switched to it. Mirror responses never reach
+// clients; for a sample of requests they are compared with the primary's and
+// differences are logged.
+type MirroringConfig struct {
+	SampleRate      float64       `yaml:"sample_rate"`       // Share of mirrored requests whose responses are compared (default 0.01)
+	MaxInFlight     int           `yaml:"max_in_flight"`     // Mirror requests in flight per instance, further reads are not copied (default 50)
+	MaxCompareBytes int64         `yaml:"max_compare_bytes"` // Largest response body compared, larger responses are skipped (default 1MiB)
+	Timeout         time.Duration `yaml:"timeout"`           // Bounds each mirror request including its body (default 30s)
+}
+
+// Validate validates the mirroring configuration and sets defaults.
+func (c *MirroringConfig) Validate() error {
+	// Set defaults
+	if c.SampleRate == 0 {
+		c.SampleRate = 0.01
+	}
+
+	if c.MaxInFlight == 0 {
+		c.MaxInFlight = 50
+	}
+
+	if c.MaxCompareBytes == 0 {
+		c.MaxCompareBytes = 1 << 20
+	}
+
+	if c.Timeout == 0 {
+		c.Timeout = 30 * time.Second
+	}
+
+	// Validate ranges
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return fmt.Errorf("sample_rate must be between 0 and 1, got %v", c.SampleRate)
+	}
+
+	if c.MaxInFlight < 1 {
+		return fmt.Errorf("max_in_flight must be positive, got %d", c.MaxInFlight)
+	}
+
+	if c.MaxCompareBytes < 1 {
+		return fmt.Errorf("max_compare_bytes must be positive, got %d", c.MaxCompareBytes)
+	}
+
+	if c.Timeout < time.Second {
+		return fmt.Errorf("timeout must be at least 1 second, got %v", c.Timeout)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetirementConfig controls how networks dropped from the proxy, e.g. devnets
+// cartographoor no longer lists, are answered. For grace_period after
+// removal, requests to a retired network get a 410 Gone with the time it was
+// retired and its final bounds, read from a tombstone record in Redis shared
+// by every instance. Afterwards the network is unknown (404).
+type RetirementConfig struct {
+	Enabled     bool          `yaml:"enabled"`
+	GracePeriod time.Duration `yaml:"grace_period"` // How long retired networks are answered with 410 Gone (default 168h)
+	KeyPrefix   string        `yaml:"key_prefix"`   // Redis key prefix of tombstone records (default "lab:retired_network:")
+}
+
+// Validate validates the retirement configuration and sets defaults.
+func (c *RetirementConfig) Validate() error {
+	// Set defaults
+	if c.GracePeriod == 0 {
+		c.GracePeriod = 7 * 24 * time.Hour
+	}
+
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = "lab:retired_network:"
+	}
+
+	// Validate ranges
+	if c.GracePeriod < time.Minute {
+		return fmt.Errorf("grace_period must be at least 1 minute, got %v", c.GracePeriod)
+	}
+
+	return nil
+}
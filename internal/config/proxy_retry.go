@@ -0,0 +1,69 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryStatusCodes are the upstream statuses retried when
+// retry.status_codes is not configured.
+var DefaultRetryStatusCodes = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryConfig controls retries of proxied reads. GET and HEAD requests without
+// a body are sent again, with exponential backoff, when the upstream fails to
+// respond or answers with one of status_codes. Networks can opt in or out with
+// their own retry setting.
+type RetryConfig struct {
+	Enabled        bool          `yaml:"enabled"`         // Default for networks without their own retry setting
+	MaxAttempts    int           `yaml:"max_attempts"`    // Attempts per request, including the first (default 3)
+	InitialBackoff time.Duration `yaml:"initial_backoff"` // Wait before the first retry, doubled for each further one (default 100ms)
+	MaxBackoff     time.Duration `yaml:"max_backoff"`     // Upper bound for the wait between attempts (default 1s)
+	StatusCodes    []int         `yaml:"status_codes"`    // Upstream statuses that are retried (default 502, 503, 504)
+}
+
+// Validate validates the retry configuration and sets defaults.
+func (c *RetryConfig) Validate() error {
+	// Set defaults
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 3
+	}
+
+	if c.InitialBackoff == 0 {
+		c.InitialBackoff = 100 * time.Millisecond
+	}
+
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = time.Second
+	}
+
+	if c.StatusCodes == nil {
+		c.StatusCodes = DefaultRetryStatusCodes
+	}
+
+	// Validate ranges
+	if c.MaxAttempts < 1 || c.MaxAttempts > 10 {
+		return fmt.Errorf("max_attempts must be between 1 and 10, got %d", c.MaxAttempts)
+	}
+
+	if c.InitialBackoff < 0 {
+		return fmt.Errorf("initial_backoff must not be negative, got %v", c.InitialBackoff)
+	}
+
+	if c.MaxBackoff < c.InitialBackoff {
+		return fmt.Errorf("max_backoff (%v) must be at least initial_backoff (%v)", c.MaxBackoff, c.InitialBackoff)
+	}
+
+	for _, code := range c.StatusCodes {
+		if code < 500 || code > 599 {
+			return fmt.Errorf("status_codes must be 5xx statuses, got %d", code)
+		}
+	}
+
+	return nil
+}
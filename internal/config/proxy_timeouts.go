@@ -0,0 +1,51 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// UpstreamTimeoutsConfig controls how long the proxy waits for an upstream to
+// start responding. The first matching path timeout wins, then the network's
+// own timeout, then the default. Streaming the response body is not limited.
+type UpstreamTimeoutsConfig struct {
+	Default time.Duration       `yaml:"default"` // Wait for upstream response headers (default 30s)
+	Paths   []PathTimeoutConfig `yaml:"paths"`   // Overrides for matching request paths, first match wins
+}
+
+// PathTimeoutConfig is the upstream timeout for requests whose path matches.
+type PathTimeoutConfig struct {
+	Pattern string        `yaml:"pattern"` // Regex matched against the request path, e.g. "^/api/v1/[^/]+/fct_attestation"
+	Timeout time.Duration `yaml:"timeout"` // Wait for upstream response headers
+}
+
+// Validate validates the upstream timeouts configuration and sets defaults.
+func (c *UpstreamTimeoutsConfig) Validate() error {
+	// Set defaults
+	if c.Default == 0 {
+		c.Default = 30 * time.Second
+	}
+
+	// Validate ranges
+	if c.Default < 0 {
+		return fmt.Errorf("default must be positive, got %v", c.Default)
+	}
+
+	for i, path := range c.Paths {
+		if path.Pattern == "" {
+			return fmt.Errorf("paths[%d] pattern cannot be empty", i)
+		}
+
+		if _, err := regexp.Compile(path.Pattern); err != nil {
+			return fmt.Errorf("paths[%d] invalid regex: %w", i, err)
+		}
+
+		if path.Timeout <= 0 {
+			return fmt.Errorf("paths[%d] timeout must be positive, got %v", i, path.Timeout)
+		}
+	}
+
+	return nil
+}
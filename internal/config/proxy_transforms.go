@@ -0,0 +1,92 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Proxy transform types.
+const (
+	TransformSlotFilters     = "slot_filters"     // Rewrite slot_* filters to slot_start_date_time_*, subject to slot_transform
+	TransformEpochToSlot     = "epoch_to_slot"    // Expand epoch_* filters to slot_* ranges
+	TransformRenameFields    = "rename_fields"    // Rename fields of JSON responses
+	TransformNetworkMetadata = "network_metadata" // Add the network's name and timing to JSON responses
+)
+
+// TransformsConfig registers request and response transforms per route. Every
+// route whose pattern matches a request contributes its transforms, in order.
+type TransformsConfig struct {
+	Routes []TransformRouteConfig `yaml:"routes"` // Default: slot_filters for every route ([] disables all transforms)
+}
+
+// TransformRouteConfig is the transform pipeline of the routes matching a pattern.
+type TransformRouteConfig struct {
+	Pattern    string            `yaml:"pattern"` // Regex matched against the path after the network, e.g. "^/fct_block$" (empty matches all)
+	Transforms []TransformConfig `yaml:"transforms"`
+}
+
+// TransformConfig is one transform of a route.
+type TransformConfig struct {
+	Type   string            `yaml:"type"`   // slot_filters, epoch_to_slot, rename_fields or network_metadata
+	Fields map[string]string `yaml:"fields"` // rename_fields: upstream field name → name in the response
+	Field  string            `yaml:"field"`  // network_metadata: top-level field holding the metadata (default "network")
+}
+
+// DefaultTransformRoutes returns the routes used when none are configured:
+// slot filters are transformed for every table.
+func DefaultTransformRoutes() []TransformRouteConfig {
+	return []TransformRouteConfig{
+		{Transforms: []TransformConfig{{Type: TransformSlotFilters}}},
+	}
+}
+
+// Validate validates the transform routes and sets defaults.
+func (c *TransformsConfig) Validate() error {
+	// Set defaults
+	if c.Routes == nil {
+		c.Routes = DefaultTransformRoutes()
+	}
+
+	for i := range c.Routes {
+		route := &c.Routes[i]
+
+		if _, err := regexp.Compile(route.Pattern); err != nil {
+			return fmt.Errorf("routes[%d]: invalid pattern: %w", i, err)
+		}
+
+		for j := range route.Transforms {
+			if err := route.Transforms[j].Validate(); err != nil {
+				return fmt.Errorf("routes[%d].transforms[%d]: %w", i, j, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates a transform and sets defaults.
+func (c *TransformConfig) Validate() error {
+	switch c.Type {
+	case TransformSlotFilters, TransformEpochToSlot:
+	case TransformRenameFields:
+		if len(c.Fields) == 0 {
+			return fmt.Errorf("rename_fields requires fields")
+		}
+
+		for from, to := range c.Fields {
+			if from == "" || to == "" {
+				return fmt.Errorf("fields: field names cannot be empty")
+			}
+		}
+	case TransformNetworkMetadata:
+		if c.Field == "" {
+			c.Field = "network"
+		}
+	default:
+		return fmt.Errorf("unknown type %q (must be %s, %s, %s or %s)", c.Type,
+			TransformSlotFilters, TransformEpochToSlot, TransformRenameFields, TransformNetworkMetadata)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// PushConfig controls the /api/v1/ws WebSocket push channel, which streams
+// bounds and network updates to frontends so they don't have to poll.
+type PushConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	MaxClients   int           `yaml:"max_clients"`   // Max concurrent WebSocket clients (default 1000)
+	PingInterval time.Duration `yaml:"ping_interval"` // How often idle clients are pinged (default 30s)
+}
+
+// Validate validates the push configuration and sets defaults.
+func (c *PushConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.MaxClients == 0 {
+		c.MaxClients = 1000
+	}
+
+	if c.PingInterval == 0 {
+		c.PingInterval = 30 * time.Second
+	}
+
+	// Validate ranges
+	if c.MaxClients < 0 {
+		return fmt.Errorf("max_clients must not be negative, got %d", c.MaxClients)
+	}
+
+	if c.PingInterval < time.Second {
+		return fmt.Errorf("ping_interval must be at least 1 second, got %v", c.PingInterval)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import "fmt"
+
+// QueryValidationConfig controls validation of proxied table queries. When
+// enabled, malformed queries are rejected with 400 before they reach the
+// upstream ClickHouse-backed APIs. Defaults apply to every table; tables can
+// override them and restrict the filters they accept.
+type QueryValidationConfig struct {
+	Enabled      bool                       `yaml:"enabled"`
+	MaxPageSize  int                        `yaml:"max_page_size"`  // Largest page_size accepted (0 = unlimited)
+	MaxSlotRange uint64                     `yaml:"max_slot_range"` // Largest span between lower and upper slot filters (0 = unlimited)
+	Tables       map[string]TableQueryRules `yaml:"tables"`         // Per-table rules, by table name
+}
+
+// TableQueryRules are the query rules of one table. Zero limits inherit the
+// defaults of QueryValidationConfig.
+type TableQueryRules struct {
+	AllowedFilters []string `yaml:"allowed_filters"` // Columns that may be filtered on, e.g. "slot" (empty allows any)
+	MaxPageSize    int      `yaml:"max_page_size"`
+	MaxSlotRange   uint64   `yaml:"max_slot_range"`
+}
+
+// Validate validates the query validation configuration.
+func (c *QueryValidationConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Validate ranges
+	if c.MaxPageSize < 0 {
+		return fmt.Errorf("max_page_size must not be negative, got %d", c.MaxPageSize)
+	}
+
+	for table, rules := range c.Tables {
+		if rules.MaxPageSize < 0 {
+			return fmt.Errorf("tables.%s.max_page_size must not be negative, got %d", table, rules.MaxPageSize)
+		}
+
+		for i, filter := range rules.AllowedFilters {
+			if filter == "" {
+				return fmt.Errorf("tables.%s.allowed_filters[%d] must not be empty", table, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Rules returns the effective rules of a table.
+func (c *QueryValidationConfig) Rules(table string) TableQueryRules {
+	rules := c.Tables[table]
+
+	if rules.MaxPageSize == 0 {
+		rules.MaxPageSize = c.MaxPageSize
+	}
+
+	if rules.MaxSlotRange == 0 {
+		rules.MaxSlotRange = c.MaxSlotRange
+	}
+
+	return rules
+}
@@ -0,0 +1,85 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitOffendersConfig controls recording denied requests per client and
+// rule, served as top offenders at /admin/v1/ratelimit/offenders to help tune
+// rules and feed abuse blocking. Denials are counted in memory and flushed to
+// Redis sorted sets, one per bucket, so every replica contributes.
+type RateLimitOffendersConfig struct {
+	Enabled       bool            `yaml:"enabled"`
+	Windows       []time.Duration `yaml:"windows"`        // Windows offenders can be reported over; the first is the default (default [1h, 24h])
+	BucketSize    time.Duration   `yaml:"bucket_size"`    // Granularity of the recorded counts (default 5m)
+	FlushInterval time.Duration   `yaml:"flush_interval"` // How often counts are written to Redis (default 10s)
+	DefaultLimit  int             `yaml:"default_limit"`  // Offenders returned without ?limit (default 20)
+	MaxLimit      int             `yaml:"max_limit"`      // Largest ?limit accepted (default 100)
+}
+
+// Validate validates the offenders configuration and sets defaults.
+func (c *RateLimitOffendersConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if len(c.Windows) == 0 {
+		c.Windows = []time.Duration{time.Hour, 24 * time.Hour}
+	}
+
+	if c.BucketSize == 0 {
+		c.BucketSize = 5 * time.Minute
+	}
+
+	if c.FlushInterval == 0 {
+		c.FlushInterval = 10 * time.Second
+	}
+
+	if c.DefaultLimit == 0 {
+		c.DefaultLimit = 20
+	}
+
+	if c.MaxLimit == 0 {
+		c.MaxLimit = 100
+	}
+
+	// Validate ranges
+	if c.BucketSize < time.Minute {
+		return fmt.Errorf("bucket_size must be at least 1 minute, got %v", c.BucketSize)
+	}
+
+	if c.FlushInterval < time.Second {
+		return fmt.Errorf("flush_interval must be at least 1 second, got %v", c.FlushInterval)
+	}
+
+	for i, window := range c.Windows {
+		if window < c.BucketSize {
+			return fmt.Errorf("windows[%d] (%v) must be at least bucket_size (%v)", i, window, c.BucketSize)
+		}
+
+		// Every bucket of the window is merged on each report
+		if window/c.BucketSize > 1000 {
+			return fmt.Errorf("windows[%d] (%v) spans more than 1000 buckets of %v", i, window, c.BucketSize)
+		}
+	}
+
+	if c.DefaultLimit < 1 || c.MaxLimit < c.DefaultLimit {
+		return fmt.Errorf("default_limit (%d) must be between 1 and max_limit (%d)", c.DefaultLimit, c.MaxLimit)
+	}
+
+	return nil
+}
+
+// MaxWindow returns the longest window, which recorded counts are kept for.
+func (c *RateLimitOffendersConfig) MaxWindow() time.Duration {
+	var longest time.Duration
+
+	for _, window := range c.Windows {
+		longest = max(longest, window)
+	}
+
+	return longest
+}
@@ -0,0 +1,41 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReadOnlyConfig controls read-only mode, in which mutating endpoints return
+// 503. It is on while enabled is set, or at runtime while redis_key exists in
+// Redis (its value, if any, replaces the message), so operators can flip every
+// instance at once during migrations and incidents.
+type ReadOnlyConfig struct {
+	Enabled      bool          `yaml:"enabled"`       // Start in read-only mode regardless of Redis
+	Message      string        `yaml:"message"`       // Message returned to rejected requests
+	RedisKey     string        `yaml:"redis_key"`     // Redis key that turns read-only mode on at runtime (default "lab:read_only")
+	PollInterval time.Duration `yaml:"poll_interval"` // How often redis_key is checked (default 5s)
+}
+
+// Validate validates the read-only configuration and sets defaults.
+func (c *ReadOnlyConfig) Validate() error {
+	// Set defaults
+	if c.Message == "" {
+		c.Message = "lab-backend is in read-only mode, please try again later"
+	}
+
+	if c.RedisKey == "" {
+		c.RedisKey = "lab:read_only"
+	}
+
+	if c.PollInterval == 0 {
+		c.PollInterval = 5 * time.Second
+	}
+
+	// Validate ranges
+	if c.PollInterval < 0 {
+		return fmt.Errorf("poll_interval must be positive, got %v", c.PollInterval)
+	}
+
+	return nil
+}
@@ -0,0 +1,484 @@
+package config
+
+//go:generate go run ./docgen -output docs_gen.go . ../cartographoor
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// referenceWrap is the width reference comments are wrapped at.
+const referenceWrap = 100
+
+// probeKey is the map key of the example entry added to maps of structs
+// while collecting defaults.
+const probeKey = "example"
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// Defaults returns the config with the default of every option: what
+// validation fills in when the option is not set. Features are left disabled.
+func Defaults() *Config {
+	return collectDefaults(false)
+}
+
+// WriteDefaults writes the default config as YAML, each option preceded by
+// its documentation. Lists and maps of settings are written as their default,
+// usually empty; WriteSchema shows their entries.
+func WriteDefaults(w io.Writer) error {
+	return writeReference(w, false)
+}
+
+// WriteSchema writes every config option as YAML, with its type as value, its
+// documentation above it and its default after it. Lists and maps of settings
+// are written with one example entry.
+func WriteSchema(w io.Writer) error {
+	return writeReference(w, true)
+}
+
+func writeReference(w io.Writer, schema bool) error {
+	ref := &reference{schema: schema}
+
+	body := ref.mapping(
+		reflect.TypeFor[Config](),
+		reflect.ValueOf(collectDefaults(false)).Elem(),
+		reflect.ValueOf(collectDefaults(true)).Elem(),
+	)
+
+	header := "lab-backend configuration defaults. Values may reference environment variables as ${VAR} or ${VAR:-default}."
+	if schema {
+		header = "lab-backend configuration reference: the type of every option, its documentation and its default."
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, HeadComment: comment(header), Content: []*yaml.Node{body}}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode config reference: %w", err)
+	}
+
+	return enc.Close()
+}
+
+// collectDefaults validates every section of an empty config with its
+// features enabled, so the defaults of disabled features are set too, then
+// disables them again. With probe, lists and maps of settings get an empty
+// entry first, so the defaults of entries and of sections that require one
+// are filled in as well; such a config is not usable, only its defaults are.
+func collectDefaults(probe bool) *Config {
+	cfg := &Config{}
+	v := reflect.ValueOf(cfg).Elem()
+
+	var enabled []reflect.Value
+
+	prepareDefaults(v, probe, &enabled)
+
+	for i := range v.NumField() {
+		field := v.Field(i)
+
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+
+		// Validation errors are expected, e.g. required options are unset
+		if validator, ok := field.Addr().Interface().(interface{ Validate() error }); ok {
+			_ = validator.Validate()
+		}
+
+		if field.Kind() == reflect.Slice {
+			for j := range field.Len() {
+				if validator, ok := field.Index(j).Addr().Interface().(interface{ Validate() error }); ok {
+					_ = validator.Validate()
+				}
+			}
+		}
+	}
+
+	for _, flag := range enabled {
+		flag.SetBool(false)
+	}
+
+	return cfg
+}
+
+// prepareDefaults turns on the Enabled flags of v's sections, recording them,
+// and with probe adds an empty entry to lists and maps of structs.
+func prepareDefaults(v reflect.Value, probe bool, enabled *[]reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Field(i)
+
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+
+			if v.Type().Field(i).Name == "Enabled" && field.Kind() == reflect.Bool && !field.Bool() {
+				field.SetBool(true)
+
+				*enabled = append(*enabled, field)
+
+				continue
+			}
+
+			prepareDefaults(field, probe, enabled)
+		}
+	case reflect.Pointer:
+		if v.IsNil() && probe && v.Type().Elem().Kind() == reflect.Struct {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		if !v.IsNil() {
+			prepareDefaults(v.Elem(), probe, enabled)
+		}
+	case reflect.Slice:
+		if probe && v.Len() == 0 && isStruct(v.Type().Elem()) {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		}
+
+		for i := range v.Len() {
+			prepareDefaults(v.Index(i), probe, enabled)
+		}
+	case reflect.Map:
+		if probe && v.Len() == 0 && isStruct(v.Type().Elem()) && v.Type().Key().Kind() == reflect.String {
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+
+			v.SetMapIndex(reflect.ValueOf(probeKey).Convert(v.Type().Key()), reflect.New(v.Type().Elem()).Elem())
+		}
+	default:
+	}
+}
+
+// reference renders config types as commented YAML.
+type reference struct {
+	schema bool
+}
+
+// mapping renders the options of a struct type. plain holds the defaults of
+// a config as loaded; probed those of a config with example entries, for
+// options inside lists and maps. Either may be invalid.
+func (r *reference) mapping(t reflect.Type, plain, probed reflect.Value) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, inline, skip := yamlKey(field)
+		if skip {
+			continue
+		}
+
+		fieldPlain, fieldProbed := structField(plain, i), structField(probed, i)
+
+		if inline {
+			node.Content = append(node.Content, r.mapping(field.Type, fieldPlain, fieldProbed).Content...)
+
+			continue
+		}
+
+		value, def := r.value(field.Type, fieldPlain, fieldProbed)
+
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: name, HeadComment: comment(fieldDoc(t, field))}
+
+		if r.schema && def != "" {
+			value.LineComment = "# default: " + def
+		}
+
+		node.Content = append(node.Content, key, value)
+	}
+
+	return node
+}
+
+// value renders an option and returns its default, formatted for comments.
+func (r *reference) value(t reflect.Type, plain, probed reflect.Value) (*yaml.Node, string) {
+	if t.Kind() == reflect.Pointer {
+		t, plain, probed = t.Elem(), deref(plain), deref(probed)
+	}
+
+	switch {
+	case isStruct(t):
+		return r.mapping(t, plain, probed), ""
+	case t.Kind() == reflect.Slice && r.schema && isStruct(t.Elem()):
+		return &yaml.Node{
+			Kind:    yaml.SequenceNode,
+			Content: []*yaml.Node{r.mapping(indirect(t.Elem()), invalid(), deref(index(probed, 0)))},
+		}, defaultText(plain)
+	case t.Kind() == reflect.Map && r.schema && isStruct(t.Elem()):
+		value, _ := r.value(t.Elem(), invalid(), mapIndex(probed, probeKey))
+
+		return &yaml.Node{
+			Kind:    yaml.MappingNode,
+			Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: "<name>"}, value},
+		}, defaultText(plain)
+	case r.schema:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: "<" + typeName(t) + ">"}, defaultText(firstSet(plain, probed))
+	case plain.IsValid() && (t.Kind() == reflect.Slice || t.Kind() == reflect.Map) && isStruct(t.Elem()):
+		// Example entries are only part of the schema
+		return r.literal(t, plain), ""
+	default:
+		return r.literal(t, firstSet(plain, probed)), ""
+	}
+}
+
+// literal renders a default value.
+func (r *reference) literal(t reflect.Type, v reflect.Value) *yaml.Node {
+	if !v.IsValid() {
+		v = reflect.Zero(t)
+	}
+
+	switch {
+	case t.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		}
+
+		return r.literal(t.Elem(), v.Elem())
+	case isStruct(t):
+		return r.mapping(t, v, invalid())
+	case t.Kind() == reflect.Slice:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+
+		for i := range v.Len() {
+			node.Content = append(node.Content, r.literal(t.Elem(), v.Index(i)))
+
+			if isStruct(t.Elem()) {
+				node.Style = 0
+			}
+		}
+
+		return node
+	case t.Kind() == reflect.Map:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+
+		if v.Len() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+
+		for _, key := range keys {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key.String()},
+				r.literal(t.Elem(), v.MapIndex(key)))
+		}
+
+		return node
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: scalarTag(t), Value: formatScalar(v)}
+	}
+}
+
+// fieldDoc returns the documentation of a field, or of its type for sections
+// without one.
+func fieldDoc(owner reflect.Type, field reflect.StructField) string {
+	if doc := fieldDocs[owner.String()+"."+field.Name]; doc != "" {
+		return doc
+	}
+
+	return fieldDocs[indirect(field.Type).String()]
+}
+
+// yamlKey returns the YAML key of a field, and whether it is inlined or skipped.
+func yamlKey(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	inline := slices.Contains(strings.Split(options, ","), "inline")
+
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, inline, false
+}
+
+// comment formats text as a YAML comment wrapped at referenceWrap.
+func comment(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	var (
+		lines []string
+		line  string
+	)
+
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > referenceWrap {
+			lines = append(lines, "# "+line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+
+		line += word
+	}
+
+	return strings.Join(append(lines, "# "+line), "\n")
+}
+
+// defaultText formats a non-zero default for a comment.
+func defaultText(v reflect.Value) string {
+	if !v.IsValid() || v.IsZero() {
+		return ""
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return defaultText(v.Elem())
+	case reflect.Slice:
+		items := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, defaultText(v.Index(i)))
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map, reflect.Struct:
+		return fmt.Sprintf("%v", v.Interface())
+	default:
+		return formatScalar(v)
+	}
+}
+
+// formatScalar formats a scalar as written in config files.
+func formatScalar(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// scalarTag returns the YAML tag of a scalar type, so strings that look like
+// numbers or booleans are quoted.
+func scalarTag(t reflect.Type) string {
+	switch {
+	case t == durationType, t.Kind() == reflect.String:
+		return "!!str"
+	case t.Kind() == reflect.Bool:
+		return "!!bool"
+	case t.Kind() == reflect.Float32, t.Kind() == reflect.Float64:
+		return "!!float"
+	default:
+		return "!!int"
+	}
+}
+
+// typeName describes a type in the schema.
+func typeName(t reflect.Type) string {
+	switch {
+	case t == durationType:
+		return "duration"
+	case t.Kind() == reflect.Pointer:
+		return typeName(t.Elem())
+	case t.Kind() == reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case t.Kind() == reflect.Map:
+		return "map[" + typeName(t.Key()) + "]" + typeName(t.Elem())
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		return "int"
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		return "uint"
+	case t.Kind() == reflect.Float32, t.Kind() == reflect.Float64:
+		return "float"
+	default:
+		return t.Kind().String()
+	}
+}
+
+func isStruct(t reflect.Type) bool {
+	return indirect(t).Kind() == reflect.Struct
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+func invalid() reflect.Value {
+	return reflect.Value{}
+}
+
+func deref(v reflect.Value) reflect.Value {
+	if v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return invalid()
+		}
+
+		return v.Elem()
+	}
+
+	return v
+}
+
+func structField(v reflect.Value, i int) reflect.Value {
+	if v = deref(v); !v.IsValid() {
+		return invalid()
+	}
+
+	return v.Field(i)
+}
+
+func index(v reflect.Value, i int) reflect.Value {
+	if !v.IsValid() || v.Len() <= i {
+		return invalid()
+	}
+
+	return v.Index(i)
+}
+
+func mapIndex(v reflect.Value, key string) reflect.Value {
+	if !v.IsValid() || v.IsNil() {
+		return invalid()
+	}
+
+	return v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+}
+
+// firstSet returns plain, or probed when plain is zero.
+func firstSet(plain, probed reflect.Value) reflect.Value {
+	if plain.IsValid() && !plain.IsZero() {
+		return plain
+	}
+
+	if probed.IsValid() && !probed.IsZero() {
+		return probed
+	}
+
+	return plain
+}
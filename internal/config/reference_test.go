@@ -0,0 +1,50 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaults(t *testing.T) {
+	cfg := Defaults()
+
+	assert.Equal(t, 7*time.Second, cfg.Bounds.RefreshInterval)
+	assert.Equal(t, 5, cfg.Bounds.CircuitBreaker.FailureThreshold)
+	assert.Equal(t, 100, cfg.Proxy.WebSocket.MaxConnectionsPerNetwork)
+	assert.False(t, cfg.Proxy.WebSocket.Enabled, "features stay disabled")
+	assert.Empty(t, cfg.Networks)
+}
+
+func TestWriteSchema(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteSchema(&buf))
+
+	var out map[string]any
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &out))
+	assert.Contains(t, out, "bounds")
+
+	assert.Contains(t, buf.String(), "  # Redis TTL for bounds data (0 = no expiration)\n  bounds_ttl: <duration>\n")
+	assert.Contains(t, buf.String(), "  refresh_interval: <duration> # default: 7s\n")
+
+	// Lists of settings show an example entry
+	assert.Contains(t, buf.String(), "networks:\n  - # Required: \"mainnet\", \"sepolia\", etc.\n    name: <string>\n")
+}
+
+func TestWriteDefaults(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteDefaults(&buf))
+
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &cfg))
+
+	assert.Equal(t, 7*time.Second, cfg.Bounds.RefreshInterval)
+	assert.Equal(t, time.Hour, cfg.Bounds.StaleRetention)
+	assert.Empty(t, cfg.Networks)
+}
@@ -0,0 +1,40 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// RequestTimeoutsConfig sets deadlines on inbound requests by path, so slow
+// upstreams can't hold cheap endpoints for the whole server write_timeout.
+type RequestTimeoutsConfig struct {
+	Policies []RequestTimeoutPolicy `yaml:"policies"` // Evaluated in order, first match wins
+}
+
+// RequestTimeoutPolicy is the deadline of requests whose path matches.
+type RequestTimeoutPolicy struct {
+	Name        string        `yaml:"name"`         // Policy name for logging and metrics
+	PathPattern string        `yaml:"path_pattern"` // Regex pattern to match request paths
+	Timeout     time.Duration `yaml:"timeout"`      // Deadline of matching requests, 0 exempts them
+}
+
+// Validate validates the request timeouts configuration.
+func (c *RequestTimeoutsConfig) Validate() error {
+	for i, policy := range c.Policies {
+		if policy.Name == "" {
+			return fmt.Errorf("policies[%d] name cannot be empty", i)
+		}
+
+		if _, err := regexp.Compile(policy.PathPattern); err != nil {
+			return fmt.Errorf("policies[%d] (%s) invalid path_pattern: %w", i, policy.Name, err)
+		}
+
+		if policy.Timeout < 0 {
+			return fmt.Errorf("policies[%d] (%s) timeout must be positive, got %v", i, policy.Name, policy.Timeout)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaValidationConfig enables dev-mode validation of JSON responses, both
+// proxied and local, against OpenAPI specs. Mismatches are only logged; it
+// buffers every JSON response and is not meant for production.
+type SchemaValidationConfig struct {
+	Enabled      bool         `yaml:"enabled"`
+	Specs        []SchemaSpec `yaml:"specs"`
+	MaxBodyBytes int64        `yaml:"max_body_bytes"` // Larger responses are not validated (default 10MiB)
+}
+
+// SchemaSpec is an OpenAPI spec and the request path it describes.
+type SchemaSpec struct {
+	Path     string `yaml:"path"`      // OpenAPI 3 spec file, YAML or JSON
+	BasePath string `yaml:"base_path"` // Request path prefix of the spec's paths, e.g. /api/v1/{network}
+}
+
+// Validate validates the schema validation configuration and sets defaults.
+func (c *SchemaValidationConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.MaxBodyBytes == 0 {
+		c.MaxBodyBytes = 10 << 20
+	}
+
+	// Validate ranges
+	if c.MaxBodyBytes < 0 {
+		return fmt.Errorf("max_body_bytes must not be negative, got %d", c.MaxBodyBytes)
+	}
+
+	if len(c.Specs) == 0 {
+		return fmt.Errorf("at least one spec is required when enabled")
+	}
+
+	for i, spec := range c.Specs {
+		if spec.Path == "" {
+			return fmt.Errorf("specs[%d]: path is required", i)
+		}
+
+		if spec.BasePath != "" && !strings.HasPrefix(spec.BasePath, "/") {
+			return fmt.Errorf("specs[%d]: base_path must start with /, got %q", i, spec.BasePath)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SLOConfig controls availability and latency SLO tracking for upstreams.
+// Every outbound request (proxy, bounds, cartographoor, gas profiler) counts
+// towards the SLOs of its subsystem and upstream host.
+type SLOConfig struct {
+	SLOTargets `yaml:",inline"` // Default targets for all upstreams
+
+	Enabled            bool                  `yaml:"enabled"`
+	Window             time.Duration         `yaml:"window"`              // Rolling window SLOs are computed over (default 1h)
+	EvaluationInterval time.Duration         `yaml:"evaluation_interval"` // How often burn rates are evaluated (default 30s)
+	MinRequests        int                   `yaml:"min_requests"`        // Requests required in the window before alerting (default 10)
+	WebhookURL         string                `yaml:"webhook_url"`         // Optional webhook for budget exhausted/recovered alerts
+	Subsystems         map[string]SLOTargets `yaml:"subsystems"`          // Per-subsystem overrides (proxy, bounds, cartographoor, gas_profiler)
+}
+
+// SLOTargets holds the objectives for a set of upstreams.
+type SLOTargets struct {
+	AvailabilityTarget float64       `yaml:"availability_target"` // Fraction of requests that must succeed (default 0.99)
+	LatencyTarget      float64       `yaml:"latency_target"`      // Fraction of successful requests that must be fast (default 0.95)
+	LatencyThreshold   time.Duration `yaml:"latency_threshold"`   // A request is fast if it completes within this (default 1s)
+}
+
+// TargetsFor returns the targets for a subsystem, falling back to the defaults.
+func (c *SLOConfig) TargetsFor(subsystem string) SLOTargets {
+	if targets, ok := c.Subsystems[subsystem]; ok {
+		return targets
+	}
+
+	return c.SLOTargets
+}
+
+// Validate validates the SLO configuration and sets defaults.
+func (c *SLOConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.Window == 0 {
+		c.Window = time.Hour
+	}
+
+	if c.EvaluationInterval == 0 {
+		c.EvaluationInterval = 30 * time.Second
+	}
+
+	if c.MinRequests == 0 {
+		c.MinRequests = 10
+	}
+
+	c.SLOTargets.setDefaults(SLOTargets{
+		AvailabilityTarget: 0.99,
+		LatencyTarget:      0.95,
+		LatencyThreshold:   time.Second,
+	})
+
+	// Validate ranges
+	if c.Window < time.Minute {
+		return fmt.Errorf("window must be at least 1 minute, got %v", c.Window)
+	}
+
+	if c.EvaluationInterval < time.Second {
+		return fmt.Errorf("evaluation_interval must be at least 1 second, got %v", c.EvaluationInterval)
+	}
+
+	if c.MinRequests < 0 {
+		return fmt.Errorf("min_requests must not be negative, got %d", c.MinRequests)
+	}
+
+	if c.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(c.WebhookURL); err != nil {
+			return fmt.Errorf("invalid webhook_url: %w", err)
+		}
+	}
+
+	if err := c.SLOTargets.validate(); err != nil {
+		return err
+	}
+
+	for name, targets := range c.Subsystems {
+		targets.setDefaults(c.SLOTargets)
+
+		if err := targets.validate(); err != nil {
+			return fmt.Errorf("subsystems.%s: %w", name, err)
+		}
+
+		c.Subsystems[name] = targets
+	}
+
+	return nil
+}
+
+// setDefaults fills unset targets from defaults.
+func (t *SLOTargets) setDefaults(defaults SLOTargets) {
+	if t.AvailabilityTarget == 0 {
+		t.AvailabilityTarget = defaults.AvailabilityTarget
+	}
+
+	if t.LatencyTarget == 0 {
+		t.LatencyTarget = defaults.LatencyTarget
+	}
+
+	if t.LatencyThreshold == 0 {
+		t.LatencyThreshold = defaults.LatencyThreshold
+	}
+}
+
+func (t *SLOTargets) validate() error {
+	if t.AvailabilityTarget <= 0 || t.AvailabilityTarget >= 1 {
+		return fmt.Errorf("availability_target must be between 0 and 1 (exclusive), got %v", t.AvailabilityTarget)
+	}
+
+	if t.LatencyTarget <= 0 || t.LatencyTarget >= 1 {
+		return fmt.Errorf("latency_target must be between 0 and 1 (exclusive), got %v", t.LatencyTarget)
+	}
+
+	if t.LatencyThreshold <= 0 {
+		return fmt.Errorf("latency_threshold must be positive, got %v", t.LatencyThreshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Slot transform modes.
+const (
+	SlotTransformModeTransform   = "transform"   // Rewrite slot_* filters to slot_start_date_time_*
+	SlotTransformModePassthrough = "passthrough" // Forward slot_* filters untouched, for upstreams indexed by slot
+)
+
+// SlotTransformConfig controls whether slot filters are rewritten to
+// timestamps before proxying. The policy can be overridden at runtime through
+// a Redis key shared by every instance.
+type SlotTransformConfig struct {
+	SlotTransformPolicy `yaml:",inline"`
+
+	RedisKey     string        `yaml:"redis_key"`     // Redis key holding a JSON policy override (default "lab:slot_transform")
+	PollInterval time.Duration `yaml:"poll_interval"` // How often redis_key is checked (default 5s)
+}
+
+// SlotTransformPolicy sets the slot transform mode globally, per network and
+// per table. The most specific mode set wins.
+type SlotTransformPolicy struct {
+	Mode     string                                `yaml:"mode" json:"mode,omitempty"`         // Default mode: "transform" or "passthrough"
+	Networks map[string]SlotTransformNetworkPolicy `yaml:"networks" json:"networks,omitempty"` // Per-network overrides
+}
+
+// SlotTransformNetworkPolicy overrides the slot transform mode for one network.
+type SlotTransformNetworkPolicy struct {
+	Mode   string            `yaml:"mode" json:"mode,omitempty"`     // Mode for every table of the network
+	Tables map[string]string `yaml:"tables" json:"tables,omitempty"` // Mode per table name
+}
+
+// Validate validates the slot transform configuration and sets defaults.
+func (c *SlotTransformConfig) Validate() error {
+	// Set defaults
+	if c.Mode == "" {
+		c.Mode = SlotTransformModeTransform
+	}
+
+	if c.RedisKey == "" {
+		c.RedisKey = "lab:slot_transform"
+	}
+
+	if c.PollInterval == 0 {
+		c.PollInterval = 5 * time.Second
+	}
+
+	// Validate ranges
+	if c.PollInterval < 0 {
+		return fmt.Errorf("poll_interval must be positive, got %v", c.PollInterval)
+	}
+
+	return c.SlotTransformPolicy.Validate()
+}
+
+// Validate checks that every mode in the policy is known. Empty modes inherit
+// from the enclosing level.
+func (p *SlotTransformPolicy) Validate() error {
+	if err := validateSlotTransformMode(p.Mode); err != nil {
+		return fmt.Errorf("mode: %w", err)
+	}
+
+	for network, n := range p.Networks {
+		if err := validateSlotTransformMode(n.Mode); err != nil {
+			return fmt.Errorf("networks.%s.mode: %w", network, err)
+		}
+
+		for table, mode := range n.Tables {
+			if err := validateSlotTransformMode(mode); err != nil {
+				return fmt.Errorf("networks.%s.tables.%s: %w", network, table, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Resolve returns the most specific mode set for a table of a network, or ""
+// if the policy sets none.
+func (p *SlotTransformPolicy) Resolve(network, table string) string {
+	if n, ok := p.Networks[network]; ok {
+		if mode := n.Tables[table]; mode != "" {
+			return mode
+		}
+
+		if n.Mode != "" {
+			return n.Mode
+		}
+	}
+
+	return p.Mode
+}
+
+func validateSlotTransformMode(mode string) error {
+	switch mode {
+	case "", SlotTransformModeTransform, SlotTransformModePassthrough:
+		return nil
+	default:
+		return fmt.Errorf("must be %q or %q, got %q", SlotTransformModeTransform, SlotTransformModePassthrough, mode)
+	}
+}
@@ -0,0 +1,49 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// StartupGateConfig controls the startup gate, which answers data
+// endpoints with 503 until the data they serve (networks, bounds) has been
+// loaded once, instead of empty responses or errors.
+type StartupGateConfig struct {
+	Enabled       *bool         `yaml:"enabled"`        // Gate data endpoints while warming up (default true)
+	RetryAfter    time.Duration `yaml:"retry_after"`    // Retry-After hint sent while warming (default 5s)
+	CheckInterval time.Duration `yaml:"check_interval"` // Minimum time between checks of a warming dependency (default 1s)
+}
+
+// Validate validates the startup gate configuration and sets defaults.
+func (c *StartupGateConfig) Validate() error {
+	// Set defaults
+	if c.Enabled == nil {
+		enabled := true
+		c.Enabled = &enabled
+	}
+
+	if c.RetryAfter == 0 {
+		c.RetryAfter = 5 * time.Second
+	}
+
+	if c.CheckInterval == 0 {
+		c.CheckInterval = time.Second
+	}
+
+	// Validate ranges
+	if c.RetryAfter < time.Second {
+		return fmt.Errorf("retry_after must be at least 1 second, got %v", c.RetryAfter)
+	}
+
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("check_interval must be positive, got %v", c.CheckInterval)
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether the startup gate is on.
+func (c *StartupGateConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
@@ -0,0 +1,84 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// SummaryConfig controls the per-network summary served at
+// /api/v1/{network}/summary. The leader periodically counts rows of a few CBT
+// tables per network and stores the counts in Redis, so landing pages can show
+// network liveness without querying the tables themselves.
+type SummaryConfig struct {
+	Enabled         bool              `yaml:"enabled"`
+	RefreshInterval time.Duration     `yaml:"refresh_interval"` // How often counts are refreshed (default 1m)
+	RequestTimeout  time.Duration     `yaml:"request_timeout"`  // HTTP request timeout (default 30s)
+	TTL             time.Duration     `yaml:"ttl"`              // Redis TTL of a network's summary (default 10m)
+	MaxPages        int               `yaml:"max_pages"`        // Max pages of 10000 rows counted per table (default 10)
+	Counts          map[string]string `yaml:"counts"`           // Count name → CBT table counted (default nodes: fct_node_active_last_24h)
+}
+
+// Validate validates the summary configuration and sets defaults.
+func (c *SummaryConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = time.Minute
+	}
+
+	if c.RequestTimeout == 0 {
+		c.RequestTimeout = 30 * time.Second
+	}
+
+	if c.TTL == 0 {
+		c.TTL = 10 * time.Minute
+	}
+
+	if c.MaxPages == 0 {
+		c.MaxPages = 10
+	}
+
+	if len(c.Counts) == 0 {
+		c.Counts = map[string]string{"nodes": "fct_node_active_last_24h"}
+	}
+
+	// Validate ranges
+	if c.RefreshInterval < time.Second {
+		return fmt.Errorf("refresh_interval must be at least 1 second, got %v", c.RefreshInterval)
+	}
+
+	if c.RequestTimeout < time.Second {
+		return fmt.Errorf("request_timeout must be at least 1 second, got %v", c.RequestTimeout)
+	}
+
+	if c.TTL < c.RefreshInterval {
+		return fmt.Errorf("ttl (%v) must be at least refresh_interval (%v)", c.TTL, c.RefreshInterval)
+	}
+
+	if c.MaxPages < 1 {
+		return fmt.Errorf("max_pages must be at least 1, got %d", c.MaxPages)
+	}
+
+	for name, table := range c.Counts {
+		if name == "" || table == "" {
+			return fmt.Errorf("counts: name and table must be set, got %q: %q", name, table)
+		}
+	}
+
+	return nil
+}
+
+// HTTPClient returns the client used to count rows upstream.
+func (c *SummaryConfig) HTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   c.RequestTimeout,
+		Transport: upstream.NewTransport(upstream.SubsystemSummary, nil),
+	}
+}
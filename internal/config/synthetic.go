@@ -0,0 +1,86 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultSyntheticTables are the tables served by the synthetic network when none are configured.
+var DefaultSyntheticTables = []string{
+	"fct_block",
+	"fct_block_head",
+	"fct_attestation_correctness_head",
+}
+
+// SyntheticConfig enables a built-in network backed by a deterministic data
+// generator instead of a real CBT API, for end-to-end UI tests and demos.
+// Its bounds advance with the wallclock and table rows are derived from the
+// table name and slot, so the same request always returns the same data.
+type SyntheticConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	Name           string        `yaml:"name"`             // Network name (default "synthetic")
+	DisplayName    string        `yaml:"display_name"`     // Human-readable name (default "Synthetic")
+	ChainID        int64         `yaml:"chain_id"`         // Reported chain ID (default 1337)
+	GenesisTime    int64         `yaml:"genesis_time"`     // Unix genesis timestamp (default 1606824023)
+	SecondsPerSlot uint64        `yaml:"seconds_per_slot"` // Slot duration (default 12)
+	Retention      time.Duration `yaml:"retention"`        // How far back table bounds reach (default 24h)
+	Tables         []string      `yaml:"tables"`           // Tables with generated data (default fct_block, fct_block_head, fct_attestation_correctness_head)
+	ListenAddress  string        `yaml:"listen_address"`   // Loopback address the generator serves on (default "127.0.0.1:0", a random port)
+}
+
+// Validate validates the synthetic network configuration and sets defaults.
+func (c *SyntheticConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.Name == "" {
+		c.Name = "synthetic"
+	}
+
+	if c.DisplayName == "" {
+		c.DisplayName = "Synthetic"
+	}
+
+	if c.ChainID == 0 {
+		c.ChainID = 1337
+	}
+
+	if c.GenesisTime == 0 {
+		c.GenesisTime = 1606824023
+	}
+
+	if c.SecondsPerSlot == 0 {
+		c.SecondsPerSlot = 12
+	}
+
+	if c.Retention == 0 {
+		c.Retention = 24 * time.Hour
+	}
+
+	if len(c.Tables) == 0 {
+		c.Tables = DefaultSyntheticTables
+	}
+
+	if c.ListenAddress == "" {
+		c.ListenAddress = "127.0.0.1:0"
+	}
+
+	// Validate ranges
+	if c.Retention < time.Duration(c.SecondsPerSlot)*time.Second {
+		return fmt.Errorf("retention must be at least one slot, got %v", c.Retention)
+	}
+
+	if c.GenesisTime > time.Now().Unix() {
+		return fmt.Errorf("genesis_time must be in the past")
+	}
+
+	if _, _, err := net.SplitHostPort(c.ListenAddress); err != nil {
+		return fmt.Errorf("invalid listen_address: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import "fmt"
+
+// TablePagesConfig controls GET /api/v1/{network}/tables/{table}/pages,
+// which splits a slot range of a table into page queries.
+type TablePagesConfig struct {
+	Enabled         *bool  `yaml:"enabled"`           // Serve the endpoint (default true)
+	DefaultPageSize uint64 `yaml:"default_page_size"` // Slots per page without ?page_size= (default 100)
+	MaxPages        int    `yaml:"max_pages"`         // Pages listed per response before it is truncated (default 1000)
+}
+
+// Validate validates the table pages configuration and sets defaults.
+func (c *TablePagesConfig) Validate() error {
+	// Set defaults
+	if c.Enabled == nil {
+		enabled := true
+		c.Enabled = &enabled
+	}
+
+	if c.DefaultPageSize == 0 {
+		c.DefaultPageSize = 100
+	}
+
+	if c.MaxPages == 0 {
+		c.MaxPages = 1000
+	}
+
+	// Validate ranges
+	if c.MaxPages < 1 {
+		return fmt.Errorf("max_pages must be at least 1, got %d", c.MaxPages)
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether the endpoint is served.
+func (c *TablePagesConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
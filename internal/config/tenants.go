@@ -0,0 +1,97 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TenantConfig is a variant of the lab served by the same deployment, e.g. a
+// client-specific lab next to lab.ethpandaops.io. Requests select it by their
+// Host header or a path prefix, and see its networks, features and header
+// policies overlaid on the global ones.
+type TenantConfig struct {
+	Name       string            `yaml:"name"`
+	Hosts      []string          `yaml:"hosts"`       // Host names selecting the tenant, without port
+	PathPrefix string            `yaml:"path_prefix"` // Path prefix selecting the tenant, e.g. "/acme", stripped before routing
+	Networks   []string          `yaml:"networks"`    // Networks shown to the tenant (default: all)
+	Features   []FeatureSettings `yaml:"features"`    // Replace global features with the same path, others are added
+	Headers    []HeaderPolicy    `yaml:"headers"`     // Header policies evaluated before headers.policies
+}
+
+// Validate validates the tenant configuration.
+func (c *TenantConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if len(c.Hosts) == 0 && c.PathPrefix == "" {
+		return fmt.Errorf("hosts or path_prefix is required")
+	}
+
+	for i, host := range c.Hosts {
+		if host == "" || strings.ContainsAny(host, ":/") {
+			return fmt.Errorf("hosts[%d] must be a host name without port, got %q", i, host)
+		}
+
+		c.Hosts[i] = strings.ToLower(host)
+	}
+
+	if c.PathPrefix != "" {
+		if !strings.HasPrefix(c.PathPrefix, "/") || strings.HasSuffix(c.PathPrefix, "/") {
+			return fmt.Errorf("path_prefix must start and not end with /, got %q", c.PathPrefix)
+		}
+
+		if c.PathPrefix == "/api" || strings.HasPrefix(c.PathPrefix, "/api/") {
+			return fmt.Errorf("path_prefix must not be under /api, got %q", c.PathPrefix)
+		}
+	}
+
+	for i := range c.Features {
+		if err := c.Features[i].Validate(); err != nil {
+			return fmt.Errorf("features[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateTenants validates every tenant and checks that no two are selected
+// by the same name, host or path prefix.
+func (c *Config) validateTenants() error {
+	names := make(map[string]bool, len(c.Tenants))
+	hosts := make(map[string]string)
+	prefixes := make(map[string]string)
+
+	for i := range c.Tenants {
+		tenant := &c.Tenants[i]
+
+		if err := tenant.Validate(); err != nil {
+			return fmt.Errorf("tenants[%d]: %w", i, err)
+		}
+
+		if names[tenant.Name] {
+			return fmt.Errorf("duplicate tenant name: %s", tenant.Name)
+		}
+
+		names[tenant.Name] = true
+
+		for _, host := range tenant.Hosts {
+			if other, ok := hosts[host]; ok {
+				return fmt.Errorf("tenants %s and %s share host %s", other, tenant.Name, host)
+			}
+
+			hosts[host] = tenant.Name
+		}
+
+		if tenant.PathPrefix != "" {
+			if other, ok := prefixes[tenant.PathPrefix]; ok {
+				return fmt.Errorf("tenants %s and %s share path_prefix %s", other, tenant.Name, tenant.PathPrefix)
+			}
+
+			prefixes[tenant.PathPrefix] = tenant.Name
+		}
+	}
+
+	return nil
+}
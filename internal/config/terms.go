@@ -0,0 +1,86 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// TermsConfig controls terms-of-use acknowledgment gating for expensive endpoints.
+// Clients must accept the current terms version before requests to any of the
+// configured endpoint classes are served.
+type TermsConfig struct {
+	Enabled bool                 `yaml:"enabled"`
+	Version string               `yaml:"version"` // Terms version; bumping it invalidates prior acknowledgments
+	URL     string               `yaml:"url"`     // Where the terms can be read
+	Secret  string               `yaml:"secret"`  // HMAC key used to sign acknowledgment tokens
+	TTL     time.Duration        `yaml:"ttl"`     // How long an acknowledgment stays valid (default 720h)
+	Classes []TermsEndpointClass `yaml:"classes"` // Endpoint classes that require acknowledgment
+}
+
+// TermsEndpointClass is a named group of endpoints gated by the terms of use.
+type TermsEndpointClass struct {
+	Name        string   `yaml:"name"`
+	PathPattern string   `yaml:"path_pattern"` // Regex pattern to match request paths
+	Methods     []string `yaml:"methods"`      // HTTP methods gated (empty = all methods)
+}
+
+// Validate validates the terms configuration and sets defaults.
+func (c *TermsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.TTL == 0 {
+		c.TTL = 30 * 24 * time.Hour
+	}
+
+	if c.Version == "" {
+		return fmt.Errorf("version is required when enabled")
+	}
+
+	if len(c.Secret) < 16 {
+		return fmt.Errorf("secret must be at least 16 characters when enabled")
+	}
+
+	if c.URL != "" {
+		if _, err := url.ParseRequestURI(c.URL); err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+	}
+
+	if c.TTL < time.Minute {
+		return fmt.Errorf("ttl must be at least 1 minute, got %v", c.TTL)
+	}
+
+	if len(c.Classes) == 0 {
+		return fmt.Errorf("at least one endpoint class is required when enabled")
+	}
+
+	names := make(map[string]bool, len(c.Classes))
+
+	for i, class := range c.Classes {
+		if class.Name == "" {
+			return fmt.Errorf("classes[%d].name is required", i)
+		}
+
+		if names[class.Name] {
+			return fmt.Errorf("duplicate endpoint class name: %s", class.Name)
+		}
+
+		names[class.Name] = true
+
+		if class.PathPattern == "" {
+			return fmt.Errorf("classes[%d].path_pattern is required", i)
+		}
+
+		if _, err := regexp.Compile(class.PathPattern); err != nil {
+			return fmt.Errorf("classes[%d].path_pattern invalid regex: %w", i, err)
+		}
+	}
+
+	return nil
+}
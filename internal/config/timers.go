@@ -0,0 +1,39 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import "fmt"
+
+// TimersConfig spreads out periodic background work (bounds and cartographoor
+// refreshes, proxy sync, health polls, leader renewal, ...) so replicas and
+// components don't hit upstreams at the same moment.
+type TimersConfig struct {
+	// Jitter moves each tick by up to this fraction of its interval (default 0.1, max 0.5).
+	Jitter *float64 `yaml:"jitter"`
+	// Splay shifts each timer's phase by up to this fraction of its interval (default 1).
+	Splay *float64 `yaml:"splay"`
+}
+
+// Validate validates the timers configuration and sets defaults.
+func (c *TimersConfig) Validate() error {
+	// Set defaults
+	if c.Jitter == nil {
+		jitter := 0.1
+		c.Jitter = &jitter
+	}
+
+	if c.Splay == nil {
+		splay := 1.0
+		c.Splay = &splay
+	}
+
+	// Validate ranges
+	if *c.Jitter < 0 || *c.Jitter > 0.5 {
+		return fmt.Errorf("jitter must be between 0 and 0.5, got %v", *c.Jitter)
+	}
+
+	if *c.Splay < 0 || *c.Splay > 1 {
+		return fmt.Errorf("splay must be between 0 and 1, got %v", *c.Splay)
+	}
+
+	return nil
+}
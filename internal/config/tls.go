@@ -0,0 +1,84 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// TLS versions accepted as min_version.
+const (
+	TLSVersion12 = "1.2"
+	TLSVersion13 = "1.3"
+)
+
+// TLSConfig controls TLS termination by the HTTP server, with a certificate
+// from files or obtained from an ACME CA such as Let's Encrypt.
+type TLSConfig struct {
+	Enabled    bool       `yaml:"enabled"`
+	CertFile   string     `yaml:"cert_file"`   // PEM certificate chain
+	KeyFile    string     `yaml:"key_file"`    // PEM private key
+	MinVersion string     `yaml:"min_version"` // "1.2" (default) or "1.3"
+	ACME       ACMEConfig `yaml:"acme"`        // Used instead of cert_file and key_file when domains are set
+}
+
+// ACMEConfig obtains and renews certificates automatically, answering
+// TLS-ALPN-01 challenges on the server port, which must be reachable on 443.
+type ACMEConfig struct {
+	Domains      []string `yaml:"domains"`       // Host names certificates are requested for
+	Email        string   `yaml:"email"`         // Contact for expiry notices, optional
+	CacheDir     string   `yaml:"cache_dir"`     // Where account keys and certificates are kept (default .tmp/acme-cache)
+	DirectoryURL string   `yaml:"directory_url"` // ACME directory (default Let's Encrypt production)
+}
+
+// Validate validates the TLS configuration and sets defaults.
+func (c *TLSConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.MinVersion == "" {
+		c.MinVersion = TLSVersion12
+	}
+
+	if len(c.ACME.Domains) > 0 && c.ACME.CacheDir == "" {
+		c.ACME.CacheDir = ".tmp/acme-cache"
+	}
+
+	// Validate
+	if c.MinVersion != TLSVersion12 && c.MinVersion != TLSVersion13 {
+		return fmt.Errorf("min_version must be %s or %s, got %q", TLSVersion12, TLSVersion13, c.MinVersion)
+	}
+
+	hasFiles := c.CertFile != "" || c.KeyFile != ""
+
+	switch {
+	case hasFiles && len(c.ACME.Domains) > 0:
+		return errors.New("cert_file/key_file and acme.domains are mutually exclusive")
+	case hasFiles && (c.CertFile == "" || c.KeyFile == ""):
+		return errors.New("cert_file and key_file must be set together")
+	case !hasFiles && len(c.ACME.Domains) == 0:
+		return errors.New("cert_file and key_file, or acme.domains, are required")
+	}
+
+	for _, domain := range c.ACME.Domains {
+		if domain == "" {
+			return errors.New("acme.domains must not contain empty names")
+		}
+	}
+
+	if c.ACME.DirectoryURL != "" {
+		if u, err := url.Parse(c.ACME.DirectoryURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("acme.directory_url must be an https URL, got %q", c.ACME.DirectoryURL)
+		}
+	}
+
+	return nil
+}
+
+// UsesACME reports whether certificates are obtained through ACME.
+func (c *TLSConfig) UsesACME() bool {
+	return c.Enabled && len(c.ACME.Domains) > 0
+}
@@ -0,0 +1,102 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// TokenIssuanceConfig controls self-service API key issuance: a caller asks
+// for a key with an email address, receives a one-time code and exchanges it
+// for a key with the default tier, networks and scopes. Requires auth to be enabled.
+type TokenIssuanceConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	VerifyURL      string        `yaml:"verify_url"`      // Page linked in the email, the code is appended as ?code= (default: code only)
+	CodeTTL        time.Duration `yaml:"code_ttl"`        // How long a verification code is valid (default 30m)
+	KeyTTL         time.Duration `yaml:"key_ttl"`         // Lifetime of issued keys (default 90 days)
+	Tier           string        `yaml:"tier"`            // Tier of issued keys (default "free")
+	Networks       []string      `yaml:"networks"`        // Networks of issued keys (default: all)
+	Scopes         []string      `yaml:"scopes"`          // Scopes of issued keys (default: config, proxy)
+	AllowedDomains []string      `yaml:"allowed_domains"` // Only issue keys to these email domains (default: any)
+	RedisPrefix    string        `yaml:"redis_prefix"`    // Redis key prefix for pending verifications (default "lab:auth:issuance:")
+	SMTP           SMTPConfig    `yaml:"smtp"`            // Mail server the verification emails are sent through
+}
+
+// SMTPConfig is an outgoing mail server.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`     // Default 587, STARTTLS is used when offered
+	Username string `yaml:"username"` // Optional, PLAIN auth
+	Password string `yaml:"password"`
+	From     string `yaml:"from"` // Sender address
+}
+
+// Validate validates the token issuance configuration and sets defaults.
+func (c *TokenIssuanceConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	// Set defaults
+	if c.CodeTTL == 0 {
+		c.CodeTTL = 30 * time.Minute
+	}
+
+	if c.KeyTTL == 0 {
+		c.KeyTTL = 90 * 24 * time.Hour
+	}
+
+	if c.Tier == "" {
+		c.Tier = TierFree
+	}
+
+	if c.Scopes == nil {
+		c.Scopes = []string{ScopeConfig, ScopeProxy}
+	}
+
+	if c.RedisPrefix == "" {
+		c.RedisPrefix = "lab:auth:issuance:"
+	}
+
+	if c.SMTP.Port == 0 {
+		c.SMTP.Port = 587
+	}
+
+	// Validate ranges
+	if c.CodeTTL < time.Minute {
+		return fmt.Errorf("code_ttl must be at least 1m, got %v", c.CodeTTL)
+	}
+
+	if c.KeyTTL < 0 {
+		return fmt.Errorf("key_ttl must not be negative, got %v", c.KeyTTL)
+	}
+
+	if !slices.Contains(Tiers, c.Tier) {
+		return fmt.Errorf("unknown tier %q (valid: %v)", c.Tier, Tiers)
+	}
+
+	for i, scope := range c.Scopes {
+		if !slices.Contains(Scopes, scope) {
+			return fmt.Errorf("scopes[%d]: unknown scope %q (valid: %v)", i, scope, Scopes)
+		}
+	}
+
+	if c.VerifyURL != "" {
+		if u, err := url.Parse(c.VerifyURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("verify_url must be an absolute URL, got %q", c.VerifyURL)
+		}
+	}
+
+	if c.SMTP.Host == "" {
+		return fmt.Errorf("smtp.host is required")
+	}
+
+	if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+		return fmt.Errorf("smtp.from: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import "fmt"
+
+// TracingConfig controls request tracing integration.
+type TracingConfig struct {
+	// Enabled honours incoming W3C trace context (traceparent). Latency histogram
+	// observations for sampled requests carry the trace ID as an exemplar.
+	// Spans are created for incoming requests, upstream HTTP calls, Redis
+	// operations and the bounds/cartographoor refresh loops, and exported over
+	// OTLP/HTTP when endpoint is set.
+	Enabled bool `yaml:"enabled"`
+
+	Endpoint    string            `yaml:"endpoint"`     // OTLP/HTTP collector host:port, e.g. "otel-collector:4318"; empty disables export
+	Insecure    bool              `yaml:"insecure"`     // Export over plain HTTP instead of HTTPS
+	Headers     map[string]string `yaml:"headers"`      // Extra headers sent to the collector (e.g. auth)
+	ServiceName string            `yaml:"service_name"` // service.name of exported spans (default "lab-backend")
+	SampleRatio float64           `yaml:"sample_ratio"` // Fraction of new traces sampled (default 1); incoming decisions are honoured
+}
+
+// Validate validates the tracing configuration and sets defaults.
+func (c *TracingConfig) Validate() error {
+	// Set defaults
+	if c.ServiceName == "" {
+		c.ServiceName = "lab-backend"
+	}
+
+	if c.SampleRatio == 0 {
+		c.SampleRatio = 1
+	}
+
+	// Validate ranges
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("sample_ratio must be between 0 and 1, got %v", c.SampleRatio)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// UpstreamAuthConfig holds credentials attached to every request sent to an
+// upstream. Credentials are never served to clients.
+type UpstreamAuthConfig struct {
+	Headers     map[string]string `yaml:"headers,omitempty"`      // Headers set on every upstream request
+	BearerToken string            `yaml:"bearer_token,omitempty"` // Sent as "Authorization: Bearer <token>"
+	BasicAuth   *BasicAuthConfig  `yaml:"basic_auth,omitempty"`   // Sent as "Authorization: Basic ..."
+}
+
+// BasicAuthConfig is a username and password for HTTP basic auth.
+type BasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Validate validates the credentials.
+func (c *UpstreamAuthConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	for name := range c.Headers {
+		if !validHeaderName(name) {
+			return fmt.Errorf("headers: invalid header name %q", name)
+		}
+	}
+
+	if c.BasicAuth != nil && c.BasicAuth.Username == "" {
+		return fmt.Errorf("basic_auth.username cannot be empty")
+	}
+
+	if c.BearerToken != "" && c.BasicAuth != nil {
+		return fmt.Errorf("bearer_token and basic_auth are mutually exclusive")
+	}
+
+	if c.BearerToken != "" || c.BasicAuth != nil {
+		for name := range c.Headers {
+			if http.CanonicalHeaderKey(name) == "Authorization" {
+				return fmt.Errorf("headers: Authorization is set by bearer_token or basic_auth")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply sets the credentials on an outgoing request, replacing any inbound
+// values. A nil config leaves the request unchanged.
+func (c *UpstreamAuthConfig) Apply(req *http.Request) {
+	if c == nil {
+		return
+	}
+
+	for name, value := range c.Headers {
+		req.Header.Set(name, value)
+	}
+
+	switch {
+	case c.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.BearerToken)
+	case c.BasicAuth != nil:
+		req.SetBasicAuth(c.BasicAuth.Username, c.BasicAuth.Password)
+	}
+}
+
+// Equal reports whether two auth configs hold the same credentials.
+func (c *UpstreamAuthConfig) Equal(other *UpstreamAuthConfig) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+
+	if c.BearerToken != other.BearerToken || !maps.Equal(c.Headers, other.Headers) {
+		return false
+	}
+
+	if c.BasicAuth == nil || other.BasicAuth == nil {
+		return c.BasicAuth == other.BasicAuth
+	}
+
+	return *c.BasicAuth == *other.BasicAuth
+}
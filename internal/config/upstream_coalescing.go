@@ -0,0 +1,52 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// UpstreamCoalescingConfig controls the coalescing of identical outbound
+// requests. While a GET to an upstream is in flight, identical ones from the
+// proxy, bounds fetcher or cartographoor refresh wait for its response
+// instead of being sent again.
+type UpstreamCoalescingConfig struct {
+	Enabled      *bool `yaml:"enabled"`        // Coalesce identical requests (default true)
+	MaxBodyBytes int64 `yaml:"max_body_bytes"` // Largest response body shared with waiting requests; larger ones make them send their own (default 8MiB)
+}
+
+// Validate validates the upstream coalescing configuration and sets defaults.
+func (c *UpstreamCoalescingConfig) Validate() error {
+	// Set defaults
+	if c.Enabled == nil {
+		enabled := true
+		c.Enabled = &enabled
+	}
+
+	if c.MaxBodyBytes == 0 {
+		c.MaxBodyBytes = 8 << 20
+	}
+
+	// Validate ranges
+	if c.MaxBodyBytes < 0 {
+		return fmt.Errorf("max_body_bytes must be positive, got %d", c.MaxBodyBytes)
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether identical requests are coalesced.
+func (c *UpstreamCoalescingConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// Coalescing returns the coalescing to configure the upstream package with,
+// the zero value when coalescing is disabled.
+func (c *UpstreamCoalescingConfig) Coalescing() upstream.Coalescing {
+	if !c.IsEnabled() {
+		return upstream.Coalescing{}
+	}
+
+	return upstream.Coalescing{MaxBodyBytes: c.MaxBodyBytes}
+}
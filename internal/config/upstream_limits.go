@@ -0,0 +1,74 @@
+//nolint:tagliatelle // superior snake-case yo.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// UpstreamLimitsConfig bounds the concurrent requests the proxy and bounds
+// fetcher send to each upstream host, so a burst of frontend traffic can't
+// overwhelm small devnet CBT APIs. Requests beyond max_concurrent wait in a
+// queue; once it is full or the wait times out, proxied requests get a 503
+// with Retry-After.
+type UpstreamLimitsConfig struct {
+	Enabled       bool           `yaml:"enabled"`
+	MaxConcurrent int            `yaml:"max_concurrent"` // Requests in flight per upstream host (default 32)
+	MaxQueue      int            `yaml:"max_queue"`      // Requests waiting for a slot per host, beyond which they are rejected (default 100)
+	QueueTimeout  time.Duration  `yaml:"queue_timeout"`  // Longest wait for a slot (default 5s)
+	Hosts         map[string]int `yaml:"hosts"`          // max_concurrent overrides per upstream host[:port]
+}
+
+// Validate validates the upstream limits configuration and sets defaults.
+func (c *UpstreamLimitsConfig) Validate() error {
+	// Set defaults
+	if c.MaxConcurrent == 0 {
+		c.MaxConcurrent = 32
+	}
+
+	if c.MaxQueue == 0 {
+		c.MaxQueue = 100
+	}
+
+	if c.QueueTimeout == 0 {
+		c.QueueTimeout = 5 * time.Second
+	}
+
+	// Validate ranges
+	if c.MaxConcurrent < 1 {
+		return fmt.Errorf("max_concurrent must be at least 1, got %d", c.MaxConcurrent)
+	}
+
+	if c.MaxQueue < 0 {
+		return fmt.Errorf("max_queue must not be negative, got %d", c.MaxQueue)
+	}
+
+	if c.QueueTimeout < 0 {
+		return fmt.Errorf("queue_timeout must be positive, got %v", c.QueueTimeout)
+	}
+
+	for host, limit := range c.Hosts {
+		if limit < 1 {
+			return fmt.Errorf("hosts.%s must be at least 1, got %d", host, limit)
+		}
+	}
+
+	return nil
+}
+
+// Limits returns the limits to configure the upstream package with, the zero
+// value when limiting is disabled.
+func (c *UpstreamLimitsConfig) Limits() upstream.Limits {
+	if !c.Enabled {
+		return upstream.Limits{}
+	}
+
+	return upstream.Limits{
+		MaxConcurrent: c.MaxConcurrent,
+		MaxQueue:      c.MaxQueue,
+		QueueTimeout:  c.QueueTimeout,
+		Hosts:         c.Hosts,
+	}
+}
@@ -0,0 +1,69 @@
+// Package contentcoding negotiates the compression of response bodies with
+// the Accept-Encoding header.
+package contentcoding
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Content codings responses are compressed with.
+const (
+	Brotli = "br"
+	Gzip   = "gzip"
+)
+
+// Accepted returns the codings of Brotli and Gzip that an Accept-Encoding
+// header allows, most preferred first: higher quality wins, brotli over gzip
+// at equal quality.
+func Accepted(header string) []string {
+	qualities := make(map[string]float64)
+
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			q = parsed
+		}
+
+		qualities[name] = q
+	}
+
+	quality := func(encoding string) float64 {
+		if q, ok := qualities[encoding]; ok {
+			return q
+		}
+
+		return qualities["*"]
+	}
+
+	br, gz := quality(Brotli), quality(Gzip)
+
+	encodings := make([]string, 0, 2)
+
+	if br > 0 && br >= gz {
+		encodings = append(encodings, Brotli)
+	}
+
+	if gz > 0 {
+		encodings = append(encodings, Gzip)
+	}
+
+	if br > 0 && br < gz {
+		encodings = append(encodings, Brotli)
+	}
+
+	return encodings
+}
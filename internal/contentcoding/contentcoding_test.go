@@ -0,0 +1,29 @@
+package contentcoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccepted(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{name: "none", header: "", expected: []string{}},
+		{name: "brotli preferred at equal quality", header: "gzip, deflate, br", expected: []string{"br", "gzip"}},
+		{name: "gzip only", header: "gzip", expected: []string{"gzip"}},
+		{name: "higher quality wins", header: "br;q=0.5, gzip;q=0.9", expected: []string{"gzip", "br"}},
+		{name: "q=0 refuses", header: "br;q=0, gzip", expected: []string{"gzip"}},
+		{name: "wildcard", header: "*", expected: []string{"br", "gzip"}},
+		{name: "identity only", header: "identity", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Accepted(tt.header))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package dashboards
+
+import (
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// RuleFile is a Prometheus alerting rules file.
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup is a named group of alerting rules.
+type RuleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a Prometheus alerting rule.
+type Rule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// alert is an alerting rule and the metrics its expression needs.
+type alert struct {
+	rule    Rule
+	metrics []string
+}
+
+// alerts are the alerting rules shipped with the binary. A rule is only
+// exported if every metric it uses is registered.
+var alerts = []alert{
+	{
+		metrics: []string{"http_requests_total"},
+		rule: Rule{
+			Alert: "LabBackendHighErrorRate",
+			Expr: `sum(rate(http_requests_total{status=~"5.."}[5m])) ` +
+				`/ sum(rate(http_requests_total[5m])) > 0.05`,
+			For:    "10m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "More than 5% of lab-backend requests are failing",
+				"description": "{{ $value | humanizePercentage }} of HTTP requests returned a 5xx status over the last 5 minutes.",
+			},
+		},
+	},
+	{
+		metrics: []string{"upstream_slo_burn_rate"},
+		rule: Rule{
+			Alert:  "LabBackendUpstreamSLOBurn",
+			Expr:   `max by (subsystem, host, slo) (upstream_slo_burn_rate) > 2`,
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Upstream {{ $labels.host }} is burning its {{ $labels.slo }} error budget",
+				"description": "{{ $labels.subsystem }} requests to {{ $labels.host }} are burning the error budget {{ $value | humanize }}x faster than sustainable.",
+			},
+		},
+	},
+	{
+		metrics: []string{"bounds_circuit_breaker_state"},
+		rule: Rule{
+			Alert:  "LabBackendBoundsCircuitOpen",
+			Expr:   `max by (network) (bounds_circuit_breaker_state) == 2`,
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Bounds fetching for {{ $labels.network }} is disabled by its circuit breaker",
+				"description": "The bounds circuit breaker for {{ $labels.network }} has been open for 15 minutes; bounds are stale.",
+			},
+		},
+	},
+	{
+		metrics: []string{"background_task_panics_total"},
+		rule: Rule{
+			Alert:  "LabBackendBackgroundTaskPanics",
+			Expr:   `sum by (task) (increase(background_task_panics_total[15m])) > 0`,
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "Background task {{ $labels.task }} panicked",
+				"description": "Background task {{ $labels.task }} panicked and was restarted; see /admin/v1/runtime/tasks.",
+			},
+		},
+	},
+	{
+		metrics: []string{"http_rate_limit_errors_total"},
+		rule: Rule{
+			Alert:  "LabBackendRateLimiterErrors",
+			Expr:   `sum(rate(http_rate_limit_errors_total[5m])) > 0`,
+			For:    "5m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     "The rate limiter is failing",
+				"description": "Rate limit checks are erroring, so requests are failing open or closed per rate_limiting.failure_mode.",
+			},
+		},
+	},
+	{
+		metrics: []string{"read_only_mode"},
+		rule: Rule{
+			Alert:  "LabBackendReadOnly",
+			Expr:   `max(read_only_mode) == 1`,
+			For:    "1h",
+			Labels: map[string]string{"severity": "info"},
+			Annotations: map[string]string{
+				"summary":     "lab-backend has been in read-only mode for an hour",
+				"description": "Mutating requests are being rejected; lift read-only mode once the migration or incident is over.",
+			},
+		},
+	},
+}
+
+// AlertRules returns the alerting rules whose metrics are all in defs.
+func AlertRules(defs []metrics.Definition) RuleFile {
+	registered := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		registered[def.Name] = true
+	}
+
+	group := RuleGroup{Name: "lab-backend", Rules: []Rule{}}
+
+	for _, a := range alerts {
+		if allRegistered(registered, a.metrics) {
+			group.Rules = append(group.Rules, a.rule)
+		}
+	}
+
+	return RuleFile{Groups: []RuleGroup{group}}
+}
+
+func allRegistered(registered map[string]bool, names []string) bool {
+	for _, name := range names {
+		if !registered[name] {
+			return false
+		}
+	}
+
+	return true
+}
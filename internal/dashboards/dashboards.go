@@ -0,0 +1,269 @@
+// Package dashboards generates a Grafana dashboard and Prometheus alert rules
+// from the metric catalogue, so monitoring assets stay in lock-step with the
+// metrics the binary registers.
+package dashboards
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// Dashboard identity, stable across versions so imports replace the old dashboard.
+const (
+	dashboardUID   = "lab-backend"
+	dashboardTitle = "Lab Backend"
+)
+
+// Grafana layout, in grid units (the grid is 24 wide).
+const (
+	panelWidth  = 12
+	panelHeight = 8
+	gridWidth   = 24
+)
+
+// datasource refers to the dashboard's Prometheus datasource variable.
+var datasource = Datasource{Type: "prometheus", UID: "${datasource}"}
+
+// GrafanaDashboard is the subset of the Grafana dashboard JSON model we generate.
+type GrafanaDashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Tags          []string   `json:"tags"`
+	Editable      bool       `json:"editable"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the dashboard's default time range.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the dashboard variables.
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a dashboard variable.
+type Variable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+// Datasource references a Grafana datasource.
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// GridPos positions a panel on the dashboard grid.
+type GridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Panel is a dashboard row or time series panel.
+type Panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	GridPos     GridPos      `json:"gridPos"`
+	Datasource  *Datasource  `json:"datasource,omitempty"`
+	Targets     []Target     `json:"targets,omitempty"`
+	FieldConfig *FieldConfig `json:"fieldConfig,omitempty"`
+}
+
+// Target is a panel query.
+type Target struct {
+	RefID        string      `json:"refId"`
+	Datasource   *Datasource `json:"datasource"`
+	Expr         string      `json:"expr"`
+	LegendFormat string      `json:"legendFormat,omitempty"`
+}
+
+// FieldConfig sets the display unit of a panel.
+type FieldConfig struct {
+	Defaults FieldDefaults `json:"defaults"`
+}
+
+// FieldDefaults holds the default field options.
+type FieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+// Dashboard returns a dashboard with one panel per metric, grouped into a row
+// per metric name prefix (http, upstream, proxy, ...).
+func Dashboard(defs []metrics.Definition, version string) GrafanaDashboard {
+	dashboard := GrafanaDashboard{
+		UID:           dashboardUID,
+		Title:         dashboardTitle,
+		Description:   fmt.Sprintf("Generated by lab-backend %s from its registered metrics", version),
+		Tags:          []string{"lab-backend", "generated", "version:" + version},
+		Editable:      true,
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          TimeRange{From: "now-6h", To: "now"},
+		Templating: Templating{List: []Variable{{
+			Name:  "datasource",
+			Label: "Datasource",
+			Type:  "datasource",
+			Query: "prometheus",
+		}}},
+	}
+
+	var (
+		id    int
+		y     int
+		group string
+		col   int
+	)
+
+	for _, def := range defs {
+		if prefix := groupOf(def.Name); prefix != group {
+			if col > 0 {
+				y += panelHeight
+			}
+
+			group, col = prefix, 0
+			id++
+
+			dashboard.Panels = append(dashboard.Panels, Panel{
+				ID:      id,
+				Type:    "row",
+				Title:   titleCase(prefix),
+				GridPos: GridPos{X: 0, Y: y, W: gridWidth, H: 1},
+			})
+
+			y++
+		}
+
+		id++
+
+		dashboard.Panels = append(dashboard.Panels, Panel{
+			ID:          id,
+			Type:        "timeseries",
+			Title:       panelTitle(def),
+			Description: def.Help,
+			GridPos:     GridPos{X: col * panelWidth, Y: y, W: panelWidth, H: panelHeight},
+			Datasource:  &datasource,
+			Targets: []Target{{
+				RefID:        "A",
+				Datasource:   &datasource,
+				Expr:         query(def),
+				LegendFormat: legend(def.Labels),
+			}},
+			FieldConfig: &FieldConfig{Defaults: FieldDefaults{Unit: unit(def)}},
+		})
+
+		col++
+		if col*panelWidth >= gridWidth {
+			col = 0
+			y += panelHeight
+		}
+	}
+
+	return dashboard
+}
+
+// query returns the PromQL plotted for a metric: rates for counters, values
+// for gauges, p95 for histograms and the mean for summaries.
+func query(def metrics.Definition) string {
+	by := ""
+	if len(def.Labels) > 0 {
+		by = " by (" + strings.Join(def.Labels, ", ") + ")"
+	}
+
+	switch def.Kind {
+	case metrics.KindCounter:
+		return fmt.Sprintf("sum%s (rate(%s[$__rate_interval]))", by, def.Name)
+	case metrics.KindHistogram:
+		labels := append([]string{"le"}, def.Labels...)
+
+		return fmt.Sprintf(
+			"histogram_quantile(0.95, sum by (%s) (rate(%s_bucket[$__rate_interval])))",
+			strings.Join(labels, ", "), def.Name,
+		)
+	case metrics.KindSummary:
+		return fmt.Sprintf(
+			"sum%[1]s (rate(%[2]s_sum[$__rate_interval])) / sum%[1]s (rate(%[2]s_count[$__rate_interval]))",
+			by, def.Name,
+		)
+	default:
+		return fmt.Sprintf("%s%s (%s)", gaugeAggregation(def.Name), by, def.Name)
+	}
+}
+
+// gaugeAggregation sums gauges that count things across instances, and takes
+// the max of states and ratios, which every instance reports in full.
+func gaugeAggregation(name string) string {
+	for _, suffix := range []string{"_state", "_mode", "_ratio", "_rate"} {
+		if strings.HasSuffix(name, suffix) {
+			return "max"
+		}
+	}
+
+	return "sum"
+}
+
+func panelTitle(def metrics.Definition) string {
+	switch def.Kind {
+	case metrics.KindCounter:
+		return def.Name + " (rate)"
+	case metrics.KindHistogram:
+		return def.Name + " (p95)"
+	case metrics.KindSummary:
+		return def.Name + " (mean)"
+	default:
+		return def.Name
+	}
+}
+
+func unit(def metrics.Definition) string {
+	switch {
+	case strings.HasSuffix(def.Name, "_seconds"):
+		return "s"
+	case strings.HasSuffix(def.Name, "_bytes"):
+		return "bytes"
+	case strings.HasSuffix(def.Name, "_ratio"):
+		return "percentunit"
+	case def.Kind == metrics.KindCounter:
+		return "ops"
+	default:
+		return "short"
+	}
+}
+
+func legend(labels []string) string {
+	parts := make([]string, 0, len(labels))
+	for _, label := range labels {
+		parts = append(parts, "{{"+label+"}}")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func groupOf(name string) string {
+	prefix, _, _ := strings.Cut(name, "_")
+
+	return prefix
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,83 @@
+package dashboards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+var testDefinitions = []metrics.Definition{
+	{Name: "bounds_circuit_breaker_state", Kind: metrics.KindGauge, Labels: []string{"network"}},
+	{Name: "http_request_duration_seconds", Kind: metrics.KindHistogram, Labels: []string{"method", "path"}},
+	{Name: "http_requests_total", Kind: metrics.KindCounter, Labels: []string{"method", "path", "status"}},
+	{Name: "push_websocket_clients", Kind: metrics.KindGauge},
+}
+
+func TestDashboard(t *testing.T) {
+	dashboard := Dashboard(testDefinitions, "v1.2.3")
+
+	assert.Equal(t, dashboardUID, dashboard.UID)
+	assert.Contains(t, dashboard.Tags, "version:v1.2.3")
+	assert.Contains(t, dashboard.Description, "v1.2.3")
+
+	// Rows for bounds, http and push, plus one panel per metric
+	require.Len(t, dashboard.Panels, 7)
+
+	exprs := make(map[string]string)
+	ids := make(map[int]bool)
+
+	for _, panel := range dashboard.Panels {
+		assert.False(t, ids[panel.ID], "duplicate panel id %d", panel.ID)
+		ids[panel.ID] = true
+
+		if panel.Type == "row" {
+			continue
+		}
+
+		require.Len(t, panel.Targets, 1)
+		exprs[panel.Title] = panel.Targets[0].Expr
+	}
+
+	assert.Equal(t,
+		"max by (network) (bounds_circuit_breaker_state)",
+		exprs["bounds_circuit_breaker_state"],
+	)
+	assert.Equal(t,
+		"histogram_quantile(0.95, sum by (le, method, path) (rate(http_request_duration_seconds_bucket[$__rate_interval])))",
+		exprs["http_request_duration_seconds (p95)"],
+	)
+	assert.Equal(t,
+		"sum by (method, path, status) (rate(http_requests_total[$__rate_interval]))",
+		exprs["http_requests_total (rate)"],
+	)
+	assert.Equal(t, "sum (push_websocket_clients)", exprs["push_websocket_clients"])
+}
+
+func TestDashboard_Layout(t *testing.T) {
+	dashboard := Dashboard(testDefinitions, "dev")
+
+	// Panels never overlap
+	for i, a := range dashboard.Panels {
+		for _, b := range dashboard.Panels[i+1:] {
+			overlap := a.GridPos.X < b.GridPos.X+b.GridPos.W && b.GridPos.X < a.GridPos.X+a.GridPos.W &&
+				a.GridPos.Y < b.GridPos.Y+b.GridPos.H && b.GridPos.Y < a.GridPos.Y+a.GridPos.H
+			assert.False(t, overlap, "panels %q and %q overlap", a.Title, b.Title)
+		}
+	}
+}
+
+func TestAlertRules(t *testing.T) {
+	rules := AlertRules(testDefinitions)
+	require.Len(t, rules.Groups, 1)
+
+	names := make([]string, 0, len(rules.Groups[0].Rules))
+	for _, rule := range rules.Groups[0].Rules {
+		names = append(names, rule.Alert)
+	}
+
+	// Only rules whose metrics are registered are exported
+	assert.ElementsMatch(t, []string{"LabBackendHighErrorRate", "LabBackendBoundsCircuitOpen"}, names)
+}
@@ -0,0 +1,307 @@
+// Package denylist blocks requests from IPs and CIDR ranges listed in Redis.
+// Entries are added and removed at runtime, expire on their own, and are
+// shared by every instance, which reloads them periodically.
+package denylist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/rediswatch"
+)
+
+// pollTimeout bounds each reload from Redis.
+const pollTimeout = 5 * time.Second
+
+var (
+	entriesGauge = metrics.NewGauge(prometheus.GaugeOpts{
+		Name: "deny_list_entries",
+		Help: "Number of deny list entries loaded",
+	})
+
+	blockedTotal = metrics.NewCounter(prometheus.CounterOpts{
+		Name: "deny_list_blocked_total",
+		Help: "Requests rejected because their IP is on the deny list",
+	})
+)
+
+// ErrInvalidCIDR is returned for entries that are neither an IP nor a CIDR range.
+var ErrInvalidCIDR = errors.New("invalid IP or CIDR")
+
+// entries is an immutable snapshot of the deny list.
+type entries struct {
+	hosts    map[string]*Entry // Single IPs, by canonical address
+	networks []network         // Wider ranges
+	all      []Entry
+}
+
+type network struct {
+	ipNet *net.IPNet
+	entry *Entry
+}
+
+// List is the deny list of this instance, reloaded from Redis.
+type List struct {
+	cfg   config.DenyListConfig
+	log   logrus.FieldLogger
+	redis redis.Client
+
+	current *rediswatch.Watcher[entries]
+}
+
+// New creates a deny list.
+func New(log logrus.FieldLogger, cfg config.DenyListConfig, redisClient redis.Client) *List {
+	l := &List{
+		cfg:   cfg,
+		log:   log.WithField("component", "deny_list"),
+		redis: redisClient,
+	}
+
+	l.current = rediswatch.New(l.log, rediswatch.Config[entries]{
+		Name:     "deny_list.poll",
+		Interval: cfg.PollInterval,
+		Timeout:  pollTimeout,
+		Load:     l.load,
+		OnChange: func(_, next *entries) { entriesGauge.Set(float64(len(next.all))) },
+	})
+
+	return l
+}
+
+// Start loads the entries once and then reloads them in the background.
+func (l *List) Start() {
+	l.current.Start()
+}
+
+// Stop stops reloading and waits for it to finish.
+func (l *List) Stop() {
+	l.current.Stop()
+}
+
+// Blocked returns the entry blocking ip, if any.
+func (l *List) Blocked(ip string) (*Entry, bool) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, false
+	}
+
+	current := l.entries()
+
+	entry, ok := current.hosts[addr.String()]
+	if !ok {
+		for _, n := range current.networks {
+			if n.ipNet.Contains(addr) {
+				entry, ok = n.entry, true
+
+				break
+			}
+		}
+	}
+
+	if !ok || entry.expired(time.Now()) {
+		return nil, false
+	}
+
+	blockedTotal.Inc()
+
+	return entry, true
+}
+
+// Entries returns every entry loaded, in CIDR order.
+func (l *List) Entries() []Entry {
+	return l.entries().all
+}
+
+// entries returns the loaded snapshot, empty before the first load.
+func (l *List) entries() *entries {
+	if current := l.current.Value(); current != nil {
+		return current
+	}
+
+	return newEntries(nil)
+}
+
+// Add blocks an IP or CIDR range for every instance, until ttl has passed
+// (0 = until removed). Adding a listed range again replaces its entry.
+func (l *List) Add(ctx context.Context, cidr, reason string, ttl time.Duration) (*Entry, error) {
+	ipNet, err := parseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		CIDR:    ipNet.String(),
+		Reason:  reason,
+		AddedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if ttl > 0 {
+		expiresAt := entry.AddedAt.Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("marshal deny list entry: %w", err)
+	}
+
+	if err := l.redis.Set(ctx, l.cfg.KeyPrefix+entry.CIDR, string(data), ttl); err != nil {
+		return nil, fmt.Errorf("store deny list entry: %w", err)
+	}
+
+	l.log.WithFields(logrus.Fields{
+		"cidr":   entry.CIDR,
+		"reason": reason,
+		"ttl":    ttl,
+	}).Warn("Added deny list entry")
+
+	return entry, l.current.Refresh(ctx)
+}
+
+// Remove unblocks an IP or CIDR range for every instance. It reports whether
+// the range was listed.
+func (l *List) Remove(ctx context.Context, cidr string) (bool, error) {
+	ipNet, err := parseCIDR(cidr)
+	if err != nil {
+		return false, err
+	}
+
+	key := l.cfg.KeyPrefix + ipNet.String()
+
+	_, err = l.redis.Get(ctx, key)
+	if errors.Is(err, redis.ErrNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("read deny list entry: %w", err)
+	}
+
+	if err := l.redis.Del(ctx, key); err != nil {
+		return false, fmt.Errorf("delete deny list entry: %w", err)
+	}
+
+	l.log.WithField("cidr", ipNet.String()).Warn("Removed deny list entry")
+
+	return true, l.current.Refresh(ctx)
+}
+
+// load reads every entry from Redis. On Redis errors the last loaded
+// entries are kept, so an outage does not lift blocks.
+func (l *List) load(ctx context.Context) (*entries, error) {
+	keys, err := l.redis.Keys(ctx, l.cfg.KeyPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("list deny list keys: %w", err)
+	}
+
+	if len(keys) > l.cfg.MaxEntries {
+		l.log.WithFields(logrus.Fields{
+			"entries":     len(keys),
+			"max_entries": l.cfg.MaxEntries,
+		}).Warn("Deny list has too many entries, ignoring the rest")
+
+		keys = keys[:l.cfg.MaxEntries]
+	}
+
+	// Pipelined, so a long list is loaded in one round trip per node
+	pipe := l.redis.GetClient().Pipeline()
+
+	for _, key := range keys {
+		pipe.Get(ctx, key)
+	}
+
+	// Exec reports keys that expired since they were listed as redis.Nil
+	results, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return nil, fmt.Errorf("read deny list entries: %w", err)
+	}
+
+	loaded := make([]Entry, 0, len(keys))
+
+	for i, result := range results {
+		cmd, ok := result.(*goredis.StringCmd)
+		if !ok || cmd.Err() != nil {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(cmd.Val()), &entry); err != nil {
+			l.log.WithError(err).WithField("key", keys[i]).Warn("Ignoring malformed deny list entry")
+
+			continue
+		}
+
+		loaded = append(loaded, entry)
+	}
+
+	return newEntries(loaded), nil
+}
+
+// newEntries indexes loaded entries. Entries with an invalid CIDR are skipped.
+func newEntries(loaded []Entry) *entries {
+	slices.SortFunc(loaded, func(a, b Entry) int { return strings.Compare(a.CIDR, b.CIDR) })
+
+	e := &entries{
+		hosts: make(map[string]*Entry),
+		all:   make([]Entry, 0, len(loaded)),
+	}
+
+	for _, entry := range loaded {
+		ipNet, err := parseCIDR(entry.CIDR)
+		if err != nil {
+			continue
+		}
+
+		// all has room for every entry, so pointers into it stay valid
+		e.all = append(e.all, entry)
+		stored := &e.all[len(e.all)-1]
+
+		if ones, bits := ipNet.Mask.Size(); ones == bits {
+			e.hosts[ipNet.IP.String()] = stored
+
+			continue
+		}
+
+		e.networks = append(e.networks, network{ipNet: ipNet, entry: stored})
+	}
+
+	return e
+}
+
+// parseCIDR parses a CIDR range, or a single IP as a /32 or /128.
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	cidr = strings.TrimSpace(cidr)
+
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCIDR, cidr)
+		}
+
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCIDR, cidr)
+	}
+
+	return ipNet, nil
+}
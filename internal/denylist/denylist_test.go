@@ -0,0 +1,115 @@
+package denylist
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func newTestList(t *testing.T) (*List, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	cfg := config.DenyListConfig{Enabled: true, PollInterval: time.Hour}
+	require.NoError(t, cfg.Validate())
+
+	list := New(logger, cfg, client)
+	list.Start()
+	t.Cleanup(list.Stop)
+
+	return list, mr
+}
+
+func TestList_AddAndRemove(t *testing.T) {
+	list, _ := newTestList(t)
+
+	_, blocked := list.Blocked("192.0.2.1")
+	assert.False(t, blocked)
+
+	entry, err := list.Add(t.Context(), "192.0.2.1", "scraper", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1/32", entry.CIDR)
+	assert.Nil(t, entry.ExpiresAt)
+
+	_, err = list.Add(t.Context(), "2001:db8::/32", "abuse", 0)
+	require.NoError(t, err)
+
+	got, blocked := list.Blocked("192.0.2.1")
+	require.True(t, blocked)
+	assert.Equal(t, "scraper", got.Reason)
+
+	_, blocked = list.Blocked("2001:db8::42")
+	assert.True(t, blocked, "addresses in a listed range are blocked")
+
+	_, blocked = list.Blocked("192.0.2.2")
+	assert.False(t, blocked)
+
+	assert.Len(t, list.Entries(), 2)
+
+	removed, err := list.Remove(t.Context(), "192.0.2.1/32")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	_, blocked = list.Blocked("192.0.2.1")
+	assert.False(t, blocked)
+
+	removed, err = list.Remove(t.Context(), "192.0.2.1")
+	require.NoError(t, err)
+	assert.False(t, removed, "removing an unlisted range reports it")
+}
+
+func TestList_InvalidCIDR(t *testing.T) {
+	list, _ := newTestList(t)
+
+	_, err := list.Add(t.Context(), "not-an-ip", "", 0)
+	require.ErrorIs(t, err, ErrInvalidCIDR)
+
+	_, err = list.Remove(t.Context(), "10.0.0.0/33")
+	require.ErrorIs(t, err, ErrInvalidCIDR)
+}
+
+func TestList_Expiry(t *testing.T) {
+	list, mr := newTestList(t)
+
+	entry, err := list.Add(t.Context(), "198.51.100.0/24", "temporary", time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, entry.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *entry.ExpiresAt, 2*time.Second)
+
+	_, blocked := list.Blocked("198.51.100.7")
+	assert.True(t, blocked)
+
+	// Redis expires the entry; the next reload drops it
+	mr.FastForward(2 * time.Hour)
+	require.NoError(t, list.current.Refresh(t.Context()))
+
+	_, blocked = list.Blocked("198.51.100.7")
+	assert.False(t, blocked)
+	assert.Empty(t, list.Entries())
+}
+
+func TestList_KeepsEntriesOnRedisFailure(t *testing.T) {
+	list, mr := newTestList(t)
+
+	_, err := list.Add(t.Context(), "192.0.2.1", "", 0)
+	require.NoError(t, err)
+
+	mr.Close()
+
+	require.Error(t, list.current.Refresh(t.Context()))
+
+	_, blocked := list.Blocked("192.0.2.1")
+	assert.True(t, blocked, "a Redis outage must not lift blocks")
+}
@@ -0,0 +1,16 @@
+//nolint:tagliatelle // superior snake-case yo.
+package denylist
+
+import "time"
+
+// Entry is a blocked IP or CIDR range.
+type Entry struct {
+	CIDR      string     `json:"cidr"`
+	Reason    string     `json:"reason,omitempty"`
+	AddedAt   time.Time  `json:"added_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Absent for entries that never expire
+}
+
+func (e *Entry) expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
@@ -0,0 +1,274 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+const (
+	// ModeDNS resolves all A/AAAA records behind the target hostname.
+	ModeDNS = "dns"
+	// ModeSRV resolves SRV records and uses each target:port as an instance.
+	ModeSRV = "srv"
+)
+
+// refreshTimeout bounds each resolution.
+const refreshTimeout = 10 * time.Second
+
+// Config holds discovery settings for a single upstream.
+type Config struct {
+	Mode            string
+	Service         string
+	Protocol        string
+	RefreshInterval time.Duration
+}
+
+// lookuper is the subset of net.Resolver used for discovery.
+type lookuper interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// Resolver periodically resolves a hostname into its individual instances
+// (multiple A/AAAA records or SRV targets) and hands them out round-robin
+// when new connections are dialed.
+type Resolver struct {
+	log    logrus.FieldLogger
+	cfg    Config
+	host   string
+	port   string
+	lookup lookuper
+	dialer *net.Dialer
+
+	mu      sync.RWMutex
+	addrs   []string
+	counter atomic.Uint64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	task *tasks.Task
+}
+
+// NewResolver creates a resolver for the host in targetURL.
+func NewResolver(log logrus.FieldLogger, cfg Config, targetURL string) (*Resolver, error) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target URL: %w", err)
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	return &Resolver{
+		log: log.WithFields(logrus.Fields{
+			"component": "discovery",
+			"host":      parsed.Hostname(),
+			"mode":      cfg.Mode,
+		}),
+		cfg:    cfg,
+		host:   parsed.Hostname(),
+		port:   port,
+		lookup: net.DefaultResolver,
+		dialer: &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Start performs an initial resolution and starts the background refresh
+// loop, which runs until Stop is called or ctx is canceled.
+// A failed initial resolution is not fatal: dials fall back to the plain hostname.
+func (r *Resolver) Start(ctx context.Context) {
+	initCtx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	err := r.refresh(initCtx)
+
+	cancel()
+
+	if err != nil {
+		r.log.WithError(err).Warn("Initial discovery failed, falling back to hostname")
+	}
+
+	r.task = tasks.Default().Register("discovery."+r.host, r.cfg.RefreshInterval)
+	r.wg.Add(1)
+
+	go r.refreshLoop(ctx)
+}
+
+// Stop stops the background refresh loop.
+func (r *Resolver) Stop() {
+	close(r.done)
+	r.wg.Wait()
+
+	tasks.Default().Unregister(r.task)
+}
+
+// Config returns the discovery configuration of this resolver.
+func (r *Resolver) Config() Config {
+	return r.cfg
+}
+
+// Addresses returns the currently known instance addresses (host:port).
+func (r *Resolver) Addresses() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.addrs)
+}
+
+// Next returns the next instance address in round-robin order.
+// Returns false if no instances are known.
+func (r *Resolver) Next() (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.addrs) == 0 {
+		return "", false
+	}
+
+	idx := r.counter.Add(1) - 1
+
+	return r.addrs[idx%uint64(len(r.addrs))], true
+}
+
+// DialContext dials the next discovered instance when addr refers to the
+// resolver's host, and addr unchanged otherwise. Suitable for http.Transport.DialContext.
+// The Host header and TLS server name keep using the original hostname.
+func (r *Resolver) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err == nil && host == r.host {
+		if instance, ok := r.Next(); ok {
+			addr = instance
+		}
+	}
+
+	return r.dialer.DialContext(ctx, network, addr)
+}
+
+// refreshLoop runs the refresh loop under the task supervisor, which
+// restarts it with backoff if it panics.
+func (r *Resolver) refreshLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	r.task.Supervise(r.log, r.done, func() { r.runRefreshLoop(ctx) })
+}
+
+func (r *Resolver) runRefreshLoop(ctx context.Context) {
+	ticker := jitter.NewTicker(r.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refreshCtx, cancel := context.WithTimeout(ctx, refreshTimeout)
+
+			if err := r.task.Run(func() error { return r.refresh(refreshCtx) }); err != nil {
+				r.log.WithError(err).Warn("Discovery refresh failed, keeping last known instances")
+			}
+
+			cancel()
+		}
+	}
+}
+
+// refresh resolves the current set of instances.
+// The previous set is kept if resolution fails or returns nothing.
+func (r *Resolver) refresh(ctx context.Context) error {
+	var (
+		addrs []string
+		err   error
+	)
+
+	switch r.cfg.Mode {
+	case ModeSRV:
+		addrs, err = r.resolveSRV(ctx)
+	default:
+		addrs, err = r.resolveHost(ctx)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(addrs) == 0 {
+		return fmt.Errorf("no instances found for %s", r.host)
+	}
+
+	slices.Sort(addrs)
+
+	r.mu.Lock()
+	changed := !slices.Equal(r.addrs, addrs)
+	r.addrs = addrs
+	r.mu.Unlock()
+
+	if changed {
+		r.log.WithField("instances", addrs).Info("Discovered upstream instances")
+	}
+
+	return nil
+}
+
+// resolveHost returns every A/AAAA record for the host joined with the target port.
+func (r *Resolver) resolveHost(ctx context.Context) ([]string, error) {
+	ips, err := r.lookup.LookupHost(ctx, r.host)
+	if err != nil {
+		return nil, fmt.Errorf("lookup host: %w", err)
+	}
+
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.JoinHostPort(ip, r.port))
+	}
+
+	return addrs, nil
+}
+
+// resolveSRV returns the SRV targets with the lowest (most preferred) priority.
+func (r *Resolver) resolveSRV(ctx context.Context) ([]string, error) {
+	_, records, err := r.lookup.LookupSRV(ctx, r.cfg.Service, r.cfg.Protocol, r.host)
+	if err != nil {
+		return nil, fmt.Errorf("lookup srv: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// Records are returned sorted by priority
+	priority := records[0].Priority
+	addrs := make([]string, 0, len(records))
+
+	for _, record := range records {
+		if record.Priority != priority {
+			break
+		}
+
+		target := record.Target
+		if len(target) > 0 && target[len(target)-1] == '.' {
+			target = target[:len(target)-1]
+		}
+
+		addrs = append(addrs, net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+
+	return addrs, nil
+}
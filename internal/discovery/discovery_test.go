@@ -0,0 +1,186 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLookuper struct {
+	hosts map[string][]string
+	srv   []*net.SRV
+	err   error
+}
+
+func (f *fakeLookuper) LookupHost(_ context.Context, host string) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.hosts[host], nil
+}
+
+func (f *fakeLookuper) LookupSRV(_ context.Context, _, _, _ string) (string, []*net.SRV, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
+
+	return "", f.srv, nil
+}
+
+func newTestResolver(t *testing.T, cfg Config, targetURL string, lookup lookuper) *Resolver {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = time.Hour
+	}
+
+	r, err := NewResolver(logger, cfg, targetURL)
+	require.NoError(t, err)
+
+	r.lookup = lookup
+
+	return r
+}
+
+func TestResolver_DNSMode(t *testing.T) {
+	lookup := &fakeLookuper{
+		hosts: map[string][]string{
+			"cbt-api.svc": {"10.0.0.2", "10.0.0.1"},
+		},
+	}
+
+	r := newTestResolver(t, Config{Mode: ModeDNS}, "https://cbt-api.svc/api/v1", lookup)
+	require.NoError(t, r.refresh(context.Background()))
+
+	assert.Equal(t, []string{"10.0.0.1:443", "10.0.0.2:443"}, r.Addresses())
+
+	// Round-robin across instances
+	first, ok := r.Next()
+	require.True(t, ok)
+
+	second, ok := r.Next()
+	require.True(t, ok)
+
+	third, ok := r.Next()
+	require.True(t, ok)
+
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, first, third)
+}
+
+func TestResolver_SRVMode(t *testing.T) {
+	lookup := &fakeLookuper{
+		srv: []*net.SRV{
+			{Target: "cbt-0.cbt.svc.", Port: 8080, Priority: 10},
+			{Target: "cbt-1.cbt.svc.", Port: 8080, Priority: 10},
+			{Target: "cbt-backup.svc.", Port: 9090, Priority: 20},
+		},
+	}
+
+	r := newTestResolver(t, Config{Mode: ModeSRV, Service: "http", Protocol: "tcp"}, "http://cbt.svc", lookup)
+	require.NoError(t, r.refresh(context.Background()))
+
+	// Only the most preferred priority is used
+	assert.Equal(t, []string{"cbt-0.cbt.svc:8080", "cbt-1.cbt.svc:8080"}, r.Addresses())
+}
+
+func TestResolver_KeepsLastKnownOnFailure(t *testing.T) {
+	lookup := &fakeLookuper{
+		hosts: map[string][]string{"cbt-api.svc": {"10.0.0.1"}},
+	}
+
+	r := newTestResolver(t, Config{Mode: ModeDNS}, "http://cbt-api.svc:8080", lookup)
+	require.NoError(t, r.refresh(context.Background()))
+
+	lookup.err = fmt.Errorf("dns unavailable")
+	require.Error(t, r.refresh(context.Background()))
+
+	assert.Equal(t, []string{"10.0.0.1:8080"}, r.Addresses())
+
+	// Empty result is also treated as a failure
+	lookup.err = nil
+	lookup.hosts = map[string][]string{}
+	require.Error(t, r.refresh(context.Background()))
+
+	assert.Equal(t, []string{"10.0.0.1:8080"}, r.Addresses())
+}
+
+func TestResolver_DialContext(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	// A hostname that doesn't resolve, discovery maps it to the test server
+	lookup := &fakeLookuper{
+		hosts: map[string][]string{"cbt-api.invalid": {backendURL.Hostname()}},
+	}
+
+	r := newTestResolver(t, Config{Mode: ModeDNS}, "http://cbt-api.invalid:"+backendURL.Port(), lookup)
+	require.NoError(t, r.refresh(context.Background()))
+
+	client := &http.Client{Transport: &http.Transport{DialContext: r.DialContext}}
+
+	req, err := http.NewRequestWithContext(
+		context.Background(),
+		http.MethodGet,
+		"http://cbt-api.invalid:"+backendURL.Port()+"/health",
+		http.NoBody,
+	)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", string(body))
+}
+
+func TestResolver_StopsOnContextCancel(t *testing.T) {
+	lookup := &fakeLookuper{hosts: map[string][]string{"cbt-api.svc": {"10.0.0.1"}}}
+	r := newTestResolver(t, Config{Mode: ModeDNS}, "http://cbt-api.svc", lookup)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	r.Start(ctx)
+
+	defer r.Stop()
+
+	assert.Equal(t, []string{"10.0.0.1:80"}, r.Addresses())
+
+	// The refresh loop exits with its context, before Stop is called
+	cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("refresh loop still running after cancel")
+	}
+}
@@ -0,0 +1,41 @@
+// Package errs defines the sentinel errors returned by data providers, and how
+// handlers map them to HTTP statuses. Providers wrap them with context, so
+// callers match with errors.Is rather than on error text.
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrNotFound is returned when the requested network or data doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrStale is returned together with the last known data when it hasn't
+	// been refreshed recently. Callers may serve the data with a warning.
+	ErrStale = errors.New("data is stale")
+
+	// ErrUpstreamUnavailable is returned when an upstream API or Redis could
+	// not be reached or answered with an error.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+
+	// ErrNotLeader is returned when a leader-only operation runs on a follower.
+	ErrNotLeader = errors.New("not the leader")
+)
+
+// HTTPStatus returns the HTTP status for err: 404 for ErrNotFound, 502 for
+// ErrUpstreamUnavailable, 503 for ErrStale and ErrNotLeader, and 500 for
+// anything else.
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUpstreamUnavailable):
+		return http.StatusBadGateway
+	case errors.Is(err, ErrStale), errors.Is(err, ErrNotLeader):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,29 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: fmt.Errorf("bounds for mainnet: %w", ErrNotFound), want: http.StatusNotFound},
+		{err: fmt.Errorf("read bounds: %w: timeout", ErrUpstreamUnavailable), want: http.StatusBadGateway},
+		{err: ErrStale, want: http.StatusServiceUnavailable},
+		{err: ErrNotLeader, want: http.StatusServiceUnavailable},
+		{err: errors.New("unmarshal bounds"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, HTTPStatus(tt.err))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package frontend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// immutableCacheControl is sent for fingerprinted files, whose name changes
+// with their content.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// viteManifests are where Vite writes its build manifest, by Vite version.
+var viteManifests = []string{".vite/manifest.json", "manifest.json"}
+
+// viteChunk is the part of a Vite build manifest entry naming its output files.
+type viteChunk struct {
+	File   string   `json:"file"`
+	CSS    []string `json:"css"`
+	Assets []string `json:"assets"`
+}
+
+// assetManifest knows which bundle files are fingerprinted, from the build's
+// asset manifest, and the hashes of all files, from SHA256SUMS, which become
+// their ETags.
+type assetManifest struct {
+	fingerprinted map[string]bool
+	sums          map[string]string
+}
+
+// readAssetManifest reads the asset manifests of a bundle. Without a Vite
+// build manifest no file is treated as fingerprinted.
+func readAssetManifest(log logrus.FieldLogger, fsys fs.FS) *assetManifest {
+	m := &assetManifest{fingerprinted: make(map[string]bool)}
+
+	if sums, err := readManifest(fsys); err == nil {
+		m.sums = sums.files
+	}
+
+	for _, name := range viteManifests {
+		data, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		chunks, err := parseViteManifest(data)
+		if err != nil {
+			// manifest.json may as well be a web app manifest
+			log.WithError(err).WithField("file", name).Debug("Not a Vite build manifest")
+
+			continue
+		}
+
+		for _, chunk := range chunks {
+			for _, file := range append(append([]string{chunk.File}, chunk.CSS...), chunk.Assets...) {
+				if file != "" {
+					m.fingerprinted[path.Clean(file)] = true
+				}
+			}
+		}
+
+		log.WithFields(logrus.Fields{
+			"file":          name,
+			"fingerprinted": len(m.fingerprinted),
+		}).Info("Loaded frontend asset manifest")
+
+		return m
+	}
+
+	log.Warn("Frontend bundle has no Vite build manifest, no asset is cached as immutable")
+
+	return m
+}
+
+// parseViteManifest returns the entries of a Vite build manifest.
+func parseViteManifest(data []byte) (map[string]viteChunk, error) {
+	var chunks map[string]viteChunk
+	if err := json.Unmarshal(data, &chunks); err != nil {
+		return nil, fmt.Errorf("parse build manifest: %w", err)
+	}
+
+	return chunks, nil
+}
+
+// cacheControl returns the Cache-Control of a bundle file: immutable when it
+// is fingerprinted, otherwise cached for maxAge and revalidated after.
+func (m *assetManifest) cacheControl(name string, maxAge time.Duration) string {
+	if m != nil && m.fingerprinted[name] {
+		return immutableCacheControl
+	}
+
+	return fmt.Sprintf("public, max-age=%d, must-revalidate", int64(maxAge.Seconds()))
+}
+
+// etag returns the ETag of a bundle file in the given content coding, empty
+// when its hash is unknown. Each coding gets its own tag, as their bodies differ.
+func (m *assetManifest) etag(name, encoding string) string {
+	if m == nil {
+		return ""
+	}
+
+	sum, ok := m.sums[name]
+	if !ok {
+		return ""
+	}
+
+	if encoding != "" {
+		return `"` + sum[:16] + "-" + encoding + `"`
+	}
+
+	return `"` + sum[:16] + `"`
+}
+
+// bundleModTime is the Last-Modified of bundle files without a modification
+// time, which embed.FS never has: the build time, else now, so clients
+// revalidate after restarts.
+func bundleModTime() time.Time {
+	if t, ok := version.BuildTime(); ok {
+		return t
+	}
+
+	return time.Now().UTC().Truncate(time.Second)
+}
@@ -0,0 +1,193 @@
+package frontend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+func TestFrontend_AssetCacheHeaders(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	sum := func(data string) string {
+		h := sha256.Sum256([]byte(data))
+
+		return hex.EncodeToString(h[:])
+	}
+
+	fsys := fstest.MapFS{
+		"index.html":            &fstest.MapFile{Data: []byte("<html><head></head></html>")},
+		"assets/app-4f2a9c.js":  &fstest.MapFile{Data: []byte("app")},
+		"assets/app-7d1e0b.css": &fstest.MapFile{Data: []byte("css")},
+		"favicon.ico":           &fstest.MapFile{Data: []byte("icon")},
+		"manifest.json":         &fstest.MapFile{Data: []byte(`{"name":"Lab","icons":[]}`)},
+		".vite/manifest.json": &fstest.MapFile{Data: []byte(
+			`{"index.html":{"file":"assets/app-4f2a9c.js","src":"index.html","isEntry":true,"css":["assets/app-7d1e0b.css"]}}`,
+		)},
+		manifestFile: &fstest.MapFile{Data: []byte(sum("icon") + "  favicon.ico\n" + sum("app") + "  assets/app-4f2a9c.js\n")},
+	}
+
+	f := &Frontend{fs: fsys, logger: logger, assetMaxAge: 5 * time.Minute}
+	f.assets.Store(readAssetManifest(logger, fsys))
+
+	serve := func(path, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		rec := httptest.NewRecorder()
+		f.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// Files from the build manifest are immutable
+	rec := serve("/assets/app-4f2a9c.js", "")
+	assert.Equal(t, immutableCacheControl, rec.Header().Get("Cache-Control"))
+	assert.Equal(t, `"`+sum("app")[:16]+`"`, rec.Header().Get("ETag"))
+
+	rec = serve("/assets/app-7d1e0b.css", "")
+	assert.Equal(t, immutableCacheControl, rec.Header().Get("Cache-Control"))
+	assert.Empty(t, rec.Header().Get("ETag"))
+
+	// Everything else is cached briefly and revalidated by ETag
+	rec = serve("/favicon.ico", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=300, must-revalidate", rec.Header().Get("Cache-Control"))
+
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, http.StatusNotModified, serve("/favicon.ico", etag).Code)
+
+	rec = serve("/manifest.json", "")
+	assert.Equal(t, "public, max-age=300, must-revalidate", rec.Header().Get("Cache-Control"))
+}
+
+func TestReadAssetManifest(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Older Vite versions write the manifest to the bundle root
+	m := readAssetManifest(logger, fstest.MapFS{
+		"manifest.json": &fstest.MapFile{Data: []byte(`{"main.ts":{"file":"assets/main-1a2b3c.js","assets":["assets/logo-9f8e7d.svg"]}}`)},
+	})
+	assert.True(t, m.fingerprinted["assets/main-1a2b3c.js"])
+	assert.True(t, m.fingerprinted["assets/logo-9f8e7d.svg"])
+
+	// Without a build manifest nothing is immutable
+	m = readAssetManifest(logger, fstest.MapFS{"assets/main-1a2b3c.js": &fstest.MapFile{Data: []byte("x")}})
+	assert.Empty(t, m.fingerprinted)
+	assert.Equal(t, "public, max-age=60, must-revalidate", m.cacheControl("assets/main-1a2b3c.js", time.Minute))
+}
+
+func TestFrontend_RangeAndConditionalRequests(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	video := strings.Repeat("0123456789", 200)
+	wasm := strings.Repeat("(module)", 500)
+
+	// Like embed.FS, the files have no modification time
+	fsys := fstest.MapFS{
+		"media/intro.mp4": &fstest.MapFile{Data: []byte(video)},
+		"lib/engine.wasm": &fstest.MapFile{Data: []byte(wasm)},
+	}
+
+	compressed, err := precompressAssets(fsys, 1024)
+	require.NoError(t, err)
+	require.Contains(t, compressed, "lib/engine.wasm")
+
+	built := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := &Frontend{fs: fsys, compressed: compressed, logger: logger, modTime: built}
+
+	serve := func(method, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, http.NoBody)
+		maps.Copy(req.Header, header)
+
+		rec := httptest.NewRecorder()
+		f.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	lastModified := built.Format(http.TimeFormat)
+
+	t.Run("HEAD", func(t *testing.T) {
+		rec := serve(http.MethodHead, "/media/intro.mp4", nil)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "video/mp4", rec.Header().Get("Content-Type"))
+		assert.Equal(t, strconv.Itoa(len(video)), rec.Header().Get("Content-Length"))
+		assert.Equal(t, lastModified, rec.Header().Get("Last-Modified"))
+		assert.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+		assert.Zero(t, rec.Body.Len())
+	})
+
+	t.Run("range", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/media/intro.mp4", http.Header{"Range": {"bytes=10-19"}})
+
+		require.Equal(t, http.StatusPartialContent, rec.Code)
+		assert.Equal(t, "bytes 10-19/2000", rec.Header().Get("Content-Range"))
+		assert.Equal(t, "0123456789", rec.Body.String())
+	})
+
+	t.Run("range of a pre-compressed asset addresses the uncompressed file", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/lib/engine.wasm", http.Header{
+			"Range":           {"bytes=0-7"},
+			"Accept-Encoding": {"br, gzip"},
+		})
+
+		require.Equal(t, http.StatusPartialContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "application/wasm", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "(module)", rec.Body.String())
+	})
+
+	t.Run("If-Modified-Since", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/media/intro.mp4", http.Header{"If-Modified-Since": {lastModified}})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+
+		older := built.Add(-time.Hour).Format(http.TimeFormat)
+		rec = serve(http.MethodGet, "/media/intro.mp4", http.Header{"If-Modified-Since": {older}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("If-Range", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/media/intro.mp4", http.Header{"Range": {"bytes=0-9"}, "If-Range": {lastModified}})
+		assert.Equal(t, http.StatusPartialContent, rec.Code)
+
+		// A changed file is sent whole
+		older := built.Add(-time.Hour).Format(http.TimeFormat)
+		rec = serve(http.MethodGet, "/media/intro.mp4", http.Header{"Range": {"bytes=0-9"}, "If-Range": {older}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, len(video), rec.Body.Len())
+	})
+}
+
+func TestBundleModTime(t *testing.T) {
+	buildDate := version.BuildDate
+	t.Cleanup(func() { version.BuildDate = buildDate })
+
+	version.BuildDate = "2026-03-01T12:00:00Z"
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), bundleModTime())
+
+	// Unknown build dates fall back to a time that is never zero
+	version.BuildDate = "unknown"
+	assert.False(t, bundleModTime().IsZero())
+}
@@ -0,0 +1,378 @@
+package frontend
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// manifestFile lists the SHA-256 of every file in a frontend bundle, in
+// sha256sum format. It is written by `make setup-frontend`.
+const manifestFile = "SHA256SUMS"
+
+// maxBundleSize caps the size of a downloaded bundle tarball.
+const maxBundleSize = 512 << 20
+
+// Bundle sources, as logged at startup.
+const (
+	sourceEmbedded = "embedded"
+	sourceFetched  = "fetched"
+	sourceLocal    = "local"
+)
+
+var (
+	errBundleMissing   = errors.New("frontend bundle is missing index.html")
+	errManifestMissing = errors.New("frontend bundle has no " + manifestFile)
+)
+
+// bundle is a frontend filesystem and where it came from.
+type bundle struct {
+	fs     fs.FS
+	source string
+	digest string // SHA-256 of the manifest the bundle was verified against, empty if unverified
+}
+
+// manifest maps bundle file paths to their hex SHA-256.
+type manifest struct {
+	files  map[string]string
+	digest string
+}
+
+// readManifest parses the manifest of a bundle.
+func readManifest(fsys fs.FS) (*manifest, error) {
+	data, err := fs.ReadFile(fsys, manifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errManifestMissing
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", manifestFile, err)
+	}
+
+	m := &manifest{files: make(map[string]string)}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		sum, name, ok := strings.Cut(line, " ")
+		if !ok || len(sum) != sha256.Size*2 {
+			return nil, fmt.Errorf("malformed %s line: %q", manifestFile, line)
+		}
+
+		// sha256sum marks binary mode with a leading '*'
+		name = strings.TrimPrefix(strings.TrimLeft(name, " "), "*")
+		m.files[path.Clean(strings.TrimPrefix(name, "./"))] = strings.ToLower(sum)
+	}
+
+	if len(m.files) == 0 {
+		return nil, fmt.Errorf("%s lists no files", manifestFile)
+	}
+
+	digest := sha256.Sum256(data)
+	m.digest = hex.EncodeToString(digest[:])
+
+	return m, nil
+}
+
+// verify checks every file the manifest lists against its hash.
+func (m *manifest) verify(fsys fs.FS) error {
+	if _, err := fs.Stat(fsys, "index.html"); err != nil {
+		return errBundleMissing
+	}
+
+	for name, want := range m.files {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		_ = f.Close()
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			return fmt.Errorf("%s: sha256 %s does not match manifest %s", name, got, want)
+		}
+	}
+
+	return nil
+}
+
+// bundleLoader picks the bundle to serve.
+type bundleLoader struct {
+	cfg      config.FrontendConfig
+	log      logrus.FieldLogger
+	version  string // Frontend version substituted into the fallback URL
+	devBuild bool   // Unreleased builds may serve web/frontend from disk
+	client   *http.Client
+}
+
+// load returns the embedded bundle if it verifies. A bundle without a
+// manifest is served unverified. A missing or corrupted bundle is replaced by
+// one fetched from the fallback URL, verified against the embedded manifest
+// when it is readable; only dev builds fall back to the local web/frontend
+// directory instead.
+func (l *bundleLoader) load(ctx context.Context, embedded fs.FS) (*bundle, error) {
+	var expected *manifest
+
+	if embedded != nil {
+		m, err := readManifest(embedded)
+
+		switch {
+		case errors.Is(err, errManifestMissing):
+			if _, statErr := fs.Stat(embedded, "index.html"); statErr == nil {
+				l.log.Warn("Embedded frontend bundle has no " + manifestFile + ", serving it unverified")
+
+				return &bundle{fs: embedded, source: sourceEmbedded}, nil
+			}
+		case err != nil:
+			l.log.WithError(err).Error("Embedded frontend manifest is unreadable")
+		default:
+			if verifyErr := m.verify(embedded); verifyErr != nil {
+				l.log.WithError(verifyErr).Error("Embedded frontend bundle failed integrity check")
+
+				expected = m
+			} else {
+				l.log.WithField("digest", m.digest).Info("Embedded frontend bundle verified")
+
+				return &bundle{fs: embedded, source: sourceEmbedded, digest: m.digest}, nil
+			}
+		}
+	}
+
+	if l.devBuild && expected == nil {
+		l.log.Info("Embedded FS not available, using local filesystem (dev mode)")
+
+		return &bundle{fs: os.DirFS("web/frontend"), source: sourceLocal}, nil
+	}
+
+	b, err := l.fetch(ctx, expected)
+	if err != nil {
+		return nil, fmt.Errorf("no usable frontend bundle: %w", err)
+	}
+
+	return b, nil
+}
+
+// fallbackURL returns the configured fallback URL for this build.
+func (l *bundleLoader) fallbackURL() (string, error) {
+	if l.version == "" && strings.Contains(l.cfg.FallbackURL, "{version}") {
+		return "", errors.New("frontend version unknown, set frontend.version to fetch a bundle")
+	}
+
+	return strings.NewReplacer(
+		"{version}", l.version,
+		"{os}", runtime.GOOS,
+		"{arch}", runtime.GOARCH,
+	).Replace(l.cfg.FallbackURL), nil
+}
+
+// fetch returns a bundle from the cache directory, downloading it first unless
+// a verified copy is already cached.
+func (l *bundleLoader) fetch(ctx context.Context, expected *manifest) (*bundle, error) {
+	bundleURL, err := l.fallbackURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bundles are cached per URL, so each version and architecture gets its own
+	key := sha256.Sum256([]byte(bundleURL))
+	dir := filepath.Join(l.cfg.CacheDir, hex.EncodeToString(key[:8]))
+	log := l.log.WithFields(logrus.Fields{"url": bundleURL, "dir": dir})
+
+	if b, err := l.open(dir, expected); err == nil {
+		log.WithField("digest", b.digest).Info("Using cached frontend bundle")
+
+		return b, nil
+	}
+
+	log.Info("Fetching frontend bundle")
+
+	if err := os.MkdirAll(l.cfg.CacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+
+	tmp, err := os.MkdirTemp(l.cfg.CacheDir, ".fetch-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	if err := l.download(ctx, bundleURL, tmp); err != nil {
+		return nil, err
+	}
+
+	if _, err := l.open(tmp, expected); err != nil {
+		return nil, fmt.Errorf("fetched bundle: %w", err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("replace cached bundle: %w", err)
+	}
+
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, fmt.Errorf("replace cached bundle: %w", err)
+	}
+
+	b, err := l.open(dir, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("digest", b.digest).Warn("Serving fetched frontend bundle")
+
+	return b, nil
+}
+
+// open verifies a bundle directory against the expected manifest, or its own
+// manifest if none is expected.
+func (l *bundleLoader) open(dir string, expected *manifest) (*bundle, error) {
+	fsys := os.DirFS(dir)
+
+	m := expected
+	if m == nil {
+		var err error
+
+		m, err = readManifest(fsys)
+		if errors.Is(err, errManifestMissing) {
+			if _, statErr := fs.Stat(fsys, "index.html"); statErr != nil {
+				return nil, errBundleMissing
+			}
+
+			l.log.WithField("dir", dir).Warn("Fetched frontend bundle has no " + manifestFile + ", serving it unverified")
+
+			return &bundle{fs: fsys, source: sourceFetched}, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.verify(fsys); err != nil {
+		return nil, err
+	}
+
+	return &bundle{fs: fsys, source: sourceFetched, digest: m.digest}, nil
+}
+
+// download extracts the tar.gz at bundleURL into dir.
+func (l *bundleLoader) download(ctx context.Context, bundleURL, dir string) error {
+	ctx, cancel := context.WithTimeout(ctx, l.cfg.FetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bundleURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download bundle: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download bundle: unexpected status %d", resp.StatusCode)
+	}
+
+	gz, err := gzip.NewReader(io.LimitReader(resp.Body, maxBundleSize))
+	if err != nil {
+		return fmt.Errorf("read bundle: %w", err)
+	}
+
+	defer gz.Close()
+
+	return extractTar(tar.NewReader(gz), dir)
+}
+
+// extractTar writes the directories and regular files of an archive into dir,
+// rejecting entries that would land outside it.
+func extractTar(tr *tar.Reader, dir string) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("read bundle: %w", err)
+		}
+
+		name := filepath.FromSlash(path.Clean(strings.TrimPrefix(hdr.Name, "./")))
+		if name == "." {
+			continue
+		}
+
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("bundle entry %q escapes the bundle directory", hdr.Name)
+		}
+
+		target := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("extract %s: %w", hdr.Name, err)
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr); err != nil {
+				return fmt.Errorf("extract %s: %w", hdr.Name, err)
+			}
+
+			// Keep the build's modification time, so every replica sends the same Last-Modified
+			if !hdr.ModTime.IsZero() {
+				if err := os.Chtimes(target, hdr.ModTime, hdr.ModTime); err != nil {
+					return fmt.Errorf("extract %s: %w", hdr.Name, err)
+				}
+			}
+		}
+	}
+}
+
+func writeFile(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
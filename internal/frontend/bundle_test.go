@@ -0,0 +1,290 @@
+package frontend
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+var testBundleFiles = map[string]string{
+	"index.html":     "<html><head></head><body></body></html>",
+	"assets/app.js":  "console.log('lab')",
+	"assets/app.css": "body{}",
+}
+
+// testManifest returns a sha256sum style manifest for files.
+func testManifest(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+
+	for _, name := range names {
+		sum := sha256.Sum256([]byte(files[name]))
+		fmt.Fprintf(&buf, "%s  ./%s\n", hex.EncodeToString(sum[:]), name)
+	}
+
+	return buf.String()
+}
+
+func testMapFS(files map[string]string, manifest string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for name, data := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+
+	if manifest != "" {
+		fsys[manifestFile] = &fstest.MapFile{Data: []byte(manifest)}
+	}
+
+	return fsys
+}
+
+// testBundleTime is the modification time of files in test tarballs.
+var testBundleTime = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, data := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     "./" + name,
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			ModTime:  testBundleTime,
+			Typeflag: tar.TypeReg,
+		}))
+
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+// newTestLoader returns a loader fetching from a server that serves tarball,
+// and a counter of requests made to it.
+func newTestLoader(t *testing.T, tarball []byte, devBuild bool) (*bundleLoader, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Path != "/v1.2.3/lab.tar.gz" || tarball == nil {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(tarball)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return &bundleLoader{
+		cfg: config.FrontendConfig{
+			FallbackURL:  server.URL + "/{version}/lab.tar.gz",
+			CacheDir:     t.TempDir(),
+			FetchTimeout: 5 * time.Second,
+		},
+		log:      logger,
+		version:  "v1.2.3",
+		devBuild: devBuild,
+		client:   server.Client(),
+	}, &requests
+}
+
+func readIndex(t *testing.T, b *bundle) string {
+	t.Helper()
+
+	data, err := fs.ReadFile(b.fs, "index.html")
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestBundleLoader_EmbeddedVerified(t *testing.T) {
+	loader, requests := newTestLoader(t, nil, false)
+
+	b, err := loader.load(context.Background(), testMapFS(testBundleFiles, testManifest(testBundleFiles)))
+	require.NoError(t, err)
+
+	assert.Equal(t, sourceEmbedded, b.source)
+	assert.NotEmpty(t, b.digest)
+	assert.Zero(t, requests.Load())
+}
+
+func TestBundleLoader_EmbeddedWithoutManifest(t *testing.T) {
+	loader, requests := newTestLoader(t, nil, false)
+
+	b, err := loader.load(context.Background(), testMapFS(testBundleFiles, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, sourceEmbedded, b.source)
+	assert.Empty(t, b.digest)
+	assert.Zero(t, requests.Load())
+}
+
+func TestBundleLoader_CorruptedFallsBackToMatchingBundle(t *testing.T) {
+	manifest := testManifest(testBundleFiles)
+
+	corrupted := testMapFS(testBundleFiles, manifest)
+	corrupted["assets/app.js"] = &fstest.MapFile{Data: []byte("tampered")}
+
+	// The release tarball carries no manifest; it is checked against the embedded one
+	loader, requests := newTestLoader(t, testTarball(t, testBundleFiles), false)
+
+	b, err := loader.load(context.Background(), corrupted)
+	require.NoError(t, err)
+
+	assert.Equal(t, sourceFetched, b.source)
+	assert.Equal(t, testBundleFiles["index.html"], readIndex(t, b))
+	assert.Equal(t, int32(1), requests.Load())
+
+	// A second start reuses the verified cached copy
+	b, err = loader.load(context.Background(), corrupted)
+	require.NoError(t, err)
+
+	assert.Equal(t, sourceFetched, b.source)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestBundleLoader_CorruptedRejectsMismatchedBundle(t *testing.T) {
+	manifest := testManifest(testBundleFiles)
+
+	corrupted := testMapFS(testBundleFiles, manifest)
+	delete(corrupted, "assets/app.css")
+
+	other := map[string]string{
+		"index.html":     testBundleFiles["index.html"],
+		"assets/app.js":  "console.log('other version')",
+		"assets/app.css": testBundleFiles["assets/app.css"],
+	}
+
+	loader, _ := newTestLoader(t, testTarball(t, other), true)
+
+	_, err := loader.load(context.Background(), corrupted)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match manifest")
+}
+
+func TestBundleLoader_MissingBundle(t *testing.T) {
+	t.Run("release build fetches a bundle", func(t *testing.T) {
+		loader, requests := newTestLoader(t, testTarball(t, testBundleFiles), false)
+
+		b, err := loader.load(context.Background(), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, sourceFetched, b.source)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("release build without a bundle to fetch fails", func(t *testing.T) {
+		loader, _ := newTestLoader(t, nil, false)
+
+		_, err := loader.load(context.Background(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status 404")
+	})
+
+	t.Run("release build with unknown version fails", func(t *testing.T) {
+		loader, requests := newTestLoader(t, testTarball(t, testBundleFiles), false)
+		loader.version = ""
+
+		_, err := loader.load(context.Background(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "frontend.version")
+		assert.Zero(t, requests.Load())
+	})
+
+	t.Run("dev build uses local files", func(t *testing.T) {
+		loader, requests := newTestLoader(t, nil, true)
+
+		b, err := loader.load(context.Background(), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, sourceLocal, b.source)
+		assert.Zero(t, requests.Load())
+	})
+}
+
+func TestExtractTar_RejectsEscapingPaths(t *testing.T) {
+	tarball := testTarball(t, map[string]string{"../../evil.html": "x"})
+
+	gz, err := gzip.NewReader(bytes.NewReader(tarball))
+	require.NoError(t, err)
+
+	err = extractTar(tar.NewReader(gz), t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes the bundle directory")
+}
+
+func TestExtractTar_KeepsModTime(t *testing.T) {
+	gz, err := gzip.NewReader(bytes.NewReader(testTarball(t, testBundleFiles)))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, extractTar(tar.NewReader(gz), dir))
+
+	info, err := os.Stat(filepath.Join(dir, "assets", "app.js"))
+	require.NoError(t, err)
+	assert.True(t, testBundleTime.Equal(info.ModTime()))
+}
+
+func TestReadManifest(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		_, err := readManifest(fstest.MapFS{})
+		require.ErrorIs(t, err, errManifestMissing)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := readManifest(fstest.MapFS{manifestFile: &fstest.MapFile{Data: []byte("nope index.html\n")}})
+		require.Error(t, err)
+	})
+
+	t.Run("binary mode entries", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("x"))
+		data := hex.EncodeToString(sum[:]) + " *index.html\n"
+
+		m, err := readManifest(fstest.MapFS{manifestFile: &fstest.MapFile{Data: []byte(data)}})
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(sum[:]), m.files["index.html"])
+	})
+}
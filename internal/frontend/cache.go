@@ -26,6 +26,7 @@ func (ric *RouteIndexCache) PrewarmRoutes(
 	configData any,
 	boundsData any,
 	versionData any,
+	forksData any,
 ) error {
 	// Open and read index.html
 	file, err := filesystem.Open(indexFileName)
@@ -74,8 +75,8 @@ func (ric *RouteIndexCache) PrewarmRoutes(
 			continue
 		}
 
-		// Inject config, bounds, version, and route-specific head
-		injected, injectErr := InjectAll(original, configData, boundsData, versionData, routeHead.Raw)
+		// Inject config, bounds, version, forks, and route-specific head
+		injected, injectErr := InjectAll(original, configData, boundsData, versionData, forksData, routeHead.Raw)
 		if injectErr != nil {
 			logger.WithError(injectErr).WithField("route", route).Error("Failed to inject data for route")
 
@@ -90,7 +91,7 @@ func (ric *RouteIndexCache) PrewarmRoutes(
 	}
 
 	// Create default version with _default head (if exists) or empty
-	defaultInjected, err := InjectAll(original, configData, boundsData, versionData, defaultHeadRaw)
+	defaultInjected, err := InjectAll(original, configData, boundsData, versionData, forksData, defaultHeadRaw)
 	if err != nil {
 		return fmt.Errorf("failed to create default injected HTML: %w", err)
 	}
@@ -133,11 +134,38 @@ func (ric *RouteIndexCache) GetForRoute(route string) []byte {
 	return ric.original
 }
 
-// Update refreshes all cached routes with new config, bounds, and version data.
+// Render injects data into index.html for a route without caching the result.
+// Used for per-request variants, such as previews of hidden networks.
+func (ric *RouteIndexCache) Render(
+	route string,
+	configData any,
+	boundsData any,
+	versionData any,
+	forksData any,
+) ([]byte, error) {
+	ric.mu.RLock()
+	original, headData := ric.original, ric.headData
+	ric.mu.RUnlock()
+
+	// Strip query parameters and hash fragments
+	if idx := strings.IndexAny(route, "?#"); idx != -1 {
+		route = route[:idx]
+	}
+
+	var headRaw string
+	if routeHead := headData.GetRouteHead(route); routeHead != nil {
+		headRaw = routeHead.Raw
+	}
+
+	return InjectAll(original, configData, boundsData, versionData, forksData, headRaw)
+}
+
+// Update refreshes all cached routes with new config, bounds, version, and fork schedule data.
 func (ric *RouteIndexCache) Update(
 	configData any,
 	boundsData any,
 	versionData any,
+	forksData any,
 ) error {
 	ric.mu.Lock()
 	defer ric.mu.Unlock()
@@ -155,8 +183,8 @@ func (ric *RouteIndexCache) Update(
 			continue
 		}
 
-		// Inject config, bounds, version, and route-specific head
-		injected, err := InjectAll(ric.original, configData, boundsData, versionData, routeHead.Raw)
+		// Inject config, bounds, version, forks, and route-specific head
+		injected, err := InjectAll(ric.original, configData, boundsData, versionData, forksData, routeHead.Raw)
 		if err != nil {
 			return fmt.Errorf("failed to inject data for route %s: %w", route, err)
 		}
@@ -164,7 +192,7 @@ func (ric *RouteIndexCache) Update(
 		newRoutes[route] = injected
 	}
 
-	defaultInjected, err := InjectAll(ric.original, configData, boundsData, versionData, defaultHeadRaw)
+	defaultInjected, err := InjectAll(ric.original, configData, boundsData, versionData, forksData, defaultHeadRaw)
 	if err != nil {
 		return fmt.Errorf("failed to create default injected HTML: %w", err)
 	}
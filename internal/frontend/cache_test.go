@@ -1,9 +1,12 @@
 package frontend
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"testing/fstest"
@@ -11,6 +14,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
 )
 
 func TestRouteIndexCache_PrewarmRoutes(t *testing.T) {
@@ -110,6 +117,7 @@ func TestRouteIndexCache_PrewarmRoutes(t *testing.T) {
 				tt.configData,
 				tt.boundsData,
 				tt.versionData,
+				nil,
 			)
 
 			if tt.expectError {
@@ -160,6 +168,7 @@ func TestRouteIndexCache_GetForRoute(t *testing.T) {
 		map[string]string{"test": "data"},
 		map[string]string{},
 		map[string]string{"version": "v1.0.0"},
+		map[string]string{},
 	)
 	require.NoError(t, err)
 
@@ -209,6 +218,7 @@ func TestRouteIndexCache_GetOriginal(t *testing.T) {
 		map[string]string{},
 		map[string]string{},
 		map[string]string{},
+		map[string]string{},
 	)
 	require.NoError(t, err)
 
@@ -243,6 +253,7 @@ func TestRouteIndexCache_Update(t *testing.T) {
 		map[string]string{"version": "1.0"},
 		map[string]string{},
 		map[string]string{"version": "v1.0.0"},
+		map[string]string{},
 	)
 	require.NoError(t, err)
 
@@ -254,6 +265,7 @@ func TestRouteIndexCache_Update(t *testing.T) {
 		map[string]string{"version": "2.0"},
 		map[string]int{"max": 200},
 		map[string]string{"version": "v2.0.0"},
+		map[string]string{"mainnet": "electra"},
 	)
 	require.NoError(t, err)
 
@@ -267,6 +279,36 @@ func TestRouteIndexCache_Update(t *testing.T) {
 	assert.Contains(t, string(updatedHome), "2.0")
 }
 
+func TestRouteIndexCache_Render(t *testing.T) {
+	cache := &RouteIndexCache{}
+
+	filesystem := fstest.MapFS{
+		"index.html": &fstest.MapFile{
+			Data: []byte("<html><head></head><body></body></html>"),
+		},
+		"head.json": &fstest.MapFile{
+			Data: []byte(`{
+				"_default": {"raw": "<meta name=\"default\">"},
+				"/": {"raw": "<title>Home</title>"}
+			}`),
+		},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	err := cache.PrewarmRoutes(logger, filesystem, map[string]string{"networks": "public"}, nil, nil, nil)
+	require.NoError(t, err)
+
+	html, err := cache.Render("/?preview=token", map[string]string{"networks": "preview"}, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "preview")
+	assert.Contains(t, string(html), "<title>Home</title>")
+
+	// The cached variant is untouched
+	assert.NotContains(t, string(cache.GetForRoute("/")), "preview")
+}
+
 func TestRouteIndexCache_Update_InvalidHTML(t *testing.T) {
 	cache := &RouteIndexCache{}
 
@@ -276,7 +318,7 @@ func TestRouteIndexCache_Update_InvalidHTML(t *testing.T) {
 	cache.headData = make(HeadData)
 	cache.mu.Unlock()
 
-	err := cache.Update(map[string]string{}, map[string]string{}, map[string]string{})
+	err := cache.Update(map[string]string{}, map[string]string{}, map[string]string{}, map[string]string{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create default injected HTML")
 }
@@ -307,6 +349,7 @@ func TestRouteIndexCache_ConcurrentAccess(t *testing.T) {
 		map[string]string{"initial": "data"},
 		map[string]string{},
 		map[string]string{"version": "v1.0.0"},
+		map[string]string{},
 	)
 	require.NoError(t, err)
 
@@ -342,7 +385,7 @@ func TestRouteIndexCache_ConcurrentAccess(t *testing.T) {
 					"writer": fmt.Sprintf("writer-%d", id),
 					"iter":   fmt.Sprintf("%d", j),
 				}
-				err := cache.Update(config, map[string]string{}, map[string]string{"version": "v1.0.0"})
+				err := cache.Update(config, map[string]string{}, map[string]string{"version": "v1.0.0"}, map[string]string{})
 				assert.NoError(t, err)
 			}
 		}(i)
@@ -359,3 +402,59 @@ func TestRouteIndexCache_ConcurrentAccess(t *testing.T) {
 	assert.NotEmpty(t, defaultHTML)
 	assert.NotEmpty(t, homeHTML)
 }
+
+func TestFrontend_TenantIndexCaches(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: "http://mainnet"},
+			{Name: "sepolia", TargetURL: "http://sepolia"},
+		},
+		Tenants: []config.TenantConfig{{Name: "acme", Hosts: []string{"lab.acme.io"}, Networks: []string{"sepolia"}}},
+	}
+
+	tenants, err := tenancy.NewResolver(cfg.Tenants, nil)
+	require.NoError(t, err)
+
+	f := &Frontend{
+		fs:            fstest.MapFS{"index.html": &fstest.MapFile{Data: []byte("<html><head></head><body></body></html>")}},
+		routeCache:    &RouteIndexCache{},
+		tenants:       tenants.Tenants(),
+		tenantCaches:  map[string]IndexCache{"acme": &RouteIndexCache{}},
+		configHandler: api.NewConfigHandler(logger, cfg, nil, nil, nil, nil),
+		logger:        logger,
+	}
+	require.NoError(t, f.prewarm(context.Background()))
+
+	serve := func(host string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Host = host
+
+		if tenant, _ := tenants.Resolve(req); tenant != nil {
+			req = req.WithContext(tenancy.ContextWithTenant(req.Context(), tenant))
+		}
+
+		rec := httptest.NewRecorder()
+		f.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		return rec.Body.String()
+	}
+
+	page := serve("lab.ethpandaops.io")
+	assert.Contains(t, page, `"name":"mainnet"`)
+	assert.Contains(t, page, `"name":"sepolia"`)
+
+	// The tenant's page is cached separately with its own networks
+	page = serve("lab.acme.io")
+	assert.NotContains(t, page, `"name":"mainnet"`)
+	assert.Contains(t, page, `"name":"sepolia"`)
+
+	// Refreshes keep every tenant's cache apart
+	cfg.Networks = append(cfg.Networks, config.NetworkConfig{Name: "hoodi", TargetURL: "http://hoodi"})
+	require.NoError(t, f.refreshCache(context.Background()))
+	assert.Contains(t, serve("lab.ethpandaops.io"), `"name":"hoodi"`)
+	assert.NotContains(t, serve("lab.acme.io"), `"name":"hoodi"`)
+}
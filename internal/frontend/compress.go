@@ -0,0 +1,150 @@
+package frontend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/ethpandaops/lab-backend/internal/contentcoding"
+)
+
+// brotliLevel trades compression ratio against startup time; 11 takes
+// several seconds on large JS bundles for a few percent.
+const brotliLevel = 9
+
+// compressibleExts are the asset types worth compressing. Images and fonts
+// are already compressed.
+var compressibleExts = map[string]bool{
+	".html": true,
+	".css":  true,
+	".js":   true,
+	".mjs":  true,
+	".json": true,
+	".map":  true,
+	".svg":  true,
+	".txt":  true,
+	".xml":  true,
+	".wasm": true,
+}
+
+// compressedAsset holds the encoded variants of an asset. A variant is nil
+// when it would not be smaller than the original.
+type compressedAsset struct {
+	br []byte
+	gz []byte
+}
+
+// variant returns the body for an encoding, or nil.
+func (a *compressedAsset) variant(encoding string) []byte {
+	switch encoding {
+	case contentcoding.Brotli:
+		return a.br
+	case contentcoding.Gzip:
+		return a.gz
+	default:
+		return nil
+	}
+}
+
+// precompressAssets compresses every compressible file of at least minSize
+// bytes with brotli and gzip, keyed by path.
+func precompressAssets(fsys fs.FS, minSize int64) (map[string]*compressedAsset, error) {
+	assets := make(map[string]*compressedAsset)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !compressibleExts[path.Ext(name)] {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if info.Size() < minSize {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		asset, err := compressAsset(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if asset.br != nil || asset.gz != nil {
+			assets[name] = asset
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("precompress assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+func compressAsset(data []byte) (*compressedAsset, error) {
+	var br bytes.Buffer
+
+	bw := brotli.NewWriterLevel(&br, brotliLevel)
+	if _, err := bw.Write(data); err != nil {
+		return nil, fmt.Errorf("brotli: %w", err)
+	}
+
+	if err := bw.Close(); err != nil {
+		return nil, fmt.Errorf("brotli: %w", err)
+	}
+
+	var gz bytes.Buffer
+
+	gw, err := gzip.NewWriterLevel(&gz, gzip.BestCompression)
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+
+	if _, err := gw.Write(data); err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+
+	return &compressedAsset{
+		br: smallerThan(br.Bytes(), len(data)),
+		gz: smallerThan(gz.Bytes(), len(data)),
+	}, nil
+}
+
+func smallerThan(encoded []byte, size int) []byte {
+	if len(encoded) >= size {
+		return nil
+	}
+
+	return encoded
+}
+
+// pick returns the most preferred variant the client accepts, or "" and nil
+// to serve the original.
+func (a *compressedAsset) pick(acceptEncoding string) (string, []byte) {
+	for _, encoding := range contentcoding.Accepted(acceptEncoding) {
+		if body := a.variant(encoding); body != nil {
+			return encoding, body
+		}
+	}
+
+	return "", nil
+}
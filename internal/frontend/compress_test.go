@@ -0,0 +1,93 @@
+package frontend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andybalholm/brotli"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecompressAssets(t *testing.T) {
+	bundle := strings.Repeat("console.log('lab');\n", 200)
+
+	fsys := fstest.MapFS{
+		"index.html":      &fstest.MapFile{Data: []byte("<html><head></head></html>")},
+		"assets/app.js":   &fstest.MapFile{Data: []byte(bundle)},
+		"assets/logo.png": &fstest.MapFile{Data: []byte(bundle)},
+	}
+
+	compressed, err := precompressAssets(fsys, 1024)
+	require.NoError(t, err)
+
+	// Small files and already compressed types are skipped
+	require.Len(t, compressed, 1)
+	require.Contains(t, compressed, "assets/app.js")
+
+	asset := compressed["assets/app.js"]
+
+	br, err := io.ReadAll(brotli.NewReader(bytes.NewReader(asset.br)))
+	require.NoError(t, err)
+	assert.Equal(t, bundle, string(br))
+
+	gz, err := gzip.NewReader(bytes.NewReader(asset.gz))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, bundle, string(data))
+}
+
+func TestFrontend_ServeCompressed(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	bundle := strings.Repeat("console.log('lab');\n", 200)
+	fsys := fstest.MapFS{
+		"assets/app.js": &fstest.MapFile{Data: []byte(bundle)},
+	}
+
+	compressed, err := precompressAssets(fsys, 1024)
+	require.NoError(t, err)
+
+	f := &Frontend{fs: fsys, compressed: compressed, logger: logger}
+
+	tests := []struct {
+		name             string
+		acceptEncoding   string
+		expectedEncoding string
+	}{
+		{name: "brotli", acceptEncoding: "gzip, br", expectedEncoding: "br"},
+		{name: "gzip", acceptEncoding: "gzip", expectedEncoding: "gzip"},
+		{name: "identity", acceptEncoding: "", expectedEncoding: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/assets/app.js", http.NoBody)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			rec := httptest.NewRecorder()
+			f.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.expectedEncoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, "application/javascript; charset=utf-8", rec.Header().Get("Content-Type"))
+
+			if tt.expectedEncoding == "" {
+				assert.Equal(t, bundle, rec.Body.String())
+			} else {
+				assert.Less(t, rec.Body.Len(), len(bundle))
+			}
+		})
+	}
+}
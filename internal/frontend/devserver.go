@@ -0,0 +1,126 @@
+package frontend
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// newDevServerFrontend returns a frontend that proxies every request to the
+// Vite dev server at cfg.DevServerURL, so hot module replacement works while
+// /api/v1 is still served by this process. Only dev builds may use it.
+func newDevServerFrontend(
+	log logrus.FieldLogger,
+	cfg config.FrontendConfig,
+	configHandler *api.ConfigHandler,
+	boundsProvider bounds.Provider,
+) (*Frontend, error) {
+	if version.Version != "dev" {
+		return nil, fmt.Errorf("dev_server_url is only supported in dev builds, this is %s", version.Version)
+	}
+
+	target, err := url.Parse(cfg.DevServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse dev_server_url: %w", err)
+	}
+
+	f := &Frontend{
+		routeCache:     &RouteIndexCache{},
+		configHandler:  configHandler,
+		boundsProvider: boundsProvider,
+		logger:         log,
+		devMode:        true,
+		done:           make(chan struct{}),
+	}
+	f.devServer = f.newDevServerProxy(target)
+
+	log.WithField("url", cfg.DevServerURL).Info("Proxying frontend to dev server")
+
+	return f, nil
+}
+
+// newDevServerProxy returns a reverse proxy to a Vite dev server. HTML pages
+// get config, bounds, version and forks injected like the bundled index.html;
+// everything else, including the HMR WebSocket, passes through as it is.
+func (f *Frontend) newDevServerProxy(target *url.URL) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+
+			// Pages must arrive uncompressed to be injected into
+			pr.Out.Header.Del("Accept-Encoding")
+		},
+		ModifyResponse: f.injectDevServerPage,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			f.logger.WithError(err).WithField("path", r.URL.Path).Warn("Frontend dev server request failed")
+			httperr.Write(w, r, http.StatusBadGateway, httperr.CodeUpstreamUnavailable, "Frontend dev server unavailable")
+		},
+	}
+}
+
+// injectDevServerPage injects config, bounds, version and fork schedule into
+// HTML pages served by the dev server, with hidden networks for preview
+// requests. Pages are rendered per request, as Vite may change them anytime.
+func (f *Frontend) injectDevServerPage(resp *http.Response) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusOK || mediaType != "text/html" {
+		return nil
+	}
+
+	html, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return fmt.Errorf("read dev server page: %w", err)
+	}
+
+	r := resp.Request
+	ctx := r.Context()
+
+	configData := f.configHandler.GetConfigData(ctx)
+	exclude := f.configHandler.HiddenNetworks(ctx)
+
+	if f.configHandler.PreviewAllowed(r) {
+		configData = f.configHandler.GetPreviewConfigData(ctx)
+		exclude = nil
+
+		if cookie := f.configHandler.PreviewCookie(r); cookie != nil {
+			resp.Header.Add("Set-Cookie", cookie.String())
+		}
+
+		resp.Header.Set("Cache-Control", "private, no-store")
+	}
+
+	html, err = InjectConfigAndBounds(
+		html,
+		configData,
+		buildBoundsData(ctx, f.boundsProvider, exclude),
+		version.GetWithFrontend(),
+		configData.ForkSchedule(),
+	)
+	if err != nil {
+		return fmt.Errorf("inject dev server page: %w", err)
+	}
+
+	html = withScriptNonce(r, html)
+
+	resp.Body = io.NopCloser(bytes.NewReader(html))
+	resp.ContentLength = int64(len(html))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(html)))
+	resp.Header.Del("ETag")
+
+	return nil
+}
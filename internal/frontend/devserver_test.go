@@ -0,0 +1,105 @@
+package frontend
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestFrontend_DevServer(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Stands in for Vite: a page, a module and an HMR socket echoing its input
+	vite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Upgrade") == "websocket":
+			conn, brw, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			_, _ = brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+			_ = brw.Flush()
+
+			line, _ := brw.ReadString('\n')
+			_, _ = brw.WriteString(line)
+			_ = brw.Flush()
+		case r.URL.Path == "/src/main.tsx":
+			w.Header().Set("Content-Type", "text/javascript")
+			_, _ = io.WriteString(w, "export {}")
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = io.WriteString(w, `<html><head><script type="module" src="/@vite/client"></script></head><body></body></html>`)
+		}
+	}))
+	defer vite.Close()
+
+	cfg := &config.Config{Networks: []config.NetworkConfig{{Name: "mainnet", TargetURL: "http://localhost"}}}
+	configHandler := api.NewConfigHandler(logger, cfg, nil, nil, nil, nil)
+
+	f, err := New(context.Background(), logger, config.FrontendConfig{DevServerURL: vite.URL}, nil, configHandler, nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, f.Start(context.Background()))
+	require.NoError(t, f.Reload(context.Background()))
+
+	defer func() { require.NoError(t, f.Stop()) }()
+
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	// Pages get the runtime config injected
+	resp, err := http.Get(server.URL + "/ethereum/slots")
+	require.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "window.__CONFIG__")
+	assert.Contains(t, string(body), `"name":"mainnet"`)
+	assert.Contains(t, string(body), `src="/@vite/client"`)
+
+	// Modules pass through as they are
+	resp, err = http.Get(server.URL + "/src/main.tsx")
+	require.NoError(t, err)
+
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, "export {}", string(body))
+
+	// The HMR socket is upgraded through the proxy
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = io.WriteString(conn, "GET / HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+	require.NoError(t, err)
+
+	br := bufio.NewReader(conn)
+
+	upgrade, err := http.ReadResponse(br, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, upgrade.StatusCode)
+
+	_, err = io.WriteString(conn, "ping\n")
+	require.NoError(t, err)
+
+	line, err := br.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", line)
+}
@@ -1,94 +1,172 @@
 package frontend
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
-	"os"
 	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/lab-backend/internal/api"
 	"github.com/ethpandaops/lab-backend/internal/bounds"
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/headers"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/negcache"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 	"github.com/ethpandaops/lab-backend/internal/version"
 	"github.com/ethpandaops/lab-backend/web"
 )
 
 // Frontend serves static frontend files with caching and config injection.
 type Frontend struct {
-	fs                    fs.FS                  // Embedded or local filesystem
-	routeCache            *RouteIndexCache       // Route-specific index cache with head injection
-	configHandler         *api.ConfigHandler     // Handler for config data
-	boundsProvider        bounds.Provider        // Provider for bounds data
-	cartographoorProvider cartographoor.Provider // Provider for cartographoor data
+	fs                    fs.FS                         // Embedded, fetched or local filesystem
+	routeCache            IndexCache                    // Route-specific index cache with head injection
+	tenants               []*tenancy.Tenant             // Tenants, each with its own index cache
+	tenantCaches          map[string]IndexCache         // Index caches of tenants, by name
+	configHandler         *api.ConfigHandler            // Handler for config data
+	boundsProvider        bounds.Provider               // Provider for bounds data
+	cartographoorProvider cartographoor.Provider        // Provider for cartographoor data
+	missingAssets         *negcache.Cache               // Asset paths recently found missing, nil to always look up
+	compressed            map[string]*compressedAsset   // Brotli and gzip variants of static assets, by path
+	assets                atomic.Pointer[assetManifest] // Fingerprinted files and file hashes of the bundle
+	assetMaxAge           time.Duration                 // Cache lifetime of files that are not fingerprinted
+	modTime               time.Time                     // Last-Modified of files without a modification time
 	logger                logrus.FieldLogger
 	devMode               bool           // True if using local filesystem
+	devServer             http.Handler   // Vite dev server proxy replacing the bundle, nil to serve files
+	watchInterval         time.Duration  // How often dev mode checks for index.html and head.json changes
 	done                  chan struct{}  // Signal to stop refresh loop
 	wg                    sync.WaitGroup // Wait group for goroutines
+	task                  *tasks.Task    // Introspection and supervision of the refresh loop
 }
 
 // New creates a new frontend server.
-// Attempts to use embedded FS first, falls back to local filesystem in dev.
+// Serves the embedded bundle if it passes its integrity check, otherwise a
+// matching bundle fetched into cfg.CacheDir; dev builds fall back to the local filesystem,
+// or proxy to cfg.DevServerURL when set.
 // Prewarms index.html into memory cache with route-specific head data injected.
 // The cache is automatically refreshed when bounds or cartographoor data updates (event-driven).
+// The bundle fetch and initial data reads run under ctx. Asset paths missing
+// from the bundle are remembered in missingAssets, if set. Every tenant of
+// tenants, which may be nil, gets its own index cache.
 func New(
+	ctx context.Context,
 	logger logrus.FieldLogger,
+	cfg config.FrontendConfig,
+	missingAssets *negcache.Cache,
 	configHandler *api.ConfigHandler,
 	boundsProvider bounds.Provider,
 	cartographoorProvider cartographoor.Provider,
+	tenants *tenancy.Resolver,
 ) (*Frontend, error) {
 	log := logger.WithField("component", "frontend")
 
-	// Try embedded FS first
-	embedFS, err := web.GetFS()
-	devMode := false
+	if cfg.DevServerURL != "" {
+		return newDevServerFrontend(log, cfg, configHandler, boundsProvider)
+	}
 
-	if err != nil || !web.Exists() {
-		log.Info("Embedded FS not available, using local filesystem (dev mode)")
+	// Embedded FS is empty unless the frontend was set up before building
+	var embedFS fs.FS
+	if sub, err := web.GetFS(); err == nil && web.Exists() {
+		embedFS = sub
+	}
 
-		devMode = true
-		embedFS = os.DirFS("web/frontend")
-	} else {
-		log.Info("Using embedded filesystem")
+	frontendVersion := cfg.Version
+	if frontendVersion == "" {
+		frontendVersion = version.GetWithFrontend().FrontendVersion
+	}
+
+	loader := &bundleLoader{
+		cfg:      cfg,
+		log:      log,
+		version:  frontendVersion,
+		devBuild: version.Version == "dev",
+		client: &http.Client{
+			Transport: upstream.NewTransport(upstream.SubsystemFrontend, nil),
+		},
 	}
 
-	// Fetch initial data
-	ctx := context.Background()
-	configData := configHandler.GetConfigData(ctx)
-	boundsData := buildBoundsData(ctx, boundsProvider)
-	versionData := version.GetWithFrontend()
+	assets, err := loader.load(ctx, embedFS)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create route-specific cache
-	routeCache := &RouteIndexCache{}
-	if err := routeCache.PrewarmRoutes(log, embedFS, configData, boundsData, versionData); err != nil {
-		return nil, fmt.Errorf("failed to prewarm route cache: %w", err)
+	log.WithField("source", assets.source).Info("Using frontend bundle")
+
+	// Files served from disk in dev mode may change, so they are never pre-compressed
+	var compressed map[string]*compressedAsset
+
+	if cfg.PrecompressEnabled() && assets.source != sourceLocal {
+		start := time.Now()
+
+		compressed, err = precompressAssets(assets.fs, cfg.MinCompress)
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithFields(logrus.Fields{
+			"assets":   len(compressed),
+			"duration": time.Since(start).Round(time.Millisecond),
+		}).Info("Pre-compressed frontend assets")
 	}
 
-	log.Info("Using route-specific caching with head.json data")
+	tenantCaches := make(map[string]IndexCache, len(tenants.Tenants()))
+	for _, tenant := range tenants.Tenants() {
+		tenantCaches[tenant.Name] = &RouteIndexCache{}
+	}
 
-	return &Frontend{
-		fs:                    embedFS,
-		routeCache:            routeCache,
+	f := &Frontend{
+		fs:                    assets.fs,
+		routeCache:            &RouteIndexCache{},
+		tenants:               tenants.Tenants(),
+		tenantCaches:          tenantCaches,
 		configHandler:         configHandler,
 		boundsProvider:        boundsProvider,
 		cartographoorProvider: cartographoorProvider,
+		missingAssets:         missingAssets,
+		compressed:            compressed,
 		logger:                log,
-		devMode:               devMode,
+		devMode:               assets.source == sourceLocal,
+		watchInterval:         cfg.WatchInterval,
+		assetMaxAge:           cfg.AssetMaxAge,
+		modTime:               bundleModTime(),
 		done:                  make(chan struct{}),
-	}, nil
+	}
+	f.assets.Store(readAssetManifest(log, assets.fs))
+
+	// Create route-specific caches with the initial data
+	if err := f.prewarm(ctx); err != nil {
+		return nil, fmt.Errorf("failed to prewarm route cache: %w", err)
+	}
+
+	log.WithField("tenants", len(f.tenants)).Info("Using route-specific caching with head.json data")
+
+	return f, nil
 }
 
 // Start starts the frontend server and background cache refresh listener.
 func (f *Frontend) Start(ctx context.Context) error {
+	// Pages from the dev server are injected per request, there is no cache
+	if f.devServer != nil {
+		return nil
+	}
+
 	f.logger.Info("Starting frontend cache refresh listener")
 
 	// Start background refresh loop that listens for bounds update notifications
+	f.task = tasks.Default().Register("frontend.refresh", 0)
 	f.wg.Add(1)
 
 	go f.refreshLoop(ctx)
@@ -106,8 +184,36 @@ func (f *Frontend) Stop() error {
 	return nil
 }
 
+// Reload re-reads index.html and head.json from the served bundle and
+// rebuilds every cached route, so head data can change without a restart.
+func (f *Frontend) Reload(ctx context.Context) error {
+	if f.devServer != nil {
+		f.logger.Info("Frontend is served by the dev server, nothing to reload")
+
+		return nil
+	}
+
+	if err := f.prewarm(ctx); err != nil {
+		return fmt.Errorf("reload route cache: %w", err)
+	}
+
+	// Files may have been added along with the new head data
+	f.missingAssets.Clear()
+	f.assets.Store(readAssetManifest(f.logger, f.fs))
+
+	f.logger.Info("Reloaded index.html and head.json")
+
+	return nil
+}
+
 // ServeHTTP handles frontend requests.
 func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.devServer != nil {
+		f.devServer.ServeHTTP(w, r)
+
+		return
+	}
+
 	// Clean path and remove leading slash
 	cleanPath := path.Clean(r.URL.Path)
 	if cleanPath == "/" {
@@ -123,8 +229,26 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Paths with an extension are assets, which get a 404 when missing rather
+	// than index.html, so scans for e.g. .php files don't render the app
+	isAsset := path.Ext(cleanPath) != ""
+
+	if isAsset && f.missingAssets.Has(cleanPath) {
+		http.NotFound(w, r)
+
+		return
+	}
+
 	// Try to serve static file
 	file, err := f.fs.Open(cleanPath)
+	if err != nil && isAsset {
+		f.logger.WithField("path", r.URL.Path).Debug("Asset not found")
+		f.missingAssets.Add(cleanPath)
+		http.NotFound(w, r)
+
+		return
+	}
+
 	if err != nil {
 		// File not found - fall back to index.html for SPA routing
 		f.logger.WithFields(logrus.Fields{
@@ -144,7 +268,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		f.logger.WithError(err).Error("Failed to stat file")
 
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "Internal Server Error")
 
 		return
 	}
@@ -156,25 +280,58 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	readSeeker, ok := file.(io.ReadSeeker)
 	if !ok {
 		f.logger.WithField("path", cleanPath).Error("File does not implement io.ReadSeeker")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "Internal Server Error")
 
 		return
 	}
 
-	http.ServeContent(w, r, cleanPath, stat.ModTime(), readSeeker)
+	// Serve a pre-compressed variant if the client accepts one. Ranges always
+	// address the uncompressed file, so seeking media works the same everywhere.
+	if asset, ok := f.compressed[cleanPath]; ok {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		if encoding, body := asset.pick(r.Header.Get("Accept-Encoding")); body != nil && r.Header.Get("Range") == "" {
+			w.Header().Set("Content-Encoding", encoding)
+
+			readSeeker = bytes.NewReader(body)
+		}
+	}
+
+	// Lets clients revalidate files whose modification time is unknown, like embedded ones
+	if etag := f.assets.Load().etag(cleanPath, w.Header().Get("Content-Encoding")); etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+
+	// Embedded files have no modification time, without which http.ServeContent
+	// sends no Last-Modified and ignores If-Modified-Since and date If-Range
+	modTime := stat.ModTime()
+	if modTime.IsZero() {
+		modTime = f.modTime
+	}
+
+	http.ServeContent(w, r, cleanPath, modTime, readSeeker)
 }
 
 // serveIndex serves the cached index.html with injected config.
 func (f *Frontend) serveIndex(w http.ResponseWriter, r *http.Request) {
+	// Preview requests get hidden networks, which are never cached
+	if f.configHandler.PreviewAllowed(r) {
+		f.servePreviewIndex(w, r)
+
+		return
+	}
+
 	// Get the request path to determine which route cache to use
 	route := r.URL.Path
-	html := f.routeCache.GetForRoute(route)
+	html := f.cacheFor(r.Context()).GetForRoute(route)
 
 	f.logger.WithFields(logrus.Fields{
 		"route":          route,
 		"content_length": len(html),
 	}).Debug("Serving route-specific cached index.html")
 
+	html = withScriptNonce(r, html)
+
 	// Set content type for index.html
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
@@ -184,7 +341,57 @@ func (f *Frontend) serveIndex(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// setCacheHeaders sets appropriate cache headers based on file type.
+// servePreviewIndex renders index.html per request with hidden networks
+// included, for requests carrying a preview token.
+func (f *Frontend) servePreviewIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	configData := f.configHandler.GetPreviewConfigData(ctx)
+
+	html, err := f.cacheFor(ctx).Render(
+		r.URL.Path,
+		configData,
+		buildBoundsData(ctx, f.boundsProvider, nil),
+		version.GetWithFrontend(),
+		configData.ForkSchedule(),
+	)
+	if err != nil {
+		f.logger.WithError(err).Error("Failed to render preview index.html")
+		httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "Internal Server Error")
+
+		return
+	}
+
+	// Remember a token passed as ?preview= for subsequent requests
+	if cookie := f.configHandler.PreviewCookie(r); cookie != nil {
+		http.SetCookie(w, cookie)
+	}
+
+	html = withScriptNonce(r, html)
+
+	// Never let shared caches store the preview variant
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(html); err != nil {
+		f.logger.WithError(err).Error("Failed to write preview index.html response")
+	}
+}
+
+// withScriptNonce adds the request's CSP nonce, if its header policy uses
+// one, to the script tags of html.
+func withScriptNonce(r *http.Request, html []byte) []byte {
+	if nonce := headers.NonceFromContext(r.Context()); nonce != "" {
+		return InjectScriptNonce(html, nonce)
+	}
+
+	return html
+}
+
+// setCacheHeaders sets the content type of a file and its Cache-Control,
+// which replaces any set by header policies: files fingerprinted in the build's
+// asset manifest are immutable, others are cached briefly and revalidated.
 func (f *Frontend) setCacheHeaders(w http.ResponseWriter, filePath string) {
 	// Determine content type
 	ext := path.Ext(filePath)
@@ -217,22 +424,30 @@ func (f *Frontend) setCacheHeaders(w http.ResponseWriter, filePath string) {
 		contentType = "application/vnd.ms-fontobject"
 	case ".ico":
 		contentType = "image/x-icon"
+	case ".webp":
+		contentType = "image/webp"
+	case ".wasm":
+		contentType = "application/wasm"
+	case ".mp4":
+		contentType = "video/mp4"
+	case ".webm":
+		contentType = "video/webm"
 	}
 
 	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", f.assets.Load().cacheControl(filePath, f.assetMaxAge))
 }
 
 // refreshLoop listens for bounds and cartographoor update notifications and refreshes the cached index.html.
 // This ensures the frontend cache stays in sync with data updates (event-driven).
+// In dev mode it also reloads index.html and head.json when they change on disk.
 func (f *Frontend) refreshLoop(ctx context.Context) {
-	defer func() {
-		if rec := recover(); rec != nil {
-			f.logger.WithField("panic", rec).Error("Frontend refresh loop panicked")
-		}
+	defer f.wg.Done()
 
-		f.wg.Done()
-	}()
+	f.task.Supervise(f.logger, f.done, func() { f.runRefreshLoop(ctx) })
+}
 
+func (f *Frontend) runRefreshLoop(ctx context.Context) {
 	// Get notification channels from providers
 	var boundsNotifyChan <-chan struct{}
 	if f.boundsProvider != nil {
@@ -244,6 +459,21 @@ func (f *Frontend) refreshLoop(ctx context.Context) {
 		cartographoorNotifyChan = f.cartographoorProvider.NotifyChannel()
 	}
 
+	// Only a bundle served from disk can change under us
+	var (
+		watcher   *fileWatcher
+		watchTick <-chan time.Time
+	)
+
+	if f.devMode && f.watchInterval > 0 {
+		watcher = newFileWatcher(f.fs)
+
+		ticker := time.NewTicker(f.watchInterval)
+		defer ticker.Stop()
+
+		watchTick = ticker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -254,43 +484,74 @@ func (f *Frontend) refreshLoop(ctx context.Context) {
 			// Bounds data has been updated, refresh the cache
 			f.logger.Debug("Bounds updated, refreshing frontend cache")
 
-			f.refreshCache(ctx)
+			_ = f.task.Run(func() error { return f.refreshCache(ctx) })
 		case <-cartographoorNotifyChan:
 			// Cartographoor data has been updated, refresh the cache
 			f.logger.Debug("Cartographoor updated, refreshing frontend cache")
 
-			f.refreshCache(ctx)
+			_ = f.task.Run(func() error { return f.refreshCache(ctx) })
+		case <-f.networksFileChanged():
+			f.logger.Debug("Networks file changed, refreshing frontend cache")
+
+			_ = f.task.Run(func() error { return f.refreshCache(ctx) })
+		case <-watchTick:
+			if !watcher.changed() {
+				continue
+			}
+
+			f.logger.Debug("index.html or head.json changed on disk, reloading frontend cache")
+
+			_ = f.task.Run(func() error { return f.Reload(ctx) })
 		}
 	}
 }
 
-// refreshCache fetches fresh config, bounds, and version data and updates the route cache.
-func (f *Frontend) refreshCache(ctx context.Context) {
+// networksFileChanged returns a channel closed when the networks of
+// networks_file change, nil when there is nothing to watch.
+func (f *Frontend) networksFileChanged() <-chan struct{} {
+	if f.configHandler == nil {
+		return nil
+	}
+
+	return f.configHandler.NetworksFileChanged()
+}
+
+// refreshCache fetches fresh config, bounds, version, and fork schedule data and updates the route cache.
+func (f *Frontend) refreshCache(ctx context.Context) error {
 	f.logger.Debug("Refreshing frontend cache with latest config, bounds, and version data")
 
-	// Fetch fresh data
-	configData := f.configHandler.GetConfigData(ctx)
-	boundsData := buildBoundsData(ctx, f.boundsProvider)
-	versionData := version.GetWithFrontend()
+	// Update route-specific caches with fresh data
+	err := f.eachCache(ctx, func(ctx context.Context, cache IndexCache) error {
+		configData, boundsData, versionData, forksData := f.indexData(ctx)
 
-	// Update route-specific cache
-	if err := f.routeCache.Update(configData, boundsData, versionData); err != nil {
+		return cache.Update(configData, boundsData, versionData, forksData)
+	})
+	if err != nil {
 		f.logger.WithError(err).Error("Failed to update route cache")
 
-		return
+		return fmt.Errorf("update route cache: %w", err)
 	}
 
 	f.logger.Debug("Route cache refreshed successfully")
+
+	return nil
 }
 
 // buildBoundsData fetches all bounds and returns them in the format expected by the frontend.
-func buildBoundsData(ctx context.Context, boundsProvider bounds.Provider) map[string]map[string]bounds.TableBounds {
+// Networks in exclude (e.g. hidden networks) and networks the tenant of ctx doesn't show are left out.
+func buildBoundsData(
+	ctx context.Context,
+	boundsProvider bounds.Provider,
+	exclude map[string]bool,
+) map[string]map[string]bounds.TableBounds {
 	boundsData := make(map[string]map[string]bounds.TableBounds)
 
+	tenant := tenancy.FromContext(ctx)
+
 	if boundsProvider != nil {
 		allBounds := boundsProvider.GetAllBounds(ctx)
 		for network, data := range allBounds {
-			if data != nil {
+			if data != nil && !exclude[network] && tenant.ShowsNetwork(network) {
 				boundsData[network] = data.Tables
 			}
 		}
@@ -298,3 +559,56 @@ func buildBoundsData(ctx context.Context, boundsProvider bounds.Provider) map[st
 
 	return boundsData
 }
+
+// prewarm loads index.html and head.json into the default and every tenant's
+// index cache, with the data of each.
+func (f *Frontend) prewarm(ctx context.Context) error {
+	return f.eachCache(ctx, func(ctx context.Context, cache IndexCache) error {
+		configData, boundsData, versionData, forksData := f.indexData(ctx)
+
+		return cache.PrewarmRoutes(f.logger, f.fs, configData, boundsData, versionData, forksData)
+	})
+}
+
+// eachCache calls fn with the default index cache, then with the cache of
+// every tenant and the tenant in ctx.
+func (f *Frontend) eachCache(ctx context.Context, fn func(ctx context.Context, cache IndexCache) error) error {
+	if err := fn(ctx, f.routeCache); err != nil {
+		return err
+	}
+
+	for _, tenant := range f.tenants {
+		if err := fn(tenancy.ContextWithTenant(ctx, tenant), f.tenantCaches[tenant.Name]); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenant.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// cacheFor returns the index cache of the tenant of ctx.
+func (f *Frontend) cacheFor(ctx context.Context) IndexCache {
+	if tenant := tenancy.FromContext(ctx); tenant != nil {
+		if cache, ok := f.tenantCaches[tenant.Name]; ok {
+			return cache
+		}
+	}
+
+	return f.routeCache
+}
+
+// indexData returns the config, bounds, version and fork schedule injected
+// into index.html for the tenant of ctx.
+func (f *Frontend) indexData(ctx context.Context) (
+	api.ConfigResponse,
+	map[string]map[string]bounds.TableBounds,
+	version.Info,
+	map[string]api.NetworkForkSchedule,
+) {
+	configData := f.configHandler.GetConfigData(ctx)
+
+	return configData,
+		buildBoundsData(ctx, f.boundsProvider, f.configHandler.HiddenNetworks(ctx)),
+		version.GetWithFrontend(),
+		configData.ForkSchedule()
+}
@@ -7,9 +7,9 @@ import (
 	"strings"
 )
 
-// InjectConfigAndBounds injects config, bounds, and version JSON into HTML head in a single script tag.
-// Finds <head> tag and inserts: <script>window.__CONFIG__={...}; window.__BOUNDS__={...}; window.__VERSION__={...};</script>.
-func InjectConfigAndBounds(htmlContent []byte, configData any, boundsData any, versionData any) ([]byte, error) {
+// InjectConfigAndBounds injects config, bounds, version, and fork schedule JSON into HTML head in a single script tag.
+// Finds <head> tag and inserts: <script>window.__CONFIG__={...}; window.__BOUNDS__={...}; window.__VERSION__={...}; window.__FORKS__={...};</script>.
+func InjectConfigAndBounds(htmlContent []byte, configData any, boundsData any, versionData any, forksData any) ([]byte, error) {
 	// Serialize config to JSON
 	configJSON, err := json.Marshal(configData)
 	if err != nil {
@@ -28,18 +28,26 @@ func InjectConfigAndBounds(htmlContent []byte, configData any, boundsData any, v
 		return nil, fmt.Errorf("failed to marshal version: %w", err)
 	}
 
+	// Serialize fork schedule to JSON
+	forksJSON, err := json.Marshal(forksData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal forks: %w", err)
+	}
+
 	// Escape for script tag safety (prevent </script> injection)
 	// Replace </ with <\/ to prevent premature script tag closure
 	safeConfigJSON := strings.ReplaceAll(string(configJSON), "</", `<\/`)
 	safeBoundsJSON := strings.ReplaceAll(string(boundsJSON), "</", `<\/`)
 	safeVersionJSON := strings.ReplaceAll(string(versionJSON), "</", `<\/`)
+	safeForksJSON := strings.ReplaceAll(string(forksJSON), "</", `<\/`)
 
-	// Create combined script tag with config, bounds, and version
+	// Create combined script tag with config, bounds, version, and forks
 	scriptTag := fmt.Sprintf(
-		"\n    <script>\n      window.__CONFIG__ = %s;\n      window.__BOUNDS__ = %s;\n      window.__VERSION__ = %s;\n    </script>\n",
+		"\n    <script>\n      window.__CONFIG__ = %s;\n      window.__BOUNDS__ = %s;\n      window.__VERSION__ = %s;\n      window.__FORKS__ = %s;\n    </script>\n",
 		safeConfigJSON,
 		safeBoundsJSON,
 		safeVersionJSON,
+		safeForksJSON,
 	)
 
 	// Find <head> tag and insert script after it
@@ -60,12 +68,12 @@ func InjectConfigAndBounds(htmlContent []byte, configData any, boundsData any, v
 	return result, nil
 }
 
-// InjectAll injects config, bounds, version, and route-specific head HTML into the HTML head.
-// This inserts both the script tag with window.__CONFIG__, window.__BOUNDS__, and window.__VERSION__,
-// and the raw head HTML for the specific route.
-func InjectAll(htmlContent []byte, configData any, boundsData any, versionData any, headRaw string) ([]byte, error) {
-	// First inject config, bounds, and version
-	result, err := InjectConfigAndBounds(htmlContent, configData, boundsData, versionData)
+// InjectAll injects config, bounds, version, fork schedule, and route-specific head HTML into the HTML head.
+// This inserts both the script tag with window.__CONFIG__, window.__BOUNDS__, window.__VERSION__, and
+// window.__FORKS__, and the raw head HTML for the specific route.
+func InjectAll(htmlContent []byte, configData any, boundsData any, versionData any, forksData any, headRaw string) ([]byte, error) {
+	// First inject config, bounds, version, and forks
+	result, err := InjectConfigAndBounds(htmlContent, configData, boundsData, versionData, forksData)
 	if err != nil {
 		return nil, err
 	}
@@ -95,3 +103,53 @@ func InjectAll(htmlContent []byte, configData any, boundsData any, versionData a
 
 	return finalResult, nil
 }
+
+// InjectScriptNonce adds a nonce attribute with nonce to every <script> tag of
+// the HTML, so the inline config script and the bundle's scripts run under a
+// Content-Security-Policy without 'unsafe-inline'. Tags that already carry a
+// nonce keep theirs.
+func InjectScriptNonce(htmlContent []byte, nonce string) []byte {
+	openTag := []byte("<script")
+	attr := []byte(` nonce="` + nonce + `"`)
+	lower := bytes.ToLower(htmlContent)
+
+	result := make([]byte, 0, len(htmlContent)+4*len(attr))
+	pos := 0
+
+	for {
+		i := bytes.Index(lower[pos:], openTag)
+		if i == -1 {
+			return append(result, htmlContent[pos:]...)
+		}
+
+		end := pos + i + len(openTag)
+		result = append(result, htmlContent[pos:end]...)
+		pos = end
+
+		// Skip e.g. <scripts>, and tags that already have a nonce
+		if pos < len(lower) && isTagNameEnd(lower[pos]) && !hasNonce(lower[pos:]) {
+			result = append(result, attr...)
+		}
+	}
+}
+
+// isTagNameEnd reports whether c ends a tag name.
+func isTagNameEnd(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\f', '/', '>':
+		return true
+	default:
+		return false
+	}
+}
+
+// hasNonce reports whether the lowercased attributes at the start of attrs,
+// up to the end of their tag, carry a nonce.
+func hasNonce(attrs []byte) bool {
+	end := bytes.IndexByte(attrs, '>')
+	if end == -1 {
+		end = len(attrs)
+	}
+
+	return bytes.Contains(attrs[:end], []byte("nonce="))
+}
@@ -15,6 +15,7 @@ func TestInjectConfigAndBounds(t *testing.T) {
 		config      any
 		bounds      any
 		version     any
+		forks       any
 		expectError bool
 		errorMsg    string
 		contains    []string
@@ -25,13 +26,16 @@ func TestInjectConfigAndBounds(t *testing.T) {
 			config:  map[string]string{"version": "1.0"},
 			bounds:  map[string]int{"max": 100},
 			version: map[string]string{"version": "v1.0.0", "git_commit": "abc123"},
+			forks:   map[string]any{"mainnet": map[string]any{"next": map[string]string{"name": "fulu"}}},
 			contains: []string{
 				"window.__CONFIG__",
 				"window.__BOUNDS__",
 				"window.__VERSION__",
+				"window.__FORKS__",
 				`"version":"1.0"`,
 				`"max":100`,
 				`"git_commit":"abc123"`,
+				`"name":"fulu"`,
 				"<script>",
 				"</script>",
 			},
@@ -64,10 +68,12 @@ func TestInjectConfigAndBounds(t *testing.T) {
 			config:  map[string]string{},
 			bounds:  map[string]string{},
 			version: map[string]string{},
+			forks:   map[string]string{},
 			contains: []string{
 				"window.__CONFIG__ = {}",
 				"window.__BOUNDS__ = {}",
 				"window.__VERSION__ = {}",
+				"window.__FORKS__ = {}",
 			},
 		},
 		{
@@ -122,7 +128,7 @@ func TestInjectConfigAndBounds(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := InjectConfigAndBounds([]byte(tt.html), tt.config, tt.bounds, tt.version)
+			result, err := InjectConfigAndBounds([]byte(tt.html), tt.config, tt.bounds, tt.version, tt.forks)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -186,10 +192,16 @@ func TestInjectAll(t *testing.T) {
 		"build_date": "2024-01-01",
 	}
 
-	t.Run("injects config, bounds, version, and head raw", func(t *testing.T) {
+	forksData := map[string]any{
+		"mainnet": map[string]any{
+			"forks": []map[string]any{{"name": "fulu", "epoch": 411392}},
+		},
+	}
+
+	t.Run("injects config, bounds, version, forks, and head raw", func(t *testing.T) {
 		headRaw := `<meta property="og:title" content="Test Page">`
 
-		result, err := InjectAll(htmlContent, configData, boundsData, versionData, headRaw)
+		result, err := InjectAll(htmlContent, configData, boundsData, versionData, forksData, headRaw)
 		require.NoError(t, err)
 
 		// Check config injection
@@ -204,6 +216,10 @@ func TestInjectAll(t *testing.T) {
 		assert.Contains(t, string(result), "window.__VERSION__")
 		assert.Contains(t, string(result), `"git_commit":"abc123"`)
 
+		// Check fork schedule injection
+		assert.Contains(t, string(result), "window.__FORKS__")
+		assert.Contains(t, string(result), `"epoch":411392`)
+
 		// Check head raw injection
 		assert.Contains(t, string(result), headRaw)
 
@@ -213,14 +229,15 @@ func TestInjectAll(t *testing.T) {
 		assert.True(t, headRawIdx < headCloseIdx, "head raw should be before </head>")
 	})
 
-	t.Run("injects only config, bounds, and version when headRaw is empty", func(t *testing.T) {
-		result, err := InjectAll(htmlContent, configData, boundsData, versionData, "")
+	t.Run("injects only config, bounds, version, and forks when headRaw is empty", func(t *testing.T) {
+		result, err := InjectAll(htmlContent, configData, boundsData, versionData, forksData, "")
 		require.NoError(t, err)
 
 		// Check config, bounds, and version are injected
 		assert.Contains(t, string(result), "window.__CONFIG__")
 		assert.Contains(t, string(result), "window.__BOUNDS__")
 		assert.Contains(t, string(result), "window.__VERSION__")
+		assert.Contains(t, string(result), "window.__FORKS__")
 
 		// Check structure is maintained
 		assert.Contains(t, string(result), "</head>")
@@ -230,7 +247,7 @@ func TestInjectAll(t *testing.T) {
 	t.Run("returns error when no head tag", func(t *testing.T) {
 		badHTML := []byte("<html><body></body></html>")
 
-		_, err := InjectAll(badHTML, configData, boundsData, versionData, "test")
+		_, err := InjectAll(badHTML, configData, boundsData, versionData, forksData, "test")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "could not find <head> tag")
 	})
@@ -238,7 +255,7 @@ func TestInjectAll(t *testing.T) {
 	t.Run("returns error when no closing head tag", func(t *testing.T) {
 		badHTML := []byte("<html><head><body></body></html>")
 
-		_, err := InjectAll(badHTML, configData, boundsData, versionData, "test")
+		_, err := InjectAll(badHTML, configData, boundsData, versionData, forksData, "test")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "could not find </head> tag")
 	})
@@ -248,7 +265,7 @@ func TestInjectAll(t *testing.T) {
 			"test": "</script><script>alert('XSS')</script>",
 		}
 
-		result, err := InjectAll(htmlContent, configWithScript, boundsData, versionData, "")
+		result, err := InjectAll(htmlContent, configWithScript, boundsData, versionData, forksData, "")
 		require.NoError(t, err)
 
 		// Check that </script> is escaped - Go's JSON encoder uses Unicode escapes
@@ -257,3 +274,38 @@ func TestInjectAll(t *testing.T) {
 		assert.Contains(t, string(result), `\u003c`)
 	})
 }
+
+func TestInjectScriptNonce(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "inline and module scripts",
+			html: `<head><script>window.__CONFIG__ = {};</script><script type="module" src="/app.js"></script></head>`,
+			want: `<head><script nonce="abc">window.__CONFIG__ = {};</script><script nonce="abc" type="module" src="/app.js"></script></head>`,
+		},
+		{
+			name: "uppercase and self-closing tags",
+			html: `<SCRIPT src="/a.js"></SCRIPT><script/>`,
+			want: `<SCRIPT nonce="abc" src="/a.js"></SCRIPT><script nonce="abc"/>`,
+		},
+		{
+			name: "existing nonce kept",
+			html: `<script nonce="other" src="/a.js"></script><script>x</script>`,
+			want: `<script nonce="other" src="/a.js"></script><script nonce="abc">x</script>`,
+		},
+		{
+			name: "other tags untouched",
+			html: `<scripts></scripts><noscript>no js</noscript>`,
+			want: `<scripts></scripts><noscript>no js</noscript>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(InjectScriptNonce([]byte(tt.html), "abc")))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ethpandaops/lab-backend/internal/frontend (interfaces: Handler,IndexCache)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_frontend.go github.com/ethpandaops/lab-backend/internal/frontend Handler,IndexCache
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	fs "io/fs"
+	http "net/http"
+	reflect "reflect"
+
+	logrus "github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHandler is a mock of Handler interface.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// Reload mocks base method.
+func (m *MockHandler) Reload(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockHandlerMockRecorder) Reload(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockHandler)(nil).Reload), ctx)
+}
+
+// ServeHTTP mocks base method.
+func (m *MockHandler) ServeHTTP(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ServeHTTP", arg0, arg1)
+}
+
+// ServeHTTP indicates an expected call of ServeHTTP.
+func (mr *MockHandlerMockRecorder) ServeHTTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeHTTP", reflect.TypeOf((*MockHandler)(nil).ServeHTTP), arg0, arg1)
+}
+
+// Start mocks base method.
+func (m *MockHandler) Start(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockHandlerMockRecorder) Start(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockHandler)(nil).Start), ctx)
+}
+
+// Stop mocks base method.
+func (m *MockHandler) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockHandlerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockHandler)(nil).Stop))
+}
+
+// MockIndexCache is a mock of IndexCache interface.
+type MockIndexCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockIndexCacheMockRecorder is the mock recorder for MockIndexCache.
+type MockIndexCacheMockRecorder struct {
+	mock *MockIndexCache
+}
+
+// NewMockIndexCache creates a new mock instance.
+func NewMockIndexCache(ctrl *gomock.Controller) *MockIndexCache {
+	mock := &MockIndexCache{ctrl: ctrl}
+	mock.recorder = &MockIndexCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexCache) EXPECT() *MockIndexCacheMockRecorder {
+	return m.recorder
+}
+
+// GetForRoute mocks base method.
+func (m *MockIndexCache) GetForRoute(route string) []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForRoute", route)
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// GetForRoute indicates an expected call of GetForRoute.
+func (mr *MockIndexCacheMockRecorder) GetForRoute(route any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForRoute", reflect.TypeOf((*MockIndexCache)(nil).GetForRoute), route)
+}
+
+// GetOriginal mocks base method.
+func (m *MockIndexCache) GetOriginal() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOriginal")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// GetOriginal indicates an expected call of GetOriginal.
+func (mr *MockIndexCacheMockRecorder) GetOriginal() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginal", reflect.TypeOf((*MockIndexCache)(nil).GetOriginal))
+}
+
+// PrewarmRoutes mocks base method.
+func (m *MockIndexCache) PrewarmRoutes(logger logrus.FieldLogger, filesystem fs.FS, configData, boundsData, versionData, forksData any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrewarmRoutes", logger, filesystem, configData, boundsData, versionData, forksData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrewarmRoutes indicates an expected call of PrewarmRoutes.
+func (mr *MockIndexCacheMockRecorder) PrewarmRoutes(logger, filesystem, configData, boundsData, versionData, forksData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrewarmRoutes", reflect.TypeOf((*MockIndexCache)(nil).PrewarmRoutes), logger, filesystem, configData, boundsData, versionData, forksData)
+}
+
+// Render mocks base method.
+func (m *MockIndexCache) Render(route string, configData, boundsData, versionData, forksData any) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", route, configData, boundsData, versionData, forksData)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockIndexCacheMockRecorder) Render(route, configData, boundsData, versionData, forksData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockIndexCache)(nil).Render), route, configData, boundsData, versionData, forksData)
+}
+
+// Update mocks base method.
+func (m *MockIndexCache) Update(configData, boundsData, versionData, forksData any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", configData, boundsData, versionData, forksData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIndexCacheMockRecorder) Update(configData, boundsData, versionData, forksData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIndexCache)(nil).Update), configData, boundsData, versionData, forksData)
+}
@@ -0,0 +1,42 @@
+package frontend
+
+//go:generate mockgen -package mocks -destination mocks/mock_frontend.go github.com/ethpandaops/lab-backend/internal/frontend Handler,IndexCache
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Compile-time interface compliance checks.
+var (
+	_ Handler    = (*Frontend)(nil)
+	_ IndexCache = (*RouteIndexCache)(nil)
+)
+
+// Handler serves the frontend and keeps its index cache up to date.
+type Handler interface {
+	http.Handler
+	// Start starts refreshing the index cache when config or bounds change.
+	Start(ctx context.Context) error
+	// Stop stops the refresh loop.
+	Stop() error
+	// Reload re-reads index.html and head.json and rebuilds the index cache.
+	Reload(ctx context.Context) error
+}
+
+// IndexCache holds index.html with config, bounds, forks and head tags injected.
+type IndexCache interface {
+	// PrewarmRoutes loads index.html and head.json and caches every route.
+	PrewarmRoutes(logger logrus.FieldLogger, filesystem fs.FS, configData, boundsData, versionData, forksData any) error
+	// GetForRoute returns the cached HTML for a route, or the default.
+	GetForRoute(route string) []byte
+	// Render injects data for a route without caching the result.
+	Render(route string, configData, boundsData, versionData, forksData any) ([]byte, error)
+	// Update regenerates every cached route with new data.
+	Update(configData, boundsData, versionData, forksData any) error
+	// GetOriginal returns index.html as loaded.
+	GetOriginal() []byte
+}
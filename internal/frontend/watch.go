@@ -0,0 +1,66 @@
+package frontend
+
+import (
+	"io/fs"
+	"time"
+)
+
+// watchedFiles are the files a reload picks up.
+var watchedFiles = []string{indexFileName, "head.json"}
+
+// fileState is what a change is detected by.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// fileWatcher detects changes to the watched files of a bundle by polling.
+// Used in dev mode, where the bundle is served from disk.
+type fileWatcher struct {
+	fs     fs.FS
+	states map[string]fileState
+}
+
+// newFileWatcher records the current state of the watched files.
+func newFileWatcher(fsys fs.FS) *fileWatcher {
+	w := &fileWatcher{fs: fsys}
+	w.states = w.snapshot()
+
+	return w
+}
+
+// changed reports whether any watched file was created, modified or removed
+// since the last call.
+func (w *fileWatcher) changed() bool {
+	states := w.snapshot()
+
+	changed := false
+
+	for name, state := range states {
+		if w.states[name] != state {
+			changed = true
+		}
+	}
+
+	w.states = states
+
+	return changed
+}
+
+func (w *fileWatcher) snapshot() map[string]fileState {
+	states := make(map[string]fileState, len(watchedFiles))
+
+	for _, name := range watchedFiles {
+		info, err := fs.Stat(w.fs, name)
+		if err != nil {
+			states[name] = fileState{}
+
+			continue
+		}
+
+		states[name] = fileState{modTime: info.ModTime(), size: info.Size(), exists: true}
+	}
+
+	return states
+}
@@ -0,0 +1,33 @@
+package frontend
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWatcher(t *testing.T) {
+	now := time.Now()
+	fsys := fstest.MapFS{
+		"index.html": &fstest.MapFile{Data: []byte("<html><head></head></html>"), ModTime: now},
+	}
+
+	w := newFileWatcher(fsys)
+	assert.False(t, w.changed())
+
+	// head.json appears
+	fsys["head.json"] = &fstest.MapFile{Data: []byte(`{}`), ModTime: now}
+	assert.True(t, w.changed())
+	assert.False(t, w.changed())
+
+	// index.html modified
+	fsys["index.html"] = &fstest.MapFile{Data: []byte("<html><head></head></html>"), ModTime: now.Add(time.Second)}
+	assert.True(t, w.changed())
+
+	// head.json removed
+	delete(fsys, "head.json")
+	assert.True(t, w.changed())
+	assert.False(t, w.changed())
+}
@@ -0,0 +1,121 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	labv1 "github.com/ethpandaops/lab-backend/pkg/proto/lab/v1"
+)
+
+// methodScopes maps each RPC to the endpoint class of its REST counterpart.
+var methodScopes = map[string]string{
+	labv1.LabService_GetConfig_FullMethodName:     config.ScopeConfig,
+	labv1.LabService_GetBounds_FullMethodName:     config.ScopeProxy,
+	labv1.LabService_WatchNetworks_FullMethodName: config.ScopeConfig,
+}
+
+// authenticatedStream overrides the context of a server stream with one
+// carrying the caller's identity.
+type authenticatedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx // carries the identity to the handler
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// unaryAuth authenticates unary calls like the REST Auth and RequireScope middlewares.
+func (s *Server) unaryAuth(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamAuth authenticates streaming calls like the REST Auth and RequireScope middlewares.
+func (s *Server) streamAuth(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate resolves the "authorization: Bearer <key>" metadata to an
+// identity attached to the returned context, and checks the key is scoped to
+// the method's endpoint class. Calls without a key continue anonymously
+// unless keys are required.
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if s.authRequired {
+			return nil, status.Error(codes.Unauthenticated, "api key required")
+		}
+
+		return ctx, nil
+	}
+
+	identity, err := s.authStore.Authenticate(ctx, token)
+
+	switch {
+	case errors.Is(err, auth.ErrInvalidKey):
+		s.log.WithField("method", method).Debug("invalid api key")
+
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	case err != nil:
+		s.log.WithError(err).Warn("api key lookup failed")
+
+		// Without Redis, keyed clients fall back to anonymous access
+		if s.authRequired {
+			return nil, status.Error(codes.Unavailable, "authentication unavailable")
+		}
+
+		return ctx, nil
+	}
+
+	if scope := methodScopes[method]; !identity.AllowsScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "api key is not scoped to %s endpoints", scope)
+	}
+
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+// bearerToken extracts the token from Bearer authorization metadata.
+func bearerToken(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
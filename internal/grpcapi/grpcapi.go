@@ -0,0 +1,336 @@
+// Package grpcapi serves the gRPC mirror of the REST config and bounds
+// endpoints, defined in proto/lab/v1/lab.proto.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/notify"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	labv1 "github.com/ethpandaops/lab-backend/pkg/proto/lab/v1"
+)
+
+// Compile-time interface compliance check.
+var _ labv1.LabServiceServer = (*Server)(nil)
+
+// Server implements LabService on top of the same config handler and
+// providers as the REST API.
+type Server struct {
+	labv1.UnimplementedLabServiceServer
+
+	cfg                   config.GRPCConfig
+	log                   logrus.FieldLogger
+	configHandler         *api.ConfigHandler
+	boundsProvider        bounds.Provider
+	cartographoorProvider cartographoor.Provider
+	authStore             *auth.Store // nil when API keys are disabled
+	authRequired          bool
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+
+	// watchers is signalled when cartographoor data may have changed
+	watchers *notify.Broadcaster
+	watching chan struct{} // Semaphore bounding concurrent WatchNetworks streams
+
+	task *tasks.Task
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates the gRPC API server. With an auth store, API keys are
+// authenticated from the "authorization" metadata and scoped like over HTTP;
+// authRequired rejects calls without one.
+func New(
+	log logrus.FieldLogger,
+	cfg config.GRPCConfig,
+	configHandler *api.ConfigHandler,
+	boundsProvider bounds.Provider,
+	cartographoorProvider cartographoor.Provider,
+	authStore *auth.Store,
+	authRequired bool,
+) *Server {
+	s := &Server{
+		cfg:                   cfg,
+		log:                   log.WithField("component", "grpc"),
+		configHandler:         configHandler,
+		boundsProvider:        boundsProvider,
+		cartographoorProvider: cartographoorProvider,
+		authStore:             authStore,
+		authRequired:          authRequired,
+		watchers:              notify.New(),
+		watching:              make(chan struct{}, cfg.MaxWatchers),
+		done:                  make(chan struct{}),
+	}
+
+	var opts []grpc.ServerOption
+	if authStore != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.unaryAuth),
+			grpc.ChainStreamInterceptor(s.streamAuth),
+		)
+	}
+
+	s.grpcServer = grpc.NewServer(opts...)
+
+	labv1.RegisterLabServiceServer(s.grpcServer, s)
+
+	return s
+}
+
+// Start listens on the configured address and serves in the background.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.cfg.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	s.listener = listener
+
+	s.task = tasks.Default().Register("grpc.watch", 0)
+	s.wg.Add(1)
+
+	go s.watchLoop()
+
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.log.WithError(err).Error("gRPC server failed")
+		}
+	}()
+
+	s.log.WithField("addr", listener.Addr().String()).Info("gRPC server started")
+
+	return nil
+}
+
+// Addr returns the address the server is listening on. Start must have been called.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Stop ends all streams and stops the server, waiting for in-flight calls
+// until ctx expires.
+func (s *Server) Stop(ctx context.Context) {
+	close(s.done)
+	s.wg.Wait()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+	}
+}
+
+// watchLoop forwards cartographoor notifications to WatchNetworks streams.
+func (s *Server) watchLoop() {
+	defer s.wg.Done()
+
+	s.task.Supervise(s.log, s.done, s.runWatchLoop)
+}
+
+func (s *Server) runWatchLoop() {
+	var notifyChan <-chan struct{}
+	if s.cartographoorProvider != nil {
+		notifyChan = s.cartographoorProvider.NotifyChannel()
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-notifyChan:
+			s.task.Tick()
+			s.watchers.Notify()
+		}
+	}
+}
+
+// GetConfig returns the networks and features served by /api/v1/config,
+// limited to the networks of a scoped API key.
+func (s *Server) GetConfig(ctx context.Context, _ *labv1.GetConfigRequest) (*labv1.GetConfigResponse, error) {
+	data := s.configHandler.GetConfigData(ctx)
+
+	features := make([]*labv1.Feature, 0, len(data.Features))
+	for _, feature := range data.Features {
+		features = append(features, &labv1.Feature{
+			Path:             feature.Path,
+			DisabledNetworks: feature.DisabledNetworks,
+		})
+	}
+
+	return &labv1.GetConfigResponse{
+		Networks: convertNetworks(data.Networks),
+		Features: features,
+	}, nil
+}
+
+// GetBounds returns the bounds served by /api/v1/{network}/bounds.
+func (s *Server) GetBounds(ctx context.Context, req *labv1.GetBoundsRequest) (*labv1.GetBoundsResponse, error) {
+	if req.GetNetwork() == "" {
+		return nil, status.Error(codes.InvalidArgument, "network is required")
+	}
+
+	if identity, ok := auth.IdentityFromContext(ctx); ok && !identity.AllowsNetwork(req.GetNetwork()) {
+		return nil, status.Errorf(codes.PermissionDenied, "api key is not scoped to network %s", req.GetNetwork())
+	}
+
+	if s.boundsProvider == nil {
+		return nil, status.Error(codes.Unavailable, "bounds service unavailable")
+	}
+
+	// Stale bounds are served like over HTTP
+	data, err := s.boundsProvider.GetBounds(ctx, req.GetNetwork())
+	if err != nil && !errors.Is(err, errs.ErrStale) {
+		return nil, status.Errorf(grpcCode(err), "network %s not found or bounds unavailable", req.GetNetwork())
+	}
+
+	tables := make(map[string]*labv1.TableBounds, len(data.Tables))
+	for table, tableBounds := range data.Tables {
+		tables[table] = &labv1.TableBounds{Min: tableBounds.Min, Max: tableBounds.Max}
+	}
+
+	return &labv1.GetBoundsResponse{
+		Tables:      tables,
+		LastUpdated: timestamppb.New(data.LastUpdated),
+	}, nil
+}
+
+// WatchNetworks sends the network list now and after every change.
+func (s *Server) WatchNetworks(_ *labv1.WatchNetworksRequest, stream labv1.LabService_WatchNetworksServer) error {
+	select {
+	case s.watching <- struct{}{}:
+		defer func() { <-s.watching }()
+	default:
+		return status.Error(codes.ResourceExhausted, "too many watchers")
+	}
+
+	updates := s.watchers.Subscribe()
+	defer s.watchers.Unsubscribe(updates)
+
+	var last []*labv1.Network
+
+	for {
+		networks := convertNetworks(s.configHandler.GetConfigData(stream.Context()).Networks)
+
+		// Followers poll on every refresh, only send actual changes
+		if last == nil || !networksEqual(last, networks) {
+			if err := stream.Send(&labv1.WatchNetworksResponse{
+				Networks:  networks,
+				Timestamp: timestamppb.New(time.Now()),
+			}); err != nil {
+				return err
+			}
+
+			last = networks
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server shutting down")
+		case <-updates:
+		}
+	}
+}
+
+func networksEqual(a, b []*labv1.Network) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// convertNetworks converts the REST network list to its protobuf form.
+func convertNetworks(networks []api.NetworkInfo) []*labv1.Network {
+	result := make([]*labv1.Network, 0, len(networks))
+
+	for _, network := range networks {
+		consensus := make(map[string]*labv1.ConsensusFork, len(network.Forks.Consensus))
+		for name, fork := range network.Forks.Consensus {
+			consensus[name] = &labv1.ConsensusFork{
+				Epoch:             fork.Epoch,
+				Timestamp:         fork.Timestamp,
+				MinClientVersions: fork.MinClientVersions,
+			}
+		}
+
+		execution := make(map[string]*labv1.ExecutionFork, len(network.Forks.Execution))
+		for name, fork := range network.Forks.Execution {
+			execution[name] = &labv1.ExecutionFork{
+				Block:     fork.Block,
+				Timestamp: fork.Timestamp,
+			}
+		}
+
+		blobSchedule := make([]*labv1.BlobScheduleEntry, 0, len(network.BlobSchedule))
+		for _, entry := range network.BlobSchedule {
+			blobSchedule = append(blobSchedule, &labv1.BlobScheduleEntry{
+				Epoch:            entry.Epoch,
+				Timestamp:        entry.Timestamp,
+				MaxBlobsPerBlock: entry.MaxBlobsPerBlock,
+			})
+		}
+
+		result = append(result, &labv1.Network{
+			Name:         network.Name,
+			DisplayName:  network.DisplayName,
+			ChainId:      network.ChainID,
+			GenesisTime:  network.GenesisTime,
+			GenesisDelay: network.GenesisDelay,
+			Forks: &labv1.Forks{
+				Consensus: consensus,
+				Execution: execution,
+			},
+			ServiceUrls:  network.ServiceUrls,
+			BlobSchedule: blobSchedule,
+			Aliases:      network.Aliases,
+		})
+	}
+
+	return result
+}
+
+// grpcCode is the gRPC counterpart of errs.HTTPStatus.
+func grpcCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, errs.ErrUpstreamUnavailable), errors.Is(err, errs.ErrStale), errors.Is(err, errs.ErrNotLeader):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,263 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+	labv1 "github.com/ethpandaops/lab-backend/pkg/proto/lab/v1"
+)
+
+// testNetworks is the cartographoor network set served to the test server.
+type testNetworks struct {
+	mu       sync.Mutex
+	networks map[string]*cartographoor.Network
+}
+
+func (n *testNetworks) set(networks map[string]*cartographoor.Network) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.networks = networks
+}
+
+func (n *testNetworks) get() map[string]*cartographoor.Network {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.networks
+}
+
+// newTestServer starts a gRPC server on a loopback port and returns a client,
+// the network set and the cartographoor notification channel.
+func newTestServer(t *testing.T, maxWatchers int) (labv1.LabServiceClient, *testNetworks, chan struct{}) {
+	t.Helper()
+
+	return newTestServerWithAuth(t, maxWatchers, nil)
+}
+
+// newTestServerWithAuth is newTestServer authenticating API keys against authStore, if set.
+func newTestServerWithAuth(
+	t *testing.T,
+	maxWatchers int,
+	authStore *auth.Store,
+) (labv1.LabServiceClient, *testNetworks, chan struct{}) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	ctrl := gomock.NewController(t)
+	notify := make(chan struct{}, 1)
+	networks := &testNetworks{networks: map[string]*cartographoor.Network{
+		"mainnet": {Name: "mainnet", DisplayName: "Mainnet", ChainID: 1, Status: cartographoor.NetworkStatusActive},
+	}}
+
+	cartoProvider := cartomocks.NewMockProvider(ctrl)
+	cartoProvider.EXPECT().NotifyChannel().Return(notify).AnyTimes()
+	cartoProvider.EXPECT().GetActiveNetworks(gomock.Any()).DoAndReturn(
+		func(context.Context) map[string]*cartographoor.Network { return networks.get() },
+	).AnyTimes()
+	cartoProvider.EXPECT().GetNetwork(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, name string) (*cartographoor.Network, error) {
+			network, exists := networks.get()[name]
+			if !exists {
+				return nil, errs.ErrNotFound
+			}
+
+			return network, nil
+		},
+	).AnyTimes()
+
+	boundsProvider := boundsmocks.NewMockProvider(ctrl)
+	boundsProvider.EXPECT().GetBounds(gomock.Any(), "mainnet").Return(&bounds.BoundsData{
+		Tables:      map[string]bounds.TableBounds{"fct_block": {Min: 1, Max: 100}},
+		LastUpdated: time.Unix(1700000000, 0),
+	}, nil).AnyTimes()
+	boundsProvider.EXPECT().GetBounds(gomock.Any(), gomock.Any()).Return(nil, errs.ErrNotFound).AnyTimes()
+
+	cfg := &config.Config{Features: []config.FeatureSettings{{Path: "/ethereum/blocks", DisabledNetworks: []string{"sepolia"}}}}
+	configHandler := api.NewConfigHandler(logger, cfg, cartoProvider, nil, nil, nil)
+
+	server := New(logger, config.GRPCConfig{
+		Enabled:       true,
+		ListenAddress: "127.0.0.1:0",
+		MaxWatchers:   maxWatchers,
+	}, configHandler, boundsProvider, cartoProvider, authStore, false)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { server.Stop(context.Background()) })
+
+	conn, err := grpc.NewClient(server.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return labv1.NewLabServiceClient(conn), networks, notify
+}
+
+func TestServer_GetConfig(t *testing.T) {
+	client, _, _ := newTestServer(t, 10)
+
+	resp, err := client.GetConfig(t.Context(), &labv1.GetConfigRequest{})
+	require.NoError(t, err)
+
+	require.Len(t, resp.GetNetworks(), 1)
+	assert.Equal(t, "mainnet", resp.GetNetworks()[0].GetName())
+	assert.Equal(t, int64(1), resp.GetNetworks()[0].GetChainId())
+
+	require.Len(t, resp.GetFeatures(), 1)
+	assert.Equal(t, "/ethereum/blocks", resp.GetFeatures()[0].GetPath())
+	assert.Equal(t, []string{"sepolia"}, resp.GetFeatures()[0].GetDisabledNetworks())
+}
+
+func TestServer_GetBounds(t *testing.T) {
+	client, _, _ := newTestServer(t, 10)
+
+	resp, err := client.GetBounds(t.Context(), &labv1.GetBoundsRequest{Network: "mainnet"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), resp.GetTables()["fct_block"].GetMax())
+	assert.Equal(t, int64(1700000000), resp.GetLastUpdated().GetSeconds())
+
+	_, err = client.GetBounds(t.Context(), &labv1.GetBoundsRequest{Network: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetBounds(t.Context(), &labv1.GetBoundsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_WatchNetworks(t *testing.T) {
+	client, networks, notify := newTestServer(t, 10)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchNetworks(ctx, &labv1.WatchNetworksRequest{})
+	require.NoError(t, err)
+
+	// Current networks are sent on subscribe
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.GetNetworks(), 1)
+
+	// A notification without changes sends nothing, the next change is sent
+	notify <- struct{}{}
+
+	networks.set(map[string]*cartographoor.Network{
+		"mainnet": {Name: "mainnet", DisplayName: "Mainnet", ChainID: 1, Status: cartographoor.NetworkStatusActive},
+		"hoodi":   {Name: "hoodi", DisplayName: "Hoodi", ChainID: 560048, Status: cartographoor.NetworkStatusActive},
+	})
+
+	notify <- struct{}{}
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Len(t, resp.GetNetworks(), 2)
+}
+
+func TestServer_WatchNetworksLimit(t *testing.T) {
+	client, _, _ := newTestServer(t, 1)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	first, err := client.WatchNetworks(ctx, &labv1.WatchNetworksRequest{})
+	require.NoError(t, err)
+
+	// Wait until the first watcher holds its slot
+	_, err = first.Recv()
+	require.NoError(t, err)
+
+	second, err := client.WatchNetworks(ctx, &labv1.WatchNetworksRequest{})
+	require.NoError(t, err)
+
+	_, err = second.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestServer_ScopedKey(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	redisClient, _ := redistest.New(t, logger)
+
+	cfg := config.AuthConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	store := auth.NewStore(logger, cfg, redisClient)
+	require.NoError(t, store.Put(t.Context(), "hoodi-key", auth.Key{
+		ID:       "k1",
+		Tier:     config.TierPro,
+		Networks: []string{"hoodi"},
+		Scopes:   []string{config.ScopeConfig, config.ScopeProxy},
+	}))
+	require.NoError(t, store.Put(t.Context(), "proxy-key", auth.Key{
+		ID:     "k2",
+		Tier:   config.TierPro,
+		Scopes: []string{config.ScopeProxy},
+	}))
+
+	client, networks, _ := newTestServerWithAuth(t, 10, store)
+	networks.set(map[string]*cartographoor.Network{
+		"mainnet": {Name: "mainnet", DisplayName: "Mainnet", ChainID: 1, Status: cartographoor.NetworkStatusActive},
+		"hoodi":   {Name: "hoodi", DisplayName: "Hoodi", ChainID: 560048, Status: cartographoor.NetworkStatusActive},
+	})
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer "+key)
+	}
+
+	// Anonymous calls see every network
+	resp, err := client.GetConfig(t.Context(), &labv1.GetConfigRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetNetworks(), 2)
+
+	// Networks are limited to the key's
+	resp, err = client.GetConfig(withKey("hoodi-key"), &labv1.GetConfigRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetNetworks(), 1)
+	assert.Equal(t, "hoodi", resp.GetNetworks()[0].GetName())
+
+	ctx, cancel := context.WithTimeout(withKey("hoodi-key"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchNetworks(ctx, &labv1.WatchNetworksRequest{})
+	require.NoError(t, err)
+
+	watched, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, watched.GetNetworks(), 1)
+	assert.Equal(t, "hoodi", watched.GetNetworks()[0].GetName())
+
+	_, err = client.GetBounds(withKey("hoodi-key"), &labv1.GetBoundsRequest{Network: "mainnet"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Endpoint classes are checked per method
+	_, err = client.GetConfig(withKey("proxy-key"), &labv1.GetConfigRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetBounds(withKey("proxy-key"), &labv1.GetBoundsRequest{Network: "mainnet"})
+	require.NoError(t, err)
+
+	_, err = client.GetConfig(withKey("unknown-key"), &labv1.GetConfigRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -1,33 +0,0 @@
-package handlers
-
-import (
-	"encoding/json"
-	"net/http"
-
-	"github.com/ethpandaops/lab-backend/internal/version"
-)
-
-// HealthResponse represents the health check response.
-type HealthResponse struct {
-	Status  string `json:"status"`
-	Version string `json:"version"`
-}
-
-// Health returns an HTTP handler for health check endpoint.
-func Health() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		response := HealthResponse{
-			Status:  "healthy",
-			Version: version.Short(),
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-
-			return
-		}
-	}
-}
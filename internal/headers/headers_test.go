@@ -1,6 +1,7 @@
 package headers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ethpandaops/lab-backend/internal/config"
@@ -430,6 +431,13 @@ func TestNewManager_ErrorHandling(t *testing.T) {
 			},
 			wantError: "invalid path_pattern in policy \"bad\"",
 		},
+		{
+			name: "unknown template variable",
+			policies: []config.HeaderPolicy{
+				{Name: "csp", PathPattern: `.*`, Headers: map[string]string{"X": "{{ hash }}"}},
+			},
+			wantError: "unknown template variable \"{{ hash }}\" in header X of policy \"csp\"",
+		},
 	}
 
 	for _, tt := range tests {
@@ -442,6 +450,44 @@ func TestNewManager_ErrorHandling(t *testing.T) {
 	}
 }
 
+func TestManagerResolve_Nonce(t *testing.T) {
+	mgr, err := NewManager([]config.HeaderPolicy{
+		{
+			Name:        "html",
+			PathPattern: `^/$`,
+			Headers: map[string]string{
+				"Content-Security-Policy": "script-src 'nonce-{{ nonce }}' 'strict-dynamic'",
+				"Cache-Control":           "no-store",
+			},
+		},
+		{Name: "api", PathPattern: `^/api/`, Headers: map[string]string{"Cache-Control": "max-age=60"}},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	first, firstCtx := mgr.Resolve(ctx, "/")
+	second, _ := mgr.Resolve(ctx, "/")
+
+	nonce := NonceFromContext(firstCtx)
+	require.NotEmpty(t, nonce)
+
+	assert.Equal(t, "script-src 'nonce-"+nonce+"' 'strict-dynamic'", first["Content-Security-Policy"])
+	assert.Equal(t, "no-store", first["Cache-Control"])
+	assert.NotEqual(t, first["Content-Security-Policy"], second["Content-Security-Policy"])
+
+	// The policy itself keeps its template
+	assert.Equal(t, "script-src 'nonce-{{nonce}}' 'strict-dynamic'", mgr.Match("/")["Content-Security-Policy"])
+
+	// Policies without templates don't get a nonce
+	api, apiCtx := mgr.Resolve(ctx, "/api/v1/config")
+	assert.Equal(t, map[string]string{"Cache-Control": "max-age=60"}, api)
+	assert.Empty(t, NonceFromContext(apiCtx))
+
+	none, _ := mgr.Resolve(ctx, "/other")
+	assert.Nil(t, none)
+}
+
 // BenchmarkManagerMatch benchmarks path matching performance.
 func BenchmarkManagerMatch(b *testing.B) {
 	policies := []config.HeaderPolicy{
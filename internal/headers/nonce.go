@@ -0,0 +1,34 @@
+package headers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// nonceBytes is the entropy of a CSP nonce, 128 bits as recommended by CSP3.
+const nonceBytes = 16
+
+// nonceContextKey carries the CSP nonce of the current request.
+type nonceContextKey struct{}
+
+// NewNonce returns a random base64 nonce for a Content-Security-Policy.
+func NewNonce() string {
+	b := make([]byte, nonceBytes)
+	_, _ = rand.Read(b) // Never fails
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// ContextWithNonce returns a context carrying the request's nonce.
+func ContextWithNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, nonceContextKey{}, nonce)
+}
+
+// NonceFromContext returns the nonce of the request's header policy, or ""
+// if its headers don't use one.
+func NonceFromContext(ctx context.Context) string {
+	nonce, _ := ctx.Value(nonceContextKey{}).(string)
+
+	return nonce
+}
@@ -1,12 +1,22 @@
 package headers
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"regexp"
+	"strings"
 
 	"github.com/ethpandaops/lab-backend/internal/config"
 )
 
+// templateVar matches {{name}} placeholders in header values.
+var templateVar = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// NonceVar is replaced by a fresh per-request nonce in header values, e.g.
+// "script-src 'nonce-{{nonce}}' 'strict-dynamic'".
+const NonceVar = "{{nonce}}"
+
 // Manager manages header policies and matches request paths to policies.
 type Manager struct {
 	policies []compiledPolicy
@@ -14,13 +24,15 @@ type Manager struct {
 
 // compiledPolicy represents a header policy with a compiled regex pattern.
 type compiledPolicy struct {
-	name    string
-	pattern *regexp.Regexp
-	headers map[string]string
+	name      string
+	pattern   *regexp.Regexp
+	headers   map[string]string
+	usesNonce bool // Some header value contains {{nonce}}
 }
 
 // NewManager creates a new Manager from a list of header policies.
-// Returns an error if any path_pattern is an invalid regex.
+// Returns an error if any path_pattern is an invalid regex, or a header
+// value uses an unknown template variable.
 func NewManager(policies []config.HeaderPolicy) (*Manager, error) {
 	compiled := make([]compiledPolicy, 0, len(policies))
 
@@ -30,23 +42,78 @@ func NewManager(policies []config.HeaderPolicy) (*Manager, error) {
 			return nil, fmt.Errorf("invalid path_pattern in policy %q: %w", p.Name, err)
 		}
 
+		headers := p.Headers
+		usesNonce := false
+
+		for key, value := range p.Headers {
+			for _, match := range templateVar.FindAllStringSubmatch(value, -1) {
+				if match[1] != "nonce" {
+					return nil, fmt.Errorf("unknown template variable %q in header %s of policy %q", match[0], key, p.Name)
+				}
+
+				usesNonce = true
+			}
+		}
+
+		// Normalize {{ nonce }} so it is substituted with a plain replace
+		if usesNonce {
+			headers = make(map[string]string, len(p.Headers))
+			for key, value := range p.Headers {
+				headers[key] = templateVar.ReplaceAllString(value, NonceVar)
+			}
+		}
+
 		compiled = append(compiled, compiledPolicy{
-			name:    p.Name,
-			pattern: pattern,
-			headers: p.Headers,
+			name:      p.Name,
+			pattern:   pattern,
+			headers:   headers,
+			usesNonce: usesNonce,
 		})
 	}
 
 	return &Manager{policies: compiled}, nil
 }
 
-// Match returns headers for the first policy matching the given path.
-// Returns nil if no policy matches.
+// Match returns headers for the first policy matching the given path, with
+// template variables unfilled. Returns nil if no policy matches.
 // Policies are evaluated in order - first match wins.
 func (m *Manager) Match(path string) map[string]string {
-	for _, p := range m.policies {
-		if p.pattern.MatchString(path) {
-			return p.headers
+	if p := m.match(path); p != nil {
+		return p.headers
+	}
+
+	return nil
+}
+
+// Resolve returns the headers of the first policy matching path for one
+// request, with template variables filled in. When a header uses the nonce,
+// a fresh one is generated and returned in ctx for NonceFromContext, so the
+// response can use it too. Returns nil if no policy matches.
+func (m *Manager) Resolve(ctx context.Context, path string) (map[string]string, context.Context) {
+	p := m.match(path)
+	if p == nil {
+		return nil, ctx
+	}
+
+	if !p.usesNonce {
+		return p.headers, ctx
+	}
+
+	nonce := NewNonce()
+
+	headers := maps.Clone(p.headers)
+	for key, value := range headers {
+		headers[key] = strings.ReplaceAll(value, NonceVar, nonce)
+	}
+
+	return headers, ContextWithNonce(ctx, nonce)
+}
+
+// match returns the first policy matching path, nil if none does.
+func (m *Manager) match(path string) *compiledPolicy {
+	for i := range m.policies {
+		if m.policies[i].pattern.MatchString(path) {
+			return &m.policies[i]
 		}
 	}
 
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/maintenance"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+// RedisCheck pings Redis, which every instance needs to serve config and bounds.
+func RedisCheck(client redis.Client) Check {
+	return Check{
+		Name:     "redis",
+		Critical: true,
+		Run: func(ctx context.Context) (string, error) {
+			if err := client.Ping(ctx); err != nil {
+				return "", fmt.Errorf("ping: %w", err)
+			}
+
+			return "", nil
+		},
+	}
+}
+
+// CartographoorCheck requires network data in Redis. The leader stores it
+// with cartographoor.networks_ttl, so data that stopped refreshing expires.
+func CartographoorCheck(provider cartographoor.Provider) Check {
+	return Check{
+		Name:     "cartographoor",
+		Critical: true,
+		Run: func(ctx context.Context) (string, error) {
+			networks := provider.GetNetworks(ctx)
+			if len(networks) == 0 {
+				return "", fmt.Errorf("no network data")
+			}
+
+			return fmt.Sprintf("%d networks", len(networks)), nil
+		},
+	}
+}
+
+// BoundsCheck reports networks whose bounds are marked stale or were last
+// updated more than maxAge ago. Stale bounds are still served, so the check
+// only degrades readiness.
+func BoundsCheck(provider bounds.Provider, maxAge time.Duration) Check {
+	return Check{
+		Name: "bounds",
+		Run: func(ctx context.Context) (string, error) {
+			all := provider.GetAllBounds(ctx)
+			if len(all) == 0 {
+				return "", fmt.Errorf("no bounds data")
+			}
+
+			var stale []string
+
+			for network, data := range all {
+				if data.Stale || time.Since(data.LastUpdated) > maxAge {
+					stale = append(stale, network)
+				}
+			}
+
+			if len(stale) > 0 {
+				sort.Strings(stale)
+
+				return "", fmt.Errorf("stale bounds for %s", strings.Join(stale, ", "))
+			}
+
+			return fmt.Sprintf("%d networks", len(all)), nil
+		},
+	}
+}
+
+// LeaderCheck reports whether some instance holds leadership. Without a
+// leader nothing is refreshed, but stored data is still served.
+func LeaderCheck(elector leader.Elector) Check {
+	return Check{
+		Name: "leader",
+		Run: func(ctx context.Context) (string, error) {
+			status, err := elector.Status(ctx)
+			if err != nil {
+				return "", fmt.Errorf("status: %w", err)
+			}
+
+			if status.LeaderID == "" {
+				return "", fmt.Errorf("no leader")
+			}
+
+			if status.IsLeader {
+				return fmt.Sprintf("leader (term %d)", status.Term), nil
+			}
+
+			return fmt.Sprintf("follower of %s (term %d)", status.LeaderID, status.Term), nil
+		},
+	}
+}
+
+// MaintenanceCheck reports the scheduled maintenance window. It never fails:
+// instances in maintenance are still healthy, and failing readiness would take
+// every replica, and with it the maintenance page, out of the load balancer.
+func MaintenanceCheck(mode *maintenance.Mode) Check {
+	return Check{
+		Name: "maintenance",
+		Run: func(context.Context) (string, error) {
+			window := mode.Window()
+
+			switch {
+			case window == nil:
+				return "none scheduled", nil
+			case mode.Active() == nil:
+				return fmt.Sprintf("scheduled from %s", window.StartsAt.UTC().Format(time.RFC3339)), nil
+			case window.EndsAt.IsZero():
+				return "active until cleared", nil
+			default:
+				return fmt.Sprintf("active until %s", window.EndsAt.UTC().Format(time.RFC3339)), nil
+			}
+		},
+	}
+}
@@ -0,0 +1,136 @@
+// Package health serves the liveness and readiness probes. Liveness only
+// reports that the process is serving requests; readiness runs a check per
+// dependency and fails when a critical one does.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// checkTimeout bounds each check, so a hanging dependency fails the probe
+// instead of timing it out.
+const checkTimeout = 2 * time.Second
+
+// Checker runs the readiness checks.
+type Checker struct {
+	checks []Check
+	logger logrus.FieldLogger
+}
+
+// NewChecker creates a checker running checks.
+func NewChecker(logger logrus.FieldLogger, checks ...Check) *Checker {
+	return &Checker{
+		checks: checks,
+		logger: logger.WithField("component", "health"),
+	}
+}
+
+// Liveness handles /healthz. It does not touch dependencies, so a Redis or
+// upstream outage never gets the process restarted.
+func (c *Checker) Liveness(w http.ResponseWriter, r *http.Request) {
+	c.write(w, r, http.StatusOK, Response{
+		Status:  StatusOK,
+		Version: version.Short(),
+	})
+}
+
+// Readiness handles /readyz. It responds 503 when a critical check fails.
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	response := c.Run(r.Context())
+
+	code := http.StatusOK
+	if response.Status == StatusUnavailable {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.write(w, r, code, response)
+}
+
+// Run runs every check concurrently and aggregates the results.
+func (c *Checker) Run(ctx context.Context) Response {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]CheckResult, len(c.checks))
+	)
+
+	for _, check := range c.checks {
+		wg.Go(func() {
+			result := c.run(ctx, check)
+
+			mu.Lock()
+			results[check.Name] = result
+			mu.Unlock()
+		})
+	}
+
+	wg.Wait()
+
+	status := StatusOK
+
+	for name, result := range results {
+		if result.Status == StatusOK {
+			continue
+		}
+
+		c.logger.WithField("check", name).WithField("error", result.Error).Debug("Health check failed")
+
+		if result.Critical {
+			status = StatusUnavailable
+		} else if status == StatusOK {
+			status = StatusDegraded
+		}
+	}
+
+	return Response{
+		Status:  status,
+		Version: version.Short(),
+		Checks:  results,
+	}
+}
+
+// run runs a single check under the check timeout.
+func (c *Checker) run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := check.Run(ctx)
+
+	result := CheckResult{
+		Status:     StatusOK,
+		Critical:   check.Critical,
+		Detail:     detail,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Status = StatusUnavailable
+		if !check.Critical {
+			result.Status = StatusDegraded
+		}
+
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func (c *Checker) write(w http.ResponseWriter, r *http.Request, code int, response Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		requestid.Logger(r.Context(), c.logger).WithError(err).Error("Failed to encode response")
+	}
+}
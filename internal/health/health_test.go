@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
+	redismocks "github.com/ethpandaops/lab-backend/internal/redis/mocks"
+)
+
+func newTestChecker(checks ...Check) *Checker {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return NewChecker(logger, checks...)
+}
+
+func staticCheck(name string, critical bool, err error) Check {
+	return Check{
+		Name:     name,
+		Critical: critical,
+		Run: func(context.Context) (string, error) {
+			return "detail", err
+		},
+	}
+}
+
+func serve(t *testing.T, handler http.HandlerFunc) (int, Response) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+
+	var response Response
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+
+	return rec.Code, response
+}
+
+func TestChecker_Readiness(t *testing.T) {
+	failure := errors.New("down")
+
+	tests := []struct {
+		name   string
+		checks []Check
+		code   int
+		status string
+	}{
+		{
+			name:   "all ok",
+			checks: []Check{staticCheck("a", true, nil), staticCheck("b", false, nil)},
+			code:   http.StatusOK,
+			status: StatusOK,
+		},
+		{
+			name:   "non-critical failure degrades",
+			checks: []Check{staticCheck("a", true, nil), staticCheck("b", false, failure)},
+			code:   http.StatusOK,
+			status: StatusDegraded,
+		},
+		{
+			name:   "critical failure is unavailable",
+			checks: []Check{staticCheck("a", true, failure), staticCheck("b", false, failure)},
+			code:   http.StatusServiceUnavailable,
+			status: StatusUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, response := serve(t, newTestChecker(tt.checks...).Readiness)
+
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.status, response.Status)
+			assert.Len(t, response.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestChecker_ReadinessDetail(t *testing.T) {
+	code, response := serve(t, newTestChecker(staticCheck("redis", true, errors.New("connection refused"))).Readiness)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	result := response.Checks["redis"]
+	assert.Equal(t, StatusUnavailable, result.Status)
+	assert.True(t, result.Critical)
+	assert.Equal(t, "connection refused", result.Error)
+}
+
+func TestChecker_CheckTimeout(t *testing.T) {
+	hanging := Check{
+		Name:     "hanging",
+		Critical: true,
+		Run: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+
+			return "", ctx.Err()
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	response := newTestChecker(hanging).Run(ctx)
+	assert.Equal(t, StatusUnavailable, response.Status)
+}
+
+func TestChecker_LivenessIgnoresDependencies(t *testing.T) {
+	code, response := serve(t, newTestChecker(staticCheck("redis", true, errors.New("down"))).Liveness)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, response.Status)
+	assert.Empty(t, response.Checks)
+}
+
+func TestDependencyChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	redisClient := redismocks.NewMockClient(ctrl)
+	redisClient.EXPECT().Ping(gomock.Any()).Return(errors.New("refused"))
+
+	_, err := RedisCheck(redisClient).Run(ctx)
+	require.Error(t, err)
+
+	carto := cartomocks.NewMockProvider(ctrl)
+	carto.EXPECT().GetNetworks(gomock.Any()).Return(map[string]*cartographoor.Network{})
+
+	_, err = CartographoorCheck(carto).Run(ctx)
+	require.Error(t, err)
+
+	boundsProvider := boundsmocks.NewMockProvider(ctrl)
+	boundsProvider.EXPECT().GetAllBounds(gomock.Any()).Return(map[string]*bounds.BoundsData{
+		"mainnet": {LastUpdated: time.Now()},
+		"sepolia": {LastUpdated: time.Now(), Stale: true},
+		"holesky": {LastUpdated: time.Now().Add(-time.Hour)},
+	})
+
+	_, err = BoundsCheck(boundsProvider, time.Minute).Run(ctx)
+	require.EqualError(t, err, "stale bounds for holesky, sepolia")
+
+	elector := leadermocks.NewMockElector(ctrl)
+	elector.EXPECT().Status(gomock.Any()).Return(leader.Status{LeaderID: "other", Term: 3}, nil)
+
+	detail, err := LeaderCheck(elector).Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "follower of other (term 3)", detail)
+
+	elector.EXPECT().Status(gomock.Any()).Return(leader.Status{}, nil)
+
+	_, err = LeaderCheck(elector).Run(ctx)
+	require.Error(t, err)
+}
@@ -0,0 +1,38 @@
+//nolint:tagliatelle // superior snake-case yo.
+package health
+
+import "context"
+
+// Statuses reported for the service and for each check.
+const (
+	StatusOK          = "ok"          // Everything works
+	StatusDegraded    = "degraded"    // A non-critical check failed; still serving
+	StatusUnavailable = "unavailable" // A critical check failed; not ready
+)
+
+// CheckFunc checks one dependency. It returns a short human-readable detail
+// on success and an error describing the failure otherwise.
+type CheckFunc func(ctx context.Context) (string, error)
+
+// Check is a named dependency check.
+type Check struct {
+	Name     string
+	Critical bool // Readiness fails when a critical check fails
+	Run      CheckFunc
+}
+
+// Response is the JSON body of /healthz and /readyz.
+type Response struct {
+	Status  string                 `json:"status"`
+	Version string                 `json:"version"`
+	Checks  map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
@@ -0,0 +1,165 @@
+// Package httperr writes error responses as RFC 7807 problem details
+// (application/problem+json) with a stable, machine-readable code, so clients
+// handle errors from the proxy, API and middleware the same way.
+//
+// Every problem carries the request ID and, for clients of the earlier
+// {"error": "..."} responses, the detail again in an error member.
+package httperr
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// Codes identify the kind of error independent of its status and message.
+// They are part of the API and must not change.
+const (
+	CodeBadRequest          = "bad_request"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeAccessDenied        = "access_denied"
+	CodeTermsNotAccepted    = "terms_not_accepted"
+	CodeNotFound            = "not_found"
+	CodeNetworkNotFound     = "network_not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeNetworkRetired      = "network_retired"
+	CodeUpgradeRequired     = "upgrade_required"
+	CodeRateLimited         = "rate_limited"
+	CodeInternal            = "internal_error"
+	CodeUpstreamUnavailable = "upstream_unavailable"
+	CodeServiceUnavailable  = "service_unavailable"
+	CodeNetworkDisabled     = "network_disabled"
+	CodeNetworkQuarantined  = "network_quarantined"
+	CodeUpstreamOverloaded  = "upstream_overloaded"
+	CodeTooManyConnections  = "too_many_connections"
+	CodeReadOnly            = "read_only"
+	CodeWarmingUp           = "warming_up"
+	CodeUpstreamTimeout     = "upstream_timeout"
+	CodeRequestTimeout      = "request_timeout"
+	CodeMaintenance         = "maintenance"
+)
+
+// Problem is an RFC 7807 problem details object. Extensions are added as
+// further top-level members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Code       string
+	Extensions map[string]any
+}
+
+// New returns a problem with status, code and detail, titled with the
+// status text.
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// With sets the extension member key to value and returns p.
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+
+	p.Extensions[key] = value
+
+	return p
+}
+
+// MarshalJSON implements json.Marshaler, flattening the extensions. The
+// standard members can't be overridden by them.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+7)
+	maps.Copy(members, p.Extensions)
+
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	members["code"] = p.Code
+
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+		members["error"] = p.Detail
+	}
+
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+// Write sends p for r, with the request path as its instance and the
+// request ID attached. Headers such as Retry-After must be set before.
+func (p *Problem) Write(w http.ResponseWriter, r *http.Request) {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+
+	if id := requestid.FromContext(r.Context()); id != "" {
+		p.With("request_id", id)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Write sends a problem with status, code and detail for r.
+func Write(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	New(status, code, detail).Write(w, r)
+}
+
+// WriteErr sends a problem for a data provider error, with the status of the
+// sentinel it wraps and that status's code.
+func WriteErr(w http.ResponseWriter, r *http.Request, err error, detail string) {
+	status := errs.HTTPStatus(err)
+
+	Write(w, r, status, CodeForStatus(status), detail)
+}
+
+// CodeForStatus returns the generic code of status, for errors without a
+// more specific one.
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusUpgradeRequired:
+		return CodeUpgradeRequired
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusBadGateway:
+		return CodeUpstreamUnavailable
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeUpstreamTimeout
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,73 @@
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+func TestProblem_Write(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sepolia/bounds", http.NoBody)
+	req = req.WithContext(requestid.ContextWithID(req.Context(), "req-123"))
+
+	rec := httptest.NewRecorder()
+
+	New(http.StatusNotFound, CodeNetworkNotFound, "network not found").
+		With("network", "sepolia").
+		With("status", "overridden").
+		Write(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, map[string]any{
+		"type":       "about:blank",
+		"title":      "Not Found",
+		"status":     float64(http.StatusNotFound), // Extensions don't replace standard members
+		"detail":     "network not found",
+		"error":      "network not found",
+		"instance":   "/api/v1/sepolia/bounds",
+		"code":       "network_not_found",
+		"network":    "sepolia",
+		"request_id": "req-123",
+	}, body)
+}
+
+func TestWriteErr(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{err: fmt.Errorf("bounds for mainnet: %w", errs.ErrNotFound), wantStatus: http.StatusNotFound, wantCode: CodeNotFound},
+		{err: fmt.Errorf("read bounds: %w", errs.ErrUpstreamUnavailable), wantStatus: http.StatusBadGateway, wantCode: CodeUpstreamUnavailable},
+		{err: errs.ErrNotLeader, wantStatus: http.StatusServiceUnavailable, wantCode: CodeServiceUnavailable},
+		{err: assert.AnError, wantStatus: http.StatusInternalServerError, wantCode: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteErr(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody), tt.err, "failed")
+
+			var body struct {
+				Code string `json:"code"`
+			}
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+			assert.Equal(t, tt.wantCode, body.Code)
+		})
+	}
+}
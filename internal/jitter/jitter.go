@@ -0,0 +1,134 @@
+// Package jitter provides tickers whose ticks are spread out, so replicas and
+// components sharing an interval don't send their upstream requests in bursts.
+//
+// Ticks stay anchored to a grid of the nominal interval: each one is moved
+// independently by up to ±jitter of the interval (jittered sampling, which
+// spreads ticks like blue noise rather than letting them drift or cluster),
+// and the whole grid is shifted by a random phase of up to splay of the
+// interval, so tickers started at the same moment don't line up.
+package jitter
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// settings holds the process-wide jitter and splay fractions.
+type settings struct {
+	jitter float64
+	splay  float64
+}
+
+// current is disabled until Configure is called, so tests and tools that
+// never configure timers get plain tickers.
+var current atomic.Pointer[settings]
+
+func init() {
+	current.Store(&settings{})
+}
+
+// Configure sets the process-wide jitter and splay, as fractions of each
+// ticker's interval. It applies to tickers created afterwards.
+func Configure(jitter, splay float64) {
+	current.Store(&settings{jitter: jitter, splay: splay})
+}
+
+// Ticker delivers jittered ticks on C. Like time.Ticker, ticks are dropped
+// if the receiver falls behind.
+type Ticker struct {
+	C <-chan time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTicker returns a ticker with the configured jitter and splay around interval.
+func NewTicker(interval time.Duration) *Ticker {
+	return newTicker(interval, *current.Load())
+}
+
+// NewTickerWithJitter returns a ticker with its own jitter, as a fraction of
+// interval, and the configured splay.
+func NewTickerWithJitter(interval time.Duration, jitter float64) *Ticker {
+	s := *current.Load()
+	s.jitter = jitter
+
+	return newTicker(interval, s)
+}
+
+func newTicker(interval time.Duration, s settings) *Ticker {
+	if interval <= 0 {
+		panic("jitter: non-positive interval for NewTicker")
+	}
+
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, stop: make(chan struct{})}
+
+	go t.run(c, interval, s)
+
+	return t
+}
+
+// Stop turns off the ticker. A tick that was already due may still be
+// received from C, but no further ticks are sent.
+func (t *Ticker) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+func (t *Ticker) run(c chan<- time.Time, interval time.Duration, s settings) {
+	// Shift the grid back by a random phase, so the first tick comes no later
+	// than it would with a plain ticker.
+	anchor := time.Now().Add(-Offset(interval, s.splay))
+
+	timer := time.NewTimer(time.Until(nextTick(anchor, interval, 1, s.jitter)))
+	defer timer.Stop()
+
+	for k := int64(2); ; k++ {
+		select {
+		case <-t.stop:
+			return
+		case now := <-timer.C:
+			select {
+			case c <- now:
+			default:
+			}
+
+			timer.Reset(time.Until(nextTick(anchor, interval, k, s.jitter)))
+		}
+	}
+}
+
+// nextTick returns the k-th grid point after anchor, moved by up to ±jitter.
+func nextTick(anchor time.Time, interval time.Duration, k int64, jitter float64) time.Time {
+	noise := time.Duration((rand.Float64()*2 - 1) * jitter * float64(interval)) //nolint:gosec // timing only.
+
+	return anchor.Add(time.Duration(k)*interval + noise)
+}
+
+// Offset returns a random duration in [0, fraction*interval), for spreading
+// one-off delays such as a retry or a first run.
+func Offset(interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || interval <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Float64() * fraction * float64(interval)) //nolint:gosec // timing only.
+}
+
+// Duration returns interval moved by up to ±jitter with the configured jitter,
+// for loops that sleep between runs instead of using a ticker.
+func Duration(interval time.Duration) time.Duration {
+	s := current.Load()
+	if s.jitter <= 0 {
+		return interval
+	}
+
+	return interval + time.Duration((rand.Float64()*2-1)*s.jitter*float64(interval)) //nolint:gosec // timing only.
+}
+
+// Fraction returns the configured jitter, as a fraction of each interval.
+func Fraction() float64 {
+	return current.Load().jitter
+}
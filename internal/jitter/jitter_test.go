@@ -0,0 +1,98 @@
+package jitter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextTick_StaysOnGrid(t *testing.T) {
+	anchor := time.Unix(1700000000, 0)
+	interval := 10 * time.Second
+
+	for k := int64(1); k <= 1000; k++ {
+		tick := nextTick(anchor, interval, k, 0.2)
+		grid := anchor.Add(time.Duration(k) * interval)
+
+		// Each tick moves independently around its grid point, so there is no drift
+		assert.LessOrEqual(t, absDuration(tick.Sub(grid)), 2*time.Second)
+	}
+
+	assert.Equal(t, anchor.Add(3*interval), nextTick(anchor, interval, 3, 0))
+}
+
+func TestOffset(t *testing.T) {
+	assert.Zero(t, Offset(time.Minute, 0))
+
+	for range 1000 {
+		offset := Offset(time.Minute, 0.5)
+		assert.GreaterOrEqual(t, offset, time.Duration(0))
+		assert.Less(t, offset, 30*time.Second)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	t.Cleanup(func() { Configure(0, 0) })
+
+	assert.Equal(t, time.Minute, Duration(time.Minute))
+
+	Configure(0.1, 0)
+
+	for range 1000 {
+		d := Duration(time.Minute)
+		assert.GreaterOrEqual(t, d, 54*time.Second)
+		assert.LessOrEqual(t, d, 66*time.Second)
+	}
+}
+
+func TestTicker(t *testing.T) {
+	t.Cleanup(func() { Configure(0, 0) })
+
+	Configure(0.2, 1)
+
+	start := time.Now()
+	ticker := NewTicker(20 * time.Millisecond)
+
+	// With full splay the first tick comes no later than a plain ticker's would
+	var last time.Time
+
+	for i := range 5 {
+		select {
+		case tick := <-ticker.C:
+			if i == 0 {
+				assert.Less(t, tick.Sub(start), 20*time.Millisecond+4*time.Millisecond+10*time.Millisecond)
+			}
+
+			assert.True(t, tick.After(last))
+			last = tick
+		case <-time.After(time.Second):
+			require.Fail(t, "ticker did not tick")
+		}
+	}
+
+	ticker.Stop()
+
+	// Drain a tick that may have been due when Stop was called
+	time.Sleep(5 * time.Millisecond)
+
+	select {
+	case <-ticker.C:
+	default:
+	}
+
+	select {
+	case <-ticker.C:
+		assert.Fail(t, "ticked after Stop")
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
@@ -4,10 +4,15 @@ package leader
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/lab-backend/internal/jitter"
 	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -20,6 +25,15 @@ type Elector interface {
 	Start(ctx context.Context) error
 	Stop() error
 	IsLeader() bool
+	Status(ctx context.Context) (Status, error)
+}
+
+// Status describes the current leadership as seen by one instance.
+type Status struct {
+	InstanceID string // ID of this instance
+	IsLeader   bool   // Whether this instance is the leader
+	LeaderID   string // ID of the lock holder, empty while nobody holds it
+	Term       int64  // Incremented every time an instance acquires leadership
 }
 
 type elector struct {
@@ -28,10 +42,12 @@ type elector struct {
 	redis          redis.Client
 	id             string // Unique instance ID
 	isLeader       bool
-	loggedFollower bool // Track if we've logged follower status
+	term           int64 // Term of our leadership, 0 if unknown
+	loggedFollower bool  // Track if we've logged follower status
 	mu             sync.RWMutex
 	done           chan struct{}
 	wg             sync.WaitGroup
+	task           *tasks.Task
 }
 
 // NewElector creates a new leader elector.
@@ -49,6 +65,7 @@ func NewElector(log logrus.FieldLogger, cfg Config, redisClient redis.Client) El
 func (e *elector) Start(ctx context.Context) error {
 	e.log.WithField("instance_id", e.id).Info("Starting leader election")
 
+	e.task = tasks.Default().Register("leader.election", e.cfg.RetryInterval)
 	e.wg.Add(1)
 
 	go e.electionLoop(ctx)
@@ -62,14 +79,14 @@ func (e *elector) Stop() error {
 	close(e.done)
 	e.wg.Wait()
 
-	// Release leadership if we hold it
+	// Hand leadership off if we hold it
 	e.mu.Lock()
 
 	if e.isLeader {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		_ = e.redis.Del(ctx, e.cfg.LockKey)
+		e.handOff(ctx)
 		e.isLeader = false
 	}
 
@@ -78,6 +95,72 @@ func (e *elector) Stop() error {
 	return nil
 }
 
+// Status reads the current lock holder and term from Redis.
+func (e *elector) Status(ctx context.Context) (Status, error) {
+	status := Status{
+		InstanceID: e.id,
+		IsLeader:   e.IsLeader(),
+	}
+
+	leaderID, err := e.redis.Get(ctx, e.cfg.LockKey)
+	if err != nil && !errors.Is(err, redis.ErrNotFound) {
+		return status, fmt.Errorf("get leader: %w", err)
+	}
+
+	status.LeaderID = leaderID
+
+	term, err := e.redis.Get(ctx, e.termKey())
+
+	switch {
+	case errors.Is(err, redis.ErrNotFound):
+	case err != nil:
+		return status, fmt.Errorf("get term: %w", err)
+	default:
+		if status.Term, err = strconv.ParseInt(term, 10, 64); err != nil {
+			return status, fmt.Errorf("parse term %q: %w", term, err)
+		}
+	}
+
+	return status, nil
+}
+
+// handOff releases the lock, unless another instance has taken it since, and
+// tells followers to take over now rather than after the lock TTL.
+// Callers must hold e.mu.
+func (e *elector) handOff(ctx context.Context) {
+	released, err := e.redis.DelIfEqual(ctx, e.cfg.LockKey, e.id)
+	if err != nil {
+		e.log.WithError(err).Warn("Failed to release leadership lock")
+
+		return
+	}
+
+	if !released {
+		return
+	}
+
+	if err := e.redis.Publish(ctx, e.handoffChannel(), e.id); err != nil {
+		e.log.WithError(err).Warn("Failed to publish leadership handoff, followers will wait for the lock to expire")
+
+		return
+	}
+
+	e.log.WithFields(logrus.Fields{
+		"instance_id": e.id,
+		"term":        e.term,
+	}).Info("Handed off leadership")
+}
+
+// termKey holds the current leadership term.
+func (e *elector) termKey() string {
+	return e.cfg.LockKey + ":term"
+}
+
+// handoffChannel carries the ID of a leader that just released the lock.
+func (e *elector) handoffChannel() string {
+	return e.cfg.LockKey + ":handoff"
+}
+
 // IsLeader returns true if this instance is the current leader.
 func (e *elector) IsLeader() bool {
 	e.mu.RLock()
@@ -89,13 +172,26 @@ func (e *elector) IsLeader() bool {
 func (e *elector) electionLoop(ctx context.Context) {
 	defer e.wg.Done()
 
+	e.task.Supervise(e.log, e.done, func() { e.runElectionLoop(ctx) })
+}
+
+func (e *elector) runElectionLoop(ctx context.Context) {
+	// Followers take over as soon as a stopping leader hands off
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handoffs, err := e.redis.Subscribe(subCtx, e.handoffChannel())
+	if err != nil {
+		e.log.WithError(err).Warn("Failed to subscribe to leadership handoffs, relying on lock expiry")
+	}
+
 	// Try to acquire leadership immediately on startup (don't wait for first ticker)
 	e.tryAcquireLeadership(ctx)
 
-	renewTicker := time.NewTicker(e.cfg.RenewInterval)
+	renewTicker := jitter.NewTicker(e.cfg.RenewInterval)
 	defer renewTicker.Stop()
 
-	retryTicker := time.NewTicker(e.cfg.RetryInterval)
+	retryTicker := jitter.NewTicker(e.cfg.RetryInterval)
 	defer retryTicker.Stop()
 
 	for {
@@ -105,13 +201,28 @@ func (e *elector) electionLoop(ctx context.Context) {
 		case <-e.done:
 			return
 		case <-renewTicker.C:
+			e.task.Tick()
+
 			if e.IsLeader() {
 				e.renewLeadership(ctx)
 			}
 		case <-retryTicker.C:
+			e.task.Tick()
+
 			if !e.IsLeader() {
 				e.tryAcquireLeadership(ctx)
 			}
+		case previous, ok := <-handoffs:
+			if !ok {
+				handoffs = nil
+
+				continue
+			}
+
+			if previous != e.id && !e.IsLeader() {
+				e.log.WithField("previous_leader_id", previous).Info("Leader handed off, trying to acquire leadership")
+				e.tryAcquireLeadership(ctx)
+			}
 		}
 	}
 }
@@ -125,11 +236,21 @@ func (e *elector) tryAcquireLeadership(ctx context.Context) {
 	}
 
 	if acquired {
+		// Count the new term; leadership doesn't depend on it
+		term, err := e.redis.Incr(ctx, e.termKey())
+		if err != nil {
+			e.log.WithError(err).Warn("Failed to increment leadership term")
+		}
+
 		e.mu.Lock()
 		e.isLeader = true
+		e.term = term
 		e.loggedFollower = false // Reset flag if we gain leadership
 		e.mu.Unlock()
-		e.log.WithField("instance_id", e.id).Info("Acquired leadership")
+		e.log.WithFields(logrus.Fields{
+			"instance_id": e.id,
+			"term":        term,
+		}).Info("Acquired leadership")
 	} else {
 		// Only log follower status once (on first attempt)
 		e.mu.Lock()
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	redismocks "github.com/ethpandaops/lab-backend/internal/redis/mocks"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
 )
 
 func TestElector_AcquireLeadership(t *testing.T) {
@@ -48,6 +49,14 @@ func TestElector_AcquireLeadership(t *testing.T) {
 				Return(tt.setNXResult, tt.setNXError).
 				Times(1)
 
+			// If acquisition succeeds, expect the term to be incremented
+			if tt.setNXResult {
+				mockRedis.EXPECT().
+					Incr(gomock.Any(), "test-lock:term").
+					Return(int64(1), nil).
+					Times(1)
+			}
+
 			// If acquisition fails, expect Get call for current leader ID logging
 			if !tt.setNXResult && tt.setNXError == nil {
 				mockRedis.EXPECT().
@@ -280,6 +289,12 @@ func TestElector_StartStop(t *testing.T) {
 		Return("other-id", nil).
 		AnyTimes()
 
+	// Mock handoff subscription (no handoffs arrive)
+	mockRedis.EXPECT().
+		Subscribe(gomock.Any(), "test-lock:handoff").
+		Return(make(<-chan string), nil).
+		AnyTimes()
+
 	elector := NewElector(logger, cfg, mockRedis)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -313,14 +328,18 @@ func TestElector_StopWhileLeader(t *testing.T) {
 		RetryInterval: 100 * time.Millisecond,
 	}
 
-	// Expect Del call when stopping as leader
+	e := NewElector(logger, cfg, mockRedis).(*elector) //nolint:errcheck // type assertion in test
+
+	// Expect the lock to be released and the handoff published when stopping as leader
+	mockRedis.EXPECT().
+		DelIfEqual(gomock.Any(), "test-lock", e.id).
+		Return(true, nil).
+		Times(1)
 	mockRedis.EXPECT().
-		Del(gomock.Any(), "test-lock").
+		Publish(gomock.Any(), "test-lock:handoff", e.id).
 		Return(nil).
 		Times(1)
 
-	e := NewElector(logger, cfg, mockRedis).(*elector) //nolint:errcheck // type assertion in test
-
 	// Manually set as leader (without starting election loop)
 	e.mu.Lock()
 	e.isLeader = true
@@ -440,6 +459,11 @@ func TestElector_LeadershipTransitions(t *testing.T) {
 
 					hadFailure = true
 				} else if setNXResult {
+					mockRedis.EXPECT().
+						Incr(gomock.Any(), "test-lock:term").
+						Return(int64(i+1), nil).
+						Times(1)
+
 					// Reset failure flag on success
 					hadFailure = false
 				}
@@ -454,3 +478,64 @@ func TestElector_LeadershipTransitions(t *testing.T) {
 		})
 	}
 }
+
+func TestElector_Handoff(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	// Retries and lock expiry are far too slow to explain a quick takeover
+	cfg := Config{
+		LockKey:       "test-lock",
+		LockTTL:       time.Minute,
+		RenewInterval: time.Minute,
+		RetryInterval: time.Minute,
+	}
+
+	first := NewElector(logger, cfg, client)
+	require.NoError(t, first.Start(t.Context()))
+	require.Eventually(t, first.IsLeader, 2*time.Second, 10*time.Millisecond)
+
+	second := NewElector(logger, cfg, client)
+	require.NoError(t, second.Start(t.Context()))
+	t.Cleanup(func() { _ = second.Stop() })
+
+	// Wait for the follower to subscribe to handoffs
+	require.Eventually(t, func() bool {
+		return mr.PubSubNumSub("test-lock:handoff")["test-lock:handoff"] == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	status, err := second.Status(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsLeader)
+	assert.Equal(t, first.(*elector).id, status.LeaderID) //nolint:errcheck // type assertion in test
+	assert.Equal(t, int64(1), status.Term)
+
+	require.NoError(t, first.Stop())
+	require.Eventually(t, second.IsLeader, 2*time.Second, 10*time.Millisecond)
+
+	status, err = second.Status(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsLeader)
+	assert.Equal(t, status.InstanceID, status.LeaderID)
+	assert.Equal(t, int64(2), status.Term)
+}
+
+func TestElector_StopDoesNotReleaseOtherLock(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	e := NewElector(logger, Config{LockKey: "test-lock"}, client).(*elector) //nolint:errcheck // type assertion in test
+	e.isLeader = true
+
+	// Our lock expired and another instance took over
+	require.NoError(t, mr.Set("test-lock", "other-instance-id"))
+	require.NoError(t, e.Stop())
+
+	holder, err := mr.Get("test-lock")
+	require.NoError(t, err)
+	assert.Equal(t, "other-instance-id", holder)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	leader "github.com/ethpandaops/lab-backend/internal/leader"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -68,6 +69,21 @@ func (mr *MockElectorMockRecorder) Start(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockElector)(nil).Start), ctx)
 }
 
+// Status mocks base method.
+func (m *MockElector) Status(ctx context.Context) (leader.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].(leader.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockElectorMockRecorder) Status(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockElector)(nil).Status), ctx)
+}
+
 // Stop mocks base method.
 func (m *MockElector) Stop() error {
 	m.ctrl.T.Helper()
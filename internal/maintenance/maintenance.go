@@ -0,0 +1,196 @@
+// Package maintenance tracks scheduled maintenance windows, during which the
+// API and frontend are replaced by a 503 and a maintenance page. A window is
+// scheduled through the admin API and stored in a Redis key shared by every
+// instance, so all replicas enter and leave maintenance together.
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/rediswatch"
+)
+
+// pollTimeout bounds each Redis check.
+const pollTimeout = 2 * time.Second
+
+// ErrInvalidWindow is returned for windows ending before they start or
+// already over.
+var ErrInvalidWindow = errors.New("invalid maintenance window")
+
+var maintenanceGauge = metrics.NewGauge(prometheus.GaugeOpts{
+	Name: "maintenance_mode",
+	Help: "Whether a maintenance window is active (1) or not (0)",
+})
+
+// Mode reports whether the backend is in maintenance and how to answer.
+type Mode struct {
+	cfg   config.MaintenanceConfig
+	log   logrus.FieldLogger
+	redis redis.Client
+	page  []byte // Custom maintenance page, nil for the built-in one
+
+	// window holds the scheduled window, nil while the key is absent or
+	// without Redis
+	window *rediswatch.Watcher[Window]
+	now    func() time.Time
+}
+
+// New creates a maintenance mode tracker. redisClient may be nil, in which
+// case maintenance can never be entered.
+func New(log logrus.FieldLogger, cfg config.MaintenanceConfig, redisClient redis.Client) (*Mode, error) {
+	m := &Mode{
+		cfg:   cfg,
+		log:   log.WithField("component", "maintenance"),
+		redis: redisClient,
+		now:   time.Now,
+	}
+
+	if redisClient != nil {
+		m.window = rediswatch.New(m.log, rediswatch.Config[Window]{
+			Name:     "maintenance.poll",
+			Interval: cfg.PollInterval,
+			Timeout:  pollTimeout,
+			Load:     rediswatch.Key(redisClient, cfg.RedisKey, decodeWindow),
+			OnChange: m.changed,
+		})
+	}
+
+	if cfg.PageFile != "" {
+		page, err := os.ReadFile(cfg.PageFile)
+		if err != nil {
+			return nil, fmt.Errorf("read maintenance page: %w", err)
+		}
+
+		m.page = page
+	}
+
+	m.updateGauge()
+
+	return m, nil
+}
+
+// Window returns the scheduled window, active or upcoming, or nil.
+func (m *Mode) Window() *Window {
+	return m.window.Value()
+}
+
+// Active returns the window in effect now, or nil outside maintenance.
+func (m *Mode) Active() *Window {
+	if w := m.window.Value(); w.ActiveAt(m.now()) {
+		return w
+	}
+
+	return nil
+}
+
+// Message returns the message of w, or the configured one if it sets none.
+func (m *Mode) Message(w *Window) string {
+	if w != nil && w.Message != "" {
+		return w.Message
+	}
+
+	return m.cfg.Message
+}
+
+// RetryAfter returns the Retry-After, in seconds, of responses during w: the
+// time left until it ends, or the configured retry_after if it has no end.
+func (m *Mode) RetryAfter(w *Window) int64 {
+	if w == nil || w.EndsAt.IsZero() {
+		return int64(m.cfg.RetryAfter.Seconds())
+	}
+
+	return max(1, int64(math.Ceil(w.EndsAt.Sub(m.now()).Seconds())))
+}
+
+// Start checks the window once and then polls it in the background.
+func (m *Mode) Start() {
+	m.window.Start()
+}
+
+// Stop stops polling and waits for it to finish.
+func (m *Mode) Stop() {
+	m.window.Stop()
+}
+
+// Schedule stores w as the maintenance window of every instance, replacing
+// any scheduled before. The key expires when the window ends.
+func (m *Mode) Schedule(ctx context.Context, w Window) error {
+	var ttl time.Duration
+
+	if !w.EndsAt.IsZero() {
+		ttl = w.EndsAt.Sub(m.now())
+
+		if ttl <= 0 || !w.EndsAt.After(w.StartsAt) {
+			return ErrInvalidWindow
+		}
+	}
+
+	data, err := json.Marshal(w)
+	if err != nil {
+		return fmt.Errorf("marshal maintenance window: %w", err)
+	}
+
+	if err := m.redis.Set(ctx, m.cfg.RedisKey, string(data), ttl); err != nil {
+		return fmt.Errorf("set maintenance key: %w", err)
+	}
+
+	return m.window.Refresh(ctx)
+}
+
+// Clear ends or cancels the maintenance window for every instance.
+func (m *Mode) Clear(ctx context.Context) error {
+	if err := m.redis.Del(ctx, m.cfg.RedisKey); err != nil {
+		return fmt.Errorf("delete maintenance key: %w", err)
+	}
+
+	return m.window.Refresh(ctx)
+}
+
+// decodeWindow parses a window stored in Redis.
+func decodeWindow(data string) (*Window, error) {
+	var w Window
+	if err := json.Unmarshal([]byte(data), &w); err != nil {
+		return nil, fmt.Errorf("parse maintenance window: %w", err)
+	}
+
+	return &w, nil
+}
+
+// changed logs window changes. On Redis errors the last known window is kept,
+// so a Redis outage neither starts nor lifts maintenance.
+func (m *Mode) changed(prev, next *Window) {
+	switch {
+	case next == nil && prev != nil:
+		m.log.Warn("Maintenance window cleared")
+	case next != nil && (prev == nil || encode(prev) != encode(next)):
+		m.log.WithFields(logrus.Fields{
+			"message":   m.Message(next),
+			"starts_at": next.StartsAt,
+			"ends_at":   next.EndsAt,
+		}).Warn("Maintenance window scheduled")
+	}
+
+	m.updateGauge()
+}
+
+func (m *Mode) updateGauge() {
+	if m.Active() != nil {
+		maintenanceGauge.Set(1)
+
+		return
+	}
+
+	maintenanceGauge.Set(0)
+}
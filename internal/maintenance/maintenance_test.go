@@ -0,0 +1,122 @@
+package maintenance
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func newTestMode(t *testing.T, cfg config.MaintenanceConfig) (*Mode, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	require.NoError(t, cfg.Validate())
+
+	mode, err := New(logger, cfg, client)
+	require.NoError(t, err)
+
+	mode.Start()
+	t.Cleanup(mode.Stop)
+
+	return mode, mr
+}
+
+func TestMode_Schedule(t *testing.T) {
+	mode, mr := newTestMode(t, config.MaintenanceConfig{RetryAfter: 10 * time.Minute})
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mode.now = func() time.Time { return now }
+
+	assert.Nil(t, mode.Active())
+
+	// An open-ended window is active right away and uses the configured message
+	require.NoError(t, mode.Schedule(t.Context(), Window{}))
+
+	window := mode.Active()
+	require.NotNil(t, window)
+	assert.Equal(t, mode.cfg.Message, mode.Message(window))
+	assert.Equal(t, int64(600), mode.RetryAfter(window))
+
+	// An upcoming window is scheduled but not active, and its key expires with it
+	require.NoError(t, mode.Schedule(t.Context(), Window{
+		Message:  "database upgrade",
+		StartsAt: now.Add(time.Hour),
+		EndsAt:   now.Add(2 * time.Hour),
+	}))
+
+	assert.Nil(t, mode.Active())
+	require.NotNil(t, mode.Window())
+	assert.Equal(t, 2*time.Hour, mr.TTL(mode.cfg.RedisKey))
+
+	now = now.Add(90 * time.Minute)
+
+	window = mode.Active()
+	require.NotNil(t, window)
+	assert.Equal(t, "database upgrade", mode.Message(window))
+	assert.Equal(t, int64(1800), mode.RetryAfter(window))
+
+	// Redis errors keep the last known window
+	mr.SetError("unavailable")
+	require.Error(t, mode.window.Refresh(t.Context()))
+	assert.NotNil(t, mode.Active())
+
+	mr.SetError("")
+
+	require.NoError(t, mode.Clear(t.Context()))
+	assert.Nil(t, mode.Active())
+	assert.Nil(t, mode.Window())
+}
+
+func TestMode_ScheduleInvalid(t *testing.T) {
+	mode, _ := newTestMode(t, config.MaintenanceConfig{})
+
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		window Window
+	}{
+		{name: "already over", window: Window{EndsAt: now.Add(-time.Minute)}},
+		{name: "ends before it starts", window: Window{StartsAt: now.Add(2 * time.Hour), EndsAt: now.Add(time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, mode.Schedule(t.Context(), tt.window), ErrInvalidWindow)
+			assert.Nil(t, mode.Window())
+		})
+	}
+}
+
+func TestMode_Page(t *testing.T) {
+	mode, _ := newTestMode(t, config.MaintenanceConfig{})
+
+	page := string(mode.Page(&Window{
+		Message: "Upgrading <clickhouse>",
+		EndsAt:  time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC),
+	}))
+
+	assert.Contains(t, page, "Upgrading &lt;clickhouse&gt;")
+	assert.Contains(t, page, `datetime="2026-03-01T14:00:00Z"`)
+
+	// A configured page is served as is
+	path := filepath.Join(t.TempDir(), "maintenance.html")
+	require.NoError(t, os.WriteFile(path, []byte("<h1>brb</h1>"), 0o600))
+
+	custom, _ := newTestMode(t, config.MaintenanceConfig{PageFile: path})
+	assert.Equal(t, "<h1>brb</h1>", string(custom.Page(nil)))
+}
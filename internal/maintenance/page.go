@@ -0,0 +1,59 @@
+package maintenance
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+)
+
+// pageTemplate is the built-in maintenance page, used without a page_file.
+var pageTemplate = template.Must(template.New("maintenance").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Down for maintenance</title>
+<style>
+body{margin:0;min-height:100vh;display:flex;align-items:center;justify-content:center;font-family:system-ui,sans-serif;background:#0b0f19;color:#e5e7eb}
+main{max-width:32rem;padding:2rem;text-align:center}
+h1{font-size:1.5rem;margin:0 0 1rem}
+p{color:#9ca3af;line-height:1.5}
+</style>
+</head>
+<body>
+<main>
+<h1>Down for maintenance</h1>
+<p>{{.Message}}</p>
+{{- if not .EndsAt.IsZero}}
+<p>Expected back by <time datetime="{{.EndsAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.EndsAt.Format "2 Jan 2006 15:04 MST"}}</time>.</p>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// Page returns the maintenance page served to frontend routes during w: the
+// configured page_file as is, or the built-in page with the window's message.
+func (m *Mode) Page(w *Window) []byte {
+	if m.page != nil {
+		return m.page
+	}
+
+	var endsAt time.Time
+	if w != nil {
+		endsAt = w.EndsAt.UTC()
+	}
+
+	var buf bytes.Buffer
+
+	_ = pageTemplate.Execute(&buf, struct {
+		Message string
+		EndsAt  time.Time
+	}{
+		Message: m.Message(w),
+		EndsAt:  endsAt,
+	})
+
+	return buf.Bytes()
+}
@@ -0,0 +1,28 @@
+//nolint:tagliatelle // superior snake-case yo.
+package maintenance
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Window is a scheduled maintenance window. Zero times leave it open-ended:
+// without StartsAt it is active right away, without EndsAt until cleared.
+type Window struct {
+	Message  string         `json:"message,omitempty"`  // Replaces the configured message
+	Details  map[string]any `json:"details,omitempty"`  // Returned as is in API responses, e.g. a status page link
+	StartsAt time.Time      `json:"starts_at,omitzero"` // When maintenance begins
+	EndsAt   time.Time      `json:"ends_at,omitzero"`   // When maintenance ends, also the Retry-After of responses
+}
+
+// ActiveAt reports whether the window covers t.
+func (w *Window) ActiveAt(t time.Time) bool {
+	return w != nil && !t.Before(w.StartsAt) && (w.EndsAt.IsZero() || t.Before(w.EndsAt))
+}
+
+// encode returns w as stored in Redis, to tell whether a polled window changed.
+func encode(w *Window) string {
+	data, _ := json.Marshal(w)
+
+	return string(data)
+}
@@ -0,0 +1,120 @@
+// Package metrics registers Prometheus metrics with the default registry, like
+// promauto, and keeps a catalogue of their definitions so monitoring assets
+// can be generated from the metrics the binary actually registers.
+package metrics
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Kind is a metric type.
+type Kind string
+
+// Metric kinds.
+const (
+	KindCounter   Kind = "counter"
+	KindGauge     Kind = "gauge"
+	KindHistogram Kind = "histogram"
+	KindSummary   Kind = "summary"
+)
+
+// Definition describes a registered metric.
+type Definition struct {
+	Name   string
+	Help   string
+	Kind   Kind
+	Labels []string
+}
+
+var (
+	mu          sync.Mutex
+	definitions = make(map[string]Definition)
+)
+
+// Definitions returns every metric registered through this package, sorted by name.
+func Definitions() []Definition {
+	mu.Lock()
+	defer mu.Unlock()
+
+	defs := make([]Definition, 0, len(definitions))
+	for _, def := range definitions {
+		defs = append(defs, def)
+	}
+
+	slices.SortFunc(defs, func(a, b Definition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return defs
+}
+
+// Lookup returns the definition of a registered metric.
+func Lookup(name string) (Definition, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	def, exists := definitions[name]
+
+	return def, exists
+}
+
+func record(namespace, subsystem, name, help string, kind Kind, labels []string) {
+	fqName := prometheus.BuildFQName(namespace, subsystem, name)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	definitions[fqName] = Definition{
+		Name:   fqName,
+		Help:   help,
+		Kind:   kind,
+		Labels: slices.Clone(labels),
+	}
+}
+
+// NewCounter creates and registers a counter.
+func NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
+	record(opts.Namespace, opts.Subsystem, opts.Name, opts.Help, KindCounter, nil)
+
+	return promauto.NewCounter(opts)
+}
+
+// NewCounterVec creates and registers a counter vector.
+func NewCounterVec(opts prometheus.CounterOpts, labels []string) *prometheus.CounterVec {
+	record(opts.Namespace, opts.Subsystem, opts.Name, opts.Help, KindCounter, labels)
+
+	return promauto.NewCounterVec(opts, labels)
+}
+
+// NewGauge creates and registers a gauge.
+func NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	record(opts.Namespace, opts.Subsystem, opts.Name, opts.Help, KindGauge, nil)
+
+	return promauto.NewGauge(opts)
+}
+
+// NewGaugeVec creates and registers a gauge vector.
+func NewGaugeVec(opts prometheus.GaugeOpts, labels []string) *prometheus.GaugeVec {
+	record(opts.Namespace, opts.Subsystem, opts.Name, opts.Help, KindGauge, labels)
+
+	return promauto.NewGaugeVec(opts, labels)
+}
+
+// NewHistogramVec creates and registers a histogram vector.
+func NewHistogramVec(opts prometheus.HistogramOpts, labels []string) *prometheus.HistogramVec {
+	record(opts.Namespace, opts.Subsystem, opts.Name, opts.Help, KindHistogram, labels)
+
+	return promauto.NewHistogramVec(opts, labels)
+}
+
+// NewSummaryVec creates and registers a summary vector.
+func NewSummaryVec(opts prometheus.SummaryOpts, labels []string) *prometheus.SummaryVec {
+	record(opts.Namespace, opts.Subsystem, opts.Name, opts.Help, KindSummary, labels)
+
+	return promauto.NewSummaryVec(opts, labels)
+}
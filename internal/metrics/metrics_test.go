@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitions(t *testing.T) {
+	NewCounterVec(prometheus.CounterOpts{
+		Namespace: "test",
+		Name:      "zz_events_total",
+		Help:      "Events",
+	}, []string{"type"})
+	NewGauge(prometheus.GaugeOpts{Name: "test_aa_clients", Help: "Clients"})
+
+	def, exists := Lookup("test_zz_events_total")
+	require.True(t, exists)
+	assert.Equal(t, Definition{
+		Name:   "test_zz_events_total",
+		Help:   "Events",
+		Kind:   KindCounter,
+		Labels: []string{"type"},
+	}, def)
+
+	defs := Definitions()
+	require.GreaterOrEqual(t, len(defs), 2)
+
+	for i := 1; i < len(defs); i++ {
+		assert.Less(t, defs[i-1].Name, defs[i].Name)
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NetworkAliases returns middleware that resolves renamed networks in API paths
+// (/api/v1/{alias}/... and /api/v1/gas-profiler/{alias}/...) to their canonical
+// name. With rewrite set the request is served transparently, otherwise the
+// client is sent a 308 redirect so method and body are preserved.
+func NetworkAliases(aliases map[string]string, rewrite bool, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, alias, canonical, ok := resolveNetworkAlias(r.URL.Path, aliases)
+			if !ok {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			log.WithFields(logrus.Fields{
+				"alias":   alias,
+				"network": canonical,
+				"path":    r.URL.Path,
+			}).Debug("Resolved network alias")
+
+			if !rewrite {
+				target := path
+				if r.URL.RawQuery != "" {
+					target += "?" + r.URL.RawQuery
+				}
+
+				http.Redirect(w, r, target, http.StatusPermanentRedirect)
+
+				return
+			}
+
+			r2 := r.Clone(r.Context())
+			r2.URL.Path = path
+			r2.URL.RawPath = ""
+
+			next.ServeHTTP(w, r2)
+		})
+	}
+}
+
+// resolveNetworkAlias replaces an aliased network segment in path.
+// Returns the rewritten path, the alias, its canonical name and whether one matched.
+func resolveNetworkAlias(path string, aliases map[string]string) (string, string, string, bool) {
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	if !ok || len(aliases) == 0 {
+		return "", "", "", false
+	}
+
+	prefix := "/api/v1/"
+
+	// Gas profiler routes carry the network one segment further in
+	if after, found := strings.CutPrefix(rest, "gas-profiler/"); found {
+		prefix += "gas-profiler/"
+		rest = after
+	}
+
+	segment, remainder, hasRemainder := strings.Cut(rest, "/")
+
+	canonical, exists := aliases[segment]
+	if !exists {
+		return "", "", "", false
+	}
+
+	rewritten := prefix + canonical
+	if hasRemainder {
+		rewritten += "/" + remainder
+	}
+
+	return rewritten, segment, canonical, true
+}
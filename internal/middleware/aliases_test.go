@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkAliases(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	aliases := map[string]string{"devnet-4": "devnet-5"}
+
+	tests := []struct {
+		name         string
+		rewrite      bool
+		path         string
+		expectStatus int
+		expectPath   string // path seen by the next handler, or Location for redirects
+	}{
+		{
+			name:         "redirects aliased network",
+			path:         "/api/v1/devnet-4/fct_block?limit=10",
+			expectStatus: http.StatusPermanentRedirect,
+			expectPath:   "/api/v1/devnet-5/fct_block?limit=10",
+		},
+		{
+			name:         "redirects aliased bounds route",
+			path:         "/api/v1/devnet-4/bounds",
+			expectStatus: http.StatusPermanentRedirect,
+			expectPath:   "/api/v1/devnet-5/bounds",
+		},
+		{
+			name:         "redirects aliased gas profiler route",
+			path:         "/api/v1/gas-profiler/devnet-4/simulate",
+			expectStatus: http.StatusPermanentRedirect,
+			expectPath:   "/api/v1/gas-profiler/devnet-5/simulate",
+		},
+		{
+			name:         "rewrites aliased network transparently",
+			rewrite:      true,
+			path:         "/api/v1/devnet-4/fct_block",
+			expectStatus: http.StatusOK,
+			expectPath:   "/api/v1/devnet-5/fct_block",
+		},
+		{
+			name:         "canonical network passes through",
+			path:         "/api/v1/devnet-5/fct_block",
+			expectStatus: http.StatusOK,
+			expectPath:   "/api/v1/devnet-5/fct_block",
+		},
+		{
+			name:         "partial segment match passes through",
+			path:         "/api/v1/devnet-40/fct_block",
+			expectStatus: http.StatusOK,
+			expectPath:   "/api/v1/devnet-40/fct_block",
+		},
+		{
+			name:         "non-API path passes through",
+			path:         "/devnet-4",
+			expectStatus: http.StatusOK,
+			expectPath:   "/devnet-4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seenPath string
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seenPath = r.URL.Path
+			})
+
+			handler := NetworkAliases(aliases, tt.rewrite, logger)(next)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+
+			if tt.expectStatus == http.StatusPermanentRedirect {
+				assert.Equal(t, tt.expectPath, rec.Header().Get("Location"))
+				assert.Empty(t, seenPath)
+			} else {
+				assert.Equal(t, tt.expectPath, seenPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Auth returns a middleware that authenticates API keys sent as
+// "Authorization: Bearer <key>" and attaches the caller's identity and tier
+// to the request context. Requests without a key continue anonymously unless
+// required is set, in which case /api/ requests are rejected.
+func Auth(store *auth.Store, required bool, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// CORS preflight requests never carry credentials
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			enforced := required && strings.HasPrefix(r.URL.Path, "/api/")
+
+			token, ok := bearerToken(r)
+			if !ok {
+				if enforced {
+					writeAuthError(w, r, http.StatusUnauthorized, "api key required")
+
+					return
+				}
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			identity, err := store.Authenticate(r.Context(), token)
+
+			switch {
+			case errors.Is(err, auth.ErrInvalidKey):
+				requestid.Logger(r.Context(), log).WithField("path", r.URL.Path).Debug("invalid api key")
+
+				writeAuthError(w, r, http.StatusUnauthorized, "invalid api key")
+
+				return
+			case err != nil:
+				requestid.Logger(r.Context(), log).WithError(err).Warn("api key lookup failed")
+
+				// Without Redis, keyed clients fall back to anonymous limits
+				if enforced {
+					writeAuthError(w, r, http.StatusServiceUnavailable, "authentication unavailable")
+
+					return
+				}
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+		})
+	}
+}
+
+// RequireTier returns a middleware that only lets through requests
+// authenticated with an API key of at least the given tier, for admin
+// endpoints. It must sit inside Auth, which attaches the identity.
+func RequireTier(tier string, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	minimum := slices.Index(config.Tiers, tier)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := auth.IdentityFromContext(r.Context())
+			if !ok {
+				writeAuthError(w, r, http.StatusUnauthorized, "api key required")
+
+				return
+			}
+
+			if slices.Index(config.Tiers, identity.Tier) < minimum {
+				requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+					"path":   r.URL.Path,
+					"key_id": identity.KeyID,
+					"tier":   identity.Tier,
+				}).Warn("api key tier not allowed")
+
+				writeAuthError(w, r, http.StatusForbidden, tier+" tier api key required")
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope returns a middleware that rejects API keys scoped to other
+// endpoint classes or networks with 403. Anonymous requests and unscoped keys
+// pass through. It must sit inside Auth, which attaches the identity.
+func RequireScope(scope string, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := auth.IdentityFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			network := scopeNetwork(r)
+
+			var message string
+
+			switch {
+			case !identity.AllowsScope(scope):
+				message = "api key is not scoped to " + scope + " endpoints"
+			case network != "" && !identity.AllowsNetwork(network):
+				message = "api key is not scoped to network " + network
+			default:
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+				"path":    r.URL.Path,
+				"key_id":  identity.KeyID,
+				"scope":   scope,
+				"network": network,
+			}).Debug("api key scope not allowed")
+
+			writeAuthError(w, r, http.StatusForbidden, message)
+		})
+	}
+}
+
+// scopeNetwork returns the network a request addresses: the {network} path
+// value, or for proxy reads through the catch-all /api/v1/ route the first
+// segment under it. Other fixed routes address no single network.
+func scopeNetwork(r *http.Request) string {
+	if network := r.PathValue("network"); network != "" || r.Pattern != "/api/v1/" {
+		return network
+	}
+
+	network, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/")
+
+	return network
+}
+
+// bearerToken extracts the token from a Bearer Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+func writeAuthError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	code := httperr.CodeForbidden
+
+	if status == http.StatusUnauthorized {
+		code = httperr.CodeUnauthorized
+
+		w.Header().Set("WWW-Authenticate", `Bearer realm="lab"`)
+	}
+
+	httperr.Write(w, r, status, code, message)
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func TestAuth(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	cfg := config.AuthConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	store := auth.NewStore(logger, cfg, client)
+	require.NoError(t, store.Put(t.Context(), "pro-key", auth.Key{ID: "k1", Tier: config.TierPro}))
+
+	tests := []struct {
+		name           string
+		required       bool
+		path           string
+		authorization  string
+		expectedStatus int
+		expectedTier   string
+	}{
+		{
+			name:           "anonymous request allowed when not required",
+			path:           "/api/v1/mainnet/fct_block",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "valid key attaches tier",
+			path:           "/api/v1/mainnet/fct_block",
+			authorization:  "Bearer pro-key",
+			expectedStatus: http.StatusOK,
+			expectedTier:   config.TierPro,
+		},
+		{
+			name:           "invalid key rejected",
+			path:           "/api/v1/mainnet/fct_block",
+			authorization:  "Bearer wrong-key",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "non-bearer authorization is anonymous",
+			path:           "/api/v1/mainnet/fct_block",
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing key rejected when required",
+			required:       true,
+			path:           "/api/v1/mainnet/fct_block",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "frontend does not require a key",
+			required:       true,
+			path:           "/",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tier string
+
+			handler := Auth(store, tt.required, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+					tier = identity.Tier
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedTier, tier)
+
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/config", RequireScope(config.ScopeConfig, logger)(ok))
+	mux.Handle("GET /api/v1/availability", RequireScope(config.ScopeProxy, logger)(ok))
+	mux.Handle("GET /api/v1/{network}/bounds", RequireScope(config.ScopeProxy, logger)(ok))
+	mux.Handle("/api/v1/gas-profiler/{network}/{action}", RequireScope(config.ScopeGasProfiler, logger)(ok))
+	mux.Handle("/api/v1/", RequireScope(config.ScopeProxy, logger)(ok))
+
+	scoped := &auth.Identity{KeyID: "k1", Networks: []string{"hoodi"}, Scopes: []string{config.ScopeProxy}}
+
+	tests := []struct {
+		name           string
+		path           string
+		identity       *auth.Identity
+		expectedStatus int
+	}{
+		{name: "anonymous passes", path: "/api/v1/mainnet/fct_block", expectedStatus: http.StatusOK},
+		{name: "unscoped key passes", path: "/api/v1/gas-profiler/mainnet/simulate", identity: &auth.Identity{KeyID: "k2"}, expectedStatus: http.StatusOK},
+		{name: "scoped network through proxy", path: "/api/v1/hoodi/fct_block", identity: scoped, expectedStatus: http.StatusOK},
+		{name: "scoped network by path value", path: "/api/v1/hoodi/bounds", identity: scoped, expectedStatus: http.StatusOK},
+		{name: "other network through proxy", path: "/api/v1/mainnet/fct_block", identity: scoped, expectedStatus: http.StatusForbidden},
+		{name: "other network by path value", path: "/api/v1/mainnet/bounds", identity: scoped, expectedStatus: http.StatusForbidden},
+		{name: "fixed proxy route addresses no network", path: "/api/v1/availability", identity: scoped, expectedStatus: http.StatusOK},
+		{name: "other endpoint class", path: "/api/v1/config", identity: scoped, expectedStatus: http.StatusForbidden},
+		{name: "other endpoint class on scoped network", path: "/api/v1/gas-profiler/hoodi/simulate", identity: scoped, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), *tt.identity))
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestRequireTier(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	handler := RequireTier(config.TierInternal, logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		identity       *auth.Identity
+		expectedStatus int
+	}{
+		{name: "anonymous rejected", expectedStatus: http.StatusUnauthorized},
+		{name: "lower tier forbidden", identity: &auth.Identity{KeyID: "k1", Tier: config.TierPro}, expectedStatus: http.StatusForbidden},
+		{name: "required tier allowed", identity: &auth.Identity{KeyID: "k2", Tier: config.TierInternal}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/v1/frontend/reload", http.NoBody)
+			if tt.identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), *tt.identity))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
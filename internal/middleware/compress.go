@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/contentcoding"
+)
+
+// Compress returns middleware that compresses responses of the configured
+// content types with brotli or gzip, whichever the client prefers, once the
+// body reaches min_size. Responses that are already encoded, bodiless or
+// upgrades are sent as they are.
+func Compress(cfg config.CompressionConfig) func(http.Handler) http.Handler {
+	types := make(map[string]bool, len(cfg.ContentTypes))
+	for _, contentType := range cfg.ContentTypes {
+		types[contentType] = true
+	}
+
+	encoders := newEncoderPools(cfg.GzipLevel, cfg.BrotliLevel)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			cw := &compressResponseWriter{
+				ResponseWriter: w,
+				types:          types,
+				minSize:        cfg.MinSize,
+				encoders:       encoders,
+				acceptEncoding: r.Header.Get("Accept-Encoding"),
+				status:         http.StatusOK,
+			}
+
+			next.ServeHTTP(cw, r)
+
+			// Not deferred: after a panic, recovery answers on the underlying writer
+			cw.Close()
+		})
+	}
+}
+
+// encoderPools reuses brotli and gzip writers across responses, as both
+// allocate large buffers.
+type encoderPools struct {
+	gzip   sync.Pool
+	brotli sync.Pool
+}
+
+func newEncoderPools(gzipLevel, brotliLevel int) *encoderPools {
+	p := &encoderPools{}
+
+	p.gzip.New = func() any {
+		gw, _ := gzip.NewWriterLevel(io.Discard, gzipLevel) // Level is validated
+
+		return gw
+	}
+
+	p.brotli.New = func() any {
+		return brotli.NewWriterLevel(io.Discard, brotliLevel)
+	}
+
+	return p
+}
+
+// get returns an encoder for coding writing to w, and a function returning
+// it to its pool.
+func (p *encoderPools) get(coding string, w io.Writer) (encoder, func()) {
+	if coding == contentcoding.Brotli {
+		bw, _ := p.brotli.Get().(*brotli.Writer)
+		bw.Reset(w)
+
+		return bw, func() { p.brotli.Put(bw) }
+	}
+
+	gw, _ := p.gzip.Get().(*gzip.Writer)
+	gw.Reset(w)
+
+	return gw, func() { p.gzip.Put(gw) }
+}
+
+// encoder is implemented by brotli and gzip writers.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressResponseWriter holds back the status and the start of the body
+// until it knows whether the response is worth compressing.
+type compressResponseWriter struct {
+	http.ResponseWriter
+
+	types          map[string]bool
+	minSize        int
+	encoders       *encoderPools
+	acceptEncoding string
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder encoder
+	release func()
+}
+
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.decided {
+		return
+	}
+
+	// Informational responses go out right away, the final one follows
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		cw.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	cw.status = code
+
+	// Nothing to hold back for responses without a body
+	if !bodyAllowed(code) {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressResponseWriter) Write(b []byte) (int, error) {
+	if !cw.decided {
+		cw.buf = append(cw.buf, b...)
+
+		if len(cw.buf) < cw.minSize {
+			return len(b), nil
+		}
+
+		if err := cw.start(true); err != nil {
+			return 0, err
+		}
+
+		return len(b), nil
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(b)
+	}
+
+	return cw.ResponseWriter.Write(b)
+}
+
+// FlushError sends what was written so far, compressing streamed responses
+// of a compressible type whatever their size. Used by http.ResponseController.
+func (cw *compressResponseWriter) FlushError() error {
+	if !cw.decided {
+		if err := cw.start(true); err != nil {
+			return err
+		}
+	}
+
+	if cw.encoder != nil {
+		if err := cw.encoder.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher.
+func (cw *compressResponseWriter) Flush() {
+	_ = cw.FlushError()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close sends a body that stayed below min_size uncompressed, and finishes
+// a compressed one.
+func (cw *compressResponseWriter) Close() {
+	if !cw.decided {
+		_ = cw.start(len(cw.buf) >= cw.minSize)
+	}
+
+	if cw.encoder != nil {
+		_ = cw.encoder.Close()
+		cw.release()
+		cw.encoder = nil
+	}
+}
+
+// start sends the status and the held back body, compressed if compress is
+// set and the response qualifies.
+func (cw *compressResponseWriter) start(compress bool) error {
+	cw.decide(compress)
+
+	buf := cw.buf
+	cw.buf = nil
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	if cw.encoder != nil {
+		_, err := cw.encoder.Write(buf)
+
+		return err
+	}
+
+	_, err := cw.ResponseWriter.Write(buf)
+
+	return err
+}
+
+// decide picks the coding and writes the status line.
+func (cw *compressResponseWriter) decide(compress bool) {
+	cw.decided = true
+
+	h := cw.Header()
+
+	// Ranges are slices of the unencoded body, they can't be compressed on their own
+	eligible := bodyAllowed(cw.status) && h.Get("Content-Encoding") == "" && h.Get("Content-Range") == "" &&
+		cw.compressible(h.Get("Content-Type"))
+
+	// Caches must tell apart the variants of compressible responses
+	if eligible {
+		addVary(h, "Accept-Encoding")
+	}
+
+	if eligible && compress {
+		if codings := contentcoding.Accepted(cw.acceptEncoding); len(codings) > 0 {
+			h.Set("Content-Encoding", codings[0])
+			h.Del("Content-Length")
+
+			// The compressed body is a different representation
+			if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+				h.Set("ETag", "W/"+etag)
+			}
+
+			cw.encoder, cw.release = cw.encoders.get(codings[0], cw.ResponseWriter)
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+// compressible reports whether contentType is one of the configured types.
+func (cw *compressResponseWriter) compressible(contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && cw.types[mediaType]
+}
+
+// bodyAllowed reports whether a response with status may have a body.
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// addVary adds value to the Vary header unless it is listed already.
+func addVary(h http.Header, value string) {
+	for _, vary := range h.Values("Vary") {
+		for field := range strings.SplitSeq(vary, ",") {
+			if field = strings.TrimSpace(field); field == "*" || strings.EqualFold(field, value) {
+				return
+			}
+		}
+	}
+
+	h.Add("Vary", value)
+}
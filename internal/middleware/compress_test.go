@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestCompress(t *testing.T) {
+	cfg := config.CompressionConfig{}
+	require.NoError(t, cfg.Validate())
+
+	large := `{"data":"` + strings.Repeat("a", 4096) + `"}`
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		headers        map[string]string
+		body           string
+		expectEncoding string
+		expectVary     bool
+	}{
+		{name: "brotli preferred", acceptEncoding: "gzip, br", contentType: "application/json", body: large, expectEncoding: "br", expectVary: true},
+		{name: "gzip", acceptEncoding: "gzip", contentType: "application/json; charset=utf-8", body: large, expectEncoding: "gzip", expectVary: true},
+		{name: "no accepted coding", acceptEncoding: "", contentType: "application/json", body: large, expectVary: true},
+		{name: "small body", acceptEncoding: "gzip", contentType: "application/json", body: `{"ok":true}`, expectVary: true},
+		{name: "type not allowed", acceptEncoding: "gzip", contentType: "image/png", body: large},
+		{
+			name: "already encoded", acceptEncoding: "gzip", contentType: "application/json", body: large,
+			headers: map[string]string{"Content-Encoding": "zstd"}, expectEncoding: "zstd",
+		},
+		{
+			name: "partial content", acceptEncoding: "gzip", contentType: "application/json", body: large,
+			headers: map[string]string{"Content-Range": "bytes 0-4106/5000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+
+				_, _ = io.WriteString(w, tt.body)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/test", http.NoBody)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			rec := httptest.NewRecorder()
+			Compress(cfg)(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectEncoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.expectVary, rec.Header().Get("Vary") == "Accept-Encoding")
+
+			var body io.Reader = rec.Body
+
+			switch rec.Header().Get("Content-Encoding") {
+			case "br":
+				body = brotli.NewReader(rec.Body)
+			case "gzip":
+				gr, err := gzip.NewReader(rec.Body)
+				require.NoError(t, err)
+
+				body = gr
+			}
+
+			decoded, err := io.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(decoded))
+		})
+	}
+}
+
+func TestCompress_Headers(t *testing.T) {
+	cfg := config.CompressionConfig{}
+	require.NoError(t, cfg.Validate())
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "4096")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Vary", "Origin")
+		_, _ = io.WriteString(w, strings.Repeat("a", 4096))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/test", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+	Compress(cfg)(next).ServeHTTP(rec, req)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Empty(t, rec.Header().Get("Content-Length"))
+	assert.Equal(t, `W/"abc"`, rec.Header().Get("ETag"))
+	assert.Equal(t, []string{"Origin", "Accept-Encoding"}, rec.Header().Values("Vary"))
+}
+
+func TestCompress_Streaming(t *testing.T) {
+	cfg := config.CompressionConfig{}
+	require.NoError(t, cfg.Validate())
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, "{}\n")
+		require.NoError(t, http.NewResponseController(w).Flush())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stream", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+	Compress(cfg)(next).ServeHTTP(rec, req)
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+	gr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+
+	decoded, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(decoded))
+}
@@ -12,8 +12,9 @@ func CORS() func(http.Handler) http.Handler {
 			// Only apply CORS to /api/* paths
 			if strings.HasPrefix(r.URL.Path, "/api/") {
 				w.Header().Set("Access-Control-Allow-Origin", "*")
-				w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, POST, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Lab-Preview-Token, X-Lab-Terms-Token, X-Lab-Client-ID, "+BuildEpochHeader)
+				w.Header().Set("Access-Control-Expose-Headers", VersionHeader+", "+RefreshRequiredHeader)
 
 				// Handle preflight requests
 				if r.Method == http.MethodOptions {
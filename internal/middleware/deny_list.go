@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/denylist"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// denyListReason is the machine-readable reason sent with deny list rejections.
+const denyListReason = "ip_denied"
+
+// DenyList returns a middleware that rejects requests from IPs on the deny
+// list with 403. The client IP is resolved by resolver, as for rate limiting.
+func DenyList(list *denylist.List, resolver *ratelimit.IPResolver, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolver.ClientIP(r)
+
+			entry, blocked := list.Blocked(ip)
+			if !blocked {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+				"ip":   ip,
+				"cidr": entry.CIDR,
+				"path": r.URL.Path,
+			}).Debug("Rejected request from denied IP")
+
+			problem := httperr.New(http.StatusForbidden, httperr.CodeAccessDenied, "access denied").
+				With("reason", denyListReason)
+
+			if entry.ExpiresAt != nil {
+				problem.With("expires_at", entry.ExpiresAt)
+			}
+
+			problem.Write(w, r)
+		})
+	}
+}
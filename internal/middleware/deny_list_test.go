@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/denylist"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func TestDenyList(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	cfg := config.DenyListConfig{Enabled: true, PollInterval: time.Hour}
+	require.NoError(t, cfg.Validate())
+
+	list := denylist.New(logger, cfg, client)
+
+	_, err := list.Add(t.Context(), "203.0.113.0/24", "scraper", 0)
+	require.NoError(t, err)
+
+	handler := DenyList(list, ratelimit.NewIPResolver([]string{"10.0.0.0/8"}), logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Denied IP
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody)
+	req.RemoteAddr = "10.0.0.1:4321"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	assert.NotContains(t, rec.Body.String(), "scraper", "operator notes are not shown to clients")
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "ip_denied", body["reason"])
+
+	// Other IPs pass, and forwarding headers from untrusted peers are ignored
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody)
+	req.RemoteAddr = "198.51.100.1:4321"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -4,17 +4,28 @@ import (
 	"net/http"
 
 	"github.com/ethpandaops/lab-backend/internal/headers"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
 	"github.com/sirupsen/logrus"
 )
 
 // Headers returns an HTTP middleware that applies headers based on configured policies.
 // The middleware matches the request path against configured patterns and sets
-// all headers from the first matching policy.
+// all headers from the first matching policy. A policy using {{nonce}} gets a
+// fresh nonce per request, passed on to handlers in the request context.
+// Requests of a tenant are matched against its policies first.
 func Headers(manager *headers.Manager, log logrus.FieldLogger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policies := manager
+			if tenant := tenancy.FromContext(r.Context()); tenant != nil {
+				policies = tenant.Headers
+			}
+
 			// Match path to policy and get headers
-			matchedHeaders := manager.Match(r.URL.Path)
+			matchedHeaders, ctx := policies.Resolve(r.Context(), r.URL.Path)
+			if ctx != r.Context() {
+				r = r.WithContext(ctx)
+			}
 
 			if len(matchedHeaders) > 0 {
 				// Set all headers from policy
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+const (
+	// inFlightPollInterval is how often Drain checks the in-flight counts.
+	inFlightPollInterval = 50 * time.Millisecond
+
+	// inFlightLogInterval is how often Drain logs its progress.
+	inFlightLogInterval = time.Second
+)
+
+var httpInFlight = metrics.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "http_in_flight_requests",
+		Help: "Number of requests being served, by kind (request, or stream for SSE and WebSocket connections)",
+	},
+	[]string{"kind"},
+)
+
+// InFlight tracks the requests being served, so shutdown can wait for them.
+// Streams (SSE responses and WebSocket connections) are counted apart from
+// regular requests: they only end when the client leaves, so Drain gives them
+// their own timeout and then closes them by canceling their context.
+type InFlight struct {
+	mu       sync.Mutex
+	requests int
+	streams  map[*inFlightEntry]struct{}
+}
+
+// inFlightEntry is one tracked request.
+type inFlightEntry struct {
+	stream bool
+	cancel context.CancelFunc
+}
+
+// NewInFlight creates an in-flight request tracker.
+func NewInFlight() *InFlight {
+	return &InFlight{streams: make(map[*inFlightEntry]struct{})}
+}
+
+// Track returns a middleware that counts requests while they are served.
+// Requests for a WebSocket upgrade or an event stream are streams from the
+// start; others become one when they respond with text/event-stream.
+func (f *InFlight) Track() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+
+			entry := &inFlightEntry{cancel: cancel}
+
+			f.add(entry, strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+				strings.Contains(r.Header.Get("Accept"), "text/event-stream"))
+			defer f.remove(entry)
+
+			next.ServeHTTP(&inFlightResponseWriter{ResponseWriter: w, inFlight: f, entry: entry}, r.WithContext(ctx))
+		})
+	}
+}
+
+// Counts returns the number of regular requests and streams being served.
+func (f *InFlight) Counts() (requests, streams int) {
+	if f == nil {
+		return 0, 0
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests, len(f.streams)
+}
+
+// Drain waits for in-flight requests to finish, logging progress. Streams
+// still open after streamTimeout are closed. Returns an error when ctx ends
+// first. The server must have stopped accepting connections already.
+func (f *InFlight) Drain(ctx context.Context, streamTimeout time.Duration, log logrus.FieldLogger) error {
+	if f == nil {
+		return nil
+	}
+
+	closeStreamsAt := time.Now().Add(streamTimeout)
+	streamsClosed := false
+
+	poll := time.NewTicker(inFlightPollInterval)
+	defer poll.Stop()
+
+	var lastLog time.Time
+
+	for {
+		requests, streams := f.Counts()
+		if requests == 0 && streams == 0 {
+			return nil
+		}
+
+		now := time.Now()
+
+		if streams > 0 && !streamsClosed && !now.Before(closeStreamsAt) {
+			log.WithField("streams", streams).Info("Drain timeout reached, closing open streams")
+
+			f.closeStreams()
+
+			streamsClosed = true
+		}
+
+		if now.Sub(lastLog) >= inFlightLogInterval {
+			log.WithFields(logrus.Fields{
+				"requests": requests,
+				"streams":  streams,
+			}).Info("Draining in-flight requests")
+
+			lastLog = now
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d requests and %d streams still in flight: %w", requests, streams, ctx.Err())
+		case <-poll.C:
+		}
+	}
+}
+
+func (f *InFlight) add(entry *inFlightEntry, stream bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if stream {
+		entry.stream = true
+		f.streams[entry] = struct{}{}
+
+		httpInFlight.WithLabelValues("stream").Inc()
+
+		return
+	}
+
+	f.requests++
+
+	httpInFlight.WithLabelValues("request").Inc()
+}
+
+// promote turns a regular request into a stream.
+func (f *InFlight) promote(entry *inFlightEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if entry.stream {
+		return
+	}
+
+	entry.stream = true
+	f.streams[entry] = struct{}{}
+	f.requests--
+
+	httpInFlight.WithLabelValues("request").Dec()
+	httpInFlight.WithLabelValues("stream").Inc()
+}
+
+func (f *InFlight) remove(entry *inFlightEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if entry.stream {
+		delete(f.streams, entry)
+
+		httpInFlight.WithLabelValues("stream").Dec()
+
+		return
+	}
+
+	f.requests--
+
+	httpInFlight.WithLabelValues("request").Dec()
+}
+
+// closeStreams cancels the context of every open stream.
+func (f *InFlight) closeStreams() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for entry := range f.streams {
+		entry.cancel()
+	}
+}
+
+// inFlightResponseWriter promotes a request to a stream when it starts an
+// event stream response.
+type inFlightResponseWriter struct {
+	http.ResponseWriter
+	inFlight *InFlight
+	entry    *inFlightEntry
+}
+
+func (w *inFlightResponseWriter) WriteHeader(code int) {
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		w.inFlight.promote(w.entry)
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *inFlightResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlight_Drain(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	inFlight := NewInFlight()
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+
+	handler := inFlight.Track()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			started <- struct{}{}
+
+			// Open until the client leaves or the drain closes it
+			<-r.Context().Done()
+
+			return
+		}
+
+		started <- struct{}{}
+
+		<-release
+	}))
+
+	done := make(chan struct{})
+
+	for _, path := range []string{"/stream", "/request"} {
+		go func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+			done <- struct{}{}
+		}()
+	}
+
+	<-started
+	<-started
+
+	requests, streams := inFlight.Counts()
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, streams)
+
+	// Regular requests are waited for until ctx ends
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := inFlight.Drain(ctx, time.Minute, logger)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Streams are closed once the drain timeout passes
+	close(release)
+
+	require.NoError(t, inFlight.Drain(context.Background(), 100*time.Millisecond, logger))
+
+	<-done
+	<-done
+
+	requests, streams = inFlight.Counts()
+	assert.Zero(t, requests)
+	assert.Zero(t, streams)
+}
+
+func TestInFlight_WebSocketIsStream(t *testing.T) {
+	inFlight := NewInFlight()
+
+	var streams int
+
+	handler := inFlight.Track()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		_, streams = inFlight.Counts()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", http.NoBody)
+	req.Header.Set("Upgrade", "websocket")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, 1, streams)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/requestid"
 )
 
 type responseWriter struct {
@@ -25,12 +27,29 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
-// Logging returns middleware that logs all HTTP requests.
+// Unwrap exposes the underlying writer to http.ResponseController,
+// so proxied WebSocket upgrades can hijack the connection.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Logging returns middleware that assigns each request an ID and logs it once
+// served. The ID is taken from a valid incoming X-Request-ID header or
+// generated, stored in the request context for handlers, upstream requests and
+// error responses, and echoed in the X-Request-ID response header.
 func Logging(logger logrus.FieldLogger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			id := requestid.FromHeader(r.Header)
+			if id == "" {
+				id = requestid.New()
+			}
+
+			r = r.WithContext(requestid.ContextWithID(r.Context(), id))
+			w.Header().Set(requestid.Header, id)
+
 			// Wrap response writer to capture status code
 			rw := &responseWriter{
 				ResponseWriter: w,
@@ -45,6 +64,7 @@ func Logging(logger logrus.FieldLogger) func(http.Handler) http.Handler {
 			duration := time.Since(start)
 
 			logger.WithFields(logrus.Fields{
+				"request_id":    id,
 				"method":        r.Method,
 				"path":          r.URL.Path,
 				"status":        rw.statusCode,
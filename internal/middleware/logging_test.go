@@ -10,6 +10,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
 )
 
 func TestLoggingMiddleware(t *testing.T) {
@@ -288,3 +291,54 @@ func TestResponseWriter_StatusCodeDefault(t *testing.T) {
 	// Verify the log contains status information
 	assert.Contains(t, logOutput, "status")
 }
+
+func TestLoggingMiddleware_RequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "generated when absent", incoming: ""},
+		{name: "incoming ID honored", incoming: "abc-123_x.y:z", keep: true},
+		{name: "invalid incoming ID replaced", incoming: "bad id\r\ninjected"},
+		{name: "overlong incoming ID replaced", incoming: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+
+			var seen string
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = requestid.FromContext(r.Context())
+
+				httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "boom")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+
+			rec := httptest.NewRecorder()
+			Logging(logger)(handler).ServeHTTP(rec, req)
+
+			require.NotEmpty(t, seen)
+
+			if tt.keep {
+				assert.Equal(t, tt.incoming, seen)
+			} else {
+				assert.NotEqual(t, tt.incoming, seen)
+			}
+
+			assert.Equal(t, seen, rec.Header().Get(requestid.Header))
+			assert.Contains(t, rec.Body.String(), `"request_id":"`+seen+`"`)
+			assert.Contains(t, buf.String(), `"request_id":"`+seen+`"`)
+		})
+	}
+}
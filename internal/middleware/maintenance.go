@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/maintenance"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// maintenanceExempt are the routes served during maintenance: health probes,
+// so load balancers keep seeing the real state of each instance, metrics and
+// profiles, and the admin API, so operators can lift maintenance.
+var maintenanceExempt = []string{"/healthz", "/health", "/readyz", "/metrics", "/debug/", "/admin/"}
+
+// Maintenance returns a middleware that answers every request with 503 and
+// Retry-After while a maintenance window is active: a problem with the
+// window's message for API routes, the maintenance page for the frontend.
+func Maintenance(mode *maintenance.Mode, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			window := mode.Active()
+			if window == nil || isMaintenanceExempt(r.URL.Path) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+				"method": r.Method,
+				"path":   r.URL.Path,
+			}).Debug("Rejected request during maintenance")
+
+			retryAfter := mode.RetryAfter(window)
+
+			w.Header().Set("Cache-Control", "no-store")
+			w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+
+			if !strings.HasPrefix(r.URL.Path, "/api/") {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				if r.Method != http.MethodHead {
+					_, _ = w.Write(mode.Page(window))
+				}
+
+				return
+			}
+
+			problem := httperr.New(http.StatusServiceUnavailable, httperr.CodeMaintenance, "service is down for maintenance").
+				With("message", mode.Message(window)).
+				With("retry_after_seconds", retryAfter)
+
+			if !window.EndsAt.IsZero() {
+				problem.With("ends_at", window.EndsAt)
+			}
+
+			if len(window.Details) > 0 {
+				problem.With("details", window.Details)
+			}
+
+			problem.Write(w, r)
+		})
+	}
+}
+
+// isMaintenanceExempt reports whether path is served during maintenance.
+func isMaintenanceExempt(path string) bool {
+	for _, route := range maintenanceExempt {
+		if path == route || (strings.HasSuffix(route, "/") && strings.HasPrefix(path, route)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/maintenance"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func TestMaintenance(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	cfg := config.MaintenanceConfig{}
+	require.NoError(t, cfg.Validate())
+
+	mode, err := maintenance.New(logger, cfg, client)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := Maintenance(mode, logger)(next)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+		return rec
+	}
+
+	// Nothing changes outside maintenance
+	assert.Equal(t, http.StatusOK, serve("/api/v1/config").Code)
+
+	require.NoError(t, mode.Schedule(t.Context(), maintenance.Window{
+		Message: "ClickHouse upgrade",
+		Details: map[string]any{"status_page": "https://status.example.com"},
+		EndsAt:  time.Now().Add(time.Hour),
+	}))
+
+	// API routes get a problem with the window's message
+	rec := serve("/api/v1/config")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	var problem map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, "maintenance", problem["code"])
+	assert.Equal(t, "ClickHouse upgrade", problem["message"])
+	assert.Equal(t, map[string]any{"status_page": "https://status.example.com"}, problem["details"])
+	assert.Contains(t, problem, "ends_at")
+
+	// Frontend routes get the maintenance page
+	rec = serve("/ethereum/slots")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "ClickHouse upgrade")
+
+	// Probes, metrics and the admin API keep working
+	for _, path := range []string{"/healthz", "/health", "/readyz", "/metrics", "/admin/v1/maintenance"} {
+		assert.Equal(t, http.StatusOK, serve(path).Code, path)
+	}
+
+	require.NoError(t, mode.Clear(t.Context()))
+	assert.Equal(t, http.StatusOK, serve("/api/v1/config").Code)
+}
@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
 )
 
 var (
-	httpRequestsTotal = prometheus.NewCounterVec(
+	httpRequestsTotal = metrics.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_requests_total",
 			Help: "Total number of HTTP requests",
@@ -18,7 +20,7 @@ var (
 		[]string{"method", "path", "status"},
 	)
 
-	httpRequestDuration = prometheus.NewHistogramVec(
+	httpRequestDuration = metrics.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "http_request_duration_seconds",
 			Help:    "HTTP request duration in seconds",
@@ -27,7 +29,7 @@ var (
 		[]string{"method", "path"},
 	)
 
-	httpRequestSize = prometheus.NewSummaryVec(
+	httpRequestSize = metrics.NewSummaryVec(
 		prometheus.SummaryOpts{
 			Name: "http_request_size_bytes",
 			Help: "HTTP request size in bytes",
@@ -35,7 +37,7 @@ var (
 		[]string{"method", "path"},
 	)
 
-	httpResponseSize = prometheus.NewSummaryVec(
+	httpResponseSize = metrics.NewSummaryVec(
 		prometheus.SummaryOpts{
 			Name: "http_response_size_bytes",
 			Help: "HTTP response size in bytes",
@@ -44,7 +46,7 @@ var (
 	)
 
 	// Rate limiting metrics.
-	RateLimitAllowedTotal = promauto.NewCounterVec(
+	RateLimitAllowedTotal = metrics.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_rate_limit_allowed_total",
 			Help: "Total number of requests allowed by rate limiter",
@@ -52,7 +54,7 @@ var (
 		[]string{"rule", "path_pattern"},
 	)
 
-	RateLimitDeniedTotal = promauto.NewCounterVec(
+	RateLimitDeniedTotal = metrics.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_rate_limit_denied_total",
 			Help: "Total number of requests denied by rate limiter",
@@ -60,7 +62,7 @@ var (
 		[]string{"rule", "path_pattern"},
 	)
 
-	RateLimitErrorsTotal = promauto.NewCounterVec(
+	RateLimitErrorsTotal = metrics.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_rate_limit_errors_total",
 			Help: "Total number of rate limiter errors",
@@ -69,14 +71,6 @@ var (
 	)
 )
 
-func init() {
-	// Register metrics with the default registry
-	prometheus.MustRegister(httpRequestsTotal)
-	prometheus.MustRegister(httpRequestDuration)
-	prometheus.MustRegister(httpRequestSize)
-	prometheus.MustRegister(httpResponseSize)
-}
-
 type metricsResponseWriter struct {
 	http.ResponseWriter
 	statusCode   int
@@ -95,6 +89,11 @@ func (mrw *metricsResponseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (mrw *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return mrw.ResponseWriter
+}
+
 // Metrics returns middleware that collects Prometheus metrics.
 func Metrics() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -125,10 +124,11 @@ func Metrics() func(http.Handler) http.Handler {
 				strconv.Itoa(mrw.statusCode),
 			).Inc()
 
-			httpRequestDuration.WithLabelValues(
-				r.Method,
-				r.URL.Path,
-			).Observe(duration.Seconds())
+			tracing.Observe(
+				httpRequestDuration.WithLabelValues(r.Method, r.URL.Path),
+				duration.Seconds(),
+				tracing.TraceIDFromContext(r.Context()),
+			)
 
 			httpResponseSize.WithLabelValues(
 				r.Method,
@@ -1,61 +1,56 @@
 package middleware
 
 import (
-	"encoding/json"
-	"net"
+	"math"
 	"net/http"
-	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
 	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
 )
 
-type compiledRule struct {
-	name    string
-	pattern *regexp.Regexp
-	limit   int
-	window  time.Duration
-}
-
 // RateLimit returns a middleware that enforces rate limiting.
+// Anonymous requests are limited per client IP. Requests authenticated with
+// an API key are limited per key, using the key tier's limit when the rule has one.
+// Rules with a burst allow that many requests above the limit at once, refilled
+// at limit per window.
+// Requests for ratelimit.LimitsPath only report limits and are never counted.
+// Client IPs are resolved by resolver.
+// Denied requests are recorded in offenders, if set.
 func RateLimit(
 	log logrus.FieldLogger,
 	cfg config.RateLimitingConfig,
+	resolver *ratelimit.IPResolver,
 	limiter ratelimit.Service,
+	offenders *ratelimit.Offenders,
 ) func(http.Handler) http.Handler {
-	// Pre-compile regex patterns for performance
-	compiledRules := make([]compiledRule, len(cfg.Rules))
-	for i, rule := range cfg.Rules {
-		compiledRules[i] = compiledRule{
-			name:    rule.Name,
-			pattern: regexp.MustCompile(rule.PathPattern),
-			limit:   rule.Limit,
-			window:  rule.Window,
-		}
-	}
-
-	// Pre-parse exempt IP ranges
-	exemptNets := parseExemptIPs(cfg.ExemptIPs)
+	policy := ratelimit.NewPolicy(cfg)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Extract client IP
-			ip := extractClientIP(r)
+			if r.URL.Path == ratelimit.LimitsPath {
+				next.ServeHTTP(w, r)
 
-			// Check if IP is whitelisted
-			if isExempt(ip, exemptNets) {
+				return
+			}
+
+			client := resolver.Client(r)
+			ip := client.IP
+
+			// Check if IP or API key tier is whitelisted
+			if policy.Exempt(client) != "" {
 				next.ServeHTTP(w, r)
 
 				return
 			}
 
 			// Find matching rate limit rule
-			rule := findMatchingRule(r.URL.Path, compiledRules)
+			rule := policy.Match(r.URL.Path)
 			if rule == nil {
 				// No matching rule, allow request
 				next.ServeHTTP(w, r)
@@ -63,46 +58,62 @@ func RateLimit(
 				return
 			}
 
-			// Check rate limit
-			allowed, remaining, resetAt, err := limiter.Allow(r.Context(), ip, rule.name, rule.limit, rule.window)
+			// Keyed clients get their own bucket and tier limit
+			limit := rule.LimitFor(client)
+
+			// Check rate limit; rules with a burst use a token bucket
+			var (
+				allowed   bool
+				remaining int
+				resetAt   time.Time
+				err       error
+			)
+
+			if rule.Burst > 0 {
+				allowed, remaining, resetAt, err = limiter.AllowBurst(r.Context(), client.Subject(), rule.Name, limit, rule.Burst, rule.Window)
+			} else {
+				allowed, remaining, resetAt, err = limiter.Allow(r.Context(), client.Subject(), rule.Name, limit, rule.Window)
+			}
 			if err != nil {
 				RateLimitErrorsTotal.WithLabelValues("redis_error").Inc()
 
-				log.WithError(err).WithFields(logrus.Fields{
+				requestid.Logger(r.Context(), log).WithError(err).WithFields(logrus.Fields{
 					"ip":   ip,
 					"path": r.URL.Path,
-					"rule": rule.name,
+					"rule": rule.Name,
 				}).Error("rate limit check failed")
 
 				// Error already handled by limiter's failure mode
 				if !allowed {
-					writeRateLimitError(w, "service unavailable", 0)
+					writeRateLimitError(w, r, "service unavailable", 0)
 
 					return
 				}
 			}
 
 			// Set rate limit headers (standard practice)
-			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rule.limit))
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
 			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
 			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
 
 			if !allowed {
 				// Rate limit exceeded
-				RateLimitDeniedTotal.WithLabelValues(rule.name, rule.pattern.String()).Inc()
+				RateLimitDeniedTotal.WithLabelValues(rule.Name, rule.Pattern.String()).Inc()
+				offenders.Record(rule.Name, client.Subject())
 
-				retryAfter := int(time.Until(resetAt).Seconds())
+				// Rounded up, as a bucket's next token is often less than a second away
+				retryAfter := int(math.Ceil(time.Until(resetAt).Seconds()))
 				if retryAfter < 0 {
-					retryAfter = int(rule.window.Seconds())
+					retryAfter = int(rule.Window.Seconds())
 				}
 
 				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
-				writeRateLimitError(w, "rate limit exceeded", retryAfter)
+				writeRateLimitError(w, r, "rate limit exceeded", retryAfter)
 
-				log.WithFields(logrus.Fields{
+				requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
 					"ip":          ip,
 					"path":        r.URL.Path,
-					"rule":        rule.name,
+					"rule":        rule.Name,
 					"retry_after": retryAfter,
 				}).Warn("rate limit exceeded")
 
@@ -110,106 +121,18 @@ func RateLimit(
 			}
 
 			// Allowed, continue to next handler
-			RateLimitAllowedTotal.WithLabelValues(rule.name, rule.pattern.String()).Inc()
+			RateLimitAllowedTotal.WithLabelValues(rule.Name, rule.Pattern.String()).Inc()
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
-// extractClientIP extracts the real client IP from the request.
-// Priority: X-Forwarded-For (Cloudflare) > X-Real-IP > RemoteAddr.
-func extractClientIP(r *http.Request) string {
-	// Cloudflare sets CF-Connecting-IP
-	if ip := r.Header.Get("CF-Connecting-IP"); ip != "" {
-		return ip
-	}
-
-	// X-Forwarded-For (may contain multiple IPs, take first)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		ips := strings.Split(xff, ",")
-		if len(ips) > 0 {
-			return strings.TrimSpace(ips[0])
-		}
-	}
-
-	// X-Real-IP
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
-	}
-
-	// Fallback to RemoteAddr (strip port)
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr
-	}
-
-	return ip
-}
-
-func parseExemptIPs(exemptIPs []string) []*net.IPNet {
-	nets := make([]*net.IPNet, 0, len(exemptIPs))
-
-	for _, cidr := range exemptIPs {
-		_, network, err := net.ParseCIDR(cidr)
-		if err != nil {
-			// Try parsing as single IP
-			if ip := net.ParseIP(cidr); ip != nil {
-				// Convert single IP to /32 or /128 CIDR
-				if ip.To4() != nil {
-					_, network, _ = net.ParseCIDR(cidr + "/32")
-				} else {
-					_, network, _ = net.ParseCIDR(cidr + "/128")
-				}
-
-				nets = append(nets, network)
-			}
-
-			continue
-		}
-
-		nets = append(nets, network)
-	}
-
-	return nets
-}
-
-func isExempt(ip string, exemptNets []*net.IPNet) bool {
-	parsedIP := net.ParseIP(ip)
-	if parsedIP == nil {
-		return false
-	}
-
-	for _, network := range exemptNets {
-		if network.Contains(parsedIP) {
-			return true
-		}
-	}
-
-	return false
-}
-
-func findMatchingRule(path string, rules []compiledRule) *compiledRule {
-	for i := range rules {
-		if rules[i].pattern.MatchString(path) {
-			return &rules[i]
-		}
-	}
-
-	return nil
-}
-
-func writeRateLimitError(w http.ResponseWriter, message string, retryAfter int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusTooManyRequests) // 429
-
-	response := map[string]any{
-		"error":  message,
-		"status": http.StatusTooManyRequests,
-	}
+func writeRateLimitError(w http.ResponseWriter, r *http.Request, message string, retryAfter int) {
+	problem := httperr.New(http.StatusTooManyRequests, httperr.CodeRateLimited, message)
 
 	if retryAfter > 0 {
-		response["retry_after"] = retryAfter
+		problem.With("retry_after", retryAfter)
 	}
 
-	_ = json.NewEncoder(w).Encode(response)
+	problem.Write(w, r)
 }
@@ -15,7 +15,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethpandaops/lab-backend/internal/auth"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/ratelimit"
 )
 
 // mockRateLimitService is a mock implementation of ratelimit.Service for testing.
@@ -34,6 +36,18 @@ func (m *mockRateLimitService) Allow(ctx context.Context, ip, key string, limit
 	return true, limit - 1, time.Now().Add(window), nil
 }
 
+func (m *mockRateLimitService) Peek(ctx context.Context, ip, key string, limit int) (int, time.Time, error) {
+	return limit, time.Time{}, nil
+}
+
+func (m *mockRateLimitService) AllowBurst(ctx context.Context, ip, key string, limit, burst int, window time.Duration) (bool, int, time.Time, error) {
+	return m.Allow(ctx, ip, key, limit+burst, window)
+}
+
+func (m *mockRateLimitService) PeekBurst(ctx context.Context, ip, key string, limit, burst int, window time.Duration) (int, time.Time, error) {
+	return limit + burst, time.Time{}, nil
+}
+
 // TestRateLimit_AllowsUnderLimit verifies that requests under the limit
 // all receive 200 status codes.
 func TestRateLimit_AllowsUnderLimit(t *testing.T) {
@@ -72,7 +86,7 @@ func TestRateLimit_AllowsUnderLimit(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	// Send N requests (all should succeed)
@@ -129,7 +143,7 @@ func TestRateLimit_DeniesOverLimit(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	// Send N requests (should all succeed)
@@ -211,7 +225,7 @@ func TestRateLimit_HeadersPresent(t *testing.T) {
 				w.WriteHeader(http.StatusOK)
 			})
 
-			middleware := RateLimit(logger, cfg, mock)
+			middleware := RateLimit(logger, cfg, nil, mock, nil)
 			wrapped := middleware(handler)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/test", http.NoBody)
@@ -263,7 +277,7 @@ func TestRateLimit_RetryAfterHeader(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/test", http.NoBody)
@@ -325,7 +339,7 @@ func TestRateLimit_ExemptIP(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	exemptIPs := []string{
@@ -397,7 +411,7 @@ func TestRateLimit_RuleMatching(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	tests := []struct {
@@ -465,7 +479,7 @@ func TestRateLimit_NoMatchingRule(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	// Paths that don't match any rule
@@ -489,7 +503,8 @@ func TestRateLimit_NoMatchingRule(t *testing.T) {
 }
 
 // TestRateLimit_IPExtraction verifies that client IP is extracted correctly
-// from CF-Connecting-IP > X-Forwarded-For > RemoteAddr.
+// from CF-Connecting-IP > X-Forwarded-For > RemoteAddr, and that forwarding
+// headers are only honored from trusted proxies.
 func TestRateLimit_IPExtraction(t *testing.T) {
 	logger := logrus.New()
 	logger.SetOutput(io.Discard)
@@ -521,7 +536,7 @@ func TestRateLimit_IPExtraction(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, ratelimit.NewIPResolver([]string{"10.0.0.0/8", "192.0.2.1"}), mock, nil)
 	wrapped := middleware(handler)
 
 	tests := []struct {
@@ -543,7 +558,7 @@ func TestRateLimit_IPExtraction(t *testing.T) {
 		{
 			name:          "X-Forwarded-For when no CF-Connecting-IP",
 			remoteAddr:    "10.0.0.1:12345",
-			xForwardedFor: "203.0.113.2, 198.51.100.1",
+			xForwardedFor: "203.0.113.2, 10.0.0.5",
 			xRealIP:       "198.18.0.1",
 			expectedIP:    "203.0.113.2",
 		},
@@ -553,6 +568,20 @@ func TestRateLimit_IPExtraction(t *testing.T) {
 			xRealIP:    "203.0.113.3",
 			expectedIP: "203.0.113.3",
 		},
+		{
+			name:          "X-Forwarded-For skips trusted hops",
+			remoteAddr:    "10.0.0.1:12345",
+			xForwardedFor: "198.18.0.9, 203.0.113.6, 10.0.0.2",
+			expectedIP:    "203.0.113.6",
+		},
+		{
+			name:           "Headers ignored from untrusted peer",
+			remoteAddr:     "198.51.100.7:12345",
+			cfConnectingIP: "203.0.113.1",
+			xForwardedFor:  "203.0.113.2",
+			xRealIP:        "203.0.113.3",
+			expectedIP:     "198.51.100.7",
+		},
 		{
 			name:       "RemoteAddr fallback",
 			remoteAddr: "203.0.113.4:54321",
@@ -625,7 +654,7 @@ func TestRateLimit_RedisError_FailOpen(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/test", http.NoBody)
@@ -668,7 +697,7 @@ func TestRateLimit_RedisError_FailClosed(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/test", http.NoBody)
@@ -725,7 +754,7 @@ func TestRateLimit_MultipleRulesFirstMatch(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	// Should match first (more specific) rule
@@ -785,7 +814,7 @@ func TestRateLimit_Integration_RealScenario(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := RateLimit(logger, cfg, mock)
+	middleware := RateLimit(logger, cfg, nil, mock, nil)
 	wrapped := middleware(handler)
 
 	// Scenario: 3 clients with different behaviors
@@ -827,3 +856,122 @@ func TestRateLimit_Integration_RealScenario(t *testing.T) {
 		})
 	}
 }
+
+// TestRateLimit_APIKeyTiers verifies that keyed requests are limited per key
+// with tier-specific limits, and that exempt tiers bypass rate limiting.
+func TestRateLimit_APIKeyTiers(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	type call struct {
+		subject string
+		limit   int
+	}
+
+	var calls []call
+
+	mock := &mockRateLimitService{
+		allowFunc: func(ctx context.Context, ip, key string, limit int, window time.Duration) (bool, int, time.Time, error) {
+			calls = append(calls, call{subject: ip, limit: limit})
+
+			return true, limit - 1, time.Now().Add(window), nil
+		},
+	}
+
+	cfg := config.RateLimitingConfig{
+		Enabled:     true,
+		FailureMode: "fail_open",
+		ExemptTiers: []string{config.TierInternal},
+		Rules: []config.RateLimitRule{
+			{
+				Name:        "api",
+				PathPattern: "^/api/.*",
+				Limit:       10,
+				Window:      1 * time.Minute,
+				TierLimits:  map[string]int{config.TierPro: 1000},
+			},
+		},
+	}
+
+	wrapped := RateLimit(logger, cfg, nil, mock, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	identities := []*auth.Identity{
+		nil,
+		{KeyID: "free-key", Tier: config.TierFree},
+		{KeyID: "pro-key", Tier: config.TierPro},
+		{KeyID: "internal-key", Tier: config.TierInternal},
+	}
+
+	for _, identity := range identities {
+		req := httptest.NewRequest(http.MethodGet, "/api/test", http.NoBody)
+		req.RemoteAddr = "203.0.113.7:1234"
+
+		if identity != nil {
+			req = req.WithContext(auth.WithIdentity(req.Context(), *identity))
+		}
+
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	assert.Equal(t, []call{
+		{subject: "203.0.113.7", limit: 10},
+		{subject: "key:free-key", limit: 10},
+		{subject: "key:pro-key", limit: 1000},
+	}, calls)
+}
+
+// TestRateLimit_BurstRuleUsesTokenBucket verifies that rules with a burst are
+// checked with AllowBurst and report the next token in Retry-After.
+func TestRateLimit_BurstRuleUsesTokenBucket(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	var gotBurst int
+
+	limiter := &burstRateLimitService{
+		allowBurstFunc: func(limit, burst int) (bool, int, time.Time, error) {
+			gotBurst = burst
+
+			return false, 0, time.Now().Add(300 * time.Millisecond), nil
+		},
+	}
+
+	cfg := config.RateLimitingConfig{
+		Enabled:     true,
+		FailureMode: "fail_open",
+		Rules: []config.RateLimitRule{
+			{Name: "api", PathPattern: "^/api/", Limit: 10, Window: time.Minute, Burst: 20},
+		},
+	}
+
+	handler := RateLimit(logger, cfg, nil, limiter, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, 20, gotBurst)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"), "sub-second waits round up")
+}
+
+// burstRateLimitService fails the test if a burst rule is checked with Allow.
+type burstRateLimitService struct {
+	mockRateLimitService
+
+	allowBurstFunc func(limit, burst int) (bool, int, time.Time, error)
+}
+
+func (m *burstRateLimitService) Allow(context.Context, string, string, int, time.Duration) (bool, int, time.Time, error) {
+	panic("burst rules must use AllowBurst")
+}
+
+func (m *burstRateLimitService) AllowBurst(_ context.Context, _, _ string, limit, burst int, _ time.Duration) (bool, int, time.Time, error) {
+	return m.allowBurstFunc(limit, burst)
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/readonly"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// readOnlyRetryAfter is the Retry-After hint, in seconds, sent while read-only.
+const readOnlyRetryAfter = "60"
+
+// ReadOnly returns a middleware that rejects mutating requests with 503 while
+// read-only mode is active: admin writes, token issuance, terms acceptance,
+// gas profiler simulations and proxied writes alike. Safe methods and CORS
+// preflights always pass, as do the "METHOD /path" routes in allow, which
+// should only name the route that turns read-only mode off.
+func ReadOnly(mode *readonly.Mode, allow []string, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if slices.Contains(allow, r.Method+" "+r.URL.Path) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			message, enabled := mode.Enabled()
+			if !enabled {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+				"method": r.Method,
+				"path":   r.URL.Path,
+			}).Debug("Rejected request in read-only mode")
+
+			w.Header().Set("Retry-After", readOnlyRetryAfter)
+
+			httperr.New(http.StatusServiceUnavailable, httperr.CodeReadOnly, "read only").
+				With("message", message).
+				Write(w, r)
+		})
+	}
+}
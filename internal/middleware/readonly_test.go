@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/readonly"
+)
+
+func TestReadOnly(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	newHandler := func(enabled bool) http.Handler {
+		cfg := config.ReadOnlyConfig{Enabled: enabled, Message: "migrating"}
+		require.NoError(t, cfg.Validate())
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		return ReadOnly(readonly.New(logger, cfg, nil), []string{"DELETE /admin/v1/read-only"}, logger)(next)
+	}
+
+	tests := []struct {
+		name           string
+		enabled        bool
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "write allowed when off", enabled: false, method: http.MethodPost, path: "/admin/v1/denylist", expectedStatus: http.StatusOK},
+		{name: "admin write rejected when on", enabled: true, method: http.MethodPost, path: "/admin/v1/denylist", expectedStatus: http.StatusServiceUnavailable},
+		{name: "maintenance rejected when on", enabled: true, method: http.MethodPut, path: "/admin/v1/maintenance", expectedStatus: http.StatusServiceUnavailable},
+		{name: "terms acceptance rejected when on", enabled: true, method: http.MethodPost, path: "/api/v1/terms/accept", expectedStatus: http.StatusServiceUnavailable},
+		{name: "reads allowed when on", enabled: true, method: http.MethodGet, path: "/admin/v1/denylist", expectedStatus: http.StatusOK},
+		{name: "preflight allowed when on", enabled: true, method: http.MethodOptions, path: "/api/v1/terms/accept", expectedStatus: http.StatusOK},
+		{name: "turning read-only off allowed when on", enabled: true, method: http.MethodDelete, path: "/admin/v1/read-only", expectedStatus: http.StatusOK},
+		{name: "turning read-only on rejected when on", enabled: true, method: http.MethodPut, path: "/admin/v1/read-only", expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newHandler(tt.enabled).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Contains(t, rec.Body.String(), "migrating")
+				assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"runtime/debug"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
 )
 
 // Recovery returns middleware that recovers from panics.
@@ -15,7 +18,7 @@ func Recovery(logger logrus.FieldLogger) func(http.Handler) http.Handler {
 			defer func() {
 				if err := recover(); err != nil {
 					// Log the panic with stack trace
-					logger.WithFields(logrus.Fields{
+					requestid.Logger(r.Context(), logger).WithFields(logrus.Fields{
 						"error":       fmt.Sprintf("%v", err),
 						"stack":       string(debug.Stack()),
 						"method":      r.Method,
@@ -24,7 +27,7 @@ func Recovery(logger logrus.FieldLogger) func(http.Handler) http.Handler {
 					}).Error("Panic recovered")
 
 					// Return 500 Internal Server Error
-					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					httperr.Write(w, r, http.StatusInternalServerError, httperr.CodeInternal, "Internal Server Error")
 				}
 			}()
 
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/schema"
+)
+
+// SchemaMismatchesTotal counts responses that did not match their OpenAPI schema.
+var SchemaMismatchesTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "schema_validation_mismatches_total",
+		Help: "Total number of responses that did not match their OpenAPI response schema",
+	},
+	[]string{"route"},
+)
+
+// schemaRecorder passes the response through while keeping a copy of the
+// body, up to a limit, for validation once the handler returns.
+type schemaRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+	limit      int64
+	overflow   bool
+}
+
+func (sr *schemaRecorder) WriteHeader(code int) {
+	sr.statusCode = code
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+func (sr *schemaRecorder) Write(b []byte) (int, error) {
+	if !sr.overflow {
+		if int64(sr.body.Len()+len(b)) > sr.limit {
+			sr.overflow = true
+			sr.body.Reset()
+		} else {
+			sr.body.Write(b)
+		}
+	}
+
+	return sr.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController,
+// so WebSocket upgrades and flushes still work.
+func (sr *schemaRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// SchemaValidation returns dev-mode middleware that validates JSON responses
+// against the validator's OpenAPI specs and logs mismatches. Responses are
+// never modified; bodies larger than maxBodyBytes are not validated.
+func SchemaValidation(
+	validator *schema.Validator,
+	maxBodyBytes int64,
+	log logrus.FieldLogger,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &schemaRecorder{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+				limit:          maxBodyBytes,
+			}
+
+			next.ServeHTTP(rec, r)
+
+			if rec.overflow || rec.body.Len() == 0 {
+				return
+			}
+
+			header := rec.Header()
+
+			mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+			if !strings.Contains(mediaType, "json") {
+				return
+			}
+
+			body, err := decodeBody(header.Get("Content-Encoding"), rec.body.Bytes())
+			if err != nil {
+				log.WithError(err).WithField("path", r.URL.Path).Debug("Skipping schema validation")
+
+				return
+			}
+
+			result, ok := validator.Validate(r.Method, r.URL.Path, rec.statusCode, body)
+			if !ok || len(result.Errors) == 0 {
+				return
+			}
+
+			SchemaMismatchesTotal.WithLabelValues(r.Method + " " + result.Route).Inc()
+
+			log.WithFields(logrus.Fields{
+				"method": r.Method,
+				"path":   r.URL.Path,
+				"status": rec.statusCode,
+				"spec":   result.Spec,
+				"route":  result.Route,
+				"errors": result.Errors,
+			}).Warn("Response does not match OpenAPI schema")
+		})
+	}
+}
+
+// decodeBody undoes gzip content encoding, which proxied responses may carry.
+func decodeBody(encoding string, body []byte) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "", "identity":
+		return body, nil
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return io.ReadAll(reader)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %s", encoding)
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/schema"
+)
+
+const testBoundsSpec = `
+openapi: 3.0.3
+paths:
+  /{network}/bounds:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties:
+                  type: object
+                  required: [min, max]
+                  properties:
+                    min: {type: integer}
+                    max: {type: integer}
+`
+
+func TestSchemaValidation(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	path := filepath.Join(t.TempDir(), "lab.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testBoundsSpec), 0o600))
+
+	validator, err := schema.NewValidator([]config.SchemaSpec{{Path: path, BasePath: "/api/v1"}})
+	require.NoError(t, err)
+
+	mismatches := SchemaMismatchesTotal.WithLabelValues("GET /{network}/bounds")
+
+	tests := []struct {
+		name           string
+		body           string
+		gzip           bool
+		maxBodyBytes   int64
+		wantMismatches float64
+	}{
+		{name: "valid response", body: `{"fct_block":{"min":1,"max":2}}`, maxBodyBytes: 1024},
+		{name: "mismatch is counted", body: `{"fct_block":{"min":1}}`, maxBodyBytes: 1024, wantMismatches: 1},
+		{name: "gzip bodies are decoded", body: `{"fct_block":{"min":"1","max":2}}`, gzip: true, maxBodyBytes: 1024, wantMismatches: 1},
+		{name: "oversized bodies are skipped", body: `{"fct_block":{"min":1}}`, maxBodyBytes: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := []byte(tt.body)
+
+			if tt.gzip {
+				var buf bytes.Buffer
+
+				gz := gzip.NewWriter(&buf)
+				_, _ = gz.Write(payload)
+				require.NoError(t, gz.Close())
+
+				payload = buf.Bytes()
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+				if tt.gzip {
+					w.Header().Set("Content-Encoding", "gzip")
+				}
+
+				_, _ = w.Write(payload)
+			})
+
+			before := testutil.ToFloat64(mismatches)
+
+			rec := httptest.NewRecorder()
+			SchemaValidation(validator, tt.maxBodyBytes, logger)(next).ServeHTTP(
+				rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/bounds", http.NoBody),
+			)
+
+			// The response always passes through untouched
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, payload, rec.Body.Bytes())
+			assert.InDelta(t, tt.wantMismatches, testutil.ToFloat64(mismatches)-before, 0)
+		})
+	}
+}
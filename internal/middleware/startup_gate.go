@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/startup"
+)
+
+// StartupGate returns a middleware that answers 503 with Retry-After until every
+// named dependency has loaded its first snapshot. CORS preflights always pass.
+func StartupGate(gate *startup.Gate, log logrus.FieldLogger, dependencies ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			pending := gate.Pending(r.Context(), dependencies...)
+			if len(pending) == 0 {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+				"path":        r.URL.Path,
+				"waiting_for": pending,
+			}).Debug("Rejected request during warm-up")
+
+			retryAfter := int(gate.RetryAfter().Seconds())
+
+			w.Header().Set("Cache-Control", "no-store")
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+			httperr.New(http.StatusServiceUnavailable, httperr.CodeWarmingUp, "service is warming up, please retry shortly").
+				With("waiting_for", pending).
+				With("retry_after_seconds", retryAfter).
+				Write(w, r)
+		})
+	}
+}
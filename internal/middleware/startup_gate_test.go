@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/startup"
+)
+
+func TestStartupGate(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	newHandler := func(ready bool) http.Handler {
+		cfg := config.StartupGateConfig{}
+		require.NoError(t, cfg.Validate())
+
+		gate := startup.New(logger, cfg, startup.Dependency{
+			Name:  startup.Bounds,
+			Ready: func(context.Context) bool { return ready },
+		})
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		return StartupGate(gate, logger, startup.Bounds)(next)
+	}
+
+	tests := []struct {
+		name           string
+		ready          bool
+		method         string
+		expectedStatus int
+	}{
+		{name: "served once warm", ready: true, method: http.MethodGet, expectedStatus: http.StatusOK},
+		{name: "rejected while warming", ready: false, method: http.MethodGet, expectedStatus: http.StatusServiceUnavailable},
+		{name: "preflight allowed while warming", ready: false, method: http.MethodOptions, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newHandler(tt.ready).ServeHTTP(rec, httptest.NewRequest(tt.method, "/api/v1/mainnet/bounds", http.NoBody))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus != http.StatusServiceUnavailable {
+				return
+			}
+
+			assert.Equal(t, "5", rec.Header().Get("Retry-After"))
+
+			var body struct {
+				Code              string   `json:"code"`
+				WaitingFor        []string `json:"waiting_for"`
+				RetryAfterSeconds int      `json:"retry_after_seconds"`
+			}
+
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+			assert.Equal(t, "warming_up", body.Code)
+			assert.Equal(t, []string{startup.Bounds}, body.WaitingFor)
+			assert.Equal(t, 5, body.RetryAfterSeconds)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+)
+
+// Tenancy returns middleware that selects the tenant of each request by its
+// path prefix or Host header and stores it in the request context. A tenant's
+// path prefix is removed, so routes further in see the same paths for every
+// tenant. Requests matching no tenant are served as the default lab.
+func Tenancy(resolver *tenancy.Resolver, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant, path := resolver.Resolve(r)
+			if tenant == nil {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			log.WithFields(logrus.Fields{
+				"tenant": tenant.Name,
+				"path":   r.URL.Path,
+			}).Debug("Resolved tenant")
+
+			r2 := r.Clone(tenancy.ContextWithTenant(r.Context(), tenant))
+			if path != r.URL.Path {
+				r2.URL.Path = path
+				r2.URL.RawPath = ""
+			}
+
+			next.ServeHTTP(w, r2)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/headers"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+)
+
+func TestTenancy(t *testing.T) {
+	policies := []config.HeaderPolicy{
+		{Name: "default", PathPattern: ".*", Headers: map[string]string{"Cache-Control": "public, max-age=1"}},
+	}
+
+	resolver, err := tenancy.NewResolver([]config.TenantConfig{{
+		Name:       "acme",
+		Hosts:      []string{"lab.acme.io"},
+		PathPrefix: "/acme",
+		Headers: []config.HeaderPolicy{
+			{Name: "acme", PathPattern: "^/api/", Headers: map[string]string{"Cache-Control": "no-store"}},
+		},
+	}}, policies)
+	require.NoError(t, err)
+
+	manager, err := headers.NewManager(policies)
+	require.NoError(t, err)
+
+	var (
+		gotTenant string
+		gotPath   string
+	)
+
+	handler := Tenancy(resolver, logrus.New())(Headers(manager, logrus.New())(
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			gotTenant, gotPath = "", r.URL.Path
+			if tenant := tenancy.FromContext(r.Context()); tenant != nil {
+				gotTenant = tenant.Name
+			}
+		}),
+	))
+
+	serve := func(host, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		req.Host = host
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// The path prefix is removed and the tenant's policies apply first
+	rec := serve("lab.ethpandaops.io", "/acme/api/v1/config")
+	assert.Equal(t, "acme", gotTenant)
+	assert.Equal(t, "/api/v1/config", gotPath)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	rec = serve("lab.acme.io", "/ethereum/slots")
+	assert.Equal(t, "acme", gotTenant)
+	assert.Equal(t, "/ethereum/slots", gotPath)
+	assert.Equal(t, "public, max-age=1", rec.Header().Get("Cache-Control"))
+
+	// Other requests are served as the default lab
+	rec = serve("lab.ethpandaops.io", "/api/v1/config")
+	assert.Empty(t, gotTenant)
+	assert.Equal(t, "/api/v1/config", gotPath)
+	assert.Equal(t, "public, max-age=1", rec.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/terms"
+)
+
+// termsAcceptPath is where clients accept the terms of use.
+const termsAcceptPath = "/api/v1/terms/accept"
+
+// Terms returns a middleware that rejects requests to gated endpoint classes
+// unless the client has accepted the current terms of use.
+func Terms(manager *terms.Manager, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// CORS preflight requests never carry cookies
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			class, gated := manager.Match(r)
+			if !gated || manager.Accepted(r, time.Now()) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			requestid.Logger(r.Context(), log).WithFields(logrus.Fields{
+				"path":  r.URL.Path,
+				"class": class,
+			}).Debug("terms of use not accepted")
+
+			writeTermsError(w, r, manager, class)
+		})
+	}
+}
+
+func writeTermsError(w http.ResponseWriter, r *http.Request, manager *terms.Manager, class string) {
+	problem := httperr.New(http.StatusForbidden, httperr.CodeTermsNotAccepted, "terms of use not accepted").
+		With("class", class).
+		With("version", manager.Version()).
+		With("accept_url", termsAcceptPath)
+
+	if manager.URL() != "" {
+		problem.With("terms_url", manager.URL())
+	}
+
+	problem.Write(w, r)
+}
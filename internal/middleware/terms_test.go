@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/terms"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerms(t *testing.T) {
+	manager, err := terms.NewManager(config.TermsConfig{
+		Enabled: true,
+		Version: "v1",
+		URL:     "https://lab.ethpandaops.io/terms",
+		Secret:  "0123456789abcdef0123456789abcdef",
+		TTL:     time.Hour,
+		Classes: []config.TermsEndpointClass{
+			{Name: "simulations", PathPattern: "^/api/v1/gas-profiler/"},
+		},
+	})
+	require.NoError(t, err)
+
+	token, _ := manager.Issue(time.Now())
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		token         string
+		wantStatus    int
+		handlerCalled bool
+	}{
+		{
+			name:          "ungated path passes",
+			method:        http.MethodGet,
+			path:          "/api/v1/mainnet/fct_block",
+			wantStatus:    http.StatusOK,
+			handlerCalled: true,
+		},
+		{
+			name:          "gated path without acknowledgment is rejected",
+			method:        http.MethodPost,
+			path:          "/api/v1/gas-profiler/mainnet/simulate-block",
+			wantStatus:    http.StatusForbidden,
+			handlerCalled: false,
+		},
+		{
+			name:          "gated path with acknowledgment passes",
+			method:        http.MethodPost,
+			path:          "/api/v1/gas-profiler/mainnet/simulate-block",
+			token:         token,
+			wantStatus:    http.StatusOK,
+			handlerCalled: true,
+		},
+		{
+			name:          "preflight passes",
+			method:        http.MethodOptions,
+			path:          "/api/v1/gas-profiler/mainnet/simulate-block",
+			wantStatus:    http.StatusOK,
+			handlerCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerCalled := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				handlerCalled = true
+
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.token != "" {
+				req.Header.Set(terms.HeaderName, tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			Terms(manager, logrus.New())(handler).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.handlerCalled, handlerCalled)
+
+			if tt.wantStatus == http.StatusForbidden {
+				var body map[string]any
+
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+				assert.Equal(t, "simulations", body["class"])
+				assert.Equal(t, "v1", body["version"])
+				assert.Equal(t, "terms_not_accepted", body["code"])
+				assert.Equal(t, "https://lab.ethpandaops.io/terms", body["terms_url"])
+			}
+		})
+	}
+}
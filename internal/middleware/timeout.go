@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// ErrRequestTimeout is the cause of request contexts canceled because the
+// deadline of their route passed.
+var ErrRequestTimeout = errors.New("request deadline exceeded")
+
+var requestTimeoutsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "http_request_timeouts_total",
+		Help: "Total number of requests whose route deadline passed before they were served, per timeout policy",
+	},
+	[]string{"policy"},
+)
+
+// timeoutPolicy is a compiled request timeout policy.
+type timeoutPolicy struct {
+	name    string
+	pattern *regexp.Regexp
+	timeout time.Duration
+}
+
+// RequestTimeout returns middleware that cancels the context of requests
+// whose path matches a policy once its timeout passes, the first matching
+// policy winning. Requests still unanswered by then get a 504. Upgrade
+// requests, and paths of policies with a zero timeout, get no deadline.
+// Path patterns must have been validated.
+func RequestTimeout(cfg config.RequestTimeoutsConfig, logger logrus.FieldLogger) func(http.Handler) http.Handler {
+	policies := make([]timeoutPolicy, 0, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		policies = append(policies, timeoutPolicy{
+			name:    p.Name,
+			pattern: regexp.MustCompile(p.PathPattern),
+			timeout: p.Timeout,
+		})
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy, ok := matchTimeoutPolicy(policies, r.URL.Path)
+			if !ok || policy.timeout == 0 || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			ctx, cancel := context.WithTimeoutCause(r.Context(), policy.timeout, ErrRequestTimeout)
+			defer cancel()
+
+			tw := &timeoutResponseWriter{ResponseWriter: w}
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			if !errors.Is(context.Cause(ctx), ErrRequestTimeout) {
+				return
+			}
+
+			requestTimeoutsTotal.WithLabelValues(policy.name).Inc()
+
+			requestid.Logger(r.Context(), logger).WithFields(logrus.Fields{
+				"policy":  policy.name,
+				"timeout": policy.timeout,
+				"path":    r.URL.Path,
+			}).Warn("Request deadline exceeded")
+
+			// Answer for handlers that gave up without writing a response
+			if !tw.wroteHeader {
+				httperr.New(http.StatusGatewayTimeout, httperr.CodeRequestTimeout, "request timed out").
+					With("timeout_seconds", policy.timeout.Seconds()).
+					Write(w, r)
+			}
+		})
+	}
+}
+
+// matchTimeoutPolicy returns the first policy matching path.
+func matchTimeoutPolicy(policies []timeoutPolicy, path string) (timeoutPolicy, bool) {
+	for _, p := range policies {
+		if p.pattern.MatchString(path) {
+			return p, true
+		}
+	}
+
+	return timeoutPolicy{}, false
+}
+
+// timeoutResponseWriter records whether the handler started its response.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (tw *timeoutResponseWriter) WriteHeader(code int) {
+	// Informational responses are followed by the final one
+	if code >= 200 || code == http.StatusSwitchingProtocols {
+		tw.wroteHeader = true
+	}
+
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *timeoutResponseWriter) Write(b []byte) (int, error) {
+	tw.wroteHeader = true
+
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (tw *timeoutResponseWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
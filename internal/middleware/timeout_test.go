@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.RequestTimeoutsConfig{Policies: []config.RequestTimeoutPolicy{
+		{Name: "stream", PathPattern: "^/api/v1/stream$", Timeout: 0},
+		{Name: "cheap", PathPattern: "^/api/v1/", Timeout: 20 * time.Millisecond},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	// Waits for the deadline, answering only if write is set
+	newHandler := func(write bool) http.Handler {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Deadline(); !ok {
+				w.WriteHeader(http.StatusOK)
+
+				return
+			}
+
+			<-r.Context().Done()
+
+			if write {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+
+		return RequestTimeout(cfg, logger)(next)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		write          bool
+		expectedStatus int
+	}{
+		{name: "unanswered request gets 504", path: "/api/v1/config", expectedStatus: http.StatusGatewayTimeout},
+		{name: "handler response kept", path: "/api/v1/config", write: true, expectedStatus: http.StatusBadGateway},
+		{name: "zero timeout exempts", path: "/api/v1/stream", expectedStatus: http.StatusOK},
+		{name: "unmatched path has no deadline", path: "/health", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newHandler(tt.write).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus == http.StatusGatewayTimeout {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, httperr.CodeRequestTimeout, body["code"])
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+)
+
+// TraceContext returns middleware that continues the trace from the incoming
+// traceparent header (or starts one) with a server span per request. The
+// sampled trace ID is stored in the request context, so metrics recorded while
+// serving the request can link to the trace via exemplars, and upstream calls
+// made with the request context become child spans.
+func TraceContext() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := tracing.StartSpan(
+				tracing.Extract(r.Context(), r.Header),
+				r.Method,
+				trace.SpanKindServer,
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("request_id", requestid.FromContext(r.Context())),
+			)
+			defer span.End()
+
+			if sc := span.SpanContext(); sc.IsSampled() {
+				ctx = tracing.ContextWithTraceID(ctx, sc.TraceID().String())
+			}
+
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			span.SetAttributes(attribute.Int("http.response.status_code", rw.statusCode))
+
+			if rw.statusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rw.statusCode))
+			}
+		})
+	}
+}
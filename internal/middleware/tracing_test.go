@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// recordSpans installs a tracer provider recording spans in memory for the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return recorder
+}
+
+func TestTraceContext_SpansAndPropagation(t *testing.T) {
+	recorder := recordSpans(t)
+
+	received := make(chan string, 1)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("traceparent")
+	}))
+	defer backend.Close()
+
+	client := &http.Client{Transport: upstream.NewTransport(upstream.SubsystemProxy, nil)}
+
+	var exemplarTraceID string
+
+	handler := TraceContext()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exemplarTraceID = tracing.TraceIDFromContext(r.Context())
+
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, backend.URL, http.NoBody)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody)
+	req.Header.Set("traceparent", testTraceparent)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", exemplarTraceID)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	clientSpan, server := spans[0], spans[1]
+
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, "Error", server.Status().Code.String())
+
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, server.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+
+	// The upstream continues the trace from the client span
+	assert.Equal(t,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-"+clientSpan.SpanContext().SpanID().String()+"-01",
+		<-received,
+	)
+}
+
+func TestTraceContext_UnsampledRequest(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var exemplarTraceID string
+
+	handler := TraceContext()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exemplarTraceID = tracing.TraceIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// The caller's sampling decision is honoured
+	assert.Empty(t, exemplarTraceID)
+	assert.Empty(t, recorder.Ended())
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// VersionHeader carries the backend's build summary on every response, so the
+// frontend notices a backend upgrade on its next request and can prompt a reload.
+const VersionHeader = "X-Lab-Version"
+
+// Version skew headers: clients send the build epoch of the page they loaded,
+// from window.__VERSION__.build_epoch, and are told to reload when it is older
+// than the backend's.
+const (
+	BuildEpochHeader      = "X-Lab-Build-Epoch"
+	RefreshRequiredHeader = "X-Refresh-Required"
+)
+
+// Version returns a middleware that sets VersionHeader to summary, encoded as
+// compact JSON like GET /api/v1/version.
+func Version(summary version.Summary) func(http.Handler) http.Handler {
+	data, _ := json.Marshal(summary)
+	value := string(data)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(VersionHeader, value)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// VersionSkew returns a middleware that sets RefreshRequiredHeader on responses
+// to requests whose BuildEpochHeader is older than epoch, so single-page app
+// sessions loaded before a deploy reload themselves. Missing or malformed
+// epochs are ignored.
+func VersionSkew(epoch int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if value := r.Header.Get(BuildEpochHeader); value != "" {
+				// The signal depends on the header, so caches must not share it
+				w.Header().Add("Vary", BuildEpochHeader)
+
+				if client, err := strconv.ParseInt(value, 10, 64); err == nil && client < epoch {
+					w.Header().Set(RefreshRequiredHeader, "true")
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
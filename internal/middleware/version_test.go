@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+func TestVersion(t *testing.T) {
+	summary := version.NewSummary([]string{"aggregate", "auth"})
+
+	handler := Version(summary)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	for _, path := range []string{"/api/v1/config", "/ethereum/slots"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+		var got version.Summary
+		require.NoError(t, json.Unmarshal([]byte(rec.Header().Get(VersionHeader)), &got), path)
+		assert.Equal(t, summary, got, path)
+	}
+}
+
+func TestVersionSkew(t *testing.T) {
+	handler := VersionSkew(1000)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name    string
+		epoch   string
+		refresh bool
+	}{
+		{name: "no epoch", epoch: "", refresh: false},
+		{name: "older build", epoch: "999", refresh: true},
+		{name: "same build", epoch: "1000", refresh: false},
+		{name: "newer build during a rollout", epoch: "1001", refresh: false},
+		{name: "malformed epoch", epoch: "yesterday", refresh: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/config", http.NoBody)
+			if tt.epoch != "" {
+				req.Header.Set(BuildEpochHeader, tt.epoch)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.refresh, rec.Header().Get(RefreshRequiredHeader) == "true")
+		})
+	}
+}
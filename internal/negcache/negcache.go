@@ -0,0 +1,113 @@
+// Package negcache remembers lookups that found nothing, so repeated requests
+// for unknown networks or missing files are answered without redoing them.
+package negcache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+var hitsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "negative_cache_hits_total",
+		Help: "Lookups answered from the negative cache",
+	},
+	[]string{"cache"},
+)
+
+// Cache is a TTL-bounded set of keys known to be missing. A nil Cache
+// remembers nothing.
+type Cache struct {
+	name       string
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]time.Time // key → expiry
+}
+
+// New creates a negative cache, labelled name in metrics. Returns nil when
+// negative caching is disabled.
+func New(name string, cfg config.NegativeCacheConfig) *Cache {
+	if !cfg.IsEnabled() {
+		return nil
+	}
+
+	return &Cache{
+		name:       name,
+		ttl:        cfg.TTL,
+		maxEntries: cfg.MaxEntries,
+		now:        time.Now,
+		entries:    make(map[string]time.Time),
+	}
+}
+
+// Has reports whether key was added less than a TTL ago.
+func (c *Cache) Has(key string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+
+	if !c.now().Before(expiry) {
+		delete(c.entries, key)
+
+		return false
+	}
+
+	hitsTotal.WithLabelValues(c.name).Inc()
+
+	return true
+}
+
+// Add remembers key as missing for a TTL. When the cache is full, expired
+// entries are dropped first; if none expired, key is not remembered, so a
+// scan of random paths cannot grow the cache without bound.
+func (c *Cache) Add(key string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		for k, expiry := range c.entries {
+			if !now.Before(expiry) {
+				delete(c.entries, k)
+			}
+		}
+
+		if len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+
+	c.entries[key] = now.Add(c.ttl)
+}
+
+// Clear forgets every key, e.g. after the set of known networks changed.
+func (c *Cache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	clear(c.entries)
+	c.mu.Unlock()
+}
@@ -0,0 +1,71 @@
+package negcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func newTestCache(t *testing.T, maxEntries int) (*Cache, *time.Time) {
+	t.Helper()
+
+	cfg := config.NegativeCacheConfig{TTL: time.Minute, MaxEntries: maxEntries}
+	require.NoError(t, cfg.Validate())
+
+	now := time.Unix(1700000000, 0)
+
+	c := New("test", cfg)
+	c.now = func() time.Time { return now }
+
+	return c, &now
+}
+
+func TestCache(t *testing.T) {
+	c, now := newTestCache(t, 10)
+
+	assert.False(t, c.Has("mainnet"))
+
+	c.Add("mainnet")
+	assert.True(t, c.Has("mainnet"))
+
+	// Expires after the TTL
+	*now = now.Add(time.Minute)
+	assert.False(t, c.Has("mainnet"))
+
+	c.Add("sepolia")
+	c.Clear()
+	assert.False(t, c.Has("sepolia"))
+}
+
+func TestCacheMaxEntries(t *testing.T) {
+	c, now := newTestCache(t, 2)
+
+	c.Add("a")
+	c.Add("b")
+	c.Add("c")
+
+	assert.True(t, c.Has("a"))
+	assert.True(t, c.Has("b"))
+	assert.False(t, c.Has("c"), "full cache does not remember new keys")
+
+	// Expired entries make room
+	*now = now.Add(time.Minute)
+	c.Add("c")
+	assert.True(t, c.Has("c"))
+}
+
+func TestCacheDisabled(t *testing.T) {
+	enabled := false
+	c := New("test", config.NegativeCacheConfig{Enabled: &enabled})
+
+	assert.Nil(t, c)
+
+	// A nil cache remembers nothing
+	c.Add("mainnet")
+	assert.False(t, c.Has("mainnet"))
+	c.Clear()
+}
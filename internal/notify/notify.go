@@ -0,0 +1,61 @@
+// Package notify fans out update signals to any number of subscribers.
+package notify
+
+import (
+	"slices"
+	"sync"
+)
+
+// Broadcaster delivers coalesced notifications to every subscriber.
+// Each subscriber channel buffers one pending signal, so Notify never blocks
+// and a slow subscriber sees at most one signal per burst of updates.
+type Broadcaster struct {
+	mu          sync.Mutex
+	subscribers []chan struct{}
+}
+
+// New creates a broadcaster with no subscribers.
+func New() *Broadcaster {
+	return &Broadcaster{}
+}
+
+// Subscribe returns a new channel that receives a signal on every Notify.
+func (b *Broadcaster) Subscribe() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, ch)
+	b.mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe stops delivering signals to ch, a channel returned by Subscribe.
+func (b *Broadcaster) Unsubscribe(ch <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = slices.DeleteFunc(b.subscribers, func(sub chan struct{}) bool {
+		return sub == ch
+	})
+}
+
+// Notify signals all subscribers without blocking.
+// Returns false if every subscriber already had a pending signal.
+func (b *Broadcaster) Notify() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delivered := false
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- struct{}{}:
+			delivered = true
+		default:
+			// Subscriber already has a pending notification, skip
+		}
+	}
+
+	return delivered
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := New()
+
+	// No subscribers, nothing delivered
+	assert.False(t, b.Notify())
+
+	first := b.Subscribe()
+	second := b.Subscribe()
+
+	assert.True(t, b.Notify())
+
+	// Pending signals are coalesced
+	assert.False(t, b.Notify())
+
+	assert.Len(t, first, 1)
+	assert.Len(t, second, 1)
+
+	<-first
+
+	assert.True(t, b.Notify())
+	assert.Len(t, first, 1)
+	assert.Len(t, second, 1)
+}
+
+func TestBroadcaster_Unsubscribe(t *testing.T) {
+	b := New()
+
+	first := b.Subscribe()
+	second := b.Subscribe()
+
+	b.Unsubscribe(first)
+
+	assert.True(t, b.Notify())
+	assert.Empty(t, first)
+	assert.Len(t, second, 1)
+}
@@ -0,0 +1,235 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"runtime/pprof"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/version"
+)
+
+// Profiler periodically collects runtime profiles and pushes them to a
+// Pyroscope-compatible ingest endpoint.
+type Profiler struct {
+	cfg        config.ProfilingConfig
+	log        logrus.FieldLogger
+	httpClient *http.Client
+	labels     map[string]string
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	task *tasks.Task
+}
+
+// New creates a new profiler.
+func New(log logrus.FieldLogger, cfg config.ProfilingConfig) *Profiler {
+	labels := map[string]string{
+		"version": version.Version,
+	}
+
+	maps.Copy(labels, cfg.Labels)
+
+	return &Profiler{
+		cfg:        cfg,
+		log:        log.WithField("component", "profiling"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		labels:     labels,
+		done:       make(chan struct{}),
+	}
+}
+
+// Start starts the background collection loop.
+func (p *Profiler) Start() {
+	p.task = tasks.Default().Register("profiling.upload", p.cfg.UploadInterval)
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		p.task.Supervise(p.log, p.done, p.run)
+	}()
+
+	p.log.WithFields(logrus.Fields{
+		"server_address":  p.cfg.ServerAddress,
+		"profile_types":   p.cfg.ProfileTypes,
+		"upload_interval": p.cfg.UploadInterval,
+	}).Info("Started continuous profiling")
+}
+
+// Stop stops the collection loop. The in-progress CPU profile is discarded.
+func (p *Profiler) Stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+func (p *Profiler) run() {
+	collectCPU := slices.Contains(p.cfg.ProfileTypes, "cpu")
+
+	for {
+		from := time.Now()
+
+		// The CPU profile covers the whole upload interval
+		var cpu bytes.Buffer
+
+		cpuStarted := false
+
+		if collectCPU {
+			if err := pprof.StartCPUProfile(&cpu); err != nil {
+				p.log.WithError(err).Warn("Failed to start CPU profile")
+			} else {
+				cpuStarted = true
+			}
+		}
+
+		select {
+		case <-p.done:
+			if cpuStarted {
+				pprof.StopCPUProfile()
+			}
+
+			return
+		case <-time.After(jitter.Duration(p.cfg.UploadInterval)):
+		}
+
+		if cpuStarted {
+			pprof.StopCPUProfile()
+		}
+
+		until := time.Now()
+
+		p.task.Tick()
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.cfg.UploadInterval)
+
+		for _, profileType := range p.cfg.ProfileTypes {
+			data, err := p.collect(profileType, &cpu, cpuStarted)
+			if err != nil {
+				p.log.WithError(err).WithField("profile_type", profileType).Warn("Failed to collect profile")
+
+				continue
+			}
+
+			if err := p.upload(ctx, profileType, data, from, until); err != nil {
+				p.log.WithError(err).WithField("profile_type", profileType).Warn("Failed to upload profile")
+			}
+		}
+
+		cancel()
+	}
+}
+
+// collect returns the pprof-encoded profile for profileType.
+func (p *Profiler) collect(profileType string, cpu *bytes.Buffer, cpuStarted bool) ([]byte, error) {
+	if profileType == "cpu" {
+		if !cpuStarted {
+			return nil, fmt.Errorf("cpu profile was not started")
+		}
+
+		return cpu.Bytes(), nil
+	}
+
+	profile := pprof.Lookup(profileType)
+	if profile == nil {
+		return nil, fmt.Errorf("unknown profile %q", profileType)
+	}
+
+	var buf bytes.Buffer
+	if err := profile.WriteTo(&buf, 0); err != nil {
+		return nil, fmt.Errorf("write profile: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// upload pushes a single pprof profile to the ingest endpoint.
+func (p *Profiler) upload(ctx context.Context, profileType string, data []byte, from, until time.Time) error {
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile("profile", profileType+".pprof")
+	if err != nil {
+		return fmt.Errorf("create form file: %w", err)
+	}
+
+	if _, err := io.Copy(part, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("write form file: %w", err)
+	}
+
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("close form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.ingestURL(from, until), &body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	if p.cfg.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", p.cfg.TenantID)
+	}
+
+	if p.cfg.BasicAuthUser != "" {
+		req.SetBasicAuth(p.cfg.BasicAuthUser, p.cfg.BasicAuthPassword)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ingestURL builds the Pyroscope ingest URL. Labels are encoded into the
+// application name as name{key=value,...}; profile types come from the pprof data.
+func (p *Profiler) ingestURL(from, until time.Time) string {
+	labels := make([]string, 0, len(p.labels))
+	for _, key := range slices.Sorted(maps.Keys(p.labels)) {
+		labels = append(labels, key+"="+p.labels[key])
+	}
+
+	query := url.Values{}
+	query.Set("name", p.cfg.ApplicationName+"{"+strings.Join(labels, ",")+"}")
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(until.Unix(), 10))
+	query.Set("format", "pprof")
+	query.Set("spyName", "gospy")
+
+	return strings.TrimRight(p.cfg.ServerAddress, "/") + "/ingest?" + query.Encode()
+}
+
+// Label wraps next so CPU samples taken while serving requests carry a
+// component label, making hot paths attributable in profiles.
+func Label(component string, next http.Handler) http.Handler {
+	labels := pprof.Labels("component", component)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pprof.Do(r.Context(), labels, func(ctx context.Context) {
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestProfiler_Upload(t *testing.T) {
+	type received struct {
+		query    map[string]string
+		tenant   string
+		user     string
+		filename string
+		profile  []byte
+	}
+
+	uploads := make(chan received, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("profile")
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+		defer file.Close()
+
+		data, _ := io.ReadAll(file)
+		user, _, _ := r.BasicAuth()
+
+		uploads <- received{
+			query: map[string]string{
+				"name":    r.URL.Query().Get("name"),
+				"from":    r.URL.Query().Get("from"),
+				"until":   r.URL.Query().Get("until"),
+				"format":  r.URL.Query().Get("format"),
+				"spyName": r.URL.Query().Get("spyName"),
+			},
+			tenant:   r.Header.Get("X-Scope-OrgID"),
+			user:     user,
+			filename: header.Filename,
+			profile:  data,
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	p := New(logger, config.ProfilingConfig{
+		Enabled:           true,
+		ServerAddress:     server.URL + "/",
+		ApplicationName:   "lab-backend",
+		Labels:            map[string]string{"env": "test"},
+		TenantID:          "ethpandaops",
+		BasicAuthUser:     "user",
+		BasicAuthPassword: "pass",
+	})
+
+	data, err := p.collect("heap", &bytes.Buffer{}, false)
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+
+	from := time.Unix(1700000000, 0)
+	until := from.Add(15 * time.Second)
+
+	require.NoError(t, p.upload(context.Background(), "heap", data, from, until))
+
+	got := <-uploads
+	assert.Equal(t, "lab-backend{env=test,version=dev}", got.query["name"])
+	assert.Equal(t, "1700000000", got.query["from"])
+	assert.Equal(t, "1700000015", got.query["until"])
+	assert.Equal(t, "pprof", got.query["format"])
+	assert.Equal(t, "gospy", got.query["spyName"])
+	assert.Equal(t, "ethpandaops", got.tenant)
+	assert.Equal(t, "user", got.user)
+	assert.Equal(t, "heap.pprof", got.filename)
+	assert.Equal(t, data, got.profile)
+}
+
+func TestProfiler_CollectCPUNotStarted(t *testing.T) {
+	p := New(logrus.New(), config.ProfilingConfig{})
+
+	_, err := p.collect("cpu", &bytes.Buffer{}, false)
+	require.Error(t, err)
+}
@@ -0,0 +1,648 @@
+//nolint:tagliatelle // superior snake-case yo.
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httputil"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+// canaryTimeout bounds the Redis calls of reading and recording a decision.
+const canaryTimeout = 5 * time.Second
+
+// Canary decisions.
+const (
+	CanaryPromote  = "promote"  // The new target URL replaces the current one
+	CanaryRollback = "rollback" // The current target URL stays, the new one is not trialled again
+)
+
+// Traffic tracks of a canary.
+const (
+	canaryTrackStable = "stable"
+	canaryTrackCanary = "canary"
+)
+
+// ErrNoCanary is returned when promoting or rolling back a network without a
+// canary.
+var ErrNoCanary = errors.New("no canary for network")
+
+var (
+	canaryRequestsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_canary_requests_total",
+			Help: "Total number of requests served while a new target URL is trialled, per network, track (stable or canary) and result (success or error)",
+		},
+		[]string{"network", "track", "result"},
+	)
+
+	canaryRequestDuration = metrics.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "proxy_canary_request_duration_seconds",
+			Help:    "Duration of requests served while a new target URL is trialled, per network and track (stable or canary)",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"network", "track"},
+	)
+)
+
+// Canary is a network's new target URL trialled next to the current one,
+// with the requests each served since the canary started. In shadow mode the
+// canary track counts the copied reads.
+type Canary struct {
+	Network   string      `json:"network"`
+	StableURL string      `json:"stable_url"`
+	CanaryURL string      `json:"canary_url"`
+	Mode      string      `json:"mode"`
+	Percent   int         `json:"percent,omitempty"` // Share of requests sent to the canary, split mode only
+	StartedAt time.Time   `json:"started_at"`
+	Stable    CanaryStats `json:"stable"`
+	Canary    CanaryStats `json:"canary"`
+}
+
+// CanaryStats summarizes the requests of one track. Errors are 5xx responses
+// and requests that ran out of time before any response.
+type CanaryStats struct {
+	Requests      int64   `json:"requests"`
+	Errors        int64   `json:"errors"`
+	ErrorRate     float64 `json:"error_rate"`
+	MeanLatencyMs float64 `json:"mean_latency_ms"`
+}
+
+// canaryDecision is a promote or rollback of one canary URL, kept in Redis so
+// every instance applies it.
+type canaryDecision struct {
+	Action    string    `json:"action"`
+	URL       string    `json:"url"`
+	DecidedAt time.Time `json:"decided_at"`
+
+	expires time.Time // Local copies only
+}
+
+// canary is the proxy of a network's new target URL and its traffic stats.
+type canary struct {
+	network   config.NetworkConfig // Desired config, with the new target URL
+	stableURL string
+	proxy     *httputil.ReverseProxy
+	timeout   time.Duration // Bounds shadow requests, which have no client to wait for
+	startedAt time.Time
+
+	stable    *canaryTrack
+	candidate *canaryTrack
+}
+
+// canaryTrack counts the requests of one side of a canary.
+type canaryTrack struct {
+	requests atomic.Int64
+	errors   atomic.Int64
+	nanos    atomic.Int64
+}
+
+func (t *canaryTrack) stats() CanaryStats {
+	stats := CanaryStats{Requests: t.requests.Load(), Errors: t.errors.Load()}
+
+	if stats.Requests > 0 {
+		stats.ErrorRate = float64(stats.Errors) / float64(stats.Requests)
+		stats.MeanLatencyMs = float64(t.nanos.Load()) / float64(stats.Requests) / float64(time.Millisecond)
+	}
+
+	return stats
+}
+
+// canaries holds the canary of every network whose target URL changed, and
+// the promote and rollback decisions made for them.
+type canaries struct {
+	cfg   config.CanaryConfig
+	redis redis.Client // nil keeps decisions on this instance
+	log   logrus.FieldLogger
+
+	shadowSlots chan struct{}
+
+	mu        sync.RWMutex
+	active    map[string]*canary        // network → canary
+	decisions map[string]canaryDecision // network → decision, for when Redis is unavailable
+
+	task   *tasks.Task
+	cancel context.CancelFunc
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newCanaries returns nil when canary mode is disabled. Without a Redis
+// client, decisions only apply to this instance.
+func newCanaries(log logrus.FieldLogger, cfg config.CanaryConfig, redisClient redis.Client) *canaries {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &canaries{
+		cfg:         cfg,
+		redis:       redisClient,
+		log:         log.WithField("component", "canaries"),
+		shadowSlots: make(chan struct{}, cfg.MaxShadowInFlight),
+		active:      make(map[string]*canary),
+		decisions:   make(map[string]canaryDecision),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start polls Redis for decisions made on other instances and hands those
+// for active canaries to apply, until Stop is called or ctx is canceled.
+// A no-op without Redis.
+func (m *canaries) Start(ctx context.Context, apply func(network string, c *canary, d canaryDecision)) {
+	if m == nil || m.redis == nil {
+		return
+	}
+
+	m.task = tasks.Default().Register("proxy.canary_decisions", m.cfg.PollInterval)
+
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	m.wg.Go(func() {
+		m.task.Supervise(m.log, m.done, func() { m.runPollLoop(ctx, apply) })
+	})
+}
+
+// Stop stops polling for decisions.
+func (m *canaries) Stop() {
+	if m == nil || m.task == nil {
+		return
+	}
+
+	m.cancel()
+	close(m.done)
+	m.wg.Wait()
+
+	tasks.Default().Unregister(m.task)
+}
+
+func (m *canaries) runPollLoop(ctx context.Context, apply func(network string, c *canary, d canaryDecision)) {
+	ticker := jitter.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = m.task.Run(func() error {
+				for network, c := range m.snapshot() {
+					if d, ok := m.decision(ctx, network, c.network.TargetURL); ok {
+						apply(network, c, d)
+					}
+				}
+
+				return nil
+			})
+		case <-m.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// get returns the active canary of network, nil if there is none.
+func (m *canaries) get(network string) *canary {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.active[network]
+}
+
+// snapshot returns a copy of the active canaries.
+func (m *canaries) snapshot() map[string]*canary {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return maps.Clone(m.active)
+}
+
+// current reports whether network already trials the target URL of desired
+// next to stableURL with the same settings.
+func (m *canaries) current(desired config.NetworkConfig, stableURL string) bool {
+	c := m.get(desired.Name)
+
+	return c != nil && c.stableURL == stableURL && reflect.DeepEqual(c.network, desired)
+}
+
+// track makes c the canary of its network. Stats carry over when only the
+// network's other settings changed.
+func (m *canaries) track(c *canary) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := c.network.Name
+
+	if existing, ok := m.active[name]; ok && existing.network.TargetURL == c.network.TargetURL && existing.stableURL == c.stableURL {
+		c.startedAt, c.stable, c.candidate = existing.startedAt, existing.stable, existing.candidate
+		m.active[name] = c
+
+		return
+	}
+
+	c.startedAt = time.Now().UTC()
+	c.stable, c.candidate = &canaryTrack{}, &canaryTrack{}
+	m.active[name] = c
+
+	m.log.WithFields(logrus.Fields{
+		"network":    name,
+		"stable_url": c.stableURL,
+		"canary_url": c.network.TargetURL,
+		"mode":       m.cfg.Mode,
+	}).Info("Started canary for new target URL")
+}
+
+// drop ends the canary of network, if any.
+func (m *canaries) drop(network, reason string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.active[network]
+	if !ok {
+		return
+	}
+
+	delete(m.active, network)
+
+	m.log.WithFields(logrus.Fields{
+		"network":    network,
+		"canary_url": c.network.TargetURL,
+		"reason":     reason,
+	}).Info("Ended canary")
+}
+
+// decide records action for the canary URL of network, in Redis when
+// available so every instance applies it.
+func (m *canaries) decide(ctx context.Context, network, action, url string) error {
+	d := canaryDecision{Action: action, URL: url, DecidedAt: time.Now().UTC().Truncate(time.Second)}
+
+	if m.redis != nil {
+		data, err := json.Marshal(d)
+		if err != nil {
+			return fmt.Errorf("failed to marshal canary decision: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, canaryTimeout)
+		defer cancel()
+
+		if err := m.redis.Set(ctx, m.cfg.KeyPrefix+network, string(data), m.cfg.DecisionTTL); err != nil {
+			return fmt.Errorf("failed to store canary decision: %w", err)
+		}
+	}
+
+	d.expires = time.Now().Add(m.cfg.DecisionTTL)
+
+	m.mu.Lock()
+	m.decisions[network] = d
+	m.mu.Unlock()
+
+	m.log.WithFields(logrus.Fields{
+		"network":    network,
+		"canary_url": url,
+		"action":     action,
+	}).Info("Recorded canary decision")
+
+	return nil
+}
+
+// decision returns the decision made for url as the target URL of network.
+// The local copy answers when Redis can't.
+func (m *canaries) decision(ctx context.Context, network, url string) (canaryDecision, bool) {
+	if m.redis != nil {
+		ctx, cancel := context.WithTimeout(ctx, canaryTimeout)
+		defer cancel()
+
+		data, err := m.redis.Get(ctx, m.cfg.KeyPrefix+network)
+		if err == nil {
+			var d canaryDecision
+			if err := json.Unmarshal([]byte(data), &d); err != nil {
+				m.log.WithError(err).WithField("network", network).Warn("Failed to unmarshal canary decision")
+
+				return canaryDecision{}, false
+			}
+
+			return d, d.URL == url
+		}
+
+		if errors.Is(err, redis.ErrNotFound) {
+			return canaryDecision{}, false
+		}
+
+		m.log.WithError(err).WithField("network", network).Warn("Failed to look up canary decision")
+	}
+
+	m.mu.RLock()
+	d, ok := m.decisions[network]
+	m.mu.RUnlock()
+
+	return d, ok && d.URL == url && time.Now().Before(d.expires)
+}
+
+// forget drops the local decision of a removed network. Decisions in Redis
+// expire on their own.
+func (m *canaries) forget(network string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	delete(m.decisions, network)
+	m.mu.Unlock()
+}
+
+// serve sends r to the canary or the stable proxy per the canary mode, and
+// counts the outcome on its track. In shadow mode, reads are also copied to
+// the canary in the background.
+func (m *canaries) serve(c *canary, w http.ResponseWriter, r *http.Request, stable http.Handler) {
+	network := c.network.Name
+
+	if m.cfg.Mode == config.CanaryModeSplit {
+		if rand.IntN(100) < m.cfg.Percent { //nolint:gosec // traffic split, not security sensitive
+			c.candidate.observe(network, canaryTrackCanary, c.proxy, w, r)
+
+			return
+		}
+	} else {
+		m.shadow(c, r)
+	}
+
+	c.stable.observe(network, canaryTrackStable, stable, w, r)
+}
+
+// shadow copies a read to the canary and discards its response. Reads beyond
+// max_shadow_in_flight are not copied.
+func (m *canaries) shadow(c *canary, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return
+	}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		return
+	}
+
+	select {
+	case m.shadowSlots <- struct{}{}:
+	default:
+		return
+	}
+
+	// Outlives the client's request, which may be answered first
+	ctx, cancel := context.WithoutCancel(r.Context()), context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	shadow := r.Clone(ctx)
+
+	go func() {
+		defer func() { <-m.shadowSlots }()
+		defer cancel()
+
+		c.candidate.observe(c.network.Name, canaryTrackCanary, c.proxy, &discardResponseWriter{header: make(http.Header)}, shadow)
+	}()
+}
+
+// observe serves r with h and counts the outcome.
+func (t *canaryTrack) observe(network, track string, h http.Handler, w http.ResponseWriter, r *http.Request) {
+	sw := &canaryStatusWriter{ResponseWriter: w, status: http.StatusOK}
+	start := time.Now()
+
+	h.ServeHTTP(sw, r)
+
+	elapsed := time.Since(start)
+
+	// A request whose deadline passed is answered by the request timeout middleware
+	failed := sw.status >= http.StatusInternalServerError ||
+		(!sw.wroteHeader && errors.Is(r.Context().Err(), context.DeadlineExceeded))
+
+	result := "success"
+	if failed {
+		result = "error"
+
+		t.errors.Add(1)
+	}
+
+	t.requests.Add(1)
+	t.nanos.Add(int64(elapsed))
+
+	canaryRequestsTotal.WithLabelValues(network, track, result).Inc()
+	canaryRequestDuration.WithLabelValues(network, track).Observe(elapsed.Seconds())
+}
+
+// list returns every active canary with its stats, sorted by network.
+func (m *canaries) list() []Canary {
+	if m == nil {
+		return []Canary{}
+	}
+
+	active := m.snapshot()
+	result := make([]Canary, 0, len(active))
+
+	for _, network := range slices.Sorted(maps.Keys(active)) {
+		c := active[network]
+
+		entry := Canary{
+			Network:   network,
+			StableURL: c.stableURL,
+			CanaryURL: c.network.TargetURL,
+			Mode:      m.cfg.Mode,
+			StartedAt: c.startedAt,
+			Stable:    c.stable.stats(),
+			Canary:    c.candidate.stats(),
+		}
+
+		if m.cfg.Mode == config.CanaryModeSplit {
+			entry.Percent = m.cfg.Percent
+		}
+
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+// canaryStatusWriter records the status of a response.
+type canaryStatusWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (w *canaryStatusWriter) WriteHeader(code int) {
+	if !w.wroteHeader && code >= http.StatusOK {
+		w.status = code
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *canaryStatusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *canaryStatusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// discardResponseWriter throws away the response of a shadow request.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header { return w.header }
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+
+func (w *discardResponseWriter) WriteHeader(int) {}
+
+// canaryEligible reports whether a target URL change of network can be
+// trialled. Upstream pools and discovered instances switch over at once.
+func canaryEligible(network config.NetworkConfig) bool {
+	return len(network.TargetURLs) < 2 && network.Discovery == nil
+}
+
+// canaryNetwork returns network as the stable proxy should serve it. A
+// changed target URL starts a canary and the stable proxy keeps currentURL,
+// until the new URL is promoted or rolled back.
+func (p *Proxy) canaryNetwork(network config.NetworkConfig, currentURL string, exists bool) config.NetworkConfig {
+	if p.canaries == nil {
+		return network
+	}
+
+	if !exists || !canaryEligible(network) {
+		p.canaries.drop(network.Name, "not eligible")
+
+		return network
+	}
+
+	if network.TargetURL == currentURL {
+		p.canaries.drop(network.Name, "target URL reverted")
+
+		return network
+	}
+
+	if d, ok := p.canaries.decision(p.ctx, network.Name, network.TargetURL); ok {
+		p.canaries.drop(network.Name, d.Action)
+
+		if d.Action == CanaryRollback {
+			network.TargetURL = currentURL
+		}
+
+		return network
+	}
+
+	if p.canaries.current(network, currentURL) {
+		network.TargetURL = currentURL
+
+		return network
+	}
+
+	mapping, err := NewPathMapping(network.PathMapping)
+	if err == nil {
+		var proxy *httputil.ReverseProxy
+
+		timeout := p.upstreamTimeout(network)
+
+		proxy, err = p.createReverseProxy(reverseProxyOptions{
+			targetURL: network.TargetURL,
+			network:   network.Name,
+			mapping:   mapping,
+			auth:      network.Auth,
+			retry:     network.RetryEnabled(p.config.Proxy.Retry.Enabled),
+			timeout:   timeout,
+		})
+		if err == nil {
+			p.canaries.track(&canary{network: network, stableURL: currentURL, proxy: proxy, timeout: timeout})
+
+			network.TargetURL = currentURL
+
+			return network
+		}
+	}
+
+	// Switch over as without canary mode
+	p.logger.WithError(err).WithField("network", network.Name).Warn("Failed to start canary, switching target URL")
+	p.canaries.drop(network.Name, "canary failed")
+
+	return network
+}
+
+// applyCanaryDecision applies a decision made on another instance.
+func (p *Proxy) applyCanaryDecision(network string, c *canary, d canaryDecision) {
+	if d.Action == CanaryRollback {
+		p.canaries.drop(network, CanaryRollback)
+
+		return
+	}
+
+	if err := p.UpdateNetwork(c.network); err != nil {
+		p.logger.WithError(err).WithField("network", network).Error("Failed to promote canary")
+	}
+}
+
+// Canaries returns the active canaries with the requests each track served.
+func (p *Proxy) Canaries() []Canary {
+	return p.canaries.list()
+}
+
+// PromoteCanary switches network over to its canary URL, on every instance.
+func (p *Proxy) PromoteCanary(ctx context.Context, network string) error {
+	c := p.canaries.get(network)
+	if c == nil {
+		return ErrNoCanary
+	}
+
+	if err := p.canaries.decide(ctx, network, CanaryPromote, c.network.TargetURL); err != nil {
+		return err
+	}
+
+	// The recorded decision lets the new target URL through
+	return p.UpdateNetwork(c.network)
+}
+
+// RollbackCanary ends the canary of network and keeps its current target URL
+// on every instance. The canary URL is not trialled again for decision_ttl.
+func (p *Proxy) RollbackCanary(ctx context.Context, network string) error {
+	c := p.canaries.get(network)
+	if c == nil {
+		return ErrNoCanary
+	}
+
+	if err := p.canaries.decide(ctx, network, CanaryRollback, c.network.TargetURL); err != nil {
+		return err
+	}
+
+	p.canaries.drop(network, CanaryRollback)
+
+	return nil
+}
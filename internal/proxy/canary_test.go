@@ -0,0 +1,220 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/discovery"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+// newCanaryTestProxy returns a proxy whose networks come from active, with
+// canary mode configured by cfg.
+func newCanaryTestProxy(
+	t *testing.T,
+	logger logrus.FieldLogger,
+	cfg config.CanaryConfig,
+	redisClient redis.Client,
+	active *map[string]*cartographoor.Network,
+) *Proxy {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	proxyCfg := &config.Config{}
+	proxyCfg.Proxy.Canary = cfg
+	require.NoError(t, proxyCfg.Proxy.Canary.Validate())
+
+	mockProvider := cartomocks.NewMockProvider(ctrl)
+	mockProvider.EXPECT().GetActiveNetworks(gomock.Any()).DoAndReturn(
+		func(_ any) map[string]*cartographoor.Network { return *active },
+	).AnyTimes()
+
+	return &Proxy{
+		config:         proxyCfg,
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		hedgeURLs:      make(map[string]string),
+		resolvers:      make(map[string]*discovery.Resolver),
+		pools:          make(map[string]*upstreamPool),
+		pathMappings:   make(map[string]*config.PathMappingConfig),
+		auths:          make(map[string]*config.UpstreamAuthConfig),
+		retries:        make(map[string]bool),
+		timeouts:       make(map[string]time.Duration),
+		logger:         logger,
+		provider:       mockProvider,
+		ctx:            t.Context(),
+		canaries:       newCanaries(logger, proxyCfg.Proxy.Canary, redisClient),
+	}
+}
+
+// namedBackend answers every request with its name and counts the requests.
+func namedBackend(t *testing.T, name string, hits *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = io.WriteString(w, name)
+	}))
+	t.Cleanup(backend.Close)
+
+	return backend
+}
+
+func TestProxy_CanarySplit(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	redisClient, _ := redistest.New(t, logger)
+
+	var oldHits, newHits, otherHits atomic.Int64
+
+	oldBackend := namedBackend(t, "old", &oldHits)
+	newBackend := namedBackend(t, "new", &newHits)
+	otherBackend := namedBackend(t, "other", &otherHits)
+
+	active := map[string]*cartographoor.Network{
+		"devnet-1": {Name: "devnet-1", TargetURL: oldBackend.URL},
+	}
+
+	cfg := config.CanaryConfig{Enabled: true, Percent: 50}
+	p := newCanaryTestProxy(t, logger, cfg, redisClient, &active)
+	replica := newCanaryTestProxy(t, logger, cfg, redisClient, &active)
+
+	serve := func(p *Proxy) string {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block", http.NoBody))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		return rec.Body.String()
+	}
+
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	require.NoError(t, replica.SyncNetworks(t.Context()))
+	assert.Empty(t, p.Canaries())
+
+	// Cartographoor moves the network, the new URL gets a share of requests
+	active = map[string]*cartographoor.Network{
+		"devnet-1": {Name: "devnet-1", TargetURL: newBackend.URL},
+	}
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	require.NoError(t, replica.SyncNetworks(t.Context()))
+
+	for range 200 {
+		serve(p)
+	}
+
+	assert.Positive(t, oldHits.Load())
+	assert.Positive(t, newHits.Load())
+
+	canaries := p.Canaries()
+	require.Len(t, canaries, 1)
+	assert.Equal(t, oldBackend.URL, canaries[0].StableURL)
+	assert.Equal(t, newBackend.URL, canaries[0].CanaryURL)
+	assert.Equal(t, config.CanaryModeSplit, canaries[0].Mode)
+	assert.Equal(t, int64(200), canaries[0].Stable.Requests+canaries[0].Canary.Requests)
+	assert.Zero(t, canaries[0].Canary.Errors)
+
+	// A later sync keeps the canary and its stats
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	assert.Equal(t, canaries[0].StartedAt, p.Canaries()[0].StartedAt)
+	assert.Equal(t, int64(200), p.Canaries()[0].Stable.Requests+p.Canaries()[0].Canary.Requests)
+
+	// Promoting switches over, the replica follows from Redis
+	require.NoError(t, p.PromoteCanary(t.Context(), "devnet-1"))
+	assert.Empty(t, p.Canaries())
+
+	require.NoError(t, replica.SyncNetworks(t.Context()))
+	assert.Empty(t, replica.Canaries())
+
+	for range 20 {
+		assert.Equal(t, "new", serve(p))
+		assert.Equal(t, "new", serve(replica))
+	}
+
+	// A rolled back URL is not trialled again
+	active = map[string]*cartographoor.Network{
+		"devnet-1": {Name: "devnet-1", TargetURL: otherBackend.URL},
+	}
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	require.Len(t, p.Canaries(), 1)
+	require.NoError(t, p.RollbackCanary(t.Context(), "devnet-1"))
+
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	assert.Empty(t, p.Canaries())
+
+	otherHits.Store(0)
+
+	for range 20 {
+		assert.Equal(t, "new", serve(p))
+	}
+
+	assert.Zero(t, otherHits.Load())
+
+	require.ErrorIs(t, p.PromoteCanary(t.Context(), "devnet-1"), ErrNoCanary)
+}
+
+func TestProxy_CanaryShadow(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	var oldHits, newHits atomic.Int64
+
+	oldBackend := namedBackend(t, "old", &oldHits)
+	newBackend := namedBackend(t, "new", &newHits)
+
+	active := map[string]*cartographoor.Network{
+		"devnet-1": {Name: "devnet-1", TargetURL: oldBackend.URL},
+	}
+
+	p := newCanaryTestProxy(t, logger, config.CanaryConfig{Enabled: true, Mode: config.CanaryModeShadow}, nil, &active)
+	require.NoError(t, p.SyncNetworks(t.Context()))
+
+	active = map[string]*cartographoor.Network{
+		"devnet-1": {Name: "devnet-1", TargetURL: newBackend.URL},
+	}
+	require.NoError(t, p.SyncNetworks(t.Context()))
+
+	// Clients only see the stable backend, reads are copied to the new one
+	for range 10 {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block", http.NoBody))
+		assert.Equal(t, "old", rec.Body.String())
+	}
+
+	assert.Eventually(t, func() bool { return newHits.Load() == 10 }, time.Second, 10*time.Millisecond)
+
+	// Writes are not copied
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/devnet-1/fct_block", http.NoBody))
+	assert.Equal(t, "old", rec.Body.String())
+
+	assert.Eventually(t, func() bool {
+		c := p.Canaries()
+
+		return len(c) == 1 && c[0].Stable.Requests == 11 && c[0].Canary.Requests == 10
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(10), newHits.Load())
+
+	// Without Redis, a rollback applies to this instance
+	require.NoError(t, p.RollbackCanary(t.Context(), "devnet-1"))
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	assert.Empty(t, p.Canaries())
+}
@@ -0,0 +1,343 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+var (
+	upstreamHealthy = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "proxy_upstream_healthy",
+			Help: "Whether an upstream of a network with several target_urls receives traffic (1) or is skipped (0)",
+		},
+		[]string{"network", "upstream"},
+	)
+
+	upstreamFailoversTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_upstream_failovers_total",
+			Help: "Total number of proxied requests retried on another upstream after a connection failure",
+		},
+		[]string{"network"},
+	)
+)
+
+// poolUpstream is one target URL of an upstream pool.
+type poolUpstream struct {
+	raw      string
+	url      *url.URL
+	healthy  atomic.Bool
+	failures atomic.Int64 // Consecutive failed checks and requests
+}
+
+// upstreamPool spreads a network's requests over its target_urls. Upstreams
+// are health checked in the background and skipped once they fail
+// unhealthy_threshold checks or requests in a row; a successful check or
+// request brings them back.
+type upstreamPool struct {
+	log       logrus.FieldLogger
+	cfg       config.FailoverConfig
+	network   string
+	auth      *config.UpstreamAuthConfig
+	client    *http.Client
+	upstreams []*poolUpstream
+	counter   atomic.Uint64
+
+	cancel context.CancelFunc // Aborts in-flight health checks on Stop
+	done   chan struct{}
+	wg     sync.WaitGroup
+	task   *tasks.Task
+}
+
+// newUpstreamPool creates a pool for targetURLs. Upstreams start healthy.
+func newUpstreamPool(
+	log logrus.FieldLogger,
+	cfg config.FailoverConfig,
+	network string,
+	targetURLs []string,
+	auth *config.UpstreamAuthConfig,
+) (*upstreamPool, error) {
+	upstreams := make([]*poolUpstream, 0, len(targetURLs))
+
+	for _, targetURL := range targetURLs {
+		parsed, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target URL %s: %w", targetURL, err)
+		}
+
+		u := &poolUpstream{raw: targetURL, url: parsed}
+		u.healthy.Store(true)
+
+		upstreams = append(upstreams, u)
+	}
+
+	return &upstreamPool{
+		log: log.WithFields(logrus.Fields{
+			"component": "upstream_pool",
+			"network":   network,
+		}),
+		cfg:     cfg,
+		network: network,
+		auth:    auth,
+		client: &http.Client{
+			Timeout:   cfg.HealthTimeout,
+			Transport: upstream.NewTransport(upstream.SubsystemProxy, nil),
+		},
+		upstreams: upstreams,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// Start starts health checking in the background, beginning immediately. It
+// runs until Stop is called or ctx is canceled.
+func (p *upstreamPool) Start(ctx context.Context) {
+	for _, u := range p.upstreams {
+		upstreamHealthy.WithLabelValues(p.network, u.url.Host).Set(1)
+	}
+
+	p.task = tasks.Default().Register("proxy.upstreams."+p.network, p.cfg.HealthInterval)
+
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	p.wg.Go(func() {
+		p.task.Supervise(p.log, p.done, func() { p.runHealthLoop(ctx) })
+	})
+}
+
+// Stop stops health checking.
+func (p *upstreamPool) Stop() {
+	p.cancel()
+	close(p.done)
+	p.wg.Wait()
+
+	tasks.Default().Unregister(p.task)
+
+	for _, u := range p.upstreams {
+		upstreamHealthy.DeleteLabelValues(p.network, u.url.Host)
+	}
+}
+
+// URLs returns the target URLs of the pool, in order.
+func (p *upstreamPool) URLs() []string {
+	urls := make([]string, 0, len(p.upstreams))
+	for _, u := range p.upstreams {
+		urls = append(urls, u.raw)
+	}
+
+	return urls
+}
+
+// order returns the upstreams in the order they should be tried: healthy ones
+// by strategy, then the unhealthy ones as a last resort.
+func (p *upstreamPool) order() []*poolUpstream {
+	healthy := make([]*poolUpstream, 0, len(p.upstreams))
+	unhealthy := make([]*poolUpstream, 0)
+
+	for _, u := range p.upstreams {
+		if u.healthy.Load() {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	if p.cfg.Strategy == config.FailoverStrategyRoundRobin && len(healthy) > 1 {
+		start := int((p.counter.Add(1) - 1) % uint64(len(healthy)))
+		healthy = slices.Concat(healthy[start:], healthy[:start])
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// success records a successful check or request.
+func (p *upstreamPool) success(u *poolUpstream) {
+	u.failures.Store(0)
+
+	if !u.healthy.Swap(true) {
+		upstreamHealthy.WithLabelValues(p.network, u.url.Host).Set(1)
+		p.log.WithField("upstream", u.url.Host).Info("Upstream healthy again")
+	}
+}
+
+// failure records a failed check or request.
+func (p *upstreamPool) failure(u *poolUpstream, err error) {
+	if u.failures.Add(1) < int64(p.cfg.UnhealthyThreshold) {
+		return
+	}
+
+	if u.healthy.Swap(false) {
+		upstreamHealthy.WithLabelValues(p.network, u.url.Host).Set(0)
+		p.log.WithError(err).WithField("upstream", u.url.Host).Warn("Upstream unhealthy, skipping it")
+	}
+}
+
+func (p *upstreamPool) runHealthLoop(ctx context.Context) {
+	ticker := jitter.NewTicker(p.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		_ = p.task.Run(func() error { return p.checkHealth(ctx) })
+
+		select {
+		case <-ticker.C:
+		case <-p.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth checks every upstream once. Returns an error naming the
+// upstreams that failed, if any.
+func (p *upstreamPool) checkHealth(ctx context.Context) error {
+	var failed []string
+
+	for _, u := range p.upstreams {
+		err := p.check(ctx, u)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err != nil {
+			failed = append(failed, u.url.Host)
+			p.failure(u, err)
+
+			continue
+		}
+
+		p.success(u)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("health check failed for %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// check requests the health path on an upstream's host.
+func (p *upstreamPool) check(ctx context.Context, u *poolUpstream) error {
+	healthURL := &url.URL{Scheme: u.url.Scheme, Host: u.url.Host, Path: p.cfg.HealthPath}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	p.auth.Apply(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// failoverTransport sends requests, which Rewrite points at the network's
+// target_url, to the upstream picked by the pool instead. Bodiless reads that
+// fail to connect are retried on the next upstream.
+type failoverTransport struct {
+	base    http.RoundTripper
+	pool    *upstreamPool
+	primary *url.URL
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := (req.Method == http.MethodGet || req.Method == http.MethodHead) &&
+		(req.Body == nil || req.Body == http.NoBody)
+
+	var lastErr error
+
+	for i, u := range t.pool.order() {
+		if i > 0 {
+			if !retryable || req.Context().Err() != nil {
+				break
+			}
+
+			upstreamFailoversTotal.WithLabelValues(t.pool.network).Inc()
+		}
+
+		resp, err := t.base.RoundTrip(t.retarget(req, u))
+		if err == nil {
+			switch resp.StatusCode {
+			case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+				t.pool.failure(u, fmt.Errorf("upstream returned %d", resp.StatusCode))
+			default:
+				t.pool.success(u)
+			}
+
+			return resp, nil
+		}
+
+		// The client left, the upstream is not to blame
+		if req.Context().Err() != nil {
+			return nil, err
+		}
+
+		// A full queue is not a broken upstream, but another may have room
+		if !errors.Is(err, upstream.ErrOverloaded) {
+			t.pool.failure(u, err)
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// retarget returns req sent to u instead of the primary upstream, keeping the
+// path below the target URL's own path.
+func (t *failoverTransport) retarget(req *http.Request, u *poolUpstream) *http.Request {
+	if u.url.Scheme == t.primary.Scheme && u.url.Host == t.primary.Host && u.url.Path == t.primary.Path {
+		return req
+	}
+
+	out := req.Clone(req.Context())
+	out.Host = ""
+	out.URL.Scheme = u.url.Scheme
+	out.URL.Host = u.url.Host
+	out.URL.Path = rebasePath(req.URL.Path, t.primary, u.url)
+	out.URL.RawPath = ""
+
+	return out
+}
+
+// rebasePath moves path from below the from URL's own path to below to's.
+func rebasePath(path string, from, to *url.URL) string {
+	return strings.TrimSuffix(to.Path, "/") + strings.TrimPrefix(path, strings.TrimSuffix(from.Path, "/"))
+}
+
+// poolChanged reports whether the desired target URLs differ from those of
+// the current pool.
+func poolChanged(current *upstreamPool, desired []string) bool {
+	if current == nil || len(desired) < 2 {
+		return (current == nil) != (len(desired) < 2)
+	}
+
+	return !slices.Equal(current.URLs(), desired)
+}
@@ -0,0 +1,168 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestUpstreamPool_Order(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	urls := []string{"http://a", "http://b", "http://c"}
+
+	hosts := func(pool *upstreamPool) []string {
+		var out []string
+		for _, u := range pool.order() {
+			out = append(out, u.url.Host)
+		}
+
+		return out
+	}
+
+	cfg := config.FailoverConfig{UnhealthyThreshold: 2}
+	require.NoError(t, cfg.Validate())
+
+	priority, err := newUpstreamPool(logger, cfg, "mainnet", urls, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b", "c"}, hosts(priority))
+
+	// Skipped after unhealthy_threshold failures, tried last
+	priority.failure(priority.upstreams[0], assert.AnError)
+	assert.Equal(t, []string{"a", "b", "c"}, hosts(priority))
+
+	priority.failure(priority.upstreams[0], assert.AnError)
+	assert.Equal(t, []string{"b", "c", "a"}, hosts(priority))
+
+	priority.success(priority.upstreams[0])
+	assert.Equal(t, []string{"a", "b", "c"}, hosts(priority))
+
+	cfg.Strategy = config.FailoverStrategyRoundRobin
+
+	roundRobin, err := newUpstreamPool(logger, cfg, "mainnet", urls, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b", "c"}, hosts(roundRobin))
+	assert.Equal(t, []string{"b", "c", "a"}, hosts(roundRobin))
+	assert.Equal(t, []string{"c", "a", "b"}, hosts(roundRobin))
+}
+
+func TestProxy_ServeHTTP_Failover(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Closed right away, so connections are refused
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	paths := make(chan string, 1)
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			paths <- r.URL.Path
+		}
+
+		w.Write([]byte(`{"ok":true}`)) //nolint:errcheck // test
+	}))
+	defer up.Close()
+
+	cfg := &config.Config{}
+	cfg.Proxy.Failover.UnhealthyThreshold = 1
+	require.NoError(t, cfg.Proxy.Failover.Validate())
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	p := &Proxy{
+		config:         cfg,
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	defer p.Shutdown() //nolint:errcheck // test
+
+	network := config.NetworkConfig{
+		Name:       "mainnet",
+		TargetURLs: []string{down.URL + "/api/v1", up.URL + "/cbt/api/v1"},
+	}
+	require.NoError(t, network.Validate())
+	require.NoError(t, p.AddNetwork(network))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"ok":true}`, rec.Body.String())
+	assert.Equal(t, "/cbt/api/v1/fct_block", <-paths)
+	assert.False(t, p.pools["mainnet"].upstreams[0].healthy.Load())
+
+	// Pools follow target_urls changes
+	network.TargetURLs = []string{up.URL}
+	network.TargetURL = ""
+	require.NoError(t, network.Validate())
+	require.NoError(t, p.UpdateNetwork(network))
+
+	assert.NotContains(t, p.pools, "mainnet")
+}
+
+func TestFailoverTransport_RetriesReadsOnly(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.FailoverConfig{}
+	require.NoError(t, cfg.Validate())
+
+	pool, err := newUpstreamPool(logger, cfg, "mainnet", []string{"http://a/api", "http://b/api"}, nil)
+	require.NoError(t, err)
+
+	var hosts []string
+
+	transport := &failoverTransport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Host)
+			if req.URL.Host == "a" {
+				return nil, assert.AnError
+			}
+
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		pool:    pool,
+		primary: pool.upstreams[0].url,
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://a/api/fct_block", http.NoBody)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []string{"a", "b"}, hosts)
+
+	hosts = nil
+
+	req = httptest.NewRequest(http.MethodPost, "http://a/api/fct_block", strings.NewReader("{}"))
+	_, err = transport.RoundTrip(req) //nolint:bodyclose // no response on error
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, []string{"a"}, hosts)
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,404 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// Network health states reported by the health prober.
+const (
+	// NetworkHealthy means every backend of the network passed the last probe.
+	NetworkHealthy = "healthy"
+	// NetworkDegraded means some backends or probes failed, but not enough in
+	// a row to stop serving the network.
+	NetworkDegraded = "degraded"
+	// NetworkUnhealthy means unhealthy_threshold probes in a row failed on
+	// every backend. Requests are answered with a 503 until healthy_threshold
+	// probes in a row succeed.
+	NetworkUnhealthy = "unhealthy"
+)
+
+var networkHealthStates = []string{NetworkHealthy, NetworkDegraded, NetworkUnhealthy}
+
+var (
+	networkHealth = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "proxy_network_health",
+			Help: "Probed health of a network's backends, 1 for the current status (healthy, degraded or unhealthy)",
+		},
+		[]string{"network", "status"},
+	)
+
+	healthShortCircuitsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_health_short_circuits_total",
+			Help: "Total number of requests answered with a 503 because the network's backend is unhealthy",
+		},
+		[]string{"network"},
+	)
+)
+
+// NetworkHealth is the probed health of a network's backends.
+type NetworkHealth struct {
+	Status               string    `json:"status"`
+	Since                time.Time `json:"since"`
+	LastProbe            time.Time `json:"last_probe"`
+	ConsecutiveFailures  int       `json:"consecutive_failures"`
+	ConsecutiveSuccesses int       `json:"consecutive_successes"`
+	LastError            string    `json:"last_error,omitempty"`
+}
+
+// probeTarget is a network's backends, as probed.
+type probeTarget struct {
+	urls []string
+	auth *config.UpstreamAuthConfig
+}
+
+// healthProber probes the backends of every network in the background and
+// keeps a status per network. A network whose backends all fail
+// unhealthy_threshold probes in a row becomes unhealthy; it recovers after
+// healthy_threshold successful probes in a row.
+type healthProber struct {
+	log     logrus.FieldLogger
+	cfg     config.HealthProbeConfig
+	client  *http.Client
+	targets func() map[string]probeTarget
+
+	mu     sync.RWMutex
+	status map[string]*NetworkHealth // network → health
+
+	cancel context.CancelFunc // Aborts in-flight probes on Stop
+	done   chan struct{}
+	wg     sync.WaitGroup
+	task   *tasks.Task
+}
+
+// newHealthProber creates a prober for the networks returned by targets.
+// Returns nil when probing is disabled.
+func newHealthProber(
+	log logrus.FieldLogger,
+	cfg config.HealthProbeConfig,
+	targets func() map[string]probeTarget,
+) *healthProber {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &healthProber{
+		log:     log.WithField("component", "health_prober"),
+		cfg:     cfg,
+		targets: targets,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: upstream.NewTransport(upstream.SubsystemProxy, nil),
+		},
+		status: make(map[string]*NetworkHealth),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts probing in the background, beginning immediately. It runs
+// until Stop is called or ctx is canceled.
+func (h *healthProber) Start(ctx context.Context) {
+	if h == nil {
+		return
+	}
+
+	h.task = tasks.Default().Register("proxy.health_probe", h.cfg.Interval)
+
+	ctx, h.cancel = context.WithCancel(ctx)
+
+	h.wg.Go(func() {
+		h.task.Supervise(h.log, h.done, func() { h.runProbeLoop(ctx) })
+	})
+}
+
+// Stop stops probing and clears the status of every network.
+func (h *healthProber) Stop() {
+	if h == nil {
+		return
+	}
+
+	h.cancel()
+	close(h.done)
+	h.wg.Wait()
+
+	tasks.Default().Unregister(h.task)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for network := range h.status {
+		h.forgetLocked(network)
+	}
+}
+
+// Status returns a copy of the health of every probed network.
+func (h *healthProber) Status() map[string]NetworkHealth {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make(map[string]NetworkHealth, len(h.status))
+	for network, health := range h.status {
+		out[network] = *health
+	}
+
+	return out
+}
+
+// Unhealthy reports whether requests to the network should be short-circuited.
+// Networks that were not probed yet are assumed healthy.
+func (h *healthProber) Unhealthy(network string) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	health, ok := h.status[network]
+
+	return ok && health.Status == NetworkUnhealthy
+}
+
+// RetryAfter returns the Retry-After, in seconds, of short-circuited requests:
+// the probe interval rounded up, at least 1.
+func (h *healthProber) RetryAfter() int {
+	return max(1, int(math.Ceil(h.cfg.Interval.Seconds())))
+}
+
+// Forget drops the status of a network, e.g. when its backends change.
+func (h *healthProber) Forget(network string) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.forgetLocked(network)
+}
+
+func (h *healthProber) forgetLocked(network string) {
+	if _, ok := h.status[network]; !ok {
+		return
+	}
+
+	delete(h.status, network)
+
+	for _, status := range networkHealthStates {
+		networkHealth.DeleteLabelValues(network, status)
+	}
+}
+
+func (h *healthProber) runProbeLoop(ctx context.Context) {
+	ticker := jitter.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		_ = h.task.Run(func() error { return h.probeAll(ctx) })
+
+		select {
+		case <-ticker.C:
+		case <-h.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probeAll probes every network concurrently and records the results.
+// Returns an error naming the networks that are not healthy, if any.
+func (h *healthProber) probeAll(ctx context.Context) error {
+	targets := h.targets()
+
+	type result struct {
+		network string
+		failed  int
+		total   int
+		err     error
+	}
+
+	results := make(chan result, len(targets))
+
+	var wg sync.WaitGroup
+
+	for network, target := range targets {
+		wg.Go(func() {
+			failed, err := h.probeNetwork(ctx, target)
+			results <- result{network: network, failed: failed, total: len(target.urls), err: err}
+		})
+	}
+
+	wg.Wait()
+	close(results)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Networks removed since the last round
+	for network := range h.status {
+		if _, ok := targets[network]; !ok {
+			h.forgetLocked(network)
+		}
+	}
+
+	var notHealthy []string
+
+	for res := range results {
+		if status := h.record(res.network, res.failed, res.total, res.err); status != NetworkHealthy {
+			notHealthy = append(notHealthy, res.network+" "+status)
+		}
+	}
+
+	if len(notHealthy) > 0 {
+		return fmt.Errorf("networks not healthy: %s", strings.Join(notHealthy, ", "))
+	}
+
+	return nil
+}
+
+// probeNetwork probes every backend of a network. Returns how many failed,
+// and the errors of the failed probes.
+func (h *healthProber) probeNetwork(ctx context.Context, target probeTarget) (int, error) {
+	var errs []error
+
+	for _, targetURL := range target.urls {
+		if err := h.probe(ctx, targetURL, target.auth); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(errs), errors.Join(errs...)
+}
+
+// probe requests the probe path on a backend's host.
+func (h *healthProber) probe(ctx context.Context, targetURL string, auth *config.UpstreamAuthConfig) error {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return fmt.Errorf("invalid target URL %s: %w", targetURL, err)
+	}
+
+	probeURL := &url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: h.cfg.Path}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	auth.Apply(req)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", parsed.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: probe returned %d", parsed.Host, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// record applies a probe result to a network's status and returns the new
+// status. A probe fails when every backend failed; partial failures degrade
+// the network without counting towards unhealthy_threshold. Must be called
+// with h.mu held.
+func (h *healthProber) record(network string, failed, total int, err error) string {
+	health, ok := h.status[network]
+	if !ok {
+		health = &NetworkHealth{Status: NetworkHealthy, Since: time.Now()}
+		h.status[network] = health
+	}
+
+	previous := health.Status
+	health.LastProbe = time.Now()
+	health.LastError = ""
+
+	if err != nil {
+		health.LastError = err.Error()
+	}
+
+	status := NetworkHealthy
+
+	if total > 0 && failed == total {
+		health.ConsecutiveFailures++
+		health.ConsecutiveSuccesses = 0
+
+		switch {
+		case health.ConsecutiveFailures >= h.cfg.UnhealthyThreshold || previous == NetworkUnhealthy:
+			status = NetworkUnhealthy
+		default:
+			status = NetworkDegraded
+		}
+	} else {
+		health.ConsecutiveFailures = 0
+		health.ConsecutiveSuccesses++
+
+		switch {
+		case previous == NetworkUnhealthy && health.ConsecutiveSuccesses < h.cfg.HealthyThreshold:
+			status = NetworkUnhealthy
+		case failed > 0:
+			status = NetworkDegraded
+		}
+	}
+
+	for _, s := range networkHealthStates {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+
+		networkHealth.WithLabelValues(network, s).Set(value)
+	}
+
+	if status == previous {
+		return status
+	}
+
+	health.Status = status
+	health.Since = health.LastProbe
+
+	log := h.log.WithFields(logrus.Fields{
+		"network": network,
+		"from":    previous,
+		"to":      status,
+	})
+
+	switch status {
+	case NetworkUnhealthy:
+		log.WithError(err).Warn("Network backend unhealthy, short-circuiting requests")
+	case NetworkDegraded:
+		log.WithError(err).Info("Network backend degraded")
+	default:
+		log.Info("Network backend healthy again")
+	}
+
+	return status
+}
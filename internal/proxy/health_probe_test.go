@@ -0,0 +1,145 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestHealthProber_Record(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.HealthProbeConfig{Enabled: true, UnhealthyThreshold: 2, HealthyThreshold: 2}
+	require.NoError(t, cfg.Validate())
+
+	h := newHealthProber(logger, cfg, nil)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	steps := []struct {
+		failed int
+		want   string
+	}{
+		{failed: 0, want: NetworkHealthy},
+		{failed: 1, want: NetworkDegraded}, // One of two backends down
+		{failed: 2, want: NetworkDegraded},
+		{failed: 2, want: NetworkUnhealthy},
+		{failed: 0, want: NetworkUnhealthy}, // Recovering
+		{failed: 2, want: NetworkUnhealthy},
+		{failed: 0, want: NetworkUnhealthy},
+		{failed: 1, want: NetworkDegraded}, // Recovered, one backend still down
+	}
+
+	for i, step := range steps {
+		var err error
+		if step.failed > 0 {
+			err = assert.AnError
+		}
+
+		assert.Equal(t, step.want, h.record("mainnet", step.failed, 2, err), "step %d", i)
+	}
+
+	assert.Equal(t, 2, h.status["mainnet"].ConsecutiveSuccesses)
+}
+
+func TestProxy_ServeHTTP_ShortCircuitsUnhealthy(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	var (
+		up       atomic.Bool
+		requests atomic.Int64
+	)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if r.URL.Path != "/health" {
+			requests.Add(1)
+		}
+
+		w.Write([]byte(`{"ok":true}`)) //nolint:errcheck // test
+	}))
+	defer backend.Close()
+
+	cfg := &config.Config{}
+	cfg.Proxy.HealthProbe = config.HealthProbeConfig{Enabled: true, UnhealthyThreshold: 1, HealthyThreshold: 1}
+	require.NoError(t, cfg.Proxy.HealthProbe.Validate())
+
+	p := &Proxy{
+		config:         cfg,
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+	}
+
+	// Probed by hand, not started
+	p.prober = newHealthProber(logger, cfg.Proxy.HealthProbe, p.probeTargets)
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL + "/api/v1"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.Error(t, p.prober.probeAll(ctx))
+	assert.Equal(t, NetworkUnhealthy, p.NetworkHealth()["mainnet"].Status)
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "15", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "network backend unavailable")
+
+	// Recovers once a probe succeeds again
+	up.Store(true)
+
+	require.NoError(t, p.prober.probeAll(ctx))
+	assert.Equal(t, NetworkHealthy, p.NetworkHealth()["mainnet"].Status)
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(1), requests.Load())
+
+	// Removed networks are forgotten
+	p.RemoveNetwork("mainnet")
+	assert.Empty(t, p.NetworkHealth())
+}
+
+func TestHealthProber_RetryAfter(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     int
+	}{
+		{interval: 15 * time.Second, want: 15},
+		{interval: 1500 * time.Millisecond, want: 2},
+		{interval: 500 * time.Millisecond, want: 1},
+	}
+
+	for _, tt := range tests {
+		prober := &healthProber{cfg: config.HealthProbeConfig{Interval: tt.interval}}
+		assert.Equal(t, tt.want, prober.RetryAfter(), tt.interval)
+	}
+}
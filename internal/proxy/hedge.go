@@ -0,0 +1,382 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+const (
+	// latencyWindowSize is the number of recent primary latencies kept per network.
+	latencyWindowSize = 256
+
+	// minLatencySamples is the number of samples required before the percentile
+	// is trusted. Until then, the configured max delay is used.
+	minLatencySamples = 20
+
+	// maxHedgeTokens caps the hedge budget so idle periods can't bank an
+	// unbounded burst of hedges.
+	maxHedgeTokens = 10.0
+)
+
+var hedgeRequestsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "proxy_hedge_requests_total",
+		Help: "Total number of hedged proxy requests by outcome",
+	},
+	[]string{"network", "outcome"},
+)
+
+// hedgeContextKey marks a request as eligible for hedging.
+type hedgeContextKey struct{}
+
+// withHedging marks the request context as hedge-eligible.
+func withHedging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hedgeContextKey{}, true)
+}
+
+// isHedgeEligible reports whether the request context was marked hedge-eligible.
+func isHedgeEligible(ctx context.Context) bool {
+	eligible, _ := ctx.Value(hedgeContextKey{}).(bool)
+
+	return eligible
+}
+
+// hedgePolicy holds the hedging configuration and the budget shared by all networks.
+// The budget works like a retry budget: every eligible request deposits
+// budget_ratio tokens and every hedge fired withdraws one.
+type hedgePolicy struct {
+	cfg      config.HedgingConfig
+	patterns []*regexp.Regexp
+
+	mu       sync.Mutex
+	tokens   float64
+	inFlight int
+}
+
+// newHedgePolicy compiles the configured path patterns.
+// Returns nil if hedging is disabled.
+func newHedgePolicy(cfg config.HedgingConfig) (*hedgePolicy, error) {
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // nil policy means hedging is disabled.
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(cfg.PathPatterns))
+
+	for _, pattern := range cfg.PathPatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hedging path pattern %q: %w", pattern, err)
+		}
+
+		patterns = append(patterns, compiled)
+	}
+
+	return &hedgePolicy{
+		cfg:      cfg,
+		patterns: patterns,
+	}, nil
+}
+
+// matches reports whether an inbound request is a hedge-eligible read.
+func (h *hedgePolicy) matches(r *http.Request) bool {
+	if h == nil {
+		return false
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if len(h.patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range h.patterns {
+		if pattern.MatchString(r.URL.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deposit credits the budget for one eligible request.
+func (h *hedgePolicy) deposit() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tokens = min(h.tokens+h.cfg.BudgetRatio, maxHedgeTokens)
+}
+
+// acquire reserves budget for a hedge request.
+// Returns false if the ratio budget or in-flight cap is exhausted.
+func (h *hedgePolicy) acquire() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tokens < 1 || h.inFlight >= h.cfg.MaxInFlight {
+		return false
+	}
+
+	h.tokens--
+	h.inFlight++
+
+	return true
+}
+
+// release frees the in-flight slot held by a hedge request.
+func (h *hedgePolicy) release() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.inFlight--
+}
+
+// latencyTracker keeps a rolling window of primary upstream latencies.
+type latencyTracker struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{
+		samples: make([]time.Duration, 0, latencyWindowSize),
+	}
+}
+
+// observe records a primary upstream latency.
+func (l *latencyTracker) observe(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) < latencyWindowSize {
+		l.samples = append(l.samples, d)
+
+		return
+	}
+
+	l.samples[l.next] = d
+	l.next = (l.next + 1) % latencyWindowSize
+}
+
+// delay returns the hedge delay: the configured percentile of recent
+// latencies, clamped to [min_delay, max_delay].
+func (l *latencyTracker) delay(cfg config.HedgingConfig) time.Duration {
+	l.mu.Lock()
+
+	if len(l.samples) < minLatencySamples {
+		l.mu.Unlock()
+
+		return cfg.MaxDelay
+	}
+
+	sorted := slices.Clone(l.samples)
+	l.mu.Unlock()
+
+	slices.Sort(sorted)
+
+	idx := int(float64(len(sorted)-1) * cfg.Percentile)
+
+	return max(cfg.MinDelay, min(sorted[idx], cfg.MaxDelay))
+}
+
+// hedgingTransport is an http.RoundTripper that sends a second request to an
+// alternate backend when the primary is slow, returning the first response.
+type hedgingTransport struct {
+	base      http.RoundTripper
+	primary   *url.URL
+	alternate *url.URL
+	network   string
+	policy    *hedgePolicy
+	latency   *latencyTracker
+}
+
+// newHedgingTransport wraps base with hedging from the primary target towards
+// alternateURL.
+func newHedgingTransport(
+	base http.RoundTripper,
+	primary *url.URL,
+	alternateURL string,
+	network string,
+	policy *hedgePolicy,
+) (*hedgingTransport, error) {
+	alternate, err := url.Parse(alternateURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hedge URL: %w", err)
+	}
+
+	return &hedgingTransport{
+		base:      base,
+		primary:   primary,
+		alternate: alternate,
+		network:   network,
+		policy:    policy,
+		latency:   newLatencyTracker(),
+	}, nil
+}
+
+// attemptResult is the outcome of a single upstream attempt.
+type attemptResult struct {
+	resp   *http.Response
+	err    error
+	hedge  bool
+	cancel context.CancelFunc
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hedgingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only bodiless reads marked eligible by ServeHTTP are hedged.
+	if !isHedgeEligible(req.Context()) || (req.Body != nil && req.Body != http.NoBody) {
+		return t.base.RoundTrip(req)
+	}
+
+	t.policy.deposit()
+
+	results := make(chan attemptResult, 2)
+	start := time.Now()
+
+	primaryCancel := t.launch(req, false, results)
+
+	var hedgeCancel context.CancelFunc
+
+	timer := time.NewTimer(t.latency.delay(t.policy.cfg))
+	defer timer.Stop()
+
+	pending := 1
+
+	select {
+	case res := <-results:
+		// Primary answered before the hedge delay elapsed
+		if res.err == nil {
+			t.latency.observe(time.Since(start))
+		}
+
+		return finishAttempt(res)
+	case <-timer.C:
+	}
+
+	if t.policy.acquire() {
+		hedgeRequestsTotal.WithLabelValues(t.network, "fired").Inc()
+
+		hedgeReq := req.Clone(req.Context())
+		hedgeReq.URL.Scheme = t.alternate.Scheme
+		hedgeReq.URL.Host = t.alternate.Host
+		hedgeReq.URL.Path = rebasePath(req.URL.Path, t.primary, t.alternate)
+		hedgeReq.URL.RawPath = ""
+		hedgeReq.Host = ""
+
+		hedgeCancel = t.launch(hedgeReq, true, results)
+
+		pending++
+	} else {
+		hedgeRequestsTotal.WithLabelValues(t.network, "budget_exhausted").Inc()
+	}
+
+	var winner attemptResult
+
+	for pending > 0 {
+		winner = <-results
+		pending--
+
+		if winner.err == nil || pending == 0 {
+			break
+		}
+
+		// This attempt failed but another is still running, wait for it
+		winner.cancel()
+	}
+
+	// A primary beaten by the hedge took at least this long; recording the
+	// lower bound keeps slow primaries from dropping out of the percentile
+	if winner.err == nil {
+		t.latency.observe(time.Since(start))
+	}
+
+	if winner.hedge && winner.err == nil {
+		hedgeRequestsTotal.WithLabelValues(t.network, "won").Inc()
+	}
+
+	// Cancel the losing attempt and drain it in the background
+	if pending > 0 {
+		if winner.hedge {
+			primaryCancel()
+		} else if hedgeCancel != nil {
+			hedgeCancel()
+		}
+
+		go drainAttempts(results, pending)
+	}
+
+	return finishAttempt(winner)
+}
+
+// launch starts an upstream attempt in its own cancellable context.
+func (t *hedgingTransport) launch(
+	req *http.Request,
+	hedge bool,
+	results chan<- attemptResult,
+) context.CancelFunc {
+	ctx, cancel := context.WithCancel(req.Context())
+
+	go func() {
+		if hedge {
+			defer t.policy.release()
+		}
+
+		resp, err := t.base.RoundTrip(req.WithContext(ctx))
+		results <- attemptResult{resp: resp, err: err, hedge: hedge, cancel: cancel}
+	}()
+
+	return cancel
+}
+
+// finishAttempt ties the attempt's context lifetime to the response body.
+func finishAttempt(res attemptResult) (*http.Response, error) {
+	if res.err != nil {
+		res.cancel()
+
+		return nil, res.err
+	}
+
+	res.resp.Body = &cancelOnCloseBody{ReadCloser: res.resp.Body, cancel: res.cancel}
+
+	return res.resp, nil
+}
+
+// drainAttempts cancels and closes attempts that lost the race.
+func drainAttempts(results <-chan attemptResult, pending int) {
+	for range pending {
+		res := <-results
+		res.cancel()
+
+		if res.resp != nil {
+			_ = res.resp.Body.Close()
+		}
+	}
+}
+
+// cancelOnCloseBody cancels the attempt context once the body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
@@ -0,0 +1,197 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func testHedgingConfig() config.HedgingConfig {
+	return config.HedgingConfig{
+		Enabled:     true,
+		Percentile:  0.95,
+		MinDelay:    10 * time.Millisecond,
+		MaxDelay:    20 * time.Millisecond,
+		BudgetRatio: 1,
+		MaxInFlight: 10,
+	}
+}
+
+func TestHedgePolicy_Matches(t *testing.T) {
+	cfg := testHedgingConfig()
+	cfg.PathPatterns = []string{"^/api/v1/[^/]+/fct_block"}
+
+	policy, err := newHedgePolicy(cfg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected bool
+	}{
+		{name: "matching GET", method: http.MethodGet, path: "/api/v1/mainnet/fct_block", expected: true},
+		{name: "matching HEAD", method: http.MethodHead, path: "/api/v1/mainnet/fct_block", expected: true},
+		{name: "non-matching path", method: http.MethodGet, path: "/api/v1/mainnet/fct_other", expected: false},
+		{name: "POST never hedged", method: http.MethodPost, path: "/api/v1/mainnet/fct_block", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			assert.Equal(t, tt.expected, policy.matches(req))
+		})
+	}
+
+	var disabled *hedgePolicy
+
+	assert.False(t, disabled.matches(httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/x", http.NoBody)))
+}
+
+func TestHedgePolicy_Budget(t *testing.T) {
+	cfg := testHedgingConfig()
+	cfg.BudgetRatio = 0.5
+	cfg.MaxInFlight = 1
+
+	policy, err := newHedgePolicy(cfg)
+	require.NoError(t, err)
+
+	// No budget deposited yet
+	assert.False(t, policy.acquire())
+
+	policy.deposit()
+	assert.False(t, policy.acquire(), "half a token is not enough")
+
+	policy.deposit()
+	assert.True(t, policy.acquire())
+
+	// In-flight cap reached even with budget available
+	policy.deposit()
+	policy.deposit()
+	assert.False(t, policy.acquire())
+
+	policy.release()
+	assert.True(t, policy.acquire())
+}
+
+func TestLatencyTracker_Delay(t *testing.T) {
+	cfg := testHedgingConfig()
+	cfg.MinDelay = 5 * time.Millisecond
+	cfg.MaxDelay = 500 * time.Millisecond
+
+	tracker := newLatencyTracker()
+
+	// Not enough samples falls back to max delay
+	assert.Equal(t, cfg.MaxDelay, tracker.delay(cfg))
+
+	for i := 1; i <= 100; i++ {
+		tracker.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	assert.Equal(t, 95*time.Millisecond, tracker.delay(cfg))
+
+	// Clamped to min delay
+	cfg.MinDelay = 200 * time.Millisecond
+	assert.Equal(t, 200*time.Millisecond, tracker.delay(cfg))
+}
+
+func TestHedgingTransport_HedgeWinsWhenPrimarySlow(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+
+		_, _ = w.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+
+	alternatePaths := make(chan string, 1)
+	alternate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alternatePaths <- r.URL.Path
+
+		_, _ = w.Write([]byte("alternate"))
+	}))
+	defer alternate.Close()
+
+	policy, err := newHedgePolicy(testHedgingConfig())
+	require.NoError(t, err)
+
+	target, err := url.Parse(primary.URL + "/api/v1")
+	require.NoError(t, err)
+
+	// The hedge keeps the path below its own target URL's path
+	transport, err := newHedgingTransport(http.DefaultTransport, target, alternate.URL+"/replica/api/v1", "mainnet", policy)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, primary.URL+"/api/v1/fct_block", http.NoBody)
+	req = req.WithContext(withHedging(req.Context()))
+	req.RequestURI = ""
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "alternate", string(body))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, "/replica/api/v1/fct_block", <-alternatePaths)
+
+	// The beaten primary is still recorded, at the time it was beaten
+	assert.Len(t, transport.latency.samples, 1)
+}
+
+func TestHedgingTransport_PrimaryFastNoHedge(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+
+	alternateHits := make(chan struct{}, 1)
+	alternate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		alternateHits <- struct{}{}
+
+		_, _ = w.Write([]byte("alternate"))
+	}))
+	defer alternate.Close()
+
+	cfg := testHedgingConfig()
+	cfg.MaxDelay = time.Second
+
+	policy, err := newHedgePolicy(cfg)
+	require.NoError(t, err)
+
+	target, err := url.Parse(primary.URL)
+	require.NoError(t, err)
+
+	transport, err := newHedgingTransport(http.DefaultTransport, target, alternate.URL, "mainnet", policy)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, primary.URL+"/api/v1/fct_block", http.NoBody)
+	req = req.WithContext(withHedging(req.Context()))
+	req.RequestURI = ""
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", string(body))
+	assert.Empty(t, alternateHits)
+}
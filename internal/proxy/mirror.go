@@ -0,0 +1,325 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+// Comparison results of sampled mirror responses.
+const (
+	mirrorMatch          = "match"
+	mirrorStatusMismatch = "status_mismatch"
+	mirrorBodyMismatch   = "body_mismatch"
+	mirrorSkipped        = "skipped" // Too large or in an encoding that can't be decoded
+)
+
+var (
+	mirrorRequestsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_mirror_requests_total",
+			Help: "Total number of proxied reads copied to a network's mirror_url, per network and result (success, error or dropped)",
+		},
+		[]string{"network", "result"},
+	)
+
+	mirrorComparisonsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_mirror_comparisons_total",
+			Help: "Total number of sampled mirror responses compared with the primary's, per network and result (match, status_mismatch, body_mismatch or skipped)",
+		},
+		[]string{"network", "result"},
+	)
+)
+
+// mirrorPolicy copies proxied reads to networks' mirror_url and compares a
+// sample of the responses.
+type mirrorPolicy struct {
+	cfg   config.MirroringConfig
+	slots chan struct{}
+	log   logrus.FieldLogger
+}
+
+func newMirrorPolicy(log logrus.FieldLogger, cfg config.MirroringConfig) *mirrorPolicy {
+	return &mirrorPolicy{
+		cfg:   cfg,
+		slots: make(chan struct{}, max(cfg.MaxInFlight, 1)),
+		log:   log.WithField("component", "mirror"),
+	}
+}
+
+// mirror sends a copy of r to mirror in the background. For sampled requests
+// the returned writer records the primary response, which is compared with
+// the mirror's once finish is called after the primary was served. Requests
+// other than reads without a body are not copied.
+func (m *mirrorPolicy) mirror(
+	w http.ResponseWriter,
+	r *http.Request,
+	network string,
+	mirror http.Handler,
+) (http.ResponseWriter, func()) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return w, func() {}
+	}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		return w, func() {}
+	}
+
+	select {
+	case m.slots <- struct{}{}:
+	default:
+		mirrorRequestsTotal.WithLabelValues(network, "dropped").Inc()
+
+		return w, func() {}
+	}
+
+	// Outlives the client's request, which may be answered first
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), m.cfg.Timeout)
+	mirrored := r.Clone(ctx)
+	log := requestid.Logger(r.Context(), m.log).WithFields(logrus.Fields{
+		"network": network,
+		"path":    r.URL.RequestURI(),
+	})
+
+	var primary *captureWriter
+
+	if rand.Float64() < m.cfg.SampleRate { //nolint:gosec // sampling, not security sensitive
+		primary = newCaptureWriter(w, m.cfg.MaxCompareBytes)
+		w = primary
+	}
+
+	primaryDone := make(chan struct{})
+
+	go func() {
+		defer func() { <-m.slots }()
+		defer cancel()
+
+		response := newCaptureWriter(&discardResponseWriter{header: make(http.Header)}, m.cfg.MaxCompareBytes)
+		mirror.ServeHTTP(response, mirrored)
+
+		result := "success"
+		if response.status >= http.StatusInternalServerError || (!response.wroteHeader && ctx.Err() != nil) {
+			result = "error"
+		}
+
+		mirrorRequestsTotal.WithLabelValues(network, result).Inc()
+
+		if primary == nil {
+			return
+		}
+
+		<-primaryDone
+
+		m.compare(log, network, primary, response)
+	}()
+
+	return w, func() { close(primaryDone) }
+}
+
+// compare records whether the mirror answered like the primary and logs
+// where they differ.
+func (m *mirrorPolicy) compare(log logrus.FieldLogger, network string, primary, mirror *captureWriter) {
+	result, diffPath := compareResponses(primary, mirror)
+
+	mirrorComparisonsTotal.WithLabelValues(network, result).Inc()
+
+	if result != mirrorStatusMismatch && result != mirrorBodyMismatch {
+		return
+	}
+
+	fields := logrus.Fields{
+		"result":         result,
+		"primary_status": primary.status,
+		"mirror_status":  mirror.status,
+		"primary_bytes":  primary.body.Len(),
+		"mirror_bytes":   mirror.body.Len(),
+	}
+
+	if diffPath != "" {
+		fields["diff_path"] = diffPath
+	}
+
+	log.WithFields(fields).Warn("Mirror response differs from primary")
+}
+
+// compareResponses compares the status and body of two responses. JSON
+// bodies are compared by value, and the path of the first difference is
+// returned.
+func compareResponses(primary, mirror *captureWriter) (string, string) {
+	if primary.status != mirror.status {
+		return mirrorStatusMismatch, ""
+	}
+
+	if primary.truncated || mirror.truncated {
+		return mirrorSkipped, ""
+	}
+
+	primaryBody, ok := decodeBody(primary.body.Bytes(), primary.encoding)
+	if !ok {
+		return mirrorSkipped, ""
+	}
+
+	mirrorBody, ok := decodeBody(mirror.body.Bytes(), mirror.encoding)
+	if !ok {
+		return mirrorSkipped, ""
+	}
+
+	var primaryJSON, mirrorJSON any
+
+	if json.Unmarshal(primaryBody, &primaryJSON) == nil && json.Unmarshal(mirrorBody, &mirrorJSON) == nil {
+		if path := jsonDiff(primaryJSON, mirrorJSON, "$"); path != "" {
+			return mirrorBodyMismatch, path
+		}
+
+		return mirrorMatch, ""
+	}
+
+	if !bytes.Equal(primaryBody, mirrorBody) {
+		return mirrorBodyMismatch, ""
+	}
+
+	return mirrorMatch, ""
+}
+
+// decodeBody returns body without its content coding, false for codings
+// other than gzip.
+func decodeBody(body []byte, encoding string) ([]byte, bool) {
+	switch encoding {
+	case "", "identity":
+		return body, true
+	case "gzip":
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, false
+		}
+
+		decoded, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, false
+		}
+
+		return decoded, true
+	default:
+		return nil, false
+	}
+}
+
+// jsonDiff returns the path of the first difference between two decoded JSON
+// values, empty when they are equal.
+func jsonDiff(a, b any, path string) string {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return path
+		}
+
+		keys := slices.Collect(maps.Keys(av))
+		keys = slices.AppendSeq(keys, maps.Keys(bv))
+		slices.Sort(keys)
+
+		for _, key := range slices.Compact(keys) {
+			_, inA := av[key]
+			_, inB := bv[key]
+
+			if !inA || !inB {
+				return path + "." + key
+			}
+
+			if diff := jsonDiff(av[key], bv[key], path+"."+key); diff != "" {
+				return diff
+			}
+		}
+
+		return ""
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			return path
+		}
+
+		for i := range min(len(av), len(bv)) {
+			if diff := jsonDiff(av[i], bv[i], path+"["+strconv.Itoa(i)+"]"); diff != "" {
+				return diff
+			}
+		}
+
+		if len(av) != len(bv) {
+			return path + "[" + strconv.Itoa(min(len(av), len(bv))) + "]"
+		}
+
+		return ""
+	default:
+		if !reflect.DeepEqual(a, b) {
+			return path
+		}
+
+		return ""
+	}
+}
+
+// captureWriter records the status, content coding and, up to a limit, the
+// body of a response while passing it on.
+type captureWriter struct {
+	http.ResponseWriter
+
+	limit       int64
+	status      int
+	encoding    string
+	wroteHeader bool
+	body        bytes.Buffer
+	truncated   bool // The body exceeded limit and was not kept
+}
+
+func newCaptureWriter(w http.ResponseWriter, limit int64) *captureWriter {
+	return &captureWriter{ResponseWriter: w, limit: limit, status: http.StatusOK}
+}
+
+func (w *captureWriter) WriteHeader(code int) {
+	if !w.wroteHeader && code >= http.StatusOK {
+		w.status = code
+		w.encoding = w.Header().Get("Content-Encoding")
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.encoding = w.Header().Get("Content-Encoding")
+		w.wroteHeader = true
+	}
+
+	if !w.truncated {
+		if int64(w.body.Len()+len(b)) > w.limit {
+			w.truncated = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,190 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestProxy_Mirror(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":[{"slot":1,"root":"0xaa"}]}`)
+	}))
+	defer primary.Close()
+
+	var mirrored atomic.Int64
+
+	mirrorBackend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrored.Add(1)
+		assert.Equal(t, "/api/v1/fct_block", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":[{"root":"0xbb","slot":1}]}`)
+	}))
+	defer mirrorBackend.Close()
+
+	cfg := &config.Config{}
+	cfg.Proxy.Mirroring.SampleRate = 1
+	require.NoError(t, cfg.Proxy.Mirroring.Validate())
+
+	p := &Proxy{
+		config:         cfg,
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		hedgeURLs:      make(map[string]string),
+		mirrors:        make(map[string]*httputil.ReverseProxy),
+		mirrorURLs:     make(map[string]string),
+		logger:         logger,
+		mirroring:      newMirrorPolicy(logger, cfg.Proxy.Mirroring),
+	}
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "devnet-1", TargetURL: primary.URL, MirrorURL: mirrorBackend.URL}))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block?slot_eq=1", http.NoBody))
+	assert.JSONEq(t, `{"data":[{"slot":1,"root":"0xaa"}]}`, rec.Body.String())
+
+	// The difference is logged with its path once both responses are in
+	require.Eventually(t, func() bool {
+		for _, entry := range hook.AllEntries() {
+			if entry.Message == "Mirror response differs from primary" {
+				return entry.Data["diff_path"] == "$.data[0].root" &&
+					entry.Data["path"] == "/api/v1/devnet-1/fct_block?slot_eq=1"
+			}
+		}
+
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	// Writes are not copied
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/devnet-1/fct_block", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(1), mirrored.Load())
+
+	// Dropping mirror_url stops the copies
+	require.NoError(t, p.UpdateNetwork(config.NetworkConfig{Name: "devnet-1", TargetURL: primary.URL}))
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(1), mirrored.Load())
+}
+
+func TestCompareResponses(t *testing.T) {
+	gzipped := func(s string) string {
+		var buf bytes.Buffer
+
+		zw := gzip.NewWriter(&buf)
+		_, _ = io.WriteString(zw, s)
+		_ = zw.Close()
+
+		return buf.String()
+	}
+
+	response := func(status int, encoding, body string) *captureWriter {
+		w := newCaptureWriter(httptest.NewRecorder(), 64)
+		if encoding != "" {
+			w.Header().Set("Content-Encoding", encoding)
+		}
+
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+
+		return w
+	}
+
+	tests := []struct {
+		name     string
+		primary  *captureWriter
+		mirror   *captureWriter
+		result   string
+		diffPath string
+	}{
+		{
+			name:    "JSON equal in another key order",
+			primary: response(http.StatusOK, "", `{"a":1,"b":[1,2]}`),
+			mirror:  response(http.StatusOK, "", `{"b":[1,2],"a":1}`),
+			result:  mirrorMatch,
+		},
+		{
+			name:     "JSON value differs",
+			primary:  response(http.StatusOK, "", `{"a":1,"b":[1,2]}`),
+			mirror:   response(http.StatusOK, "", `{"a":1,"b":[1,3]}`),
+			result:   mirrorBodyMismatch,
+			diffPath: "$.b[1]",
+		},
+		{
+			name:     "JSON array length differs",
+			primary:  response(http.StatusOK, "", `[1,2]`),
+			mirror:   response(http.StatusOK, "", `[1]`),
+			result:   mirrorBodyMismatch,
+			diffPath: "$[1]",
+		},
+		{
+			name:     "JSON key missing",
+			primary:  response(http.StatusOK, "", `{"a":1}`),
+			mirror:   response(http.StatusOK, "", `{"a":1,"b":2}`),
+			result:   mirrorBodyMismatch,
+			diffPath: "$.b",
+		},
+		{
+			name:    "status differs",
+			primary: response(http.StatusOK, "", `{}`),
+			mirror:  response(http.StatusNotFound, "", `{}`),
+			result:  mirrorStatusMismatch,
+		},
+		{
+			name:    "gzip decoded",
+			primary: response(http.StatusOK, "gzip", gzipped(`{"a":1}`)),
+			mirror:  response(http.StatusOK, "", `{"a":1}`),
+			result:  mirrorMatch,
+		},
+		{
+			name:    "text differs",
+			primary: response(http.StatusOK, "", "ok"),
+			mirror:  response(http.StatusOK, "", "nok"),
+			result:  mirrorBodyMismatch,
+		},
+		{
+			name:    "too large",
+			primary: response(http.StatusOK, "", string(bytes.Repeat([]byte("a"), 65))),
+			mirror:  response(http.StatusOK, "", "a"),
+			result:  mirrorSkipped,
+		},
+		{
+			name:    "unknown encoding",
+			primary: response(http.StatusOK, "br", "x"),
+			mirror:  response(http.StatusOK, "br", "x"),
+			result:  mirrorSkipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, diffPath := compareResponses(tt.primary, tt.mirror)
+			assert.Equal(t, tt.result, result)
+			assert.Equal(t, tt.diffPath, diffPath)
+		})
+	}
+}
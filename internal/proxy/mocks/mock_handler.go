@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ethpandaops/lab-backend/internal/proxy (interfaces: Handler)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_handler.go github.com/ethpandaops/lab-backend/internal/proxy Handler
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHandler is a mock of Handler interface.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// NetworkCount mocks base method.
+func (m *MockHandler) NetworkCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// NetworkCount indicates an expected call of NetworkCount.
+func (mr *MockHandlerMockRecorder) NetworkCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkCount", reflect.TypeOf((*MockHandler)(nil).NetworkCount))
+}
+
+// Networks mocks base method.
+func (m *MockHandler) Networks() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Networks")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Networks indicates an expected call of Networks.
+func (mr *MockHandlerMockRecorder) Networks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Networks", reflect.TypeOf((*MockHandler)(nil).Networks))
+}
+
+// ServeHTTP mocks base method.
+func (m *MockHandler) ServeHTTP(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ServeHTTP", arg0, arg1)
+}
+
+// ServeHTTP indicates an expected call of ServeHTTP.
+func (mr *MockHandlerMockRecorder) ServeHTTP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeHTTP", reflect.TypeOf((*MockHandler)(nil).ServeHTTP), arg0, arg1)
+}
+
+// Shutdown mocks base method.
+func (m *MockHandler) Shutdown() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockHandlerMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockHandler)(nil).Shutdown))
+}
@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// outboundHeaderPolicy strips sensitive inbound headers and injects required
+// upstream headers on every proxied request, including hedges and WebSocket upgrades.
+type outboundHeaderPolicy struct {
+	strip []string
+	set   map[string]string
+}
+
+// newOutboundHeaderPolicy builds a policy from validated config.
+func newOutboundHeaderPolicy(cfg config.OutboundHeadersConfig) *outboundHeaderPolicy {
+	strip := cfg.Strip
+	if strip == nil {
+		strip = config.DefaultOutboundStripHeaders
+	}
+
+	policy := &outboundHeaderPolicy{
+		strip: make([]string, 0, len(strip)),
+		set:   make(map[string]string, len(cfg.Set)),
+	}
+
+	for _, name := range strip {
+		policy.strip = append(policy.strip, http.CanonicalHeaderKey(name))
+	}
+
+	for name, value := range cfg.Set {
+		policy.set[http.CanonicalHeaderKey(name)] = value
+	}
+
+	return policy
+}
+
+// defaultOutboundHeaderPolicy is used by proxies built without a policy,
+// so credentials are stripped even then.
+var defaultOutboundHeaderPolicy = newOutboundHeaderPolicy(config.OutboundHeadersConfig{})
+
+// apply rewrites the outbound request headers in place.
+func (o *outboundHeaderPolicy) apply(h http.Header) {
+	if o == nil {
+		o = defaultOutboundHeaderPolicy
+	}
+
+	for _, name := range o.strip {
+		h.Del(name)
+	}
+
+	for name, value := range o.set {
+		h.Set(name, value)
+	}
+}
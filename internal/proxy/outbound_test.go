@@ -0,0 +1,185 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+)
+
+func TestProxy_OutboundHeaderPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *outboundHeaderPolicy
+		expected map[string]string
+	}{
+		{
+			name:   "nil policy strips default headers",
+			policy: nil,
+			expected: map[string]string{
+				"Cookie":                  "",
+				"Authorization":           "",
+				"Cf-Access-Jwt-Assertion": "",
+				"Accept":                  "application/json",
+			},
+		},
+		{
+			name: "configured policy strips and injects",
+			policy: newOutboundHeaderPolicy(config.OutboundHeadersConfig{
+				Strip: []string{"accept"},
+				Set:   map[string]string{"x-upstream-key": "secret"},
+			}),
+			expected: map[string]string{
+				"Cookie":         "session=abc",
+				"Accept":         "",
+				"X-Upstream-Key": "secret",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			received := make(chan http.Header, 1)
+
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received <- r.Header.Clone()
+			}))
+			defer backend.Close()
+
+			p := &Proxy{
+				config:          &config.Config{},
+				proxies:         make(map[string]*httputil.ReverseProxy),
+				proxyURLs:       make(map[string]string),
+				localProxies:    make(map[string]*httputil.ReverseProxy),
+				localProxyURLs:  make(map[string]string),
+				localTables:     make(map[string]map[string]bool),
+				logger:          logger,
+				outboundHeaders: tt.policy,
+			}
+
+			require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/bounds", http.NoBody)
+			req.Header.Set("Cookie", "session=abc")
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Cf-Access-Jwt-Assertion", "jwt")
+			req.Header.Set("Accept", "application/json")
+
+			p.ServeHTTP(httptest.NewRecorder(), req)
+
+			headers := <-received
+			for name, value := range tt.expected {
+				assert.Equal(t, value, headers.Get(name), name)
+			}
+		})
+	}
+}
+
+func TestProxy_ForwardsRequestID(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	received := make(chan http.Header, 1)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+	}))
+	defer backend.Close()
+
+	p := &Proxy{
+		config:         &config.Config{},
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+	}
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/bounds", http.NoBody)
+	req = req.WithContext(requestid.ContextWithID(req.Context(), "req-123"))
+
+	p.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "req-123", (<-received).Get(requestid.Header))
+
+	// Errors written by the proxy itself carry the ID too
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/unknown/bounds", http.NoBody)
+	req = req.WithContext(requestid.ContextWithID(req.Context(), "req-456"))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, req)
+
+	var body map[string]any
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "req-456", body["request_id"])
+	assert.Equal(t, "network_not_found", body["code"])
+}
+
+func TestProxy_UpstreamAuth(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	received := make(chan http.Header, 1)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+	}))
+	defer backend.Close()
+
+	p := &Proxy{
+		config:         &config.Config{},
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+	}
+
+	network := config.NetworkConfig{
+		Name:      "mainnet",
+		TargetURL: backend.URL,
+		Auth: &config.UpstreamAuthConfig{
+			Headers:     map[string]string{"X-Api-Key": "key"},
+			BearerToken: "upstream-token",
+		},
+	}
+	require.NoError(t, p.AddNetwork(network))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody)
+	req.Header.Set("Authorization", "Bearer client-token")
+	req.Header.Set("X-Api-Key", "client-key")
+
+	p.ServeHTTP(httptest.NewRecorder(), req)
+
+	headers := <-received
+	assert.Equal(t, "Bearer upstream-token", headers.Get("Authorization"))
+	assert.Equal(t, "key", headers.Get("X-Api-Key"))
+
+	// Changed credentials replace the network's proxy
+	network.Auth = &config.UpstreamAuthConfig{BasicAuth: &config.BasicAuthConfig{Username: "lab", Password: "secret"}}
+	require.NoError(t, p.UpdateNetwork(network))
+
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block", http.NoBody))
+
+	headers = <-received
+	assert.Equal(t, "Basic bGFiOnNlY3JldA==", headers.Get("Authorization"))
+	assert.Empty(t, headers.Get("X-Api-Key"))
+}
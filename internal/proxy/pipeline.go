@@ -0,0 +1,395 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
+)
+
+// maxTransformBodySize is the largest response buffered for response
+// transforms. Larger responses are forwarded untransformed.
+const maxTransformBodySize = 32 << 20
+
+// TransformContext describes the request a transform applies to.
+type TransformContext struct {
+	Network   string
+	Table     string
+	SlotMode  string // Slot transform mode of the table
+	Wallclock *wallclock.Service
+	Logger    logrus.FieldLogger
+}
+
+// Transform is one step of a route's pipeline. Query rewrites the parsed query
+// before proxying, returning the rewrites made; it may modify query in place.
+// Response rewrites the decoded body of successful JSON object responses.
+// Either may be nil.
+type Transform struct {
+	Name     string
+	Query    func(tc *TransformContext, query url.Values) (url.Values, []QueryRewrite)
+	Response func(tc *TransformContext, body map[string]any)
+}
+
+// transformBuilders builds the transform of each config type.
+var transformBuilders = map[string]func(cfg config.TransformConfig) Transform{
+	config.TransformSlotFilters: func(config.TransformConfig) Transform {
+		return Transform{Name: config.TransformSlotFilters, Query: slotFilters}
+	},
+	config.TransformEpochToSlot: func(config.TransformConfig) Transform {
+		return Transform{Name: config.TransformEpochToSlot, Query: epochToSlot}
+	},
+	config.TransformRenameFields: func(cfg config.TransformConfig) Transform {
+		return Transform{Name: config.TransformRenameFields, Response: renameFields(cfg.Fields)}
+	},
+	config.TransformNetworkMetadata: func(cfg config.TransformConfig) Transform {
+		field := cfg.Field
+		if field == "" {
+			field = "network"
+		}
+
+		return Transform{Name: config.TransformNetworkMetadata, Response: networkMetadata(field)}
+	},
+}
+
+// TransformPipeline holds the transforms registered per route pattern.
+// A nil TransformPipeline transforms slot filters on every route.
+type TransformPipeline struct {
+	routes []transformRoute
+}
+
+type transformRoute struct {
+	pattern    *regexp.Regexp
+	transforms []Transform
+}
+
+// defaultTransforms is the pipeline of a nil TransformPipeline.
+var defaultTransforms = func() *TransformPipeline {
+	pipeline, err := NewTransformPipeline(config.TransformsConfig{})
+	if err != nil {
+		panic(err)
+	}
+
+	return pipeline
+}()
+
+// NewTransformPipeline compiles the transform routes. No routes configured
+// means the default routes.
+func NewTransformPipeline(cfg config.TransformsConfig) (*TransformPipeline, error) {
+	routes := cfg.Routes
+	if routes == nil {
+		routes = config.DefaultTransformRoutes()
+	}
+
+	pipeline := &TransformPipeline{routes: make([]transformRoute, 0, len(routes))}
+
+	for i, route := range routes {
+		pattern, err := regexp.Compile(route.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("routes[%d] invalid pattern: %w", i, err)
+		}
+
+		compiled := transformRoute{pattern: pattern, transforms: make([]Transform, 0, len(route.Transforms))}
+
+		for j, transform := range route.Transforms {
+			build, ok := transformBuilders[transform.Type]
+			if !ok {
+				return nil, fmt.Errorf("routes[%d].transforms[%d] unknown type %q", i, j, transform.Type)
+			}
+
+			compiled.transforms = append(compiled.transforms, build(transform))
+		}
+
+		pipeline.routes = append(pipeline.routes, compiled)
+	}
+
+	return pipeline, nil
+}
+
+// match returns the transforms of every route whose pattern matches path, the
+// path after the network (e.g. "/fct_block"), in route order.
+func (p *TransformPipeline) match(path string) transformChain {
+	if p == nil {
+		p = defaultTransforms
+	}
+
+	var chain transformChain
+
+	for _, route := range p.routes {
+		if route.pattern.MatchString(path) {
+			chain = append(chain, route.transforms...)
+		}
+	}
+
+	return chain
+}
+
+// transformChain is the transforms applied to one request, in order.
+type transformChain []Transform
+
+// query runs the query transforms. Returns the original query when nothing
+// was rewritten or it can't be parsed (fail-open).
+func (c transformChain) query(tc *TransformContext, rawQuery string) (string, []QueryRewrite) {
+	if rawQuery == "" || !slices.ContainsFunc(c, func(t Transform) bool { return t.Query != nil }) {
+		return rawQuery, nil
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		tc.Logger.WithFields(logrus.Fields{
+			"network": tc.Network,
+			"query":   rawQuery,
+			"error":   err.Error(),
+		}).Warn("Failed to parse query string, using original")
+
+		return rawQuery, nil
+	}
+
+	var rewrites []QueryRewrite
+
+	for _, transform := range c {
+		if transform.Query == nil {
+			continue
+		}
+
+		var made []QueryRewrite
+
+		values, made = transform.Query(tc, values)
+		rewrites = append(rewrites, made...)
+	}
+
+	if len(rewrites) == 0 {
+		return rawQuery, nil
+	}
+
+	return values.Encode(), rewrites
+}
+
+// transformsResponses reports whether any transform rewrites responses.
+func (c transformChain) transformsResponses() bool {
+	return slices.ContainsFunc(c, func(t Transform) bool { return t.Response != nil })
+}
+
+// transformResponse runs the response transforms of the request on a
+// successful JSON object response. Other responses, and those too large to
+// buffer, are forwarded untouched.
+func (p *Proxy) transformResponse(resp *http.Response) error {
+	rt, ok := requestTransformFrom(resp.Request.Context())
+	if !ok || !rt.chain.transformsResponses() || !transformableResponse(resp) {
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTransformBodySize+1))
+	if err != nil {
+		return fmt.Errorf("read response for transforms: %w", err)
+	}
+
+	if len(data) > maxTransformBodySize {
+		rt.tc.Logger.WithFields(logrus.Fields{
+			"network": rt.tc.Network,
+			"table":   rt.tc.Table,
+		}).Debug("Response too large to transform, forwarding untransformed")
+
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+
+		return nil
+	}
+
+	_ = resp.Body.Close()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Keep large integers exact
+
+	var body map[string]any
+	if err := decoder.Decode(&body); err != nil || body == nil {
+		setResponseBody(resp, data)
+
+		return nil
+	}
+
+	for _, transform := range rt.chain {
+		if transform.Response != nil {
+			transform.Response(rt.tc, body)
+		}
+	}
+
+	transformed, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode transformed response: %w", err)
+	}
+
+	setResponseBody(resp, transformed)
+
+	// The upstream's validator no longer describes the body
+	resp.Header.Del("Etag")
+
+	return nil
+}
+
+// transformableResponse reports whether resp is an uncompressed JSON body
+// response to a successful request.
+func transformableResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || resp.Request.Method == http.MethodHead {
+		return false
+	}
+
+	if resp.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	return err == nil && mediaType == "application/json"
+}
+
+// setResponseBody replaces the body of resp.
+func setResponseBody(resp *http.Response, data []byte) {
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(data)))
+}
+
+// slotFilters rewrites slot_* filters to slot_start_date_time_*, unless the
+// table's slot transform mode is passthrough.
+func slotFilters(tc *TransformContext, query url.Values) (url.Values, []QueryRewrite) {
+	if tc.SlotMode == config.SlotTransformModePassthrough {
+		return query, nil
+	}
+
+	return transformSlotFilters(tc.Logger, tc.Network, tc.Wallclock, query)
+}
+
+// epochToSlot expands epoch_* filters to the slot_* range they cover, using
+// the network's slots per epoch. An epoch filter is kept when the query
+// already has the slot filter it would expand to.
+func epochToSlot(tc *TransformContext, query url.Values) (url.Values, []QueryRewrite) {
+	if tc.Wallclock == nil {
+		return query, nil
+	}
+
+	timing, ok := tc.Wallclock.GetConfig(tc.Network)
+	if !ok || timing.SlotsPerEpoch == 0 {
+		return query, nil
+	}
+
+	var rewrites []QueryRewrite
+
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		isEpoch, operator, epoch := detectFilter("epoch", key, query[key])
+		if !isEpoch || epoch >= math.MaxUint64/timing.SlotsPerEpoch-1 {
+			continue
+		}
+
+		filters := epochSlotFilters(operator, epoch, timing.SlotsPerEpoch)
+		if slices.ContainsFunc(filters, func(f [2]string) bool { return query.Has(f[0]) }) {
+			continue
+		}
+
+		from := key + "=" + query.Get(key)
+
+		query.Del(key)
+
+		for _, filter := range filters {
+			query.Set(filter[0], filter[1])
+			rewrites = append(rewrites, QueryRewrite{From: from, To: filter[0] + "=" + filter[1]})
+		}
+	}
+
+	return query, rewrites
+}
+
+// epochSlotFilters returns the slot filters, as key and value, equivalent to
+// an epoch filter.
+func epochSlotFilters(operator string, epoch, slotsPerEpoch uint64) [][2]string {
+	var (
+		first = strconv.FormatUint(epoch*slotsPerEpoch, 10)
+		last  = strconv.FormatUint((epoch+1)*slotsPerEpoch-1, 10)
+		next  = strconv.FormatUint((epoch+1)*slotsPerEpoch, 10)
+	)
+
+	switch operator {
+	case "eq":
+		return [][2]string{{"slot_gte", first}, {"slot_lte", last}}
+	case "gte":
+		return [][2]string{{"slot_gte", first}}
+	case "gt":
+		return [][2]string{{"slot_gte", next}}
+	case "lte":
+		return [][2]string{{"slot_lte", last}}
+	default: // lt
+		return [][2]string{{"slot_lt", first}}
+	}
+}
+
+// renameFields renames object fields anywhere in the response.
+func renameFields(fields map[string]string) func(*TransformContext, map[string]any) {
+	return func(_ *TransformContext, body map[string]any) {
+		renameKeys(body, fields)
+	}
+}
+
+func renameKeys(value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		// Moved in two steps so swapping two names works
+		moved := make(map[string]any)
+
+		for from, to := range fields {
+			if field, ok := v[from]; ok {
+				moved[to] = field
+
+				delete(v, from)
+			}
+		}
+
+		maps.Copy(v, moved)
+
+		for _, child := range v {
+			renameKeys(child, fields)
+		}
+	case []any:
+		for _, child := range v {
+			renameKeys(child, fields)
+		}
+	}
+}
+
+// NetworkMetadata is added to responses by the network_metadata transform.
+type NetworkMetadata struct {
+	Name           string `json:"name"`
+	GenesisTime    int64  `json:"genesis_time,omitempty"`
+	SecondsPerSlot uint64 `json:"seconds_per_slot,omitempty"`
+	SlotsPerEpoch  uint64 `json:"slots_per_epoch,omitempty"`
+}
+
+// networkMetadata adds the network's name and timing as a top-level field.
+func networkMetadata(field string) func(*TransformContext, map[string]any) {
+	return func(tc *TransformContext, body map[string]any) {
+		metadata := NetworkMetadata{Name: tc.Network}
+
+		if tc.Wallclock != nil {
+			if timing, ok := tc.Wallclock.GetConfig(tc.Network); ok {
+				metadata.GenesisTime = timing.GenesisTime.Unix()
+				metadata.SecondsPerSlot = timing.SecondsPerSlot
+				metadata.SlotsPerEpoch = timing.SlotsPerEpoch
+			}
+		}
+
+		body[field] = metadata
+	}
+}
@@ -0,0 +1,166 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestEpochToSlot(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	tc := &TransformContext{Network: "mainnet", Wallclock: setupTestWallclock(t), Logger: logger}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "eq", query: "epoch_eq=2", want: "slot_gte=64&slot_lte=95"},
+		{name: "gte", query: "epoch_gte=2", want: "slot_gte=64"},
+		{name: "gt", query: "epoch_gt=2", want: "slot_gte=96"},
+		{name: "lte", query: "epoch_lte=2", want: "slot_lte=95"},
+		{name: "lt", query: "epoch_lt=2", want: "slot_lt=64"},
+		{name: "range", query: "epoch_gte=1&epoch_lt=3&limit=5", want: "limit=5&slot_gte=32&slot_lt=96"},
+		{name: "slot filter already set", query: "epoch_gte=2&slot_gte=70", want: "epoch_gte=2&slot_gte=70"},
+		{name: "not a number", query: "epoch_eq=head", want: "epoch_eq=head"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, _ := epochToSlot(tc, query)
+			assert.Equal(t, tt.want, got.Encode())
+		})
+	}
+}
+
+func TestNewTransformPipeline(t *testing.T) {
+	pipeline, err := NewTransformPipeline(config.TransformsConfig{Routes: []config.TransformRouteConfig{
+		{Pattern: "^/fct_block$", Transforms: []config.TransformConfig{{Type: config.TransformEpochToSlot}}},
+		{Transforms: []config.TransformConfig{{Type: config.TransformSlotFilters}}},
+	}})
+	require.NoError(t, err)
+
+	names := func(chain transformChain) []string {
+		out := make([]string, 0, len(chain))
+		for _, transform := range chain {
+			out = append(out, transform.Name)
+		}
+
+		return out
+	}
+
+	assert.Equal(t, []string{config.TransformEpochToSlot, config.TransformSlotFilters}, names(pipeline.match("/fct_block")))
+	assert.Equal(t, []string{config.TransformSlotFilters}, names(pipeline.match("/fct_block_head")))
+
+	// Nil and no routes configured both mean the default routes
+	assert.Equal(t, []string{config.TransformSlotFilters}, names((*TransformPipeline)(nil).match("/fct_block")))
+
+	disabled, err := NewTransformPipeline(config.TransformsConfig{Routes: []config.TransformRouteConfig{}})
+	require.NoError(t, err)
+	assert.Empty(t, disabled.match("/fct_block"))
+
+	_, err = NewTransformPipeline(config.TransformsConfig{Routes: []config.TransformRouteConfig{
+		{Transforms: []config.TransformConfig{{Type: "unknown"}}},
+	}})
+	assert.Error(t, err)
+}
+
+func TestProxy_ServeHTTP_TransformPipeline(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"upstream"`)
+
+		json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck // test
+			"query":     r.URL.RawQuery,
+			"fct_block": []map[string]any{{"slot": 64, "block_root": "0xabc", "block_number": uint64(18446744073709551615)}},
+		})
+	}))
+	defer backend.Close()
+
+	transformsCfg := config.TransformsConfig{Routes: []config.TransformRouteConfig{
+		{
+			Pattern: "^/fct_block$",
+			Transforms: []config.TransformConfig{
+				{Type: config.TransformEpochToSlot},
+				{Type: config.TransformSlotFilters},
+				{Type: config.TransformRenameFields, Fields: map[string]string{"slot": "slot_number"}},
+				{Type: config.TransformNetworkMetadata},
+			},
+		},
+	}}
+	require.NoError(t, transformsCfg.Validate())
+
+	transforms, err := NewTransformPipeline(transformsCfg)
+	require.NoError(t, err)
+
+	p := &Proxy{
+		config:         &config.Config{},
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+		wallclockSvc:   setupTestWallclock(t),
+		transforms:     transforms,
+	}
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+
+	t.Run("matching route", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block?epoch_eq=2", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("ETag"))
+		assert.Equal(t,
+			"epoch_eq=2 -> slot_gte=64, epoch_eq=2 -> slot_lte=95, "+
+				"slot_gte=64 -> slot_start_date_time_gte=1606824791, slot_lte=95 -> slot_start_date_time_lte=1606825163",
+			rec.Header().Get(TransformedHeader))
+
+		var body struct {
+			Query    string           `json:"query"`
+			FctBlock []map[string]any `json:"fct_block"`
+			Network  NetworkMetadata  `json:"network"`
+		}
+
+		decoder := json.NewDecoder(rec.Body)
+		decoder.UseNumber()
+		require.NoError(t, decoder.Decode(&body))
+
+		assert.Equal(t, "slot_start_date_time_gte=1606824791&slot_start_date_time_lte=1606825163", body.Query)
+		assert.Equal(t, json.Number("64"), body.FctBlock[0]["slot_number"])
+		assert.NotContains(t, body.FctBlock[0], "slot")
+		assert.Equal(t, json.Number("18446744073709551615"), body.FctBlock[0]["block_number"])
+		assert.Equal(t, NetworkMetadata{Name: "mainnet", GenesisTime: 1606824023, SecondsPerSlot: 12, SlotsPerEpoch: 32}, body.Network)
+	})
+
+	t.Run("other route", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_head?slot_eq=1000", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"upstream"`, rec.Header().Get("ETag"))
+		assert.Empty(t, rec.Header().Get(TransformedHeader))
+		assert.Contains(t, rec.Body.String(), `"query":"slot_eq=1000"`)
+		assert.Contains(t, rec.Body.String(), `"slot":64`)
+	})
+}
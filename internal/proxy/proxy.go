@@ -2,18 +2,31 @@ package proxy
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/lab-backend/internal/bounds"
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/discovery"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/negcache"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
 	"github.com/ethpandaops/lab-backend/internal/wallclock"
 )
 
@@ -32,18 +45,85 @@ type Proxy struct {
 	localProxyURLs map[string]string                 // network → local URL
 	localTables    map[string]map[string]bool        // network → set of table names
 
+	// Hedged reads towards an alternate backend replica
+	hedgePolicy *hedgePolicy      // nil when hedging is disabled
+	hedgeURLs   map[string]string // network → hedge target URL
+
+	// Copies of proxied reads sent to a network's mirror_url
+	mirroring  *mirrorPolicy
+	mirrors    map[string]*httputil.ReverseProxy // network → mirror proxy
+	mirrorURLs map[string]string                 // network → mirror URL
+
+	// DNS-based discovery of target_url instances
+	resolvers map[string]*discovery.Resolver // network → resolver
+
+	// Health-checked failover between several target_urls
+	pools map[string]*upstreamPool // network → pool, for networks with several target_urls
+
+	// Continuous health probing of network backends
+	prober *healthProber // nil when health probing is disabled
+
+	// Per-network upstream path mappings, kept for change detection
+	pathMappings map[string]*config.PathMappingConfig // network → mapping config
+
+	// Per-network upstream credentials, kept for change detection
+	auths map[string]*config.UpstreamAuthConfig // network → auth config
+
+	// Networks whose reads are retried, kept for change detection
+	retries map[string]bool // network → retry enabled
+
+	// Upstream timeouts per request path and network
+	timeoutPolicy *timeoutPolicy           // nil without path overrides
+	timeouts      map[string]time.Duration // network → timeout, kept for change detection
+
+	// WebSocket upgrade passthrough
+	websockets *websocketLimiter // nil when WebSocket passthrough is disabled
+
+	// Headers stripped from and injected into upstream requests
+	outboundHeaders *outboundHeaderPolicy
+
+	// Per-table query rules checked before proxying
+	queries *queryValidator // nil when query validation is disabled
+
+	// Whether slot filters are transformed, per network and table
+	slotTransform *slottransform.Service // nil always transforms
+
+	// Request and response transforms per route
+	transforms *TransformPipeline // nil transforms slot filters only
+
+	// Recently requested networks that turned out not to exist
+	unknownNetworks *negcache.Cache // nil when negative caching is disabled
+
+	// Tombstones of networks removed from the proxy
+	retirements *retirements // nil when retirement is disabled
+
+	// Trials of changed target URLs before switching over
+	canaries *canaries // nil when canary mode is disabled
+
+	// Lifecycle of background work outliving the calls that start it: discovery
+	// resolvers, upstream pools, health probes and canary polling
+	ctx    context.Context //nolint:containedctx // canceled on Shutdown
+	cancel context.CancelFunc
+
 	// Periodic sync lifecycle
-	syncTicker *time.Ticker
+	syncTicker *jitter.Ticker
+	syncTask   *tasks.Task
+	syncCancel context.CancelFunc // Aborts an in-flight sync on shutdown
 	stopChan   chan struct{}
 	wg         sync.WaitGroup
 }
 
 // New creates a new proxy service with pre-configured ReverseProxy instances.
+// The initial sync and periodic syncs run under ctx.
 func New(
+	ctx context.Context,
 	logger logrus.FieldLogger,
 	cfg *config.Config,
 	provider cartographoor.Provider,
 	wallclockSvc *wallclock.Service,
+	slotTransform *slottransform.Service,
+	redisClient redis.Client,
+	boundsProvider bounds.Provider,
 ) (*Proxy, error) {
 	p := &Proxy{
 		config:         cfg,
@@ -52,22 +132,69 @@ func New(
 		localProxies:   make(map[string]*httputil.ReverseProxy),
 		localProxyURLs: make(map[string]string),
 		localTables:    make(map[string]map[string]bool),
+		hedgeURLs:      make(map[string]string),
+		mirrors:        make(map[string]*httputil.ReverseProxy),
+		mirrorURLs:     make(map[string]string),
+		resolvers:      make(map[string]*discovery.Resolver),
+		pools:          make(map[string]*upstreamPool),
+		pathMappings:   make(map[string]*config.PathMappingConfig),
+		auths:          make(map[string]*config.UpstreamAuthConfig),
+		retries:        make(map[string]bool),
+		timeouts:       make(map[string]time.Duration),
 		logger:         logger.WithField("component", "proxy"),
 		provider:       provider,
 		wallclockSvc:   wallclockSvc,
+		slotTransform:  slotTransform,
 		stopChan:       make(chan struct{}),
 	}
 
+	p.ctx, p.cancel = context.WithCancel(ctx)
+
+	hedgePolicy, err := newHedgePolicy(cfg.Proxy.Hedging)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hedge policy: %w", err)
+	}
+
+	p.hedgePolicy = hedgePolicy
+
+	transforms, err := NewTransformPipeline(cfg.Proxy.Transforms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transform pipeline: %w", err)
+	}
+
+	p.transforms = transforms
+
+	timeoutPolicy, err := newTimeoutPolicy(cfg.Proxy.Timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create timeout policy: %w", err)
+	}
+
+	p.timeoutPolicy = timeoutPolicy
+	p.websockets = newWebSocketLimiter(cfg.Proxy.WebSocket)
+	p.outboundHeaders = newOutboundHeaderPolicy(cfg.Proxy.OutboundHeaders)
+	p.queries = newQueryValidator(cfg.Proxy.QueryValidation)
+	p.unknownNetworks = negcache.New("proxy_networks", cfg.NegativeCache)
+	p.prober = newHealthProber(p.logger, cfg.Proxy.HealthProbe, p.probeTargets)
+	p.retirements = newRetirements(p.logger, cfg.Proxy.Retirement, redisClient, boundsProvider)
+	p.canaries = newCanaries(p.logger, cfg.Proxy.Canary, redisClient)
+	p.mirroring = newMirrorPolicy(p.logger, cfg.Proxy.Mirroring)
+
 	// Initial sync: build merged network list and create proxies
 	// Uses cartographoor-first, config-overlay approach.
-	if err := p.SyncNetworks(context.Background()); err != nil {
+	if err := p.SyncNetworks(ctx); err != nil {
 		// Don't error - proxy still usable with whatever loaded
 		p.logger.WithError(err).Warn("Initial network sync failed")
 	}
 
+	// Probe the networks loaded by the initial sync right away
+	p.prober.Start(p.ctx)
+
+	// Pick up canary decisions made on other instances
+	p.canaries.Start(p.ctx, p.applyCanaryDecision)
+
 	// Start periodic sync if provider available
 	if provider != nil {
-		p.startPeriodicSync()
+		p.startPeriodicSync(ctx)
 	}
 
 	return p, nil
@@ -75,15 +202,17 @@ func New(
 
 // ServeHTTP implements http.Handler interface.
 func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := requestid.Logger(r.Context(), p.logger)
+
 	// Extract network from path
 	network, remainingPath, err := ExtractNetwork(r.URL.Path)
 	if err != nil {
-		p.logger.WithFields(logrus.Fields{
+		log.WithFields(logrus.Fields{
 			"path":  r.URL.Path,
 			"error": err.Error(),
 		}).Warn("Invalid path format")
 
-		p.writeJSONError(w, http.StatusBadRequest, "invalid path format", "")
+		p.writeError(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "invalid path format", "")
 
 		return
 	}
@@ -92,46 +221,143 @@ func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	proxy, exists := p.proxies[network]
 	localProxy := p.localProxies[network]
 	localTableSet := p.localTables[network]
+	mirror := p.mirrors[network]
 	p.mu.RUnlock()
 
 	if !exists {
+		// Answer repeated requests for unknown networks without looking them up again
+		if p.unknownNetworks.Has(network) {
+			p.writeError(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found", network)
+
+			return
+		}
+
 		// Check if network is configured but disabled
 		networkCfg, err := p.config.GetNetworkByName(network)
 		if err == nil && networkCfg.Enabled != nil && !*networkCfg.Enabled {
-			p.logger.WithField("network", network).Debug("Network is disabled")
+			log.WithField("network", network).Debug("Network is disabled")
 
-			p.writeJSONError(w, http.StatusServiceUnavailable, "network disabled", network)
+			p.writeError(w, r, http.StatusServiceUnavailable, httperr.CodeNetworkDisabled, "network disabled", network)
+
+			return
+		}
+
+		// Check if cartographoor lists the network but it was quarantined
+		if reason := p.quarantineReason(r.Context(), network); reason != "" {
+			log.WithField("network", network).WithField("reason", reason).Debug("Network is quarantined")
+
+			p.writeError(w, r, http.StatusServiceUnavailable, httperr.CodeNetworkQuarantined, "network quarantined: "+reason, network)
+
+			return
+		}
+
+		// Check if the network was removed within the retirement grace period
+		if tombstone, ok := p.retirements.lookup(r.Context(), network); ok {
+			log.WithField("network", network).Debug("Network is retired")
+
+			p.writeRetired(w, r, tombstone)
 
 			return
 		}
 
 		// Network not found in config
-		p.logger.WithField("network", network).Debug("Network not found")
+		log.WithField("network", network).Debug("Network not found")
 
-		p.writeJSONError(w, http.StatusNotFound, "network not found", network)
+		p.unknownNetworks.Add(network)
+
+		p.writeError(w, r, http.StatusNotFound, httperr.CodeNetworkNotFound, "network not found", network)
+
+		return
+	}
+
+	// Preview query transformation without calling the upstream
+	if table, ok := debugTransformTable(remainingPath); ok {
+		p.serveDebugTransform(w, r, network, table)
 
 		return
 	}
 
-	// Check if this request should be routed to local proxy (hybrid mode)
 	tableName := ExtractTableName(remainingPath)
+
+	// Reject malformed queries before they reach the upstream
+	if qerr := p.queries.validate(tableName, r.URL.Query()); qerr != nil {
+		queryRejectionsTotal.WithLabelValues(network, qerr.reason).Inc()
+		log.WithFields(logrus.Fields{
+			"network": network,
+			"table":   tableName,
+			"reason":  qerr.reason,
+		}).Debug("Rejected invalid query")
+
+		p.writeError(w, r, http.StatusBadRequest, httperr.CodeBadRequest, qerr.message, network)
+
+		return
+	}
+
+	routedLocally := localProxy != nil && localTableSet[tableName]
+
+	// Fail fast while probes find the backend down, instead of waiting for
+	// every request to time out
+	if !routedLocally && p.prober.Unhealthy(network) {
+		healthShortCircuitsTotal.WithLabelValues(network).Inc()
+
+		w.Header().Set("Retry-After", strconv.Itoa(p.prober.RetryAfter()))
+		p.writeError(w, r, http.StatusServiceUnavailable, httperr.CodeUpstreamUnavailable, "network backend unavailable", network)
+
+		return
+	}
+
+	// Apply the route's query transforms, e.g. slot filters to timestamps
+	r = p.prepareQuery(w, r, network, remainingPath)
+
+	if isWebSocketUpgrade(r) {
+		p.serveWebSocket(w, r, proxy, network)
+
+		return
+	}
+
+	// Slow or cheap routes wait longer or shorter for the upstream
+	if timeout, ok := p.timeoutPolicy.match(r.URL.Path); ok {
+		r = r.WithContext(withUpstreamTimeout(r.Context(), timeout))
+	}
+
+	// Check if this request should be routed to local proxy (hybrid mode)
 	selectedProxy := proxy
 
-	if localProxy != nil && localTableSet[tableName] {
+	if routedLocally {
 		selectedProxy = localProxy
 
-		p.logger.WithFields(logrus.Fields{
+		log.WithFields(logrus.Fields{
 			"method":  r.Method,
 			"network": network,
 			"table":   tableName,
 			"path":    r.URL.Path,
 		}).Debug("Routing to local proxy (hybrid override)")
 	} else {
-		p.logger.WithFields(logrus.Fields{
+		log.WithFields(logrus.Fields{
 			"method":  r.Method,
 			"network": network,
 			"path":    r.URL.Path,
 		}).Debug("Proxying request")
+
+		// Mark latency-sensitive reads for hedging (no-op without a hedge target)
+		if p.hedgePolicy.matches(r) {
+			r = r.WithContext(withHedging(r.Context()))
+		}
+	}
+
+	// Copy reads to the network's mirror, comparing a sample of responses
+	if mirror != nil && !routedLocally {
+		var finish func()
+
+		w, finish = p.mirroring.mirror(w, r, network, mirror)
+		defer finish()
+	}
+
+	// Trial a changed target URL next to the current one
+	if c := p.canaries.get(network); c != nil && !routedLocally {
+		p.canaries.serve(c, w, r, selectedProxy)
+
+		return
 	}
 
 	// Forward request to selected backend
@@ -139,27 +365,107 @@ func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	selectedProxy.ServeHTTP(w, r)
 }
 
+// serveWebSocket proxies a WebSocket upgrade to the network's primary backend.
+// httputil.ReverseProxy handles the protocol switch itself; this enforces the
+// per-network connection limit and the idle timeout. Upgrades are never hedged.
+func (p *Proxy) serveWebSocket(w http.ResponseWriter, r *http.Request, proxy *httputil.ReverseProxy, network string) {
+	log := requestid.Logger(r.Context(), p.logger)
+
+	if p.websockets == nil {
+		p.writeError(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "websocket upgrades are not enabled", network)
+
+		return
+	}
+
+	if !p.websockets.acquire(network) {
+		log.WithField("network", network).Warn("WebSocket connection limit reached")
+
+		p.writeError(w, r, http.StatusServiceUnavailable, httperr.CodeTooManyConnections, "too many websocket connections", network)
+
+		return
+	}
+	defer p.websockets.release(network)
+
+	log.WithFields(logrus.Fields{
+		"network": network,
+		"path":    r.URL.Path,
+	}).Debug("Proxying WebSocket connection")
+
+	proxy.ServeHTTP(&idleTimeoutWriter{ResponseWriter: w, timeout: p.websockets.cfg.IdleTimeout}, r)
+}
+
+// reverseProxyOptions configures a ReverseProxy built by createReverseProxy.
+type reverseProxyOptions struct {
+	targetURL string
+	network   string
+
+	// hedgeURL receives hedges of eligible reads, when hedging is enabled
+	hedgeURL string
+	// resolver spreads connections across its discovered instances
+	resolver *discovery.Resolver
+	// mapping adapts paths to the upstream's prefix convention
+	mapping *PathMapping
+	// auth credentials are sent with every upstream request
+	auth *config.UpstreamAuthConfig
+	// pool picks the upstream of each request, failing over between its
+	// target URLs
+	pool *upstreamPool
+	// retry sends reads that hit a transient upstream failure again per
+	// proxy.retry
+	retry bool
+	// timeout bounds each upstream attempt's wait for response headers,
+	// unless the request's path has its own
+	timeout time.Duration
+}
+
 // createReverseProxy creates and configures a ReverseProxy for a target URL.
-func (p *Proxy) createReverseProxy(
-	targetURL string,
-	networkName string,
-) (*httputil.ReverseProxy, error) {
+// Unset options are disabled.
+func (p *Proxy) createReverseProxy(opts reverseProxyOptions) (*httputil.ReverseProxy, error) {
 	// Parse target URL
-	target, err := url.Parse(targetURL)
+	target, err := url.Parse(opts.targetURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid target URL: %w", err)
 	}
 
-	// Create custom Transport with connection pooling
+	// Create custom Transport with connection pooling. Response header
+	// timeouts are applied per request by timeoutTransport.
 	transport := &http.Transport{
 		MaxIdleConns:          100,
 		MaxIdleConnsPerHost:   10,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
-		ResponseHeaderTimeout: 30 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 	}
 
+	if opts.resolver != nil {
+		transport.DialContext = opts.resolver.DialContext
+	}
+
+	// Record every upstream attempt, including hedges, per target host.
+	// Identical attempts in flight at the same time share one response, whose
+	// body is read after the header timeout
+	var roundTripper http.RoundTripper = upstream.Coalesced(&timeoutTransport{
+		base:    upstream.Limited(upstream.NewTransport(upstream.SubsystemProxy, transport)),
+		timeout: opts.timeout,
+	})
+
+	if opts.hedgeURL != "" && p.hedgePolicy != nil {
+		hedging, err := newHedgingTransport(roundTripper, target, opts.hedgeURL, opts.network, p.hedgePolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		roundTripper = hedging
+	}
+
+	if opts.pool != nil {
+		roundTripper = &failoverTransport{base: roundTripper, pool: opts.pool, primary: target}
+	}
+
+	if opts.retry {
+		roundTripper = &retryTransport{base: roundTripper, cfg: p.config.Proxy.Retry, network: opts.network}
+	}
+
 	// Create ReverseProxy with Rewrite function and response modification
 	proxy := &httputil.ReverseProxy{
 		Rewrite: func(r *httputil.ProxyRequest) {
@@ -168,11 +474,22 @@ func (p *Proxy) createReverseProxy(
 			// Enable X-Forwarded-* headers
 			r.SetXForwarded()
 
+			// Never forward client credentials to upstreams
+			p.outboundHeaders.apply(r.Out.Header)
+
+			// Authenticate to the upstream; hedges are cloned from r.Out
+			opts.auth.Apply(r.Out)
+
+			// Let upstream logs be correlated with ours
+			if id := requestid.FromContext(r.In.Context()); id != "" {
+				r.Out.Header.Set(requestid.Header, id)
+			}
+
 			// Rewrite path to remove network segment
-			rewrittenPath, err := RewritePath(r.In.URL.Path)
+			rewrittenPath, err := RewritePath(r.In.URL.Path, opts.mapping)
 			if err != nil {
-				p.logger.WithFields(logrus.Fields{
-					"network": networkName,
+				requestid.Logger(r.In.Context(), p.logger).WithFields(logrus.Fields{
+					"network": opts.network,
 					"path":    r.In.URL.Path,
 					"error":   err.Error(),
 				}).Error("Failed to rewrite path")
@@ -182,32 +499,25 @@ func (p *Proxy) createReverseProxy(
 
 			r.Out.URL.Path = rewrittenPath
 
-			// Transform query parameters (slot_* to slot_start_time_*)
-			originalQuery := r.In.URL.RawQuery
-			transformedQuery := transformQueryParams(
-				p.logger,
-				networkName,
-				p.wallclockSvc,
-				originalQuery,
-			)
-			r.Out.URL.RawQuery = transformedQuery
-
-			// Log transformation if query changed
-			if originalQuery != transformedQuery {
-				p.logger.WithFields(logrus.Fields{
-					"network":     networkName,
-					"original":    originalQuery,
-					"transformed": transformedQuery,
-				}).Debug("Transformed slot filters to slot_start_date_time")
+			// Query with the route's query transforms applied
+			r.Out.URL.RawQuery = outboundQuery(r.In)
+
+			// Response transforms need the body uncompressed; the transport
+			// then negotiates and decodes gzip itself
+			if rt, ok := requestTransformFrom(r.In.Context()); ok && rt.chain.transformsResponses() {
+				r.Out.Header.Del("Accept-Encoding")
 			}
 		},
-		ModifyResponse: func(r *http.Response) error {
-			return nil
-		},
-		Transport: transport,
+		ModifyResponse: p.transformResponse,
+		Transport:      roundTripper,
 		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
-			p.logger.WithFields(logrus.Fields{
-				"network":     networkName,
+			// The route's deadline passed, the request timeout middleware answers
+			if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+				return
+			}
+
+			requestid.Logger(r.Context(), p.logger).WithFields(logrus.Fields{
+				"network":     opts.network,
 				"target_url":  target.String(),
 				"error":       err.Error(),
 				"method":      r.Method,
@@ -215,7 +525,20 @@ func (p *Proxy) createReverseProxy(
 				"remote_addr": r.RemoteAddr,
 			}).Error("Backend error")
 
-			p.writeJSONError(w, http.StatusBadGateway, "backend unavailable", networkName)
+			if errors.Is(err, upstream.ErrOverloaded) {
+				w.Header().Set("Retry-After", "1")
+				p.writeError(w, r, http.StatusServiceUnavailable, httperr.CodeUpstreamOverloaded, "backend busy", opts.network)
+
+				return
+			}
+
+			if errors.Is(err, errUpstreamTimeout) {
+				p.writeError(w, r, http.StatusGatewayTimeout, httperr.CodeUpstreamTimeout, "backend timed out", opts.network)
+
+				return
+			}
+
+			p.writeError(w, r, http.StatusBadGateway, httperr.CodeUpstreamUnavailable, "backend unavailable", opts.network)
 		},
 	}
 
@@ -223,7 +546,7 @@ func (p *Proxy) createReverseProxy(
 }
 
 // startPeriodicSync starts the background sync goroutine.
-func (p *Proxy) startPeriodicSync() {
+func (p *Proxy) startPeriodicSync(ctx context.Context) {
 	// Use cartographoor refresh interval for proxy sync
 	// Default to 5 minutes if not configured
 	interval := p.config.Cartographoor.RefreshInterval
@@ -231,10 +554,14 @@ func (p *Proxy) startPeriodicSync() {
 		interval = 5 * time.Minute
 	}
 
-	p.syncTicker = time.NewTicker(interval)
+	p.syncTicker = jitter.NewTicker(interval)
+	p.syncTask = tasks.Default().Register("proxy.sync", interval)
+
+	ctx, p.syncCancel = context.WithCancel(ctx)
+
 	p.wg.Add(1)
 
-	go p.syncLoop()
+	go p.syncLoop(ctx)
 
 	p.logger.WithField("refresh_interval", interval).Info("Started periodic network sync")
 }
@@ -243,6 +570,7 @@ func (p *Proxy) startPeriodicSync() {
 func (p *Proxy) stopPeriodicSync() {
 	if p.syncTicker != nil {
 		close(p.stopChan)
+		p.syncCancel()
 
 		p.syncTicker.Stop()
 		p.wg.Wait()
@@ -251,16 +579,30 @@ func (p *Proxy) stopPeriodicSync() {
 	}
 }
 
-// syncLoop runs the periodic sync in background.
-func (p *Proxy) syncLoop() {
+// syncLoop runs the periodic sync in background, restarting it if it panics.
+func (p *Proxy) syncLoop(ctx context.Context) {
 	defer p.wg.Done()
 
+	p.syncTask.Supervise(p.logger, p.stopChan, func() { p.runSyncLoop(ctx) })
+}
+
+func (p *Proxy) runSyncLoop(ctx context.Context) {
 	for {
 		select {
 		case <-p.syncTicker.C:
-			if err := p.SyncNetworks(context.Background()); err != nil {
+			if err := p.syncTask.Run(func() error {
+				return p.SyncNetworks(ctx)
+			}); err != nil {
 				p.logger.WithError(err).Error("Periodic network sync failed")
 			}
+		case <-p.config.NetworksFileChanged():
+			p.logger.Debug("Networks file changed, syncing networks")
+
+			if err := p.syncTask.Run(func() error {
+				return p.SyncNetworks(ctx)
+			}); err != nil {
+				p.logger.WithError(err).Error("Network sync failed")
+			}
 		case <-p.stopChan:
 			return
 		}
@@ -302,6 +644,8 @@ func (p *Proxy) SyncNetworks(ctx context.Context) error {
 			// Add new network
 			if err := p.AddNetwork(networkCfg); err != nil {
 				p.logger.WithError(err).WithField("network", name).Error("Failed to add network")
+			} else {
+				p.retirements.revive(ctx, name)
 			}
 		}
 	}
@@ -319,6 +663,7 @@ func (p *Proxy) SyncNetworks(ctx context.Context) error {
 	for _, name := range currentNetworks {
 		if !desiredNames[name] {
 			p.logger.WithField("network", name).Info("Removing network no longer in config")
+			p.retire(ctx, name)
 			p.RemoveNetwork(name)
 		}
 	}
@@ -334,10 +679,58 @@ func (p *Proxy) NetworkCount() int {
 	return len(p.proxies)
 }
 
+// Networks returns the names of all proxied networks, sorted.
+func (p *Proxy) Networks() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(p.proxies))
+}
+
+// NetworkHealth returns the probed health of every network's backends, or
+// nil when health probing is disabled.
+func (p *Proxy) NetworkHealth() map[string]NetworkHealth {
+	return p.prober.Status()
+}
+
+// probeTargets returns the backends of every network for the health prober:
+// all target_urls of networks with an upstream pool, the target_url
+// otherwise. Local overrides are not probed.
+func (p *Proxy) probeTargets() map[string]probeTarget {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	targets := make(map[string]probeTarget, len(p.proxyURLs))
+
+	for name, targetURL := range p.proxyURLs {
+		urls := []string{targetURL}
+		if pool, ok := p.pools[name]; ok {
+			urls = pool.URLs()
+		}
+
+		targets[name] = probeTarget{urls: urls, auth: p.auths[name]}
+	}
+
+	return targets
+}
+
 // Shutdown stops the proxy and cleans up resources.
 func (p *Proxy) Shutdown() error {
 	p.logger.Info("Shutting down proxy")
+	p.cancel()
 	p.stopPeriodicSync()
+	p.prober.Stop()
+	p.canaries.Stop()
+
+	p.mu.Lock()
+	for name := range p.resolvers {
+		p.replaceResolver(name, nil)
+	}
+
+	for name := range p.pools {
+		p.replacePool(name, nil)
+	}
+	p.mu.Unlock()
 
 	return nil
 }
@@ -346,25 +739,81 @@ func (p *Proxy) Shutdown() error {
 // Used by cartographoor when new devnets are discovered.
 // Assumes network has already been health-checked by BuildMergedNetworkList.
 func (p *Proxy) AddNetwork(network config.NetworkConfig) error {
+	mapping, err := NewPathMapping(network.PathMapping)
+	if err != nil {
+		return fmt.Errorf("invalid path mapping for %s: %w", network.Name, err)
+	}
+
+	pool, err := p.newPool(network)
+	if err != nil {
+		return fmt.Errorf("failed to create upstream pool for %s: %w", network.Name, err)
+	}
+
+	// Resolve before taking the lock, DNS lookups may be slow
+	resolver, err := p.startResolver(network)
+	if err != nil {
+		return fmt.Errorf("failed to create resolver for %s: %w", network.Name, err)
+	}
+
+	retry := network.RetryEnabled(p.config.Proxy.Retry.Enabled)
+	timeout := p.upstreamTimeout(network)
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	// Create reverse proxy for this network
-	proxy, err := p.createReverseProxy(network.TargetURL, network.Name)
+	proxy, err := p.createReverseProxy(reverseProxyOptions{
+		targetURL: network.TargetURL,
+		network:   network.Name,
+		hedgeURL:  network.HedgeTargetURL,
+		resolver:  resolver,
+		mapping:   mapping,
+		auth:      network.Auth,
+		pool:      pool,
+		retry:     retry,
+		timeout:   timeout,
+	})
 	if err != nil {
+		p.stopResolver(resolver)
+
 		return fmt.Errorf("failed to create proxy for %s: %w", network.Name, err)
 	}
 
+	// Build everything before registering, so a failure leaves no partial network
+	mirror, err := p.createMirror(network, mapping, timeout)
+	if err != nil {
+		p.stopResolver(resolver)
+
+		return fmt.Errorf("failed to create mirror proxy for %s: %w", network.Name, err)
+	}
+
+	localProxy, err := p.createLocalProxy(network)
+	if err != nil {
+		p.stopResolver(resolver)
+
+		return fmt.Errorf("failed to create local proxy for %s: %w", network.Name, err)
+	}
+
+	p.replaceResolver(network.Name, resolver)
+	p.replacePool(network.Name, pool)
+
 	p.proxies[network.Name] = proxy
 	p.proxyURLs[network.Name] = network.TargetURL
+	p.setPathMapping(network.Name, network.PathMapping)
+	p.setAuth(network.Name, network.Auth)
+	p.setRetry(network.Name, retry)
+	p.setTimeout(network.Name, network.Timeout)
 
-	// Set up local override proxy for hybrid mode
-	if network.LocalOverrides != nil {
-		if err := p.setupLocalProxy(network); err != nil {
-			return fmt.Errorf("failed to create local proxy for %s: %w", network.Name, err)
-		}
+	if network.HedgeTargetURL != "" {
+		p.hedgeURLs[network.Name] = network.HedgeTargetURL
 	}
 
+	p.setMirror(network, mirror)
+	p.setLocalProxy(network, localProxy)
+
+	// The network may have been remembered as unknown
+	p.unknownNetworks.Clear()
+
 	p.logger.WithFields(logrus.Fields{
 		"network":    network.Name,
 		"target_url": network.TargetURL,
@@ -384,6 +833,18 @@ func (p *Proxy) RemoveNetwork(networkName string) {
 	delete(p.localProxies, networkName)
 	delete(p.localProxyURLs, networkName)
 	delete(p.localTables, networkName)
+	delete(p.hedgeURLs, networkName)
+	delete(p.mirrors, networkName)
+	delete(p.mirrorURLs, networkName)
+	p.setPathMapping(networkName, nil)
+	p.setAuth(networkName, nil)
+	p.setRetry(networkName, false)
+	p.setTimeout(networkName, 0)
+	p.replaceResolver(networkName, nil)
+	p.replacePool(networkName, nil)
+	p.prober.Forget(networkName)
+	p.canaries.drop(networkName, "network removed")
+	p.canaries.forget(networkName)
 
 	p.logger.WithField("network", networkName).Info("Network proxy removed")
 }
@@ -395,15 +856,38 @@ func (p *Proxy) UpdateNetwork(network config.NetworkConfig) error {
 	p.mu.RLock()
 	currentURL, exists := p.proxyURLs[network.Name]
 	currentLocalURL := p.localProxyURLs[network.Name]
+	currentHedgeURL := p.hedgeURLs[network.Name]
+	currentMirrorURL := p.mirrorURLs[network.Name]
+	currentResolver := p.resolvers[network.Name]
+	currentMapping := p.pathMappings[network.Name]
+	currentAuth := p.auths[network.Name]
+	currentPool := p.pools[network.Name]
+	currentRetry := p.retries[network.Name]
+	currentTimeout := p.timeouts[network.Name]
 	p.mu.RUnlock()
 
+	// A changed target URL is trialled first, the stable proxy keeps the current one
+	network = p.canaryNetwork(network, currentURL, exists)
+
+	retry := network.RetryEnabled(p.config.Proxy.Retry.Enabled)
+	timeout := p.upstreamTimeout(network)
+
 	// Determine if local override URL changed
 	newLocalURL := ""
 	if network.LocalOverrides != nil {
 		newLocalURL = network.LocalOverrides.TargetURL
 	}
 
-	mainChanged := !exists || currentURL != network.TargetURL
+	mainChanged := !exists ||
+		currentURL != network.TargetURL ||
+		currentHedgeURL != network.HedgeTargetURL ||
+		currentMirrorURL != network.MirrorURL ||
+		discoveryChanged(currentResolver, network.Discovery) ||
+		pathMappingChanged(currentMapping, network.PathMapping) ||
+		poolChanged(currentPool, network.TargetURLs) ||
+		!currentAuth.Equal(network.Auth) ||
+		currentRetry != retry ||
+		currentTimeout != network.Timeout
 	localChanged := currentLocalURL != newLocalURL
 
 	if !mainChanged && !localChanged {
@@ -415,34 +899,92 @@ func (p *Proxy) UpdateNetwork(network config.NetworkConfig) error {
 		return nil
 	}
 
+	// Resolve before taking the lock, DNS lookups may be slow
+	var (
+		resolver *discovery.Resolver
+		mapping  *PathMapping
+		pool     *upstreamPool
+	)
+
+	if mainChanged {
+		var err error
+
+		mapping, err = NewPathMapping(network.PathMapping)
+		if err != nil {
+			return fmt.Errorf("invalid path mapping for %s: %w", network.Name, err)
+		}
+
+		pool, err = p.newPool(network)
+		if err != nil {
+			return fmt.Errorf("failed to update upstream pool for %s: %w", network.Name, err)
+		}
+
+		resolver, err = p.startResolver(network)
+		if err != nil {
+			return fmt.Errorf("failed to update resolver for %s: %w", network.Name, err)
+		}
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	if mainChanged {
-		proxy, err := p.createReverseProxy(network.TargetURL, network.Name)
+		proxy, err := p.createReverseProxy(reverseProxyOptions{
+			targetURL: network.TargetURL,
+			network:   network.Name,
+			hedgeURL:  network.HedgeTargetURL,
+			resolver:  resolver,
+			mapping:   mapping,
+			auth:      network.Auth,
+			pool:      pool,
+			retry:     retry,
+			timeout:   timeout,
+		})
 		if err != nil {
+			p.stopResolver(resolver)
+
 			return fmt.Errorf("failed to update proxy for %s: %w", network.Name, err)
 		}
 
+		mirror, err := p.createMirror(network, mapping, timeout)
+		if err != nil {
+			p.stopResolver(resolver)
+
+			return fmt.Errorf("failed to update mirror proxy for %s: %w", network.Name, err)
+		}
+
+		p.replaceResolver(network.Name, resolver)
+		p.replacePool(network.Name, pool)
+
+		// Probe results for the old backends no longer apply
+		if currentURL != network.TargetURL || poolChanged(currentPool, network.TargetURLs) {
+			p.prober.Forget(network.Name)
+		}
+
 		p.proxies[network.Name] = proxy
 		p.proxyURLs[network.Name] = network.TargetURL
+		p.setPathMapping(network.Name, network.PathMapping)
+		p.setAuth(network.Name, network.Auth)
+		p.setRetry(network.Name, retry)
+		p.setTimeout(network.Name, network.Timeout)
+
+		if network.HedgeTargetURL != "" {
+			p.hedgeURLs[network.Name] = network.HedgeTargetURL
+		} else {
+			delete(p.hedgeURLs, network.Name)
+		}
+
+		p.setMirror(network, mirror)
 	}
 
 	// Update local proxy state
 	if localChanged {
-		// Clean up old local proxy state
-		delete(p.localProxies, network.Name)
-		delete(p.localProxyURLs, network.Name)
-		delete(p.localTables, network.Name)
-
-		if network.LocalOverrides != nil {
-			if err := p.setupLocalProxy(network); err != nil {
-				return fmt.Errorf(
-					"failed to update local proxy for %s: %w",
-					network.Name, err,
-				)
-			}
+		localProxy, err := p.createLocalProxy(network)
+		if err != nil {
+			return fmt.Errorf("failed to update local proxy for %s: %w", network.Name, err)
 		}
+
+		p.setLocalProxy(network, localProxy)
 	}
 
 	p.logger.WithFields(logrus.Fields{
@@ -453,15 +995,64 @@ func (p *Proxy) UpdateNetwork(network config.NetworkConfig) error {
 	return nil
 }
 
-// setupLocalProxy creates and stores a local reverse proxy for hybrid mode.
+// createMirror creates the proxy of the network's mirror_url, nil when the
+// network has none. Mirrors are never hedged, retried or failed over.
+func (p *Proxy) createMirror(network config.NetworkConfig, mapping *PathMapping, timeout time.Duration) (*httputil.ReverseProxy, error) {
+	if network.MirrorURL == "" {
+		return nil, nil //nolint:nilnil // nil mirror means none is configured.
+	}
+
+	return p.createReverseProxy(reverseProxyOptions{
+		targetURL: network.MirrorURL,
+		network:   network.Name,
+		mapping:   mapping,
+		auth:      network.Auth,
+		timeout:   timeout,
+	})
+}
+
+// setMirror stores the network's mirror proxy, or drops it when nil.
 // Must be called with p.mu held.
-func (p *Proxy) setupLocalProxy(network config.NetworkConfig) error {
-	localProxy, err := p.createReverseProxy(
-		network.LocalOverrides.TargetURL,
-		network.Name+"-local",
-	)
+func (p *Proxy) setMirror(network config.NetworkConfig, mirror *httputil.ReverseProxy) {
+	if mirror == nil {
+		delete(p.mirrors, network.Name)
+		delete(p.mirrorURLs, network.Name)
+
+		return
+	}
+
+	p.mirrors[network.Name] = mirror
+	p.mirrorURLs[network.Name] = network.MirrorURL
+}
+
+// createLocalProxy creates the local reverse proxy of hybrid mode, nil when
+// the network has no local overrides.
+func (p *Proxy) createLocalProxy(network config.NetworkConfig) (*httputil.ReverseProxy, error) {
+	if network.LocalOverrides == nil {
+		return nil, nil //nolint:nilnil // nil proxy means no local overrides.
+	}
+
+	localProxy, err := p.createReverseProxy(reverseProxyOptions{
+		targetURL: network.LocalOverrides.TargetURL,
+		network:   network.Name + "-local",
+		timeout:   p.config.Proxy.Timeouts.Default,
+	})
 	if err != nil {
-		return fmt.Errorf("create local reverse proxy: %w", err)
+		return nil, fmt.Errorf("create local reverse proxy: %w", err)
+	}
+
+	return localProxy, nil
+}
+
+// setLocalProxy stores the network's local proxy and its tables, or drops
+// them when nil. Must be called with p.mu held.
+func (p *Proxy) setLocalProxy(network config.NetworkConfig, localProxy *httputil.ReverseProxy) {
+	if localProxy == nil {
+		delete(p.localProxies, network.Name)
+		delete(p.localProxyURLs, network.Name)
+		delete(p.localTables, network.Name)
+
+		return
 	}
 
 	p.localProxies[network.Name] = localProxy
@@ -479,27 +1070,204 @@ func (p *Proxy) setupLocalProxy(network config.NetworkConfig) error {
 		"local_target": network.LocalOverrides.TargetURL,
 		"local_tables": network.LocalOverrides.Tables,
 	}).Info("Local override proxy configured for hybrid mode")
+}
 
-	return nil
+// startResolver creates and starts a DNS resolver when discovery is configured.
+// Returns nil if the network does not use discovery.
+func (p *Proxy) startResolver(network config.NetworkConfig) (*discovery.Resolver, error) {
+	if network.Discovery == nil {
+		return nil, nil //nolint:nilnil // nil resolver means discovery is disabled.
+	}
+
+	resolver, err := discovery.NewResolver(
+		p.logger.WithField("network", network.Name),
+		discovery.Config{
+			Mode:            network.Discovery.Mode,
+			Service:         network.Discovery.Service,
+			Protocol:        network.Discovery.Protocol,
+			RefreshInterval: network.Discovery.RefreshInterval,
+		},
+		network.TargetURL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.Start(p.ctx)
+
+	return resolver, nil
 }
 
-// writeJSONError writes a JSON error response.
-func (p *Proxy) writeJSONError(w http.ResponseWriter, statusCode int, message string, network string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+// stopResolver stops a resolver if non-nil.
+func (p *Proxy) stopResolver(resolver *discovery.Resolver) {
+	if resolver != nil {
+		resolver.Stop()
+	}
+}
 
-	response := map[string]string{
-		"error": message,
+// replaceResolver stops the network's current resolver and stores the new one.
+// Must be called with p.mu held.
+func (p *Proxy) replaceResolver(networkName string, resolver *discovery.Resolver) {
+	if current, ok := p.resolvers[networkName]; ok && current != resolver {
+		current.Stop()
+		delete(p.resolvers, networkName)
 	}
 
-	if network != "" {
-		response["network"] = network
+	if resolver != nil {
+		p.resolvers[networkName] = resolver
 	}
+}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		p.logger.WithFields(logrus.Fields{
-			"error":       err.Error(),
-			"status_code": statusCode,
-		}).Error("Failed to encode error response")
+// newPool creates an upstream pool when the network has several target URLs.
+// Returns nil otherwise. The pool is started by replacePool.
+func (p *Proxy) newPool(network config.NetworkConfig) (*upstreamPool, error) {
+	if len(network.TargetURLs) < 2 {
+		return nil, nil //nolint:nilnil // nil pool means a single upstream.
+	}
+
+	return newUpstreamPool(p.logger, p.config.Proxy.Failover, network.Name, network.TargetURLs, network.Auth)
+}
+
+// replacePool stops the network's current upstream pool, then starts and
+// stores the new one. Must be called with p.mu held.
+func (p *Proxy) replacePool(networkName string, pool *upstreamPool) {
+	if current, ok := p.pools[networkName]; ok && current != pool {
+		current.Stop()
+		delete(p.pools, networkName)
 	}
+
+	if pool != nil {
+		if p.pools == nil {
+			p.pools = make(map[string]*upstreamPool)
+		}
+
+		pool.Start(p.ctx)
+		p.pools[networkName] = pool
+	}
+}
+
+// discoveryChanged reports whether the desired discovery config differs from
+// the config of the currently running resolver.
+func discoveryChanged(current *discovery.Resolver, desired *config.DiscoveryConfig) bool {
+	if current == nil || desired == nil {
+		return (current == nil) != (desired == nil)
+	}
+
+	return current.Config() != discovery.Config{
+		Mode:            desired.Mode,
+		Service:         desired.Service,
+		Protocol:        desired.Protocol,
+		RefreshInterval: desired.RefreshInterval,
+	}
+}
+
+// setPathMapping records the network's path mapping config for change detection.
+// Must be called with p.mu held.
+func (p *Proxy) setPathMapping(networkName string, mapping *config.PathMappingConfig) {
+	if p.pathMappings == nil {
+		p.pathMappings = make(map[string]*config.PathMappingConfig)
+	}
+
+	if mapping == nil {
+		delete(p.pathMappings, networkName)
+
+		return
+	}
+
+	p.pathMappings[networkName] = mapping
+}
+
+// setAuth records the network's upstream credentials for change detection.
+// Must be called with p.mu held.
+func (p *Proxy) setAuth(networkName string, auth *config.UpstreamAuthConfig) {
+	if p.auths == nil {
+		p.auths = make(map[string]*config.UpstreamAuthConfig)
+	}
+
+	if auth == nil {
+		delete(p.auths, networkName)
+
+		return
+	}
+
+	p.auths[networkName] = auth
+}
+
+// setRetry records whether the network's reads are retried. Must be called
+// with p.mu held.
+func (p *Proxy) setRetry(networkName string, retry bool) {
+	if p.retries == nil {
+		p.retries = make(map[string]bool)
+	}
+
+	if !retry {
+		delete(p.retries, networkName)
+
+		return
+	}
+
+	p.retries[networkName] = true
+}
+
+// setTimeout records the network's own upstream timeout. Must be called with
+// p.mu held.
+func (p *Proxy) setTimeout(networkName string, timeout time.Duration) {
+	if p.timeouts == nil {
+		p.timeouts = make(map[string]time.Duration)
+	}
+
+	if timeout == 0 {
+		delete(p.timeouts, networkName)
+
+		return
+	}
+
+	p.timeouts[networkName] = timeout
+}
+
+// upstreamTimeout returns how long the network's upstream attempts wait for
+// response headers: the network's own timeout, or proxy.timeouts.default.
+func (p *Proxy) upstreamTimeout(network config.NetworkConfig) time.Duration {
+	if network.Timeout > 0 {
+		return network.Timeout
+	}
+
+	return p.config.Proxy.Timeouts.Default
+}
+
+// pathMappingChanged reports whether two path mapping configs differ.
+func pathMappingChanged(current, desired *config.PathMappingConfig) bool {
+	if current == nil || desired == nil {
+		return (current == nil) != (desired == nil)
+	}
+
+	return current.StripPrefix != desired.StripPrefix ||
+		current.AddPrefix != desired.AddPrefix ||
+		!slices.Equal(current.Rewrites, desired.Rewrites)
+}
+
+// quarantineReason returns why cartographoor data for a network was
+// quarantined, or "" if it was not.
+func (p *Proxy) quarantineReason(ctx context.Context, network string) string {
+	if p.provider == nil {
+		return ""
+	}
+
+	cartNet, err := p.provider.GetNetwork(ctx, network)
+	if err != nil || cartNet.Status != cartographoor.NetworkStatusQuarantined {
+		return ""
+	}
+
+	return cartNet.StatusReason
+}
+
+// writeError writes a problem response, naming the network if there is one.
+func (p *Proxy) writeError(w http.ResponseWriter, r *http.Request, status int, code, message string, network string) {
+	problem := httperr.New(status, code, message)
+
+	if network != "" {
+		problem.With("network", network)
+	}
+
+	problem.Write(w, r)
 }
@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +18,7 @@ import (
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/negcache"
 )
 
 func TestProxy_AddNetwork(t *testing.T) {
@@ -50,6 +53,29 @@ func TestProxy_AddNetwork(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid target URL",
 		},
+		{
+			name: "invalid mirror URL leaves no partial network",
+			network: config.NetworkConfig{
+				Name:      "mirrored",
+				TargetURL: "http://localhost:8080",
+				MirrorURL: "://invalid-url",
+			},
+			expectError: true,
+			errorMsg:    "failed to create mirror proxy",
+		},
+		{
+			name: "invalid local override URL leaves no partial network",
+			network: config.NetworkConfig{
+				Name:      "hybrid",
+				TargetURL: "http://localhost:8080",
+				LocalOverrides: &config.LocalOverridesConfig{
+					TargetURL: "://invalid-url",
+					Tables:    []string{"fct_block"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "failed to create local proxy",
+		},
 	}
 
 	for _, tt := range tests {
@@ -77,6 +103,9 @@ func TestProxy_AddNetwork(t *testing.T) {
 				if tt.errorMsg != "" {
 					assert.Contains(t, err.Error(), tt.errorMsg)
 				}
+
+				assert.NotContains(t, p.proxies, tt.network.Name)
+				assert.NotContains(t, p.proxyURLs, tt.network.Name)
 			} else {
 				require.NoError(t, err)
 				assert.Contains(t, p.proxies, tt.network.Name)
@@ -290,6 +319,56 @@ func TestProxy_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestProxy_ServeHTTP_UnknownNetworkCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := &config.Config{}
+	require.NoError(t, cfg.NegativeCache.Validate())
+
+	// Only the first request for an unknown network looks it up in cartographoor
+	mockProvider := cartomocks.NewMockProvider(ctrl)
+	mockProvider.EXPECT().
+		GetNetwork(gomock.Any(), "devnet-1").
+		Return(nil, assert.AnError).
+		Times(1)
+
+	p := &Proxy{
+		config:          cfg,
+		proxies:         make(map[string]*httputil.ReverseProxy),
+		proxyURLs:       make(map[string]string),
+		localProxies:    make(map[string]*httputil.ReverseProxy),
+		localProxyURLs:  make(map[string]string),
+		localTables:     make(map[string]map[string]bool),
+		logger:          logger,
+		provider:        mockProvider,
+		unknownNetworks: negcache.New("test", cfg.NegativeCache),
+	}
+
+	for range 3 {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block", http.NoBody))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	// Adding the network forgets it was unknown
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "devnet-1", TargetURL: backend.URL}))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
 func TestProxy_SyncNetworks(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -637,3 +716,54 @@ func TestProxy_ConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestProxy_BackgroundLoopsExitOnCancel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	network := config.NetworkConfig{Name: "mainnet", TargetURLs: []string{backend.URL + "/a", backend.URL + "/b"}}
+	require.NoError(t, network.Validate())
+
+	cfg := &config.Config{Networks: []config.NetworkConfig{network}}
+	cfg.Proxy.HealthProbe.Enabled = true
+	require.NoError(t, cfg.Proxy.HealthProbe.Validate())
+	require.NoError(t, cfg.Proxy.Failover.Validate())
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	p, err := New(ctx, logger, cfg, nil, setupTestWallclock(t), nil, nil, nil)
+	require.NoError(t, err)
+
+	defer p.Shutdown() //nolint:errcheck // test
+
+	require.Contains(t, p.pools, "mainnet")
+
+	// Canceling the lifecycle context alone stops probing and pool health checks
+	cancel()
+
+	waitGroupDone(t, &p.prober.wg, "health prober")
+	waitGroupDone(t, &p.pools["mainnet"].wg, "upstream pool")
+}
+
+// waitGroupDone fails the test if wg does not finish within a few seconds.
+func waitGroupDone(t *testing.T, wg *sync.WaitGroup, name string) {
+	t.Helper()
+
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s goroutines still running after cancel", name)
+	}
+}
@@ -0,0 +1,186 @@
+//nolint:tagliatelle // superior snake-case yo.
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+// retirementTimeout bounds the Redis and bounds calls of recording a tombstone.
+const retirementTimeout = 5 * time.Second
+
+var retiredRequestsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "proxy_retired_network_requests_total",
+		Help: "Total number of requests for retired networks answered with 410 Gone",
+	},
+	[]string{"network"},
+)
+
+// Tombstone records a network that was removed from the proxy, kept in Redis
+// for the retirement grace period.
+type Tombstone struct {
+	Network     string             `json:"network"`
+	RetiredAt   time.Time          `json:"retired_at"`
+	FinalBounds *bounds.BoundsData `json:"final_bounds,omitempty"` // Last known bounds, if any were left
+}
+
+// retirements stores the tombstones of retired networks in Redis, where every
+// instance finds them.
+type retirements struct {
+	cfg    config.RetirementConfig
+	redis  redis.Client
+	bounds bounds.Provider
+	log    logrus.FieldLogger
+}
+
+// newRetirements returns nil when retirement is disabled or there is no Redis
+// client to keep tombstones in.
+func newRetirements(
+	log logrus.FieldLogger,
+	cfg config.RetirementConfig,
+	redisClient redis.Client,
+	boundsProvider bounds.Provider,
+) *retirements {
+	if !cfg.Enabled || redisClient == nil {
+		return nil
+	}
+
+	return &retirements{
+		cfg:    cfg,
+		redis:  redisClient,
+		bounds: boundsProvider,
+		log:    log.WithField("component", "retirements"),
+	}
+}
+
+// retire records a tombstone for network with its last known bounds. The
+// first instance to retire a network sets retired_at, later ones leave it.
+func (r *retirements) retire(ctx context.Context, network string) {
+	if r == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, retirementTimeout)
+	defer cancel()
+
+	tombstone := Tombstone{
+		Network:   network,
+		RetiredAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	// Stale bounds come with an error, they are the last known ones all the same
+	if r.bounds != nil {
+		if data, _ := r.bounds.GetBounds(ctx, network); data != nil {
+			tombstone.FinalBounds = data
+		}
+	}
+
+	data, err := json.Marshal(tombstone)
+	if err != nil {
+		r.log.WithError(err).WithField("network", network).Error("Failed to marshal tombstone")
+
+		return
+	}
+
+	created, err := r.redis.SetNX(ctx, r.cfg.KeyPrefix+network, string(data), r.cfg.GracePeriod)
+	if err != nil {
+		r.log.WithError(err).WithField("network", network).Error("Failed to store tombstone")
+
+		return
+	}
+
+	if created {
+		r.log.WithFields(logrus.Fields{
+			"network":      network,
+			"grace_period": r.cfg.GracePeriod,
+		}).Info("Retired network")
+	}
+}
+
+// revive deletes the tombstone of a network that is proxied again.
+func (r *retirements) revive(ctx context.Context, network string) {
+	if r == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, retirementTimeout)
+	defer cancel()
+
+	if err := r.redis.Del(ctx, r.cfg.KeyPrefix+network); err != nil {
+		r.log.WithError(err).WithField("network", network).Warn("Failed to delete tombstone")
+	}
+}
+
+// lookup returns the tombstone of network if it was retired within the grace
+// period.
+func (r *retirements) lookup(ctx context.Context, network string) (*Tombstone, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	data, err := r.redis.Get(ctx, r.cfg.KeyPrefix+network)
+	if err != nil {
+		if !errors.Is(err, redis.ErrNotFound) {
+			r.log.WithError(err).WithField("network", network).Warn("Failed to look up tombstone")
+		}
+
+		return nil, false
+	}
+
+	var tombstone Tombstone
+	if err := json.Unmarshal([]byte(data), &tombstone); err != nil {
+		r.log.WithError(err).WithField("network", network).Warn("Failed to unmarshal tombstone")
+
+		return nil, false
+	}
+
+	return &tombstone, true
+}
+
+// retire records a tombstone for a network that is being removed. Networks
+// disabled in config or quarantined by cartographoor are not retired, their
+// own responses explain why they are unavailable.
+func (p *Proxy) retire(ctx context.Context, network string) {
+	if p.retirements == nil {
+		return
+	}
+
+	if networkCfg, err := p.config.GetNetworkByName(network); err == nil && networkCfg.Enabled != nil && !*networkCfg.Enabled {
+		return
+	}
+
+	if p.quarantineReason(ctx, network) != "" {
+		return
+	}
+
+	p.retirements.retire(ctx, network)
+}
+
+// writeRetired answers a request for a retired network with 410 Gone and the
+// retirement metadata.
+func (p *Proxy) writeRetired(w http.ResponseWriter, r *http.Request, tombstone *Tombstone) {
+	retiredRequestsTotal.WithLabelValues(tombstone.Network).Inc()
+
+	problem := httperr.New(http.StatusGone, httperr.CodeNetworkRetired, "network retired").
+		With("network", tombstone.Network).
+		With("retired_at", tombstone.RetiredAt)
+
+	if tombstone.FinalBounds != nil {
+		problem.With("final_bounds", tombstone.FinalBounds)
+	}
+
+	problem.Write(w, r)
+}
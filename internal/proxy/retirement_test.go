@@ -0,0 +1,124 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	boundsmocks "github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	cartomocks "github.com/ethpandaops/lab-backend/internal/cartographoor/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func TestProxy_RetiredNetworks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	redisClient, mr := redistest.New(t, logger)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	cfg := &config.Config{}
+	cfg.Proxy.Retirement.Enabled = true
+	require.NoError(t, cfg.Proxy.Retirement.Validate())
+
+	active := map[string]*cartographoor.Network{
+		"devnet-1": {Name: "devnet-1", TargetURL: backend.URL},
+	}
+
+	mockProvider := cartomocks.NewMockProvider(ctrl)
+	mockProvider.EXPECT().GetActiveNetworks(gomock.Any()).DoAndReturn(
+		func(_ any) map[string]*cartographoor.Network { return active },
+	).AnyTimes()
+	mockProvider.EXPECT().GetNetwork(gomock.Any(), gomock.Any()).Return(nil, assert.AnError).AnyTimes()
+
+	finalBounds := &bounds.BoundsData{Tables: map[string]bounds.TableBounds{"fct_block": {Min: 1, Max: 42}}}
+
+	mockBounds := boundsmocks.NewMockProvider(ctrl)
+	mockBounds.EXPECT().GetBounds(gomock.Any(), "devnet-1").Return(finalBounds, nil).Times(1)
+
+	p := &Proxy{
+		config:         cfg,
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+		provider:       mockProvider,
+		retirements:    newRetirements(logger, cfg.Proxy.Retirement, redisClient, mockBounds),
+	}
+
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devnet-1/fct_block", http.NoBody))
+
+		return rec
+	}
+
+	require.NoError(t, p.SyncNetworks(t.Context()))
+	assert.Equal(t, http.StatusOK, serve().Code)
+
+	// Cartographoor drops the network
+	active = map[string]*cartographoor.Network{}
+	require.NoError(t, p.SyncNetworks(t.Context()))
+
+	rec := serve()
+	require.Equal(t, http.StatusGone, rec.Code)
+
+	var body struct {
+		Error       string             `json:"error"`
+		Network     string             `json:"network"`
+		RetiredAt   time.Time          `json:"retired_at"`
+		FinalBounds *bounds.BoundsData `json:"final_bounds"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	assert.Equal(t, "network retired", body.Error)
+	assert.Equal(t, "devnet-1", body.Network)
+	assert.False(t, body.RetiredAt.IsZero())
+	assert.Equal(t, finalBounds, body.FinalBounds)
+
+	// Unknown once the grace period is over
+	mr.FastForward(cfg.Proxy.Retirement.GracePeriod)
+	assert.Equal(t, http.StatusNotFound, serve().Code)
+}
+
+func TestProxy_RevivedNetworkDropsTombstone(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	redisClient, _ := redistest.New(t, logger)
+
+	cfg := config.RetirementConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	r := newRetirements(logger, cfg, redisClient, nil)
+
+	r.retire(t.Context(), "devnet-1")
+
+	_, ok := r.lookup(t.Context(), "devnet-1")
+	require.True(t, ok)
+
+	r.revive(t.Context(), "devnet-1")
+
+	_, ok = r.lookup(t.Context(), "devnet-1")
+	assert.False(t, ok)
+}
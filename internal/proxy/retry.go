@@ -0,0 +1,88 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// maxDrainBytes caps how much of a retried response body is read so its
+// connection can be reused.
+const maxDrainBytes = 64 << 10
+
+var retriesTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "proxy_retries_total",
+		Help: "Total number of proxied reads sent again after a transient upstream failure, by reason (status code or error)",
+	},
+	[]string{"network", "reason"},
+)
+
+// retryTransport sends bodiless GET and HEAD requests again, with exponential
+// backoff, when the upstream fails to respond or answers with a retryable
+// status. The last attempt's response or error is returned as is.
+type retryTransport struct {
+	base    http.RoundTripper
+	cfg     config.RetryConfig
+	network string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := (req.Method == http.MethodGet || req.Method == http.MethodHead) &&
+		(req.Body == nil || req.Body == http.NoBody)
+	if !retryable {
+		return t.base.RoundTrip(req)
+	}
+
+	backoff := t.cfg.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+
+		// The client left, there is no one to retry for; an overloaded
+		// upstream should not get more requests
+		if req.Context().Err() != nil || attempt >= t.cfg.MaxAttempts || errors.Is(err, upstream.ErrOverloaded) {
+			return resp, err
+		}
+
+		var reason string
+
+		switch {
+		case err != nil:
+			reason = "error"
+		case slices.Contains(t.cfg.StatusCodes, resp.StatusCode):
+			reason = strconv.Itoa(resp.StatusCode)
+
+			// Let the connection be reused for the next attempt
+			_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		retriesTotal.WithLabelValues(t.network, reason).Inc()
+
+		timer := time.NewTimer(jitter.Duration(backoff))
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, t.cfg.MaxBackoff)
+	}
+}
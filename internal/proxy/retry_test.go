@@ -0,0 +1,97 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cfg := config.RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	require.NoError(t, cfg.Validate())
+
+	var statuses []int
+
+	transport := &retryTransport{
+		base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			if len(statuses) == 0 {
+				return nil, assert.AnError
+			}
+
+			status := statuses[0]
+			statuses = statuses[1:]
+
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		}),
+		cfg:     cfg,
+		network: "mainnet",
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		want     int
+		left     int
+	}{
+		{
+			name:     "retries until success",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "returns the last response after max_attempts",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusOK},
+			want:     http.StatusGatewayTimeout,
+			left:     1,
+		},
+		{
+			name:     "other statuses are not retried",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusInternalServerError, http.StatusOK},
+			want:     http.StatusInternalServerError,
+			left:     1,
+		},
+		{
+			name:     "writes are not retried",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:     http.StatusServiceUnavailable,
+			left:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses = tt.statuses
+
+			body := io.Reader(http.NoBody)
+			if tt.method == http.MethodPost {
+				body = strings.NewReader("{}")
+			}
+
+			resp, err := transport.RoundTrip(httptest.NewRequest(tt.method, "http://a/api/fct_block", body))
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.want, resp.StatusCode)
+			assert.Len(t, statuses, tt.left)
+		})
+	}
+
+	// Connection errors are retried too, the last one is returned
+	statuses = nil
+
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://a/api/fct_block", http.NoBody)) //nolint:bodyclose // no response on error
+	require.ErrorIs(t, err, assert.AnError)
+}
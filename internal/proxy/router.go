@@ -2,9 +2,75 @@ package proxy
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
 )
 
+// PathMapping is a compiled per-network upstream path mapping.
+// A nil PathMapping leaves paths unchanged.
+type PathMapping struct {
+	stripPrefix string
+	addPrefix   string
+	rewrites    []pathRewrite
+}
+
+type pathRewrite struct {
+	match   *regexp.Regexp
+	replace string
+}
+
+// NewPathMapping compiles a path mapping config. Returns nil for a nil config.
+func NewPathMapping(cfg *config.PathMappingConfig) (*PathMapping, error) {
+	if cfg == nil {
+		return nil, nil //nolint:nilnil // nil mapping means the default convention.
+	}
+
+	mapping := &PathMapping{
+		stripPrefix: strings.TrimSuffix(cfg.StripPrefix, "/"),
+		addPrefix:   strings.TrimSuffix(cfg.AddPrefix, "/"),
+		rewrites:    make([]pathRewrite, 0, len(cfg.Rewrites)),
+	}
+
+	for i, rewrite := range cfg.Rewrites {
+		match, err := regexp.Compile(rewrite.Match)
+		if err != nil {
+			return nil, fmt.Errorf("rewrites[%d] invalid regex: %w", i, err)
+		}
+
+		mapping.rewrites = append(mapping.rewrites, pathRewrite{match: match, replace: rewrite.Replace})
+	}
+
+	return mapping, nil
+}
+
+// Apply maps a default-convention upstream path: strip prefix, regex rewrites, add prefix.
+func (m *PathMapping) Apply(path string) string {
+	if m == nil {
+		return path
+	}
+
+	if m.stripPrefix != "" {
+		// Only strip whole segments: /api/v1 strips /api/v1/x but not /api/v10
+		if rest, ok := strings.CutPrefix(path, m.stripPrefix); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			path = rest
+		}
+	}
+
+	for _, rewrite := range m.rewrites {
+		path = rewrite.match.ReplaceAllString(path, rewrite.replace)
+	}
+
+	path = m.addPrefix + path
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return path
+}
+
 // ExtractNetwork extracts network name from URL path.
 // Path format: /api/v1/{network}/...
 // Returns: network name, remaining path, error.
@@ -36,10 +102,11 @@ func ExtractNetwork(path string) (network string, remainingPath string, err erro
 	return network, remainingPath, nil
 }
 
-// RewritePath removes the network segment from path for backend forwarding.
+// RewritePath removes the network segment from path for backend forwarding,
+// then applies the network's path mapping, if any.
 // Input: /api/v1/{network}/fct_block?slot_eq=1000.
 // Output: /api/v1/fct_block (query preserved automatically by ReverseProxy).
-func RewritePath(path string) (string, error) {
+func RewritePath(path string, mapping *PathMapping) (string, error) {
 	// Use ExtractNetwork to get remainingPath
 	_, remainingPath, err := ExtractNetwork(path)
 	if err != nil {
@@ -47,7 +114,7 @@ func RewritePath(path string) (string, error) {
 	}
 
 	// Return /api/v1 + remainingPath
-	return "/api/v1" + remainingPath, nil
+	return mapping.Apply("/api/v1" + remainingPath), nil
 }
 
 // ExtractTableName returns the first path segment from a remaining path.
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
 )
 
 func TestExtractNetwork(t *testing.T) {
@@ -152,7 +154,7 @@ func TestRewritePath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := RewritePath(tt.input)
+			result, err := RewritePath(tt.input, nil)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -269,3 +271,64 @@ func TestValidatePath(t *testing.T) {
 		})
 	}
 }
+
+func TestPathMapping_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *config.PathMappingConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "nil mapping keeps default convention",
+			cfg:      nil,
+			input:    "/api/v1/fct_block",
+			expected: "/api/v1/fct_block",
+		},
+		{
+			name:     "strip prefix serves from root",
+			cfg:      &config.PathMappingConfig{StripPrefix: "/api/v1"},
+			input:    "/api/v1/fct_block",
+			expected: "/fct_block",
+		},
+		{
+			name:     "strip prefix only matches whole segments",
+			cfg:      &config.PathMappingConfig{StripPrefix: "/api/v1"},
+			input:    "/api/v10/fct_block",
+			expected: "/api/v10/fct_block",
+		},
+		{
+			name:     "strip prefix leaving nothing maps to root",
+			cfg:      &config.PathMappingConfig{StripPrefix: "/api/v1/"},
+			input:    "/api/v1",
+			expected: "/",
+		},
+		{
+			name:     "add prefix",
+			cfg:      &config.PathMappingConfig{AddPrefix: "/cbt/"},
+			input:    "/api/v1/fct_block",
+			expected: "/cbt/api/v1/fct_block",
+		},
+		{
+			name: "strip, rewrite and add in order",
+			cfg: &config.PathMappingConfig{
+				StripPrefix: "/api/v1",
+				AddPrefix:   "/v2",
+				Rewrites: []config.PathRewriteConfig{
+					{Match: `^/fct_(\w+)`, Replace: "/tables/fct_$1"},
+				},
+			},
+			input:    "/api/v1/fct_block/summary",
+			expected: "/v2/tables/fct_block/summary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := NewPathMapping(tt.cfg)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, mapping.Apply(tt.input))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// errUpstreamTimeout is the cause of attempts canceled because the upstream
+// did not start responding in time.
+var errUpstreamTimeout = errors.New("upstream timed out")
+
+// timeoutContextKey carries the upstream timeout of a request's path.
+type timeoutContextKey struct{}
+
+// withUpstreamTimeout sets the upstream timeout for the request's path.
+func withUpstreamTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutContextKey{}, timeout)
+}
+
+// upstreamTimeoutFrom returns the upstream timeout set for the request's
+// path, if any.
+func upstreamTimeoutFrom(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(timeoutContextKey{}).(time.Duration)
+
+	return timeout, ok
+}
+
+// pathTimeout is a compiled path timeout override.
+type pathTimeout struct {
+	pattern *regexp.Regexp
+	timeout time.Duration
+}
+
+// timeoutPolicy picks the upstream timeout of a request by its path.
+type timeoutPolicy struct {
+	paths []pathTimeout
+}
+
+// newTimeoutPolicy compiles the configured path timeouts.
+// Returns nil if there are none.
+func newTimeoutPolicy(cfg config.UpstreamTimeoutsConfig) (*timeoutPolicy, error) {
+	if len(cfg.Paths) == 0 {
+		return nil, nil //nolint:nilnil // nil policy means no path overrides.
+	}
+
+	paths := make([]pathTimeout, 0, len(cfg.Paths))
+
+	for _, path := range cfg.Paths {
+		compiled, err := regexp.Compile(path.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout path pattern %q: %w", path.Pattern, err)
+		}
+
+		paths = append(paths, pathTimeout{pattern: compiled, timeout: path.Timeout})
+	}
+
+	return &timeoutPolicy{paths: paths}, nil
+}
+
+// match returns the timeout of the first path override matching the inbound
+// request path.
+func (t *timeoutPolicy) match(path string) (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	for _, p := range t.paths {
+		if p.pattern.MatchString(path) {
+			return p.timeout, true
+		}
+	}
+
+	return 0, false
+}
+
+// timeoutTransport cancels an upstream attempt that has not returned response
+// headers within the request's path timeout, or the network's timeout. Each
+// attempt, including retries, hedges and failovers, gets the full timeout.
+// Reading the body is not limited.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration // Network timeout, 0 waits indefinitely
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.timeout
+	if pathTimeout, ok := upstreamTimeoutFrom(req.Context()); ok {
+		timeout = pathTimeout
+	}
+
+	if timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(timeout, func() { cancel(errUpstreamTimeout) })
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+
+	if !timer.Stop() {
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		cancel(nil)
+
+		return nil, fmt.Errorf("no response within %v: %w", timeout, errUpstreamTimeout)
+	}
+
+	if err != nil {
+		cancel(nil)
+
+		return nil, err
+	}
+
+	// Upgraded connections need the body as is, their context ends with the
+	// inbound request
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		return resp, nil
+	}
+
+	// The attempt's context lives until the body is read
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: func() { cancel(nil) }}
+
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestProxy_ServeHTTP_UpstreamTimeouts(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		w.Write([]byte(`{"ok":true}`)) //nolint:errcheck // test
+	}))
+	defer backend.Close()
+
+	cfg := &config.Config{}
+	cfg.Proxy.Timeouts = config.UpstreamTimeoutsConfig{
+		Default: 10 * time.Millisecond,
+		Paths: []config.PathTimeoutConfig{
+			{Pattern: "^/api/v1/[^/]+/fct_attestation", Timeout: 5 * time.Second},
+		},
+	}
+	require.NoError(t, cfg.Proxy.Timeouts.Validate())
+
+	policy, err := newTimeoutPolicy(cfg.Proxy.Timeouts)
+	require.NoError(t, err)
+
+	p := &Proxy{
+		config:         cfg,
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		timeoutPolicy:  policy,
+		logger:         logger,
+	}
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "sepolia", TargetURL: backend.URL, Timeout: 5 * time.Second}))
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "default timeout", path: "/api/v1/mainnet/fct_block", want: http.StatusGatewayTimeout},
+		{name: "path timeout", path: "/api/v1/mainnet/fct_attestation_range", want: http.StatusOK},
+		{name: "network timeout", path: "/api/v1/sepolia/fct_block", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
@@ -1,14 +1,224 @@
 package proxy
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/ethpandaops/lab-backend/internal/wallclock"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/requestid"
+	"github.com/ethpandaops/lab-backend/internal/wallclock"
 )
 
+// SlotTransformHeader reports on responses that slot filters were forwarded
+// untransformed.
+const SlotTransformHeader = "X-Lab-Slot-Transform"
+
+// TransformedHeader summarizes on responses the query parameters rewritten
+// before proxying.
+const TransformedHeader = "X-Lab-Transformed"
+
+// slotPassthroughWarning is the Warning header sent with passthrough responses.
+const slotPassthroughWarning = `299 lab-backend "slot filters were forwarded without transformation to slot_start_date_time"`
+
+var slotFilterRequestsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "proxy_slot_filter_requests_total",
+		Help: "Total number of proxied requests with slot filters by slot transform mode",
+	},
+	[]string{"network", "mode"},
+)
+
+// QueryRewrite is one query parameter rewritten before proxying.
+type QueryRewrite struct {
+	From string `json:"from"` // e.g. slot_eq=1000
+	To   string `json:"to"`   // e.g. slot_start_date_time_eq=1606836023
+}
+
+// QueryTransform is how a request query is transformed before proxying.
+type QueryTransform struct {
+	Mode        string         `json:"mode"`
+	Original    string         `json:"original"`
+	Transformed string         `json:"transformed"`
+	Rewrites    []QueryRewrite `json:"rewrites"`
+}
+
+// summary formats the rewrites for the TransformedHeader.
+func (t QueryTransform) summary() string {
+	parts := make([]string, 0, len(t.Rewrites))
+	for _, rw := range t.Rewrites {
+		parts = append(parts, rw.From+" -> "+rw.To)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// TransformQuery returns how the query of a request for a table of a network
+// is transformed before proxying, without proxying it.
+func (p *Proxy) TransformQuery(network, table, rawQuery string) QueryTransform {
+	return p.planTransforms(network, "/"+table, rawQuery).result
+}
+
+// requestTransform is the transform pipeline of a proxied request.
+type requestTransform struct {
+	tc     *TransformContext
+	chain  transformChain
+	result QueryTransform
+}
+
+// planTransforms matches the route's transforms and applies them to the query.
+func (p *Proxy) planTransforms(network, path, rawQuery string) *requestTransform {
+	table := ExtractTableName(path)
+
+	rt := &requestTransform{
+		tc: &TransformContext{
+			Network:   network,
+			Table:     table,
+			SlotMode:  p.slotTransform.Mode(network, table),
+			Wallclock: p.wallclockSvc,
+			Logger:    p.logger,
+		},
+		chain: p.transforms.match(path),
+	}
+
+	rt.result = QueryTransform{
+		Mode:        rt.tc.SlotMode,
+		Original:    rawQuery,
+		Transformed: rawQuery,
+		Rewrites:    []QueryRewrite{},
+	}
+
+	transformed, rewrites := rt.chain.query(rt.tc, rawQuery)
+	if len(rewrites) > 0 {
+		rt.result.Transformed = transformed
+		rt.result.Rewrites = rewrites
+	}
+
+	return rt
+}
+
+// debugTransformPath is served by the proxy itself for every network, returning
+// the transformed query without calling the upstream. An optional table segment
+// selects a per-table slot transform mode.
+const debugTransformPath = "/debug/transform"
+
+// DebugTransformResponse is the response for GET /api/v1/{network}/debug/transform.
+type DebugTransformResponse struct {
+	Network string `json:"network"`
+	Table   string `json:"table,omitempty"`
+	QueryTransform
+}
+
+// debugTransformTable reports whether remainingPath is the debug transform
+// endpoint, and the table it names if any.
+func debugTransformTable(remainingPath string) (string, bool) {
+	rest, ok := strings.CutPrefix(remainingPath, debugTransformPath)
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return "", false
+	}
+
+	return ExtractTableName(rest), true
+}
+
+// serveDebugTransform replies with how the request query would be transformed.
+func (p *Proxy) serveDebugTransform(w http.ResponseWriter, r *http.Request, network, table string) {
+	if r.Method != http.MethodGet {
+		p.writeError(w, r, http.StatusMethodNotAllowed, httperr.CodeMethodNotAllowed, "method not allowed", network)
+
+		return
+	}
+
+	response := DebugTransformResponse{
+		Network:        network,
+		Table:          table,
+		QueryTransform: p.TransformQuery(network, table, r.URL.RawQuery),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		requestid.Logger(r.Context(), p.logger).WithError(err).Error("Failed to encode debug transform response")
+	}
+}
+
+// requestTransformContextKey carries the requestTransform of a request.
+type requestTransformContextKey struct{}
+
+// prepareQuery transforms the query of r and stores the transforms in the
+// request context for the reverse proxy. Rewrites are summarized in the
+// TransformedHeader, and slot filters forwarded untransformed are warned about.
+func (p *Proxy) prepareQuery(w http.ResponseWriter, r *http.Request, network, path string) *http.Request {
+	rt := p.planTransforms(network, path, r.URL.RawQuery)
+	result := rt.result
+
+	if hasSlotFilters(r.URL.RawQuery) {
+		slotFilterRequestsTotal.WithLabelValues(network, result.Mode).Inc()
+
+		if result.Mode == config.SlotTransformModePassthrough {
+			w.Header().Set(SlotTransformHeader, result.Mode)
+			w.Header().Add("Warning", slotPassthroughWarning)
+		}
+	}
+
+	if len(result.Rewrites) > 0 {
+		w.Header().Set(TransformedHeader, result.summary())
+
+		requestid.Logger(r.Context(), p.logger).WithFields(logrus.Fields{
+			"network":     network,
+			"original":    result.Original,
+			"transformed": result.Transformed,
+		}).Debug("Transformed query")
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), requestTransformContextKey{}, rt))
+}
+
+// requestTransformFrom returns the transforms stored by prepareQuery, if any.
+func requestTransformFrom(ctx context.Context) (*requestTransform, bool) {
+	rt, ok := ctx.Value(requestTransformContextKey{}).(*requestTransform)
+
+	return rt, ok
+}
+
+// outboundQuery returns the query to send upstream for an incoming request.
+func outboundQuery(in *http.Request) string {
+	if rt, ok := requestTransformFrom(in.Context()); ok {
+		return rt.result.Transformed
+	}
+
+	return in.URL.RawQuery
+}
+
+// hasSlotFilters reports whether a query string contains a slot filter.
+func hasSlotFilters(rawQuery string) bool {
+	if !strings.Contains(rawQuery, "slot_") {
+		return false
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return false
+	}
+
+	for key, valuesSlice := range values {
+		if isSlot, _, _ := detectSlotFilter(key, valuesSlice); isSlot {
+			return true
+		}
+	}
+
+	return false
+}
+
 // transformQueryParams transforms slot_* filters to slot_start_date_time_* filters.
 // Returns the original query string if transformation fails (fail-open).
 func transformQueryParams(
@@ -17,9 +227,22 @@ func transformQueryParams(
 	wallclockSvc *wallclock.Service,
 	originalQuery string,
 ) string {
+	transformedQuery, _ := transformQuery(logger, networkName, wallclockSvc, originalQuery)
+
+	return transformedQuery
+}
+
+// transformQuery is transformQueryParams, also returning the rewrites made
+// sorted by original parameter.
+func transformQuery(
+	logger logrus.FieldLogger,
+	networkName string,
+	wallclockSvc *wallclock.Service,
+	originalQuery string,
+) (string, []QueryRewrite) {
 	// If no wallclock service or empty query, return original
 	if wallclockSvc == nil || originalQuery == "" {
-		return originalQuery
+		return originalQuery, nil
 	}
 
 	// Parse query string
@@ -31,11 +254,36 @@ func transformQueryParams(
 			"error":   err.Error(),
 		}).Warn("Failed to parse query string, using original")
 
-		return originalQuery
+		return originalQuery, nil
 	}
 
-	// Track if any transformations were made
-	transformed := false
+	transformedValues, rewrites := transformSlotFilters(logger, networkName, wallclockSvc, values)
+
+	// If no transformations were made, return original
+	if len(rewrites) == 0 {
+		return originalQuery, nil
+	}
+
+	// Return transformed query string
+	return transformedValues.Encode(), rewrites
+}
+
+// transformSlotFilters replaces the slot_* filters of a parsed query with
+// slot_start_date_time_* filters, returning the rewrites made sorted by
+// original parameter. Filters whose slot start can't be calculated are kept.
+func transformSlotFilters(
+	logger logrus.FieldLogger,
+	networkName string,
+	wallclockSvc *wallclock.Service,
+	values url.Values,
+) (url.Values, []QueryRewrite) {
+	if wallclockSvc == nil {
+		return values, nil
+	}
+
+	// Track the transformations made
+	var rewrites []QueryRewrite
+
 	transformedValues := make(url.Values)
 
 	// Iterate over each parameter
@@ -67,8 +315,12 @@ func transformQueryParams(
 
 		// Replace slot_* with slot_start_date_time_*
 		newKey := "slot_start_date_time_" + operator
-		transformedValues[newKey] = []string{strconv.FormatUint(uint64(slotStartTime), 10)}
-		transformed = true
+		newValue := strconv.FormatUint(uint64(slotStartTime), 10)
+		transformedValues[newKey] = []string{newValue}
+		rewrites = append(rewrites, QueryRewrite{
+			From: key + "=" + valuesSlice[0],
+			To:   newKey + "=" + newValue,
+		})
 
 		logger.WithFields(logrus.Fields{
 			"network":              networkName,
@@ -78,20 +330,29 @@ func transformQueryParams(
 		}).Debug("Transformed slot filter to slot_start_date_time")
 	}
 
-	// If no transformations were made, return original
-	if !transformed {
-		return originalQuery
+	if len(rewrites) == 0 {
+		return values, nil
 	}
 
-	// Return transformed query string
-	return transformedValues.Encode()
+	slices.SortFunc(rewrites, func(a, b QueryRewrite) int {
+		return strings.Compare(a.From, b.From)
+	})
+
+	return transformedValues, rewrites
 }
 
 // detectSlotFilter checks if a query parameter is a slot filter.
 // Returns: isSlotFilter, operator (e.g., "eq", "gte"), value.
 func detectSlotFilter(key string, values []string) (bool, string, uint64) {
-	// Check if key starts with "slot_"
-	if !strings.HasPrefix(key, "slot_") {
+	return detectFilter("slot", key, values)
+}
+
+// detectFilter checks if a query parameter is a numeric filter on column.
+// Returns: isFilter, operator (e.g., "eq", "gte"), value.
+func detectFilter(column, key string, values []string) (bool, string, uint64) {
+	// Check if key starts with the column prefix
+	operator, ok := strings.CutPrefix(key, column+"_")
+	if !ok {
 		return false, "", 0
 	}
 
@@ -100,9 +361,6 @@ func detectSlotFilter(key string, values []string) (bool, string, uint64) {
 		return false, "", 0
 	}
 
-	// Extract operator from key
-	operator := strings.TrimPrefix(key, "slot_")
-
 	// Validate operator
 	switch operator {
 	case "eq", "gte", "lte", "gt", "lt":
@@ -112,12 +370,12 @@ func detectSlotFilter(key string, values []string) (bool, string, uint64) {
 		return false, "", 0
 	}
 
-	// Parse slot value
-	slotValue, err := strconv.ParseUint(values[0], 10, 64)
+	// Parse value
+	value, err := strconv.ParseUint(values[0], 10, 64)
 	if err != nil {
-		// Invalid slot value
+		// Invalid value
 		return false, "", 0
 	}
 
-	return true, operator, slotValue
+	return true, operator, value
 }
@@ -1,9 +1,17 @@
 package proxy
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
 	"testing"
 	"time"
 
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
 	"github.com/ethpandaops/lab-backend/internal/wallclock"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -302,3 +310,175 @@ func TestTransformQueryParams_AllOperators(t *testing.T) {
 		})
 	}
 }
+
+func TestProxy_ServeHTTP_SlotTransformMode(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.RawQuery)) //nolint:errcheck // test
+	}))
+	defer backend.Close()
+
+	slotCfg := config.SlotTransformConfig{
+		SlotTransformPolicy: config.SlotTransformPolicy{
+			Networks: map[string]config.SlotTransformNetworkPolicy{
+				"mainnet": {Tables: map[string]string{"fct_slot_native": config.SlotTransformModePassthrough}},
+			},
+		},
+	}
+	require.NoError(t, slotCfg.Validate())
+
+	p := &Proxy{
+		config:         &config.Config{},
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+		wallclockSvc:   setupTestWallclock(t),
+		slotTransform:  slottransform.New(logger, slotCfg, nil),
+	}
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+
+	tests := []struct {
+		name        string
+		path        string
+		wantQuery   string
+		transformed string
+		passthrough bool
+	}{
+		{
+			name:        "transformed table",
+			path:        "/api/v1/mainnet/fct_block?" + testSlotEq1000,
+			wantQuery:   "slot_start_date_time_eq=1606836023",
+			transformed: "slot_eq=1000 -> slot_start_date_time_eq=1606836023",
+		},
+		{
+			name:        "passthrough table",
+			path:        "/api/v1/mainnet/fct_slot_native?" + testSlotEq1000,
+			wantQuery:   testSlotEq1000,
+			passthrough: true,
+		},
+		{
+			name:      "passthrough table without slot filters",
+			path:      "/api/v1/mainnet/fct_slot_native?limit=10",
+			wantQuery: "limit=10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantQuery, rec.Body.String())
+			assert.Equal(t, tt.transformed, rec.Header().Get(TransformedHeader))
+
+			if tt.passthrough {
+				assert.Equal(t, config.SlotTransformModePassthrough, rec.Header().Get(SlotTransformHeader))
+				assert.Contains(t, rec.Header().Get("Warning"), "299")
+			} else {
+				assert.Empty(t, rec.Header().Get(SlotTransformHeader))
+				assert.Empty(t, rec.Header().Get("Warning"))
+			}
+		})
+	}
+}
+
+func TestHasSlotFilters(t *testing.T) {
+	assert.True(t, hasSlotFilters("slot_gte=10&limit=5"))
+	assert.False(t, hasSlotFilters("slot_start_date_time_gte=10"))
+	assert.False(t, hasSlotFilters("slot_eq=head"))
+	assert.False(t, hasSlotFilters(""))
+}
+
+func TestProxy_ServeHTTP_DebugTransform(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	var upstreamCalls int
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCalls++
+	}))
+	defer backend.Close()
+
+	slotCfg := config.SlotTransformConfig{
+		SlotTransformPolicy: config.SlotTransformPolicy{
+			Networks: map[string]config.SlotTransformNetworkPolicy{
+				"mainnet": {Tables: map[string]string{"fct_slot_native": config.SlotTransformModePassthrough}},
+			},
+		},
+	}
+	require.NoError(t, slotCfg.Validate())
+
+	cfg := &config.Config{Networks: []config.NetworkConfig{{Name: "mainnet", TargetURL: backend.URL}}}
+
+	p, err := New(context.Background(), logger, cfg, nil, setupTestWallclock(t), slottransform.New(logger, slotCfg, nil), nil, nil)
+	require.NoError(t, err)
+
+	defer p.Shutdown() //nolint:errcheck // test
+
+	serve := func(method, path string) (int, DebugTransformResponse) {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(method, path, http.NoBody))
+
+		var resp DebugTransformResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		}
+
+		return rec.Code, resp
+	}
+
+	code, resp := serve(http.MethodGet, "/api/v1/mainnet/debug/transform?slot_lt=2000&slot_gte=1000&limit=10")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "mainnet", resp.Network)
+	assert.Equal(t, config.SlotTransformModeTransform, resp.Mode)
+	assert.Equal(t, "slot_lt=2000&slot_gte=1000&limit=10", resp.Original)
+	assert.Equal(t, "limit=10&slot_start_date_time_gte=1606836023&slot_start_date_time_lt=1606848023", resp.Transformed)
+	assert.Equal(t, []QueryRewrite{
+		{From: "slot_gte=1000", To: "slot_start_date_time_gte=1606836023"},
+		{From: "slot_lt=2000", To: "slot_start_date_time_lt=1606848023"},
+	}, resp.Rewrites)
+
+	code, resp = serve(http.MethodGet, "/api/v1/mainnet/debug/transform/fct_slot_native?"+testSlotEq1000)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "fct_slot_native", resp.Table)
+	assert.Equal(t, config.SlotTransformModePassthrough, resp.Mode)
+	assert.Equal(t, testSlotEq1000, resp.Transformed)
+	assert.Empty(t, resp.Rewrites)
+
+	code, _ = serve(http.MethodPost, "/api/v1/mainnet/debug/transform")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, _ = serve(http.MethodGet, "/api/v1/unknown/debug/transform?"+testSlotEq1000)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	assert.Zero(t, upstreamCalls)
+}
+
+func TestDebugTransformTable(t *testing.T) {
+	tests := []struct {
+		path   string
+		table  string
+		isPath bool
+	}{
+		{path: "/debug/transform", isPath: true},
+		{path: "/debug/transform/fct_block", table: "fct_block", isPath: true},
+		{path: "/debug/transformer"},
+		{path: "/fct_block"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			table, ok := debugTransformTable(tt.path)
+			assert.Equal(t, tt.isPath, ok)
+			assert.Equal(t, tt.table, table)
+		})
+	}
+}
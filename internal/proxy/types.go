@@ -0,0 +1,19 @@
+package proxy
+
+//go:generate mockgen -package mocks -destination mocks/mock_handler.go github.com/ethpandaops/lab-backend/internal/proxy Handler
+
+import "net/http"
+
+// Compile-time interface compliance check.
+var _ Handler = (*Proxy)(nil)
+
+// Handler is the network proxy as used by the server and cache warmer.
+type Handler interface {
+	http.Handler
+	// NetworkCount returns the number of active network proxies.
+	NetworkCount() int
+	// Networks returns the names of all proxied networks, sorted.
+	Networks() []string
+	// Shutdown stops periodic sync and discovery.
+	Shutdown() error
+}
@@ -0,0 +1,229 @@
+package proxy
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+var queryRejectionsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "proxy_query_rejections_total",
+		Help: "Total number of proxied table queries rejected by query validation by reason",
+	},
+	[]string{"network", "reason"},
+)
+
+// queryParams are the query parameters that are not column filters.
+var queryParams = []string{"page_size", "page_token", "order_by"}
+
+// filterOperators are the operator suffixes of column filters, e.g. slot_gte.
+// Longer suffixes come first, so not_in_values is not read as in_values.
+var filterOperators = []string{
+	"not_in_values", "in_values", "not_like", "like",
+	"gte", "lte", "eq", "ne", "gt", "lt",
+}
+
+// queryError is a query rejected by validation.
+type queryError struct {
+	reason  string // Metric label, e.g. "page_size"
+	message string // Returned to the client
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+// queryValidator checks table queries against the configured rules before
+// they are proxied.
+type queryValidator struct {
+	cfg config.QueryValidationConfig
+}
+
+// newQueryValidator creates a query validator, or nil when validation is
+// disabled.
+func newQueryValidator(cfg config.QueryValidationConfig) *queryValidator {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &queryValidator{cfg: cfg}
+}
+
+// validate checks the query of a table. A nil validator accepts every query.
+func (v *queryValidator) validate(table string, values url.Values) *queryError {
+	if v == nil || table == "" {
+		return nil
+	}
+
+	rules := v.cfg.Rules(table)
+
+	if err := validateFilters(table, rules, values); err != nil {
+		return err
+	}
+
+	if err := validatePageSize(rules, values); err != nil {
+		return err
+	}
+
+	return validateSlotRange(rules, values)
+}
+
+// validateFilters rejects filters on columns the table does not allow.
+func validateFilters(table string, rules config.TableQueryRules, values url.Values) *queryError {
+	if len(rules.AllowedFilters) == 0 {
+		return nil
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if slices.Contains(queryParams, key) {
+			continue
+		}
+
+		column, ok := filterColumn(key)
+		if !ok {
+			return &queryError{
+				reason: "unknown_parameter",
+				message: fmt.Sprintf(
+					"unknown query parameter %q, expected one of %s or a filter like <column>_eq",
+					key, strings.Join(queryParams, ", "),
+				),
+			}
+		}
+
+		if !slices.Contains(rules.AllowedFilters, column) {
+			return &queryError{
+				reason: "filter",
+				message: fmt.Sprintf(
+					"filter %q is not allowed on table %s, allowed columns: %s",
+					key, table, strings.Join(rules.AllowedFilters, ", "),
+				),
+			}
+		}
+	}
+
+	return nil
+}
+
+// filterColumn splits the column off a filter parameter, e.g. slot from
+// slot_gte.
+func filterColumn(key string) (string, bool) {
+	for _, operator := range filterOperators {
+		if column, ok := strings.CutSuffix(key, "_"+operator); ok && column != "" {
+			return column, true
+		}
+	}
+
+	return "", false
+}
+
+// validatePageSize rejects non-numeric page sizes and those above the limit.
+func validatePageSize(rules config.TableQueryRules, values url.Values) *queryError {
+	raw := values.Get("page_size")
+	if raw == "" {
+		return nil
+	}
+
+	pageSize, err := strconv.Atoi(raw)
+	if err != nil || pageSize < 1 {
+		return &queryError{
+			reason:  "page_size",
+			message: fmt.Sprintf("page_size must be a positive number, got %q", raw),
+		}
+	}
+
+	if rules.MaxPageSize > 0 && pageSize > rules.MaxPageSize {
+		return &queryError{
+			reason:  "page_size",
+			message: fmt.Sprintf("page_size must be at most %d, got %d", rules.MaxPageSize, pageSize),
+		}
+	}
+
+	return nil
+}
+
+// validateSlotRange rejects non-numeric slot filters and, with a max slot
+// range, ranges that are inverted, open-ended or too large.
+func validateSlotRange(rules config.TableQueryRules, values url.Values) *queryError {
+	slots := make(map[string]uint64, 5)
+
+	for _, operator := range []string{"eq", "gte", "gt", "lte", "lt"} {
+		key := "slot_" + operator
+
+		raw := values.Get(key)
+		if raw == "" {
+			continue
+		}
+
+		slot, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return &queryError{
+				reason:  "slot",
+				message: fmt.Sprintf("%s must be a slot number, got %q", key, raw),
+			}
+		}
+
+		slots[operator] = slot
+	}
+
+	lower, hasLower := slotBound(slots, "gte", "gt", 1)
+	upper, hasUpper := slotBound(slots, "lte", "lt", -1)
+
+	if hasLower && hasUpper && upper < lower {
+		return &queryError{
+			reason:  "slot_range",
+			message: fmt.Sprintf("slot range is empty: upper bound %d is below lower bound %d", upper, lower),
+		}
+	}
+
+	if rules.MaxSlotRange == 0 || (!hasLower && !hasUpper) {
+		return nil
+	}
+
+	if !hasLower || !hasUpper {
+		return &queryError{
+			reason:  "slot_range",
+			message: fmt.Sprintf("slot ranges need both a lower (slot_gte) and an upper (slot_lte) bound of at most %d slots", rules.MaxSlotRange),
+		}
+	}
+
+	if upper-lower >= rules.MaxSlotRange {
+		return &queryError{
+			reason:  "slot_range",
+			message: fmt.Sprintf("slot range must span at most %d slots, got %d", rules.MaxSlotRange, upper-lower+1),
+		}
+	}
+
+	return nil
+}
+
+// slotBound returns the inclusive slot bound of the inclusive or exclusive
+// filter, adjusting exclusive ones by step.
+func slotBound(slots map[string]uint64, inclusive, exclusive string, step int) (uint64, bool) {
+	if slot, ok := slots[inclusive]; ok {
+		return slot, true
+	}
+
+	slot, ok := slots[exclusive]
+	if !ok {
+		return 0, false
+	}
+
+	if step > 0 {
+		return slot + 1, true
+	}
+
+	if slot == 0 {
+		return 0, true
+	}
+
+	return slot - 1, true
+}
@@ -0,0 +1,149 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestQueryValidator_Validate(t *testing.T) {
+	cfg := config.QueryValidationConfig{
+		Enabled:      true,
+		MaxPageSize:  1000,
+		MaxSlotRange: 100,
+		Tables: map[string]config.TableQueryRules{
+			"fct_block": {
+				AllowedFilters: []string{"slot", "block_root"},
+				MaxPageSize:    50,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	v := newQueryValidator(cfg)
+
+	tests := []struct {
+		name    string
+		table   string
+		query   string
+		reason  string
+		message string
+	}{
+		{name: "allowed filters", table: "fct_block", query: "slot_gte=1&slot_lte=10&block_root_eq=0xab&order_by=slot"},
+		{name: "unrestricted table", table: "fct_attestation", query: "anything_eq=1&page_size=1000"},
+		{name: "single slot", table: "fct_block", query: "slot_eq=5"},
+		{name: "not_in_values operator", table: "fct_block", query: "block_root_not_in_values=0xab"},
+		{
+			name: "filter not allowed", table: "fct_block", query: "proposer_index_eq=1",
+			reason: "filter", message: "allowed columns: slot, block_root",
+		},
+		{
+			name: "unknown parameter", table: "fct_block", query: "limit=10",
+			reason: "unknown_parameter", message: `unknown query parameter "limit"`,
+		},
+		{
+			name: "table page size", table: "fct_block", query: "page_size=51",
+			reason: "page_size", message: "page_size must be at most 50, got 51",
+		},
+		{
+			name: "default page size", table: "fct_attestation", query: "page_size=1001",
+			reason: "page_size", message: "page_size must be at most 1000",
+		},
+		{
+			name: "non-numeric page size", table: "fct_attestation", query: "page_size=all",
+			reason: "page_size", message: "page_size must be a positive number",
+		},
+		{
+			name: "non-numeric slot", table: "fct_block", query: "slot_eq=head",
+			reason: "slot", message: "slot_eq must be a slot number",
+		},
+		{
+			name: "inverted range", table: "fct_block", query: "slot_gte=10&slot_lte=5",
+			reason: "slot_range", message: "slot range is empty",
+		},
+		{
+			name: "open range", table: "fct_block", query: "slot_gte=10",
+			reason: "slot_range", message: "need both a lower",
+		},
+		{
+			name: "range too large", table: "fct_block", query: "slot_gte=0&slot_lte=100",
+			reason: "slot_range", message: "slot range must span at most 100 slots, got 101",
+		},
+		{name: "largest range", table: "fct_block", query: "slot_gt=0&slot_lt=101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			qerr := v.validate(tt.table, values)
+			if tt.reason == "" {
+				assert.Nil(t, qerr)
+
+				return
+			}
+
+			require.NotNil(t, qerr)
+			assert.Equal(t, tt.reason, qerr.reason)
+			assert.Contains(t, qerr.message, tt.message)
+		})
+	}
+}
+
+func TestQueryValidator_Disabled(t *testing.T) {
+	v := newQueryValidator(config.QueryValidationConfig{MaxPageSize: 1})
+	assert.Nil(t, v)
+
+	values, err := url.ParseQuery("page_size=1000&slot_eq=head")
+	require.NoError(t, err)
+	assert.Nil(t, v.validate("fct_block", values))
+}
+
+func TestProxy_ServeHTTP_QueryValidation(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	var requests atomic.Int32
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	p := &Proxy{
+		config:         &config.Config{},
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+		queries:        newQueryValidator(config.QueryValidationConfig{Enabled: true, MaxPageSize: 10}),
+	}
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block?page_size=11", http.NoBody))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "page_size must be at most 10, got 11")
+	assert.Equal(t, int32(0), requests.Load())
+
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/fct_block?page_size=10", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int32(1), requests.Load())
+}
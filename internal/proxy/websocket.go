@@ -0,0 +1,156 @@
+package proxy
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+var (
+	websocketConnections = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "proxy_websocket_connections",
+			Help: "Number of open proxied WebSocket connections",
+		},
+		[]string{"network"},
+	)
+
+	websocketRejectedTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_websocket_rejected_total",
+			Help: "Total number of WebSocket upgrades rejected by the per-network connection limit",
+		},
+		[]string{"network"},
+	)
+)
+
+// isWebSocketUpgrade reports whether r asks to switch to the WebSocket protocol.
+func isWebSocketUpgrade(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+
+	for _, value := range r.Header.Values("Connection") {
+		for token := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// websocketLimiter caps concurrent proxied WebSocket connections per network.
+type websocketLimiter struct {
+	cfg config.WebSocketConfig
+
+	mu     sync.Mutex
+	active map[string]int
+}
+
+// newWebSocketLimiter returns nil when WebSocket passthrough is disabled.
+func newWebSocketLimiter(cfg config.WebSocketConfig) *websocketLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &websocketLimiter{
+		cfg:    cfg,
+		active: make(map[string]int),
+	}
+}
+
+// acquire reserves a connection slot for network.
+// Returns false if the network is at its connection limit.
+func (l *websocketLimiter) acquire(network string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active[network] >= l.cfg.MaxConnectionsPerNetwork {
+		websocketRejectedTotal.WithLabelValues(network).Inc()
+
+		return false
+	}
+
+	l.active[network]++
+	websocketConnections.WithLabelValues(network).Inc()
+
+	return true
+}
+
+// release frees a slot reserved by acquire.
+func (l *websocketLimiter) release(network string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active[network]--
+	if l.active[network] <= 0 {
+		delete(l.active, network)
+	}
+
+	websocketConnections.WithLabelValues(network).Dec()
+}
+
+// idleTimeoutWriter hands out hijacked connections that close after a
+// period without traffic. httputil.ReverseProxy hijacks the client
+// connection through http.ResponseController, which finds Hijack here.
+type idleTimeoutWriter struct {
+	http.ResponseWriter
+	timeout time.Duration
+}
+
+// Hijack implements http.Hijacker.
+func (w *idleTimeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idle := &idleTimeoutConn{Conn: conn, timeout: w.timeout}
+	idle.extend()
+
+	return idle, brw, nil
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *idleTimeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// idleTimeoutConn pushes its deadline forward on every read or write. The
+// deadline covers both directions, so traffic either way keeps it open.
+type idleTimeoutConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *idleTimeoutConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.extend()
+	}
+
+	return n, err
+}
+
+func (c *idleTimeoutConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.extend()
+	}
+
+	return n, err
+}
+
+func (c *idleTimeoutConn) extend() {
+	_ = c.Conn.SetDeadline(time.Now().Add(c.timeout))
+}
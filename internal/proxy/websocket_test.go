@@ -0,0 +1,154 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/middleware"
+)
+
+// newEchoUpgradeBackend returns a backend that accepts any upgrade and echoes bytes back.
+func newEchoUpgradeBackend(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, brw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		_ = brw.Flush()
+
+		_, _ = io.Copy(conn, brw)
+	}))
+	t.Cleanup(backend.Close)
+
+	return backend
+}
+
+func newWebSocketTestProxy(t *testing.T, wsCfg config.WebSocketConfig) *httptest.Server {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	backend := newEchoUpgradeBackend(t)
+
+	p := &Proxy{
+		config:         &config.Config{},
+		proxies:        make(map[string]*httputil.ReverseProxy),
+		proxyURLs:      make(map[string]string),
+		localProxies:   make(map[string]*httputil.ReverseProxy),
+		localProxyURLs: make(map[string]string),
+		localTables:    make(map[string]map[string]bool),
+		logger:         logger,
+		websockets:     newWebSocketLimiter(wsCfg),
+	}
+
+	require.NoError(t, p.AddNetwork(config.NetworkConfig{Name: "mainnet", TargetURL: backend.URL}))
+
+	// Wrap in middleware that replaces the ResponseWriter, as the server does
+	server := httptest.NewServer(middleware.Metrics()(middleware.Logging(logger)(p)))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// dialWebSocket sends an upgrade request and returns the connection and response status.
+func dialWebSocket(t *testing.T, server *httptest.Server) (net.Conn, *bufio.Reader, int) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	_, err = conn.Write([]byte("GET /api/v1/mainnet/stream HTTP/1.1\r\nHost: lab\r\n" +
+		"Upgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n\r\n"))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+
+	return conn, reader, resp.StatusCode
+}
+
+func TestProxy_WebSocketPassthrough(t *testing.T) {
+	server := newWebSocketTestProxy(t, config.WebSocketConfig{
+		Enabled:                  true,
+		MaxConnectionsPerNetwork: 1,
+		IdleTimeout:              time.Minute,
+	})
+
+	conn, reader, status := dialWebSocket(t, server)
+	require.Equal(t, http.StatusSwitchingProtocols, status)
+
+	_, err := conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	echo := make([]byte, 4)
+	_, err = io.ReadFull(reader, echo)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(echo))
+
+	// A second connection exceeds the per-network limit
+	_, _, status = dialWebSocket(t, server)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestProxy_WebSocketIdleTimeout(t *testing.T) {
+	server := newWebSocketTestProxy(t, config.WebSocketConfig{
+		Enabled:                  true,
+		MaxConnectionsPerNetwork: 1,
+		IdleTimeout:              100 * time.Millisecond,
+	})
+
+	conn, reader, status := dialWebSocket(t, server)
+	require.Equal(t, http.StatusSwitchingProtocols, status)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	// The proxy closes the idle connection, which the client sees as EOF
+	_, err := reader.ReadByte()
+	require.ErrorIs(t, err, io.EOF)
+
+	// The slot is released once the connection is closed
+	assert.Eventually(t, func() bool {
+		_, _, status := dialWebSocket(t, server)
+
+		return status == http.StatusSwitchingProtocols
+	}, time.Second, 20*time.Millisecond)
+}
+
+func TestProxy_WebSocketDisabled(t *testing.T) {
+	server := newWebSocketTestProxy(t, config.WebSocketConfig{})
+
+	_, _, status := dialWebSocket(t, server)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestIsWebSocketUpgrade(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/mainnet/stream", http.NoBody)
+	assert.False(t, isWebSocketUpgrade(req))
+
+	req.Header.Set("Upgrade", "WebSocket")
+	assert.False(t, isWebSocketUpgrade(req))
+
+	req.Header.Set("Connection", "keep-alive, Upgrade")
+	assert.True(t, isWebSocketUpgrade(req))
+}
@@ -0,0 +1,173 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript takes a token from a bucket holding up to capacity tokens,
+// refilled continuously at rate tokens per millisecond. A missing bucket is
+// full. It returns whether a token was taken and the tokens left, as a string
+// since Lua numbers are truncated to integers on the way out.
+//
+// KEYS[1]: bucket key
+// ARGV: capacity, rate, now (unix ms), ttl (ms)
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+
+return {allowed, tostring(tokens)}
+`)
+
+// bucket describes a token bucket: limit tokens per window, plus burst.
+type bucket struct {
+	capacity float64
+	rate     float64 // Tokens per millisecond
+}
+
+func newBucket(limit, burst int, window time.Duration) bucket {
+	return bucket{
+		capacity: float64(limit + burst),
+		rate:     float64(limit) / (float64(window) / float64(time.Millisecond)),
+	}
+}
+
+// untilTokens is how long refilling n tokens takes.
+func (b bucket) untilTokens(n float64) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(n/b.rate)) * time.Millisecond
+}
+
+// result converts the tokens left into the remaining budget and the time the
+// caller should come back: when the bucket is full again if the request was
+// allowed, or when the next token arrives if it was not.
+func (b bucket) result(allowed bool, tokens float64, now time.Time) (int, time.Time) {
+	if !allowed {
+		return 0, now.Add(b.untilTokens(1 - tokens))
+	}
+
+	return int(tokens), now.Add(b.untilTokens(b.capacity - tokens))
+}
+
+func bucketKey(ip, key string) string {
+	return fmt.Sprintf("rate_limit_bucket:%s:%s", ip, key)
+}
+
+// AllowBurst implements token bucket rate limiting with a Lua script, so
+// concurrent requests on different replicas take tokens atomically.
+func (s *service) AllowBurst(
+	ctx context.Context,
+	ip, key string,
+	limit, burst int,
+	window time.Duration,
+) (bool, int, time.Time, error) {
+	var (
+		b   = newBucket(limit, burst, window)
+		now = time.Now()
+		// An idle bucket refills completely, which is the same as no bucket
+		ttl = b.untilTokens(b.capacity)
+	)
+
+	result, err := tokenBucketScript.Run(ctx, s.redis, []string{bucketKey(ip, key)},
+		b.capacity, b.rate, now.UnixMilli(), ttl.Milliseconds(),
+	).Slice()
+	if err == nil && len(result) != 2 {
+		err = fmt.Errorf("unexpected token bucket result %v", result)
+	}
+
+	if err != nil {
+		s.log.WithError(err).Error("failed to take rate limit token in Redis")
+
+		// Handle failure based on configured mode
+		if s.failureMode == "fail_closed" {
+			return false, 0, time.Time{}, fmt.Errorf("rate limiter unavailable: %w", err)
+		}
+
+		// fail_open: allow request
+		return true, 0, time.Time{}, nil
+	}
+
+	allowed, _ := result[0].(int64)
+	tokensLeft, _ := result[1].(string)
+
+	tokens, err := strconv.ParseFloat(tokensLeft, 64)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to parse rate limit tokens")
+	}
+
+	remaining, resetAt := b.result(allowed == 1, tokens, now)
+
+	return allowed == 1, remaining, resetAt, nil
+}
+
+// PeekBurst computes the tokens AllowBurst would find in a bucket, without
+// taking one.
+func (s *service) PeekBurst(
+	ctx context.Context,
+	ip, key string,
+	limit, burst int,
+	window time.Duration,
+) (int, time.Time, error) {
+	b := newBucket(limit, burst, window)
+
+	state, err := s.redis.HMGet(ctx, bucketKey(ip, key), "tokens", "ts").Result()
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("read rate limit bucket: %w", err)
+	}
+
+	tokensLeft, _ := state[0].(string)
+	lastTaken, _ := state[1].(string)
+
+	if tokensLeft == "" || lastTaken == "" {
+		return int(b.capacity), time.Time{}, nil
+	}
+
+	tokens, err := strconv.ParseFloat(tokensLeft, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("parse rate limit tokens: %w", err)
+	}
+
+	ts, err := strconv.ParseInt(lastTaken, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("parse rate limit timestamp: %w", err)
+	}
+
+	now := time.Now()
+	tokens = math.Min(b.capacity, tokens+float64(max(now.UnixMilli()-ts, 0))*b.rate)
+
+	remaining, resetAt := b.result(true, tokens, now)
+	if tokens >= b.capacity {
+		resetAt = time.Time{}
+	}
+
+	return remaining, resetAt, nil
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+)
+
+// IPResolver extracts the client IP of requests. Forwarding headers are only
+// honored when the direct peer is a trusted proxy, as anyone else can set
+// them. A nil IPResolver trusts no proxies.
+type IPResolver struct {
+	trusted []*net.IPNet
+}
+
+// NewIPResolver creates a resolver trusting the given IPs and CIDR ranges.
+// They must have been validated already.
+func NewIPResolver(trustedProxies []string) *IPResolver {
+	return &IPResolver{trusted: parseCIDRs(trustedProxies)}
+}
+
+// Client returns the client a request is rate limited as.
+func (res *IPResolver) Client(r *http.Request) Client {
+	identity, authenticated := auth.IdentityFromContext(r.Context())
+
+	return Client{
+		IP:            res.ClientIP(r),
+		Identity:      identity,
+		Authenticated: authenticated,
+	}
+}
+
+// ClientIP extracts the real client IP from the request.
+// From trusted proxies: CF-Connecting-IP > X-Forwarded-For > X-Real-IP > RemoteAddr.
+// From anyone else: RemoteAddr.
+func (res *IPResolver) ClientIP(r *http.Request) string {
+	// The direct peer, without port
+	peer, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		peer = r.RemoteAddr
+	}
+
+	if !res.trusts(peer) {
+		return peer
+	}
+
+	// Cloudflare sets CF-Connecting-IP
+	if ip := strings.TrimSpace(r.Header.Get("CF-Connecting-IP")); ip != "" {
+		return ip
+	}
+
+	// X-Forwarded-For lists every hop, each appending its peer. Entries left of
+	// the last trusted proxy are client-supplied, so take the rightmost
+	// untrusted one.
+	if hops := forwardedFor(r); len(hops) > 0 {
+		for i := len(hops) - 1; i > 0; i-- {
+			if !res.trusts(hops[i]) {
+				return hops[i]
+			}
+		}
+
+		return hops[0]
+	}
+
+	// X-Real-IP
+	if xri := strings.TrimSpace(r.Header.Get("X-Real-IP")); xri != "" {
+		return xri
+	}
+
+	return peer
+}
+
+func (res *IPResolver) trusts(ip string) bool {
+	return res != nil && containsIP(res.trusted, ip)
+}
+
+// forwardedFor returns the non-empty X-Forwarded-For entries across every
+// header line, in order.
+func forwardedFor(r *http.Request) []string {
+	var hops []string
+
+	for _, line := range r.Header.Values("X-Forwarded-For") {
+		for hop := range strings.SplitSeq(line, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	return hops
+}
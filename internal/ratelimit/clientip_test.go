@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPResolver_ClientIP(t *testing.T) {
+	resolver := NewIPResolver([]string{"10.0.0.0/8", "2001:db8::1"})
+
+	tests := []struct {
+		name       string
+		resolver   *IPResolver
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "untrusted peer ignores headers",
+			resolver:   resolver,
+			remoteAddr: "198.51.100.1:443",
+			headers:    map[string][]string{"Cf-Connecting-Ip": {"203.0.113.1"}, "X-Forwarded-For": {"203.0.113.2"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "nil resolver trusts nobody",
+			remoteAddr: "10.0.0.1:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.2"}},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "trusted peer honors CF-Connecting-IP",
+			resolver:   resolver,
+			remoteAddr: "10.0.0.1:443",
+			headers:    map[string][]string{"Cf-Connecting-Ip": {"203.0.113.1"}, "X-Forwarded-For": {"203.0.113.2"}},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "spoofed X-Forwarded-For prefix is skipped",
+			resolver:   resolver,
+			remoteAddr: "10.0.0.1:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.2.3.4, 203.0.113.2", "10.0.0.7"}},
+			want:       "203.0.113.2",
+		},
+		{
+			name:       "all hops trusted uses the first",
+			resolver:   resolver,
+			remoteAddr: "[2001:db8::1]:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			want:       "10.0.0.3",
+		},
+		{
+			name:       "trusted peer honors X-Real-IP",
+			resolver:   resolver,
+			remoteAddr: "10.0.0.1:443",
+			headers:    map[string][]string{"X-Real-Ip": {"203.0.113.3"}},
+			want:       "203.0.113.3",
+		},
+		{
+			name:       "trusted peer without headers",
+			resolver:   resolver,
+			remoteAddr: "10.0.0.1:443",
+			want:       "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/test", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header = tt.headers
+
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+
+			assert.Equal(t, tt.want, tt.resolver.ClientIP(req))
+		})
+	}
+}
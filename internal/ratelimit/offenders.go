@@ -0,0 +1,241 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+const (
+	// offendersKeyPrefix holds one sorted set of denials per bucket. The hash
+	// tag keeps every bucket in one cluster slot, so they can be merged.
+	offendersKeyPrefix = "{rate_limit_offenders}:"
+
+	// memberSeparator joins rule and client in a sorted set member. Neither
+	// rule names nor clients contain it.
+	memberSeparator = "\x1f"
+)
+
+// Offender is a client denied by a rule, with how often it was denied.
+type Offender struct {
+	Client string `json:"client"` // IP, or key:<id> for API keys
+	Rule   string `json:"rule"`
+	Denied int64  `json:"denied"`
+}
+
+// Offenders counts denied requests per client and rule, and reports the top
+// offenders over a window. Denials are counted in memory and added to a
+// Redis sorted set per bucket every flush interval, so replicas add up. A
+// nil Offenders records nothing.
+type Offenders struct {
+	cfg   config.RateLimitOffendersConfig
+	redis redis.UniversalClient
+	log   logrus.FieldLogger
+	now   func() time.Time
+
+	mu      sync.Mutex
+	pending map[time.Time]map[string]int64 // bucket start → member → denials
+
+	task *tasks.Task
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewOffenders creates a denial recorder.
+func NewOffenders(
+	log logrus.FieldLogger,
+	cfg config.RateLimitOffendersConfig,
+	redisClient redis.UniversalClient,
+) *Offenders {
+	return &Offenders{
+		cfg:     cfg,
+		redis:   redisClient,
+		log:     log.WithField("component", "ratelimit_offenders"),
+		now:     time.Now,
+		pending: make(map[time.Time]map[string]int64),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start flushes recorded denials to Redis in the background.
+func (o *Offenders) Start(ctx context.Context) {
+	o.task = tasks.Default().Register("ratelimit.offenders.flush", o.cfg.FlushInterval)
+	o.wg.Add(1)
+
+	go o.flushLoop(ctx)
+}
+
+// Stop stops the flush loop and flushes what is left.
+func (o *Offenders) Stop() {
+	close(o.done)
+	o.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := o.Flush(ctx); err != nil {
+		o.log.WithError(err).Warn("Failed to flush rate limit offenders")
+	}
+}
+
+// Record counts a request from client denied by rule.
+func (o *Offenders) Record(rule, client string) {
+	if o == nil {
+		return
+	}
+
+	bucket := o.now().Truncate(o.cfg.BucketSize)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	counts, ok := o.pending[bucket]
+	if !ok {
+		counts = make(map[string]int64)
+		o.pending[bucket] = counts
+	}
+
+	counts[rule+memberSeparator+client]++
+}
+
+func (o *Offenders) flushLoop(ctx context.Context) {
+	defer o.wg.Done()
+
+	o.task.Supervise(o.log, o.done, func() {
+		ticker := jitter.NewTicker(o.cfg.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-o.done:
+				return
+			case <-ticker.C:
+				_ = o.task.Run(func() error { return o.Flush(ctx) })
+			}
+		}
+	})
+}
+
+// Flush adds the denials recorded since the last flush to Redis. Denials that
+// fail to be written are dropped, as they only feed reports.
+func (o *Offenders) Flush(ctx context.Context) error {
+	o.mu.Lock()
+	pending := o.pending
+	o.pending = make(map[time.Time]map[string]int64)
+	o.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Buckets are kept until the longest window no longer covers them
+	var (
+		ttl = o.cfg.MaxWindow() + o.cfg.BucketSize
+		now = o.now()
+	)
+
+	pipe := o.redis.Pipeline()
+
+	for bucket, counts := range pending {
+		key := offendersKey(bucket)
+
+		for member, denied := range counts {
+			pipe.ZIncrBy(ctx, key, float64(denied), member)
+		}
+
+		pipe.Expire(ctx, key, bucket.Add(ttl).Sub(now))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("flush rate limit offenders: %w", err)
+	}
+
+	return nil
+}
+
+// Top returns the limit clients denied most over window, across all rules or
+// only rule when set. The bucket in progress is included, so the window is
+// rounded up to whole buckets.
+func (o *Offenders) Top(ctx context.Context, window time.Duration, rule string, limit int) ([]Offender, error) {
+	var (
+		current = o.now().Truncate(o.cfg.BucketSize)
+		buckets = int((window + o.cfg.BucketSize - 1) / o.cfg.BucketSize)
+		keys    = make([]string, 0, buckets)
+	)
+
+	for i := range buckets {
+		keys = append(keys, offendersKey(current.Add(-time.Duration(i)*o.cfg.BucketSize)))
+	}
+
+	// Merge the buckets into a short-lived set to rank across them
+	union := offendersKeyPrefix + "union:" + randomSuffix()
+
+	pipe := o.redis.TxPipeline()
+	pipe.ZUnionStore(ctx, union, &redis.ZStore{Keys: keys, Aggregate: "SUM"})
+	pipe.Expire(ctx, union, time.Minute)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("merge rate limit offenders: %w", err)
+	}
+
+	defer o.redis.Del(context.WithoutCancel(ctx), union)
+
+	// Filtering by rule needs every member; otherwise Redis ranks them
+	stop := int64(limit - 1)
+	if rule != "" {
+		stop = -1
+	}
+
+	members, err := o.redis.ZRevRangeWithScores(ctx, union, 0, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read rate limit offenders: %w", err)
+	}
+
+	offenders := make([]Offender, 0, min(limit, len(members)))
+
+	for _, member := range members {
+		name, _ := member.Member.(string)
+
+		memberRule, client, ok := strings.Cut(name, memberSeparator)
+		if !ok || (rule != "" && memberRule != rule) {
+			continue
+		}
+
+		offenders = append(offenders, Offender{
+			Client: client,
+			Rule:   memberRule,
+			Denied: int64(member.Score),
+		})
+
+		if len(offenders) == limit {
+			break
+		}
+	}
+
+	return offenders, nil
+}
+
+func offendersKey(bucket time.Time) string {
+	return offendersKeyPrefix + strconv.FormatInt(bucket.Unix(), 10)
+}
+
+func randomSuffix() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func newTestOffenders(t *testing.T) (*Offenders, *time.Time, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.RateLimitOffendersConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	o := NewOffenders(logger, cfg, client)
+	o.now = func() time.Time { return now }
+
+	return o, &now, mr
+}
+
+func TestOffenders_Top(t *testing.T) {
+	o, now, mr := newTestOffenders(t)
+	ctx := context.Background()
+
+	for range 5 {
+		o.Record("api_proxy", "192.0.2.1")
+	}
+
+	o.Record("api_proxy", "192.0.2.2")
+	o.Record("bounds_endpoint", "192.0.2.2")
+
+	require.NoError(t, o.Flush(ctx))
+
+	// Two hours later, another client is denied in a new bucket
+	*now = now.Add(2 * time.Hour)
+
+	for range 3 {
+		o.Record("api_proxy", "key:acme")
+	}
+
+	// Counts from several replicas add up
+	o.Record("api_proxy", "192.0.2.1")
+	require.NoError(t, o.Flush(ctx))
+
+	top, err := o.Top(ctx, time.Hour, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Offender{
+		{Client: "key:acme", Rule: "api_proxy", Denied: 3},
+		{Client: "192.0.2.1", Rule: "api_proxy", Denied: 1},
+	}, top)
+
+	top, err = o.Top(ctx, 24*time.Hour, "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Offender{
+		{Client: "192.0.2.1", Rule: "api_proxy", Denied: 6},
+		{Client: "key:acme", Rule: "api_proxy", Denied: 3},
+	}, top)
+
+	top, err = o.Top(ctx, 24*time.Hour, "bounds_endpoint", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Offender{{Client: "192.0.2.2", Rule: "bounds_endpoint", Denied: 1}}, top)
+
+	// Buckets expire once the longest window no longer covers them
+	for _, key := range mr.Keys() {
+		assert.Positive(t, mr.TTL(key), "key %s should expire", key)
+	}
+}
+
+func TestOffenders_NilRecordsNothing(t *testing.T) {
+	var o *Offenders
+
+	assert.NotPanics(t, func() { o.Record("api_proxy", "192.0.2.1") })
+}
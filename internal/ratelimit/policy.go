@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"net"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// LimitsPath is the endpoint reporting a client's limits. Requests for it are
+// never counted against a rule.
+const LimitsPath = "/api/v1/limits"
+
+// Policy decides which rule and limit apply to a client. It is shared by the
+// rate limit middleware and the limits endpoint, so both agree.
+type Policy struct {
+	rules       []Rule
+	exemptNets  []*net.IPNet
+	exemptTiers []string
+}
+
+// Rule is a compiled rate limit rule.
+type Rule struct {
+	Name       string
+	Pattern    *regexp.Regexp
+	Limit      int
+	Window     time.Duration
+	Burst      int // Extra requests allowed in a burst; 0 uses a fixed window
+	TierLimits map[string]int
+}
+
+// Client is who a request is rate limited as.
+type Client struct {
+	IP            string
+	Identity      auth.Identity
+	Authenticated bool // Whether the request carried a valid API key
+}
+
+// NewPolicy compiles the rate limiting configuration. Patterns must have been
+// validated already.
+func NewPolicy(cfg config.RateLimitingConfig) *Policy {
+	// Pre-compile regex patterns for performance
+	rules := make([]Rule, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		rules[i] = Rule{
+			Name:       rule.Name,
+			Pattern:    regexp.MustCompile(rule.PathPattern),
+			Limit:      rule.Limit,
+			Window:     rule.Window,
+			Burst:      rule.Burst,
+			TierLimits: rule.TierLimits,
+		}
+	}
+
+	return &Policy{
+		rules:       rules,
+		exemptNets:  parseCIDRs(cfg.ExemptIPs),
+		exemptTiers: cfg.ExemptTiers,
+	}
+}
+
+// Subject is the bucket key of the client: its API key when authenticated,
+// its IP otherwise.
+func (c Client) Subject() string {
+	if c.Authenticated {
+		return "key:" + c.Identity.KeyID
+	}
+
+	return c.IP
+}
+
+// Rules returns every rule, in match order.
+func (p *Policy) Rules() []Rule {
+	return p.rules
+}
+
+// Exempt returns why a client bypasses rate limiting ("ip" or "tier"), or ""
+// if it does not.
+func (p *Policy) Exempt(c Client) string {
+	if containsIP(p.exemptNets, c.IP) {
+		return "ip"
+	}
+
+	if c.Authenticated && slices.Contains(p.exemptTiers, c.Identity.Tier) {
+		return "tier"
+	}
+
+	return ""
+}
+
+// Match returns the first rule matching path, or nil.
+func (p *Policy) Match(path string) *Rule {
+	for i := range p.rules {
+		if p.rules[i].Pattern.MatchString(path) {
+			return &p.rules[i]
+		}
+	}
+
+	return nil
+}
+
+// LimitFor returns the limit of the rule for a client, using the key tier's
+// limit when the rule has one.
+func (r *Rule) LimitFor(c Client) int {
+	if c.Authenticated {
+		if tierLimit, ok := r.TierLimits[c.Identity.Tier]; ok {
+			return tierLimit
+		}
+	}
+
+	return r.Limit
+}
+
+func parseCIDRs(cidrs []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			// Try parsing as single IP
+			if ip := net.ParseIP(cidr); ip != nil {
+				// Convert single IP to /32 or /128 CIDR
+				if ip.To4() != nil {
+					_, network, _ = net.ParseCIDR(cidr + "/32")
+				} else {
+					_, network, _ = net.ParseCIDR(cidr + "/128")
+				}
+
+				nets = append(nets, network)
+			}
+
+			continue
+		}
+
+		nets = append(nets, network)
+	}
+
+	return nets
+}
+
+func containsIP(nets []*net.IPNet, ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+
+	for _, network := range nets {
+		if network.Contains(parsedIP) {
+			return true
+		}
+	}
+
+	return false
+}
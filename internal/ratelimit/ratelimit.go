@@ -2,6 +2,7 @@ package ratelimit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -22,10 +23,34 @@ type Service interface {
 		limit int,
 		window time.Duration,
 	) (allowed bool, remaining int, resetAt time.Time, err error)
+	// Peek returns the remaining budget of a bucket without consuming it.
+	// resetAt is zero while the bucket is unused.
+	Peek(
+		ctx context.Context,
+		ip, key string,
+		limit int,
+	) (remaining int, resetAt time.Time, err error)
+	// AllowBurst takes a token from a bucket refilled at limit per window
+	// that holds up to limit+burst tokens. resetAt is when the bucket is full
+	// again, or when the next token arrives if the request was denied.
+	AllowBurst(
+		ctx context.Context,
+		ip, key string,
+		limit, burst int,
+		window time.Duration,
+	) (allowed bool, remaining int, resetAt time.Time, err error)
+	// PeekBurst returns the tokens left in a bucket without taking one.
+	// resetAt is zero while the bucket is full.
+	PeekBurst(
+		ctx context.Context,
+		ip, key string,
+		limit, burst int,
+		window time.Duration,
+	) (remaining int, resetAt time.Time, err error)
 }
 
 type service struct {
-	redis *redis.Client
+	redis redis.UniversalClient
 	log   logrus.FieldLogger
 
 	// Failure mode: "fail_open" or "fail_closed"
@@ -34,7 +59,7 @@ type service struct {
 
 func NewService(
 	log logrus.FieldLogger,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	failureMode string,
 ) Service {
 	return &service{
@@ -68,6 +93,33 @@ func (s *service) Stop() error {
 	return nil
 }
 
+// Peek reads the counter and TTL Allow maintains, without incrementing.
+func (s *service) Peek(ctx context.Context, ip, key string, limit int) (int, time.Time, error) {
+	redisKey := fmt.Sprintf("rate_limit:%s:%s", ip, key)
+
+	count, err := s.redis.Get(ctx, redisKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return limit, time.Time{}, nil
+	}
+
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("read rate limit counter: %w", err)
+	}
+
+	ttl, err := s.redis.TTL(ctx, redisKey).Result()
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("read rate limit TTL: %w", err)
+	}
+
+	// The key expired between both calls, or has no TTL yet
+	var resetAt time.Time
+	if ttl > 0 {
+		resetAt = time.Now().Add(ttl)
+	}
+
+	return max(limit-int(count), 0), resetAt, nil
+}
+
 // Allow implements sliding window rate limiting using Redis INCR + EXPIRE.
 func (s *service) Allow(
 	ctx context.Context,
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -426,3 +428,177 @@ func TestService_Allow_ConcurrentRequests(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(numGoroutines), count, "all requests should be counted")
 }
+
+// TestService_Peek verifies that Peek reports the remaining budget without
+// consuming it.
+func TestService_Peek(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc := NewService(logger, client, "fail_open")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// No requests yet: full budget and no window
+	remaining, resetAt, err := svc.Peek(ctx, "192.168.1.1", "api", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, remaining)
+	assert.True(t, resetAt.IsZero(), "reset time should not be set before the first request")
+
+	for range 3 {
+		_, _, _, err = svc.Allow(ctx, "192.168.1.1", "api", 10, 1*time.Minute)
+		require.NoError(t, err)
+	}
+
+	remaining, resetAt, err = svc.Peek(ctx, "192.168.1.1", "api", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 7, remaining)
+	assert.True(t, resetAt.After(time.Now()), "reset time should be in the future")
+
+	// Peeking again does not consume the budget
+	remaining, _, err = svc.Peek(ctx, "192.168.1.1", "api", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 7, remaining)
+
+	count, err := client.Get(ctx, "rate_limit:192.168.1.1:api").Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count, "peek should not increment the counter")
+}
+
+// TestService_AllowBurst verifies that a token bucket allows limit+burst
+// requests at once, then refills at limit per window.
+func TestService_AllowBurst(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc := NewService(logger, client, "fail_open")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// No requests yet: a full bucket
+	remaining, resetAt, err := svc.PeekBurst(ctx, "192.168.1.1", "api", 10, 5, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 15, remaining)
+	assert.True(t, resetAt.IsZero(), "reset time should not be set while the bucket is full")
+
+	allowed, remaining, resetAt, err := svc.AllowBurst(ctx, "192.168.1.1", "api", 10, 5, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 14, remaining)
+	assert.WithinDuration(t, time.Now().Add(6*time.Second), resetAt, time.Second, "one token refills every 6s")
+
+	for i := range 14 {
+		allowed, _, _, err = svc.AllowBurst(ctx, "192.168.1.1", "api", 10, 5, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, allowed, "request %d should be allowed within the burst", i+2)
+	}
+
+	// The burst is spent
+	allowed, remaining, resetAt, err = svc.AllowBurst(ctx, "192.168.1.1", "api", 10, 5, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, allowed, "request beyond limit+burst should be denied")
+	assert.Equal(t, 0, remaining)
+	assert.True(t, resetAt.After(time.Now()), "next token should arrive in the future")
+	assert.WithinDuration(t, time.Now().Add(6*time.Second), resetAt, time.Second)
+
+	remaining, _, err = svc.PeekBurst(ctx, "192.168.1.1", "api", 10, 5, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 0, remaining)
+
+	// Buckets are per client
+	allowed, _, _, err = svc.AllowBurst(ctx, "192.168.1.2", "api", 10, 5, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+// TestService_AllowBurst_Refill verifies that tokens are refilled over time.
+func TestService_AllowBurst_Refill(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc := NewService(logger, client, "fail_open")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 2 per 200ms plus a burst of 2: one token every 100ms
+	for range 4 {
+		allowed, _, _, err := svc.AllowBurst(ctx, "192.168.1.1", "api", 2, 2, 200*time.Millisecond)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+
+	allowed, _, _, err := svc.AllowBurst(ctx, "192.168.1.1", "api", 2, 2, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	time.Sleep(120 * time.Millisecond)
+
+	allowed, _, _, err = svc.AllowBurst(ctx, "192.168.1.1", "api", 2, 2, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, allowed, "a token should have been refilled")
+}
+
+// TestService_AllowBurst_Concurrent verifies that concurrent requests never
+// take more than limit+burst tokens.
+func TestService_AllowBurst_Concurrent(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer client.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	svc := NewService(logger, client, "fail_open")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int32
+	)
+
+	for range 50 {
+		wg.Go(func() {
+			ok, _, _, err := svc.AllowBurst(ctx, "192.168.1.1", "api", 10, 10, time.Hour)
+			if err == nil && ok {
+				allowed.Add(1)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(20), allowed.Load())
+}
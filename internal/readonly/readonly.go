@@ -0,0 +1,133 @@
+// Package readonly tracks read-only mode, during which mutating endpoints are
+// rejected. The mode is set in config or toggled at runtime through a Redis
+// key shared by every instance.
+package readonly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/rediswatch"
+)
+
+// pollTimeout bounds each Redis check.
+const pollTimeout = 2 * time.Second
+
+var readOnlyGauge = metrics.NewGauge(prometheus.GaugeOpts{
+	Name: "read_only_mode",
+	Help: "Whether read-only mode is active (1) or not (0)",
+})
+
+// Mode reports whether the backend is read-only and why.
+type Mode struct {
+	cfg   config.ReadOnlyConfig
+	log   logrus.FieldLogger
+	redis redis.Client
+
+	// runtime holds the message set through Redis, nil while the key is absent
+	// or without Redis
+	runtime *rediswatch.Watcher[string]
+}
+
+// New creates a read-only mode tracker. redisClient may be nil, in which case
+// only the configured mode applies.
+func New(log logrus.FieldLogger, cfg config.ReadOnlyConfig, redisClient redis.Client) *Mode {
+	m := &Mode{
+		cfg:   cfg,
+		log:   log.WithField("component", "read_only"),
+		redis: redisClient,
+	}
+
+	if redisClient != nil {
+		m.runtime = rediswatch.New(m.log, rediswatch.Config[string]{
+			Name:     "read_only.poll",
+			Interval: cfg.PollInterval,
+			Timeout:  pollTimeout,
+			Load: rediswatch.Key(redisClient, cfg.RedisKey, func(data string) (*string, error) {
+				return &data, nil
+			}),
+			OnChange: m.changed,
+		})
+	}
+
+	m.updateGauge()
+
+	return m
+}
+
+// Enabled reports whether read-only mode is active, with the message to return
+// to rejected requests.
+func (m *Mode) Enabled() (string, bool) {
+	if msg := m.runtime.Value(); msg != nil {
+		if *msg != "" {
+			return *msg, true
+		}
+
+		return m.cfg.Message, true
+	}
+
+	return m.cfg.Message, m.cfg.Enabled
+}
+
+// Start checks the runtime key once and then polls it in the background.
+func (m *Mode) Start() {
+	if m.cfg.Enabled {
+		m.log.Warn("Read-only mode enabled in config")
+	}
+
+	m.runtime.Start()
+}
+
+// Stop stops polling and waits for it to finish.
+func (m *Mode) Stop() {
+	m.runtime.Stop()
+}
+
+// Set turns runtime read-only mode on for every instance, with an optional
+// message.
+func (m *Mode) Set(ctx context.Context, message string) error {
+	if err := m.redis.Set(ctx, m.cfg.RedisKey, message, 0); err != nil {
+		return fmt.Errorf("set read-only key: %w", err)
+	}
+
+	return m.runtime.Refresh(ctx)
+}
+
+// Clear turns runtime read-only mode off for every instance.
+func (m *Mode) Clear(ctx context.Context) error {
+	if err := m.redis.Del(ctx, m.cfg.RedisKey); err != nil {
+		return fmt.Errorf("delete read-only key: %w", err)
+	}
+
+	return m.runtime.Refresh(ctx)
+}
+
+// changed logs runtime transitions. On Redis errors the last known state is
+// kept, so a Redis outage neither enables nor lifts read-only mode.
+func (m *Mode) changed(prev, next *string) {
+	switch {
+	case next == nil && prev != nil:
+		m.log.Warn("Read-only mode lifted")
+	case next != nil && (prev == nil || *prev != *next):
+		m.log.WithField("message", *next).Warn("Read-only mode enabled at runtime")
+	}
+
+	m.updateGauge()
+}
+
+func (m *Mode) updateGauge() {
+	if _, enabled := m.Enabled(); enabled {
+		readOnlyGauge.Set(1)
+
+		return
+	}
+
+	readOnlyGauge.Set(0)
+}
@@ -0,0 +1,73 @@
+package readonly
+
+import (
+	"io"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func newTestMode(t *testing.T, cfg config.ReadOnlyConfig) (*Mode, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	require.NoError(t, cfg.Validate())
+
+	mode := New(logger, cfg, client)
+	mode.Start()
+	t.Cleanup(mode.Stop)
+
+	return mode, mr
+}
+
+func TestMode_Config(t *testing.T) {
+	mode, _ := newTestMode(t, config.ReadOnlyConfig{Enabled: true, Message: "migrating"})
+
+	message, enabled := mode.Enabled()
+	assert.True(t, enabled)
+	assert.Equal(t, "migrating", message)
+}
+
+func TestMode_Runtime(t *testing.T) {
+	mode, mr := newTestMode(t, config.ReadOnlyConfig{})
+
+	_, enabled := mode.Enabled()
+	assert.False(t, enabled)
+
+	// An empty value uses the configured message
+	require.NoError(t, mode.Set(t.Context(), ""))
+
+	message, enabled := mode.Enabled()
+	assert.True(t, enabled)
+	assert.Equal(t, mode.cfg.Message, message)
+
+	require.NoError(t, mode.Set(t.Context(), "incident in progress"))
+
+	message, enabled = mode.Enabled()
+	assert.True(t, enabled)
+	assert.Equal(t, "incident in progress", message)
+
+	// Redis errors keep the last known state
+	mr.SetError("unavailable")
+	require.Error(t, mode.runtime.Refresh(t.Context()))
+
+	_, enabled = mode.Enabled()
+	assert.True(t, enabled)
+
+	mr.SetError("")
+
+	require.NoError(t, mode.Clear(t.Context()))
+
+	_, enabled = mode.Enabled()
+	assert.False(t, enabled)
+}
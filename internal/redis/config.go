@@ -2,13 +2,25 @@ package redis
 
 import "time"
 
+// Topologies the client can connect to.
+const (
+	ModeStandalone = "standalone" // A single Redis server at Address
+	ModeSentinel   = "sentinel"   // The master of MasterName, discovered through SentinelAddresses
+	ModeCluster    = "cluster"    // A Redis Cluster, seeded from ClusterAddresses
+)
+
 // Config holds Redis client configuration.
 type Config struct {
-	Address      string
-	Password     string
-	DB           int
-	DialTimeout  time.Duration
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	PoolSize     int
+	Mode              string // ModeStandalone when empty
+	Address           string
+	Password          string
+	DB                int
+	MasterName        string
+	SentinelAddresses []string
+	SentinelPassword  string
+	ClusterAddresses  []string
+	DialTimeout       time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	PoolSize          int
 }
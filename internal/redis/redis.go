@@ -4,7 +4,9 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -14,6 +16,9 @@ import (
 // Compile-time interface compliance check.
 var _ Client = (*client)(nil)
 
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("key not found")
+
 // Client provides Redis operations for lab-backend.
 type Client interface {
 	Start(ctx context.Context) error
@@ -23,13 +28,18 @@ type Client interface {
 	Set(ctx context.Context, key string, value string, ttl time.Duration) error
 	Del(ctx context.Context, keys ...string) error
 	SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
-	GetClient() *redis.Client
+	DelIfEqual(ctx context.Context, key string, value string) (bool, error)
+	Incr(ctx context.Context, key string) (int64, error)
+	Publish(ctx context.Context, channel string, message string) error
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+	Keys(ctx context.Context, pattern string) ([]string, error)
+	GetClient() redis.UniversalClient
 }
 
 type client struct {
 	log    logrus.FieldLogger
 	cfg    Config
-	client *redis.Client
+	client redis.UniversalClient
 }
 
 // NewClient creates a new Redis client.
@@ -42,20 +52,58 @@ func NewClient(log logrus.FieldLogger, cfg Config) Client {
 
 // Start initializes the Redis connection pool and verifies connectivity.
 func (c *client) Start(ctx context.Context) error {
-	c.log.WithFields(logrus.Fields{
-		"address": c.cfg.Address,
-		"db":      c.cfg.DB,
-	}).Info("Initializing Redis client")
-
-	c.client = redis.NewClient(&redis.Options{
-		Addr:         c.cfg.Address,
-		Password:     c.cfg.Password,
-		DB:           c.cfg.DB,
-		DialTimeout:  c.cfg.DialTimeout,
-		ReadTimeout:  c.cfg.ReadTimeout,
-		WriteTimeout: c.cfg.WriteTimeout,
-		PoolSize:     c.cfg.PoolSize,
-	})
+	switch c.cfg.Mode {
+	case ModeSentinel:
+		c.log.WithFields(logrus.Fields{
+			"mode":        c.cfg.Mode,
+			"master_name": c.cfg.MasterName,
+			"sentinels":   c.cfg.SentinelAddresses,
+			"db":          c.cfg.DB,
+		}).Info("Initializing Redis client")
+
+		c.client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       c.cfg.MasterName,
+			SentinelAddrs:    c.cfg.SentinelAddresses,
+			SentinelPassword: c.cfg.SentinelPassword,
+			Password:         c.cfg.Password,
+			DB:               c.cfg.DB,
+			DialTimeout:      c.cfg.DialTimeout,
+			ReadTimeout:      c.cfg.ReadTimeout,
+			WriteTimeout:     c.cfg.WriteTimeout,
+			PoolSize:         c.cfg.PoolSize,
+		})
+	case ModeCluster:
+		c.log.WithFields(logrus.Fields{
+			"mode":  c.cfg.Mode,
+			"nodes": c.cfg.ClusterAddresses,
+		}).Info("Initializing Redis client")
+
+		c.client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        c.cfg.ClusterAddresses,
+			Password:     c.cfg.Password,
+			DialTimeout:  c.cfg.DialTimeout,
+			ReadTimeout:  c.cfg.ReadTimeout,
+			WriteTimeout: c.cfg.WriteTimeout,
+			PoolSize:     c.cfg.PoolSize,
+		})
+	default:
+		c.log.WithFields(logrus.Fields{
+			"address": c.cfg.Address,
+			"db":      c.cfg.DB,
+		}).Info("Initializing Redis client")
+
+		c.client = redis.NewClient(&redis.Options{
+			Addr:         c.cfg.Address,
+			Password:     c.cfg.Password,
+			DB:           c.cfg.DB,
+			DialTimeout:  c.cfg.DialTimeout,
+			ReadTimeout:  c.cfg.ReadTimeout,
+			WriteTimeout: c.cfg.WriteTimeout,
+			PoolSize:     c.cfg.PoolSize,
+		})
+	}
+
+	c.client.AddHook(tracingHook{})
 
 	// Verify connection
 	if err := c.Ping(ctx); err != nil {
@@ -87,7 +135,7 @@ func (c *client) Ping(ctx context.Context) error {
 func (c *client) Get(ctx context.Context, key string) (string, error) {
 	val, err := c.client.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return "", fmt.Errorf("key not found: %s", key)
+		return "", fmt.Errorf("%w: %s", ErrNotFound, key)
 	}
 
 	return val, err
@@ -127,7 +175,108 @@ func (c *client) SetNX(
 	return err == nil, err
 }
 
+// delIfEqualScript deletes a key only while it holds the expected value.
+var delIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// DelIfEqual atomically deletes key if it holds value (used to release a lock
+// without releasing one acquired by another instance since).
+// Returns true if the key was deleted.
+func (c *client) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	deleted, err := delIfEqualScript.Run(ctx, c.client, []string{key}, value).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted == 1, nil
+}
+
+// Incr increments an integer key, starting from 0 if it doesn't exist.
+func (c *client) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+// Publish sends a message to every subscriber of channel.
+func (c *client) Publish(ctx context.Context, channel, message string) error {
+	return c.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe returns the messages published to channel. The subscription is
+// confirmed before returning and the channel is closed once ctx is done.
+// Messages published while the connection is re-established are lost.
+func (c *client) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	pubsub := c.client.Subscribe(ctx, channel)
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+
+		return nil, fmt.Errorf("subscribe to %s: %w", channel, err)
+	}
+
+	messages := make(chan string)
+
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		ch := pubsub.Channel()
+
+		for {
+			select {
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				select {
+				case messages <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// Keys returns the keys matching pattern. In cluster mode every master is
+// asked, as each only holds its own slots.
+func (c *client) Keys(ctx context.Context, pattern string) ([]string, error) {
+	cluster, ok := c.client.(*redis.ClusterClient)
+	if !ok {
+		return c.client.Keys(ctx, pattern).Result()
+	}
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		nodeKeys, err := node.Keys(ctx, pattern).Result()
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		keys = append(keys, nodeKeys...)
+		mu.Unlock()
+
+		return nil
+	})
+
+	return keys, err
+}
+
 // GetClient returns the underlying go-redis client for advanced operations.
-func (c *client) GetClient() *redis.Client {
+// It is a *redis.Client, or a *redis.ClusterClient in cluster mode.
+func (c *client) GetClient() redis.UniversalClient {
 	return c.client
 }
@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Keys(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client := NewClient(logger, Config{
+		Mode:        ModeStandalone,
+		Address:     mr.Addr(),
+		DialTimeout: time.Second,
+		PoolSize:    1,
+	})
+
+	ctx := context.Background()
+
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(func() { _ = client.Stop() })
+
+	require.NoError(t, client.Set(ctx, "lab:bounds:mainnet", "{}", 0))
+	require.NoError(t, client.Set(ctx, "lab:bounds:sepolia", "{}", 0))
+	require.NoError(t, client.Set(ctx, "lab:config:networks", "{}", 0))
+
+	keys, err := client.Keys(ctx, "lab:bounds:*")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"lab:bounds:mainnet", "lab:bounds:sepolia"}, keys)
+}
+
+func TestClient_StartFailsWithoutSentinels(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client := NewClient(logger, Config{
+		Mode:              ModeSentinel,
+		MasterName:        "mymaster",
+		SentinelAddresses: []string{"127.0.0.1:1"},
+		DialTimeout:       100 * time.Millisecond,
+		PoolSize:          1,
+	})
+
+	t.Cleanup(func() { _ = client.Stop() })
+
+	require.Error(t, client.Start(context.Background()))
+}
@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+)
+
+// tracingHook records a client span for every Redis command and pipeline.
+type tracingHook struct{}
+
+var _ redis.Hook = tracingHook{}
+
+func (tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := tracing.StartSpan(
+			ctx,
+			"redis "+cmd.Name(),
+			trace.SpanKindClient,
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", cmd.Name()),
+		)
+
+		err := next(ctx, cmd)
+
+		tracing.EndSpan(span, spanError(err))
+
+		return err
+	}
+}
+
+func (tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := tracing.StartSpan(
+			ctx,
+			"redis pipeline",
+			trace.SpanKindClient,
+			attribute.String("db.system", "redis"),
+			attribute.Int("db.redis.pipeline_length", len(cmds)),
+		)
+
+		err := next(ctx, cmds)
+
+		tracing.EndSpan(span, spanError(err))
+
+		return err
+	}
+}
+
+// spanError returns err unless it only reports a missing key.
+func spanError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	return err
+}
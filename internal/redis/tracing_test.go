@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClient_TracesCommands(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	mr := miniredis.RunT(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client := NewClient(logger, Config{
+		Address:     mr.Addr(),
+		DialTimeout: time.Second,
+		PoolSize:    1,
+	})
+
+	ctx := context.Background()
+
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(func() { _ = client.Stop() })
+
+	require.NoError(t, client.Set(ctx, "key", "value", 0))
+
+	_, err := client.Get(ctx, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	names := make(map[string]string)
+	for _, span := range recorder.Ended() {
+		names[span.Name()] = span.Status().Code.String()
+	}
+
+	assert.Contains(t, names, "redis ping")
+	assert.Contains(t, names, "redis set")
+
+	// A missing key is not a failed span
+	assert.Equal(t, "Unset", names["redis get"])
+}
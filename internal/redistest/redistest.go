@@ -0,0 +1,27 @@
+// Package redistest provides Redis clients backed by miniredis for tests.
+package redistest
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/redis"
+)
+
+// New starts a miniredis server and a client connected to it, both stopped
+// when the test ends. The server is returned to seed keys, fast-forward TTLs
+// or inject errors.
+func New(t testing.TB, log logrus.FieldLogger) (redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(log, redis.Config{Address: mr.Addr()})
+	require.NoError(t, client.Start(t.Context()))
+	t.Cleanup(func() { _ = client.Stop() })
+
+	return client, mr
+}
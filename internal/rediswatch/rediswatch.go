@@ -0,0 +1,154 @@
+// Package rediswatch keeps a value stored in Redis in sync on every instance.
+// A Watcher loads the value once on start and then polls it in a supervised
+// background loop, keeping the last known value when Redis is unreachable.
+package rediswatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+// Config describes what a Watcher loads and how often.
+type Config[T any] struct {
+	Name     string        // Task name, e.g. "read_only.poll"
+	Interval time.Duration // Poll interval
+	Timeout  time.Duration // Bounds each load
+
+	// Load reads the current value, nil when it is absent
+	Load func(ctx context.Context) (*T, error)
+
+	// OnChange is called after every successful load with the previous and
+	// new value, for logging and metrics. Optional.
+	OnChange func(prev, next *T)
+}
+
+// Watcher holds the last value loaded from Redis.
+type Watcher[T any] struct {
+	cfg Config[T]
+	log logrus.FieldLogger
+
+	value atomic.Pointer[T]
+
+	ctx    context.Context //nolint:containedctx // canceled on Stop
+	cancel context.CancelFunc
+	task   *tasks.Task
+	wg     sync.WaitGroup
+}
+
+// New creates a watcher. It loads nothing until Start or Refresh is called.
+func New[T any](log logrus.FieldLogger, cfg Config[T]) *Watcher[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Watcher[T]{
+		cfg:    cfg,
+		log:    log,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Key returns a Load function reading a single key, decoded with decode.
+// A missing key loads as nil.
+func Key[T any](client redis.Client, key string, decode func(data string) (*T, error)) func(ctx context.Context) (*T, error) {
+	return func(ctx context.Context) (*T, error) {
+		data, err := client.Get(ctx, key)
+
+		switch {
+		case errors.Is(err, redis.ErrNotFound):
+			return nil, nil
+		case err != nil:
+			return nil, fmt.Errorf("get %s: %w", key, err)
+		}
+
+		return decode(data)
+	}
+}
+
+// Value returns the last loaded value, nil while absent or before the first
+// load. A nil watcher has no value.
+func (w *Watcher[T]) Value() *T {
+	if w == nil {
+		return nil
+	}
+
+	return w.value.Load()
+}
+
+// Start loads the value once and then polls it in the background until Stop.
+// A nil watcher does nothing.
+func (w *Watcher[T]) Start() {
+	if w == nil {
+		return
+	}
+
+	w.task = tasks.Default().Register(w.cfg.Name, w.cfg.Interval)
+
+	if err := w.task.Run(w.poll); err != nil {
+		w.log.WithError(err).Warn("Initial load from Redis failed")
+	}
+
+	w.wg.Go(func() {
+		w.task.Supervise(w.log, w.ctx.Done(), w.pollLoop)
+	})
+}
+
+// Stop stops polling and waits for it to finish.
+func (w *Watcher[T]) Stop() {
+	if w == nil {
+		return
+	}
+
+	w.cancel()
+	w.wg.Wait()
+
+	tasks.Default().Unregister(w.task)
+}
+
+// Refresh loads the value now, under ctx, e.g. right after it was written.
+// On errors the last known value is kept.
+func (w *Watcher[T]) Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.Timeout)
+	defer cancel()
+
+	next, err := w.cfg.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	prev := w.value.Swap(next)
+
+	if w.cfg.OnChange != nil {
+		w.cfg.OnChange(prev, next)
+	}
+
+	return nil
+}
+
+// pollLoop runs poll on every interval until stopped.
+func (w *Watcher[T]) pollLoop() {
+	ticker := jitter.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = w.task.Run(w.poll)
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Watcher[T]) poll() error {
+	return w.Refresh(w.ctx)
+}
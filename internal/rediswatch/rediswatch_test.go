@@ -0,0 +1,58 @@
+package rediswatch
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func TestWatcher(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	var changes int
+
+	w := New(logger, Config[string]{
+		Name:     "rediswatch.test",
+		Interval: time.Hour,
+		Timeout:  time.Second,
+		Load:     Key(client, "watched", func(data string) (*string, error) { return &data, nil }),
+		OnChange: func(_, _ *string) { changes++ },
+	})
+	w.Start()
+	t.Cleanup(w.Stop)
+
+	assert.Nil(t, w.Value())
+
+	require.NoError(t, mr.Set("watched", "on"))
+	require.NoError(t, w.Refresh(t.Context()))
+	require.NotNil(t, w.Value())
+	assert.Equal(t, "on", *w.Value())
+
+	// Redis errors keep the last known value
+	mr.SetError("unavailable")
+	require.Error(t, w.Refresh(t.Context()))
+	mr.SetError("")
+	assert.Equal(t, "on", *w.Value())
+
+	mr.Del("watched")
+	require.NoError(t, w.Refresh(t.Context()))
+	assert.Nil(t, w.Value())
+	assert.Equal(t, 3, changes)
+}
+
+func TestWatcher_Nil(t *testing.T) {
+	var w *Watcher[string]
+
+	w.Start()
+	w.Stop()
+	assert.Nil(t, w.Value())
+}
@@ -0,0 +1,76 @@
+// Package requestid assigns every request an ID that is echoed to the client,
+// forwarded to upstreams and attached to log lines and error responses, so one
+// request can be followed across services.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Header carries the request ID on incoming requests, responses and proxied requests.
+const Header = "X-Request-ID"
+
+// maxLength caps the length of a client-supplied request ID.
+const maxLength = 128
+
+// contextKey stores the request ID of the current request.
+type contextKey struct{}
+
+// New returns a new random request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// ContextWithID returns a context carrying id.
+func ContextWithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or "" if none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+
+	return id
+}
+
+// FromHeader returns the request ID supplied by the client. IDs that are too
+// long or contain anything but letters, digits and -_.: are ignored, so they
+// can be logged and echoed safely.
+func FromHeader(h http.Header) string {
+	id := h.Get(Header)
+	if id == "" || len(id) > maxLength {
+		return ""
+	}
+
+	for i := range len(id) {
+		if !validChar(id[i]) {
+			return ""
+		}
+	}
+
+	return id
+}
+
+// Logger returns log with the request ID of ctx attached, if any.
+func Logger(ctx context.Context, log logrus.FieldLogger) logrus.FieldLogger {
+	if id := FromContext(ctx); id != "" {
+		return log.WithField("request_id", id)
+	}
+
+	return log
+}
+
+func validChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	case c == '-', c == '_', c == '.', c == ':':
+		return true
+	default:
+		return false
+	}
+}
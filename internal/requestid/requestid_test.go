@@ -0,0 +1,50 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "absent", value: "", expected: ""},
+		{name: "uuid", value: "0f8fad5b-d9cb-469f-a165-70867728950e", expected: "0f8fad5b-d9cb-469f-a165-70867728950e"},
+		{name: "allowed punctuation", value: "trace:abc_1.2", expected: "trace:abc_1.2"},
+		{name: "whitespace", value: "abc def", expected: ""},
+		{name: "quotes", value: `abc"def`, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.value != "" {
+				h.Set(Header, tt.value)
+			}
+
+			assert.Equal(t, tt.expected, FromHeader(h))
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	Logger(context.Background(), logger).Info("without")
+	assert.NotContains(t, buf.String(), "request_id")
+
+	Logger(ContextWithID(context.Background(), "abc"), logger).Info("with")
+	assert.Contains(t, buf.String(), `"request_id":"abc"`)
+}
@@ -0,0 +1,292 @@
+// Package scheduler runs periodic jobs on the leader only. Each job gets its
+// own jittered ticker and supervised loop; followers skip their ticks. The
+// outcome of every run is stored in Redis, so any replica can report when a
+// job last ran and how it went, whichever instance ran it.
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+const (
+	redisKeyPrefix = "lab:scheduler:"
+
+	// recordRetention is how long a job's record outlives its last run, so
+	// jobs that were removed eventually drop out of Redis.
+	recordRetention = 7 * 24 * time.Hour
+
+	// recordTimeout bounds reading and writing a job's record.
+	recordTimeout = 5 * time.Second
+)
+
+// job is a registered job and its loop.
+type job struct {
+	Job
+
+	task *tasks.Task
+}
+
+// Scheduler runs registered jobs on the leader.
+type Scheduler struct {
+	log      logrus.FieldLogger
+	redis    redis.Client
+	elector  leader.Elector
+	instance string
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	started bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a scheduler.
+func New(log logrus.FieldLogger, redisClient redis.Client, elector leader.Elector) *Scheduler {
+	instance, _ := os.Hostname()
+
+	return &Scheduler{
+		log:      log.WithField("component", "scheduler"),
+		redis:    redisClient,
+		elector:  elector,
+		instance: instance,
+		jobs:     make(map[string]*job),
+		done:     make(chan struct{}),
+	}
+}
+
+// Register adds a job, replacing any job with the same name. Jobs must be
+// registered before Start.
+func (s *Scheduler) Register(j Job) {
+	if j.Interval <= 0 {
+		panic(fmt.Sprintf("scheduler: non-positive interval for job %s", j.Name))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		panic(fmt.Sprintf("scheduler: job %s registered after start", j.Name))
+	}
+
+	s.jobs[j.Name] = &job{Job: j}
+}
+
+// Start runs every registered job in the background.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.started = true
+
+	for _, j := range s.jobs {
+		j.task = tasks.Default().Register(j.Name, j.Interval)
+		s.wg.Add(1)
+
+		go s.jobLoop(ctx, j)
+	}
+
+	s.log.WithField("jobs", len(s.jobs)).Info("Started scheduler")
+}
+
+// Stop stops every job and waits for in-flight runs to finish.
+func (s *Scheduler) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// Statuses returns every registered job with its record from Redis, sorted
+// by name. Jobs that never ran have an empty record.
+func (s *Scheduler) Statuses(ctx context.Context) ([]Status, error) {
+	s.mu.Lock()
+
+	jobs := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+
+	s.mu.Unlock()
+
+	statuses := make([]Status, 0, len(jobs))
+
+	for _, j := range jobs {
+		record, err := s.record(ctx, j.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, Status{
+			Name:     j.Name,
+			Interval: j.Interval.String(),
+			Jitter:   j.jitter(),
+			Record:   *record,
+		})
+	}
+
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return statuses, nil
+}
+
+// jobLoop runs a job's loop under the task supervisor, which restarts it
+// with backoff if it panics.
+func (s *Scheduler) jobLoop(ctx context.Context, j *job) {
+	defer s.wg.Done()
+
+	j.task.Supervise(s.log.WithField("job", j.Name), s.done, func() { s.runJobLoop(ctx, j) })
+}
+
+func (s *Scheduler) runJobLoop(ctx context.Context, j *job) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var ticker *jitter.Ticker
+	if j.Jitter != nil {
+		ticker = jitter.NewTickerWithJitter(j.Interval, *j.Jitter)
+	} else {
+		ticker = jitter.NewTicker(j.Interval)
+	}
+
+	defer ticker.Stop()
+
+	// Give leader election a moment to settle.
+	time.Sleep(100 * time.Millisecond)
+
+	for {
+		// Only the leader runs jobs; followers only show they are alive
+		if s.elector.IsLeader() {
+			_ = j.task.Run(func() error { return s.Run(ctx, j.Name) })
+		} else {
+			j.task.Tick()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run runs a registered job once, if this instance is the leader, and
+// records the outcome in Redis.
+func (s *Scheduler) Run(ctx context.Context, name string) error {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	s.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("job %s: %w", name, errs.ErrNotFound)
+	}
+
+	// Leadership may have been lost since the caller checked
+	if !s.elector.IsLeader() {
+		return errs.ErrNotLeader
+	}
+
+	start := time.Now()
+	err := j.Run(ctx)
+
+	s.store(context.WithoutCancel(ctx), j.Name, start, time.Since(start), err)
+
+	if err != nil {
+		s.log.WithError(err).WithField("job", j.Name).Warn("Scheduled job failed")
+	}
+
+	return err
+}
+
+// store adds a run to the job's record. Records only feed reports, so
+// failures to store them are logged and otherwise ignored.
+func (s *Scheduler) store(ctx context.Context, name string, start time.Time, duration time.Duration, runErr error) {
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+
+	// Only the leader writes records, so read-modify-write does not race
+	record, err := s.record(ctx, name)
+	if err != nil {
+		s.log.WithError(err).WithField("job", name).Warn("Failed to read job record")
+
+		record = &Record{}
+	}
+
+	start = start.UTC()
+	record.LastRunAt = &start
+	record.LastDurationMs = float64(duration) / float64(time.Millisecond)
+	record.Runs++
+	record.Instance = s.instance
+
+	if runErr != nil {
+		record.LastError = runErr.Error()
+		record.LastErrorAt = &start
+		record.Failures++
+	} else {
+		record.LastError = ""
+		record.LastSuccessAt = &start
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		s.log.WithError(err).WithField("job", name).Warn("Failed to marshal job record")
+
+		return
+	}
+
+	if err := s.redis.Set(ctx, redisKeyPrefix+name, string(data), recordRetention); err != nil {
+		s.log.WithError(err).WithField("job", name).Warn("Failed to store job record")
+	}
+}
+
+// record reads a job's record from Redis. A job that never ran has an empty one.
+func (s *Scheduler) record(ctx context.Context, name string) (*Record, error) {
+	data, err := s.redis.Get(ctx, redisKeyPrefix+name)
+	if errors.Is(err, redis.ErrNotFound) {
+		return &Record{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read record of job %s: %w", name, err)
+	}
+
+	var record Record
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, fmt.Errorf("unmarshal record of job %s: %w", name, err)
+	}
+
+	return &record, nil
+}
+
+// jitter returns the jitter the job's ticker uses.
+func (j *job) jitter() float64 {
+	if j.Jitter != nil {
+		return *j.Jitter
+	}
+
+	return jitter.Fraction()
+}
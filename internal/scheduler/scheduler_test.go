@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+func newTestScheduler(t *testing.T, isLeader *atomic.Bool) *Scheduler {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	elector := leadermocks.NewMockElector(gomock.NewController(t))
+	elector.EXPECT().IsLeader().DoAndReturn(isLeader.Load).AnyTimes()
+
+	return New(logger, client, elector)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	var isLeader atomic.Bool
+
+	isLeader.Store(true)
+
+	s := newTestScheduler(t, &isLeader)
+	ctx := context.Background()
+
+	var fail atomic.Bool
+
+	s.Register(Job{
+		Name:     "test.flaky",
+		Interval: time.Minute,
+		Run: func(context.Context) error {
+			if fail.Load() {
+				return errors.New("upstream down")
+			}
+
+			return nil
+		},
+	})
+
+	// A successful run
+	require.NoError(t, s.Run(ctx, "test.flaky"))
+
+	statuses, err := s.Statuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+
+	first := statuses[0]
+	assert.Equal(t, "1m0s", first.Interval)
+	assert.Equal(t, uint64(1), first.Runs)
+	assert.NotNil(t, first.LastSuccessAt)
+	assert.Empty(t, first.LastError)
+
+	// A failed run keeps the last success
+	fail.Store(true)
+	require.Error(t, s.Run(ctx, "test.flaky"))
+
+	statuses, err = s.Statuses(ctx)
+	require.NoError(t, err)
+
+	failed := statuses[0]
+	assert.Equal(t, uint64(2), failed.Runs)
+	assert.Equal(t, uint64(1), failed.Failures)
+	assert.Equal(t, "upstream down", failed.LastError)
+	assert.NotNil(t, failed.LastErrorAt)
+	assert.Equal(t, first.LastSuccessAt, failed.LastSuccessAt)
+
+	// A later success clears the error
+	fail.Store(false)
+	require.NoError(t, s.Run(ctx, "test.flaky"))
+
+	statuses, err = s.Statuses(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, statuses[0].LastError)
+	assert.Equal(t, uint64(1), statuses[0].Failures)
+
+	// Unknown jobs
+	require.ErrorIs(t, s.Run(ctx, "test.unknown"), errs.ErrNotFound)
+}
+
+func TestScheduler_RunFollower(t *testing.T) {
+	var isLeader atomic.Bool
+
+	s := newTestScheduler(t, &isLeader)
+	ctx := context.Background()
+
+	var runs atomic.Int32
+
+	s.Register(Job{
+		Name:     "test.leader_only",
+		Interval: time.Minute,
+		Run: func(context.Context) error {
+			runs.Add(1)
+
+			return nil
+		},
+	})
+
+	require.ErrorIs(t, s.Run(ctx, "test.leader_only"), errs.ErrNotLeader)
+	assert.Zero(t, runs.Load())
+
+	statuses, err := s.Statuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Nil(t, statuses[0].LastRunAt, "jobs that never ran have an empty record")
+}
+
+func TestScheduler_StartRunsOnLeader(t *testing.T) {
+	var isLeader atomic.Bool
+
+	isLeader.Store(true)
+
+	s := newTestScheduler(t, &isLeader)
+
+	jitter := 0.0
+	ran := make(chan struct{}, 1)
+
+	s.Register(Job{
+		Name:     "test.start",
+		Interval: time.Hour,
+		Jitter:   &jitter,
+		Run: func(context.Context) error {
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+
+			return nil
+		},
+	})
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run on start")
+	}
+
+	assert.Panics(t, func() {
+		s.Register(Job{Name: "test.late", Interval: time.Minute})
+	}, "jobs cannot be added once started")
+}
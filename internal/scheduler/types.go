@@ -0,0 +1,35 @@
+//nolint:tagliatelle // superior snake-case yo.
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a task run periodically on the leader.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Jitter   *float64 // Fraction of Interval each run is moved by, up to ±; nil uses timers.jitter
+	Run      func(ctx context.Context) error
+}
+
+// Record is the outcome of a job's runs, shared by every replica through Redis.
+type Record struct {
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	LastDurationMs float64    `json:"last_duration_ms"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"` // Error of the last run, empty if it succeeded
+	LastErrorAt    *time.Time `json:"last_error_at,omitempty"`
+	Runs           uint64     `json:"runs"`
+	Failures       uint64     `json:"failures"`
+	Instance       string     `json:"instance,omitempty"` // Instance that ran the job last
+}
+
+// Status is a scheduled job with the outcome of its runs.
+type Status struct {
+	Name     string  `json:"name"`
+	Interval string  `json:"interval"`
+	Jitter   float64 `json:"jitter"`
+	Record
+}
@@ -0,0 +1,260 @@
+// Package schema validates JSON responses against the response schemas of
+// OpenAPI 3 specs. It supports the subset of JSON Schema used by the CBT API
+// and lab-backend specs: type, nullable, properties, required, items,
+// additionalProperties, enum, $ref and allOf/anyOf/oneOf.
+package schema
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document is the part of an OpenAPI 3 document needed for response validation.
+type document struct {
+	Paths      map[string]pathItem `yaml:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type pathItem struct {
+	Get    *operation `yaml:"get"`
+	Put    *operation `yaml:"put"`
+	Post   *operation `yaml:"post"`
+	Delete *operation `yaml:"delete"`
+	Patch  *operation `yaml:"patch"`
+	Head   *operation `yaml:"head"`
+}
+
+type operation struct {
+	Responses map[string]response `yaml:"responses"`
+}
+
+type response struct {
+	Content map[string]struct {
+		Schema *Schema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+// Schema is a JSON Schema object from an OpenAPI document.
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 typeList           `yaml:"type"`
+	Nullable             bool               `yaml:"nullable"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	Required             []string           `yaml:"required"`
+	Items                *Schema            `yaml:"items"`
+	AdditionalProperties *additional        `yaml:"additionalProperties"`
+	Enum                 []any              `yaml:"enum"`
+	AllOf                []*Schema          `yaml:"allOf"`
+	AnyOf                []*Schema          `yaml:"anyOf"`
+	OneOf                []*Schema          `yaml:"oneOf"`
+}
+
+// typeList is a schema type, which OpenAPI 3.1 also allows as a list.
+type typeList []string
+
+// UnmarshalYAML accepts a single type or a list of types.
+func (t *typeList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = typeList{node.Value}
+
+		return nil
+	}
+
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+
+	*t = types
+
+	return nil
+}
+
+// additional is additionalProperties, either a boolean or a schema.
+type additional struct {
+	allowed bool
+	schema  *Schema
+}
+
+// UnmarshalYAML accepts a boolean or a schema.
+func (a *additional) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.allowed)
+	}
+
+	a.allowed = true
+	a.schema = &Schema{}
+
+	return node.Decode(a.schema)
+}
+
+// route is one operation's path template, split into segments.
+type route struct {
+	template string
+	segments []string
+	item     pathItem
+}
+
+// spec is a loaded OpenAPI document mounted at a base path.
+type spec struct {
+	name     string
+	base     []string
+	routes   []route
+	document *document
+}
+
+// loadSpec reads an OpenAPI document (YAML or JSON) from path.
+func loadSpec(path, basePath string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("spec %s has no paths", path)
+	}
+
+	s := &spec{
+		name:     path,
+		base:     splitPath(basePath),
+		document: &doc,
+		routes:   make([]route, 0, len(doc.Paths)),
+	}
+
+	for template, item := range doc.Paths {
+		s.routes = append(s.routes, route{
+			template: template,
+			segments: splitPath(template),
+			item:     item,
+		})
+	}
+
+	return s, nil
+}
+
+// match finds the route for a request path. Literal routes win over templated ones.
+func (s *spec) match(path string) (*route, bool) {
+	segments := splitPath(path)
+	if len(segments) < len(s.base) || !matchSegments(s.base, segments[:len(s.base)]) {
+		return nil, false
+	}
+
+	segments = segments[len(s.base):]
+
+	var found *route
+
+	for i := range s.routes {
+		r := &s.routes[i]
+		if len(r.segments) != len(segments) || !matchSegments(r.segments, segments) {
+			continue
+		}
+
+		if found == nil || !strings.Contains(r.template, "{") {
+			found = r
+		}
+	}
+
+	return found, found != nil
+}
+
+// responseSchema returns the JSON schema for a method and status, trying the
+// exact code, then its class (e.g. 2XX), then default.
+func (r *route) responseSchema(method string, status int) (*Schema, bool) {
+	var op *operation
+
+	switch method {
+	case http.MethodGet:
+		op = r.item.Get
+	case http.MethodPut:
+		op = r.item.Put
+	case http.MethodPost:
+		op = r.item.Post
+	case http.MethodDelete:
+		op = r.item.Delete
+	case http.MethodPatch:
+		op = r.item.Patch
+	case http.MethodHead:
+		op = r.item.Head
+	}
+
+	if op == nil {
+		return nil, false
+	}
+
+	code := strconv.Itoa(status)
+
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		resp, exists := op.Responses[key]
+		if !exists {
+			continue
+		}
+
+		for contentType, content := range resp.Content {
+			if content.Schema != nil && strings.Contains(contentType, "json") {
+				return content.Schema, true
+			}
+		}
+
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// resolve follows a local $ref (#/components/schemas/Name).
+func (s *spec) resolve(schema *Schema) (*Schema, error) {
+	for depth := 0; schema.Ref != ""; depth++ {
+		if depth > 32 {
+			return nil, fmt.Errorf("$ref cycle at %s", schema.Ref)
+		}
+
+		name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+		if !ok {
+			return nil, fmt.Errorf("unsupported $ref %s", schema.Ref)
+		}
+
+		target, exists := s.document.Components.Schemas[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown $ref %s", schema.Ref)
+		}
+
+		schema = target
+	}
+
+	return schema, nil
+}
+
+func matchSegments(template, segments []string) bool {
+	for i, segment := range template {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		if segment != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
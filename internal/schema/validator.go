@@ -0,0 +1,255 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// maxErrors caps the mismatches reported for a single response.
+const maxErrors = 20
+
+// Result is the outcome of validating one response.
+type Result struct {
+	Spec   string   // Spec file the response was validated against
+	Route  string   // Matched path template
+	Errors []string // Mismatches, empty if the response is valid
+}
+
+// Validator validates responses against a set of OpenAPI specs.
+type Validator struct {
+	specs []*spec
+}
+
+// NewValidator loads the configured specs.
+func NewValidator(specs []config.SchemaSpec) (*Validator, error) {
+	v := &Validator{specs: make([]*spec, 0, len(specs))}
+
+	for _, cfg := range specs {
+		s, err := loadSpec(cfg.Path, cfg.BasePath)
+		if err != nil {
+			return nil, fmt.Errorf("spec %s: %w", cfg.Path, err)
+		}
+
+		v.specs = append(v.specs, s)
+	}
+
+	return v, nil
+}
+
+// Validate checks a JSON response body against the schema for the request's
+// method, path and status. Returns false if no spec describes the response.
+func (v *Validator) Validate(method, path string, status int, body []byte) (Result, bool) {
+	for _, s := range v.specs {
+		r, ok := s.match(path)
+		if !ok {
+			continue
+		}
+
+		schema, ok := r.responseSchema(method, status)
+		if !ok {
+			continue
+		}
+
+		result := Result{Spec: s.name, Route: r.template}
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			result.Errors = []string{fmt.Sprintf("invalid JSON: %v", err)}
+
+			return result, true
+		}
+
+		c := &checker{spec: s}
+		c.check("$", schema, value)
+		result.Errors = c.errors
+
+		return result, true
+	}
+
+	return Result{}, false
+}
+
+// checker walks a value against a schema, collecting mismatches.
+type checker struct {
+	spec   *spec
+	errors []string
+}
+
+func (c *checker) fail(at, format string, args ...any) {
+	if len(c.errors) < maxErrors {
+		c.errors = append(c.errors, at+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (c *checker) check(at string, schema *Schema, value any) {
+	schema, err := c.spec.resolve(schema)
+	if err != nil {
+		c.fail(at, "%v", err)
+
+		return
+	}
+
+	for _, sub := range schema.AllOf {
+		c.check(at, sub, value)
+	}
+
+	if len(schema.AnyOf) > 0 && !c.matchesAny(at, schema.AnyOf, value) {
+		c.fail(at, "does not match any anyOf schema")
+	}
+
+	if len(schema.OneOf) > 0 && !c.matchesAny(at, schema.OneOf, value) {
+		c.fail(at, "does not match any oneOf schema")
+	}
+
+	if value == nil {
+		if len(schema.Type) > 0 && !schema.Nullable && !schema.Type.has("null") {
+			c.fail(at, "null is not allowed")
+		}
+
+		return
+	}
+
+	if len(schema.Type) > 0 && !schema.Type.matches(value) {
+		c.fail(at, "expected %s, got %s", strings.Join(schema.Type, " or "), jsonType(value))
+
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		c.fail(at, "value %v is not one of the allowed values", value)
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		c.checkObject(at, schema, typed)
+	case []any:
+		if schema.Items != nil {
+			for i, item := range typed {
+				c.check(fmt.Sprintf("%s[%d]", at, i), schema.Items, item)
+			}
+		}
+	}
+}
+
+func (c *checker) checkObject(at string, schema *Schema, object map[string]any) {
+	for _, name := range schema.Required {
+		if _, exists := object[name]; !exists {
+			c.fail(at, "missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, exists := schema.Properties[name]; exists {
+			c.check(at+"."+name, property, object[name])
+
+			continue
+		}
+
+		if schema.AdditionalProperties == nil {
+			continue
+		}
+
+		if !schema.AdditionalProperties.allowed {
+			c.fail(at, "unexpected property %q", name)
+
+			continue
+		}
+
+		if schema.AdditionalProperties.schema != nil {
+			c.check(at+"."+name, schema.AdditionalProperties.schema, object[name])
+		}
+	}
+}
+
+// matchesAny reports whether value is valid against at least one schema.
+func (c *checker) matchesAny(at string, schemas []*Schema, value any) bool {
+	for _, sub := range schemas {
+		trial := &checker{spec: c.spec}
+		trial.check(at, sub, value)
+
+		if len(trial.errors) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t typeList) has(name string) bool {
+	for _, typ := range t {
+		if typ == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches reports whether a decoded JSON value has one of the types.
+func (t typeList) matches(value any) bool {
+	actual := jsonType(value)
+
+	for _, typ := range t {
+		if typ == actual || (typ == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonType returns the JSON Schema type of a value decoded with UseNumber.
+func jsonType(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := typed.Int64(); err == nil {
+			return "integer"
+		}
+
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// inEnum compares by JSON encoding, since spec values are decoded from YAML.
+func inEnum(enum []any, value any) bool {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range enum {
+		candidate, err := json.Marshal(allowed)
+		if err == nil && bytes.Equal(candidate, encoded) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: CBT API (test subset)
+  version: "1"
+paths:
+  /fct_block:
+    get:
+      responses:
+        200:
+          description: Blocks
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ListFctBlockResponse"
+        default:
+          description: Error
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Status"
+  /fct_block/{slot}:
+    get:
+      responses:
+        "200":
+          description: Block
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/FctBlock"
+components:
+  schemas:
+    ListFctBlockResponse:
+      type: object
+      required: [fct_block]
+      properties:
+        fct_block:
+          type: array
+          items:
+            $ref: "#/components/schemas/FctBlock"
+        next_page_token:
+          type: string
+    FctBlock:
+      type: object
+      required: [slot, block_root]
+      additionalProperties: false
+      properties:
+        slot:
+          type: integer
+        block_root:
+          type: string
+        status:
+          type: string
+          enum: [canonical, orphaned]
+        proposer_index:
+          type: integer
+          nullable: true
+    Status:
+      type: object
+      properties:
+        code:
+          type: integer
+        message:
+          type: string
+`
+
+// newTestValidator loads testSpec mounted at /api/v1/{network}.
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "cbt.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSpec), 0o600))
+
+	validator, err := NewValidator([]config.SchemaSpec{{Path: path, BasePath: "/api/v1/{network}"}})
+	require.NoError(t, err)
+
+	return validator
+}
+
+func TestValidator_Validate(t *testing.T) {
+	validator := newTestValidator(t)
+
+	tests := []struct {
+		name      string
+		path      string
+		status    int
+		body      string
+		wantRoute string
+		wantErrs  []string
+	}{
+		{
+			name:      "valid list response",
+			path:      "/api/v1/mainnet/fct_block",
+			status:    200,
+			body:      `{"fct_block":[{"slot":1,"block_root":"0xab","status":"canonical","proposer_index":null}],"next_page_token":""}`,
+			wantRoute: "/fct_block",
+		},
+		{
+			name:      "missing required property and wrong type",
+			path:      "/api/v1/mainnet/fct_block",
+			status:    200,
+			body:      `{"fct_block":[{"slot":"1"}]}`,
+			wantRoute: "/fct_block",
+			wantErrs: []string{
+				`$.fct_block[0]: missing required property "block_root"`,
+				"$.fct_block[0].slot: expected integer, got string",
+			},
+		},
+		{
+			name:      "enum, non-integer and unexpected property",
+			path:      "/api/v1/mainnet/fct_block/5",
+			status:    200,
+			body:      `{"slot":1.5,"block_root":"0xab","status":"missed","extra":true}`,
+			wantRoute: "/fct_block/{slot}",
+			wantErrs: []string{
+				`$: unexpected property "extra"`,
+				"$.slot: expected integer, got number",
+				"$.status: value missed is not one of the allowed values",
+			},
+		},
+		{
+			name:      "error responses use the default schema",
+			path:      "/api/v1/holesky/fct_block",
+			status:    404,
+			body:      `{"code":"not_found"}`,
+			wantRoute: "/fct_block",
+			wantErrs:  []string{"$.code: expected integer, got string"},
+		},
+		{
+			name:      "invalid JSON",
+			path:      "/api/v1/mainnet/fct_block",
+			status:    200,
+			body:      `{"fct_block":`,
+			wantRoute: "/fct_block",
+			wantErrs:  []string{"invalid JSON: unexpected EOF"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := validator.Validate("GET", tt.path, tt.status, []byte(tt.body))
+			require.True(t, ok)
+
+			assert.Equal(t, tt.wantRoute, result.Route)
+			assert.Equal(t, tt.wantErrs, result.Errors)
+		})
+	}
+}
+
+func TestValidator_Unmatched(t *testing.T) {
+	validator := newTestValidator(t)
+
+	// Outside the base path, unknown route, undocumented method and status
+	for _, tc := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{"GET", "/api/v1/config", 200},
+		{"GET", "/api/v1/mainnet/fct_attestation", 200},
+		{"POST", "/api/v1/mainnet/fct_block", 200},
+		{"GET", "/api/v1/mainnet/fct_block/5", 500},
+	} {
+		_, ok := validator.Validate(tc.method, tc.path, tc.status, []byte(`{}`))
+		assert.False(t, ok, "%s %s %d", tc.method, tc.path, tc.status)
+	}
+}
@@ -0,0 +1,446 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+// Push event types sent over /api/v1/ws.
+const (
+	pushEventBounds   = "bounds"
+	pushEventNetworks = "networks"
+)
+
+// pushClientBuffer is how many events may queue for a client before it is
+// considered too slow and disconnected.
+const pushClientBuffer = 16
+
+var (
+	pushClients = metrics.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "push_websocket_clients",
+			Help: "Number of connected /api/v1/ws push clients",
+		},
+	)
+
+	pushEventsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "push_events_total",
+			Help: "Total number of events broadcast to /api/v1/ws push clients",
+		},
+		[]string{"type"},
+	)
+)
+
+// pushEvent is a single message sent to push clients.
+// Data matches the corresponding REST payload: the networks list of
+// /api/v1/config, or per-network table bounds as served by /api/v1/{network}/bounds.
+// Networks events also carry the changes of the update that triggered them.
+// Hidden networks are never pushed, and clients with a scoped API key only
+// receive the event types and networks their key allows.
+type pushEvent struct {
+	Type      string                 `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      any                    `json:"data"`
+	Changes   *cartographoor.Changes `json:"changes,omitempty"`
+}
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*pushHub)(nil)
+
+// pushHub serves GET /api/v1/ws and broadcasts bounds and network updates to
+// every connected client whenever a provider signals new data.
+type pushHub struct {
+	cfg                   config.PushConfig
+	logger                logrus.FieldLogger
+	configHandler         *api.ConfigHandler
+	boundsProvider        bounds.Provider
+	cartographoorProvider cartographoor.Provider
+
+	task *tasks.Task
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	mu      sync.Mutex
+	clients map[*pushClient]struct{}
+}
+
+// pushClient is a connected WebSocket client.
+type pushClient struct {
+	conn     *websocket.Conn
+	send     chan []byte
+	identity *auth.Identity // API key the client connected with, nil when anonymous
+}
+
+func newPushHub(
+	logger logrus.FieldLogger,
+	cfg config.PushConfig,
+	configHandler *api.ConfigHandler,
+	boundsProvider bounds.Provider,
+	cartographoorProvider cartographoor.Provider,
+) *pushHub {
+	return &pushHub{
+		cfg:                   cfg,
+		logger:                logger.WithField("component", "push"),
+		configHandler:         configHandler,
+		boundsProvider:        boundsProvider,
+		cartographoorProvider: cartographoorProvider,
+		done:                  make(chan struct{}),
+		clients:               make(map[*pushClient]struct{}),
+	}
+}
+
+// Start subscribes to provider notifications and starts broadcasting.
+// Broadcasts read provider data under ctx.
+func (h *pushHub) Start(ctx context.Context) {
+	h.task = tasks.Default().Register("push.broadcast", 0)
+	h.wg.Add(1)
+
+	go h.broadcastLoop(ctx)
+}
+
+// Stop stops broadcasting and disconnects all clients.
+func (h *pushHub) Stop() {
+	close(h.done)
+	h.wg.Wait()
+}
+
+// broadcastLoop runs the broadcast loop under the task supervisor, which
+// restarts it with backoff if it panics.
+func (h *pushHub) broadcastLoop(ctx context.Context) {
+	defer h.wg.Done()
+
+	h.task.Supervise(h.logger, h.done, func() { h.runBroadcastLoop(ctx) })
+}
+
+func (h *pushHub) runBroadcastLoop(ctx context.Context) {
+	var boundsNotifyChan <-chan struct{}
+	if h.boundsProvider != nil {
+		boundsNotifyChan = h.boundsProvider.NotifyChannel()
+	}
+
+	var cartographoorNotifyChan <-chan struct{}
+	if h.cartographoorProvider != nil {
+		cartographoorNotifyChan = h.cartographoorProvider.NotifyChannel()
+	}
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-boundsNotifyChan:
+			_ = h.task.Run(func() error { return h.publish(ctx, pushEventBounds) })
+		case <-cartographoorNotifyChan:
+			_ = h.task.Run(func() error { return h.publish(ctx, pushEventNetworks) })
+		}
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket and streams events until the
+// client disconnects or the hub stops.
+func (h *pushHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	full := len(h.clients) >= h.cfg.MaxClients
+	h.mu.Unlock()
+
+	if full {
+		h.logger.Warn("Push client limit reached")
+		httperr.Write(w, r, http.StatusServiceUnavailable, httperr.CodeTooManyConnections, "too many websocket connections")
+
+		return
+	}
+
+	var identity *auth.Identity
+	if id, ok := auth.IdentityFromContext(r.Context()); ok {
+		identity = &id
+	}
+
+	if identity != nil && !identity.AllowsScope(config.ScopeConfig) && !identity.AllowsScope(config.ScopeProxy) {
+		httperr.Write(w, r, http.StatusForbidden, httperr.CodeForbidden, "api key is not scoped to push events")
+
+		return
+	}
+
+	conn, err := acceptWebSocket(w, r)
+	if err != nil {
+		h.logger.WithError(err).Debug("WebSocket upgrade failed")
+
+		return
+	}
+
+	client := &pushClient{conn: conn, send: make(chan []byte, pushClientBuffer), identity: identity}
+
+	// Send the current state first so clients don't need a separate fetch
+	for _, eventType := range []string{pushEventNetworks, pushEventBounds} {
+		if msg, ok, err := h.buildEvent(r.Context(), eventType).encodeFor(identity); err == nil && ok {
+			client.send <- msg
+		}
+	}
+
+	h.register(client)
+	defer h.unregister(client)
+
+	h.serveClient(r.Context(), client)
+}
+
+// serveClient writes queued events and pings until the connection ends, the
+// hub stops or ctx is canceled on shutdown.
+func (h *pushHub) serveClient(ctx context.Context, client *pushClient) {
+	defer client.conn.CloseNow()
+
+	// Reader: answers pings, handles the close handshake and receives pongs
+	readerDone := make(chan struct{})
+
+	go func() {
+		defer close(readerDone)
+
+		h.readClient(ctx, client)
+	}()
+
+	ticker := time.NewTicker(h.cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-client.send:
+			if !ok {
+				// Dropped as a slow consumer
+				_ = client.conn.Close(websocket.StatusGoingAway, "")
+
+				return
+			}
+
+			if err := h.write(ctx, client, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			// Clients that miss a pong for a whole interval are gone
+			pingCtx, cancel := context.WithTimeout(ctx, h.cfg.PingInterval)
+			err := client.conn.Ping(pingCtx)
+
+			cancel()
+
+			if err != nil {
+				return
+			}
+		case <-readerDone:
+			return
+		case <-h.done:
+			_ = client.conn.Close(websocket.StatusGoingAway, "")
+
+			return
+		case <-ctx.Done():
+			_ = client.conn.Close(websocket.StatusGoingAway, "")
+
+			return
+		}
+	}
+}
+
+// write sends a text message, giving up after wsWriteTimeout.
+func (h *pushHub) write(ctx context.Context, client *pushClient, msg []byte) error {
+	writeCtx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+	defer cancel()
+
+	return client.conn.Write(writeCtx, websocket.MessageText, msg)
+}
+
+// readClient reads and discards client messages, which keeps control frames
+// flowing. Returns when the client closes or the connection fails, including
+// protocol errors and messages over wsMaxClientPayload.
+func (h *pushHub) readClient(ctx context.Context, client *pushClient) {
+	for {
+		_, _, err := client.conn.Read(ctx)
+		if err == nil {
+			continue
+		}
+
+		// Anything but a close from the client is a protocol violation or a
+		// dead connection. coder/websocket returns some violations, such as
+		// unmasked frames (RFC 6455 section 5.1), without closing, so close
+		// with 1002 here; this is a no-op once a close frame was sent.
+		if websocket.CloseStatus(err) == -1 {
+			_ = client.conn.Close(websocket.StatusProtocolError, "")
+		}
+
+		return
+	}
+}
+
+func (h *pushHub) register(client *pushClient) {
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+
+	pushClients.Inc()
+}
+
+func (h *pushHub) unregister(client *pushClient) {
+	h.mu.Lock()
+	_, exists := h.clients[client]
+	delete(h.clients, client)
+	h.mu.Unlock()
+
+	if exists {
+		pushClients.Dec()
+	}
+}
+
+// publish sends an event of the given type to every connected client,
+// encoded once per distinct API key. Clients whose queue is full are
+// disconnected.
+func (h *pushHub) publish(ctx context.Context, eventType string) error {
+	h.mu.Lock()
+	count := len(h.clients)
+	h.mu.Unlock()
+
+	if count == 0 {
+		return nil
+	}
+
+	event := h.buildEvent(ctx, eventType)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Encoded message per API key ID, "" for anonymous clients; nil when the
+	// key may not receive this event type
+	encoded := make(map[string][]byte)
+
+	for client := range h.clients {
+		var keyID string
+		if client.identity != nil {
+			keyID = client.identity.KeyID
+		}
+
+		msg, seen := encoded[keyID]
+		if !seen {
+			encodedMsg, ok, err := event.encodeFor(client.identity)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				msg = encodedMsg
+			}
+
+			encoded[keyID] = msg
+		}
+
+		if msg == nil {
+			continue
+		}
+
+		select {
+		case client.send <- msg:
+		default:
+			h.logger.Debug("Disconnecting slow push client")
+
+			delete(h.clients, client)
+			close(client.send)
+			pushClients.Dec()
+		}
+	}
+
+	pushEventsTotal.WithLabelValues(eventType).Inc()
+
+	return nil
+}
+
+// buildEvent returns the current state for an event type, with hidden
+// networks removed.
+func (h *pushHub) buildEvent(ctx context.Context, eventType string) pushEvent {
+	event := pushEvent{Type: eventType, Timestamp: time.Now().UTC()}
+
+	switch eventType {
+	case pushEventNetworks:
+		event.Data = h.configHandler.GetConfigData(ctx).Networks
+
+		if h.cartographoorProvider != nil {
+			if changes := h.cartographoorProvider.LastChanges(); changes != nil {
+				event.Changes = changes.Without(h.configHandler.HiddenNetworks(ctx))
+			}
+		}
+	case pushEventBounds:
+		boundsData := make(map[string]map[string]bounds.TableBounds)
+
+		if h.boundsProvider != nil {
+			hidden := h.configHandler.HiddenNetworks(ctx)
+
+			for network, data := range h.boundsProvider.GetAllBounds(ctx) {
+				if data != nil && !hidden[network] {
+					boundsData[network] = data.Tables
+				}
+			}
+		}
+
+		event.Data = boundsData
+	}
+
+	return event
+}
+
+// encodeFor encodes the event for a client's API key, keeping only the
+// networks it is scoped to. Reports false when the key may not receive the
+// event type at all: networks events need the config scope, bounds events
+// the proxy scope.
+func (e pushEvent) encodeFor(identity *auth.Identity) ([]byte, bool, error) {
+	if identity != nil {
+		switch e.Type {
+		case pushEventNetworks:
+			if !identity.AllowsScope(config.ScopeConfig) {
+				return nil, false, nil
+			}
+
+			if networks, ok := e.Data.([]api.NetworkInfo); ok {
+				e.Data = slices.DeleteFunc(slices.Clone(networks), func(n api.NetworkInfo) bool {
+					return !identity.AllowsNetwork(n.Name)
+				})
+			}
+
+			if e.Changes != nil {
+				e.Changes = e.Changes.Filter(identity.AllowsNetwork)
+			}
+		case pushEventBounds:
+			if !identity.AllowsScope(config.ScopeProxy) {
+				return nil, false, nil
+			}
+
+			if boundsData, ok := e.Data.(map[string]map[string]bounds.TableBounds); ok {
+				scoped := make(map[string]map[string]bounds.TableBounds, len(boundsData))
+
+				for network, tables := range boundsData {
+					if identity.AllowsNetwork(network) {
+						scoped[network] = tables
+					}
+				}
+
+				e.Data = scoped
+			}
+		}
+	}
+
+	msg, err := json.Marshal(e)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return msg, true, nil
+}
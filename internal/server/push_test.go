@@ -0,0 +1,286 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/auth"
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/bounds/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// newTestPushServer starts a push hub whose bounds notifications are driven by the returned channel.
+func newTestPushServer(t *testing.T, maxClients int) (*httptest.Server, chan struct{}) {
+	t.Helper()
+
+	return newTestPushServerAs(t, maxClients, nil)
+}
+
+// newTestPushServerAs is newTestPushServer with every request authenticated as identity, if set.
+func newTestPushServerAs(t *testing.T, maxClients int, identity *auth.Identity) (*httptest.Server, chan struct{}) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	notify := make(chan struct{}, 1)
+
+	boundsProvider := mocks.NewMockProvider(gomock.NewController(t))
+	boundsProvider.EXPECT().NotifyChannel().Return(notify).AnyTimes()
+	boundsProvider.EXPECT().GetAllBounds(gomock.Any()).Return(map[string]*bounds.BoundsData{
+		"mainnet": {Tables: map[string]bounds.TableBounds{"fct_block": {Min: 1, Max: 100}}},
+		"hoodi":   {Tables: map[string]bounds.TableBounds{"fct_block": {Min: 1, Max: 50}}},
+	}).AnyTimes()
+
+	cfg := &config.Config{Networks: []config.NetworkConfig{
+		{Name: "mainnet", TargetURL: "http://localhost"},
+		{Name: "hoodi", TargetURL: "http://localhost"},
+	}}
+	configHandler := api.NewConfigHandler(logger, cfg, nil, nil, nil, nil)
+
+	hub := newPushHub(logger, config.PushConfig{
+		Enabled:      true,
+		MaxClients:   maxClients,
+		PingInterval: time.Minute,
+	}, configHandler, boundsProvider, nil)
+	hub.Start(context.Background())
+	t.Cleanup(hub.Stop)
+
+	var handler http.Handler = hub
+	if identity != nil {
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hub.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), *identity)))
+		})
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server, notify
+}
+
+// dialPush performs the WebSocket handshake and returns the connection and response status.
+func dialPush(t *testing.T, server *httptest.Server) (*websocket.Conn, int) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	conn, resp, err := websocket.Dial(ctx, server.URL+"/api/v1/ws", nil)
+	if err != nil {
+		require.NotNil(t, resp, err)
+
+		return nil, resp.StatusCode
+	}
+
+	t.Cleanup(func() { conn.CloseNow() })
+
+	return conn, resp.StatusCode
+}
+
+func readPushEvent(t *testing.T, conn *websocket.Conn) pushEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	msgType, payload, err := conn.Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, websocket.MessageText, msgType)
+
+	var event pushEvent
+	require.NoError(t, json.Unmarshal(payload, &event))
+
+	return event
+}
+
+func TestPushHub_StreamsEvents(t *testing.T) {
+	server, notify := newTestPushServer(t, 10)
+
+	conn, status := dialPush(t, server)
+	require.Equal(t, http.StatusSwitchingProtocols, status)
+
+	// Current state is sent on connect
+	assert.Equal(t, pushEventNetworks, readPushEvent(t, conn).Type)
+
+	event := readPushEvent(t, conn)
+	assert.Equal(t, pushEventBounds, event.Type)
+	assert.Contains(t, event.Data, "mainnet")
+
+	// Provider notifications are pushed
+	notify <- struct{}{}
+
+	assert.Equal(t, pushEventBounds, readPushEvent(t, conn).Type)
+
+	// The close handshake completes
+	require.NoError(t, conn.Close(websocket.StatusNormalClosure, ""))
+}
+
+func TestPushHub_ScopedKey(t *testing.T) {
+	t.Run("only allowed event types and networks", func(t *testing.T) {
+		server, notify := newTestPushServerAs(t, 10, &auth.Identity{
+			KeyID:    "k1",
+			Networks: []string{"hoodi"},
+			Scopes:   []string{config.ScopeProxy},
+		})
+
+		conn, status := dialPush(t, server)
+		require.Equal(t, http.StatusSwitchingProtocols, status)
+
+		// No networks event without the config scope
+		event := readPushEvent(t, conn)
+		assert.Equal(t, pushEventBounds, event.Type)
+		assert.Contains(t, event.Data, "hoodi")
+		assert.NotContains(t, event.Data, "mainnet")
+
+		notify <- struct{}{}
+
+		event = readPushEvent(t, conn)
+		assert.Equal(t, pushEventBounds, event.Type)
+		assert.Contains(t, event.Data, "hoodi")
+		assert.NotContains(t, event.Data, "mainnet")
+	})
+
+	t.Run("networks filtered", func(t *testing.T) {
+		server, _ := newTestPushServerAs(t, 10, &auth.Identity{
+			KeyID:    "k2",
+			Networks: []string{"hoodi"},
+			Scopes:   []string{config.ScopeConfig},
+		})
+
+		conn, status := dialPush(t, server)
+		require.Equal(t, http.StatusSwitchingProtocols, status)
+
+		event := readPushEvent(t, conn)
+		require.Equal(t, pushEventNetworks, event.Type)
+
+		networks, ok := event.Data.([]any)
+		require.True(t, ok)
+		require.Len(t, networks, 1)
+		assert.Equal(t, "hoodi", networks[0].(map[string]any)["name"])
+	})
+
+	t.Run("no push scope", func(t *testing.T) {
+		server, _ := newTestPushServerAs(t, 10, &auth.Identity{
+			KeyID:  "k3",
+			Scopes: []string{config.ScopeGasProfiler},
+		})
+
+		_, status := dialPush(t, server)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+}
+
+func TestPushHub_ClosesOversizedMessages(t *testing.T) {
+	server, _ := newTestPushServer(t, 10)
+
+	conn, status := dialPush(t, server)
+	require.Equal(t, http.StatusSwitchingProtocols, status)
+
+	readPushEvent(t, conn)
+	readPushEvent(t, conn)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, conn.Write(ctx, websocket.MessageText, make([]byte, wsMaxClientPayload+1)))
+
+	_, _, err := conn.Read(ctx)
+	assert.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+}
+
+func TestPushHub_RejectsUnmaskedFrames(t *testing.T) {
+	server, _ := newTestPushServer(t, 10)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+	_, err = conn.Write([]byte("GET /api/v1/ws HTTP/1.1\r\nHost: lab\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	// Client frames must be masked (RFC 6455 section 5.1)
+	_, err = conn.Write([]byte{0x81, 2, 'h', 'i'})
+	require.NoError(t, err)
+
+	// Skip pushed events until the close frame
+	for {
+		header := make([]byte, 2)
+		_, err := io.ReadFull(reader, header)
+		require.NoError(t, err)
+
+		length := int(header[1] & 0x7F)
+
+		switch length {
+		case 126:
+			var ext uint16
+			require.NoError(t, binary.Read(reader, binary.BigEndian, &ext))
+			length = int(ext)
+		case 127:
+			var ext uint64
+			require.NoError(t, binary.Read(reader, binary.BigEndian, &ext))
+			length = int(ext)
+		}
+
+		payload := make([]byte, length)
+		_, err = io.ReadFull(reader, payload)
+		require.NoError(t, err)
+
+		if header[0]&0x0F == 0x8 {
+			require.GreaterOrEqual(t, len(payload), 2)
+			assert.Equal(t, uint16(websocket.StatusProtocolError), binary.BigEndian.Uint16(payload))
+
+			return
+		}
+	}
+}
+
+func TestPushHub_ClientLimit(t *testing.T) {
+	server, _ := newTestPushServer(t, 1)
+
+	conn, status := dialPush(t, server)
+	require.Equal(t, http.StatusSwitchingProtocols, status)
+
+	// Wait until the first client is registered
+	readPushEvent(t, conn)
+
+	_, status = dialPush(t, server)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestPushHub_RejectsPlainRequests(t *testing.T) {
+	server, _ := newTestPushServer(t, 10)
+
+	resp, err := http.Get(server.URL + "/api/v1/ws")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -4,111 +4,542 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/lab-backend/internal/alerting"
 	"github.com/ethpandaops/lab-backend/internal/api"
+	"github.com/ethpandaops/lab-backend/internal/auth"
 	"github.com/ethpandaops/lab-backend/internal/bounds"
 	"github.com/ethpandaops/lab-backend/internal/cartographoor"
 	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/denylist"
 	"github.com/ethpandaops/lab-backend/internal/frontend"
-	"github.com/ethpandaops/lab-backend/internal/handlers"
+	"github.com/ethpandaops/lab-backend/internal/grpcapi"
 	"github.com/ethpandaops/lab-backend/internal/headers"
+	"github.com/ethpandaops/lab-backend/internal/health"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/maintenance"
 	"github.com/ethpandaops/lab-backend/internal/middleware"
+	"github.com/ethpandaops/lab-backend/internal/negcache"
+	"github.com/ethpandaops/lab-backend/internal/profiling"
 	"github.com/ethpandaops/lab-backend/internal/proxy"
 	"github.com/ethpandaops/lab-backend/internal/ratelimit"
+	"github.com/ethpandaops/lab-backend/internal/readonly"
 	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/scheduler"
+	"github.com/ethpandaops/lab-backend/internal/schema"
+	"github.com/ethpandaops/lab-backend/internal/slo"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+	"github.com/ethpandaops/lab-backend/internal/startup"
+	"github.com/ethpandaops/lab-backend/internal/summary"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/tenancy"
+	"github.com/ethpandaops/lab-backend/internal/terms"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+	"github.com/ethpandaops/lab-backend/internal/version"
 	"github.com/ethpandaops/lab-backend/internal/wallclock"
 )
 
+// readOnlyExempt lists the only mutating route served in read-only mode, so
+// operators can turn it off through the API.
+var readOnlyExempt = []string{"DELETE /admin/v1/read-only"}
+
 // Server represents the HTTP server.
 type Server struct {
 	httpServer            *http.Server
-	proxy                 *proxy.Proxy
-	frontend              *frontend.Frontend
+	inFlight              *middleware.InFlight
+	drainTimeout          time.Duration
+	proxy                 proxy.Handler
+	frontend              frontend.Handler
 	rateLimiter           ratelimit.Service
-	gasProfilerHandler    *api.GasProfilerHandler
+	offenders             *ratelimit.Offenders
+	gasProfilerHandler    api.GasProfiler
+	sloService            *slo.Service
+	scheduler             *scheduler.Scheduler
+	profiler              *profiling.Profiler
+	pushHub               *pushHub
+	readOnly              *readonly.Mode
+	maintenance           *maintenance.Mode
+	denyList              *denylist.List
+	slotTransform         *slottransform.Service
+	grpcServer            *grpcapi.Server
 	logger                logrus.FieldLogger
 	cartographoorProvider cartographoor.Provider
 	boundsProvider        bounds.Provider
 	wallclockSvc          *wallclock.Service
 }
 
-// New creates a new HTTP server with all routes and middleware.
+// New creates a new HTTP server with all routes and middleware. Startup work
+// such as the initial proxy sync and frontend bundle fetch runs under ctx, and
+// so do the proxy's periodic syncs.
 func New(
+	ctx context.Context,
 	logger logrus.FieldLogger,
 	cfg *config.Config,
 	redisClient redis.Client,
+	elector leader.Elector,
 	cartographoorProvider cartographoor.Provider,
 	boundsProvider bounds.Provider,
 	wallclockSvc *wallclock.Service,
 ) (*Server, error) {
 	mux := http.NewServeMux()
 
-	// Health endpoint (no middleware needed for simple health check)
-	mux.HandleFunc("GET /health", handlers.Health())
+	// Maintenance windows, scheduled through the admin API and shared through Redis
+	maintenanceMode, err := maintenance.New(logger, cfg.Maintenance, redisClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create maintenance mode: %w", err)
+	}
+
+	// Read-only mode, from config or toggled at runtime through Redis
+	readOnly := readonly.New(logger, cfg.ReadOnly, redisClient)
+
+	// Liveness and readiness probes (no middleware needed). /health is kept
+	// as an alias of /healthz for existing probes.
+	checker := health.NewChecker(logger,
+		health.RedisCheck(redisClient),
+		health.CartographoorCheck(cartographoorProvider),
+		health.BoundsCheck(boundsProvider, 3*cfg.Bounds.RefreshInterval),
+		health.LeaderCheck(elector),
+		health.MaintenanceCheck(maintenanceMode),
+	)
+	mux.HandleFunc("GET /healthz", checker.Liveness)
+	mux.HandleFunc("GET /health", checker.Liveness)
+	mux.HandleFunc("GET /readyz", checker.Readiness)
+	logger.WithField("route", "GET /healthz").Info("Registered route")
 	logger.WithField("route", "GET /health").Info("Registered route")
+	logger.WithField("route", "GET /readyz").Info("Registered route")
+
+	// Data endpoints answer 503 until networks and bounds have been loaded once
+	gate := startup.New(logger, cfg.StartupGate,
+		startup.CartographoorDependency(cartographoorProvider),
+		startup.BoundsDependency(boundsProvider),
+	)
+	gateLog := logger.WithField("component", "startup_gate")
+	gated := func(next http.Handler, dependencies ...string) http.Handler {
+		return middleware.StartupGate(gate, gateLog, dependencies...)(next)
+	}
 
-	// Metrics endpoint (Prometheus format)
-	mux.Handle("GET /metrics", promhttp.Handler())
+	// Metrics endpoint (Prometheus format, OpenMetrics when negotiated for exemplars)
+	mux.Handle("GET /metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
 	logger.WithField("route", "GET /metrics").Info("Registered route")
 
+	// pprof endpoints for scraping profilers (e.g. Parca)
+	if cfg.Profiling.ExposePprof {
+		mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+		mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+		logger.WithField("route", "GET /debug/pprof/").Info("Registered route")
+	}
+
+	// API keys may be scoped to endpoint classes and networks
+	scopeLog := logger.WithField("component", "auth")
+	scoped := func(scope string, next http.Handler) http.Handler {
+		if !cfg.Auth.Enabled {
+			return next
+		}
+
+		return middleware.RequireScope(scope, scopeLog)(next)
+	}
+
+	var authStore *auth.Store
+	if cfg.Auth.Enabled {
+		authStore = auth.NewStore(logger, cfg.Auth, redisClient)
+	}
+
+	// Self-service API key issuance (must come before wildcard proxy)
+	if cfg.TokenIssuance.Enabled {
+		issuer := auth.NewIssuer(
+			logger,
+			cfg.TokenIssuance,
+			authStore,
+			redisClient,
+			auth.NewSMTPMailer(cfg.TokenIssuance.SMTP),
+		)
+		tokensHandler := api.NewTokensHandler(issuer, logger)
+
+		mux.HandleFunc("POST /api/v1/tokens/request", tokensHandler.Request)
+		logger.WithField("route", "POST /api/v1/tokens/request").Info("Registered route")
+
+		mux.HandleFunc("POST /api/v1/tokens/verify", tokensHandler.Verify)
+		logger.WithField("route", "POST /api/v1/tokens/verify").Info("Registered route")
+	}
+
+	// Slot filter transformation policy, from config or overridden at runtime through Redis
+	slotTransform := slottransform.New(logger, cfg.Proxy.SlotTransform, redisClient)
+
+	// Slot transform override, internal API keys only
+	if cfg.Auth.Enabled {
+		slotTransformHandler := api.NewSlotTransformHandler(slotTransform, logger)
+		requireInternal := middleware.RequireTier(config.TierInternal, logger.WithField("component", "auth"))
+		mux.Handle("GET /admin/v1/slot-transform", requireInternal(http.HandlerFunc(slotTransformHandler.Get)))
+		mux.Handle("PUT /admin/v1/slot-transform", requireInternal(http.HandlerFunc(slotTransformHandler.Put)))
+		mux.Handle("DELETE /admin/v1/slot-transform", requireInternal(http.HandlerFunc(slotTransformHandler.Delete)))
+		logger.WithField("route", "/admin/v1/slot-transform").Info("Registered slot transform routes")
+	} else {
+		logger.Info("Slot transform admin endpoints disabled, they require auth to be enabled")
+	}
+
 	// Config API (must come before wildcard proxy route)
-	configHandler := api.NewConfigHandler(logger, cfg, cartographoorProvider)
-	mux.Handle("GET /api/v1/config", configHandler)
+	configHandler := api.NewConfigHandler(logger, cfg, cartographoorProvider, boundsProvider, slotTransform, wallclockSvc)
+	mux.Handle("GET /api/v1/config", scoped(config.ScopeConfig, gated(configHandler, startup.Cartographoor)))
 	logger.WithField("route", "GET /api/v1/config").Info("Registered route")
 
+	// WebSocket push of bounds and network updates (must come before wildcard proxy)
+	var hub *pushHub
+
+	if cfg.Push.Enabled {
+		hub = newPushHub(logger, cfg.Push, configHandler, boundsProvider, cartographoorProvider)
+		mux.Handle("GET /api/v1/ws", hub)
+		logger.WithField("route", "GET /api/v1/ws").Info("Registered route")
+	}
+
+	// gRPC mirror of the config and bounds endpoints, on its own port
+	var grpcServer *grpcapi.Server
+
+	if cfg.GRPC.Enabled {
+		grpcServer = grpcapi.New(logger, cfg.GRPC, configHandler, boundsProvider, cartographoorProvider, authStore, cfg.Auth.Required)
+	}
+
 	// Network-scoped bounds endpoint (must come before wildcard proxy)
-	boundsHandler := api.NewBoundsHandler(boundsProvider, logger)
-	mux.Handle("GET /api/v1/{network}/bounds", boundsHandler)
+	boundsHandler := api.NewBoundsHandler(boundsProvider, wallclockSvc, negcache.New("bounds_networks", cfg.NegativeCache), logger)
+	mux.Handle("GET /api/v1/{network}/bounds", scoped(config.ScopeProxy, gated(boundsHandler, startup.Bounds)))
 	logger.WithField("route", "GET /api/v1/{network}/bounds").Info("Registered route")
 
+	// Slot range pagination of a table from its bounds (must come before wildcard proxy)
+	if cfg.TablePages.IsEnabled() {
+		pagesHandler := api.NewTablePagesHandler(cfg.TablePages, boundsProvider, wallclockSvc, logger)
+		mux.Handle("GET /api/v1/{network}/tables/{table}/pages", scoped(config.ScopeProxy, gated(pagesHandler, startup.Bounds)))
+		logger.WithField("route", "GET /api/v1/{network}/tables/{table}/pages").Info("Registered route")
+	}
+
+	// Which feature is usable on which network, from feature tables and bounds
+	availabilityHandler := api.NewAvailabilityHandler(configHandler, boundsProvider, wallclockSvc, logger)
+	mux.Handle("GET /api/v1/availability", scoped(config.ScopeProxy, gated(availabilityHandler, startup.Bounds)))
+	logger.WithField("route", "GET /api/v1/availability").Info("Registered route")
+
+	// Stream of bounds changes between refreshes, as server-sent events
+	mux.Handle("GET /api/v1/bounds/changes", scoped(config.ScopeProxy, api.NewBoundsChangesHandler(boundsProvider, logger)))
+	logger.WithField("route", "GET /api/v1/bounds/changes").Info("Registered route")
+
+	// Slot and epoch conversions from the wallclock service (must come before wildcard proxy)
+	wallclockHandler := api.NewWallclockHandler(wallclockSvc, logger)
+	mux.Handle("GET /api/v1/{network}/wallclock", scoped(config.ScopeProxy, gated(http.HandlerFunc(wallclockHandler.Current), startup.Cartographoor)))
+	mux.Handle("GET /api/v1/{network}/wallclock/slots/{slot}", scoped(config.ScopeProxy, gated(http.HandlerFunc(wallclockHandler.Slot), startup.Cartographoor)))
+	mux.Handle("GET /api/v1/{network}/wallclock/epochs/{epoch}", scoped(config.ScopeProxy, gated(http.HandlerFunc(wallclockHandler.Epoch), startup.Cartographoor)))
+	mux.Handle("GET /api/v1/{network}/wallclock/timestamps/{timestamp}", scoped(config.ScopeProxy, gated(http.HandlerFunc(wallclockHandler.Timestamp), startup.Cartographoor)))
+	logger.WithField("route", "GET /api/v1/{network}/wallclock").Info("Registered route")
+
+	// Periodic jobs run by the leader
+	sched := scheduler.New(logger, redisClient, elector)
+
+	// Alerts for tables whose max bound stops advancing
+	if cfg.FreshnessAlerts.Enabled {
+		sched.Register(alerting.NewFreshness(logger, cfg.FreshnessAlerts, redisClient, boundsProvider).Job())
+	}
+
+	// Network liveness summary, counted by the leader (must come before wildcard proxy)
+	if cfg.Summary.Enabled {
+		summaryService := summary.New(logger, cfg, redisClient, elector, cartographoorProvider)
+		sched.Register(summaryService.Job())
+		mux.Handle("GET /api/v1/{network}/summary", scoped(config.ScopeProxy, gated(api.NewSummaryHandler(summaryService, logger), startup.Cartographoor)))
+		logger.WithField("route", "GET /api/v1/{network}/summary").Info("Registered route")
+	}
+
+	// Bounds fetcher circuit breaker status (must come before wildcard proxy)
+	mux.Handle("GET /api/v1/bounds/status", scoped(config.ScopeProxy, api.NewBoundsStatusHandler(boundsProvider, configHandler, logger)))
+	logger.WithField("route", "GET /api/v1/bounds/status").Info("Registered route")
+
+	// Outbound request stats per upstream host, internal API keys only. The old
+	// /api/v1/admin path is kept as an alias (must come before wildcard proxy)
+	if cfg.Auth.Enabled {
+		upstreamsHandler := middleware.RequireTier(
+			config.TierInternal, logger.WithField("component", "auth"),
+		)(api.NewUpstreamsHandler(upstream.Default(), logger))
+
+		for _, route := range []string{"GET /admin/v1/upstreams", "GET /api/v1/admin/upstreams"} {
+			mux.Handle(route, upstreamsHandler)
+			logger.WithField("route", route).Info("Registered route")
+		}
+	} else {
+		logger.Info("Upstreams endpoint disabled, it requires auth to be enabled")
+	}
+
+	// Background loops of this instance, internal API keys only. The old
+	// /api/v1/admin path is kept as an alias (must come before wildcard proxy)
+	if cfg.Auth.Enabled {
+		tasksHandler := middleware.RequireTier(
+			config.TierInternal, logger.WithField("component", "auth"),
+		)(api.NewTasksHandler(tasks.Default(), logger))
+
+		for _, route := range []string{"GET /admin/v1/runtime/tasks", "GET /api/v1/admin/runtime/tasks"} {
+			mux.Handle(route, tasksHandler)
+			logger.WithField("route", route).Info("Registered route")
+		}
+	} else {
+		logger.Info("Runtime tasks endpoint disabled, it requires auth to be enabled")
+	}
+
+	// Forwarding headers are only honored from trusted proxies
+	ipResolver := ratelimit.NewIPResolver(cfg.Server.TrustedProxies)
+
+	if len(cfg.Server.TrustedProxies) == 0 && (cfg.RateLimiting.Enabled || cfg.DenyList.Enabled) {
+		logger.Warn("No trusted proxies configured, clients are identified by their direct peer address")
+	}
+
+	// Create rate limiter service if enabled
+	var rateLimiter ratelimit.Service
+	if cfg.RateLimiting.Enabled {
+		rateLimiter = ratelimit.NewService(
+			logger,
+			redisClient.GetClient(),
+			cfg.RateLimiting.FailureMode,
+		)
+
+		logger.Info("Rate limiting enabled")
+	}
+
+	// Deny list of abusive IPs and ranges, managed by internal API keys
+	var denyList *denylist.List
+
+	if cfg.DenyList.Enabled {
+		denyList = denylist.New(logger, cfg.DenyList, redisClient)
+
+		if cfg.Auth.Enabled {
+			denyListHandler := api.NewDenyListHandler(denyList, logger)
+			requireInternal := middleware.RequireTier(config.TierInternal, logger.WithField("component", "auth"))
+			mux.Handle("GET /admin/v1/denylist", requireInternal(http.HandlerFunc(denyListHandler.List)))
+			mux.Handle("POST /admin/v1/denylist", requireInternal(http.HandlerFunc(denyListHandler.Add)))
+			mux.Handle("DELETE /admin/v1/denylist", requireInternal(http.HandlerFunc(denyListHandler.Remove)))
+			logger.WithField("route", "/admin/v1/denylist").Info("Registered deny list routes")
+		} else {
+			logger.Info("Deny list admin endpoints disabled, they require auth to be enabled")
+		}
+	}
+
+	// Denied request analytics, internal API keys only
+	var offenders *ratelimit.Offenders
+
+	if cfg.RateLimiting.Enabled && cfg.RateLimiting.Offenders.Enabled {
+		offenders = ratelimit.NewOffenders(logger, cfg.RateLimiting.Offenders, redisClient.GetClient())
+
+		if cfg.Auth.Enabled {
+			mux.Handle("GET /admin/v1/ratelimit/offenders", middleware.RequireTier(
+				config.TierInternal, logger.WithField("component", "auth"),
+			)(api.NewOffendersHandler(offenders, cfg.RateLimiting.Offenders, logger)))
+			logger.WithField("route", "GET /admin/v1/ratelimit/offenders").Info("Registered route")
+		} else {
+			logger.Info("Rate limit offenders endpoint disabled, it requires auth to be enabled")
+		}
+	}
+
+	// Scheduled job runs, internal API keys only
+	if cfg.Auth.Enabled {
+		mux.Handle("GET /admin/v1/tasks", middleware.RequireTier(
+			config.TierInternal, logger.WithField("component", "auth"),
+		)(api.NewScheduledTasksHandler(sched, logger)))
+		logger.WithField("route", "GET /admin/v1/tasks").Info("Registered route")
+	} else {
+		logger.Info("Scheduled tasks endpoint disabled, it requires auth to be enabled")
+	}
+
+	// Effective rate limits of the caller (must come before wildcard proxy)
+	mux.Handle("GET "+ratelimit.LimitsPath, api.NewLimitsHandler(cfg.RateLimiting, rateLimiter, ipResolver, logger))
+	logger.WithField("route", "GET "+ratelimit.LimitsPath).Info("Registered route")
+
+	// Leader election status (must come before wildcard proxy)
+	mux.Handle("GET /api/v1/status/leader", api.NewLeaderStatusHandler(elector, logger))
+	logger.WithField("route", "GET /api/v1/status/leader").Info("Registered route")
+
+	// Backend version and enabled features, also sent on every response (must come before wildcard proxy)
+	versionSummary := version.NewSummary(cfg.FeatureFlags())
+	versionHandler := api.NewVersionHandler(versionSummary, logger)
+	mux.Handle("GET /api/v1/version", versionHandler)
+	mux.HandleFunc("GET /api/v1/version/epoch", versionHandler.Epoch)
+	logger.WithField("route", "GET /api/v1/version").Info("Registered route")
+	logger.WithField("route", "GET /api/v1/version/epoch").Info("Registered route")
+
+	// Build info and dependency versions, internal API keys only. The old
+	// /api/v1/admin path is kept as an alias (must come before wildcard proxy)
+	if cfg.Auth.Enabled {
+		buildInfoHandler := middleware.RequireTier(
+			config.TierInternal, logger.WithField("component", "auth"),
+		)(api.NewBuildInfoHandler(logger))
+
+		for _, route := range []string{"GET /admin/v1/buildinfo", "GET /api/v1/admin/buildinfo"} {
+			mux.Handle(route, buildInfoHandler)
+			logger.WithField("route", route).Info("Registered route")
+		}
+	} else {
+		logger.Info("Build info endpoint disabled, it requires auth to be enabled")
+	}
+
+	// Upstream SLO tracking (must come before wildcard proxy)
+	var sloService *slo.Service
+
+	if cfg.SLO.Enabled {
+		sloService = slo.New(logger, cfg.SLO, upstream.Default())
+
+		// Burn rates, internal API keys only. The old /api/v1/admin path is kept as an alias
+		if cfg.Auth.Enabled {
+			sloHandler := middleware.RequireTier(
+				config.TierInternal, logger.WithField("component", "auth"),
+			)(api.NewSLOHandler(sloService, cfg.SLO.Window.String(), logger))
+
+			for _, route := range []string{"GET /admin/v1/slo", "GET /api/v1/admin/slo"} {
+				mux.Handle(route, sloHandler)
+				logger.WithField("route", route).Info("Registered route")
+			}
+		} else {
+			logger.Info("SLO endpoint disabled, it requires auth to be enabled")
+		}
+	}
+
+	// Terms of use acknowledgment (must come before wildcard proxy)
+	var termsManager *terms.Manager
+
+	if cfg.Terms.Enabled {
+		var err error
+
+		termsManager, err = terms.NewManager(cfg.Terms)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize terms manager: %w", err)
+		}
+
+		termsHandler := api.NewTermsHandler(termsManager, logger)
+		mux.HandleFunc("GET /api/v1/terms", termsHandler.Info)
+		mux.HandleFunc("POST /api/v1/terms/accept", termsHandler.Accept)
+		logger.WithField("classes", termsManager.Classes()).Info("Registered terms of use routes")
+	}
+
 	// Gas profiler endpoints (must come before wildcard proxy)
-	var gasProfilerHandler *api.GasProfilerHandler
+	var gasProfilerHandler api.GasProfiler
 
 	if cfg.GasProfiler.Enabled {
-		gasProfilerHandler = api.NewGasProfilerHandler(&cfg.GasProfiler, logger)
-		mux.Handle("/api/v1/gas-profiler/{network}/{action}", gasProfilerHandler)
+		gasProfilerHandler = api.NewGasProfilerHandler(&cfg.GasProfiler, redisClient, logger)
+		mux.Handle("/api/v1/gas-profiler/{network}/{action}", scoped(config.ScopeGasProfiler, gasProfilerHandler))
 		logger.WithFields(logrus.Fields{
 			"route":     "/api/v1/gas-profiler/{network}/{action}",
 			"endpoints": len(cfg.GasProfiler.Endpoints),
 		}).Info("Registered gas profiler routes")
+
+		// Per-endpoint balancer state, internal API keys only
+		if cfg.Auth.Enabled {
+			mux.Handle("GET /admin/v1/gas-profiler/endpoints", middleware.RequireTier(
+				config.TierInternal, logger.WithField("component", "auth"),
+			)(api.NewGasProfilerEndpointsHandler(gasProfilerHandler, cfg.GasProfiler.Strategy, logger)))
+			logger.WithField("route", "GET /admin/v1/gas-profiler/endpoints").Info("Registered route")
+		} else {
+			logger.Info("Gas profiler endpoints view disabled, it requires auth to be enabled")
+		}
 	}
 
 	// Network-based proxy for all other API routes
-	proxyHandler, err := proxy.New(logger.WithField("component", "proxy"), cfg, cartographoorProvider, wallclockSvc)
+	proxyHandler, err := proxy.New(
+		ctx, logger.WithField("component", "proxy"), cfg, cartographoorProvider, wallclockSvc, slotTransform, redisClient, boundsProvider,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create proxy: %w", err)
 	}
 
-	mux.Handle("/api/v1/", proxyHandler)
+	mux.Handle("/api/v1/", scoped(config.ScopeProxy, gated(withProfilingLabel(cfg, "proxy", proxyHandler), startup.Cartographoor)))
 	logger.WithField("networks", proxyHandler.NetworkCount()).Info("Registered proxy routes")
 
+	// Probed backend health per network, internal API keys only
+	if cfg.Proxy.HealthProbe.Enabled {
+		if cfg.Auth.Enabled {
+			mux.Handle("GET /admin/v1/proxy/health", middleware.RequireTier(
+				config.TierInternal, logger.WithField("component", "auth"),
+			)(api.NewProxyHealthHandler(proxyHandler, logger)))
+			logger.WithField("route", "GET /admin/v1/proxy/health").Info("Registered route")
+		} else {
+			logger.Info("Proxy health endpoint disabled, it requires auth to be enabled")
+		}
+	}
+
+	// Canaries of changed target URLs, promoted and rolled back by internal API keys
+	if cfg.Proxy.Canary.Enabled {
+		if cfg.Auth.Enabled {
+			canariesHandler := api.NewProxyCanariesHandler(proxyHandler, logger)
+			requireInternal := middleware.RequireTier(config.TierInternal, logger.WithField("component", "auth"))
+			mux.Handle("GET /admin/v1/proxy/canaries", requireInternal(http.HandlerFunc(canariesHandler.List)))
+			mux.Handle("POST /admin/v1/proxy/canaries/{network}/promote", requireInternal(http.HandlerFunc(canariesHandler.Promote)))
+			mux.Handle("POST /admin/v1/proxy/canaries/{network}/rollback", requireInternal(http.HandlerFunc(canariesHandler.Rollback)))
+			logger.WithField("route", "/admin/v1/proxy/canaries").Info("Registered proxy canary routes")
+		} else {
+			logger.Info("Proxy canary admin endpoints disabled, they require auth to be enabled")
+		}
+	}
+
+	// Slot range fan-out over several tables, sent through the proxy
+	if cfg.Aggregate.Enabled {
+		mux.Handle("GET /api/v1/{network}/aggregate",
+			scoped(config.ScopeProxy, gated(api.NewAggregateHandler(cfg.Aggregate, proxyHandler, logger), startup.Cartographoor)))
+		logger.WithField("route", "GET /api/v1/{network}/aggregate").Info("Registered route")
+	}
+
+	// Tenants selected by Host header or path prefix, each with its own overlay and index cache
+	tenants, err := tenancy.NewResolver(cfg.Tenants, cfg.Headers.Policies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tenants: %w", err)
+	}
+
+	// Maintenance windows, internal API keys only
+	if cfg.Auth.Enabled {
+		maintenanceHandler := api.NewMaintenanceHandler(maintenanceMode, logger)
+		requireInternal := middleware.RequireTier(config.TierInternal, logger.WithField("component", "auth"))
+		mux.Handle("GET /admin/v1/maintenance", requireInternal(http.HandlerFunc(maintenanceHandler.Get)))
+		mux.Handle("PUT /admin/v1/maintenance", requireInternal(http.HandlerFunc(maintenanceHandler.Put)))
+		mux.Handle("DELETE /admin/v1/maintenance", requireInternal(http.HandlerFunc(maintenanceHandler.Delete)))
+		logger.WithField("route", "/admin/v1/maintenance").Info("Registered maintenance routes")
+	} else {
+		logger.Info("Maintenance endpoints disabled, they require auth to be enabled")
+	}
+
+	// Read-only mode switch, internal API keys only
+	if cfg.Auth.Enabled {
+		readOnlyHandler := api.NewReadOnlyHandler(readOnly, logger)
+		requireInternal := middleware.RequireTier(config.TierInternal, logger.WithField("component", "auth"))
+		mux.Handle("GET /admin/v1/read-only", requireInternal(http.HandlerFunc(readOnlyHandler.Get)))
+		mux.Handle("PUT /admin/v1/read-only", requireInternal(http.HandlerFunc(readOnlyHandler.Put)))
+		mux.Handle("DELETE /admin/v1/read-only", requireInternal(http.HandlerFunc(readOnlyHandler.Delete)))
+		logger.WithField("route", "/admin/v1/read-only").Info("Registered read-only mode routes")
+	} else {
+		logger.Info("Read-only mode endpoints disabled, they require auth to be enabled")
+	}
+
 	// Frontend handler (catch-all for non-API routes)
 	// Pass providers so frontend can refresh its cache when data updates
-	frontendHandler, err := frontend.New(logger, configHandler, boundsProvider, cartographoorProvider)
+	missingAssets := negcache.New("frontend_assets", cfg.NegativeCache)
+
+	frontendHandler, err := frontend.New(ctx, logger, cfg.Frontend, missingAssets, configHandler, boundsProvider, cartographoorProvider, tenants)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create frontend handler: %w", err)
 	}
 
+	// Frontend reload hook for deploys that change head.json or index.html, internal API keys only
+	if cfg.Auth.Enabled {
+		mux.Handle("POST /admin/v1/frontend/reload", middleware.RequireTier(
+			config.TierInternal, logger.WithField("component", "auth"),
+		)(api.NewFrontendReloadHandler(frontendHandler, logger)))
+		logger.WithField("route", "POST /admin/v1/frontend/reload").Info("Registered route")
+	} else {
+		logger.Info("Frontend reload endpoint disabled, it requires auth to be enabled")
+	}
+
 	// Mount frontend as catch-all (must be last)
-	mux.Handle("/", frontendHandler)
+	mux.Handle("/", withProfilingLabel(cfg, "frontend", frontendHandler))
 	logger.WithField("route", "GET /").Info("Registered route")
 
-	// Create rate limiter service if enabled
-	var rateLimiter ratelimit.Service
-	if cfg.RateLimiting.Enabled {
-		rateLimiter = ratelimit.NewService(
-			logger,
-			redisClient.GetClient(),
-			cfg.RateLimiting.FailureMode,
-		)
-
-		logger.Info("Rate limiting enabled")
-	}
-
 	// Initialize headers manager from config
 	headersManager, err := headers.NewManager(cfg.Headers.Policies)
 	if err != nil {
@@ -117,19 +548,115 @@ func New(
 
 	logger.WithField("policies", len(cfg.Headers.Policies)).Info("Headers middleware initialized")
 
-	// Apply middleware chain: Logging → Headers → Metrics → CORS → RateLimit → Recovery
-	handler := middleware.Logging(logger)(mux)
+	// Apply middleware chain, innermost first: SchemaValidation → Terms → ReadOnly → Maintenance → RateLimit → Auth → DenyList → RequestTimeout → Headers → Version → VersionSkew → Compress → Metrics → CORS → NetworkAliases → Tenancy → Recovery → TraceContext → Logging → InFlight
+	var handler http.Handler = mux
+
+	// Dev-mode response validation sits innermost so it sees canonical paths and raw handler output
+	if cfg.SchemaValidation.Enabled {
+		validator, err := schema.NewValidator(cfg.SchemaValidation.Specs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema validation specs: %w", err)
+		}
+
+		handler = middleware.SchemaValidation(
+			validator,
+			cfg.SchemaValidation.MaxBodyBytes,
+			logger.WithField("component", "schema_validation"),
+		)(handler)
+
+		logger.WithField("specs", len(cfg.SchemaValidation.Specs)).Warn("Response schema validation enabled (dev mode)")
+	}
+
+	if termsManager != nil {
+		handler = middleware.Terms(termsManager, logger.WithField("component", "terms"))(handler)
+	}
+
+	// Reject every mutating request while read-only, except the one that turns it off
+	handler = middleware.ReadOnly(readOnly, readOnlyExempt, logger.WithField("component", "read_only"))(handler)
+
+	// Replace the API and frontend with 503s and the maintenance page during maintenance windows
+	handler = middleware.Maintenance(maintenanceMode, logger.WithField("component", "maintenance"))(handler)
+
+	// Inside CORS, so 401, 403 and 429 responses carry CORS headers
+	if cfg.RateLimiting.Enabled {
+		handler = middleware.RateLimit(logger, cfg.RateLimiting, ipResolver, rateLimiter, offenders)(handler)
+	}
+
+	// Authenticate API keys before rate limiting so limits apply per key and tier,
+	// scopes are checked per route
+	if cfg.Auth.Enabled {
+		handler = middleware.Auth(authStore, cfg.Auth.Required, logger.WithField("component", "auth"))(handler)
+
+		logger.WithField("required", cfg.Auth.Required).Info("API key authentication enabled")
+	}
+
+	// Reject denied IPs before they cost an API key lookup or a rate limit check
+	if denyList != nil {
+		handler = middleware.DenyList(denyList, ipResolver, logger.WithField("component", "deny_list"))(handler)
+	}
+
+	// Inside Headers, Version and CORS, so 504s are answered like any other response.
+	// Deadlines cover the Redis calls of auth and rate limiting
+	if len(cfg.RequestTimeouts.Policies) > 0 {
+		handler = middleware.RequestTimeout(cfg.RequestTimeouts, logger.WithField("component", "request_timeout"))(handler)
+
+		logger.WithField("policies", len(cfg.RequestTimeouts.Policies)).Info("Request timeouts enabled")
+	}
+
 	handler = middleware.Headers(headersManager, logger.WithField("component", "headers"))(handler)
+
+	handler = middleware.Version(versionSummary)(handler)
+
+	// Tell SPA sessions loaded from an older build to reload, unless the build time is unknown
+	if epoch := version.BuildEpoch(); epoch > 0 {
+		handler = middleware.VersionSkew(epoch)(handler)
+	}
+
+	if cfg.Compression.IsEnabled() {
+		handler = middleware.Compress(cfg.Compression)(handler)
+	}
+
 	handler = middleware.Metrics()(handler)
+
 	handler = middleware.CORS()(handler)
 
-	// Add rate limiting AFTER CORS but BEFORE recovery
-	if cfg.RateLimiting.Enabled {
-		handler = middleware.RateLimit(logger, cfg.RateLimiting, rateLimiter)(handler)
+	// Resolve renamed networks before anything else sees the path
+	if aliases := cfg.NetworkAliases(); len(aliases) > 0 {
+		rewrite := cfg.Proxy.AliasMode == config.AliasModeRewrite
+		handler = middleware.NetworkAliases(aliases, rewrite, logger.WithField("component", "aliases"))(handler)
+
+		logger.WithFields(logrus.Fields{
+			"aliases": len(aliases),
+			"mode":    cfg.Proxy.AliasMode,
+		}).Info("Network aliases enabled")
+	}
+
+	// Select the tenant and remove its path prefix before aliases are resolved
+	if len(cfg.Tenants) > 0 {
+		handler = middleware.Tenancy(tenants, logger.WithField("component", "tenancy"))(handler)
+
+		logger.WithField("tenants", len(cfg.Tenants)).Info("Tenancy enabled")
 	}
 
 	handler = middleware.Recovery(logger)(handler)
 
+	// Trace the whole chain, so auth and rate limit Redis calls land in the request span
+	if cfg.Tracing.Enabled {
+		handler = middleware.TraceContext()(handler)
+	}
+
+	// Outermost, so every request is logged and carries its request ID through the whole chain
+	handler = middleware.Logging(logger)(handler)
+
+	// Count requests being served, so shutdown can drain them
+	inFlight := middleware.NewInFlight()
+	handler = inFlight.Track()(handler)
+
+	tlsConfig, err := newTLSConfig(cfg.Server.TLS)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -138,14 +665,34 @@ func New(
 		ReadTimeout:       cfg.Server.ReadTimeout,
 		WriteTimeout:      cfg.Server.WriteTimeout,
 		IdleTimeout:       120 * time.Second,
+		TLSConfig:         tlsConfig,
+		Protocols:         protocols(cfg.Server),
+	}
+
+	// Continuous profiling push
+	var profiler *profiling.Profiler
+	if cfg.Profiling.Enabled {
+		profiler = profiling.New(logger, cfg.Profiling)
 	}
 
 	return &Server{
 		httpServer:            httpServer,
+		inFlight:              inFlight,
+		drainTimeout:          cfg.Server.DrainTimeout,
 		proxy:                 proxyHandler,
 		frontend:              frontendHandler,
 		rateLimiter:           rateLimiter,
+		offenders:             offenders,
 		gasProfilerHandler:    gasProfilerHandler,
+		sloService:            sloService,
+		scheduler:             sched,
+		profiler:              profiler,
+		pushHub:               hub,
+		readOnly:              readOnly,
+		maintenance:           maintenanceMode,
+		denyList:              denyList,
+		slotTransform:         slotTransform,
+		grpcServer:            grpcServer,
 		logger:                logger,
 		cartographoorProvider: cartographoorProvider,
 		boundsProvider:        boundsProvider,
@@ -153,39 +700,168 @@ func New(
 	}, nil
 }
 
-// Start starts the HTTP server (blocking call).
-func (s *Server) Start() error {
+// withProfilingLabel tags CPU samples taken while serving handler with a
+// component label when continuous profiling is enabled.
+func withProfilingLabel(cfg *config.Config, component string, handler http.Handler) http.Handler {
+	if !cfg.Profiling.Enabled {
+		return handler
+	}
+
+	return profiling.Label(component, handler)
+}
+
+// Start starts the HTTP server (blocking call). Background work started here
+// runs under ctx.
+func (s *Server) Start(ctx context.Context) error {
 	// Start rate limiter if enabled
 	if s.rateLimiter != nil {
-		if err := s.rateLimiter.Start(context.Background()); err != nil {
+		if err := s.rateLimiter.Start(ctx); err != nil {
 			return fmt.Errorf("failed to start rate limiter: %w", err)
 		}
 	}
 
+	// Start flushing denied request counts if enabled
+	if s.offenders != nil {
+		s.offenders.Start(ctx)
+	}
+
+	// Start deny list reloads if enabled
+	if s.denyList != nil {
+		s.denyList.Start()
+	}
+
+	// Start read-only mode polling
+	s.readOnly.Start()
+
+	// Start maintenance window polling
+	if s.maintenance != nil {
+		s.maintenance.Start()
+	}
+
+	// Start slot transform override polling
+	s.slotTransform.Start()
+
 	// Start frontend cache refresh loop
-	if err := s.frontend.Start(context.Background()); err != nil {
+	if err := s.frontend.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start frontend: %w", err)
 	}
 
 	// Start gas profiler health poller if enabled
 	if s.gasProfilerHandler != nil {
-		s.gasProfilerHandler.Start()
+		s.gasProfilerHandler.Start(ctx)
+	}
+
+	// Start continuous profiling if enabled
+	if s.profiler != nil {
+		s.profiler.Start()
 	}
 
-	s.logger.WithField("addr", s.httpServer.Addr).Info("Starting HTTP server")
+	// Start SLO evaluation if enabled
+	if s.sloService != nil {
+		s.sloService.Start()
+	}
+
+	// Start scheduled jobs
+	if s.scheduler != nil {
+		s.scheduler.Start(ctx)
+	}
+
+	// Start WebSocket push broadcasts if enabled
+	if s.pushHub != nil {
+		s.pushHub.Start(ctx)
+	}
+
+	// Start gRPC API if enabled
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Start(); err != nil {
+			return fmt.Errorf("failed to start gRPC server: %w", err)
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"addr":  s.httpServer.Addr,
+		"tls":   s.httpServer.TLSConfig != nil,
+		"http2": s.httpServer.Protocols.HTTP2() || s.httpServer.Protocols.UnencryptedHTTP2(),
+	}).Info("Starting HTTP server")
+
+	// Certificates come from TLSConfig
+	if s.httpServer.TLSConfig != nil {
+		return s.httpServer.ListenAndServeTLS("", "")
+	}
 
 	return s.httpServer.ListenAndServe()
 }
 
+// Proxy returns the network proxy, for replaying requests outside the middleware chain.
+func (s *Server) Proxy() proxy.Handler {
+	return s.proxy
+}
+
 // Shutdown gracefully shuts down the server.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down HTTP server")
 
+	// Stop accepting connections and let in-flight requests finish while the
+	// services they use are still running. SSE and WebSocket connections get
+	// the drain timeout, then are closed.
+	shutdownErr := make(chan error, 1)
+
+	go func() {
+		shutdownErr <- s.httpServer.Shutdown(ctx)
+	}()
+
+	if err := s.inFlight.Drain(ctx, s.drainTimeout, s.logger); err != nil {
+		s.logger.WithError(err).Warn("In-flight requests did not finish in time")
+	} else {
+		s.logger.Info("In-flight requests drained")
+	}
+
 	// Stop gas profiler health poller
 	if s.gasProfilerHandler != nil {
 		s.gasProfilerHandler.Stop()
 	}
 
+	// Stop continuous profiling
+	if s.profiler != nil {
+		s.profiler.Stop()
+	}
+
+	// Stop SLO evaluation
+	if s.sloService != nil {
+		s.sloService.Stop()
+	}
+
+	// Stop scheduled jobs
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+	}
+
+	// Stop WebSocket push and disconnect clients
+	if s.pushHub != nil {
+		s.pushHub.Stop()
+	}
+
+	// Stop deny list reloads
+	if s.denyList != nil {
+		s.denyList.Stop()
+	}
+
+	// Stop read-only mode polling
+	s.readOnly.Stop()
+
+	// Stop maintenance window polling
+	if s.maintenance != nil {
+		s.maintenance.Stop()
+	}
+
+	// Stop slot transform override polling
+	s.slotTransform.Stop()
+
+	// Stop gRPC API and end watch streams
+	if s.grpcServer != nil {
+		s.grpcServer.Stop(ctx)
+	}
+
 	// Shutdown frontend cache refresh loop
 	if s.frontend != nil {
 		if err := s.frontend.Stop(); err != nil {
@@ -200,6 +876,11 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// Flush remaining denied request counts
+	if s.offenders != nil {
+		s.offenders.Stop()
+	}
+
 	// Shutdown rate limiter
 	if s.rateLimiter != nil {
 		if err := s.rateLimiter.Stop(); err != nil {
@@ -207,5 +888,5 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	return s.httpServer.Shutdown(ctx)
+	return <-shutdownErr
 }
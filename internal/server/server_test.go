@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	apimocks "github.com/ethpandaops/lab-backend/internal/api/mocks"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	frontendmocks "github.com/ethpandaops/lab-backend/internal/frontend/mocks"
+	proxymocks "github.com/ethpandaops/lab-backend/internal/proxy/mocks"
+	"github.com/ethpandaops/lab-backend/internal/readonly"
+	"github.com/ethpandaops/lab-backend/internal/slottransform"
+)
+
+func TestServer_Shutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	gasProfiler := apimocks.NewMockGasProfiler(ctrl)
+	frontendHandler := frontendmocks.NewMockHandler(ctrl)
+	proxyHandler := proxymocks.NewMockHandler(ctrl)
+
+	// Components stop before the proxy, and a failing one does not stop the rest
+	gomock.InOrder(
+		gasProfiler.EXPECT().Stop(),
+		frontendHandler.EXPECT().Stop().Return(errors.New("boom")),
+		proxyHandler.EXPECT().Shutdown().Return(nil),
+	)
+
+	s := &Server{
+		httpServer:         &http.Server{},
+		proxy:              proxyHandler,
+		frontend:           frontendHandler,
+		gasProfilerHandler: gasProfiler,
+		readOnly:           readonly.New(logger, config.ReadOnlyConfig{}, nil),
+		slotTransform:      slottransform.New(logger, config.SlotTransformConfig{}, nil),
+		logger:             logger,
+	}
+
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestServer_ShutdownWithoutGasProfiler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	frontendHandler := frontendmocks.NewMockHandler(ctrl)
+	frontendHandler.EXPECT().Stop().Return(nil)
+
+	proxyHandler := proxymocks.NewMockHandler(ctrl)
+	proxyHandler.EXPECT().Shutdown().Return(nil)
+
+	s := &Server{
+		httpServer:    &http.Server{},
+		proxy:         proxyHandler,
+		frontend:      frontendHandler,
+		readOnly:      readonly.New(logger, config.ReadOnlyConfig{}, nil),
+		slotTransform: slottransform.New(logger, config.SlotTransformConfig{}, nil),
+		logger:        logger,
+	}
+
+	require.NoError(t, s.Shutdown(context.Background()))
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+// protocols returns the protocols the HTTP server speaks: HTTP/1.1, plus
+// HTTP/2 over TLS and, with h2c, over plain connections.
+func protocols(cfg config.ServerConfig) *http.Protocols {
+	protos := new(http.Protocols)
+	protos.SetHTTP1(true)
+	protos.SetHTTP2(cfg.TLS.Enabled && cfg.HTTP2Enabled())
+	protos.SetUnencryptedHTTP2(cfg.H2C)
+
+	return protos
+}
+
+// newTLSConfig returns the TLS settings of the HTTP server, or nil when TLS
+// is disabled. Certificate files are loaded here, so a bad pair fails startup.
+func newTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // nil config means plain HTTP.
+	}
+
+	var tlsConfig *tls.Config
+
+	if cfg.UsesACME() {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.ACME.Domains...),
+			Cache:      autocert.DirCache(cfg.ACME.CacheDir),
+			Email:      cfg.ACME.Email,
+		}
+
+		if cfg.ACME.DirectoryURL != "" {
+			manager.Client = &acme.Client{DirectoryURL: cfg.ACME.DirectoryURL}
+		}
+
+		// Answers TLS-ALPN-01 challenges; net/http adds h2 and http/1.1 as configured
+		tlsConfig = &tls.Config{
+			GetCertificate: manager.GetCertificate,
+			NextProtos:     []string{acme.ALPNProto},
+		}
+	} else {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	tlsConfig.MinVersion = tls.VersionTLS12
+	if cfg.MinVersion == config.TLSVersion13 {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
+
+	return tlsConfig, nil
+}
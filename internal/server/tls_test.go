@@ -0,0 +1,56 @@
+package server
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestProtocols(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name        string
+		cfg         config.ServerConfig
+		http2       bool
+		unencrypted bool
+	}{
+		{name: "plain", cfg: config.ServerConfig{}},
+		{name: "h2c", cfg: config.ServerConfig{H2C: true}, unencrypted: true},
+		{name: "tls", cfg: config.ServerConfig{TLS: config.TLSConfig{Enabled: true}}, http2: true},
+		{name: "tls without http2", cfg: config.ServerConfig{HTTP2: &disabled, TLS: config.TLSConfig{Enabled: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protos := protocols(tt.cfg)
+
+			assert.True(t, protos.HTTP1())
+			assert.Equal(t, tt.http2, protos.HTTP2())
+			assert.Equal(t, tt.unencrypted, protos.UnencryptedHTTP2())
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	tlsConfig, err := newTLSConfig(config.TLSConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = newTLSConfig(config.TLSConfig{Enabled: true, CertFile: "missing.pem", KeyFile: "missing.key"})
+	require.Error(t, err)
+
+	cfg := config.TLSConfig{Enabled: true, MinVersion: config.TLSVersion13, ACME: config.ACMEConfig{Domains: []string{"lab.example.com"}}}
+	require.NoError(t, cfg.Validate())
+
+	tlsConfig, err = newTLSConfig(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, tlsConfig.GetCertificate)
+	assert.Equal(t, []string{acme.ALPNProto}, tlsConfig.NextProtos)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/ethpandaops/lab-backend/internal/httperr"
+)
+
+const (
+	// wsMaxClientPayload bounds messages read from clients, which never need to send data.
+	wsMaxClientPayload = 4096
+
+	wsWriteTimeout = 10 * time.Second
+)
+
+// isWebSocketUpgrade reports whether r asks to switch to the WebSocket protocol.
+func isWebSocketUpgrade(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+
+	for _, value := range r.Header.Values("Connection") {
+		for token := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// acceptWebSocket validates the handshake and switches protocols. Framing,
+// masking, fragmentation and control frames are left to coder/websocket.
+// On failure an HTTP error has already been written.
+func acceptWebSocket(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
+	if r.Method != http.MethodGet || !isWebSocketUpgrade(r) || r.Header.Get("Sec-WebSocket-Key") == "" {
+		httperr.Write(w, r, http.StatusBadRequest, httperr.CodeBadRequest, "websocket upgrade required")
+
+		return nil, fmt.Errorf("not a websocket upgrade")
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		httperr.Write(w, r, http.StatusUpgradeRequired, httperr.CodeUpgradeRequired, "unsupported websocket version")
+
+		return nil, fmt.Errorf("unsupported websocket version")
+	}
+
+	// Clear the server's read/write timeouts, the connection is long-lived
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		// Events are public and the stream carries no credentials, so like the
+		// REST API any origin may subscribe.
+		OriginPatterns: []string{"*"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("accept: %w", err)
+	}
+
+	conn.SetReadLimit(wsMaxClientPayload)
+
+	return conn, nil
+}
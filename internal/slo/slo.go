@@ -0,0 +1,351 @@
+package slo
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/jitter"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+// SLO names used in reports, metrics and alerts.
+const (
+	SLOAvailability = "availability"
+	SLOLatency      = "latency"
+)
+
+// Alert statuses sent to the webhook.
+const (
+	AlertStatusExhausted = "exhausted"
+	AlertStatusRecovered = "recovered"
+)
+
+var (
+	sloIndicator = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_slo_indicator_ratio",
+			Help: "Fraction of good requests within the SLO window",
+		},
+		[]string{"subsystem", "host", "slo"},
+	)
+
+	sloBurnRate = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_slo_burn_rate",
+			Help: "Error budget burn rate within the SLO window (1 = budget exactly consumed)",
+		},
+		[]string{"subsystem", "host", "slo"},
+	)
+
+	sloBudgetRemaining = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_slo_error_budget_remaining_ratio",
+			Help: "Fraction of the error budget remaining within the SLO window",
+		},
+		[]string{"subsystem", "host", "slo"},
+	)
+)
+
+// Report is the SLO status of one upstream host for one subsystem.
+type Report struct {
+	Subsystem        string    `json:"subsystem"`
+	Host             string    `json:"host"`
+	Requests         uint64    `json:"requests"`
+	LatencyThreshold string    `json:"latency_threshold"`
+	Availability     SLIReport `json:"availability"`
+	Latency          SLIReport `json:"latency"`
+}
+
+// SLIReport is the status of a single objective.
+type SLIReport struct {
+	Target          float64 `json:"target"`
+	Actual          float64 `json:"actual"`
+	BurnRate        float64 `json:"burn_rate"`
+	BudgetRemaining float64 `json:"budget_remaining"`
+	Exhausted       bool    `json:"exhausted"`
+}
+
+// Alert is the webhook payload sent when an error budget is exhausted or recovers.
+type Alert struct {
+	Status    string    `json:"status"`
+	SLO       string    `json:"slo"`
+	Subsystem string    `json:"subsystem"`
+	Host      string    `json:"host"`
+	Target    float64   `json:"target"`
+	Actual    float64   `json:"actual"`
+	BurnRate  float64   `json:"burn_rate"`
+	Window    string    `json:"window"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// key identifies a tracked (subsystem, host) pair.
+type key struct {
+	subsystem string
+	host      string
+}
+
+// alertKey identifies a single objective of a tracked upstream.
+type alertKey struct {
+	key
+	slo string
+}
+
+// Service computes rolling availability and latency SLOs from outbound
+// request observations and alerts when error budgets are exhausted.
+type Service struct {
+	cfg        config.SLOConfig
+	log        logrus.FieldLogger
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu        sync.Mutex
+	windows   map[key]*window
+	exhausted map[alertKey]bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	task *tasks.Task
+}
+
+// New creates an SLO service and subscribes it to the tracker's observations.
+func New(log logrus.FieldLogger, cfg config.SLOConfig, tracker *upstream.Tracker) *Service {
+	s := &Service{
+		cfg:        cfg,
+		log:        log.WithField("component", "slo"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+		windows:    make(map[key]*window),
+		exhausted:  make(map[alertKey]bool),
+		done:       make(chan struct{}),
+	}
+
+	tracker.AddObserver(s.observe)
+
+	return s
+}
+
+// Start starts the periodic evaluation loop.
+func (s *Service) Start() {
+	s.task = tasks.Default().Register("slo.evaluate", s.cfg.EvaluationInterval)
+	s.wg.Add(1)
+
+	go s.evaluateLoop()
+
+	s.log.WithFields(logrus.Fields{
+		"window":              s.cfg.Window,
+		"evaluation_interval": s.cfg.EvaluationInterval,
+		"webhook":             s.cfg.WebhookURL != "",
+	}).Info("Started SLO tracking")
+}
+
+// Stop stops the evaluation loop.
+func (s *Service) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// observe records a completed outbound request.
+func (s *Service) observe(obs upstream.Observation) {
+	targets := s.cfg.TargetsFor(obs.Subsystem)
+	failed := obs.Err != nil || obs.StatusCode >= http.StatusInternalServerError
+	slow := obs.Duration > targets.LatencyThreshold
+
+	k := key{subsystem: obs.Subsystem, host: obs.Host}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.windows[k]
+	if !ok {
+		w = newWindow(s.cfg.Window)
+		s.windows[k] = w
+	}
+
+	w.record(obs.Time, failed, slow)
+}
+
+// Reports returns the current SLO status of every tracked upstream,
+// sorted by subsystem then host.
+func (s *Service) Reports() []Report {
+	now := s.now()
+
+	s.mu.Lock()
+
+	sums := make(map[key]counts, len(s.windows))
+	for k, w := range s.windows {
+		sums[k] = w.sum(now)
+	}
+
+	s.mu.Unlock()
+
+	reports := make([]Report, 0, len(sums))
+
+	for k, c := range sums {
+		targets := s.cfg.TargetsFor(k.subsystem)
+		enough := c.total >= uint64(s.cfg.MinRequests) //nolint:gosec // min_requests is validated non-negative.
+
+		reports = append(reports, Report{
+			Subsystem:        k.subsystem,
+			Host:             k.host,
+			Requests:         c.total,
+			LatencyThreshold: targets.LatencyThreshold.String(),
+			Availability:     newSLIReport(targets.AvailabilityTarget, c.ok, c.total, enough),
+			Latency:          newSLIReport(targets.LatencyTarget, c.ok-c.slow, c.ok, enough),
+		})
+	}
+
+	slices.SortFunc(reports, func(a, b Report) int {
+		return cmp.Or(
+			cmp.Compare(a.Subsystem, b.Subsystem),
+			cmp.Compare(a.Host, b.Host),
+		)
+	})
+
+	return reports
+}
+
+// newSLIReport computes the burn rate and remaining budget for good/total requests.
+// An objective is only considered exhausted once enough requests were observed.
+func newSLIReport(target float64, good, total uint64, enough bool) SLIReport {
+	report := SLIReport{
+		Target:          target,
+		Actual:          1,
+		BudgetRemaining: 1,
+	}
+
+	if total == 0 {
+		return report
+	}
+
+	report.Actual = float64(good) / float64(total)
+	report.BurnRate = (1 - report.Actual) / (1 - target)
+	report.BudgetRemaining = max(0, 1-report.BurnRate)
+	report.Exhausted = enough && report.BurnRate >= 1
+
+	return report
+}
+
+func (s *Service) evaluateLoop() {
+	defer s.wg.Done()
+
+	s.task.Supervise(s.log, s.done, s.runEvaluateLoop)
+}
+
+func (s *Service) runEvaluateLoop() {
+	ticker := jitter.NewTicker(s.cfg.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			_ = s.task.Run(func() error {
+				s.evaluate(context.Background())
+
+				return nil
+			})
+		}
+	}
+}
+
+// evaluate updates SLO metrics and fires alerts on budget state transitions.
+func (s *Service) evaluate(ctx context.Context) {
+	for _, report := range s.Reports() {
+		k := key{subsystem: report.Subsystem, host: report.Host}
+
+		s.evaluateSLI(ctx, k, SLOAvailability, report.Availability)
+		s.evaluateSLI(ctx, k, SLOLatency, report.Latency)
+	}
+}
+
+func (s *Service) evaluateSLI(ctx context.Context, k key, name string, sli SLIReport) {
+	sloIndicator.WithLabelValues(k.subsystem, k.host, name).Set(sli.Actual)
+	sloBurnRate.WithLabelValues(k.subsystem, k.host, name).Set(sli.BurnRate)
+	sloBudgetRemaining.WithLabelValues(k.subsystem, k.host, name).Set(sli.BudgetRemaining)
+
+	ak := alertKey{key: k, slo: name}
+
+	s.mu.Lock()
+	wasExhausted := s.exhausted[ak]
+	s.exhausted[ak] = sli.Exhausted
+	s.mu.Unlock()
+
+	if wasExhausted == sli.Exhausted {
+		return
+	}
+
+	status := AlertStatusRecovered
+	if sli.Exhausted {
+		status = AlertStatusExhausted
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"subsystem": k.subsystem,
+		"host":      k.host,
+		"slo":       name,
+		"actual":    sli.Actual,
+		"target":    sli.Target,
+		"burn_rate": sli.BurnRate,
+	}).Warnf("Upstream error budget %s", status)
+
+	if s.cfg.WebhookURL == "" {
+		return
+	}
+
+	alert := Alert{
+		Status:    status,
+		SLO:       name,
+		Subsystem: k.subsystem,
+		Host:      k.host,
+		Target:    sli.Target,
+		Actual:    sli.Actual,
+		BurnRate:  sli.BurnRate,
+		Window:    s.cfg.Window.String(),
+		Timestamp: s.now().UTC(),
+	}
+
+	if err := s.sendAlert(ctx, alert); err != nil {
+		s.log.WithError(err).Error("Failed to send SLO alert webhook")
+	}
+}
+
+// sendAlert posts an alert to the configured webhook.
+func (s *Service) sendAlert(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package slo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/upstream"
+)
+
+func newTestService(t *testing.T, webhookURL string) (*Service, *time.Time) {
+	t.Helper()
+
+	cfg := config.SLOConfig{
+		Enabled:     true,
+		MinRequests: 10,
+		WebhookURL:  webhookURL,
+	}
+	require.NoError(t, cfg.Validate())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s := New(logger, cfg, upstream.NewTracker())
+	s.now = func() time.Time { return now }
+
+	return s, &now
+}
+
+func observeN(s *Service, n int, obs upstream.Observation) {
+	for range n {
+		s.observe(obs)
+	}
+}
+
+func TestService_Reports(t *testing.T) {
+	s, now := newTestService(t, "")
+
+	ok := upstream.Observation{
+		Subsystem:  upstream.SubsystemProxy,
+		Host:       "cbt-mainnet:8080",
+		StatusCode: http.StatusOK,
+		Duration:   100 * time.Millisecond,
+		Time:       *now,
+	}
+
+	slow := ok
+	slow.Duration = 2 * time.Second
+
+	failed := ok
+	failed.StatusCode = http.StatusBadGateway
+
+	observeN(s, 90, ok)
+	observeN(s, 8, slow)
+	observeN(s, 2, failed)
+
+	reports := s.Reports()
+	require.Len(t, reports, 1)
+
+	report := reports[0]
+	assert.Equal(t, uint64(100), report.Requests)
+
+	// 98/100 succeeded against a 99% target: burn rate 2
+	assert.InDelta(t, 0.98, report.Availability.Actual, 0.0001)
+	assert.InDelta(t, 2.0, report.Availability.BurnRate, 0.0001)
+	assert.InDelta(t, 0.0, report.Availability.BudgetRemaining, 0.0001)
+	assert.True(t, report.Availability.Exhausted)
+
+	// 90/98 successful requests were fast against a 95% target
+	assert.InDelta(t, 90.0/98.0, report.Latency.Actual, 0.0001)
+	assert.True(t, report.Latency.Exhausted)
+}
+
+func TestService_ReportsExpireOutsideWindow(t *testing.T) {
+	s, now := newTestService(t, "")
+
+	observeN(s, 20, upstream.Observation{
+		Subsystem: upstream.SubsystemBounds,
+		Host:      "cbt-mainnet:8080",
+		Err:       errors.New("connection refused"),
+		Time:      *now,
+	})
+
+	require.True(t, s.Reports()[0].Availability.Exhausted)
+
+	*now = now.Add(2 * time.Hour)
+
+	report := s.Reports()[0]
+	assert.Equal(t, uint64(0), report.Requests)
+	assert.False(t, report.Availability.Exhausted)
+	assert.InDelta(t, 1.0, report.Availability.BudgetRemaining, 0.0001)
+}
+
+func TestService_MinRequests(t *testing.T) {
+	s, now := newTestService(t, "")
+
+	observeN(s, 5, upstream.Observation{
+		Subsystem: upstream.SubsystemCartographoor,
+		Host:      "cartographoor.example.com",
+		Err:       errors.New("timeout"),
+		Time:      *now,
+	})
+
+	report := s.Reports()[0]
+	assert.InDelta(t, 0.0, report.Availability.Actual, 0.0001)
+	assert.False(t, report.Availability.Exhausted, "too few requests to alert")
+}
+
+func TestService_WebhookAlerts(t *testing.T) {
+	alerts := make(chan Alert, 10)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+
+		if err := json.NewDecoder(r.Body).Decode(&alert); err == nil {
+			alerts <- alert
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	s, now := newTestService(t, webhook.URL)
+
+	observeN(s, 20, upstream.Observation{
+		Subsystem:  upstream.SubsystemGasProfiler,
+		Host:       "erigon-mainnet:8545",
+		StatusCode: http.StatusServiceUnavailable,
+		Time:       *now,
+	})
+
+	s.evaluate(context.Background())
+
+	require.Len(t, alerts, 1)
+
+	alert := <-alerts
+	assert.Equal(t, AlertStatusExhausted, alert.Status)
+	assert.Equal(t, SLOAvailability, alert.SLO)
+	assert.Equal(t, "erigon-mainnet:8545", alert.Host)
+
+	// No repeat alert while still exhausted
+	s.evaluate(context.Background())
+	assert.Empty(t, alerts)
+
+	// Failures age out of the window
+	*now = now.Add(2 * time.Hour)
+
+	s.evaluate(context.Background())
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, AlertStatusRecovered, (<-alerts).Status)
+}
@@ -0,0 +1,84 @@
+package slo
+
+import "time"
+
+// windowBuckets is the number of buckets a rolling window is split into.
+const windowBuckets = 60
+
+// bucket holds counts for one slice of the rolling window.
+type bucket struct {
+	slot   int64 // Bucket index since the epoch; identifies stale buckets
+	total  uint64
+	failed uint64
+	ok     uint64
+	slow   uint64
+}
+
+// counts is the sum of all buckets within the window.
+type counts struct {
+	total  uint64 // All requests
+	failed uint64 // Transport errors and 5xx responses
+	ok     uint64 // Requests that did not fail
+	slow   uint64 // Successful requests slower than the latency threshold
+}
+
+// window is a fixed-size ring of time buckets covering the SLO window.
+// It is not safe for concurrent use.
+type window struct {
+	bucketSize time.Duration
+	buckets    [windowBuckets]bucket
+}
+
+func newWindow(size time.Duration) *window {
+	return &window{
+		bucketSize: max(size/windowBuckets, time.Second),
+	}
+}
+
+// slotFor returns the bucket slot for t.
+func (w *window) slotFor(t time.Time) int64 {
+	return t.UnixNano() / int64(w.bucketSize)
+}
+
+// record adds a single request outcome to the bucket for t.
+func (w *window) record(t time.Time, failed, slow bool) {
+	slot := w.slotFor(t)
+	b := &w.buckets[slot%windowBuckets]
+
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+
+	b.total++
+
+	switch {
+	case failed:
+		b.failed++
+	case slow:
+		b.ok++
+		b.slow++
+	default:
+		b.ok++
+	}
+}
+
+// sum returns the totals of all buckets that fall within the window ending at now.
+func (w *window) sum(now time.Time) counts {
+	var c counts
+
+	current := w.slotFor(now)
+
+	for i := range w.buckets {
+		b := &w.buckets[i]
+		if b.total == 0 || b.slot <= current-windowBuckets || b.slot > current {
+			continue
+		}
+
+		c.total += b.total
+		c.failed += b.failed
+		c.ok += b.ok
+		c.slow += b.slow
+	}
+
+	return c
+}
@@ -0,0 +1,174 @@
+// Package slottransform decides whether slot filters are rewritten to
+// timestamps before proxying. The policy is set in config and can be
+// overridden at runtime through a Redis key shared by every instance, for
+// upstreams that index natively by slot and would be queried wrongly if their
+// filters were transformed.
+package slottransform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/rediswatch"
+)
+
+// pollTimeout bounds each Redis check.
+const pollTimeout = 2 * time.Second
+
+// ErrInvalidPolicy is returned by Set for policies that fail validation.
+var ErrInvalidPolicy = errors.New("invalid slot transform policy")
+
+var overrideGauge = metrics.NewGauge(prometheus.GaugeOpts{
+	Name: "slot_transform_override_active",
+	Help: "Whether a runtime slot transform policy override is set (1) or not (0)",
+})
+
+// Status is the configured policy and the runtime override, if any.
+type Status struct {
+	RedisKey string                      `json:"redis_key"`
+	Config   config.SlotTransformPolicy  `json:"config"`
+	Override *config.SlotTransformPolicy `json:"override,omitempty"`
+}
+
+// Service resolves the slot transform mode of a request.
+type Service struct {
+	cfg   config.SlotTransformConfig
+	log   logrus.FieldLogger
+	redis redis.Client
+
+	// override holds the policy set through Redis, nil while the key is
+	// absent or without Redis
+	override *rediswatch.Watcher[config.SlotTransformPolicy]
+}
+
+// New creates a slot transform service. redisClient may be nil, in which case
+// only the configured policy applies.
+func New(log logrus.FieldLogger, cfg config.SlotTransformConfig, redisClient redis.Client) *Service {
+	overrideGauge.Set(0)
+
+	s := &Service{
+		cfg:   cfg,
+		log:   log.WithField("component", "slot_transform"),
+		redis: redisClient,
+	}
+
+	if redisClient != nil {
+		s.override = rediswatch.New(s.log, rediswatch.Config[config.SlotTransformPolicy]{
+			Name:     "slot_transform.poll",
+			Interval: cfg.PollInterval,
+			Timeout:  pollTimeout,
+			Load:     rediswatch.Key(redisClient, cfg.RedisKey, decodePolicy),
+			OnChange: s.changed,
+		})
+	}
+
+	return s
+}
+
+// Mode returns the slot transform mode for a table of a network. The runtime
+// override takes precedence over config; a nil service always transforms.
+func (s *Service) Mode(network, table string) string {
+	if s == nil {
+		return config.SlotTransformModeTransform
+	}
+
+	if override := s.override.Value(); override != nil {
+		if mode := override.Resolve(network, table); mode != "" {
+			return mode
+		}
+	}
+
+	if mode := s.cfg.Resolve(network, table); mode != "" {
+		return mode
+	}
+
+	return config.SlotTransformModeTransform
+}
+
+// Status returns the configured policy and the runtime override.
+func (s *Service) Status() Status {
+	return Status{
+		RedisKey: s.cfg.RedisKey,
+		Config:   s.cfg.SlotTransformPolicy,
+		Override: s.override.Value(),
+	}
+}
+
+// Start checks the runtime key once and then polls it in the background.
+func (s *Service) Start() {
+	s.override.Start()
+}
+
+// Stop stops polling and waits for it to finish.
+func (s *Service) Stop() {
+	s.override.Stop()
+}
+
+// Set overrides the policy on every instance.
+func (s *Service) Set(ctx context.Context, policy config.SlotTransformPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("encode policy: %w", err)
+	}
+
+	if err := s.redis.Set(ctx, s.cfg.RedisKey, string(data), 0); err != nil {
+		return fmt.Errorf("set slot transform key: %w", err)
+	}
+
+	return s.override.Refresh(ctx)
+}
+
+// Clear removes the runtime override on every instance.
+func (s *Service) Clear(ctx context.Context) error {
+	if err := s.redis.Del(ctx, s.cfg.RedisKey); err != nil {
+		return fmt.Errorf("delete slot transform key: %w", err)
+	}
+
+	return s.override.Refresh(ctx)
+}
+
+// decodePolicy parses an override stored in Redis. An invalid policy is an
+// error, so the last known override is kept.
+func decodePolicy(data string) (*config.SlotTransformPolicy, error) {
+	var policy config.SlotTransformPolicy
+
+	if err := json.Unmarshal([]byte(data), &policy); err != nil {
+		return nil, fmt.Errorf("decode slot transform override: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid slot transform override: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// changed logs override changes and updates the gauge.
+func (s *Service) changed(prev, next *config.SlotTransformPolicy) {
+	switch {
+	case next == nil && prev != nil:
+		s.log.Warn("Slot transform override cleared")
+	case next != nil && (prev == nil || !reflect.DeepEqual(*prev, *next)):
+		s.log.WithField("policy", next).Warn("Slot transform override set at runtime")
+	}
+
+	if next != nil {
+		overrideGauge.Set(1)
+	} else {
+		overrideGauge.Set(0)
+	}
+}
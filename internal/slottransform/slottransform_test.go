@@ -0,0 +1,100 @@
+package slottransform
+
+import (
+	"io"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+const (
+	transform   = config.SlotTransformModeTransform
+	passthrough = config.SlotTransformModePassthrough
+)
+
+func newTestService(t *testing.T, cfg config.SlotTransformConfig) (*Service, *miniredis.Miniredis) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, mr := redistest.New(t, logger)
+
+	require.NoError(t, cfg.Validate())
+
+	service := New(logger, cfg, client)
+	service.Start()
+	t.Cleanup(service.Stop)
+
+	return service, mr
+}
+
+func TestService_Config(t *testing.T) {
+	service, _ := newTestService(t, config.SlotTransformConfig{
+		SlotTransformPolicy: config.SlotTransformPolicy{
+			Networks: map[string]config.SlotTransformNetworkPolicy{
+				"devnet":  {Mode: passthrough, Tables: map[string]string{"fct_block": transform}},
+				"holesky": {Tables: map[string]string{"fct_block": passthrough}},
+			},
+		},
+	})
+
+	assert.Equal(t, transform, service.Mode("mainnet", "fct_block"))
+	assert.Equal(t, passthrough, service.Mode("devnet", "fct_attestation"))
+	assert.Equal(t, transform, service.Mode("devnet", "fct_block"))
+	assert.Equal(t, passthrough, service.Mode("holesky", "fct_block"))
+	assert.Equal(t, transform, service.Mode("holesky", "fct_attestation"))
+}
+
+func TestService_Override(t *testing.T) {
+	service, mr := newTestService(t, config.SlotTransformConfig{
+		SlotTransformPolicy: config.SlotTransformPolicy{
+			Networks: map[string]config.SlotTransformNetworkPolicy{
+				"devnet": {Mode: passthrough},
+			},
+		},
+	})
+
+	require.NoError(t, service.Set(t.Context(), config.SlotTransformPolicy{
+		Networks: map[string]config.SlotTransformNetworkPolicy{
+			"mainnet": {Tables: map[string]string{"fct_block": passthrough}},
+		},
+	}))
+
+	assert.Equal(t, passthrough, service.Mode("mainnet", "fct_block"))
+	assert.Equal(t, transform, service.Mode("mainnet", "fct_attestation"))
+
+	// Levels the override leaves unset fall back to config
+	assert.Equal(t, passthrough, service.Mode("devnet", "fct_block"))
+	require.NotNil(t, service.Status().Override)
+
+	// Invalid policies are rejected and the last valid one is kept
+	require.ErrorIs(t, service.Set(t.Context(), config.SlotTransformPolicy{Mode: "sometimes"}), ErrInvalidPolicy)
+
+	mr.Set(service.cfg.RedisKey, "{not json")
+	require.Error(t, service.override.Refresh(t.Context()))
+	assert.Equal(t, passthrough, service.Mode("mainnet", "fct_block"))
+
+	// Redis errors keep the last known state
+	mr.SetError("unavailable")
+	require.Error(t, service.override.Refresh(t.Context()))
+	assert.Equal(t, passthrough, service.Mode("mainnet", "fct_block"))
+
+	mr.SetError("")
+
+	require.NoError(t, service.Clear(t.Context()))
+	assert.Equal(t, transform, service.Mode("mainnet", "fct_block"))
+	assert.Nil(t, service.Status().Override)
+}
+
+func TestService_Nil(t *testing.T) {
+	var service *Service
+
+	assert.Equal(t, transform, service.Mode("mainnet", "fct_block"))
+}
@@ -0,0 +1,162 @@
+// Package startup gates data endpoints while the data behind them warms up:
+// until a dependency's first snapshot has been loaded since startup, endpoints
+// serving it answer 503 instead of empty data or errors.
+package startup
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// Dependency names.
+const (
+	Cartographoor = "cartographoor"
+	Bounds        = "bounds"
+)
+
+// checkTimeout bounds each readiness check.
+const checkTimeout = 2 * time.Second
+
+var warmingGauge = metrics.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "startup_gate_pending",
+		Help: "Whether a dependency is still warming up (1) or has loaded its first snapshot (0)",
+	},
+	[]string{"dependency"},
+)
+
+// Dependency is data the API needs before it can serve. Ready reports whether
+// its first snapshot exists.
+type Dependency struct {
+	Name  string
+	Ready func(ctx context.Context) bool
+}
+
+// CartographoorDependency is ready once network data exists.
+func CartographoorDependency(provider cartographoor.Provider) Dependency {
+	return Dependency{
+		Name: Cartographoor,
+		Ready: func(ctx context.Context) bool {
+			return len(provider.GetNetworks(ctx)) > 0
+		},
+	}
+}
+
+// BoundsDependency is ready once bounds exist for some network.
+func BoundsDependency(provider bounds.Provider) Dependency {
+	return Dependency{
+		Name: Bounds,
+		Ready: func(ctx context.Context) bool {
+			return len(provider.GetAllBounds(ctx)) > 0
+		},
+	}
+}
+
+// Gate reports which dependencies are still warming up. A dependency is
+// checked on demand, at most once per check interval, and stays warm once it
+// was ready.
+type Gate struct {
+	cfg     config.StartupGateConfig
+	log     logrus.FieldLogger
+	started time.Time
+	deps    map[string]*dependency
+}
+
+type dependency struct {
+	Dependency
+
+	warm      atomic.Bool
+	mu        sync.Mutex // Serialises checks
+	checkedAt time.Time
+}
+
+// New creates a startup gate over deps. With the gate disabled every
+// dependency counts as warm.
+func New(log logrus.FieldLogger, cfg config.StartupGateConfig, deps ...Dependency) *Gate {
+	g := &Gate{
+		cfg:     cfg,
+		log:     log.WithField("component", "startup_gate"),
+		started: time.Now(),
+		deps:    make(map[string]*dependency, len(deps)),
+	}
+
+	for _, dep := range deps {
+		d := &dependency{Dependency: dep}
+		d.warm.Store(!cfg.IsEnabled())
+
+		g.deps[dep.Name] = d
+
+		if cfg.IsEnabled() {
+			warmingGauge.WithLabelValues(dep.Name).Set(1)
+		}
+	}
+
+	return g
+}
+
+// RetryAfter is how long clients are told to wait while warming.
+func (g *Gate) RetryAfter() time.Duration {
+	return g.cfg.RetryAfter
+}
+
+// Pending returns the named dependencies that are still warming up, in the
+// given order. Unknown names are ignored.
+func (g *Gate) Pending(ctx context.Context, names ...string) []string {
+	var pending []string
+
+	for _, name := range names {
+		d, ok := g.deps[name]
+		if !ok || g.check(ctx, d) {
+			continue
+		}
+
+		pending = append(pending, name)
+	}
+
+	return pending
+}
+
+// check reports whether a dependency is warm, checking it again when the
+// last check is older than the check interval.
+func (g *Gate) check(ctx context.Context, d *dependency) bool {
+	if d.warm.Load() {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Another request may have checked while we waited
+	if d.warm.Load() || time.Since(d.checkedAt) < g.cfg.CheckInterval {
+		return d.warm.Load()
+	}
+
+	d.checkedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if !d.Ready(ctx) {
+		return false
+	}
+
+	d.warm.Store(true)
+	warmingGauge.WithLabelValues(d.Name).Set(0)
+
+	g.log.WithFields(logrus.Fields{
+		"dependency": d.Name,
+		"after":      time.Since(g.started).Round(time.Millisecond),
+	}).Info("Dependency warmed up")
+
+	return true
+}
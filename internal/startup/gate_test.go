@@ -0,0 +1,69 @@
+package startup
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func newTestGate(t *testing.T, enabled bool, deps ...Dependency) *Gate {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.StartupGateConfig{Enabled: &enabled, CheckInterval: time.Hour}
+	require.NoError(t, cfg.Validate())
+
+	return New(logger, cfg, deps...)
+}
+
+func TestGatePending(t *testing.T) {
+	var ready atomic.Bool
+
+	checks := 0
+	dep := Dependency{
+		Name: Bounds,
+		Ready: func(context.Context) bool {
+			checks++
+
+			return ready.Load()
+		},
+	}
+
+	gate := newTestGate(t, true, dep)
+
+	assert.Equal(t, []string{Bounds}, gate.Pending(context.Background(), Bounds, "unknown"))
+
+	// Checks are throttled by the check interval
+	ready.Store(true)
+	assert.Equal(t, []string{Bounds}, gate.Pending(context.Background(), Bounds))
+	assert.Equal(t, 1, checks)
+
+	gate.deps[Bounds].checkedAt = time.Time{}
+	assert.Empty(t, gate.Pending(context.Background(), Bounds))
+
+	// Stays warm once ready
+	ready.Store(false)
+	assert.Empty(t, gate.Pending(context.Background(), Bounds))
+	assert.Equal(t, 2, checks)
+}
+
+func TestGateDisabled(t *testing.T) {
+	dep := Dependency{
+		Name:  Cartographoor,
+		Ready: func(context.Context) bool { return false },
+	}
+
+	gate := newTestGate(t, false, dep)
+
+	assert.Empty(t, gate.Pending(context.Background(), Cartographoor))
+}
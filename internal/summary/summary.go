@@ -0,0 +1,260 @@
+// Package summary keeps a small per-network summary, row counts of a few CBT
+// tables such as active nodes, so landing pages can show network liveness
+// without running the queries themselves. The leader counts and stores the
+// summaries in Redis; every replica serves them from there.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethpandaops/lab-backend/internal/cartographoor"
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/redis"
+	"github.com/ethpandaops/lab-backend/internal/scheduler"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+)
+
+const (
+	redisKeyPrefix = "lab:summary:"
+
+	// pageSize is the number of rows requested per page while counting.
+	pageSize = 10000
+)
+
+// Service refreshes network summaries on the leader and reads them from Redis.
+type Service struct {
+	log                   logrus.FieldLogger
+	cfg                   *config.Config
+	redis                 redis.Client
+	elector               leader.Elector
+	cartographoorProvider cartographoor.Provider
+	httpClient            *http.Client
+}
+
+// New creates a summary service.
+func New(
+	log logrus.FieldLogger,
+	cfg *config.Config,
+	redisClient redis.Client,
+	elector leader.Elector,
+	cartographoorProvider cartographoor.Provider,
+) *Service {
+	return &Service{
+		log:                   log.WithField("component", "summary"),
+		cfg:                   cfg,
+		redis:                 redisClient,
+		elector:               elector,
+		cartographoorProvider: cartographoorProvider,
+		httpClient:            cfg.Summary.HTTPClient(),
+	}
+}
+
+// Job returns the job refreshing summaries, run on the leader by the scheduler.
+func (s *Service) Job() scheduler.Job {
+	return scheduler.Job{
+		Name:     "summary.refresh",
+		Interval: s.cfg.Summary.RefreshInterval,
+		Run:      s.Refresh,
+	}
+}
+
+// Get returns the summary of a network. Errors wrap errs.ErrNotFound when the
+// network has no summary, or errs.ErrUpstreamUnavailable when Redis fails.
+func (s *Service) Get(ctx context.Context, network string) (*Summary, error) {
+	data, err := s.redis.Get(ctx, redisKeyPrefix+network)
+	if errors.Is(err, redis.ErrNotFound) {
+		return nil, fmt.Errorf("summary for %s: %w", network, errs.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read summary for %s: %w: %w", network, errs.ErrUpstreamUnavailable, err)
+	}
+
+	var summary Summary
+	if err := json.Unmarshal([]byte(data), &summary); err != nil {
+		return nil, fmt.Errorf("unmarshal summary for %s: %w", network, err)
+	}
+
+	return &summary, nil
+}
+
+// Refresh counts the configured tables of every enabled network and stores
+// the summaries in Redis. A network that fails keeps its previous summary
+// until it expires.
+func (s *Service) Refresh(ctx context.Context) (err error) {
+	// Leadership may have been lost since the caller checked
+	if !s.elector.IsLeader() {
+		return errs.ErrNotLeader
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "summary.refresh", trace.SpanKindInternal)
+	defer func() { tracing.EndSpan(span, err) }()
+
+	networks := make([]config.NetworkConfig, 0)
+
+	for _, network := range config.BuildMergedNetworkList(ctx, s.log, s.cfg, s.cartographoorProvider) {
+		if (network.Enabled == nil || *network.Enabled) && network.TargetURL != "" {
+			networks = append(networks, network)
+		}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		stored int
+	)
+
+	for _, network := range networks {
+		wg.Add(1)
+
+		go func(network config.NetworkConfig) {
+			defer wg.Done()
+
+			log := s.log.WithField("network", network.Name)
+
+			summary, err := s.summarize(ctx, network)
+			if err != nil {
+				log.WithError(err).Warn("Failed to summarize network")
+
+				return
+			}
+
+			data, err := json.Marshal(summary)
+			if err != nil {
+				log.WithError(err).Error("Failed to marshal summary")
+
+				return
+			}
+
+			if err := s.redis.Set(ctx, redisKeyPrefix+network.Name, string(data), s.cfg.Summary.TTL); err != nil {
+				log.WithError(err).Error("Failed to store summary in Redis")
+
+				return
+			}
+
+			mu.Lock()
+			stored++
+			mu.Unlock()
+		}(network)
+	}
+
+	wg.Wait()
+
+	s.log.WithFields(logrus.Fields{
+		"stored": stored,
+		"total":  len(networks),
+	}).Debug("Refreshed network summaries")
+
+	if stored == 0 && len(networks) > 0 {
+		return fmt.Errorf("no summaries refreshed for %d networks: %w", len(networks), errs.ErrUpstreamUnavailable)
+	}
+
+	return nil
+}
+
+// summarize counts every configured table of a network.
+func (s *Service) summarize(ctx context.Context, network config.NetworkConfig) (*Summary, error) {
+	summary := &Summary{
+		Network:     network.Name,
+		Counts:      make(map[string]int64, len(s.cfg.Summary.Counts)),
+		LastUpdated: time.Now(),
+	}
+
+	for name, table := range s.cfg.Summary.Counts {
+		count, truncated, err := s.countRows(ctx, network.TargetURL, table)
+		if err != nil {
+			return nil, fmt.Errorf("count %s: %w", table, err)
+		}
+
+		summary.Counts[name] = count
+		summary.Truncated = summary.Truncated || truncated
+	}
+
+	return summary, nil
+}
+
+// countRows counts the rows of a CBT table by paging through it, up to
+// max_pages pages. It reports whether rows were left uncounted.
+func (s *Service) countRows(ctx context.Context, targetURL, table string) (int64, bool, error) {
+	var (
+		count     int64
+		pageToken string
+	)
+
+	for range s.cfg.Summary.MaxPages {
+		query := url.Values{"page_size": {fmt.Sprint(pageSize)}}
+		if pageToken != "" {
+			query.Set("page_token", pageToken)
+		}
+
+		rows, next, err := s.fetchPage(ctx, fmt.Sprintf("%s/%s?%s", targetURL, table, query.Encode()), table)
+		if err != nil {
+			return 0, false, err
+		}
+
+		count += int64(rows)
+
+		if next == "" {
+			return count, false, nil
+		}
+
+		pageToken = next
+	}
+
+	return count, true, nil
+}
+
+// fetchPage returns the number of rows on a page and the next page token.
+func (s *Service) fetchPage(ctx context.Context, reqURL, table string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return 0, "", fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("fetch page: %w: %w", errs.ErrUpstreamUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return 0, "", fmt.Errorf("%w: unexpected status %d: %s", errs.ErrUpstreamUnavailable, resp.StatusCode, string(body))
+	}
+
+	// Rows are listed under the table name; only their number matters
+	var page map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return 0, "", fmt.Errorf("parse JSON: %w", err)
+	}
+
+	var rows []json.RawMessage
+	if raw, ok := page[table]; ok {
+		if err := json.Unmarshal(raw, &rows); err != nil {
+			return 0, "", fmt.Errorf("parse %s rows: %w", table, err)
+		}
+	}
+
+	var next string
+	if raw, ok := page["next_page_token"]; ok {
+		if err := json.Unmarshal(raw, &next); err != nil {
+			return 0, "", fmt.Errorf("parse next_page_token: %w", err)
+		}
+	}
+
+	return len(rows), next, nil
+}
@@ -0,0 +1,148 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/errs"
+	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
+	"github.com/ethpandaops/lab-backend/internal/redistest"
+)
+
+// cbtServer serves tables of rows rows each, pageRows rows per page.
+func cbtServer(t *testing.T, rows, pageRows int, failing *atomic.Bool) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/sepolia") && failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		table := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		offset := 0
+		if token := r.URL.Query().Get("page_token"); token != "" {
+			offset = len(token) * pageRows
+		}
+
+		page := make([]map[string]any, 0, pageRows)
+		for i := offset; i < rows && i < offset+pageRows; i++ {
+			page = append(page, map[string]any{"meta_client_name": i})
+		}
+
+		response := map[string]any{table: page}
+		if offset+pageRows < rows {
+			response["next_page_token"] = strings.Repeat("x", offset/pageRows+1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response) //nolint:errcheck // test.
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestService(t *testing.T, server *httptest.Server, maxPages int) *Service {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, _ := redistest.New(t, logger)
+
+	cfg := &config.Config{
+		Networks: []config.NetworkConfig{
+			{Name: "mainnet", TargetURL: server.URL + "/mainnet"},
+			{Name: "sepolia", TargetURL: server.URL + "/sepolia"},
+		},
+		Summary: config.SummaryConfig{
+			Enabled:  true,
+			MaxPages: maxPages,
+			Counts: map[string]string{
+				"nodes":        "fct_node_active_last_24h",
+				"observations": "fct_block_first_seen_by_node",
+			},
+		},
+	}
+	require.NoError(t, cfg.Summary.Validate())
+
+	elector := leadermocks.NewMockElector(gomock.NewController(t))
+	elector.EXPECT().IsLeader().Return(true).AnyTimes()
+
+	return New(logger, cfg, client, elector, nil)
+}
+
+func TestService_Refresh(t *testing.T) {
+	var failing atomic.Bool
+
+	s := newTestService(t, cbtServer(t, 25, 10, &failing), 10)
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "mainnet")
+	require.ErrorIs(t, err, errs.ErrNotFound)
+
+	require.NoError(t, s.Refresh(ctx))
+
+	for _, network := range []string{"mainnet", "sepolia"} {
+		summary, err := s.Get(ctx, network)
+		require.NoError(t, err)
+
+		assert.Equal(t, network, summary.Network)
+		assert.Equal(t, map[string]int64{"nodes": 25, "observations": 25}, summary.Counts)
+		assert.False(t, summary.Truncated)
+		assert.False(t, summary.LastUpdated.IsZero())
+	}
+
+	// A failing network keeps its last summary
+	before, err := s.Get(ctx, "sepolia")
+	require.NoError(t, err)
+
+	failing.Store(true)
+	require.NoError(t, s.Refresh(ctx))
+
+	after, err := s.Get(ctx, "sepolia")
+	require.NoError(t, err)
+	assert.Equal(t, before.LastUpdated, after.LastUpdated)
+}
+
+func TestService_RefreshTruncates(t *testing.T) {
+	var failing atomic.Bool
+
+	s := newTestService(t, cbtServer(t, 25, 10, &failing), 2)
+	ctx := context.Background()
+
+	require.NoError(t, s.Refresh(ctx))
+
+	summary, err := s.Get(ctx, "mainnet")
+	require.NoError(t, err)
+
+	// Only max_pages pages are counted
+	assert.Equal(t, int64(20), summary.Counts["nodes"])
+	assert.True(t, summary.Truncated)
+}
+
+func TestService_RefreshFailsWhenNothingStored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	s := newTestService(t, server, 10)
+
+	require.ErrorIs(t, s.Refresh(context.Background()), errs.ErrUpstreamUnavailable)
+}
@@ -0,0 +1,12 @@
+//nolint:tagliatelle // superior snake-case yo.
+package summary
+
+import "time"
+
+// Summary is the liveness summary of a network.
+type Summary struct {
+	Network     string           `json:"network"`
+	Counts      map[string]int64 `json:"counts"`              // Count name → rows counted
+	Truncated   bool             `json:"truncated,omitempty"` // Some table had more than max_pages pages; its count is a lower bound
+	LastUpdated time.Time        `json:"last_updated"`
+}
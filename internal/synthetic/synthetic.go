@@ -0,0 +1,348 @@
+// Package synthetic serves a deterministic, CBT-like API for a fake network,
+// so the frontend can be exercised end-to-end without a real upstream.
+package synthetic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+const (
+	// defaultPageSize and maxPageSize mirror the CBT API's pagination limits.
+	defaultPageSize = 100
+	maxPageSize     = 10000
+
+	// slotsPerEpoch is used for the generated epoch column.
+	slotsPerEpoch = 32
+
+	apiPrefix = "/api/v1/"
+)
+
+// Verify interface compliance at compile time.
+var _ http.Handler = (*Network)(nil)
+
+// Network generates data for the synthetic network and serves it over a
+// loopback listener, so the proxy and bounds fetcher treat it like any other
+// CBT API backend.
+type Network struct {
+	cfg    config.SyntheticConfig
+	log    logrus.FieldLogger
+	tables map[string]bool
+	now    func() time.Time
+
+	listener net.Listener
+	server   *http.Server
+}
+
+// New creates a synthetic network from a validated config.
+func New(log logrus.FieldLogger, cfg config.SyntheticConfig) *Network {
+	tables := make(map[string]bool, len(cfg.Tables))
+	for _, table := range cfg.Tables {
+		tables[table] = true
+	}
+
+	return &Network{
+		cfg:    cfg,
+		log:    log.WithField("component", "synthetic"),
+		tables: tables,
+		now:    time.Now,
+	}
+}
+
+// Start begins serving the generated API on the configured listen address.
+func (n *Network) Start() error {
+	listener, err := net.Listen("tcp", n.cfg.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	n.listener = listener
+	n.server = &http.Server{
+		Handler:           n,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := n.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			n.log.WithError(err).Error("Synthetic network server failed")
+		}
+	}()
+
+	n.log.WithFields(logrus.Fields{
+		"network": n.cfg.Name,
+		"url":     n.URL(),
+		"tables":  len(n.tables),
+	}).Info("Synthetic network started")
+
+	return nil
+}
+
+// Stop shuts down the generated API.
+func (n *Network) Stop(ctx context.Context) error {
+	if n.server == nil {
+		return nil
+	}
+
+	return n.server.Shutdown(ctx)
+}
+
+// URL returns the base URL of the generated API, for use as a target_url.
+func (n *Network) URL() string {
+	return "http://" + n.listener.Addr().String() + "/api/v1"
+}
+
+// NetworkConfig returns the network entry that routes the synthetic network
+// to the generated API. Start must have been called.
+func (n *Network) NetworkConfig() config.NetworkConfig {
+	enabled := true
+	chainID := n.cfg.ChainID
+	genesisTime := n.cfg.GenesisTime
+	genesisDelay := int64(0)
+
+	return config.NetworkConfig{
+		Name:         n.cfg.Name,
+		Enabled:      &enabled,
+		TargetURL:    n.URL(),
+		DisplayName:  n.cfg.DisplayName,
+		ChainID:      &chainID,
+		GenesisTime:  &genesisTime,
+		GenesisDelay: &genesisDelay,
+	}
+}
+
+// Bounds returns the generated table bounds at now. Positions are slot start
+// timestamps: the window ends after the current slot and reaches back by the
+// configured retention.
+func (n *Network) Bounds(now time.Time) bounds.TableBounds {
+	slotSeconds := int64(n.cfg.SecondsPerSlot)
+	currentSlot := max(0, (now.Unix()-n.cfg.GenesisTime)/slotSeconds)
+	retentionSlots := int64(n.cfg.Retention/time.Second) / slotSeconds
+
+	firstSlot := max(0, currentSlot-retentionSlots+1)
+
+	return bounds.TableBounds{
+		Min: n.slotStart(firstSlot),
+		Max: n.slotStart(currentSlot + 1),
+	}
+}
+
+// ServeHTTP serves admin_cbt_incremental for bounds and generated rows for tables.
+func (n *Network) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+		return
+	}
+
+	table, ok := strings.CutPrefix(r.URL.Path, apiPrefix)
+	if !ok || table == "" || strings.Contains(table, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+
+		return
+	}
+
+	if table == "admin_cbt_incremental" {
+		n.serveIncremental(w, r)
+
+		return
+	}
+
+	if !n.tables[table] {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("table %s not found", table))
+
+		return
+	}
+
+	n.serveTable(w, r, table)
+}
+
+// serveIncremental reports one processed range per table, which the bounds
+// fetcher turns into the current bounds.
+func (n *Network) serveIncremental(w http.ResponseWriter, r *http.Request) {
+	database := r.URL.Query().Get("database_eq")
+	if database != "" && database != n.cfg.Name {
+		writeJSON(w, bounds.AdminCBTIncrementalResponse{AdminCBTIncremental: []bounds.IncrementalTableRecord{}})
+
+		return
+	}
+
+	now := n.now()
+	current := n.Bounds(now)
+
+	records := make([]bounds.IncrementalTableRecord, 0, len(n.cfg.Tables))
+	for _, table := range n.cfg.Tables {
+		records = append(records, bounds.IncrementalTableRecord{
+			Database:        n.cfg.Name,
+			Table:           table,
+			Position:        current.Min,
+			Interval:        current.Max - current.Min,
+			UpdatedDateTime: now.Unix(),
+		})
+	}
+
+	writeJSON(w, bounds.AdminCBTIncrementalResponse{AdminCBTIncremental: records})
+}
+
+// serveTable returns generated rows for the slots within the current bounds
+// and the request's slot_start_date_time (or slot) filters.
+func (n *Network) serveTable(w http.ResponseWriter, r *http.Request, table string) {
+	query := r.URL.Query()
+
+	pageSize, err := intParam(query, "page_size", defaultPageSize)
+	if err != nil || pageSize <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid page_size")
+
+		return
+	}
+
+	pageSize = min(pageSize, maxPageSize)
+
+	offset, err := intParam(query, "page_token", 0)
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest, "invalid page_token")
+
+		return
+	}
+
+	current := n.Bounds(n.now())
+	first := n.slotAt(current.Min)
+	last := n.slotAt(current.Max) - 1
+
+	first, last, err = n.applyFilters(query, first, last)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	descending := strings.Contains(strings.ToLower(query.Get("order_by")), "desc")
+
+	rows := make([]map[string]any, 0, pageSize)
+
+	total := last - first + 1
+	for i := offset; i < offset+pageSize && i < total; i++ {
+		slot := first + i
+		if descending {
+			slot = last - i
+		}
+
+		rows = append(rows, n.row(table, slot))
+	}
+
+	nextPageToken := ""
+	if offset+pageSize < total {
+		nextPageToken = strconv.FormatInt(offset+pageSize, 10)
+	}
+
+	writeJSON(w, map[string]any{
+		table:             rows,
+		"next_page_token": nextPageToken,
+	})
+}
+
+// applyFilters narrows the slot range [first, last] by the CBT-style
+// slot_start_date_time_* and slot_* filters in query.
+func (n *Network) applyFilters(query url.Values, first, last int64) (int64, int64, error) {
+	for _, column := range []string{"slot_start_date_time", "slot"} {
+		toSlot := func(v int64) int64 { return v }
+		if column == "slot_start_date_time" {
+			toSlot = func(v int64) int64 { return n.slotAt(v) }
+		}
+
+		for _, op := range []string{"eq", "gte", "gt", "lte", "lt"} {
+			raw := query.Get(column + "_" + op)
+			if raw == "" {
+				continue
+			}
+
+			value, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid %s_%s", column, op)
+			}
+
+			slot := toSlot(value)
+
+			switch op {
+			case "eq":
+				first, last = max(first, slot), min(last, slot)
+			case "gte":
+				first = max(first, slot)
+			case "gt":
+				first = max(first, slot+1)
+			case "lte":
+				last = min(last, slot)
+			case "lt":
+				last = min(last, slot-1)
+			}
+		}
+	}
+
+	return first, last, nil
+}
+
+// row generates the deterministic row for a table and slot.
+func (n *Network) row(table string, slot int64) map[string]any {
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%s/%s/%d", n.cfg.Name, table, slot)
+	seed := hash.Sum64()
+	epoch := slot / slotsPerEpoch
+
+	return map[string]any{
+		"slot":                  slot,
+		"slot_start_date_time":  n.slotStart(slot),
+		"epoch":                 epoch,
+		"epoch_start_date_time": n.slotStart(epoch * slotsPerEpoch),
+		"block_root":            fmt.Sprintf("0x%016x%016x%016x%016x", seed, seed>>1, seed>>2, seed>>3),
+		"value":                 int64(seed % 1000),
+	}
+}
+
+func (n *Network) slotStart(slot int64) int64 {
+	return n.cfg.GenesisTime + slot*int64(n.cfg.SecondsPerSlot)
+}
+
+// slotAt returns the slot containing timestamp ts.
+func (n *Network) slotAt(ts int64) int64 {
+	return (ts - n.cfg.GenesisTime) / int64(n.cfg.SecondsPerSlot)
+}
+
+func intParam(query url.Values, name string, fallback int64) (int64, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    status,
+		"message": message,
+	})
+}
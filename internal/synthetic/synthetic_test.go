@@ -0,0 +1,134 @@
+package synthetic
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/bounds"
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+const testGenesis = 1606824023
+
+func newTestNetwork(t *testing.T, now time.Time) *Network {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.SyntheticConfig{Enabled: true, GenesisTime: testGenesis, Retention: time.Hour}
+	require.NoError(t, cfg.Validate())
+
+	n := New(logger, cfg)
+	n.now = func() time.Time { return now }
+
+	return n
+}
+
+func get(t *testing.T, n *Network, target string, body any) int {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	n.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+
+	if body != nil && rec.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(body))
+	}
+
+	return rec.Code
+}
+
+func TestNetwork_BoundsAdvanceWithWallclock(t *testing.T) {
+	now := time.Unix(testGenesis+1000*12+5, 0)
+	n := newTestNetwork(t, now)
+
+	current := n.Bounds(now)
+	assert.Equal(t, int64(testGenesis+1001*12), current.Max, "ends after the current slot")
+	assert.Equal(t, int64(time.Hour/time.Second), current.Max-current.Min, "reaches back by the retention")
+
+	later := n.Bounds(now.Add(12 * time.Second))
+	assert.Equal(t, current.Max+12, later.Max)
+	assert.Equal(t, current.Min+12, later.Min)
+}
+
+func TestNetwork_AdminCBTIncremental(t *testing.T) {
+	now := time.Unix(testGenesis+1000*12, 0)
+	n := newTestNetwork(t, now)
+
+	var resp bounds.AdminCBTIncrementalResponse
+	require.Equal(t, http.StatusOK, get(t, n, "/api/v1/admin_cbt_incremental?database_eq=synthetic", &resp))
+	require.Len(t, resp.AdminCBTIncremental, len(config.DefaultSyntheticTables))
+
+	current := n.Bounds(now)
+	for _, record := range resp.AdminCBTIncremental {
+		assert.Equal(t, current.Min, record.Position)
+		assert.Equal(t, current.Max, record.Position+record.Interval)
+	}
+
+	// Other databases have no data
+	resp = bounds.AdminCBTIncrementalResponse{}
+	require.Equal(t, http.StatusOK, get(t, n, "/api/v1/admin_cbt_incremental?database_eq=mainnet", &resp))
+	assert.Empty(t, resp.AdminCBTIncremental)
+}
+
+func TestNetwork_TableRows(t *testing.T) {
+	now := time.Unix(testGenesis+1000*12, 0)
+	n := newTestNetwork(t, now)
+
+	var page map[string]json.RawMessage
+	require.Equal(t, http.StatusOK, get(t, n, "/api/v1/fct_block?slot_gte=990&page_size=4", &page))
+
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal(page["fct_block"], &rows))
+	require.Len(t, rows, 4)
+	assert.InDelta(t, 990, rows[0]["slot"], 0)
+	assert.InDelta(t, testGenesis+990*12, rows[0]["slot_start_date_time"], 0)
+	assert.JSONEq(t, `"4"`, string(page["next_page_token"]))
+
+	// Rows are deterministic
+	var again map[string]json.RawMessage
+	require.Equal(t, http.StatusOK, get(t, n, "/api/v1/fct_block?slot_gte=990&page_size=4", &again))
+	assert.Equal(t, page["fct_block"], again["fct_block"])
+
+	// Last page of the range ends at the current slot
+	require.Equal(t, http.StatusOK, get(t, n, "/api/v1/fct_block?slot_gte=990&page_size=4&page_token=8", &page))
+	require.NoError(t, json.Unmarshal(page["fct_block"], &rows))
+	require.Len(t, rows, 3)
+	assert.InDelta(t, 1000, rows[2]["slot"], 0)
+	assert.JSONEq(t, `""`, string(page["next_page_token"]))
+
+	// Timestamp filters, descending order
+	target := "/api/v1/fct_block?slot_start_date_time_lte=" +
+		"1606836023&order_by=slot_start_date_time%20DESC&page_size=1"
+	require.Equal(t, http.StatusOK, get(t, n, target, &page))
+	require.NoError(t, json.Unmarshal(page["fct_block"], &rows))
+	require.Len(t, rows, 1)
+	assert.InDelta(t, 1000, rows[0]["slot"], 0)
+
+	assert.Equal(t, http.StatusNotFound, get(t, n, "/api/v1/fct_unknown", nil))
+	assert.Equal(t, http.StatusBadRequest, get(t, n, "/api/v1/fct_block?page_size=abc", nil))
+}
+
+func TestNetwork_ServesOverLoopback(t *testing.T) {
+	n := newTestNetwork(t, time.Now())
+	require.NoError(t, n.Start())
+	t.Cleanup(func() { _ = n.Stop(t.Context()) })
+
+	network := n.NetworkConfig()
+	assert.Equal(t, "synthetic", network.Name)
+	assert.Equal(t, n.URL(), network.TargetURL)
+
+	resp, err := http.Get(network.TargetURL + "/fct_block_head?page_size=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
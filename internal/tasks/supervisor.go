@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// Restart backoff bounds. Variables so tests can shorten them.
+var (
+	minRestartBackoff = time.Second
+	maxRestartBackoff = time.Minute
+)
+
+var (
+	taskPanicsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "background_task_panics_total",
+			Help: "Total number of panics recovered from background loops",
+		},
+		[]string{"task"},
+	)
+
+	taskRestartsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "background_task_restarts_total",
+			Help: "Total number of background loop restarts after a panic",
+		},
+		[]string{"task"},
+	)
+)
+
+// Supervise runs loop until it returns normally, restarting it with
+// exponential backoff whenever it panics. Restarts stop once done is closed.
+// It blocks, so call it from the loop's goroutine.
+func (t *Task) Supervise(log logrus.FieldLogger, done <-chan struct{}, loop func()) {
+	backoff := minRestartBackoff
+
+	for {
+		started := time.Now()
+
+		rec, stack := runRecovered(loop)
+		t.Exit(rec)
+
+		if rec == nil {
+			return
+		}
+
+		// A loop that stayed up for a while starts over from the minimum backoff
+		if time.Since(started) > maxRestartBackoff {
+			backoff = minRestartBackoff
+		}
+
+		taskPanicsTotal.WithLabelValues(t.Name()).Inc()
+
+		log.WithFields(logrus.Fields{
+			"task":    t.Name(),
+			"panic":   fmt.Sprint(rec),
+			"stack":   stack,
+			"backoff": backoff,
+		}).Error("Background loop panicked, restarting")
+
+		select {
+		case <-done:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxRestartBackoff)
+
+		t.restart()
+		taskRestartsTotal.WithLabelValues(t.Name()).Inc()
+
+		log.WithField("task", t.Name()).Info("Restarted background loop")
+	}
+}
+
+// runRecovered calls loop and returns the recovered panic value and stack, if any.
+func runRecovered(loop func()) (rec any, stack string) {
+	defer func() {
+		if rec = recover(); rec != nil {
+			stack = string(debug.Stack())
+		}
+	}()
+
+	loop()
+
+	return nil, ""
+}
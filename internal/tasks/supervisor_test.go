@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shortenBackoff(t *testing.T) {
+	t.Helper()
+
+	minBackoff, maxBackoff := minRestartBackoff, maxRestartBackoff
+	minRestartBackoff, maxRestartBackoff = time.Millisecond, 4*time.Millisecond
+
+	t.Cleanup(func() {
+		minRestartBackoff, maxRestartBackoff = minBackoff, maxBackoff
+	})
+}
+
+func discardLogger() logrus.FieldLogger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return logger
+}
+
+func TestSupervise_RestartsAfterPanic(t *testing.T) {
+	shortenBackoff(t)
+
+	registry := NewRegistry()
+	task := registry.Register("supervise.restart", time.Minute)
+	restartsBefore := testutil.ToFloat64(taskRestartsTotal.WithLabelValues(task.Name()))
+
+	calls := 0
+
+	task.Supervise(discardLogger(), make(chan struct{}), func() {
+		calls++
+		if calls < 3 {
+			panic("boom")
+		}
+	})
+
+	assert.Equal(t, 3, calls)
+
+	statuses := registry.Snapshot(time.Now())
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StateStopped, statuses[0].State)
+	assert.Equal(t, uint64(2), statuses[0].Restarts)
+	assert.Equal(t, "boom", statuses[0].Panic)
+	assert.InDelta(t, 2, testutil.ToFloat64(taskRestartsTotal.WithLabelValues(task.Name()))-restartsBefore, 0)
+}
+
+func TestSupervise_StopsRestartingWhenDone(t *testing.T) {
+	shortenBackoff(t)
+
+	registry := NewRegistry()
+	task := registry.Register("supervise.done", time.Minute)
+
+	done := make(chan struct{})
+	calls := 0
+
+	task.Supervise(discardLogger(), done, func() {
+		calls++
+
+		close(done)
+		panic("boom")
+	})
+
+	assert.Equal(t, 1, calls)
+
+	statuses := registry.Snapshot(time.Now())
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StatePanicked, statuses[0].State)
+	assert.Zero(t, statuses[0].Restarts)
+}
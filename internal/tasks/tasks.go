@@ -0,0 +1,265 @@
+package tasks
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Task states.
+const (
+	StateIdle     = "idle"
+	StateRunning  = "running"
+	StateStopped  = "stopped"
+	StatePanicked = "panicked"
+)
+
+// defaultRegistry holds every background loop in the process.
+var defaultRegistry = NewRegistry()
+
+// Default returns the process-wide task registry.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Status is a point-in-time view of a background loop.
+type Status struct {
+	Name              string     `json:"name"`
+	Interval          string     `json:"interval,omitempty"` // Empty for event-driven loops
+	State             string     `json:"state"`
+	RegisteredAt      time.Time  `json:"registered_at"`
+	LastTickAt        *time.Time `json:"last_tick_at,omitempty"`
+	NextRunAt         *time.Time `json:"next_run_at,omitempty"`
+	Overdue           bool       `json:"overdue"`
+	LastRunAt         *time.Time `json:"last_run_at,omitempty"`
+	LastRunDurationMs float64    `json:"last_run_duration_ms"`
+	Runs              uint64     `json:"runs"`
+	Restarts          uint64     `json:"restarts"`
+	Failures          uint64     `json:"failures"`
+	LastError         string     `json:"last_error,omitempty"`
+	LastErrorAt       *time.Time `json:"last_error_at,omitempty"`
+	Panic             string     `json:"panic,omitempty"`
+}
+
+// Registry tracks registered background loops.
+type Registry struct {
+	mu    sync.Mutex
+	tasks map[string]*Task
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		tasks: make(map[string]*Task),
+	}
+}
+
+// Register adds a loop to the registry, replacing any task with the same name.
+// interval is the expected time between ticks; zero marks an event-driven loop.
+func (r *Registry) Register(name string, interval time.Duration) *Task {
+	t := &Task{
+		name:         name,
+		interval:     interval,
+		state:        StateIdle,
+		registeredAt: time.Now(),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tasks[name] = t
+
+	return t
+}
+
+// Unregister removes t from the registry. A newer task registered under the
+// same name is left in place.
+func (r *Registry) Unregister(t *Task) {
+	if t == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tasks[t.name] == t {
+		delete(r.tasks, t.name)
+	}
+}
+
+// Snapshot returns the status of every registered task, sorted by name.
+func (r *Registry) Snapshot(now time.Time) []Status {
+	r.mu.Lock()
+
+	tasks := make([]*Task, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		tasks = append(tasks, t)
+	}
+
+	r.mu.Unlock()
+
+	statuses := make([]Status, 0, len(tasks))
+	for _, t := range tasks {
+		statuses = append(statuses, t.status(now))
+	}
+
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return statuses
+}
+
+// Task records the activity of a single background loop.
+// A nil *Task is valid and records nothing.
+type Task struct {
+	name         string
+	interval     time.Duration
+	registeredAt time.Time
+
+	mu              sync.Mutex
+	state           string
+	lastTick        time.Time
+	lastRun         time.Time
+	lastRunDuration time.Duration
+	runs            uint64
+	restarts        uint64
+	failures        uint64
+	lastError       string
+	lastErrorAt     time.Time
+	panicValue      string
+}
+
+// Name returns the task name, or "unknown" for a nil task.
+func (t *Task) Name() string {
+	if t == nil {
+		return "unknown"
+	}
+
+	return t.name
+}
+
+// Tick records a loop iteration, whether or not it did any work
+// (e.g. a follower skipping a leader-only refresh).
+func (t *Task) Tick() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lastTick = time.Now()
+}
+
+// Run ticks and runs fn, recording its duration and error.
+func (t *Task) Run(fn func() error) error {
+	if t == nil {
+		return fn()
+	}
+
+	start := time.Now()
+
+	t.mu.Lock()
+	t.lastTick = start
+	t.state = StateRunning
+	t.mu.Unlock()
+
+	err := fn()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state = StateIdle
+	t.lastRun = start
+	t.lastRunDuration = time.Since(start)
+	t.runs++
+
+	if err != nil {
+		t.failures++
+		t.lastError = err.Error()
+		t.lastErrorAt = time.Now()
+	}
+
+	return err
+}
+
+// Exit marks the loop as finished. Pass the recovered panic value, if any,
+// from the loop's deferred recover.
+func (t *Task) Exit(rec any) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if rec != nil {
+		t.state = StatePanicked
+		t.panicValue = fmt.Sprint(rec)
+
+		return
+	}
+
+	t.state = StateStopped
+}
+
+// restart marks a panicked loop as live again.
+func (t *Task) restart() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state = StateIdle
+	t.restarts++
+}
+
+func (t *Task) status(now time.Time) Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := Status{
+		Name:              t.name,
+		State:             t.state,
+		RegisteredAt:      t.registeredAt,
+		LastTickAt:        timePtr(t.lastTick),
+		LastRunAt:         timePtr(t.lastRun),
+		LastRunDurationMs: float64(t.lastRunDuration) / float64(time.Millisecond),
+		Runs:              t.runs,
+		Restarts:          t.restarts,
+		Failures:          t.failures,
+		LastError:         t.lastError,
+		LastErrorAt:       timePtr(t.lastErrorAt),
+		Panic:             t.panicValue,
+	}
+
+	if t.interval > 0 {
+		s.Interval = t.interval.String()
+
+		base := t.lastTick
+		if base.IsZero() {
+			base = t.registeredAt
+		}
+
+		next := base.Add(t.interval)
+		s.NextRunAt = &next
+
+		// A live loop that missed a full extra interval has likely stalled
+		s.Overdue = (t.state == StateIdle || t.state == StateRunning) && now.After(next.Add(t.interval))
+	}
+
+	return s
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
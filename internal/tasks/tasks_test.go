@@ -0,0 +1,83 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_RunRecordsResults(t *testing.T) {
+	registry := NewRegistry()
+	task := registry.Register("bounds.refresh", time.Minute)
+
+	require.NoError(t, task.Run(func() error { return nil }))
+	require.Error(t, task.Run(func() error { return errors.New("upstream down") }))
+
+	statuses := registry.Snapshot(time.Now())
+	require.Len(t, statuses, 1)
+
+	status := statuses[0]
+	assert.Equal(t, "bounds.refresh", status.Name)
+	assert.Equal(t, "1m0s", status.Interval)
+	assert.Equal(t, StateIdle, status.State)
+	assert.Equal(t, uint64(2), status.Runs)
+	assert.Equal(t, uint64(1), status.Failures)
+	assert.Equal(t, "upstream down", status.LastError)
+	require.NotNil(t, status.LastRunAt)
+	require.NotNil(t, status.NextRunAt)
+	assert.Equal(t, status.LastTickAt.Add(time.Minute), *status.NextRunAt)
+	assert.False(t, status.Overdue)
+}
+
+func TestTask_Overdue(t *testing.T) {
+	registry := NewRegistry()
+	task := registry.Register("proxy.sync", time.Minute)
+	task.Tick()
+
+	statuses := registry.Snapshot(time.Now().Add(3 * time.Minute))
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Overdue)
+
+	// Stopped loops are never overdue
+	task.Exit(nil)
+
+	statuses = registry.Snapshot(time.Now().Add(3 * time.Minute))
+	assert.Equal(t, StateStopped, statuses[0].State)
+	assert.False(t, statuses[0].Overdue)
+}
+
+func TestTask_ExitWithPanic(t *testing.T) {
+	registry := NewRegistry()
+	task := registry.Register("frontend.refresh", 0)
+	task.Exit("boom")
+
+	statuses := registry.Snapshot(time.Now())
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StatePanicked, statuses[0].State)
+	assert.Equal(t, "boom", statuses[0].Panic)
+	assert.Empty(t, statuses[0].Interval)
+	assert.Nil(t, statuses[0].NextRunAt)
+}
+
+func TestRegistry_UnregisterKeepsReplacement(t *testing.T) {
+	registry := NewRegistry()
+	first := registry.Register("discovery.example.com", time.Minute)
+	second := registry.Register("discovery.example.com", time.Minute)
+
+	registry.Unregister(first)
+	assert.Len(t, registry.Snapshot(time.Now()), 1)
+
+	registry.Unregister(second)
+	assert.Empty(t, registry.Snapshot(time.Now()))
+}
+
+func TestTask_NilIsNoop(t *testing.T) {
+	var task *Task
+
+	task.Tick()
+	task.Exit(nil)
+	assert.EqualError(t, task.Run(func() error { return errors.New("failed") }), "failed")
+}
@@ -0,0 +1,149 @@
+// Package tenancy selects the lab variant a request is served as. A tenant,
+// picked by the request's Host header or a path prefix, overlays its own
+// networks, features and header policies on the global configuration, so one
+// deployment can serve several labs.
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/headers"
+)
+
+// contextKey stores the tenant of the current request.
+type contextKey struct{}
+
+// Tenant is a lab variant with its overlay on the global configuration.
+type Tenant struct {
+	Name       string
+	PathPrefix string
+	Headers    *headers.Manager // Tenant policies followed by the global ones
+
+	networks map[string]bool // Networks shown, nil for all
+	features []config.FeatureSettings
+}
+
+// ContextWithTenant returns a context carrying t.
+func ContextWithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant stored in ctx, or nil for the default lab.
+func FromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(contextKey{}).(*Tenant)
+
+	return t
+}
+
+// ShowsNetwork reports whether the tenant shows network. The default lab,
+// a nil tenant, shows every network.
+func (t *Tenant) ShowsNetwork(network string) bool {
+	return t == nil || t.networks == nil || t.networks[network]
+}
+
+// Features returns global with the tenant's features overlaid: a tenant
+// feature replaces the global one with the same path, others are added.
+func (t *Tenant) Features(global []config.FeatureSettings) []config.FeatureSettings {
+	if t == nil || len(t.features) == 0 {
+		return global
+	}
+
+	features := make([]config.FeatureSettings, 0, len(global)+len(t.features))
+
+	for _, feature := range global {
+		if !slices.ContainsFunc(t.features, func(f config.FeatureSettings) bool { return f.Path == feature.Path }) {
+			features = append(features, feature)
+		}
+	}
+
+	return append(features, t.features...)
+}
+
+// Resolver picks the tenant of a request.
+type Resolver struct {
+	tenants  []*Tenant
+	byHost   map[string]*Tenant
+	prefixed []*Tenant // Tenants with a path prefix, longest prefix first
+}
+
+// NewResolver compiles the configured tenants. Each tenant's header policies
+// are evaluated before the global policies.
+func NewResolver(tenants []config.TenantConfig, policies []config.HeaderPolicy) (*Resolver, error) {
+	r := &Resolver{byHost: make(map[string]*Tenant)}
+
+	for _, cfg := range tenants {
+		manager, err := headers.NewManager(append(slices.Clone(cfg.Headers), policies...))
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", cfg.Name, err)
+		}
+
+		t := &Tenant{
+			Name:       cfg.Name,
+			PathPrefix: cfg.PathPrefix,
+			Headers:    manager,
+			features:   cfg.Features,
+		}
+
+		if len(cfg.Networks) > 0 {
+			t.networks = make(map[string]bool, len(cfg.Networks))
+			for _, network := range cfg.Networks {
+				t.networks[network] = true
+			}
+		}
+
+		r.tenants = append(r.tenants, t)
+
+		for _, host := range cfg.Hosts {
+			r.byHost[strings.ToLower(host)] = t
+		}
+
+		if t.PathPrefix != "" {
+			r.prefixed = append(r.prefixed, t)
+		}
+	}
+
+	slices.SortFunc(r.prefixed, func(a, b *Tenant) int { return len(b.PathPrefix) - len(a.PathPrefix) })
+
+	return r, nil
+}
+
+// Tenants returns every configured tenant. It is nil-safe.
+func (r *Resolver) Tenants() []*Tenant {
+	if r == nil {
+		return nil
+	}
+
+	return r.tenants
+}
+
+// Resolve returns the tenant of req and the path with its prefix removed,
+// nil and the path as it is for the default lab. A path prefix wins over
+// the Host header.
+func (r *Resolver) Resolve(req *http.Request) (*Tenant, string) {
+	if r == nil {
+		return nil, req.URL.Path
+	}
+
+	for _, t := range r.prefixed {
+		if rest, ok := strings.CutPrefix(req.URL.Path, t.PathPrefix); ok && (rest == "" || rest[0] == '/') {
+			if rest == "" {
+				rest = "/"
+			}
+
+			return t, rest
+		}
+	}
+
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return r.byHost[strings.ToLower(host)], req.URL.Path
+}
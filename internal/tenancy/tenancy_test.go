@@ -0,0 +1,100 @@
+package tenancy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	r, err := NewResolver([]config.TenantConfig{
+		{Name: "acme", Hosts: []string{"lab.acme.io"}},
+		{Name: "devs", PathPrefix: "/devs"},
+		{Name: "devs-eu", PathPrefix: "/devs/eu"},
+	}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		host   string
+		path   string
+		tenant string
+		rest   string
+	}{
+		{name: "host", host: "lab.acme.io", path: "/api/v1/config", tenant: "acme", rest: "/api/v1/config"},
+		{name: "host with port and case", host: "LAB.acme.io:8080", path: "/", tenant: "acme", rest: "/"},
+		{name: "path prefix", host: "lab.ethpandaops.io", path: "/devs/api/v1/config", tenant: "devs", rest: "/api/v1/config"},
+		{name: "bare path prefix", host: "lab.ethpandaops.io", path: "/devs", tenant: "devs", rest: "/"},
+		{name: "longest prefix", host: "lab.ethpandaops.io", path: "/devs/eu/ethereum", tenant: "devs-eu", rest: "/ethereum"},
+		{name: "prefix wins over host", host: "lab.acme.io", path: "/devs/", tenant: "devs", rest: "/"},
+		{name: "segment boundary", host: "lab.ethpandaops.io", path: "/devsite", rest: "/devsite"},
+		{name: "default", host: "lab.ethpandaops.io", path: "/api/v1/config", rest: "/api/v1/config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			req.Host = tt.host
+
+			tenant, rest := r.Resolve(req)
+			assert.Equal(t, tt.rest, rest)
+
+			if tt.tenant == "" {
+				assert.Nil(t, tenant)
+			} else {
+				require.NotNil(t, tenant)
+				assert.Equal(t, tt.tenant, tenant.Name)
+			}
+		})
+	}
+
+	// Without tenants every request is served as the default lab
+	var none *Resolver
+
+	tenant, rest := none.Resolve(httptest.NewRequest(http.MethodGet, "/devs", http.NoBody))
+	assert.Nil(t, tenant)
+	assert.Equal(t, "/devs", rest)
+}
+
+func TestTenant_Overlay(t *testing.T) {
+	r, err := NewResolver([]config.TenantConfig{{
+		Name:     "acme",
+		Hosts:    []string{"lab.acme.io"},
+		Networks: []string{"mainnet"},
+		Features: []config.FeatureSettings{
+			{Path: "/ethereum/epochs", DisabledNetworks: []string{"mainnet"}},
+			{Path: "/acme/dashboard"},
+		},
+		Headers: []config.HeaderPolicy{
+			{Name: "acme_html", PathPattern: `^/$`, Headers: map[string]string{"Cache-Control": "no-store"}},
+		},
+	}}, []config.HeaderPolicy{
+		{Name: "default", PathPattern: ".*", Headers: map[string]string{"Cache-Control": "public, max-age=1"}},
+	})
+	require.NoError(t, err)
+
+	tenant := r.Tenants()[0]
+
+	assert.True(t, tenant.ShowsNetwork("mainnet"))
+	assert.False(t, tenant.ShowsNetwork("sepolia"))
+
+	var defaultLab *Tenant
+	assert.True(t, defaultLab.ShowsNetwork("sepolia"))
+
+	global := []config.FeatureSettings{{Path: "/ethereum/epochs"}, {Path: "/ethereum/slots"}}
+	assert.Equal(t, global, defaultLab.Features(global))
+	assert.Equal(t, []config.FeatureSettings{
+		{Path: "/ethereum/slots"},
+		{Path: "/ethereum/epochs", DisabledNetworks: []string{"mainnet"}},
+		{Path: "/acme/dashboard"},
+	}, tenant.Features(global))
+
+	// Tenant policies come before the global ones
+	assert.Equal(t, "no-store", tenant.Headers.Match("/")["Cache-Control"])
+	assert.Equal(t, "public, max-age=1", tenant.Headers.Match("/ethereum")["Cache-Control"])
+}
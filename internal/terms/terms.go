@@ -0,0 +1,168 @@
+package terms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+const (
+	// CookieName is the cookie carrying a signed acknowledgment token.
+	CookieName = "lab_terms"
+
+	// HeaderName carries a signed acknowledgment token for programmatic clients.
+	HeaderName = "X-Lab-Terms-Token"
+)
+
+// compiledClass is an endpoint class with a compiled path pattern.
+type compiledClass struct {
+	name    string
+	pattern *regexp.Regexp
+	methods []string
+}
+
+// Manager matches requests to gated endpoint classes and issues and verifies
+// signed acknowledgment tokens for the current terms version.
+type Manager struct {
+	version string
+	url     string
+	secret  []byte
+	ttl     time.Duration
+	classes []compiledClass
+}
+
+// NewManager creates a Manager from the terms configuration.
+// Returns an error if any path_pattern is an invalid regex.
+func NewManager(cfg config.TermsConfig) (*Manager, error) {
+	classes := make([]compiledClass, 0, len(cfg.Classes))
+
+	for _, class := range cfg.Classes {
+		pattern, err := regexp.Compile(class.PathPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path_pattern in class %q: %w", class.Name, err)
+		}
+
+		methods := make([]string, 0, len(class.Methods))
+		for _, method := range class.Methods {
+			methods = append(methods, strings.ToUpper(method))
+		}
+
+		classes = append(classes, compiledClass{
+			name:    class.Name,
+			pattern: pattern,
+			methods: methods,
+		})
+	}
+
+	return &Manager{
+		version: cfg.Version,
+		url:     cfg.URL,
+		secret:  []byte(cfg.Secret),
+		ttl:     cfg.TTL,
+		classes: classes,
+	}, nil
+}
+
+// Version returns the current terms version.
+func (m *Manager) Version() string {
+	return m.version
+}
+
+// URL returns where the terms can be read.
+func (m *Manager) URL() string {
+	return m.url
+}
+
+// Classes returns the names of all gated endpoint classes.
+func (m *Manager) Classes() []string {
+	names := make([]string, 0, len(m.classes))
+	for _, class := range m.classes {
+		names = append(names, class.name)
+	}
+
+	return names
+}
+
+// Match returns the endpoint class gating the request.
+// Returns false if the request is not gated. First match wins.
+func (m *Manager) Match(r *http.Request) (string, bool) {
+	for _, class := range m.classes {
+		if len(class.methods) > 0 && !slices.Contains(class.methods, r.Method) {
+			continue
+		}
+
+		if class.pattern.MatchString(r.URL.Path) {
+			return class.name, true
+		}
+	}
+
+	return "", false
+}
+
+// Issue returns a signed acknowledgment token for the current terms version.
+func (m *Manager) Issue(now time.Time) (string, time.Time) {
+	expiresAt := now.Add(m.ttl).Truncate(time.Second)
+	payload := m.version + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + m.sign(payload), expiresAt
+}
+
+// Verify reports whether token is a valid, unexpired acknowledgment of the
+// current terms version.
+func (m *Manager) Verify(token string, now time.Time) bool {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+
+	payload := string(raw)
+	if !hmac.Equal([]byte(signature), []byte(m.sign(payload))) {
+		return false
+	}
+
+	version, expiry, ok := strings.Cut(payload, "|")
+	if !ok || version != m.version {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return now.Unix() < expiresAt
+}
+
+// Accepted reports whether the request carries a valid acknowledgment token,
+// either in the terms cookie or the terms header.
+func (m *Manager) Accepted(r *http.Request, now time.Time) bool {
+	if token := r.Header.Get(HeaderName); token != "" && m.Verify(token, now) {
+		return true
+	}
+
+	cookie, err := r.Cookie(CookieName)
+
+	return err == nil && m.Verify(cookie.Value, now)
+}
+
+// sign returns the base64 HMAC-SHA256 signature of payload.
+func (m *Manager) sign(payload string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,102 @@
+package terms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+)
+
+func testTermsConfig() config.TermsConfig {
+	return config.TermsConfig{
+		Enabled: true,
+		Version: "2025-01",
+		Secret:  "0123456789abcdef0123456789abcdef",
+		TTL:     time.Hour,
+		Classes: []config.TermsEndpointClass{
+			{Name: "simulations", PathPattern: "^/api/v1/gas-profiler/[^/]+/simulate-", Methods: []string{"post"}},
+			{Name: "exports", PathPattern: "/export$"},
+		},
+	}
+}
+
+func TestManager_Match(t *testing.T) {
+	manager, err := NewManager(testTermsConfig())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		class    string
+		expected bool
+	}{
+		{name: "simulation POST", method: http.MethodPost, path: "/api/v1/gas-profiler/mainnet/simulate-block", class: "simulations", expected: true},
+		{name: "simulation GET not gated", method: http.MethodGet, path: "/api/v1/gas-profiler/mainnet/simulate-block", expected: false},
+		{name: "export any method", method: http.MethodGet, path: "/api/v1/mainnet/fct_block/export", class: "exports", expected: true},
+		{name: "ungated path", method: http.MethodGet, path: "/api/v1/mainnet/fct_block", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, ok := manager.Match(httptest.NewRequest(tt.method, tt.path, http.NoBody))
+			assert.Equal(t, tt.expected, ok)
+			assert.Equal(t, tt.class, class)
+		})
+	}
+}
+
+func TestManager_IssueVerify(t *testing.T) {
+	cfg := testTermsConfig()
+
+	manager, err := NewManager(cfg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, expiresAt := manager.Issue(now)
+
+	assert.True(t, manager.Verify(token, now))
+	assert.False(t, manager.Verify(token, expiresAt), "expired")
+	assert.False(t, manager.Verify(token+"x", now), "tampered signature")
+	assert.False(t, manager.Verify("garbage", now))
+
+	// Bumping the version invalidates prior acknowledgments
+	cfg.Version = "2025-02"
+
+	bumped, err := NewManager(cfg)
+	require.NoError(t, err)
+
+	assert.False(t, bumped.Verify(token, now))
+
+	// A different secret never verifies
+	cfg.Version = "2025-01"
+	cfg.Secret = "fedcba9876543210fedcba9876543210"
+
+	other, err := NewManager(cfg)
+	require.NoError(t, err)
+
+	assert.False(t, other.Verify(token, now))
+}
+
+func TestManager_Accepted(t *testing.T) {
+	manager, err := NewManager(testTermsConfig())
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, _ := manager.Issue(now)
+
+	viaHeader := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	viaHeader.Header.Set(HeaderName, token)
+	assert.True(t, manager.Accepted(viaHeader, now))
+
+	viaCookie := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	viaCookie.AddCookie(&http.Cookie{Name: CookieName, Value: token})
+	assert.True(t, manager.Accepted(viaCookie, now))
+
+	assert.False(t, manager.Accepted(httptest.NewRequest(http.MethodGet, "/", http.NoBody), now))
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TraceparentHeader is the W3C Trace Context header.
+const TraceparentHeader = "traceparent"
+
+// traceIDContextKey stores the sampled trace ID of the current request.
+type traceIDContextKey struct{}
+
+// ContextWithTraceID returns a context carrying traceID.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDContextKey{}, traceID)
+}
+
+// TraceIDFromContext returns the trace ID stored in ctx, or "" if none.
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDContextKey{}).(string)
+
+	return traceID
+}
+
+// TraceIDFromHeader returns the trace ID from a W3C traceparent header when the
+// trace is sampled. Unsampled or malformed headers return "".
+func TraceIDFromHeader(h http.Header) string {
+	// Format: version-traceid-parentid-flags, e.g.
+	// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	parts := strings.Split(h.Get(TraceparentHeader), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[3]) != 2 {
+		return ""
+	}
+
+	traceID := parts[1]
+	if !isHex(traceID) || strings.Trim(traceID, "0") == "" {
+		return ""
+	}
+
+	// Only sampled traces make useful exemplars
+	if !isHex(parts[3]) || hexValue(parts[3][1])&0x1 == 0 {
+		return ""
+	}
+
+	return traceID
+}
+
+// Observe records v on obs, attaching traceID as an exemplar when set and supported.
+func Observe(obs prometheus.Observer, v float64, traceID string) {
+	if traceID != "" {
+		if eo, ok := obs.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": traceID})
+
+			return
+		}
+	}
+
+	obs.Observe(v)
+}
+
+func isHex(s string) bool {
+	for i := range len(s) {
+		if hexValue(s[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hexValue returns the value of a lowercase hex digit, or -1.
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceIDFromHeader(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceparent string
+		expected    string
+	}{
+		{
+			name:        "sampled trace",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expected:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:        "unsampled trace",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			expected:    "",
+		},
+		{
+			name:        "all-zero trace ID",
+			traceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			expected:    "",
+		},
+		{
+			name:        "uppercase is invalid",
+			traceparent: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			expected:    "",
+		},
+		{
+			name:        "malformed",
+			traceparent: "not-a-traceparent",
+			expected:    "",
+		},
+		{
+			name:        "missing",
+			traceparent: "",
+			expected:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.traceparent != "" {
+				h.Set(TraceparentHeader, tt.traceparent)
+			}
+
+			assert.Equal(t, tt.expected, TraceIDFromHeader(h))
+		})
+	}
+}
+
+func TestContextWithTraceID(t *testing.T) {
+	assert.Empty(t, TraceIDFromContext(context.Background()))
+
+	ctx := ContextWithTraceID(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceIDFromContext(ctx))
+}
+
+func TestObserve_AttachesExemplar(t *testing.T) {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_duration_seconds",
+		Help:    "test",
+		Buckets: []float64{1},
+	})
+
+	Observe(histogram, 0.5, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	var metric dto.Metric
+	require.NoError(t, histogram.Write(&metric))
+
+	exemplar := metric.GetHistogram().GetBucket()[0].GetExemplar()
+	require.NotNil(t, exemplar)
+	assert.Equal(t, "trace_id", exemplar.GetLabel()[0].GetName())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", exemplar.GetLabel()[0].GetValue())
+
+	// Without a trace ID the observation is plain
+	plain := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_plain_seconds",
+		Help:    "test",
+		Buckets: []float64{1},
+	})
+
+	Observe(plain, 0.5, "")
+
+	require.NoError(t, plain.Write(&metric))
+	assert.Nil(t, metric.GetHistogram().GetBucket()[0].GetExemplar())
+}
@@ -0,0 +1,262 @@
+package upstream
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// coalesceKeyHeaders are the request headers that can change an upstream's
+// response, so requests only share one when these match too.
+var coalesceKeyHeaders = []string{
+	"Accept",
+	"Accept-Encoding",
+	"Authorization",
+	"Cookie",
+	"If-Modified-Since",
+	"If-None-Match",
+	"Range",
+}
+
+var coalescedTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "upstream_coalesced_requests_total",
+		Help: "Total number of outbound requests answered with the response of an identical request already in flight, per upstream host",
+	},
+	[]string{"host"},
+)
+
+// Coalescing configures the sharing of identical in-flight requests. The
+// zero value disables it.
+type Coalescing struct {
+	MaxBodyBytes int64 // Largest response body shared with waiting requests, 0 disables coalescing
+}
+
+// defaultCoalescer is used by transports from Coalesced. Nil until
+// ConfigureCoalescing is called with a body limit, so tests and tools never
+// share responses.
+var defaultCoalescer atomic.Pointer[Coalescer]
+
+// ConfigureCoalescing sets the process-wide coalescing applied by transports
+// from Coalesced. A zero MaxBodyBytes disables coalescing.
+func ConfigureCoalescing(coalescing Coalescing) {
+	if coalescing.MaxBodyBytes <= 0 {
+		defaultCoalescer.Store(nil)
+
+		return
+	}
+
+	defaultCoalescer.Store(NewCoalescer(coalescing))
+}
+
+// Coalescer keeps one outbound request in flight per identical GET or HEAD
+// and hands its response to every request that arrived while it was.
+type Coalescer struct {
+	coalescing Coalescing
+
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is an in-flight request and, once done is closed, its outcome.
+type coalescedCall struct {
+	done chan struct{}
+
+	resp   *http.Response // Copy of the response without its body
+	body   []byte
+	err    error
+	shared bool // False when waiters must send their own request
+}
+
+// NewCoalescer creates a coalescer with the given settings.
+func NewCoalescer(coalescing Coalescing) *Coalescer {
+	return &Coalescer{
+		coalescing: coalescing,
+		calls:      make(map[string]*coalescedCall),
+	}
+}
+
+// Do sends req through base, unless an identical request is already in
+// flight, in which case it waits for that request's response. Requests with
+// a body or upgrading the connection are always sent as is. A request that
+// fails because its own client left, or whose response body is too large to
+// share, makes its waiters send their own requests.
+func (c *Coalescer) Do(base http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if !coalescable(req) {
+		return base.RoundTrip(req)
+	}
+
+	key := coalesceKey(req)
+
+	c.mu.Lock()
+
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+
+		return c.wait(base, req, call)
+	}
+
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	return c.send(base, req, call)
+}
+
+// send makes the shared request and records its outcome in call.
+func (c *Coalescer) send(base http.RoundTripper, req *http.Request, call *coalescedCall) (*http.Response, error) {
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		// Waiters whose clients are still there try again themselves
+		call.err = err
+		call.shared = req.Context().Err() == nil
+
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.coalescing.MaxBodyBytes+1))
+
+	// Too large or broken off: the leader streams the rest, waiters fetch their own
+	if err != nil || int64(len(body)) > c.coalescing.MaxBodyBytes {
+		resp.Body = &prefixedBody{
+			Reader: io.MultiReader(bytes.NewReader(body), &tailReader{err: err, rest: resp.Body}),
+			Closer: resp.Body,
+		}
+
+		return resp, nil
+	}
+
+	resp.Body.Close()
+
+	shared := *resp
+	shared.Header = resp.Header.Clone()
+	shared.Trailer = resp.Trailer.Clone()
+	shared.Body = nil
+
+	call.resp = &shared
+	call.body = body
+	call.shared = true
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// wait returns the response of call for req, sending req itself when call's
+// response can't be shared.
+func (c *Coalescer) wait(base http.RoundTripper, req *http.Request, call *coalescedCall) (*http.Response, error) {
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-call.done:
+	}
+
+	if !call.shared {
+		return base.RoundTrip(req)
+	}
+
+	coalescedTotal.WithLabelValues(req.URL.Host).Inc()
+
+	if call.err != nil {
+		return nil, call.err
+	}
+
+	resp := *call.resp
+	resp.Header = call.resp.Header.Clone()
+	resp.Trailer = call.resp.Trailer.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(call.body))
+	resp.Request = req
+
+	return &resp, nil
+}
+
+// coalescable reports whether req may share a response with identical requests.
+func coalescable(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		return false
+	}
+
+	return req.Header.Get("Upgrade") == ""
+}
+
+// coalesceKey identifies requests that get the same response.
+func coalesceKey(req *http.Request) string {
+	var key strings.Builder
+
+	key.WriteString(req.Method)
+	key.WriteByte(' ')
+	key.WriteString(req.URL.String())
+
+	for _, name := range coalesceKeyHeaders {
+		key.WriteByte('\n')
+		key.WriteString(strings.Join(req.Header.Values(name), ","))
+	}
+
+	return key.String()
+}
+
+// prefixedBody is a response body of which a prefix was already read.
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// tailReader reads the rest of a body, or fails with err when reading it
+// already failed.
+type tailReader struct {
+	err  error
+	rest io.Reader
+}
+
+func (r *tailReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	return r.rest.Read(p)
+}
+
+// coalescedTransport shares responses between identical in-flight requests
+// with the coalescer set by ConfigureCoalescing.
+type coalescedTransport struct {
+	base http.RoundTripper
+}
+
+// Coalesced wraps base so identical GET and HEAD requests in flight at the
+// same time are sent upstream once. A nil base uses http.DefaultTransport.
+func Coalesced(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &coalescedTransport{base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *coalescedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	coalescer := defaultCoalescer.Load()
+	if coalescer == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	return coalescer.Do(t.base, req)
+}
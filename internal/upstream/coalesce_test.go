@@ -0,0 +1,112 @@
+package upstream
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTransport answers every request with body once release is closed.
+type blockingTransport struct {
+	body    string
+	release chan struct{}
+	calls   atomic.Int64
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls.Add(1)
+	<-t.release
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+// sendConcurrently sends n requests built by newReq through coalescer and
+// returns their bodies once base is released.
+func sendConcurrently(t *testing.T, coalescer *Coalescer, base *blockingTransport, n int, newReq func() *http.Request) []string {
+	t.Helper()
+
+	bodies := make([]string, n)
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Go(func() {
+			resp, err := coalescer.Do(base, newReq())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			defer resp.Body.Close()
+
+			// Callers may change their own response
+			resp.Header.Set("X-Caller", "done")
+
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+		})
+	}
+
+	// Let every request reach the coalescer before the first response
+	require.Eventually(t, func() bool { return base.calls.Load() >= 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(base.release)
+
+	wg.Wait()
+
+	return bodies
+}
+
+func TestCoalescer_SharesInFlightResponse(t *testing.T) {
+	coalescer := NewCoalescer(Coalescing{MaxBodyBytes: 1 << 10})
+	base := &blockingTransport{body: `{"ok":true}`, release: make(chan struct{})}
+
+	bodies := sendConcurrently(t, coalescer, base, 5, func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, "http://cbt-api/api/v1/fct_block", nil)
+	})
+
+	assert.Equal(t, int64(1), base.calls.Load())
+
+	for _, body := range bodies {
+		assert.JSONEq(t, `{"ok":true}`, body)
+	}
+
+	// Requests that differ in their credentials are sent on their own
+	base = &blockingTransport{body: `{}`, release: make(chan struct{})}
+
+	var n atomic.Int64
+
+	sendConcurrently(t, coalescer, base, 3, func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "http://cbt-api/api/v1/fct_block", nil)
+		req.Header.Set("Authorization", "Bearer "+strings.Repeat("x", int(n.Add(1))))
+
+		return req
+	})
+
+	assert.Equal(t, int64(3), base.calls.Load())
+}
+
+func TestCoalescer_LargeBodiesNotShared(t *testing.T) {
+	coalescer := NewCoalescer(Coalescing{MaxBodyBytes: 4})
+	base := &blockingTransport{body: "too large", release: make(chan struct{})}
+
+	bodies := sendConcurrently(t, coalescer, base, 3, func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, "http://cbt-api/api/v1/fct_block", nil)
+	})
+
+	// Every request gets the whole body, waiters from their own request
+	assert.Equal(t, int64(3), base.calls.Load())
+	assert.Equal(t, []string{"too large", "too large", "too large"}, bodies)
+}
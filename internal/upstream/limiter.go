@@ -0,0 +1,234 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+)
+
+// ErrOverloaded is returned by limited transports when an upstream host has
+// no free request slot and its queue is full or the wait timed out.
+var ErrOverloaded = errors.New("upstream overloaded")
+
+// Reasons a request is rejected by the limiter.
+const (
+	rejectQueueFull    = "queue_full"
+	rejectQueueTimeout = "queue_timeout"
+)
+
+var (
+	limiterInFlight = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_limiter_in_flight",
+			Help: "Number of outbound requests holding a concurrency slot, per upstream host",
+		},
+		[]string{"host"},
+	)
+
+	limiterQueued = metrics.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_limiter_queued",
+			Help: "Number of outbound requests waiting for a concurrency slot, per upstream host",
+		},
+		[]string{"host"},
+	)
+
+	limiterRejectedTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "upstream_limiter_rejected_total",
+			Help: "Total number of outbound requests rejected by the concurrency limiter, by reason (queue_full or queue_timeout)",
+		},
+		[]string{"host", "reason"},
+	)
+)
+
+// Limits bound the concurrent outbound requests per upstream host. The zero
+// value disables limiting.
+type Limits struct {
+	MaxConcurrent int            // Requests in flight per host, 0 disables limiting
+	MaxQueue      int            // Requests waiting for a slot per host
+	QueueTimeout  time.Duration  // Longest wait for a slot
+	Hosts         map[string]int // MaxConcurrent overrides per host
+}
+
+// defaultLimiter is used by transports from Limited. Nil until ConfigureLimits
+// is called with limits, so tests and tools never queue.
+var defaultLimiter atomic.Pointer[Limiter]
+
+// ConfigureLimits sets the process-wide limits applied by transports from
+// Limited. Zero limits disable limiting.
+func ConfigureLimits(limits Limits) {
+	if limits.MaxConcurrent <= 0 {
+		defaultLimiter.Store(nil)
+
+		return
+	}
+
+	defaultLimiter.Store(NewLimiter(limits))
+}
+
+// Limiter holds a request slot semaphore and wait queue per upstream host.
+type Limiter struct {
+	limits Limits
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// hostLimiter is the semaphore and queue of one upstream host.
+type hostLimiter struct {
+	slots  chan struct{}
+	queued atomic.Int64
+}
+
+// NewLimiter creates a limiter with the given limits.
+func NewLimiter(limits Limits) *Limiter {
+	return &Limiter{
+		limits: limits,
+		hosts:  make(map[string]*hostLimiter),
+	}
+}
+
+// Acquire takes a request slot for host, waiting in its queue while all slots
+// are taken. The returned release function frees the slot and is safe to call
+// more than once. Returns ErrOverloaded when the queue is full or the wait
+// times out, and the context's error when it ends first.
+func (l *Limiter) Acquire(ctx context.Context, host string) (func(), error) {
+	h := l.host(host)
+
+	select {
+	case h.slots <- struct{}{}:
+		return l.releaser(h, host), nil
+	default:
+	}
+
+	if h.queued.Add(1) > int64(l.limits.MaxQueue) {
+		h.queued.Add(-1)
+		limiterRejectedTotal.WithLabelValues(host, rejectQueueFull).Inc()
+
+		return nil, fmt.Errorf("%s: %d requests queued: %w", host, l.limits.MaxQueue, ErrOverloaded)
+	}
+
+	limiterQueued.WithLabelValues(host).Inc()
+
+	defer func() {
+		h.queued.Add(-1)
+		limiterQueued.WithLabelValues(host).Dec()
+	}()
+
+	timer := time.NewTimer(l.limits.QueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case h.slots <- struct{}{}:
+		return l.releaser(h, host), nil
+	case <-timer.C:
+		limiterRejectedTotal.WithLabelValues(host, rejectQueueTimeout).Inc()
+
+		return nil, fmt.Errorf("%s: no free slot within %v: %w", host, l.limits.QueueTimeout, ErrOverloaded)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// host returns the limiter of host, creating it on first use.
+func (l *Limiter) host(host string) *hostLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hosts[host]
+	if !ok {
+		size := l.limits.MaxConcurrent
+		if override, ok := l.limits.Hosts[host]; ok {
+			size = override
+		}
+
+		h = &hostLimiter{slots: make(chan struct{}, size)}
+		l.hosts[host] = h
+	}
+
+	return h
+}
+
+// releaser counts a taken slot and returns the function that frees it.
+func (l *Limiter) releaser(h *hostLimiter, host string) func() {
+	limiterInFlight.WithLabelValues(host).Inc()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			<-h.slots
+			limiterInFlight.WithLabelValues(host).Dec()
+		})
+	}
+}
+
+// limitedTransport holds a slot of the default limiter for every request
+// until its response body is closed.
+type limitedTransport struct {
+	base http.RoundTripper
+}
+
+// Limited wraps base so requests wait for a slot of their upstream host under
+// the limits set by ConfigureLimits. A nil base uses http.DefaultTransport.
+func Limited(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &limitedTransport{base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := defaultLimiter.Load()
+	if limiter == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	release, err := limiter.Acquire(req.Context(), req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	// Upgraded connections are long-lived and need the body as is
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		release()
+
+		return resp, nil
+	}
+
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releaseOnCloseBody frees its request slot once the body is closed.
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+
+	return err
+}
@@ -0,0 +1,88 @@
+package upstream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Acquire(t *testing.T) {
+	limiter := NewLimiter(Limits{
+		MaxConcurrent: 1,
+		MaxQueue:      1,
+		QueueTimeout:  50 * time.Millisecond,
+		Hosts:         map[string]int{"big:8080": 2},
+	})
+
+	ctx := context.Background()
+
+	release, err := limiter.Acquire(ctx, "small")
+	require.NoError(t, err)
+
+	// Waits in the queue, then gives up
+	_, err = limiter.Acquire(ctx, "small")
+	require.ErrorIs(t, err, ErrOverloaded)
+
+	// Rejected right away while the queue is full
+	queued := make(chan error, 1)
+
+	go func() {
+		release, err := limiter.Acquire(ctx, "small")
+		if err == nil {
+			release()
+		}
+
+		queued <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		return limiter.host("small").queued.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	_, err = limiter.Acquire(ctx, "small")
+	require.ErrorIs(t, err, ErrOverloaded)
+
+	// The queued request gets the freed slot
+	release()
+	release() // No-op
+
+	require.NoError(t, <-queued)
+
+	// Hosts have their own slots
+	for range 2 {
+		_, err = limiter.Acquire(ctx, "big:8080")
+		require.NoError(t, err)
+	}
+}
+
+func TestLimited_ReleasesOnBodyClose(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	ConfigureLimits(Limits{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: 50 * time.Millisecond})
+	defer ConfigureLimits(Limits{})
+
+	client := &http.Client{Transport: Limited(nil)}
+
+	resp, err := client.Get(backend.URL)
+	require.NoError(t, err)
+
+	// The slot is held until the body is closed
+	_, err = client.Get(backend.URL) //nolint:bodyclose // no response on error
+	require.ErrorIs(t, err, ErrOverloaded)
+
+	resp.Body.Close()
+
+	resp, err = client.Get(backend.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
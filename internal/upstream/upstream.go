@@ -0,0 +1,297 @@
+package upstream
+
+import (
+	"cmp"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tracing"
+)
+
+// Subsystem names used to attribute outbound requests.
+const (
+	SubsystemProxy         = "proxy"
+	SubsystemBounds        = "bounds"
+	SubsystemCartographoor = "cartographoor"
+	SubsystemGasProfiler   = "gas_profiler"
+	SubsystemFrontend      = "frontend"
+	SubsystemSummary       = "summary"
+)
+
+var (
+	upstreamRequestsTotal = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "upstream_requests_total",
+			Help: "Total number of outbound requests by subsystem, upstream host and status",
+		},
+		[]string{"subsystem", "host", "status"},
+	)
+
+	upstreamRequestDuration = metrics.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "upstream_request_duration_seconds",
+			Help:    "Outbound request duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"subsystem", "host"},
+	)
+)
+
+// defaultTracker records every request sent through a transport from NewTransport.
+var defaultTracker = NewTracker()
+
+// HostStats is a point-in-time summary of outbound traffic to one upstream host
+// from one subsystem.
+type HostStats struct {
+	Subsystem     string           `json:"subsystem"`
+	Host          string           `json:"host"`
+	Requests      uint64           `json:"requests"`
+	Errors        uint64           `json:"errors"`
+	InFlight      int64            `json:"in_flight"`
+	StatusCodes   map[string]int64 `json:"status_codes"`
+	AvgLatencyMs  float64          `json:"avg_latency_ms"`
+	MaxLatencyMs  float64          `json:"max_latency_ms"`
+	LastRequestAt time.Time        `json:"last_request_at"`
+}
+
+// Observation describes a single completed outbound request.
+type Observation struct {
+	Subsystem  string
+	Host       string
+	StatusCode int   // Zero when the request failed without a response
+	Err        error // Transport error, if any
+	Duration   time.Duration
+	Time       time.Time
+}
+
+// Observer is notified of every completed outbound request.
+type Observer func(Observation)
+
+// hostKey identifies a tracked (subsystem, host) pair.
+type hostKey struct {
+	subsystem string
+	host      string
+}
+
+// hostCounters accumulates stats for one (subsystem, host) pair.
+type hostCounters struct {
+	requests     uint64
+	completed    uint64
+	errors       uint64
+	inFlight     int64
+	statusCodes  map[string]int64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+	lastRequest  time.Time
+}
+
+// Tracker accumulates outbound request counts and latencies per upstream host.
+type Tracker struct {
+	mu        sync.Mutex
+	started   time.Time
+	hosts     map[hostKey]*hostCounters
+	observers []Observer
+}
+
+// NewTracker creates an empty tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		started: time.Now(),
+		hosts:   make(map[hostKey]*hostCounters),
+	}
+}
+
+// Default returns the process-wide tracker used by NewTransport.
+func Default() *Tracker {
+	return defaultTracker
+}
+
+// AddObserver registers fn to be called after every completed request.
+// Observers run synchronously on the request path and must be cheap.
+func (t *Tracker) AddObserver(fn Observer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.observers = append(t.observers, fn)
+}
+
+// Since returns when the tracker started recording.
+func (t *Tracker) Since() time.Time {
+	return t.started
+}
+
+// Snapshot returns stats for every tracked upstream, sorted by subsystem then host.
+func (t *Tracker) Snapshot() []HostStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]HostStats, 0, len(t.hosts))
+
+	for key, c := range t.hosts {
+		s := HostStats{
+			Subsystem:     key.subsystem,
+			Host:          key.host,
+			Requests:      c.requests,
+			Errors:        c.errors,
+			InFlight:      c.inFlight,
+			StatusCodes:   maps.Clone(c.statusCodes),
+			MaxLatencyMs:  durationMs(c.maxLatency),
+			LastRequestAt: c.lastRequest,
+		}
+
+		if c.completed > 0 {
+			s.AvgLatencyMs = durationMs(c.totalLatency) / float64(c.completed)
+		}
+
+		stats = append(stats, s)
+	}
+
+	slices.SortFunc(stats, func(a, b HostStats) int {
+		return cmp.Or(
+			cmp.Compare(a.Subsystem, b.Subsystem),
+			cmp.Compare(a.Host, b.Host),
+		)
+	})
+
+	return stats
+}
+
+// begin records the start of a request.
+func (t *Tracker) begin(key hostKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.hosts[key]
+	if !ok {
+		c = &hostCounters{statusCodes: make(map[string]int64)}
+		t.hosts[key] = c
+	}
+
+	c.requests++
+	c.inFlight++
+	c.lastRequest = time.Now()
+}
+
+// end records the outcome of a request started with begin and notifies observers.
+// status is empty when the request failed without a response.
+func (t *Tracker) end(key hostKey, status string, obs Observation) {
+	t.mu.Lock()
+
+	c := t.hosts[key]
+	c.inFlight--
+	c.completed++
+	c.totalLatency += obs.Duration
+	c.maxLatency = max(c.maxLatency, obs.Duration)
+
+	if status == "" {
+		c.errors++
+	} else {
+		c.statusCodes[status]++
+	}
+
+	observers := t.observers
+	t.mu.Unlock()
+
+	for _, fn := range observers {
+		fn(obs)
+	}
+}
+
+// transport is an http.RoundTripper that records outbound requests in a Tracker.
+type transport struct {
+	base      http.RoundTripper
+	subsystem string
+	tracker   *Tracker
+}
+
+// NewTransport wraps base so every request is recorded against subsystem in the
+// default tracker. A nil base uses http.DefaultTransport.
+func NewTransport(subsystem string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{
+		base:      base,
+		subsystem: subsystem,
+		tracker:   defaultTracker,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := hostKey{subsystem: t.subsystem, host: req.URL.Host}
+	start := time.Now()
+
+	t.tracker.begin(key)
+
+	ctx, span := tracing.StartSpan(
+		req.Context(),
+		req.Method+" "+key.host,
+		trace.SpanKindClient,
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", key.host),
+		attribute.String("url.path", req.URL.Path),
+		attribute.String("subsystem", t.subsystem),
+	)
+
+	// RoundTrippers must not modify the caller's request
+	if span.SpanContext().IsValid() {
+		req = req.Clone(ctx)
+		tracing.Inject(ctx, req.Header)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+
+	tracing.EndSpan(span, err)
+
+	obs := Observation{
+		Subsystem: t.subsystem,
+		Host:      key.host,
+		Err:       err,
+		Duration:  elapsed,
+		Time:      start,
+	}
+
+	status := "error"
+	if err == nil {
+		obs.StatusCode = resp.StatusCode
+		status = strconv.Itoa(resp.StatusCode)
+		t.tracker.end(key, status, obs)
+	} else {
+		t.tracker.end(key, "", obs)
+	}
+
+	upstreamRequestsTotal.WithLabelValues(t.subsystem, key.host, status).Inc()
+	tracing.Observe(
+		upstreamRequestDuration.WithLabelValues(t.subsystem, key.host),
+		elapsed.Seconds(),
+		tracing.TraceIDFromContext(req.Context()),
+	)
+
+	return resp, err
+}
+
+// durationMs converts a duration to fractional milliseconds.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,90 @@
+package upstream
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_RecordsPerHost(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	tracker := NewTracker()
+	client := &http.Client{
+		Transport: &transport{base: http.DefaultTransport, subsystem: SubsystemBounds, tracker: tracker},
+	}
+
+	for _, path := range []string{"/ok", "/ok", "/missing"} {
+		resp, err := client.Get(backend.URL + path)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+	}
+
+	stats := tracker.Snapshot()
+	require.Len(t, stats, 1)
+
+	assert.Equal(t, SubsystemBounds, stats[0].Subsystem)
+	assert.Equal(t, backendURL.Host, stats[0].Host)
+	assert.Equal(t, uint64(3), stats[0].Requests)
+	assert.Equal(t, uint64(0), stats[0].Errors)
+	assert.Equal(t, int64(0), stats[0].InFlight)
+	assert.Equal(t, map[string]int64{"200": 2, "404": 1}, stats[0].StatusCodes)
+	assert.False(t, stats[0].LastRequestAt.IsZero())
+}
+
+type failingRoundTripper struct{}
+
+func (failingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTransport_RecordsErrorsAndSorts(t *testing.T) {
+	tracker := NewTracker()
+
+	proxyClient := &http.Client{
+		Transport: &transport{base: failingRoundTripper{}, subsystem: SubsystemProxy, tracker: tracker},
+	}
+	cartoClient := &http.Client{
+		Transport: &transport{base: failingRoundTripper{}, subsystem: SubsystemCartographoor, tracker: tracker},
+	}
+
+	_, err := proxyClient.Get("http://cbt-b.example.com/api/v1/x")
+	require.Error(t, err)
+
+	_, err = proxyClient.Get("http://cbt-a.example.com/api/v1/x")
+	require.Error(t, err)
+
+	_, err = cartoClient.Get("http://cartographoor.example.com/networks.json")
+	require.Error(t, err)
+
+	stats := tracker.Snapshot()
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, SubsystemCartographoor, stats[0].Subsystem)
+	assert.Equal(t, "cbt-a.example.com", stats[1].Host)
+	assert.Equal(t, "cbt-b.example.com", stats[2].Host)
+
+	for _, s := range stats {
+		assert.Equal(t, uint64(1), s.Requests)
+		assert.Equal(t, uint64(1), s.Errors)
+		assert.Empty(t, s.StatusCodes)
+	}
+}
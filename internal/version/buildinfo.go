@@ -0,0 +1,92 @@
+//nolint:tagliatelle // superior snake-case yo.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+)
+
+// BuildInfo describes how the running binary was built, including every
+// dependency module linked into it.
+type BuildInfo struct {
+	Version      Info              `json:"version"`
+	GoVersion    string            `json:"go_version"`
+	Path         string            `json:"path"`
+	Main         Module            `json:"main"`
+	VCS          VCSInfo           `json:"vcs"`
+	Settings     map[string]string `json:"settings"`
+	Dependencies []Module          `json:"dependencies"`
+}
+
+// Module is a Go module linked into the binary.
+type Module struct {
+	Path    string  `json:"path"`
+	Version string  `json:"version"`
+	Sum     string  `json:"sum,omitempty"`
+	Replace *Module `json:"replace,omitempty"`
+}
+
+// VCSInfo holds version control metadata stamped by the Go toolchain.
+type VCSInfo struct {
+	System   string `json:"system,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Time     string `json:"time,omitempty"`
+	Modified bool   `json:"modified"`
+}
+
+// GetBuildInfo returns the module build info embedded in the binary.
+// Module fields are empty when the binary was built without module support.
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:      GetWithFrontend(),
+		GoVersion:    runtime.Version(),
+		Settings:     make(map[string]string),
+		Dependencies: make([]Module, 0),
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.GoVersion = bi.GoVersion
+	info.Path = bi.Path
+	info.Main = toModule(&bi.Main)
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs":
+			info.VCS.System = setting.Value
+		case "vcs.revision":
+			info.VCS.Revision = setting.Value
+		case "vcs.time":
+			info.VCS.Time = setting.Value
+		case "vcs.modified":
+			info.VCS.Modified, _ = strconv.ParseBool(setting.Value)
+		default:
+			info.Settings[setting.Key] = setting.Value
+		}
+	}
+
+	for _, dep := range bi.Deps {
+		info.Dependencies = append(info.Dependencies, toModule(dep))
+	}
+
+	return info
+}
+
+func toModule(m *debug.Module) Module {
+	module := Module{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+
+	if m.Replace != nil {
+		replace := toModule(m.Replace)
+		module.Replace = &replace
+	}
+
+	return module
+}
@@ -4,7 +4,10 @@ package version
 import (
 	"fmt"
 	"os"
+	"runtime"
+	"runtime/debug"
 	"strings"
+	"time"
 )
 
 var (
@@ -20,14 +23,41 @@ type Info struct {
 	GitCommit       string `json:"git_commit"`
 	BuildDate       string `json:"build_date"`
 	FrontendVersion string `json:"frontend_version,omitempty"`
+	BuildEpoch      int64  `json:"build_epoch,omitempty"` // Unix time of the build, 0 when unknown
+}
+
+// Summary identifies the running backend build and the features turned on
+// in it, so clients can detect upgrades.
+type Summary struct {
+	Version   string   `json:"version"`
+	GitCommit string   `json:"git_commit"`
+	BuildDate string   `json:"build_date"`
+	GoVersion string   `json:"go_version"`
+	Features  []string `json:"features"`
+}
+
+// NewSummary returns the summary of the running build with features enabled.
+func NewSummary(features []string) Summary {
+	if features == nil {
+		features = []string{}
+	}
+
+	return Summary{
+		Version:   Short(),
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Features:  features,
+	}
 }
 
 // Get returns version information as a struct.
 func Get() Info {
 	return Info{
-		Version:   Version,
-		GitCommit: GitCommit,
-		BuildDate: BuildDate,
+		Version:    Version,
+		GitCommit:  GitCommit,
+		BuildDate:  BuildDate,
+		BuildEpoch: BuildEpoch(),
 	}
 }
 
@@ -62,3 +92,37 @@ func Short() string {
 func Full() string {
 	return fmt.Sprintf("%s (commit: %s, built: %s)", Version, GitCommit, BuildDate)
 }
+
+// BuildTime returns when the binary was built: BuildDate, else the commit time
+// stamped by the Go toolchain. It reports false when neither is known.
+func BuildTime() (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, BuildDate); err == nil {
+		return t, true
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			if setting.Key != "vcs.time" {
+				continue
+			}
+
+			if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// BuildEpoch returns the build time as Unix seconds, the same on every replica
+// running this build, or 0 when it is unknown. Clients compare it to detect
+// that the backend was upgraded under them.
+func BuildEpoch() int64 {
+	t, ok := BuildTime()
+	if !ok {
+		return 0
+	}
+
+	return t.Unix()
+}
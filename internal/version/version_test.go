@@ -180,3 +180,39 @@ func TestInfo_JSONMarshaling(t *testing.T) {
 		require.NoError(t, os.RemoveAll(".tmp"))
 	})
 }
+
+func TestGetBuildInfo(t *testing.T) {
+	info := GetBuildInfo()
+
+	assert.Equal(t, Version, info.Version.Version)
+	assert.NotEmpty(t, info.GoVersion)
+	assert.NotNil(t, info.Settings)
+	assert.NotNil(t, info.Dependencies)
+
+	// Must serialize for the admin endpoint
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"dependencies"`)
+}
+
+func TestNewSummary(t *testing.T) {
+	summary := NewSummary(nil)
+
+	assert.Equal(t, Short(), summary.Version)
+	assert.Equal(t, GitCommit, summary.GitCommit)
+	assert.NotEmpty(t, summary.GoVersion)
+
+	// No features encode as an empty list, not null
+	data, err := json.Marshal(summary)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"features":[]`)
+}
+
+func TestBuildEpoch(t *testing.T) {
+	buildDate := BuildDate
+	t.Cleanup(func() { BuildDate = buildDate })
+
+	BuildDate = "2026-03-01T12:00:00Z"
+	assert.Equal(t, int64(1772366400), BuildEpoch())
+	assert.Equal(t, int64(1772366400), Get().BuildEpoch)
+}
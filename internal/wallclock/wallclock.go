@@ -2,6 +2,9 @@ package wallclock
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -19,15 +22,18 @@ type Service struct {
 // Network represents a single network's wallclock.
 type Network struct {
 	Name      string
+	config    NetworkConfig
 	wallclock *ethwallclock.EthereumBeaconChain
 	mu        sync.Mutex
 }
 
 // NetworkConfig represents wallclock configuration for a network.
 type NetworkConfig struct {
-	Name           string
-	GenesisTime    time.Time
-	SecondsPerSlot uint64 // Defaults to 12 if not specified
+	Name               string
+	GenesisTime        time.Time
+	SecondsPerSlot     uint64 // Defaults to 12 if not specified
+	SlotsPerEpoch      uint64 // Defaults to 32 if not specified
+	GenesisForkVersion string // Informational, carried for consumers
 }
 
 // New creates a new wallclock service.
@@ -70,19 +76,35 @@ func (s *Service) Name() string {
 }
 
 // AddNetwork dynamically adds or updates a network wallclock.
+// An existing wallclock is only recreated if its timing changed.
 func (s *Service) AddNetwork(config NetworkConfig) error {
+	if config.GenesisTime.Unix() <= 0 {
+		return fmt.Errorf("network %s: genesis time is not set", config.Name)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Default seconds per slot to 12 if not specified
-	secondsPerSlot := config.SecondsPerSlot
-	if secondsPerSlot == 0 {
-		secondsPerSlot = 12
+	if config.SecondsPerSlot == 0 {
+		config.SecondsPerSlot = 12
+	}
+
+	// Default slots per epoch to 32 if not specified
+	if config.SlotsPerEpoch == 0 {
+		config.SlotsPerEpoch = 32
 	}
 
 	// Check if network already exists
-	if _, exists := s.networks[config.Name]; exists {
-		// Network already exists, no need to recreate
+	existing, exists := s.networks[config.Name]
+	if exists && existing.config.GenesisTime.Equal(config.GenesisTime) &&
+		existing.config.SecondsPerSlot == config.SecondsPerSlot &&
+		existing.config.SlotsPerEpoch == config.SlotsPerEpoch {
+		// Timing unchanged, no need to recreate
+		existing.mu.Lock()
+		existing.config.GenesisForkVersion = config.GenesisForkVersion
+		existing.mu.Unlock()
+
 		s.log.WithFields(logrus.Fields{
 			"network": config.Name,
 			"genesis": config.GenesisTime.Format(time.RFC3339),
@@ -93,24 +115,38 @@ func (s *Service) AddNetwork(config NetworkConfig) error {
 
 	// Create network wallclock
 	network := &Network{
-		Name: config.Name,
+		Name:   config.Name,
+		config: config,
 	}
 
 	// Create the wallclock
-	slotDuration := time.Second * time.Duration(secondsPerSlot)
+	slotDuration := time.Second * time.Duration(config.SecondsPerSlot)
 	network.wallclock = ethwallclock.NewEthereumBeaconChain(
 		config.GenesisTime,
 		slotDuration,
-		32, // 32 slots per epoch is constant for Ethereum
+		config.SlotsPerEpoch,
 	)
 
 	s.networks[config.Name] = network
 
+	// Replace a wallclock whose timing changed (e.g. a devnet relaunch)
+	if exists {
+		if old := existing.GetWallclock(); old != nil {
+			old.Stop()
+		}
+	}
+
+	message := "Initialized network wallclock"
+	if exists {
+		message = "Updated network wallclock"
+	}
+
 	s.log.WithFields(logrus.Fields{
 		"network":        config.Name,
 		"genesis":        config.GenesisTime.Format(time.RFC3339),
-		"secondsPerSlot": secondsPerSlot,
-	}).Info("Initialized network wallclock")
+		"secondsPerSlot": config.SecondsPerSlot,
+		"slotsPerEpoch":  config.SlotsPerEpoch,
+	}).Info(message)
 
 	return nil
 }
@@ -134,6 +170,14 @@ func (s *Service) RemoveNetwork(networkName string) {
 	s.log.WithField("network", networkName).Info("Removed network wallclock")
 }
 
+// Networks returns the names of the networks with a wallclock.
+func (s *Service) Networks() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(s.networks))
+}
+
 // GetWallclock returns the wallclock for a specific network.
 // Returns nil if the network is not found.
 func (s *Service) GetWallclock(networkName string) *ethwallclock.EthereumBeaconChain {
@@ -145,6 +189,20 @@ func (s *Service) GetWallclock(networkName string) *ethwallclock.EthereumBeaconC
 	return network.GetWallclock()
 }
 
+// GetConfig returns the timing a network's wallclock was created with,
+// with defaults applied.
+func (s *Service) GetConfig(networkName string) (NetworkConfig, bool) {
+	network := s.getNetwork(networkName)
+	if network == nil {
+		return NetworkConfig{}, false
+	}
+
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	return network.config, true
+}
+
 // getNetwork returns the network for a specific name.
 func (s *Service) getNetwork(networkName string) *Network {
 	s.mu.RLock()
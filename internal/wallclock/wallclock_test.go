@@ -55,6 +55,16 @@ func TestService_AddNetwork(t *testing.T) {
 	assert.NotNil(t, network.wallclock)
 }
 
+func TestService_AddNetwork_NoGenesis(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	svc := New(logger)
+
+	err := svc.AddNetwork(NetworkConfig{Name: "broken", GenesisTime: time.Unix(0, 0)})
+	require.Error(t, err)
+	assert.Empty(t, svc.Networks())
+}
+
 func TestService_AddNetwork_DefaultSecondsPerSlot(t *testing.T) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
@@ -101,6 +111,49 @@ func TestService_AddNetwork_Duplicate(t *testing.T) {
 	assert.Equal(t, 1, len(svc.networks))
 }
 
+func TestService_AddNetwork_TimingChange(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	svc := New(logger)
+
+	genesisTime := time.Unix(1700000000, 0)
+
+	require.NoError(t, svc.AddNetwork(NetworkConfig{
+		Name:        "devnet",
+		GenesisTime: genesisTime,
+	}))
+
+	original := svc.GetWallclock("devnet")
+
+	// Only the fork version changed, the wallclock is kept
+	require.NoError(t, svc.AddNetwork(NetworkConfig{
+		Name:               "devnet",
+		GenesisTime:        genesisTime,
+		GenesisForkVersion: "0x10000038",
+	}))
+	assert.Same(t, original, svc.GetWallclock("devnet"))
+
+	cfg, exists := svc.GetConfig("devnet")
+	require.True(t, exists)
+	assert.Equal(t, "0x10000038", cfg.GenesisForkVersion)
+	assert.Equal(t, uint64(12), cfg.SecondsPerSlot)
+	assert.Equal(t, uint64(32), cfg.SlotsPerEpoch)
+
+	// 6 second slots and 8 slot epochs recreate it
+	require.NoError(t, svc.AddNetwork(NetworkConfig{
+		Name:           "devnet",
+		GenesisTime:    genesisTime,
+		SecondsPerSlot: 6,
+		SlotsPerEpoch:  8,
+	}))
+	assert.NotSame(t, original, svc.GetWallclock("devnet"))
+
+	assert.Equal(t, uint32(1700000000+100*6), svc.CalculateSlotStartTime("devnet", 100))
+
+	epoch := svc.GetWallclock("devnet").Epochs().FromSlot(100)
+	assert.Equal(t, uint64(12), epoch.Number())
+}
+
 func TestService_RemoveNetwork(t *testing.T) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
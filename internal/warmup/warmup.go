@@ -0,0 +1,277 @@
+// Package warmup replays popular queries through the proxy after startup, so
+// upstream caches are warm before real traffic arrives after a deploy.
+package warmup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	"github.com/ethpandaops/lab-backend/internal/leader"
+	"github.com/ethpandaops/lab-backend/internal/metrics"
+	"github.com/ethpandaops/lab-backend/internal/tasks"
+)
+
+// UserAgent identifies warming requests in upstream logs.
+const UserAgent = "lab-backend-cache-warmer"
+
+// leaderPollInterval is how often leadership is checked while waiting.
+const leaderPollInterval = 500 * time.Millisecond
+
+var warmRequestsTotal = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cache_warming_requests_total",
+		Help: "Total number of cache warming queries replayed, by result",
+	},
+	[]string{"result"},
+)
+
+// Proxy is the handler warming queries are replayed through.
+type Proxy interface {
+	http.Handler
+	Networks() []string
+}
+
+// Warmer replays configured queries once after startup, on the leader only.
+type Warmer struct {
+	cfg     config.CacheWarmingConfig
+	log     logrus.FieldLogger
+	proxy   Proxy
+	elector leader.Elector
+
+	task *tasks.Task
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Result summarises a warming run.
+type Result struct {
+	Succeeded int
+	Failed    int
+}
+
+// New creates a cache warmer.
+func New(
+	log logrus.FieldLogger,
+	cfg config.CacheWarmingConfig,
+	proxy Proxy,
+	elector leader.Elector,
+) *Warmer {
+	return &Warmer{
+		cfg:     cfg,
+		log:     log.WithField("component", "warmup"),
+		proxy:   proxy,
+		elector: elector,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start warms the cache in the background once this instance is leader.
+// If leadership isn't acquired within leader_wait, warming is skipped.
+func (w *Warmer) Start(ctx context.Context) {
+	w.task = tasks.Default().Register("cache.warm", 0)
+	w.wg.Add(1)
+
+	go w.warmLoop(ctx)
+}
+
+// Stop cancels any in-flight warming and waits for it to finish.
+func (w *Warmer) Stop() {
+	close(w.done)
+	w.wg.Wait()
+}
+
+// warmLoop runs the one-shot warm under the task supervisor.
+func (w *Warmer) warmLoop(ctx context.Context) {
+	defer w.wg.Done()
+
+	w.task.Supervise(w.log, w.done, func() { w.runWarm(ctx) })
+}
+
+func (w *Warmer) runWarm(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if !w.waitForLeader(ctx) {
+		w.log.Info("Not leader, skipping cache warming")
+
+		return
+	}
+
+	_ = w.task.Run(func() error {
+		result := w.Warm(ctx)
+		if result.Succeeded == 0 && result.Failed > 0 {
+			return fmt.Errorf("all %d warming queries failed", result.Failed)
+		}
+
+		return nil
+	})
+}
+
+// waitForLeader reports whether this instance became leader within leader_wait.
+func (w *Warmer) waitForLeader(ctx context.Context) bool {
+	deadline := time.NewTimer(w.cfg.LeaderWait)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(leaderPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if w.elector.IsLeader() {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// Warm replays every query for its networks, concurrency at a time, and
+// discards the responses.
+func (w *Warmer) Warm(ctx context.Context) Result {
+	targets := w.targets()
+
+	w.log.WithField("queries", len(targets)).Info("Warming cache")
+
+	var (
+		start  = time.Now()
+		sem    = make(chan struct{}, w.cfg.Concurrency)
+		mu     sync.Mutex
+		result Result
+		wg     sync.WaitGroup
+	)
+
+	for _, target := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return result
+		}
+
+		wg.Add(1)
+
+		go func(target string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := w.replay(ctx, target)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				result.Failed++
+
+				warmRequestsTotal.WithLabelValues("error").Inc()
+				w.log.WithError(err).WithField("path", target).Debug("Warming query failed")
+
+				return
+			}
+
+			result.Succeeded++
+
+			warmRequestsTotal.WithLabelValues("success").Inc()
+		}(target)
+	}
+
+	wg.Wait()
+
+	w.log.WithFields(logrus.Fields{
+		"succeeded":   result.Succeeded,
+		"failed":      result.Failed,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}).Info("Cache warming completed")
+
+	return result
+}
+
+// targets expands the query templates into request paths for every network.
+func (w *Warmer) targets() []string {
+	proxied := w.proxy.Networks()
+
+	var targets []string
+
+	for _, query := range w.cfg.Queries {
+		networks := query.Networks
+		if len(networks) == 0 {
+			networks = proxied
+		}
+
+		for _, network := range networks {
+			if !slices.Contains(proxied, network) {
+				w.log.WithField("network", network).Warn("Skipping cache warming for unknown network")
+
+				continue
+			}
+
+			path := strings.ReplaceAll(query.Path, "{network}", network)
+			targets = append(targets, "/api/v1/"+network+"/"+path)
+		}
+	}
+
+	return targets
+}
+
+// replay sends one query through the proxy and checks the response status.
+func (w *Warmer) replay(ctx context.Context, target string) error {
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.RemoteAddr = "127.0.0.1:0"
+	req.Header.Set("User-Agent", UserAgent)
+
+	rw := &discardWriter{header: make(http.Header), status: http.StatusOK}
+	w.proxy.ServeHTTP(rw, req)
+
+	if rw.status >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %d", rw.status)
+	}
+
+	return nil
+}
+
+// discardWriter records the status of a response and drops the body.
+type discardWriter struct {
+	header http.Header
+	status int
+}
+
+func (d *discardWriter) Header() http.Header {
+	return d.header
+}
+
+func (d *discardWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (d *discardWriter) WriteHeader(code int) {
+	d.status = code
+}
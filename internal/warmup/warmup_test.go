@@ -0,0 +1,105 @@
+package warmup
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethpandaops/lab-backend/internal/config"
+	leadermocks "github.com/ethpandaops/lab-backend/internal/leader/mocks"
+)
+
+// fakeProxy records replayed requests and fails paths containing "broken".
+type fakeProxy struct {
+	networks []string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeProxy) Networks() []string {
+	return f.networks
+}
+
+func (f *fakeProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r.URL.RequestURI())
+	f.mu.Unlock()
+
+	if r.UserAgent() != UserAgent || r.URL.Query().Has("broken") {
+		w.WriteHeader(http.StatusBadGateway)
+
+		return
+	}
+
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (f *fakeProxy) seen() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.requests...)
+}
+
+func newTestWarmer(t *testing.T, queries []config.WarmQuery, isLeader bool) (*Warmer, *fakeProxy) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.CacheWarmingConfig{Enabled: true, Queries: queries, LeaderWait: 50 * time.Millisecond}
+	require.NoError(t, cfg.Validate())
+
+	elector := leadermocks.NewMockElector(gomock.NewController(t))
+	elector.EXPECT().IsLeader().Return(isLeader).AnyTimes()
+
+	proxy := &fakeProxy{networks: []string{"holesky", "mainnet"}}
+
+	return New(logger, cfg, proxy, elector), proxy
+}
+
+func TestWarmer_Warm(t *testing.T) {
+	warmer, proxy := newTestWarmer(t, []config.WarmQuery{
+		{Path: "fct_block?page_size=100"},
+		{Path: "admin_cbt_incremental?database_eq={network}", Networks: []string{"mainnet", "unknown"}},
+		{Path: "fct_block?broken=1", Networks: []string{"holesky"}},
+	}, true)
+
+	result := warmer.Warm(context.Background())
+
+	assert.Equal(t, Result{Succeeded: 3, Failed: 1}, result)
+	assert.ElementsMatch(t, []string{
+		"/api/v1/holesky/fct_block?page_size=100",
+		"/api/v1/mainnet/fct_block?page_size=100",
+		"/api/v1/mainnet/admin_cbt_incremental?database_eq=mainnet",
+		"/api/v1/holesky/fct_block?broken=1",
+	}, proxy.seen())
+}
+
+func TestWarmer_LeaderOnly(t *testing.T) {
+	queries := []config.WarmQuery{{Path: "fct_block"}}
+
+	// Followers give up after leader_wait without replaying anything
+	follower, followerProxy := newTestWarmer(t, queries, false)
+	follower.Start(context.Background())
+	follower.wg.Wait()
+	follower.Stop()
+
+	assert.Empty(t, followerProxy.seen())
+
+	leader, leaderProxy := newTestWarmer(t, queries, true)
+	leader.Start(context.Background())
+	leader.wg.Wait()
+	leader.Stop()
+
+	assert.Len(t, leaderProxy.seen(), 2)
+}
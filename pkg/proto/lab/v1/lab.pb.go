@@ -0,0 +1,895 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: lab/v1/lab.proto
+
+package labv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_lab_v1_lab_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{0}
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Networks      []*Network             `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	Features      []*Feature             `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_lab_v1_lab_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetConfigResponse) GetNetworks() []*Network {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *GetConfigResponse) GetFeatures() []*Feature {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type GetBoundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBoundsRequest) Reset() {
+	*x = GetBoundsRequest{}
+	mi := &file_lab_v1_lab_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoundsRequest) ProtoMessage() {}
+
+func (x *GetBoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoundsRequest.ProtoReflect.Descriptor instead.
+func (*GetBoundsRequest) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBoundsRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type GetBoundsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Tables        map[string]*TableBounds `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	LastUpdated   *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBoundsResponse) Reset() {
+	*x = GetBoundsResponse{}
+	mi := &file_lab_v1_lab_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBoundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoundsResponse) ProtoMessage() {}
+
+func (x *GetBoundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoundsResponse.ProtoReflect.Descriptor instead.
+func (*GetBoundsResponse) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBoundsResponse) GetTables() map[string]*TableBounds {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *GetBoundsResponse) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+type WatchNetworksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchNetworksRequest) Reset() {
+	*x = WatchNetworksRequest{}
+	mi := &file_lab_v1_lab_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNetworksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNetworksRequest) ProtoMessage() {}
+
+func (x *WatchNetworksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNetworksRequest.ProtoReflect.Descriptor instead.
+func (*WatchNetworksRequest) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{4}
+}
+
+type WatchNetworksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Networks      []*Network             `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchNetworksResponse) Reset() {
+	*x = WatchNetworksResponse{}
+	mi := &file_lab_v1_lab_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNetworksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNetworksResponse) ProtoMessage() {}
+
+func (x *WatchNetworksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNetworksResponse.ProtoReflect.Descriptor instead.
+func (*WatchNetworksResponse) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchNetworksResponse) GetNetworks() []*Network {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *WatchNetworksResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// Network is network metadata, as listed by /api/v1/config.
+type Network struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ChainId     int64                  `protobuf:"varint,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	GenesisTime int64                  `protobuf:"varint,4,opt,name=genesis_time,json=genesisTime,proto3" json:"genesis_time,omitempty"`
+	// Genesis delay in seconds.
+	GenesisDelay int64  `protobuf:"varint,5,opt,name=genesis_delay,json=genesisDelay,proto3" json:"genesis_delay,omitempty"`
+	Forks        *Forks `protobuf:"bytes,6,opt,name=forks,proto3" json:"forks,omitempty"`
+	// Service name to URL.
+	ServiceUrls  map[string]string    `protobuf:"bytes,7,rep,name=service_urls,json=serviceUrls,proto3" json:"service_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	BlobSchedule []*BlobScheduleEntry `protobuf:"bytes,8,rep,name=blob_schedule,json=blobSchedule,proto3" json:"blob_schedule,omitempty"`
+	// Former names that resolve to this network.
+	Aliases       []string `protobuf:"bytes,9,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Network) Reset() {
+	*x = Network{}
+	mi := &file_lab_v1_lab_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Network) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Network) ProtoMessage() {}
+
+func (x *Network) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Network.ProtoReflect.Descriptor instead.
+func (*Network) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Network) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Network) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Network) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Network) GetGenesisTime() int64 {
+	if x != nil {
+		return x.GenesisTime
+	}
+	return 0
+}
+
+func (x *Network) GetGenesisDelay() int64 {
+	if x != nil {
+		return x.GenesisDelay
+	}
+	return 0
+}
+
+func (x *Network) GetForks() *Forks {
+	if x != nil {
+		return x.Forks
+	}
+	return nil
+}
+
+func (x *Network) GetServiceUrls() map[string]string {
+	if x != nil {
+		return x.ServiceUrls
+	}
+	return nil
+}
+
+func (x *Network) GetBlobSchedule() []*BlobScheduleEntry {
+	if x != nil {
+		return x.BlobSchedule
+	}
+	return nil
+}
+
+func (x *Network) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type Forks struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Consensus     map[string]*ConsensusFork `protobuf:"bytes,1,rep,name=consensus,proto3" json:"consensus,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Execution     map[string]*ExecutionFork `protobuf:"bytes,2,rep,name=execution,proto3" json:"execution,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Forks) Reset() {
+	*x = Forks{}
+	mi := &file_lab_v1_lab_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Forks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Forks) ProtoMessage() {}
+
+func (x *Forks) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Forks.ProtoReflect.Descriptor instead.
+func (*Forks) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Forks) GetConsensus() map[string]*ConsensusFork {
+	if x != nil {
+		return x.Consensus
+	}
+	return nil
+}
+
+func (x *Forks) GetExecution() map[string]*ExecutionFork {
+	if x != nil {
+		return x.Execution
+	}
+	return nil
+}
+
+type ConsensusFork struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Epoch     int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Timestamp int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Client name to minimum version.
+	MinClientVersions map[string]string `protobuf:"bytes,3,rep,name=min_client_versions,json=minClientVersions,proto3" json:"min_client_versions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConsensusFork) Reset() {
+	*x = ConsensusFork{}
+	mi := &file_lab_v1_lab_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsensusFork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusFork) ProtoMessage() {}
+
+func (x *ConsensusFork) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusFork.ProtoReflect.Descriptor instead.
+func (*ConsensusFork) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConsensusFork) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ConsensusFork) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ConsensusFork) GetMinClientVersions() map[string]string {
+	if x != nil {
+		return x.MinClientVersions
+	}
+	return nil
+}
+
+type ExecutionFork struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         int64                  `protobuf:"varint,1,opt,name=block,proto3" json:"block,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionFork) Reset() {
+	*x = ExecutionFork{}
+	mi := &file_lab_v1_lab_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionFork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionFork) ProtoMessage() {}
+
+func (x *ExecutionFork) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionFork.ProtoReflect.Descriptor instead.
+func (*ExecutionFork) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExecutionFork) GetBlock() int64 {
+	if x != nil {
+		return x.Block
+	}
+	return 0
+}
+
+func (x *ExecutionFork) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type BlobScheduleEntry struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Epoch            int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Timestamp        int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MaxBlobsPerBlock int64                  `protobuf:"varint,3,opt,name=max_blobs_per_block,json=maxBlobsPerBlock,proto3" json:"max_blobs_per_block,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BlobScheduleEntry) Reset() {
+	*x = BlobScheduleEntry{}
+	mi := &file_lab_v1_lab_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlobScheduleEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobScheduleEntry) ProtoMessage() {}
+
+func (x *BlobScheduleEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobScheduleEntry.ProtoReflect.Descriptor instead.
+func (*BlobScheduleEntry) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BlobScheduleEntry) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *BlobScheduleEntry) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *BlobScheduleEntry) GetMaxBlobsPerBlock() int64 {
+	if x != nil {
+		return x.MaxBlobsPerBlock
+	}
+	return 0
+}
+
+// Feature is enabled for every network except those listed.
+type Feature struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Path             string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	DisabledNetworks []string               `protobuf:"bytes,2,rep,name=disabled_networks,json=disabledNetworks,proto3" json:"disabled_networks,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Feature) Reset() {
+	*x = Feature{}
+	mi := &file_lab_v1_lab_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feature) ProtoMessage() {}
+
+func (x *Feature) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feature.ProtoReflect.Descriptor instead.
+func (*Feature) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Feature) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Feature) GetDisabledNetworks() []string {
+	if x != nil {
+		return x.DisabledNetworks
+	}
+	return nil
+}
+
+type TableBounds struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Minimum position for the table.
+	Min int64 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	// Maximum position plus interval for the table.
+	Max           int64 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableBounds) Reset() {
+	*x = TableBounds{}
+	mi := &file_lab_v1_lab_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableBounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableBounds) ProtoMessage() {}
+
+func (x *TableBounds) ProtoReflect() protoreflect.Message {
+	mi := &file_lab_v1_lab_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableBounds.ProtoReflect.Descriptor instead.
+func (*TableBounds) Descriptor() ([]byte, []int) {
+	return file_lab_v1_lab_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TableBounds) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *TableBounds) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+var File_lab_v1_lab_proto protoreflect.FileDescriptor
+
+const file_lab_v1_lab_proto_rawDesc = "" +
+	"\n" +
+	"\x10lab/v1/lab.proto\x12\x06lab.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
+	"\x10GetConfigRequest\"m\n" +
+	"\x11GetConfigResponse\x12+\n" +
+	"\bnetworks\x18\x01 \x03(\v2\x0f.lab.v1.NetworkR\bnetworks\x12+\n" +
+	"\bfeatures\x18\x02 \x03(\v2\x0f.lab.v1.FeatureR\bfeatures\",\n" +
+	"\x10GetBoundsRequest\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\"\xe1\x01\n" +
+	"\x11GetBoundsResponse\x12=\n" +
+	"\x06tables\x18\x01 \x03(\v2%.lab.v1.GetBoundsResponse.TablesEntryR\x06tables\x12=\n" +
+	"\flast_updated\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x1aN\n" +
+	"\vTablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.lab.v1.TableBoundsR\x05value:\x028\x01\"\x16\n" +
+	"\x14WatchNetworksRequest\"~\n" +
+	"\x15WatchNetworksResponse\x12+\n" +
+	"\bnetworks\x18\x01 \x03(\v2\x0f.lab.v1.NetworkR\bnetworks\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xa7\x03\n" +
+	"\aNetwork\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x19\n" +
+	"\bchain_id\x18\x03 \x01(\x03R\achainId\x12!\n" +
+	"\fgenesis_time\x18\x04 \x01(\x03R\vgenesisTime\x12#\n" +
+	"\rgenesis_delay\x18\x05 \x01(\x03R\fgenesisDelay\x12#\n" +
+	"\x05forks\x18\x06 \x01(\v2\r.lab.v1.ForksR\x05forks\x12C\n" +
+	"\fservice_urls\x18\a \x03(\v2 .lab.v1.Network.ServiceUrlsEntryR\vserviceUrls\x12>\n" +
+	"\rblob_schedule\x18\b \x03(\v2\x19.lab.v1.BlobScheduleEntryR\fblobSchedule\x12\x18\n" +
+	"\aaliases\x18\t \x03(\tR\aaliases\x1a>\n" +
+	"\x10ServiceUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa9\x02\n" +
+	"\x05Forks\x12:\n" +
+	"\tconsensus\x18\x01 \x03(\v2\x1c.lab.v1.Forks.ConsensusEntryR\tconsensus\x12:\n" +
+	"\texecution\x18\x02 \x03(\v2\x1c.lab.v1.Forks.ExecutionEntryR\texecution\x1aS\n" +
+	"\x0eConsensusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.lab.v1.ConsensusForkR\x05value:\x028\x01\x1aS\n" +
+	"\x0eExecutionEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.lab.v1.ExecutionForkR\x05value:\x028\x01\"\xe7\x01\n" +
+	"\rConsensusFork\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\\\n" +
+	"\x13min_client_versions\x18\x03 \x03(\v2,.lab.v1.ConsensusFork.MinClientVersionsEntryR\x11minClientVersions\x1aD\n" +
+	"\x16MinClientVersionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"C\n" +
+	"\rExecutionFork\x12\x14\n" +
+	"\x05block\x18\x01 \x01(\x03R\x05block\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\"v\n" +
+	"\x11BlobScheduleEntry\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12-\n" +
+	"\x13max_blobs_per_block\x18\x03 \x01(\x03R\x10maxBlobsPerBlock\"J\n" +
+	"\aFeature\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12+\n" +
+	"\x11disabled_networks\x18\x02 \x03(\tR\x10disabledNetworks\"1\n" +
+	"\vTableBounds\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x03R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x03R\x03max2\xe0\x01\n" +
+	"\n" +
+	"LabService\x12@\n" +
+	"\tGetConfig\x12\x18.lab.v1.GetConfigRequest\x1a\x19.lab.v1.GetConfigResponse\x12@\n" +
+	"\tGetBounds\x12\x18.lab.v1.GetBoundsRequest\x1a\x19.lab.v1.GetBoundsResponse\x12N\n" +
+	"\rWatchNetworks\x12\x1c.lab.v1.WatchNetworksRequest\x1a\x1d.lab.v1.WatchNetworksResponse0\x01B;Z9github.com/ethpandaops/lab-backend/pkg/proto/lab/v1;labv1b\x06proto3"
+
+var (
+	file_lab_v1_lab_proto_rawDescOnce sync.Once
+	file_lab_v1_lab_proto_rawDescData []byte
+)
+
+func file_lab_v1_lab_proto_rawDescGZIP() []byte {
+	file_lab_v1_lab_proto_rawDescOnce.Do(func() {
+		file_lab_v1_lab_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lab_v1_lab_proto_rawDesc), len(file_lab_v1_lab_proto_rawDesc)))
+	})
+	return file_lab_v1_lab_proto_rawDescData
+}
+
+var file_lab_v1_lab_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_lab_v1_lab_proto_goTypes = []any{
+	(*GetConfigRequest)(nil),      // 0: lab.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),     // 1: lab.v1.GetConfigResponse
+	(*GetBoundsRequest)(nil),      // 2: lab.v1.GetBoundsRequest
+	(*GetBoundsResponse)(nil),     // 3: lab.v1.GetBoundsResponse
+	(*WatchNetworksRequest)(nil),  // 4: lab.v1.WatchNetworksRequest
+	(*WatchNetworksResponse)(nil), // 5: lab.v1.WatchNetworksResponse
+	(*Network)(nil),               // 6: lab.v1.Network
+	(*Forks)(nil),                 // 7: lab.v1.Forks
+	(*ConsensusFork)(nil),         // 8: lab.v1.ConsensusFork
+	(*ExecutionFork)(nil),         // 9: lab.v1.ExecutionFork
+	(*BlobScheduleEntry)(nil),     // 10: lab.v1.BlobScheduleEntry
+	(*Feature)(nil),               // 11: lab.v1.Feature
+	(*TableBounds)(nil),           // 12: lab.v1.TableBounds
+	nil,                           // 13: lab.v1.GetBoundsResponse.TablesEntry
+	nil,                           // 14: lab.v1.Network.ServiceUrlsEntry
+	nil,                           // 15: lab.v1.Forks.ConsensusEntry
+	nil,                           // 16: lab.v1.Forks.ExecutionEntry
+	nil,                           // 17: lab.v1.ConsensusFork.MinClientVersionsEntry
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+}
+var file_lab_v1_lab_proto_depIdxs = []int32{
+	6,  // 0: lab.v1.GetConfigResponse.networks:type_name -> lab.v1.Network
+	11, // 1: lab.v1.GetConfigResponse.features:type_name -> lab.v1.Feature
+	13, // 2: lab.v1.GetBoundsResponse.tables:type_name -> lab.v1.GetBoundsResponse.TablesEntry
+	18, // 3: lab.v1.GetBoundsResponse.last_updated:type_name -> google.protobuf.Timestamp
+	6,  // 4: lab.v1.WatchNetworksResponse.networks:type_name -> lab.v1.Network
+	18, // 5: lab.v1.WatchNetworksResponse.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 6: lab.v1.Network.forks:type_name -> lab.v1.Forks
+	14, // 7: lab.v1.Network.service_urls:type_name -> lab.v1.Network.ServiceUrlsEntry
+	10, // 8: lab.v1.Network.blob_schedule:type_name -> lab.v1.BlobScheduleEntry
+	15, // 9: lab.v1.Forks.consensus:type_name -> lab.v1.Forks.ConsensusEntry
+	16, // 10: lab.v1.Forks.execution:type_name -> lab.v1.Forks.ExecutionEntry
+	17, // 11: lab.v1.ConsensusFork.min_client_versions:type_name -> lab.v1.ConsensusFork.MinClientVersionsEntry
+	12, // 12: lab.v1.GetBoundsResponse.TablesEntry.value:type_name -> lab.v1.TableBounds
+	8,  // 13: lab.v1.Forks.ConsensusEntry.value:type_name -> lab.v1.ConsensusFork
+	9,  // 14: lab.v1.Forks.ExecutionEntry.value:type_name -> lab.v1.ExecutionFork
+	0,  // 15: lab.v1.LabService.GetConfig:input_type -> lab.v1.GetConfigRequest
+	2,  // 16: lab.v1.LabService.GetBounds:input_type -> lab.v1.GetBoundsRequest
+	4,  // 17: lab.v1.LabService.WatchNetworks:input_type -> lab.v1.WatchNetworksRequest
+	1,  // 18: lab.v1.LabService.GetConfig:output_type -> lab.v1.GetConfigResponse
+	3,  // 19: lab.v1.LabService.GetBounds:output_type -> lab.v1.GetBoundsResponse
+	5,  // 20: lab.v1.LabService.WatchNetworks:output_type -> lab.v1.WatchNetworksResponse
+	18, // [18:21] is the sub-list for method output_type
+	15, // [15:18] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_lab_v1_lab_proto_init() }
+func file_lab_v1_lab_proto_init() {
+	if File_lab_v1_lab_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lab_v1_lab_proto_rawDesc), len(file_lab_v1_lab_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lab_v1_lab_proto_goTypes,
+		DependencyIndexes: file_lab_v1_lab_proto_depIdxs,
+		MessageInfos:      file_lab_v1_lab_proto_msgTypes,
+	}.Build()
+	File_lab_v1_lab_proto = out.File
+	file_lab_v1_lab_proto_goTypes = nil
+	file_lab_v1_lab_proto_depIdxs = nil
+}
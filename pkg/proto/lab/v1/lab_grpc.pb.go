@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: lab/v1/lab.proto
+
+package labv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LabService_GetConfig_FullMethodName     = "/lab.v1.LabService/GetConfig"
+	LabService_GetBounds_FullMethodName     = "/lab.v1.LabService/GetBounds"
+	LabService_WatchNetworks_FullMethodName = "/lab.v1.LabService/WatchNetworks"
+)
+
+// LabServiceClient is the client API for LabService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LabService mirrors the REST config and bounds endpoints for backends that
+// would rather not poll over HTTP.
+type LabServiceClient interface {
+	// GetConfig returns the same networks and features as GET /api/v1/config.
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// GetBounds returns per-table bounds for one network, as GET /api/v1/{network}/bounds.
+	GetBounds(ctx context.Context, in *GetBoundsRequest, opts ...grpc.CallOption) (*GetBoundsResponse, error)
+	// WatchNetworks sends the network list on subscribe and again whenever it
+	// changes after a cartographoor update.
+	WatchNetworks(ctx context.Context, in *WatchNetworksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchNetworksResponse], error)
+}
+
+type labServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLabServiceClient(cc grpc.ClientConnInterface) LabServiceClient {
+	return &labServiceClient{cc}
+}
+
+func (c *labServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, LabService_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *labServiceClient) GetBounds(ctx context.Context, in *GetBoundsRequest, opts ...grpc.CallOption) (*GetBoundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBoundsResponse)
+	err := c.cc.Invoke(ctx, LabService_GetBounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *labServiceClient) WatchNetworks(ctx context.Context, in *WatchNetworksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchNetworksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LabService_ServiceDesc.Streams[0], LabService_WatchNetworks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNetworksRequest, WatchNetworksResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LabService_WatchNetworksClient = grpc.ServerStreamingClient[WatchNetworksResponse]
+
+// LabServiceServer is the server API for LabService service.
+// All implementations must embed UnimplementedLabServiceServer
+// for forward compatibility.
+//
+// LabService mirrors the REST config and bounds endpoints for backends that
+// would rather not poll over HTTP.
+type LabServiceServer interface {
+	// GetConfig returns the same networks and features as GET /api/v1/config.
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// GetBounds returns per-table bounds for one network, as GET /api/v1/{network}/bounds.
+	GetBounds(context.Context, *GetBoundsRequest) (*GetBoundsResponse, error)
+	// WatchNetworks sends the network list on subscribe and again whenever it
+	// changes after a cartographoor update.
+	WatchNetworks(*WatchNetworksRequest, grpc.ServerStreamingServer[WatchNetworksResponse]) error
+	mustEmbedUnimplementedLabServiceServer()
+}
+
+// UnimplementedLabServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLabServiceServer struct{}
+
+func (UnimplementedLabServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedLabServiceServer) GetBounds(context.Context, *GetBoundsRequest) (*GetBoundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBounds not implemented")
+}
+func (UnimplementedLabServiceServer) WatchNetworks(*WatchNetworksRequest, grpc.ServerStreamingServer[WatchNetworksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNetworks not implemented")
+}
+func (UnimplementedLabServiceServer) mustEmbedUnimplementedLabServiceServer() {}
+func (UnimplementedLabServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeLabServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LabServiceServer will
+// result in compilation errors.
+type UnsafeLabServiceServer interface {
+	mustEmbedUnimplementedLabServiceServer()
+}
+
+func RegisterLabServiceServer(s grpc.ServiceRegistrar, srv LabServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLabServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LabService_ServiceDesc, srv)
+}
+
+func _LabService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LabServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LabService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LabServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LabService_GetBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LabServiceServer).GetBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LabService_GetBounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LabServiceServer).GetBounds(ctx, req.(*GetBoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LabService_WatchNetworks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNetworksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LabServiceServer).WatchNetworks(m, &grpc.GenericServerStream[WatchNetworksRequest, WatchNetworksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LabService_WatchNetworksServer = grpc.ServerStreamingServer[WatchNetworksResponse]
+
+// LabService_ServiceDesc is the grpc.ServiceDesc for LabService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LabService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lab.v1.LabService",
+	HandlerType: (*LabServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConfig",
+			Handler:    _LabService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetBounds",
+			Handler:    _LabService_GetBounds_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNetworks",
+			Handler:       _LabService_WatchNetworks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "lab/v1/lab.proto",
+}